@@ -2,26 +2,47 @@ package main
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"ticres/internal/config"
+	grpcdelivery "ticres/internal/delivery/grpc"
 	delivery "ticres/internal/delivery/http"
 	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
 	"ticres/internal/repository"
 	"ticres/internal/usecase"
 	"ticres/internal/worker"
+	"ticres/pkg/alert"
+	"ticres/pkg/cache"
+	"ticres/pkg/broker"
+	"ticres/pkg/clock"
 	"ticres/pkg/database"
+	"ticres/pkg/email"
+	"ticres/pkg/errorreport"
+	"ticres/pkg/encryption"
+	"ticres/pkg/jwtkeys"
 	"ticres/pkg/logger"
+	"ticres/pkg/oauth"
+	"ticres/pkg/openapi"
+	"ticres/pkg/payment"
+	"ticres/pkg/payout"
+	"ticres/pkg/storage"
+	"ticres/pkg/tracing"
+	"ticres/pkg/warehouse"
 
 	"github.com/gin-gonic/gin"
 
 	swaggerFiles "github.com/swaggo/files"
     ginSwagger "github.com/swaggo/gin-swagger"
-    _ "ticres/docs"
+    "ticres/docs"
 )
 
 // @title           Ticres API
@@ -44,6 +65,16 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
+// @securityDefinitions.apikey GateKey
+// @in header
+// @name X-Gate-Key
+// @description API key of a turnstile gate, issued by the event's organizer.
+
+// @securityDefinitions.apikey APIKey
+// @in header
+// @name X-API-Key
+// @description API key of a partner system, issued by an admin. Accepted on admin and organizer routes in place of a JWT, within the key's scopes.
+
 func main() {
 	// 0. Initialize Logger
 	mode := os.Getenv("APP_MODE")
@@ -63,7 +94,38 @@ func main() {
 		logger.Fatal("load config failed", logger.Err(err))
 	}
 
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing.ServiceName, cfg.Tracing.OTLPEndpoint, cfg.Tracing.SampleRatio)
+	if err != nil {
+		logger.Fatal("tracing init failed", logger.Err(err))
+	}
+	if cfg.Tracing.OTLPEndpoint != "" {
+		logger.Info("tracing enabled", logger.String("endpoint", cfg.Tracing.OTLPEndpoint), logger.Float64("sample_ratio", cfg.Tracing.SampleRatio))
+	}
+
+	errorReporter, err := errorreport.NewReporter(cfg.ErrorReport.SentryDSN, cfg.ErrorReport.Environment, cfg.ErrorReport.Release)
+	if err != nil {
+		logger.Fatal("error reporting init failed", logger.Err(err))
+	}
+	if cfg.ErrorReport.SentryDSN != "" {
+		logger.Info("error reporting enabled", logger.String("environment", cfg.ErrorReport.Environment))
+	}
+
 	// 2. Connect Database
+	if cfg.DB.AutoMigrate {
+		if err := database.MigrateUp(cfg.DB.Host, cfg.DB.Port, cfg.DB.User, cfg.DB.Password, cfg.DB.Name, cfg.DB.SSLMode); err != nil {
+			logger.Fatal("database migration failed", logger.Err(err))
+		}
+		logger.Info("database migrations applied")
+	}
+
+	poolConfig := database.PoolConfig{
+		MaxConns:         int32(cfg.DB.MaxConns),
+		MinConns:         int32(cfg.DB.MinConns),
+		MaxConnLifetime:  cfg.DB.MaxConnLifetime,
+		MaxConnIdleTime:  cfg.DB.MaxConnIdleTime,
+		ConnectTimeout:   cfg.DB.ConnectTimeout,
+		StatementTimeout: cfg.DB.StatementTimeout,
+	}
 	dbPool, err := database.NewPostgresConnection(
 		cfg.DB.Host,
 		cfg.DB.Port,
@@ -71,50 +133,359 @@ func main() {
 		cfg.DB.Password,
 		cfg.DB.Name,
 		cfg.DB.SSLMode,
+		poolConfig,
 	)
 	if err != nil {
 		logger.Fatal("database connection failed", logger.Err(err))
 	}
 	defer dbPool.Close()
-	logger.Info("database connected successfully")
+	logger.Info("database connected successfully", logger.Int("max_conns", cfg.DB.MaxConns))
 
-	redisClient, err := database.NewRedClient(cfg.Cache.Host, cfg.Cache.Port, cfg.Cache.Password, cfg.Cache.UseTLS)
-	if err != nil {
+	// Lag-tolerant reads go to the replica when there is one
+	readPool := dbPool
+	if cfg.DB.ReplicaDSN != "" {
+		replica, err := database.NewPostgresReplica(cfg.DB.ReplicaDSN, poolConfig)
+		if err != nil {
+			logger.Warn("read replica unavailable, reading from the primary", logger.Err(err))
+		} else {
+			defer replica.Close()
+			readPool = replica
+			logger.Info("read replica connected successfully")
+		}
+	}
+
+	redisBreaker := database.NewRedisBreaker(cfg.Cache.BreakerThreshold, cfg.Cache.BreakerCooldown, clock.Real{})
+	redisClient, err := database.NewRedClient(cfg.Cache.Host, cfg.Cache.Port, cfg.Cache.Password, cfg.Cache.UseTLS, cfg.Cache.Timeout, redisBreaker)
+	switch {
+	case err != nil && cfg.Cache.Required:
 		logger.Fatal("redis connection failed", logger.Err(err))
+	case err != nil:
+		// Every Redis user falls back to the database or skips its check
+		logger.Warn("redis unavailable, starting without cache", logger.Err(err))
+	default:
+		logger.Info("redis connected successfully")
 	}
-	logger.Info("redis connected successfully")
 
 	// 3. Init Layers (Dependency Injection)
 	userRepo := repository.NewUserRepository(dbPool)
-	eventRepo := repository.NewEventRepository(dbPool, redisClient)
-	bookingRepo := repository.NewBookingRepository(dbPool)
+	// Keeps caches consistent across API instances
+	cacheBus := cache.NewBus(redisClient, cache.DefaultChannel)
+	cacheBusCtx, stopCacheBus := context.WithCancel(context.Background())
+	go cacheBus.Run(cacheBusCtx)
+
+	seatCache := repository.NewSeatAvailabilityCache(redisClient)
+	eventCache := cache.NewLoader(cacheBus, cache.Policy{
+		TTL:      cfg.Cache.EventTTL,
+		Jitter:   cfg.Cache.TTLJitter,
+		StaleFor: cfg.Cache.StaleWhileRevalidate,
+	})
+	eventRepo := repository.NewEventRepository(dbPool, readPool, redisClient, cacheBus, eventCache, seatCache)
+	seatUpdates := repository.NewSeatUpdatePublisher(redisClient, seatCache)
+	bookingRepo := repository.NewBookingRepository(dbPool, readPool, seatUpdates)
 	transactionRepo := repository.NewTransactionRepository(dbPool)
-	refundRepo := repository.NewRefundRepository(dbPool)
+	txManager := repository.NewTxManager(dbPool)
+	refundRepo := repository.NewRefundRepository(dbPool, readPool)
+	ticketRepo := repository.NewTicketRepository(dbPool)
+	auditRepo := repository.NewAuditRepository(dbPool)
+	organizerRepo := repository.NewOrganizerRepository(dbPool)
+	bankAccountRepo := repository.NewBankAccountRepository(dbPool)
+	analyticsRepo := repository.NewAnalyticsRepository(dbPool)
+	experimentRepo := repository.NewExperimentRepository(dbPool)
+	jobRepo := repository.NewJobRepository(dbPool)
+	outboxRepo := repository.NewOutboxRepository(dbPool)
+	backfillRepo := repository.NewBackfillRepository(dbPool)
+	userExportRepo := repository.NewUserExportRepository(dbPool)
+	bookingModificationRepo := repository.NewBookingModificationRepository(dbPool, seatUpdates)
+	upgradeOfferRepo := repository.NewUpgradeOfferRepository(dbPool, seatUpdates)
+	sectionImageRepo := repository.NewSectionImageRepository(dbPool)
+	ticketTierRepo := repository.NewTicketTierRepository(dbPool, seatCache)
+	eventStaffRepo := repository.NewEventStaffRepository(dbPool)
+	gateRepo := repository.NewGateRepository(dbPool)
+	apiKeyRepo := repository.NewAPIKeyRepository(dbPool)
+	notificationRepo := repository.NewNotificationRepository(dbPool)
+	webhookRepo := repository.NewWebhookRepository(dbPool)
+	salesGoalRepo := repository.NewSalesGoalRepository(dbPool)
+	attendanceRepo := repository.NewAttendanceRepository(dbPool)
+	inventoryRepo := repository.NewInventoryRepository(dbPool)
+	invoiceRepo := repository.NewInvoiceRepository(dbPool)
+	reportCache := repository.NewReportCache(redisClient)
+	funnelLimiter := repository.NewFunnelLimiter(redisClient)
+	healthRepo := repository.NewHealthRepository(dbPool, redisClient, redisBreaker)
+	warehouseRepo := repository.NewWarehouseRepository(dbPool)
+
+	var fileStorage storage.Storage
+	switch cfg.Storage.Driver {
+	case "local":
+		fileStorage, err = storage.NewLocalStorage(cfg.Storage.LocalDir, cfg.Storage.BaseURL)
+		if err != nil {
+			logger.Fatal("storage init failed", logger.Err(err))
+		}
+	case "s3":
+		if cfg.Storage.S3Bucket == "" {
+			logger.Fatal("S3_BUCKET is required for the s3 storage driver")
+		}
+		fileStorage = storage.NewS3Storage(cfg.Storage.S3Endpoint, cfg.Storage.S3Region, cfg.Storage.S3Bucket,
+			cfg.Storage.S3AccessKeyID, cfg.Storage.S3SecretAccessKey, cfg.Storage.S3SessionToken, cfg.Storage.BaseURL)
+	default:
+		logger.Fatal("unknown STORAGE_DRIVER", logger.String("driver", cfg.Storage.Driver))
+	}
+	logger.Info("file storage configured", logger.String("driver", cfg.Storage.Driver))
+
+	accountCipher, err := encryption.NewCipherFromBase64(cfg.Payout.EncryptionKey)
+	if err != nil {
+		logger.Fatal("invalid PAYOUT_ENCRYPTION_KEY", logger.Err(err))
+	}
+	totpCipher, err := encryption.NewCipherFromBase64(cfg.JWT.TwoFactorKey)
+	if err != nil {
+		logger.Fatal("invalid TWO_FACTOR_ENCRYPTION_KEY", logger.Err(err))
+	}
+	webhookCipher, err := encryption.NewCipherFromBase64(cfg.Webhook.EncryptionKey)
+	if err != nil {
+		logger.Fatal("invalid WEBHOOK_ENCRYPTION_KEY", logger.Err(err))
+	}
+
+	// JWT_SECRET also keys the 2FA challenge and OAuth state MACs, and is the
+	// default payment link and queue token secret, so it is required even
+	// when access tokens are signed with RS256
+	for _, key := range []struct{ name, secret string }{
+		{"JWT_SECRET", cfg.JWT.Secret},
+		{"PAYMENT_LINK_SECRET", cfg.Booking.PaymentLinkSecret},
+		{"QUEUE_TOKEN_SECRET", cfg.Booking.QueueTokenSecret},
+	} {
+		if key.secret == "" {
+			logger.Fatal("missing HMAC secret", logger.String("setting", key.name))
+		}
+	}
 
-	timeoutContext := time.Duration(5) * time.Second
-	notifWorker := worker.NewNotificationWorker(userRepo, bookingRepo, transactionRepo, refundRepo)
+	var jwtKeys *jwtkeys.KeySet
+	switch cfg.JWT.Algorithm {
+	case "HS256":
+		jwtKeys, err = jwtkeys.NewHMAC([]byte(cfg.JWT.Secret))
+	case "RS256":
+		jwtKeys, err = jwtkeys.LoadRSAFiles(cfg.JWT.PrivateKeyFile, cfg.JWT.PreviousKeyFile)
+	default:
+		logger.Fatal("invalid JWT_ALGORITHM, want HS256 or RS256", logger.String("algorithm", cfg.JWT.Algorithm))
+	}
+	if err != nil {
+		logger.Fatal("load JWT signing keys failed", logger.Err(err))
+	}
+	logger.Info("access tokens configured", logger.String("algorithm", jwtKeys.Algorithm()))
+
+	timeouts, err := usecase.LoadTimeouts(cfg.Server.UsecaseTimeout, cfg.Server.UsecaseTimeoutOverrides)
+	if err != nil {
+		logger.Fatal("invalid USECASE_TIMEOUT_OVERRIDES", logger.Err(err))
+	}
+
+	alertRules, err := usecase.LoadAlertRules(cfg.Alert.Rules)
+	if err != nil {
+		logger.Fatal("load alert rules failed", logger.Err(err))
+	}
+	alertNotifier := alert.NewNotifier(cfg.Alert.WebhookURL)
+	auditUseCase := usecase.NewAuditUsecase(auditRepo, alertNotifier, alertRules, timeouts.For("audit"))
+	inventoryMonitor := usecase.NewInventoryMonitor(inventoryRepo, alertNotifier, timeouts.For("inventory"))
+
+	var mailer email.Sender
+	switch cfg.Email.Provider {
+	case "smtp":
+		mailer = email.NewSMTPSender(cfg.Email.SMTPHost, cfg.Email.SMTPPort, cfg.Email.SMTPUsername, cfg.Email.SMTPPassword, cfg.Email.From)
+	case "ses":
+		mailer = email.NewSESSender(cfg.Email.SESRegion, cfg.Email.SESAccessKeyID, cfg.Email.SESSecretAccessKey, cfg.Email.SESSessionToken, cfg.Email.From)
+	case "log":
+		mailer = email.LogSender{}
+	default:
+		logger.Fatal("unknown EMAIL_PROVIDER", logger.String("provider", cfg.Email.Provider))
+	}
+	logger.Info("email provider configured", logger.String("provider", cfg.Email.Provider))
+
+	paymentGateway := payment.NewResilientGateway(payment.NewSandboxGateway(), payment.ResilienceConfig{
+		CallTimeout:      cfg.Payment.GatewayTimeout,
+		MaxRetries:       cfg.Payment.GatewayMaxRetries,
+		RetryBackoff:     cfg.Payment.GatewayRetryBackoff,
+		FailureThreshold: cfg.Payment.GatewayBreakerThreshold,
+		OpenDuration:     cfg.Payment.GatewayBreakerCooldown,
+	})
+
+	switch cfg.Worker.QueueOverflow {
+	case worker.OverflowPersist, worker.OverflowDrop:
+	default:
+		logger.Fatal("unknown WORKER_QUEUE_OVERFLOW", logger.String("overflow", cfg.Worker.QueueOverflow))
+	}
+	eventBroker, err := broker.New(broker.Config{
+		Driver:      cfg.Broker.Driver,
+		URL:         cfg.Broker.URL,
+		Username:    cfg.Broker.Username,
+		Password:    cfg.Broker.Password,
+		TopicPrefix: cfg.Broker.TopicPrefix,
+	})
+	if err != nil {
+		logger.Fatal("message broker setup failed", logger.Err(err))
+	}
+	if eventBroker != nil {
+		logger.Info("publishing domain events", logger.String("broker", cfg.Broker.Driver), logger.String("topic_prefix", cfg.Broker.TopicPrefix))
+	}
+	systemClock := clock.Real{}
+	notifWorker := worker.NewNotificationWorker(jobRepo, outboxRepo, userRepo, bookingRepo, transactionRepo, refundRepo, eventRepo, ticketRepo, upgradeOfferRepo, backfillRepo, userExportRepo, notificationRepo, webhookRepo, webhookCipher, eventBroker, paymentGateway, auditUseCase, mailer, cfg.Email.Locale, systemClock, worker.QueueConfig{
+		EnqueueTimeout: cfg.Worker.EnqueueTimeout,
+		OutboxLimit:    cfg.Worker.OutboxLimit,
+		OutboxMaxAge:   cfg.Worker.OutboxMaxAge,
+		Overflow:       cfg.Worker.QueueOverflow,
+		Notifier:       alertNotifier,
+	})
 	notifWorker.Start()
 
-	userUsecase := usecase.NewUserUsecase(userRepo, timeoutContext, cfg.JWT.Secret, cfg.JWT.ExpTime)
-	eventUseCase := usecase.NewEventUsecase(eventRepo, timeoutContext, notifWorker)
-	bookingUseCase := usecase.NewBookingUsecase(bookingRepo, transactionRepo, timeoutContext, notifWorker)
-	paymentUseCase := usecase.NewPaymentUsecase(bookingRepo, transactionRepo, timeoutContext)
+	oauthProviders := map[string]oauth.Provider{}
+	if cfg.OAuth.GoogleClientID != "" {
+		oauthProviders["google"] = oauth.NewGoogle(cfg.OAuth.GoogleClientID, cfg.OAuth.GoogleClientSecret, cfg.OAuth.GoogleRedirectURL)
+		logger.Info("google sign-in enabled", logger.String("redirect_url", cfg.OAuth.GoogleRedirectURL))
+	}
+	userUsecase := usecase.NewUserUsecase(userRepo, timeouts.For("user"), cfg.JWT.Secret, jwtKeys, cfg.JWT.ExpTime, auditUseCase, notifWorker, cfg.Server.FrontendURL+"/reset-password", userExportRepo, txManager, notifWorker, totpCipher, systemClock, oauthProviders)
+	eventUseCase := usecase.NewEventUsecase(eventRepo, txManager, timeouts.For("event"), notifWorker, notifWorker, auditUseCase, fileStorage, cfg.Event.MinLeadTime)
+	experimentUseCase := usecase.NewExperimentUsecase(experimentRepo, eventRepo, auditUseCase, timeouts.For("experiment"))
+	conflictPolicy := usecase.BookingConflictPolicy{
+		Window: cfg.Booking.ConflictWindow,
+		Block:  cfg.Booking.ConflictMode == "block",
+	}
+	if cfg.Booking.ConflictMode == "off" {
+		conflictPolicy.Window = 0
+	}
+	outagePolicy := usecase.GatewayOutagePolicy{
+		Gateway: paymentGateway,
+		Hold:    cfg.Booking.GatewayOutageHold,
+	}
+	bookingUseCase := usecase.NewBookingUsecase(bookingRepo, transactionRepo, txManager, userRepo, timeouts.For("booking"), notifWorker, notifWorker, notifWorker, experimentUseCase, conflictPolicy, cfg.Booking.MaxTicketsPerUser, outagePolicy, systemClock)
+	funnelUseCase := usecase.NewFunnelUsecase(eventRepo, funnelLimiter, auditUseCase, cfg.Booking.EventRateLimit, cfg.Booking.QueueTokenSecret, timeouts.For("funnel"))
+	paymentUseCase := usecase.NewPaymentUsecase(bookingRepo, transactionRepo, ticketRepo, paymentGateway, notifWorker, notifWorker, notifWorker, auditUseCase, cfg.Booking.PaymentLinkSecret, cfg.Server.FrontendURL+"/pay", cfg.Booking.GatewayOutageHold, systemClock, timeouts.For("payment"))
+	bookingModificationUseCase := usecase.NewBookingModificationUsecase(bookingModificationRepo, bookingRepo, ticketRepo, notifWorker, auditUseCase, timeouts.For("booking_modification"))
+	upgradeOfferUseCase := usecase.NewUpgradeOfferUsecase(upgradeOfferRepo, transactionRepo, ticketRepo, notifWorker, cfg.Server.FrontendURL+"/upgrade-offers", systemClock, timeouts.For("upgrade_offer"))
+	checkinUseCase := usecase.NewCheckinUsecase(ticketRepo, timeouts.For("checkin"))
+	organizerUseCase := usecase.NewOrganizerUsecase(organizerRepo, fileStorage, accountCipher, auditUseCase, timeouts.For("organizer"))
+	sectionImageUseCase := usecase.NewSectionImageUsecase(sectionImageRepo, eventRepo, fileStorage, timeouts.For("section_image"))
+	ticketTierUseCase := usecase.NewTicketTierUsecase(ticketTierRepo, eventRepo, timeouts.For("ticket_tier"))
+	refundUseCase := usecase.NewRefundUsecase(refundRepo, bookingRepo, eventRepo, reportCache, cfg.Report.CacheTTL, notifWorker, auditUseCase, systemClock, timeouts.For("refund"))
+	ticketUseCase := usecase.NewTicketUsecase(ticketRepo, bookingRepo, notifWorker, auditUseCase, timeouts.For("ticket"))
+	invoiceUseCase := usecase.NewInvoiceUsecase(invoiceRepo, timeouts.For("invoice"))
+	eventStaffUseCase := usecase.NewEventStaffUsecase(eventStaffRepo, eventRepo, auditUseCase, timeouts.For("event_staff"))
+	gateUseCase := usecase.NewGateUsecase(gateRepo, ticketRepo, eventRepo, auditUseCase, timeouts.For("gate"))
+	apiKeyUseCase := usecase.NewAPIKeyUsecase(apiKeyRepo, userRepo, auditUseCase, systemClock, timeouts.For("api_key"))
+	notificationUseCase := usecase.NewNotificationUsecase(notificationRepo, timeouts.For("notification"))
+	webhookUseCase := usecase.NewWebhookUsecase(webhookRepo, userRepo, webhookCipher, auditUseCase, timeouts.For("webhook"))
+	forecastUseCase := usecase.NewForecastUsecase(eventRepo, analyticsRepo, userRepo, notifWorker, timeouts.For("forecast"))
+	analyticsUseCase := usecase.NewAnalyticsUsecase(eventRepo, analyticsRepo, timeouts.For("analytics"))
+	salesGoalUseCase := usecase.NewSalesGoalUsecase(salesGoalRepo, eventRepo, userRepo, notifWorker, systemClock, timeouts.For("sales_goal"))
+	attendanceUseCase := usecase.NewAttendanceUsecase(attendanceRepo, eventRepo, userRepo, notifWorker, cfg.Server.FrontendURL+"/organizer/events", cfg.Event.AttendanceReportDelay, systemClock, timeouts.For("attendance"))
+	jobUseCase := usecase.NewJobUsecase(jobRepo, notifWorker, auditUseCase, timeouts.For("job"))
+	backfillUseCase := usecase.NewBackfillUsecase(backfillRepo, txManager, notifWorker, auditUseCase, timeouts.For("backfill"))
+	statusUseCase := usecase.NewStatusUsecase(healthRepo, jobRepo, notifWorker, paymentGateway, inventoryMonitor, cfg.Alert.InventoryCheckInterval, timeouts.For("status"))
+	bankAccountUseCase := usecase.NewBankAccountUsecase(bankAccountRepo, accountCipher, payout.NewSandboxProvider(), auditUseCase, timeouts.For("bank_account"))
 
 	// Handlers
 	userHandler := delivery.NewUserHandler(userUsecase, bookingUseCase)
 	eventHandler := delivery.NewEventHandler(eventUseCase)
-	bookingHandler := delivery.NewBookingHandler(bookingUseCase)
-	adminHandler := delivery.NewAdminHandler(bookingUseCase)
+	bookingHandler := delivery.NewBookingHandler(bookingUseCase, funnelUseCase)
+	adminHandler := delivery.NewAdminHandler(bookingUseCase, userUsecase)
 	paymentHandler := delivery.NewPaymentHandler(paymentUseCase)
+	checkinHandler := delivery.NewCheckinHandler(checkinUseCase)
+	organizerHandler := delivery.NewOrganizerHandler(organizerUseCase)
+	bankAccountHandler := delivery.NewBankAccountHandler(bankAccountUseCase)
+	analyticsHandler := delivery.NewAnalyticsHandler(forecastUseCase, analyticsUseCase, attendanceUseCase)
+	experimentHandler := delivery.NewExperimentHandler(experimentUseCase)
+	jobHandler := delivery.NewJobHandler(jobUseCase)
+	backfillHandler := delivery.NewBackfillHandler(backfillUseCase)
+	funnelHandler := delivery.NewFunnelHandler(funnelUseCase)
+	statusHandler := delivery.NewStatusHandler(statusUseCase)
+	jwksHandler := delivery.NewJWKSHandler(jwtKeys)
+	bookingModificationHandler := delivery.NewBookingModificationHandler(bookingModificationUseCase)
+	upgradeOfferHandler := delivery.NewUpgradeOfferHandler(upgradeOfferUseCase)
+	sectionImageHandler := delivery.NewSectionImageHandler(sectionImageUseCase)
+	ticketTierHandler := delivery.NewTicketTierHandler(ticketTierUseCase)
+	refundHandler := delivery.NewRefundHandler(refundUseCase)
+	ticketHandler := delivery.NewTicketHandler(ticketUseCase)
+	invoiceHandler := delivery.NewInvoiceHandler(invoiceUseCase)
+	eventStaffHandler := delivery.NewEventStaffHandler(eventStaffUseCase)
+	gateHandler := delivery.NewGateHandler(gateUseCase)
+	apiKeyHandler := delivery.NewAPIKeyHandler(apiKeyUseCase)
+	auditHandler := delivery.NewAuditHandler(auditUseCase)
+	notificationHandler := delivery.NewNotificationHandler(notificationUseCase)
+	webhookHandler := delivery.NewWebhookHandler(webhookUseCase)
+	salesGoalHandler := delivery.NewSalesGoalHandler(salesGoalUseCase)
+
+	// Applications stored before account numbers were encrypted. A failure
+	// leaves the rest for the next start.
+	if n, err := organizerUseCase.EncryptStoredAccountNumbers(context.Background()); err != nil {
+		logger.Error("failed to encrypt stored organizer account numbers", logger.Int("encrypted", n), logger.Err(err))
+	} else if n > 0 {
+		logger.Info("encrypted stored organizer account numbers", logger.Int("encrypted", n))
+	}
+
+	forecastScheduler := worker.NewForecastScheduler(forecastUseCase, time.Hour)
+	forecastScheduler.Start()
+
+	upgradeOfferScheduler := worker.NewUpgradeOfferScheduler(upgradeOfferUseCase, time.Hour)
+	upgradeOfferScheduler.Start()
+
+	salesGoalScheduler := worker.NewSalesGoalScheduler(salesGoalUseCase, cfg.Event.SalesGoalCheckInterval)
+	salesGoalScheduler.Start()
+
+	attendanceReportScheduler := worker.NewAttendanceReportScheduler(attendanceUseCase, cfg.Event.AttendanceReportInterval)
+	attendanceReportScheduler.Start()
+
+	// Overselling is the worst failure mode, so the invariants are checked continuously
+	inventoryScheduler := worker.NewInventoryScheduler(inventoryMonitor, cfg.Alert.InventoryCheckInterval)
+	inventoryScheduler.Start()
+
+	holdReleaseScheduler := worker.NewHoldReleaseScheduler(bookingUseCase, cfg.Booking.HoldReleaseInterval)
+	holdReleaseScheduler.Start()
+
+	gatewayRecoveryScheduler := worker.NewGatewayRecoveryScheduler(bookingUseCase, cfg.Booking.GatewayRecoveryInterval)
+	gatewayRecoveryScheduler.Start()
+
+	reportScheduler := worker.NewReportScheduler(refundUseCase, cfg.Report.RefreshInterval)
+	reportScheduler.Start()
+
+	warehouseDestinations, err := warehouse.ParseDestinations(cfg.Warehouse.Destinations)
+	if err != nil {
+		logger.Fatal("load warehouse destinations failed", logger.Err(err))
+	}
+	warehouseTargets, err := usecase.NewWarehouseTargets(warehouseDestinations)
+	if err != nil {
+		logger.Fatal("warehouse destination setup failed", logger.Err(err))
+	}
+	var warehouseScheduler *worker.WarehouseExportScheduler
+	if len(warehouseTargets) > 0 {
+		warehouseExportUseCase := usecase.NewWarehouseExportUsecase(warehouseRepo, warehouseTargets, usecase.WarehouseExportConfig{
+			BatchSize: cfg.Warehouse.BatchSize,
+			Lag:       cfg.Warehouse.Lag,
+		})
+		warehouseScheduler = worker.NewWarehouseExportScheduler(warehouseExportUseCase, cfg.Warehouse.Interval)
+		warehouseScheduler.Start()
+	}
 
 	// 4. Setup Router (Gin)
-	r := gin.Default()
+	r := gin.New()
+	r.Use(middleware.RequestIDMiddleware())
+	// Inside the access log, so requests that panicked are logged as 500s
+	r.Use(middleware.AccessLogMiddleware())
+	r.Use(middleware.RecoveryMiddleware(errorReporter))
+	r.Use(middleware.TracingMiddleware())
+	r.Use(middleware.LocaleMiddleware())
+	// Registered before ErrorHandler so responses it renders are validated too
+	if cfg.Server.OpenAPIValidation != "off" {
+		validator, err := openapi.New([]byte(docs.SwaggerInfo.ReadDoc()))
+		if err != nil {
+			logger.Fatal("failed to load API document for validation", logger.Err(err))
+		}
+		validateResponses := cfg.Server.OpenAPIValidation == "all" && mode != "production"
+		r.Use(middleware.OpenAPIValidation(validator, validateResponses))
+		logger.Info("openapi validation enabled", logger.Any("responses", validateResponses))
+	}
+	r.Use(middleware.ErrorHandler())
 
 	// CORS middleware for frontend
 	r.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, X-Request-ID, X-Queue-Token, If-None-Match, X-API-Key, traceparent, tracestate")
+		c.Header("Access-Control-Expose-Headers", "X-Request-ID, X-Trace-Id, ETag, Retry-After")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
 			return
@@ -122,37 +493,190 @@ func main() {
 		c.Next()
 	})
 
+	// Event posters are public. Only their prefix is served from local disk;
+	// other uploads such as organizer documents go through the API.
+	if cfg.Storage.Driver == "local" && strings.HasPrefix(cfg.Storage.BaseURL, "/") {
+		r.Static(path.Join(cfg.Storage.BaseURL, usecase.EventImagePrefix), filepath.Join(cfg.Storage.LocalDir, usecase.EventImagePrefix))
+	}
+
 	// Swagger route
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Status page data, outside /api/v1 like the docs
+	r.GET("/status", statusHandler.Get)
+	// Token signing keys for services verifying access tokens
+	r.GET("/.well-known/jwks.json", jwksHandler.Get)
+
 	v1 := r.Group("/api/v1")
 	{
 		// Public routes
 		v1.POST("/register", userHandler.Register)
 		v1.POST("/login", userHandler.Login)
+		v1.POST("/auth/login/2fa", userHandler.CompleteLogin)
+		v1.GET("/auth/google", userHandler.GoogleLogin)
+		v1.GET("/auth/google/callback", userHandler.GoogleCallback)
+		v1.POST("/auth/forgot-password", userHandler.ForgotPassword)
+		v1.POST("/auth/reset-password", userHandler.ResetPassword)
+		v1.POST("/upgrade-offers/lookup", upgradeOfferHandler.Lookup)
+		v1.POST("/upgrade-offers/accept", upgradeOfferHandler.Accept)
+		v1.POST("/payment-links/lookup", paymentHandler.LookupLink)
+		v1.POST("/payment-links/pay", paymentHandler.PayWithLink)
 		v1.GET("/events", eventHandler.List)
 		v1.GET("/events/:id", eventHandler.GetByID)
+		v1.GET("/events/:id/sections", eventHandler.ListSections)
+		v1.GET("/events/:id/section-images", sectionImageHandler.List)
+		v1.GET("/section-images/:id", sectionImageHandler.Download)
+		v1.GET("/events/:id/seats", eventHandler.ListSeats)
+		v1.GET("/events/:id/seats/stream", eventHandler.StreamSeats)
+		v1.GET("/public/events/:id/availability", eventHandler.PublicAvailability)
 
 		// Protected routes (authenticated users)
 		protected := v1.Group("/")
-		protected.Use(middleware.AuthMiddleware(cfg.JWT.Secret))
+		protected.Use(middleware.AuthMiddleware(jwtKeys, userUsecase))
 		{
 			protected.GET("/me", userHandler.Me)
+			protected.PUT("/me", userHandler.UpdateMe)
+			protected.DELETE("/me", userHandler.DeleteMe)
+			protected.PUT("/me/password", userHandler.ChangePassword)
+			protected.GET("/me/export", userHandler.ExportMe)
+			protected.POST("/me/2fa/enable", userHandler.EnableTwoFactor)
+			protected.POST("/me/2fa/verify", userHandler.VerifyTwoFactor)
+			protected.GET("/me/sessions", userHandler.ListSessions)
+			protected.DELETE("/me/sessions/:id", userHandler.RevokeSession)
+			protected.GET("/me/notifications", notificationHandler.List)
+			protected.POST("/me/notifications/:id/read", notificationHandler.MarkRead)
 			protected.GET("/me/bookings", userHandler.GetMyBookings)
-			protected.POST("/events", eventHandler.Create)
+			protected.GET("/me/bookings/:id", userHandler.GetMyBooking)
+			protected.POST("/me/bookings/:id/refund-request", refundHandler.RequestRefund)
+			protected.POST("/events", middleware.RoleMiddleware("admin", "organizer"), eventHandler.Create)
+			protected.GET("/events/:id/pricing", experimentHandler.Pricing)
 			protected.POST("/bookings", bookingHandler.Create)
+			protected.POST("/bookings/:id/seat-changes", bookingModificationHandler.ChangeSeats)
+			protected.GET("/bookings/:id/seat-changes", bookingModificationHandler.List)
 			protected.POST("/payments", paymentHandler.ProcessPayment)
 			protected.GET("/payments/:booking_id", paymentHandler.GetPaymentStatus)
+			protected.POST("/organizer/applications", organizerHandler.Apply)
+			protected.GET("/organizer/applications/me", organizerHandler.MyApplication)
+			protected.POST("/organizer/applications/me/documents", organizerHandler.UploadDocument)
 		}
 
 		// Admin routes
 		adminGroup := v1.Group("/admin")
-		adminGroup.Use(middleware.AuthMiddleware(cfg.JWT.Secret), middleware.AdminMiddleware(cfg.JWT.Secret))
+		adminGroup.Use(middleware.APIKeyMiddleware(apiKeyUseCase, "admin"), middleware.AuthMiddleware(jwtKeys, userUsecase), middleware.AdminMiddleware(cfg.JWT.Secret))
 		{
+			adminGroup.GET("/events", eventHandler.AdminList)
 			adminGroup.PUT("/events/:id", eventHandler.Update)
 			adminGroup.DELETE("/events/:id", eventHandler.Delete)
+			adminGroup.POST("/events/:id/restore", eventHandler.Restore)
+			adminGroup.PUT("/events/:id/layout", eventHandler.UpdateLayout)
+			adminGroup.PUT("/events/:id/image", eventHandler.UploadImage)
+			adminGroup.PUT("/events/:id/ticket-limit", eventHandler.SetTicketLimit)
+			adminGroup.PUT("/events/:id/booking-rate-limit", eventHandler.SetBookingRateLimit)
+			adminGroup.POST("/events/:id/queue-tokens", funnelHandler.IssueQueueTokens)
+			adminGroup.GET("/events/:id/sales-waves", eventHandler.GetSalesWaves)
+			adminGroup.PUT("/events/:id/sales-waves", eventHandler.SetSalesWaves)
+			adminGroup.POST("/events/:id/tiers", ticketTierHandler.Create)
+			adminGroup.GET("/events/:id/tiers", ticketTierHandler.List)
+			adminGroup.PUT("/events/:id/tiers/:tier_id", ticketTierHandler.Update)
+			adminGroup.DELETE("/events/:id/tiers/:tier_id", ticketTierHandler.Delete)
+			adminGroup.POST("/events/:id/tiers/:tier_id/seats", ticketTierHandler.AssignSeats)
 			adminGroup.GET("/bookings", adminHandler.GetAllBookings)
+			adminGroup.GET("/bookings/lapsed-holds", adminHandler.PreviewLapsedHolds)
+			adminGroup.POST("/bookings/:id/payment-link", paymentHandler.CreateLink)
+			adminGroup.POST("/bookings/:id/refund", refundHandler.RefundBooking)
+			adminGroup.POST("/bookings/:id/resend-tickets", ticketHandler.ResendTickets)
+			adminGroup.POST("/bookings/:id/regenerate-tickets", ticketHandler.RegenerateTickets)
+			adminGroup.GET("/refund-reasons", refundHandler.ListReasons)
+			adminGroup.POST("/refund-reasons", refundHandler.CreateReason)
+			adminGroup.PUT("/refund-reasons/:code", refundHandler.UpdateReason)
+			adminGroup.GET("/reports/refunds", refundHandler.Report)
+			adminGroup.GET("/invoices", invoiceHandler.List)
+			adminGroup.GET("/invoices/gaps", invoiceHandler.Gaps)
+			adminGroup.GET("/refund-requests", refundHandler.ListRequests)
+			adminGroup.POST("/refund-requests/:id/approve", refundHandler.ApproveRequest)
+			adminGroup.POST("/refund-requests/:id/reject", refundHandler.RejectRequest)
 			adminGroup.GET("/events/:id/bookings", adminHandler.GetEventBookings)
+			adminGroup.GET("/events/:id/bookings/export", adminHandler.ExportEventBookings)
+			adminGroup.GET("/events/:id/capacity", eventHandler.Capacity)
+			adminGroup.PUT("/users/:id/role", adminHandler.UpdateUserRole)
+			adminGroup.GET("/users/:id/summary", adminHandler.GetUserSummary)
+			adminGroup.GET("/organizer-applications", organizerHandler.ListApplications)
+			adminGroup.GET("/organizer-applications/:id", organizerHandler.GetApplication)
+			adminGroup.GET("/organizer-applications/:id/documents/:doc_id", organizerHandler.DownloadDocument)
+			adminGroup.POST("/organizer-applications/:id/approve", organizerHandler.Approve)
+			adminGroup.POST("/organizer-applications/:id/reject", organizerHandler.Reject)
+			adminGroup.POST("/events/:id/experiments", experimentHandler.Create)
+			adminGroup.GET("/events/:id/experiments", experimentHandler.List)
+			adminGroup.POST("/experiments/:id/stop", experimentHandler.Stop)
+			adminGroup.GET("/experiments/:id/results", experimentHandler.Results)
+			adminGroup.GET("/jobs/dead", jobHandler.ListDead)
+			adminGroup.GET("/jobs/queue", jobHandler.QueueStats)
+			adminGroup.POST("/jobs/:id/retry", jobHandler.Requeue)
+			adminGroup.GET("/backfills", backfillHandler.List)
+			adminGroup.GET("/backfills/:name", backfillHandler.Get)
+			adminGroup.POST("/backfills/:name/start", backfillHandler.Start)
+			adminGroup.POST("/api-keys", apiKeyHandler.Create)
+			adminGroup.GET("/api-keys", apiKeyHandler.List)
+			adminGroup.DELETE("/api-keys/:id", apiKeyHandler.Revoke)
+			adminGroup.POST("/webhooks", webhookHandler.Create)
+			adminGroup.GET("/webhooks", webhookHandler.List)
+			adminGroup.PATCH("/webhooks/:id", webhookHandler.Update)
+			adminGroup.DELETE("/webhooks/:id", webhookHandler.Delete)
+			adminGroup.GET("/webhooks/:id/deliveries", webhookHandler.Deliveries)
+			adminGroup.GET("/audit-logs", auditHandler.List)
+		}
+
+		// Gate check-in routes (admin, or staff granted check-in on the event)
+		checkinGroup := v1.Group("/admin")
+		checkinGroup.Use(middleware.AuthMiddleware(jwtKeys, userUsecase), middleware.RoleMiddleware("admin", "staff"))
+		{
+			checkinGroup.POST("/events/:id/checkin", middleware.EventAccessMiddleware(eventStaffUseCase, entity.StaffScopeCheckin), checkinHandler.CheckIn)
+		}
+
+		// Turnstile routes, authenticated by per-gate API key
+		gateGroup := v1.Group("/gate")
+		gateGroup.Use(middleware.GateKeyMiddleware(gateUseCase))
+		{
+			gateGroup.POST("/scans", gateHandler.ScanBatch)
+		}
+
+		// Staff routes, limited to the events an organizer granted access to
+		staffGroup := v1.Group("/staff")
+		staffGroup.Use(middleware.AuthMiddleware(jwtKeys, userUsecase), middleware.RoleMiddleware("staff"))
+		{
+			staffGroup.GET("/events", eventStaffHandler.ListMine)
+			staffGroup.GET("/events/:id/forecast", middleware.EventAccessMiddleware(eventStaffUseCase, entity.StaffScopeReports), analyticsHandler.Forecast)
+			staffGroup.GET("/events/:id/comparison", middleware.EventAccessMiddleware(eventStaffUseCase, entity.StaffScopeReports), analyticsHandler.Compare)
+			staffGroup.GET("/events/:id/attendance", middleware.EventAccessMiddleware(eventStaffUseCase, entity.StaffScopeReports), analyticsHandler.Attendance)
+		}
+
+		// Organizer routes
+		organizerGroup := v1.Group("/organizer")
+		organizerGroup.Use(middleware.APIKeyMiddleware(apiKeyUseCase, "organizer"), middleware.AuthMiddleware(jwtKeys, userUsecase), middleware.RoleMiddleware("organizer"))
+		{
+			organizerGroup.POST("/bank-accounts", bankAccountHandler.Add)
+			organizerGroup.GET("/bank-accounts", bankAccountHandler.List)
+			organizerGroup.GET("/invoices", invoiceHandler.ListMine)
+			organizerGroup.POST("/bank-accounts/:id/verify", bankAccountHandler.Verify)
+			organizerGroup.PUT("/bank-accounts/:id/default", bankAccountHandler.SetDefault)
+			organizerGroup.GET("/events/:id/forecast", analyticsHandler.Forecast)
+			organizerGroup.GET("/events/:id/comparison", analyticsHandler.Compare)
+			organizerGroup.GET("/events/:id/attendance", analyticsHandler.Attendance)
+			organizerGroup.PUT("/events/:id/sales-goal", salesGoalHandler.Set)
+			organizerGroup.GET("/events/:id/sales-goal", salesGoalHandler.Get)
+			organizerGroup.DELETE("/events/:id/sales-goal", salesGoalHandler.Delete)
+			organizerGroup.PUT("/events/:id/content", eventHandler.UpdateContent)
+			organizerGroup.PUT("/events/:id/image", eventHandler.UploadImage)
+			organizerGroup.PUT("/events/:id/refund-policy", eventHandler.SetRefundPolicy)
+			organizerGroup.DELETE("/events/:id/refund-policy", eventHandler.ClearRefundPolicy)
+			organizerGroup.POST("/events/:id/staff", eventStaffHandler.Grant)
+			organizerGroup.GET("/events/:id/staff", eventStaffHandler.List)
+			organizerGroup.DELETE("/events/:id/staff/:user_id", eventStaffHandler.Revoke)
+			organizerGroup.POST("/events/:id/gates", gateHandler.Create)
+			organizerGroup.GET("/events/:id/gates", gateHandler.List)
+			organizerGroup.DELETE("/events/:id/gates/:gate_id", gateHandler.Revoke)
+			organizerGroup.PUT("/events/:id/sections/:section/image", sectionImageHandler.Upload)
+			organizerGroup.DELETE("/events/:id/sections/:section/image", sectionImageHandler.Delete)
 		}
 	}
 
@@ -161,6 +685,7 @@ func main() {
 		Addr:    ":" + cfg.Server.Port,
 		Handler: r,
 	}
+	srv.RegisterOnShutdown(eventHandler.CloseStreams)
 
 	// 5. Run Server
 	go func() {
@@ -170,6 +695,19 @@ func main() {
 		}
 	}()
 
+	// gRPC API for service-to-service integrations, on its own port
+	grpcServer := grpcdelivery.NewServer(jwtKeys, userUsecase, eventUseCase, bookingUseCase, funnelUseCase, paymentUseCase)
+	grpcListener, err := net.Listen("tcp", ":"+cfg.Server.GRPCPort)
+	if err != nil {
+		logger.Fatal("failed to listen for gRPC", logger.Err(err))
+	}
+	go func() {
+		logger.Info("gRPC server starting", logger.String("port", cfg.Server.GRPCPort))
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logger.Fatal("failed to start gRPC server", logger.Err(err))
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -182,7 +720,42 @@ func main() {
 		logger.Fatal("server forced to shutdown", logger.Err(err))
 	}
 
+	// In-flight gRPC calls get what is left of the same grace period
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+
+	forecastScheduler.Stop()
+	upgradeOfferScheduler.Stop()
+	salesGoalScheduler.Stop()
+	attendanceReportScheduler.Stop()
+	inventoryScheduler.Stop()
+	holdReleaseScheduler.Stop()
+	gatewayRecoveryScheduler.Stop()
+	reportScheduler.Stop()
+	if warehouseScheduler != nil {
+		warehouseScheduler.Stop()
+	}
 	notifWorker.Stop()
+	if eventBroker != nil {
+		eventBroker.Close()
+	}
+	stopCacheBus()
+
+	// Flush spans from the final requests and jobs
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("failed to flush traces", logger.Err(err))
+	}
+	if err := errorReporter.Close(ctx); err != nil {
+		logger.Error("failed to flush error reports", logger.Err(err))
+	}
 
 	logger.Info("server exited")
 }
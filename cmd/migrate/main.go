@@ -0,0 +1,91 @@
+// Command migrate applies the embedded database migrations.
+//
+//	migrate up            apply all pending migrations
+//	migrate down [N]      roll back N migrations (default 1)
+//	migrate version       print the current version
+//	migrate force V       set the version without running migrations (fixes a dirty state)
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"ticres/internal/config"
+	"ticres/pkg/database"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	m, err := database.NewMigrator(cfg.DB.Host, cfg.DB.Port, cfg.DB.User, cfg.DB.Password, cfg.DB.Name, cfg.DB.SSLMode)
+	if err != nil {
+		log.Fatalf("failed to init migrator: %v", err)
+	}
+	defer m.Close()
+
+	switch os.Args[1] {
+	case "up":
+		err = m.Up()
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			if steps, err = strconv.Atoi(os.Args[2]); err != nil || steps < 1 {
+				log.Fatalf("invalid step count %q", os.Args[2])
+			}
+		}
+		err = m.Steps(-steps)
+	case "version":
+		version, dirty, verr := m.Version()
+		if errors.Is(verr, migrate.ErrNilVersion) {
+			fmt.Println("no migrations applied")
+			return
+		}
+		if verr != nil {
+			log.Fatalf("failed to read version: %v", verr)
+		}
+		fmt.Printf("version %d (dirty: %t)\n", version, dirty)
+		return
+	case "force":
+		if len(os.Args) < 3 {
+			usage()
+		}
+		version, perr := strconv.Atoi(os.Args[2])
+		if perr != nil {
+			log.Fatalf("invalid version %q", os.Args[2])
+		}
+		err = m.Force(version)
+	default:
+		usage()
+	}
+
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("no change")
+		return
+	}
+	if err != nil {
+		log.Fatalf("migration failed: %v", err)
+	}
+
+	if version, dirty, verr := m.Version(); verr == nil {
+		fmt.Printf("migrated to version %d (dirty: %t)\n", version, dirty)
+	} else if errors.Is(verr, migrate.ErrNilVersion) {
+		fmt.Println("all migrations rolled back")
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate up | down [N] | version | force V")
+	os.Exit(2)
+}
@@ -20,6 +20,7 @@ func main() {
 
 	pool, err := database.NewPostgresConnection(
 		cfg.DB.Host, cfg.DB.Port, cfg.DB.User, cfg.DB.Password, cfg.DB.Name, cfg.DB.SSLMode,
+		database.PoolConfig{ConnectTimeout: cfg.DB.ConnectTimeout, StatementTimeout: cfg.DB.StatementTimeout},
 	)
 	if err != nil {
 		log.Fatalf("failed to connect to database: %v", err)
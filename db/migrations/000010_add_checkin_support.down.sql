@@ -0,0 +1,7 @@
+ALTER TABLE booking_items DROP CONSTRAINT IF EXISTS fk_booking_items_checked_in_by;
+ALTER TABLE booking_items DROP COLUMN IF EXISTS checked_in_by;
+ALTER TABLE booking_items DROP COLUMN IF EXISTS checked_in_at;
+ALTER TABLE booking_items DROP COLUMN IF EXISTS ticket_code;
+
+-- Postgres cannot drop a single enum value, so demote staff accounts instead
+UPDATE users SET role = 'user' WHERE role = 'staff';
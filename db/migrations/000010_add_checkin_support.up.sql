@@ -0,0 +1,12 @@
+-- Gate operators get their own role so they don't need full admin access
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'staff';
+
+-- Every booked seat becomes a ticket with its own QR code
+ALTER TABLE booking_items ADD COLUMN ticket_code VARCHAR(64) UNIQUE;
+ALTER TABLE booking_items ADD COLUMN checked_in_at TIMESTAMP;
+ALTER TABLE booking_items ADD COLUMN checked_in_by INTEGER;
+
+ALTER TABLE booking_items
+  ADD CONSTRAINT fk_booking_items_checked_in_by
+    FOREIGN KEY (checked_in_by)
+    REFERENCES users (user_id);
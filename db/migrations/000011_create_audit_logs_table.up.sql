@@ -0,0 +1,16 @@
+CREATE TABLE audit_logs (
+  audit_id SERIAL PRIMARY KEY,
+  actor_id INTEGER, -- NULL for system actions (worker)
+  action VARCHAR(100) NOT NULL,
+  entity_type VARCHAR(50),
+  entity_id BIGINT,
+  details JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_audit_logs_users
+    FOREIGN KEY (actor_id)
+    REFERENCES users (user_id)
+);
+
+CREATE INDEX idx_audit_logs_action_created_at ON audit_logs (action, created_at);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs (actor_id);
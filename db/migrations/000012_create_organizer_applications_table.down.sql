@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS organizer_documents;
+DROP TABLE IF EXISTS organizer_applications;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS fk_events_organizer;
+ALTER TABLE events DROP COLUMN IF EXISTS organizer_id;
+
+-- Postgres cannot drop a single enum value, so demote organizer accounts instead
+UPDATE users SET role = 'user' WHERE role = 'organizer';
@@ -0,0 +1,57 @@
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'organizer';
+
+-- Events are owned by the organizer who created them
+ALTER TABLE events ADD COLUMN organizer_id INTEGER;
+ALTER TABLE events
+  ADD CONSTRAINT fk_events_organizer
+    FOREIGN KEY (organizer_id)
+    REFERENCES users (user_id);
+
+CREATE TABLE organizer_applications (
+  application_id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL,
+  business_name VARCHAR(255) NOT NULL,
+  business_type VARCHAR(50),
+  business_address TEXT,
+  tax_id VARCHAR(50),
+  phone VARCHAR(30),
+  bank_name VARCHAR(100),
+  bank_account_name VARCHAR(255),
+  bank_account_number VARCHAR(50),
+  status VARCHAR(20) DEFAULT 'PENDING',
+  review_note TEXT,
+  reviewed_by INTEGER,
+  reviewed_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_organizer_applications_users
+    FOREIGN KEY (user_id)
+    REFERENCES users (user_id),
+
+  CONSTRAINT fk_organizer_applications_reviewer
+    FOREIGN KEY (reviewed_by)
+    REFERENCES users (user_id)
+);
+
+CREATE INDEX idx_organizer_applications_status ON organizer_applications (status, created_at);
+
+CREATE TABLE organizer_documents (
+  document_id SERIAL PRIMARY KEY,
+  application_id INTEGER NOT NULL,
+  doc_type VARCHAR(50) NOT NULL,
+  file_name VARCHAR(255),
+  storage_key VARCHAR(500) NOT NULL,
+  content_type VARCHAR(100),
+  size_bytes BIGINT,
+  uploaded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_organizer_documents_application
+    FOREIGN KEY (application_id)
+    REFERENCES organizer_applications (application_id)
+);
+
+-- A user can only have one open or approved application at a time
+CREATE UNIQUE INDEX idx_organizer_applications_active_user
+  ON organizer_applications (user_id)
+  WHERE status IN ('PENDING', 'APPROVED');
@@ -0,0 +1,29 @@
+CREATE TABLE bank_accounts (
+  bank_account_id SERIAL PRIMARY KEY,
+  organizer_id INTEGER NOT NULL,
+  bank_name VARCHAR(100) NOT NULL,
+  account_holder VARCHAR(255) NOT NULL,
+  account_number_enc TEXT NOT NULL, -- AES-GCM encrypted, never stored in plaintext
+  account_last4 VARCHAR(4) NOT NULL,
+  verification_method VARCHAR(20) NOT NULL,
+  status VARCHAR(30) NOT NULL DEFAULT 'PENDING_VERIFICATION',
+  deposit_amount_1 DECIMAL(10,2),
+  deposit_amount_2 DECIMAL(10,2),
+  verify_attempts INTEGER NOT NULL DEFAULT 0,
+  is_default BOOLEAN NOT NULL DEFAULT FALSE,
+  verified_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_bank_accounts_users
+    FOREIGN KEY (organizer_id)
+    REFERENCES users (user_id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_bank_accounts_organizer_id ON bank_accounts (organizer_id);
+
+-- At most one default payout account per organizer
+CREATE UNIQUE INDEX idx_bank_accounts_default
+  ON bank_accounts (organizer_id)
+  WHERE is_default;
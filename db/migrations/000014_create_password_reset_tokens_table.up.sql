@@ -0,0 +1,15 @@
+CREATE TABLE password_reset_tokens (
+  token_id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL,
+  token_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256 of the emailed token
+  expires_at TIMESTAMP NOT NULL,
+  used_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_password_reset_tokens_users
+    FOREIGN KEY (user_id)
+    REFERENCES users (user_id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
@@ -0,0 +1 @@
+ALTER TABLE events DROP COLUMN IF EXISTS marketing_boost_sent_at;
@@ -0,0 +1,2 @@
+-- Last time the organizer was nudged about slow ticket sales
+ALTER TABLE events ADD COLUMN marketing_boost_sent_at TIMESTAMP;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_events_organizer_series;
+ALTER TABLE events DROP COLUMN IF EXISTS series;
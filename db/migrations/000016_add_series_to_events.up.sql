@@ -0,0 +1,4 @@
+-- Optional grouping for recurring events (e.g. "Jazz Night"), used to compare sales across editions
+ALTER TABLE events ADD COLUMN series VARCHAR(150);
+
+CREATE INDEX idx_events_organizer_series ON events (organizer_id, series);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_booking_variant_id;
+ALTER TABLE booking DROP COLUMN IF EXISTS variant_id;
+DROP TABLE IF EXISTS pricing_exposures;
+DROP TABLE IF EXISTS pricing_variants;
+DROP TABLE IF EXISTS pricing_experiments;
@@ -0,0 +1,52 @@
+CREATE TABLE pricing_experiments (
+  experiment_id SERIAL PRIMARY KEY,
+  event_id INTEGER NOT NULL,
+  name VARCHAR(150) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+  created_by INTEGER,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  ended_at TIMESTAMP,
+
+  CONSTRAINT fk_pricing_experiments_events
+    FOREIGN KEY (event_id)
+    REFERENCES events (event_id)
+    ON DELETE CASCADE
+);
+
+-- Only one experiment may run per event at a time
+CREATE UNIQUE INDEX idx_pricing_experiments_active
+  ON pricing_experiments (event_id)
+  WHERE status = 'ACTIVE';
+
+-- Variants are immutable once the experiment starts so bucketing stays stable
+CREATE TABLE pricing_variants (
+  variant_id SERIAL PRIMARY KEY,
+  experiment_id INTEGER NOT NULL,
+  variant_key VARCHAR(50) NOT NULL,
+  weight INTEGER NOT NULL CHECK (weight > 0),
+  price_multiplier DECIMAL(6,4) NOT NULL DEFAULT 1,
+  fee_display VARCHAR(20) NOT NULL DEFAULT 'inclusive',
+
+  CONSTRAINT fk_pricing_variants_experiments
+    FOREIGN KEY (experiment_id)
+    REFERENCES pricing_experiments (experiment_id)
+    ON DELETE CASCADE,
+  CONSTRAINT uq_pricing_variants_key UNIQUE (experiment_id, variant_key)
+);
+
+-- First time a user was shown a variant; the denominator for conversion
+CREATE TABLE pricing_exposures (
+  experiment_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  variant_id INTEGER NOT NULL,
+  exposed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  PRIMARY KEY (experiment_id, user_id),
+  CONSTRAINT fk_pricing_exposures_variants
+    FOREIGN KEY (variant_id)
+    REFERENCES pricing_variants (variant_id)
+    ON DELETE CASCADE
+);
+
+ALTER TABLE booking ADD COLUMN variant_id INTEGER REFERENCES pricing_variants (variant_id) ON DELETE SET NULL;
+CREATE INDEX idx_booking_variant_id ON booking (variant_id) WHERE variant_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Seat numbering template used when seats are generated. NULL keeps the
+-- original "<event_id>-<n>" seat numbers for existing events.
+ALTER TABLE events ADD COLUMN seat_numbering JSONB;
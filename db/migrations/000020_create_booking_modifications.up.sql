@@ -0,0 +1,20 @@
+-- Seat changes on paid bookings. from_seat_ids[i] was swapped for
+-- to_seat_ids[i]; amount_charged is the price difference paid for the change.
+CREATE TABLE booking_modifications (
+  modification_id SERIAL PRIMARY KEY,
+  booking_id INTEGER NOT NULL,
+  from_seat_ids INTEGER[] NOT NULL,
+  to_seat_ids INTEGER[] NOT NULL,
+  previous_amount DECIMAL(10, 2) NOT NULL,
+  new_amount DECIMAL(10, 2) NOT NULL,
+  amount_charged DECIMAL(10, 2) NOT NULL DEFAULT 0,
+  payment_method VARCHAR(50),
+  external_id VARCHAR(255),
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_booking_modifications_booking
+    FOREIGN KEY (booking_id)
+    REFERENCES booking (booking_id)
+);
+
+CREATE INDEX idx_booking_modifications_booking_id ON booking_modifications (booking_id);
@@ -0,0 +1,28 @@
+-- Paid upgrade offers for unsold premium seats close to the event. The
+-- emailed accept link carries a token; only its SHA-256 hash is stored.
+CREATE TABLE upgrade_offers (
+  offer_id SERIAL PRIMARY KEY,
+  booking_id INTEGER NOT NULL,
+  from_seat_id INTEGER NOT NULL,
+  to_seat_id INTEGER NOT NULL,
+  price_difference DECIMAL(10, 2) NOT NULL,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, ACCEPTED, EXPIRED
+  expires_at TIMESTAMP NOT NULL,
+  accepted_at TIMESTAMP,
+  modification_id INTEGER,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_upgrade_offers_booking
+    FOREIGN KEY (booking_id)
+    REFERENCES booking (booking_id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_upgrade_offers_modification
+    FOREIGN KEY (modification_id)
+    REFERENCES booking_modifications (modification_id)
+);
+
+-- A ticket is offered an upgrade at most once
+CREATE UNIQUE INDEX idx_upgrade_offers_ticket ON upgrade_offers (booking_id, from_seat_id);
+-- A premium seat is offered to one ticket holder at a time
+CREATE UNIQUE INDEX idx_upgrade_offers_pending_seat ON upgrade_offers (to_seat_id) WHERE status = 'PENDING';
@@ -0,0 +1,3 @@
+-- Organizer-managed detail page content: FAQ, door time, prohibited items
+-- and description blocks. NULL until the organizer adds any.
+ALTER TABLE events ADD COLUMN content JSONB;
@@ -0,0 +1,17 @@
+-- View-from-seat image per section, shown in the seat picker. Sections are
+-- the seats.category values of the event.
+CREATE TABLE section_images (
+  image_id SERIAL PRIMARY KEY,
+  event_id INTEGER NOT NULL,
+  section VARCHAR(50) NOT NULL,
+  storage_key VARCHAR(500) NOT NULL,
+  content_type VARCHAR(100) NOT NULL,
+  size_bytes BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT fk_section_images_events
+    FOREIGN KEY (event_id)
+    REFERENCES events (event_id)
+    ON DELETE CASCADE,
+  CONSTRAINT uq_section_images_event_section UNIQUE (event_id, section)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE seats DROP COLUMN IF EXISTS pos_y;
+ALTER TABLE seats DROP COLUMN IF EXISTS pos_x;
+ALTER TABLE seats DROP COLUMN IF EXISTS col_number;
+ALTER TABLE seats DROP COLUMN IF EXISTS row_label;
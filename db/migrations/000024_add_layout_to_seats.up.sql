@@ -0,0 +1,7 @@
+-- Seat map placement. Row and column are filled from the numbering scheme
+-- when seats are generated; x/y are set by admins through the layout API
+-- and stay NULL for seats that have not been placed.
+ALTER TABLE seats ADD COLUMN row_label VARCHAR(10);
+ALTER TABLE seats ADD COLUMN col_number INTEGER;
+ALTER TABLE seats ADD COLUMN pos_x DOUBLE PRECISION;
+ALTER TABLE seats ADD COLUMN pos_y DOUBLE PRECISION;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_seats_tier_id;
+ALTER TABLE seats DROP COLUMN IF EXISTS tier_id;
+DROP TABLE IF EXISTS ticket_tiers;
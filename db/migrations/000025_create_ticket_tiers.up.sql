@@ -0,0 +1,15 @@
+-- Named price levels per event. Seats assigned to a tier take its price;
+-- quota caps how many seats the tier may hold.
+CREATE TABLE ticket_tiers (
+  tier_id SERIAL PRIMARY KEY,
+  event_id INTEGER NOT NULL REFERENCES events (event_id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  price DECIMAL(10, 2) NOT NULL,
+  quota INTEGER NOT NULL CHECK (quota > 0),
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (event_id, name)
+);
+
+ALTER TABLE seats ADD COLUMN tier_id INTEGER REFERENCES ticket_tiers (tier_id) ON DELETE SET NULL;
+CREATE INDEX idx_seats_tier_id ON seats (tier_id);
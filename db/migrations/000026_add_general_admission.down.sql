@@ -0,0 +1,5 @@
+ALTER TABLE booking DROP COLUMN IF EXISTS ga_released;
+ALTER TABLE booking DROP COLUMN IF EXISTS ga_quantity;
+ALTER TABLE events DROP COLUMN IF EXISTS ga_remaining;
+ALTER TABLE events DROP COLUMN IF EXISTS general_price;
+ALTER TABLE events DROP COLUMN IF EXISTS admission_mode;
@@ -0,0 +1,11 @@
+-- General admission events have no seat rows. Capacity is tracked by the
+-- ga_remaining counter, decremented atomically per booking, and each
+-- ticket is a booking_items row without a seat.
+ALTER TABLE events ADD COLUMN admission_mode VARCHAR(10) NOT NULL DEFAULT 'seated'
+  CHECK (admission_mode IN ('seated', 'general'));
+ALTER TABLE events ADD COLUMN general_price DECIMAL(10, 2);
+ALTER TABLE events ADD COLUMN ga_remaining INTEGER CHECK (ga_remaining >= 0);
+
+-- ga_released makes returning the quantity to the counter idempotent
+ALTER TABLE booking ADD COLUMN ga_quantity INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE booking ADD COLUMN ga_released BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_refund_refund_date;
+ALTER TABLE refund DROP COLUMN IF EXISTS reason;
+ALTER TABLE refund RENAME COLUMN note TO reason;
+DROP TABLE IF EXISTS refund_reasons;
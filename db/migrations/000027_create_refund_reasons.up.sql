@@ -0,0 +1,24 @@
+-- Refund reasons are an admin-managed taxonomy so finance can aggregate
+-- refunds by cause. Deactivated reasons stay valid for existing refunds.
+CREATE TABLE refund_reasons (
+    code VARCHAR(50) PRIMARY KEY,
+    label VARCHAR(100) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO refund_reasons (code, label) VALUES
+    ('event_cancelled', 'Event cancelled'),
+    ('customer_request', 'Customer request'),
+    ('duplicate_charge', 'Duplicate charge'),
+    ('fraud', 'Fraud');
+
+-- The old free-text reason is kept as an optional note
+ALTER TABLE refund RENAME COLUMN reason TO note;
+ALTER TABLE refund ADD COLUMN reason VARCHAR(50) REFERENCES refund_reasons (code);
+
+-- Refunds were only issued for cancelled events until now
+UPDATE refund SET reason = 'event_cancelled';
+ALTER TABLE refund ALTER COLUMN reason SET NOT NULL;
+
+CREATE INDEX idx_refund_refund_date ON refund (refund_date);
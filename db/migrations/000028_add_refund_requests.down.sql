@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_refund_status;
+DROP INDEX IF EXISTS idx_refund_open_request;
+ALTER TABLE refund DROP CONSTRAINT IF EXISTS refund_status_check;
+ALTER TABLE refund ALTER COLUMN status DROP NOT NULL;
+ALTER TABLE refund ALTER COLUMN status SET DEFAULT 'PENDING';
+ALTER TABLE refund DROP COLUMN IF EXISTS review_note;
+ALTER TABLE refund DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE refund DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE refund DROP COLUMN IF EXISTS requested_at;
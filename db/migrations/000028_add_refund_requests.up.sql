@@ -0,0 +1,19 @@
+-- Customers can request a refund for a PAID booking. A request moves
+-- REQUESTED -> APPROVED -> COMPLETED, or to REJECTED; refund_date is only
+-- set once the money is returned.
+ALTER TABLE refund ADD COLUMN requested_at TIMESTAMP;
+ALTER TABLE refund ADD COLUMN reviewed_by INTEGER REFERENCES users (user_id);
+ALTER TABLE refund ADD COLUMN reviewed_at TIMESTAMP;
+ALTER TABLE refund ADD COLUMN review_note VARCHAR(255);
+
+-- Every refund so far was recorded when the money was already returned
+UPDATE refund SET status = 'COMPLETED' WHERE status IS NULL OR status = 'PENDING';
+ALTER TABLE refund ALTER COLUMN status SET DEFAULT 'REQUESTED';
+ALTER TABLE refund ALTER COLUMN status SET NOT NULL;
+ALTER TABLE refund ADD CONSTRAINT refund_status_check
+  CHECK (status IN ('REQUESTED', 'APPROVED', 'REJECTED', 'COMPLETED'));
+
+-- A booking has at most one request waiting for review or processing
+CREATE UNIQUE INDEX idx_refund_open_request ON refund (booking_id)
+  WHERE status IN ('REQUESTED', 'APPROVED');
+CREATE INDEX idx_refund_status ON refund (status);
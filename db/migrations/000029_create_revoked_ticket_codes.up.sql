@@ -0,0 +1,19 @@
+-- Ticket codes replaced by an admin stay on record so the gate can tell a
+-- revoked ticket from a mistyped one
+CREATE TABLE revoked_ticket_codes (
+  code VARCHAR(64) PRIMARY KEY,
+  booking_item_id INTEGER NOT NULL,
+  revoked_by INTEGER,
+  revoked_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_revoked_ticket_codes_item
+    FOREIGN KEY (booking_item_id)
+    REFERENCES booking_items (id)
+    ON DELETE CASCADE,
+
+  CONSTRAINT fk_revoked_ticket_codes_revoked_by
+    FOREIGN KEY (revoked_by)
+    REFERENCES users (user_id)
+);
+
+CREATE INDEX idx_revoked_ticket_codes_item ON revoked_ticket_codes (booking_item_id);
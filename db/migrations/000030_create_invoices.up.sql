@@ -0,0 +1,28 @@
+-- Invoice numbers run per issuer and calendar year (Western Indonesian Time).
+-- The issuer is the event's organizer, or 0 for events the platform sells
+-- itself. A number is taken by bumping the counter row in the same
+-- transaction that completes the payment, so a rolled back payment hands its
+-- number back and the series stays without gaps.
+CREATE TABLE invoice_sequences (
+  issuer_id INTEGER NOT NULL,
+  year INTEGER NOT NULL,
+  last_number INTEGER NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (issuer_id, year)
+);
+
+CREATE TABLE invoices (
+  invoice_id SERIAL PRIMARY KEY,
+  invoice_number VARCHAR(40) NOT NULL UNIQUE,
+  issuer_id INTEGER NOT NULL,
+  year INTEGER NOT NULL,
+  sequence INTEGER NOT NULL,
+  payment_id INTEGER NOT NULL UNIQUE,
+  amount DECIMAL(10, 2) NOT NULL,
+  issued_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT uq_invoices_sequence UNIQUE (issuer_id, year, sequence),
+  CONSTRAINT fk_invoices_transaction
+    FOREIGN KEY (payment_id)
+    REFERENCES transactions (payment_id)
+);
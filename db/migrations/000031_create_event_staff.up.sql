@@ -0,0 +1,25 @@
+-- Organizers give staff accounts access to one of their events, one row per
+-- scope: 'checkin' to scan tickets at the gate, 'reports' to read the
+-- event's sales reports.
+CREATE TABLE event_staff (
+  event_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  scope VARCHAR(20) NOT NULL CHECK (scope IN ('checkin', 'reports')),
+  granted_by INTEGER NOT NULL,
+  granted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (event_id, user_id, scope),
+  CONSTRAINT fk_event_staff_event
+    FOREIGN KEY (event_id)
+    REFERENCES events (event_id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_event_staff_user
+    FOREIGN KEY (user_id)
+    REFERENCES users (user_id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_event_staff_granted_by
+    FOREIGN KEY (granted_by)
+    REFERENCES users (user_id)
+);
+
+CREATE INDEX idx_event_staff_user ON event_staff (user_id);
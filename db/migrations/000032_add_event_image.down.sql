@@ -0,0 +1,3 @@
+ALTER TABLE events
+  DROP COLUMN IF EXISTS image_url,
+  DROP COLUMN IF EXISTS image_key;
@@ -0,0 +1,4 @@
+-- Event poster: where it is kept in storage and the URL clients load it from.
+ALTER TABLE events
+  ADD COLUMN image_key VARCHAR(255),
+  ADD COLUMN image_url TEXT;
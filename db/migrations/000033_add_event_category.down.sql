@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_events_date;
+DROP INDEX IF EXISTS idx_events_category;
+ALTER TABLE events DROP COLUMN IF EXISTS category;
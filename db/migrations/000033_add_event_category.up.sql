@@ -0,0 +1,6 @@
+-- Free-form event category (concert, sports, theatre, ...) used to filter
+-- the event list.
+ALTER TABLE events ADD COLUMN category VARCHAR(50);
+
+CREATE INDEX idx_events_category ON events (LOWER(category));
+CREATE INDEX idx_events_date ON events (date);
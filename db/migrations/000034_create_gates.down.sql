@@ -0,0 +1,3 @@
+ALTER TABLE booking_items DROP CONSTRAINT IF EXISTS fk_booking_items_checked_in_gate;
+ALTER TABLE booking_items DROP COLUMN IF EXISTS checked_in_gate;
+DROP TABLE IF EXISTS gates;
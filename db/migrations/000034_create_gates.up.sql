@@ -0,0 +1,32 @@
+-- Turnstiles authenticate with a per-gate API key instead of a staff login.
+-- Only the SHA-256 of the key is stored; it is shown once when the gate is
+-- created.
+CREATE TABLE gates (
+  gate_id SERIAL PRIMARY KEY,
+  event_id INTEGER NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  key_hash CHAR(64) NOT NULL UNIQUE,
+  created_by INTEGER NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  last_used_at TIMESTAMP,
+  revoked_at TIMESTAMP,
+
+  CONSTRAINT fk_gates_event
+    FOREIGN KEY (event_id)
+    REFERENCES events (event_id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_gates_created_by
+    FOREIGN KEY (created_by)
+    REFERENCES users (user_id)
+);
+
+CREATE INDEX idx_gates_event ON gates (event_id);
+
+-- Tickets admitted by a turnstile record the gate instead of a staff account
+ALTER TABLE booking_items ADD COLUMN checked_in_gate INTEGER;
+
+ALTER TABLE booking_items
+  ADD CONSTRAINT fk_booking_items_checked_in_gate
+    FOREIGN KEY (checked_in_gate)
+    REFERENCES gates (gate_id)
+    ON DELETE SET NULL;
@@ -0,0 +1,11 @@
+-- Full-text search over the event name, location and the text of its
+-- description blocks, weighted in that order. The 'simple' configuration is
+-- used because names and venues are proper nouns and Postgres ships no
+-- Indonesian dictionary to stem the descriptions with.
+ALTER TABLE events ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+  setweight(to_tsvector('simple', COALESCE(location, '')), 'B') ||
+  setweight(jsonb_to_tsvector('simple', COALESCE(jsonb_path_query_array(content, '$.blocks[*].text'), '[]'::jsonb), '["string"]'), 'C')
+) STORED;
+
+CREATE INDEX idx_events_search_vector ON events USING GIN (search_vector);
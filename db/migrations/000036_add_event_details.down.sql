@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_events_search_vector;
+ALTER TABLE events DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE events ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+  setweight(to_tsvector('simple', COALESCE(location, '')), 'B') ||
+  setweight(jsonb_to_tsvector('simple', COALESCE(jsonb_path_query_array(content, '$.blocks[*].text'), '[]'::jsonb), '["string"]'), 'C')
+) STORED;
+CREATE INDEX idx_events_search_vector ON events USING GIN (search_vector);
+
+ALTER TABLE events
+  DROP COLUMN IF EXISTS metadata,
+  DROP COLUMN IF EXISTS organizer_name,
+  DROP COLUMN IF EXISTS terms,
+  DROP COLUMN IF EXISTS description;
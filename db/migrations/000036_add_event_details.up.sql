@@ -0,0 +1,17 @@
+ALTER TABLE events
+  ADD COLUMN description TEXT,
+  ADD COLUMN terms TEXT,
+  ADD COLUMN organizer_name VARCHAR(150),
+  ADD COLUMN metadata JSONB;
+
+-- The summary description is searchable alongside the location
+DROP INDEX IF EXISTS idx_events_search_vector;
+ALTER TABLE events DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE events ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+  setweight(to_tsvector('simple', COALESCE(location, '')), 'B') ||
+  setweight(to_tsvector('simple', COALESCE(description, '')), 'B') ||
+  setweight(jsonb_to_tsvector('simple', COALESCE(jsonb_path_query_array(content, '$.blocks[*].text'), '[]'::jsonb), '["string"]'), 'C')
+) STORED;
+
+CREATE INDEX idx_events_search_vector ON events USING GIN (search_vector);
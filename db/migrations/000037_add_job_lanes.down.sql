@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_jobs_lane_status_run_at;
+ALTER TABLE jobs DROP COLUMN IF EXISTS lane;
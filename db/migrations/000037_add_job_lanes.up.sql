@@ -0,0 +1,8 @@
+-- Priority lane of each job. Lanes are served by separate workers, so bulk
+-- work never delays transactional email.
+ALTER TABLE jobs ADD COLUMN lane VARCHAR(20) NOT NULL DEFAULT 'transactional';
+
+UPDATE jobs SET lane = 'bulk' WHERE job_type = 'refund';
+UPDATE jobs SET lane = 'reminder' WHERE job_type = 'upgrade_offer';
+
+CREATE INDEX idx_jobs_lane_status_run_at ON jobs (lane, status, run_at);
@@ -0,0 +1,3 @@
+-- Per-event cap on the tickets one user may hold; NULL falls back to the
+-- configured default.
+ALTER TABLE events ADD COLUMN max_tickets_per_user INTEGER CHECK (max_tickets_per_user > 0);
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS warehouse_export_watermarks;
+
+DROP INDEX IF EXISTS idx_audit_logs_created_at_id;
+
+DROP TRIGGER IF EXISTS trg_refund_updated_at ON refund;
+DROP INDEX IF EXISTS idx_refund_updated_at;
+ALTER TABLE refund DROP COLUMN IF EXISTS updated_at;
+
+DROP TRIGGER IF EXISTS trg_transactions_updated_at ON transactions;
+DROP INDEX IF EXISTS idx_transactions_updated_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS updated_at;
+
+DROP TRIGGER IF EXISTS trg_booking_updated_at ON booking;
+DROP INDEX IF EXISTS idx_booking_updated_at;
+ALTER TABLE booking DROP COLUMN IF EXISTS updated_at;
+
+DROP FUNCTION IF EXISTS set_updated_at();
@@ -0,0 +1,46 @@
+-- Warehouse exports pick up changed rows by (updated_at, id), so the exported
+-- tables need an updated_at that every UPDATE bumps.
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = clock_timestamp();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE booking ADD COLUMN updated_at TIMESTAMP;
+UPDATE booking SET updated_at = COALESCE(created_at, CURRENT_TIMESTAMP);
+ALTER TABLE booking ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE booking ALTER COLUMN updated_at SET NOT NULL;
+CREATE TRIGGER trg_booking_updated_at BEFORE UPDATE ON booking
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE INDEX idx_booking_updated_at ON booking (updated_at, booking_id);
+
+ALTER TABLE transactions ADD COLUMN updated_at TIMESTAMP;
+UPDATE transactions SET updated_at = COALESCE(transaction_date, CURRENT_TIMESTAMP);
+ALTER TABLE transactions ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE transactions ALTER COLUMN updated_at SET NOT NULL;
+CREATE TRIGGER trg_transactions_updated_at BEFORE UPDATE ON transactions
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE INDEX idx_transactions_updated_at ON transactions (updated_at, payment_id);
+
+ALTER TABLE refund ADD COLUMN updated_at TIMESTAMP;
+UPDATE refund SET updated_at = COALESCE(refund_date, CURRENT_TIMESTAMP);
+ALTER TABLE refund ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE refund ALTER COLUMN updated_at SET NOT NULL;
+CREATE TRIGGER trg_refund_updated_at BEFORE UPDATE ON refund
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE INDEX idx_refund_updated_at ON refund (updated_at, refund_id);
+
+-- Audit logs are append-only; created_at is their watermark
+CREATE INDEX idx_audit_logs_created_at_id ON audit_logs (created_at, audit_id);
+
+-- One watermark per destination and dataset: the last exported row
+CREATE TABLE warehouse_export_watermarks (
+    destination VARCHAR(100) NOT NULL,
+    dataset VARCHAR(50) NOT NULL,
+    watermark_at TIMESTAMP NOT NULL,
+    watermark_id BIGINT NOT NULL,
+    exported_rows BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (destination, dataset)
+);
@@ -0,0 +1,10 @@
+-- Waves release an event's tickets for sale in steps. The tickets on sale at
+-- a moment are the quantities of the waves started by then; events without
+-- waves sell their whole inventory at once.
+CREATE TABLE event_sales_waves (
+  wave_id SERIAL PRIMARY KEY,
+  event_id INTEGER NOT NULL REFERENCES events (event_id) ON DELETE CASCADE,
+  starts_at TIMESTAMP NOT NULL,
+  quantity INTEGER NOT NULL CHECK (quantity > 0),
+  UNIQUE (event_id, starts_at)
+);
@@ -0,0 +1,6 @@
+ALTER TABLE events ALTER COLUMN status DROP NOT NULL;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_status_check;
+ALTER TABLE transactions ALTER COLUMN status DROP NOT NULL;
+ALTER TABLE booking DROP CONSTRAINT IF EXISTS booking_status_check;
+ALTER TABLE booking ALTER COLUMN status DROP NOT NULL;
+ALTER TABLE booking ALTER COLUMN status DROP DEFAULT;
@@ -0,0 +1,18 @@
+-- Booking and payment statuses are uppercase and events keep their
+-- lowercase enum; see entity/status.go. Values set by hand in another case
+-- are normalized before the checks are added.
+UPDATE booking SET status = UPPER(TRIM(status)) WHERE status <> UPPER(TRIM(status));
+UPDATE booking SET status = 'PENDING' WHERE status IS NULL;
+ALTER TABLE booking ALTER COLUMN status SET DEFAULT 'PENDING';
+ALTER TABLE booking ALTER COLUMN status SET NOT NULL;
+ALTER TABLE booking ADD CONSTRAINT booking_status_check
+  CHECK (status IN ('PENDING', 'PAID', 'CANCELLED', 'EXPIRED', 'REFUNDED'));
+
+UPDATE transactions SET status = UPPER(TRIM(status)) WHERE status <> UPPER(TRIM(status));
+UPDATE transactions SET status = 'PENDING' WHERE status IS NULL;
+ALTER TABLE transactions ALTER COLUMN status SET NOT NULL;
+ALTER TABLE transactions ADD CONSTRAINT transactions_status_check
+  CHECK (status IN ('PENDING', 'COMPLETED', 'FAILED', 'CANCELLED', 'REFUNDED'));
+
+UPDATE events SET status = 'available' WHERE status IS NULL;
+ALTER TABLE events ALTER COLUMN status SET NOT NULL;
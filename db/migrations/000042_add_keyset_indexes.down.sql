@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_booking_status_id;
+DROP INDEX IF EXISTS idx_booking_created_at_id;
+DROP INDEX IF EXISTS idx_events_created_at_id;
@@ -0,0 +1,6 @@
+-- Cursor pages of the event and admin booking lists continue after the
+-- last row's sort keys, with the ID breaking ties, so each page is an
+-- index range scan however deep it is.
+CREATE INDEX idx_events_created_at_id ON events (created_at, event_id);
+CREATE INDEX idx_booking_created_at_id ON booking (created_at, booking_id);
+CREATE INDEX idx_booking_status_id ON booking (status, booking_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_events_live_created_at_id;
+ALTER TABLE events DROP COLUMN IF EXISTS deleted_at;
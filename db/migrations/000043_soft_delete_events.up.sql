@@ -0,0 +1,10 @@
+-- Deleting an event hides it instead of only marking it cancelled, so it
+-- drops out of the public list while its bookings, refunds and audit
+-- history keep pointing at it. Events cancelled before this keep their
+-- status and are hidden as of their last update.
+ALTER TABLE events ADD COLUMN deleted_at TIMESTAMP;
+
+UPDATE events SET deleted_at = COALESCE(updated_at, created_at, NOW()) WHERE status = 'cancelled';
+
+-- The public list only reads live events
+CREATE INDEX idx_events_live_created_at_id ON events (created_at, event_id) WHERE deleted_at IS NULL;
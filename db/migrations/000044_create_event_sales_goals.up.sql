@@ -0,0 +1,16 @@
+-- Organizers set a ticket sales goal per event. A scheduled job compares
+-- PAID tickets against it and emails the organizer as sales pass each
+-- threshold, when the event sells out and when sales stall; the alert
+-- columns record what was sent so each alert goes out once.
+CREATE TABLE event_sales_goals (
+  event_id INTEGER PRIMARY KEY REFERENCES events (event_id) ON DELETE CASCADE,
+  target_tickets INTEGER NOT NULL CHECK (target_tickets > 0),
+  thresholds INTEGER[] NOT NULL DEFAULT '{50,100}',
+  notify_sold_out BOOLEAN NOT NULL DEFAULT TRUE,
+  stall_after_hours INTEGER NOT NULL DEFAULT 0 CHECK (stall_after_hours >= 0),
+  reached_thresholds INTEGER[] NOT NULL DEFAULT '{}',
+  sold_out_alerted_at TIMESTAMP,
+  stall_alerted_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
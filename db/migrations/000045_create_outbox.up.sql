@@ -0,0 +1,11 @@
+-- Jobs written in the same transaction as the state change that triggers
+-- them. The worker's dispatcher moves them into jobs, so a crash between
+-- the commit and the enqueue can no longer lose one.
+CREATE TABLE outbox (
+  outbox_id BIGSERIAL PRIMARY KEY,
+  job_type VARCHAR(50) NOT NULL,
+  lane VARCHAR(20) NOT NULL DEFAULT 'transactional',
+  payload JSONB NOT NULL DEFAULT '{}',
+  max_attempts INTEGER NOT NULL DEFAULT 5,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
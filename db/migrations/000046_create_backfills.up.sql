@@ -0,0 +1,20 @@
+-- Progress of batched data backfills. Large tables such as seats are
+-- changed in small primary key ranges by the worker instead of one UPDATE
+-- in a migration, which would lock every row bookings need for as long as
+-- it runs. last_id is the checkpoint a crashed or failed run resumes from;
+-- max_id is the highest key when the run started, since rows written later
+-- already have the new shape.
+CREATE TABLE backfills (
+  name VARCHAR(100) PRIMARY KEY,
+  status VARCHAR(20) NOT NULL DEFAULT 'RUNNING'
+    CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+  batch_size INTEGER NOT NULL CHECK (batch_size > 0),
+  last_id BIGINT NOT NULL DEFAULT 0,
+  max_id BIGINT NOT NULL DEFAULT 0,
+  rows_updated BIGINT NOT NULL DEFAULT 0,
+  batches INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT,
+  started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  completed_at TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- Per-event budget of booking requests per second during an on-sale; NULL
+-- falls back to the configured default.
+ALTER TABLE events ADD COLUMN booking_rate_limit INTEGER CHECK (booking_rate_limit > 0);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_booking_awaiting_gateway;
+ALTER TABLE booking DROP COLUMN IF EXISTS awaiting_gateway_since;
@@ -0,0 +1,5 @@
+-- Set on PENDING bookings held while the payment gateway was down, so their
+-- customers are told once payments work again.
+ALTER TABLE booking ADD COLUMN awaiting_gateway_since TIMESTAMPTZ;
+
+CREATE INDEX idx_booking_awaiting_gateway ON booking (awaiting_gateway_since) WHERE awaiting_gateway_since IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE refund DROP COLUMN IF EXISTS percent;
+ALTER TABLE events DROP COLUMN IF EXISTS refund_policy;
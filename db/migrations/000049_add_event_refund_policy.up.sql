@@ -0,0 +1,7 @@
+-- The organizer's refund policy for refunds customers ask for; NULL refunds
+-- in full.
+ALTER TABLE events ADD COLUMN refund_policy JSONB;
+
+-- Share of what was paid a refund returns, set from the policy when the
+-- refund is requested.
+ALTER TABLE refund ADD COLUMN percent SMALLINT NOT NULL DEFAULT 100 CHECK (percent BETWEEN 0 AND 100);
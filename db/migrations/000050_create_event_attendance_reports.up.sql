@@ -0,0 +1,9 @@
+-- A scheduled job writes one row per organizer event once it has ended,
+-- freezing its PAID tickets and check-ins, and emails the organizer the
+-- report. The row records that the report went out, so it is sent once.
+CREATE TABLE event_attendance_reports (
+  event_id INTEGER PRIMARY KEY REFERENCES events (event_id) ON DELETE CASCADE,
+  tickets_sold INTEGER NOT NULL,
+  checked_in INTEGER NOT NULL,
+  generated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,5 @@
+-- Users can now pick a username, so it must identify one account. Accounts
+-- registered without one stored an empty string; they have none.
+UPDATE users SET username = NULL WHERE username = '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_key ON users (LOWER(username));
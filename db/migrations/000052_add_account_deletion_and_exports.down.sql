@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_data_exports;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,19 @@
+-- Deleted accounts keep their row, so bookings, payments and invoices still
+-- point at a user, but their name, username, email and password are wiped.
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+
+-- Personal data exports. The worker builds the archive of a PENDING export
+-- and stores it here, READY to download. Only a user's latest export is
+-- kept, and none once the account is deleted.
+CREATE TABLE user_data_exports (
+  export_id BIGSERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+  status VARCHAR(20) NOT NULL DEFAULT 'PENDING'
+    CHECK (status IN ('PENDING', 'READY', 'FAILED')),
+  archive JSONB,
+  last_error TEXT,
+  requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_user_data_exports_user_id ON user_data_exports (user_id, export_id);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS user_recovery_codes;
+
+ALTER TABLE users
+  DROP COLUMN IF EXISTS totp_failed_at,
+  DROP COLUMN IF EXISTS totp_failures,
+  DROP COLUMN IF EXISTS totp_last_step,
+  DROP COLUMN IF EXISTS totp_enabled,
+  DROP COLUMN IF EXISTS totp_secret;
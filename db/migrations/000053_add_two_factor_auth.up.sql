@@ -0,0 +1,23 @@
+-- TOTP two-factor authentication. The secret is stored encrypted and is
+-- set while the user scans it, but only checked on login once a first code
+-- verified it. totp_last_step is the time step of the last code accepted,
+-- so a code can't be used twice, and wrong codes are counted so they can't
+-- be guessed.
+ALTER TABLE users
+  ADD COLUMN totp_secret TEXT,
+  ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN totp_last_step BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN totp_failures INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN totp_failed_at TIMESTAMP;
+
+-- Single-use recovery codes for users who lose their authenticator, issued
+-- when 2FA is enabled. Only their hashes are stored.
+CREATE TABLE user_recovery_codes (
+  id BIGSERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+  code_hash VARCHAR(64) NOT NULL,
+  used_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_recovery_codes_user_id ON user_recovery_codes (user_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_oauth;
+
+ALTER TABLE users
+  DROP COLUMN IF EXISTS oauth_subject,
+  DROP COLUMN IF EXISTS oauth_provider;
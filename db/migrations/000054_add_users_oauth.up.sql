@@ -0,0 +1,10 @@
+-- Accounts signed in with an identity provider such as Google. The subject
+-- is the provider's stable ID for the account, so a user changing their
+-- email at the provider keeps their account here. Accounts created this way
+-- have no password until the user sets one through a reset link.
+ALTER TABLE users
+  ADD COLUMN oauth_provider VARCHAR(20),
+  ADD COLUMN oauth_subject VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_users_oauth ON users (oauth_provider, oauth_subject)
+  WHERE oauth_subject IS NOT NULL;
@@ -0,0 +1,19 @@
+-- API keys let partner systems call the API as an organizer or admin
+-- without a user's JWT. Only the SHA-256 of the key is stored; it is shown
+-- once when the key is created. key_prefix is the start of the key, kept to
+-- tell keys apart in listings.
+CREATE TABLE api_keys (
+  key_id BIGSERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  key_prefix VARCHAR(16) NOT NULL,
+  key_hash CHAR(64) NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL,
+  created_by INTEGER NOT NULL REFERENCES users (user_id),
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP,
+  last_used_at TIMESTAMP,
+  revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_api_keys_user ON api_keys (user_id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_audit_logs_created_at;
+DROP INDEX IF EXISTS idx_audit_logs_entity;
+
+ALTER TABLE audit_logs
+  DROP COLUMN IF EXISTS api_key_id,
+  DROP COLUMN IF EXISTS request_id,
+  DROP COLUMN IF EXISTS after,
+  DROP COLUMN IF EXISTS before;
@@ -0,0 +1,11 @@
+-- State of the entity before and after the audited change, and where the
+-- change came from: the request ID shared with the logs, and the API key
+-- when the actor used one instead of a login.
+ALTER TABLE audit_logs
+  ADD COLUMN before JSONB,
+  ADD COLUMN after JSONB,
+  ADD COLUMN request_id VARCHAR(128),
+  ADD COLUMN api_key_id BIGINT;
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_id, created_at);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at);
@@ -0,0 +1,17 @@
+-- In-app inbox, filled by the worker next to the emails it sends. The
+-- message is stored untranslated with its arguments, so it is shown in the
+-- reader's language. job_id makes a retried job add its notification once.
+CREATE TABLE notifications (
+  notification_id BIGSERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+  kind VARCHAR(50) NOT NULL,
+  message TEXT NOT NULL,
+  message_args TEXT[] NOT NULL DEFAULT '{}',
+  booking_id BIGINT,
+  job_id BIGINT UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  read_at TIMESTAMP
+);
+
+CREATE INDEX idx_notifications_user ON notifications (user_id, notification_id DESC);
+CREATE INDEX idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
@@ -0,0 +1,38 @@
+-- Webhooks push booking and payment lifecycle events to an organizer's
+-- systems. A webhook without an organizer receives the events of every
+-- event. The signing secret is encrypted, as it is needed again to sign
+-- each delivery.
+CREATE TABLE webhooks (
+  webhook_id BIGSERIAL PRIMARY KEY,
+  organizer_id INTEGER REFERENCES users (user_id) ON DELETE CASCADE,
+  url TEXT NOT NULL,
+  event_types TEXT[] NOT NULL,
+  secret_encrypted TEXT NOT NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_by INTEGER NOT NULL REFERENCES users (user_id),
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhooks_organizer ON webhooks (organizer_id) WHERE active;
+
+-- One row per lifecycle event sent to a webhook, with the outcome of its
+-- last attempt. event_key names the lifecycle event, so a retried fan-out
+-- does not deliver it twice.
+CREATE TABLE webhook_deliveries (
+  delivery_id BIGSERIAL PRIMARY KEY,
+  webhook_id BIGINT NOT NULL REFERENCES webhooks (webhook_id) ON DELETE CASCADE,
+  event_key VARCHAR(100) NOT NULL,
+  event_type VARCHAR(50) NOT NULL,
+  payload JSONB NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  response_status INTEGER,
+  last_error TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  last_attempt_at TIMESTAMP,
+  delivered_at TIMESTAMP,
+  UNIQUE (webhook_id, event_key)
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries (webhook_id, delivery_id DESC);
@@ -0,0 +1,15 @@
+-- One row per issued access token, so users can see the devices they are
+-- signed in on and revoke them. Tokens carry the session ID and are only
+-- accepted while the session is neither revoked nor expired.
+CREATE TABLE user_sessions (
+  session_id BIGSERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+  user_agent TEXT NOT NULL DEFAULT '',
+  ip_address VARCHAR(45) NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP NOT NULL,
+  revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_user_sessions_user ON user_sessions (user_id, last_seen_at DESC) WHERE revoked_at IS NULL;
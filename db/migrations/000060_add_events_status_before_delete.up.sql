@@ -0,0 +1,3 @@
+-- Deleting an event cancels it; the status it had is kept so restoring the
+-- event puts it back. Events deleted before this stay cancelled on restore.
+ALTER TABLE events ADD COLUMN status_before_delete status_event;
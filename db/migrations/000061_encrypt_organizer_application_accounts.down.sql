@@ -0,0 +1,3 @@
+-- Numbers already encrypted can't be decrypted here and are lost.
+ALTER TABLE organizer_applications DROP COLUMN IF EXISTS bank_account_last4;
+ALTER TABLE organizer_applications DROP COLUMN IF EXISTS bank_account_number_enc;
@@ -0,0 +1,11 @@
+-- Bank account numbers on organizer applications are stored encrypted with
+-- the payout key, like bank_accounts, and only the last four digits are
+-- returned. The key lives in the API's config, so the API encrypts the
+-- numbers already stored when it starts and clears bank_account_number;
+-- the column is only read for rows it has not encrypted yet.
+ALTER TABLE organizer_applications ADD COLUMN bank_account_number_enc TEXT;
+ALTER TABLE organizer_applications ADD COLUMN bank_account_last4 VARCHAR(4);
+
+UPDATE organizer_applications
+SET bank_account_last4 = right(bank_account_number, 4)
+WHERE bank_account_number <> '';
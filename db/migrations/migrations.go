@@ -0,0 +1,8 @@
+// Package migrations embeds the versioned SQL migrations so the API and the
+// migrate command can apply them without the files on disk.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
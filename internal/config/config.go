@@ -1,21 +1,64 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
 	Server ServerConfig
 	DB     DatabaseConfig
 	JWT		JWTConfig
 	Cache	RedisConfig
+	Alert	AlertConfig
+	Storage	StorageConfig
+	Payout	PayoutConfig
+	Webhook	WebhookConfig
+	Payment	PaymentConfig
+	Worker	WorkerConfig
+	Email	EmailConfig
+	Tracing	TracingConfig
+	ErrorReport	ErrorReportConfig
+	Event	EventConfig
+	Booking	BookingConfig
+	Report	ReportConfig
+	Warehouse	WarehouseConfig
+	OAuth	OAuthConfig
+	Broker	BrokerConfig
 }
 
 type ServerConfig struct {
 	Port string
+	// GRPCPort is where the gRPC API for service-to-service integrations
+	// listens, next to the HTTP API on Port.
+	GRPCPort string
+	// FrontendURL is the web client's base URL, used for links in emails.
+	FrontendURL string
+	// OpenAPIValidation checks traffic against the generated API document:
+	// "off" (the default), "requests" to reject requests that do not match
+	// it, or "all" to also log responses that do not match outside
+	// production.
+	OpenAPIValidation string
+	// UsecaseTimeout bounds each usecase call. UsecaseTimeoutOverrides sets
+	// it per usecase as "name=duration,...", on top of the longer built-in
+	// timeouts of reports and admin jobs and the shorter one of status.
+	UsecaseTimeout          time.Duration
+	UsecaseTimeoutOverrides string
 }
 
 type JWTConfig struct{
 	Secret 	string
 	ExpTime int
+	// Algorithm signs access tokens with HS256 and Secret (the default) or
+	// RS256 and the RSA private key in PrivateKeyFile. PreviousKeyFile holds
+	// the key signed with before a rotation, whose tokens are still accepted.
+	Algorithm       string
+	PrivateKeyFile  string
+	PreviousKeyFile string
+	// TwoFactorKey is the base64 encoded 32 byte key TOTP secrets are
+	// encrypted with. It defaults to the payout encryption key.
+	TwoFactorKey string
 }
 
 type RedisConfig struct{
@@ -23,8 +66,197 @@ type RedisConfig struct{
 	Port	string
 	Password string
 	UseTLS	bool
+	// Required makes the API refuse to start without Redis. When false it
+	// starts anyway and runs uncached until Redis answers.
+	Required bool
+	// Timeout bounds each command. After BreakerThreshold failures in a
+	// row commands are skipped for BreakerCooldown, so requests don't wait
+	// on a Redis that is down.
+	Timeout          time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// EventTTL is how long the event list and details are cached, plus up
+	// to TTLJitter of it at random so entries don't all expire at once.
+	// With StaleWhileRevalidate set, expired entries are served that much
+	// longer while they are reloaded in the background.
+	EventTTL             time.Duration
+	TTLJitter            float64
+	StaleWhileRevalidate time.Duration
+}
+
+
+// AlertConfig configures admin activity alerts. Rules is a JSON array of
+// entity.AlertRule; the built-in rules are used when it is empty.
+// InventoryCheckInterval sets how often the oversell invariants are checked.
+type AlertConfig struct {
+	WebhookURL             string
+	Rules                  string
+	InventoryCheckInterval time.Duration
+}
+
+// StorageConfig configures where uploaded files are kept: "local" (the
+// default) keeps them under LocalDir, "s3" in an S3 bucket. BaseURL is
+// where public files such as event posters are downloaded from.
+type StorageConfig struct {
+	Driver   string
+	LocalDir string
+	BaseURL  string
+
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3SessionToken    string
 }
 
+// PayoutConfig holds the base64 encoded 32 byte key used to encrypt
+// organizer bank account numbers at rest.
+type PayoutConfig struct {
+	EncryptionKey string
+}
+
+// WebhookConfig holds the base64 encoded 32 byte key webhook signing
+// secrets are encrypted with. It defaults to the payout encryption key.
+type WebhookConfig struct {
+	EncryptionKey string
+}
+
+// PaymentConfig bounds calls to the payment gateway: each attempt gets
+// GatewayTimeout, idempotent calls are retried up to GatewayMaxRetries times
+// starting GatewayRetryBackoff apart, and after GatewayBreakerThreshold
+// failures in a row calls fail fast for GatewayBreakerCooldown.
+type PaymentConfig struct {
+	GatewayTimeout          time.Duration
+	GatewayMaxRetries       int
+	GatewayRetryBackoff     time.Duration
+	GatewayBreakerThreshold int
+	GatewayBreakerCooldown  time.Duration
+}
+
+// WorkerConfig bounds how background jobs are queued. Storing a job in the
+// outbox may take up to EnqueueTimeout. Once the outbox holds OutboxLimit
+// entries, or its oldest entry is OutboxMaxAge old, ops are alerted and
+// QueueOverflow decides what happens to new jobs: "persist" (the default)
+// stores them anyway, "drop" discards them.
+type WorkerConfig struct {
+	EnqueueTimeout time.Duration
+	OutboxLimit    int
+	OutboxMaxAge   time.Duration
+	QueueOverflow  string
+}
+
+// EmailConfig selects the mail provider: "smtp", "ses", or "log" (the
+// default, which only logs outgoing emails).
+type EmailConfig struct {
+	Provider string
+	From     string
+	// Locale picks the language of the email templates, "id" or "en"
+	Locale string
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	SESRegion          string
+	SESAccessKeyID     string
+	SESSecretAccessKey string
+	SESSessionToken    string
+}
+
+// TracingConfig configures OpenTelemetry trace export over OTLP/HTTP.
+// Tracing is off when OTLPEndpoint is empty.
+type TracingConfig struct {
+	ServiceName  string
+	OTLPEndpoint string
+	SampleRatio  float64
+}
+
+// ErrorReportConfig configures where panics are reported. Reporting is off
+// when SentryDSN is empty; Environment defaults to APP_MODE.
+type ErrorReportConfig struct {
+	SentryDSN   string
+	Environment string
+	Release     string
+}
+
+// EventConfig sets how far ahead new and rescheduled events must start.
+// MinLeadTime zero only requires a date in the future.
+// SalesGoalCheckInterval sets how often sales are checked against
+// organizers' sales goals. An event's attendance report is sent
+// AttendanceReportDelay after it starts, checked every
+// AttendanceReportInterval.
+type EventConfig struct {
+	MinLeadTime              time.Duration
+	SalesGoalCheckInterval   time.Duration
+	AttendanceReportDelay    time.Duration
+	AttendanceReportInterval time.Duration
+}
+
+// BookingConfig sets how bookings that overlap a PAID ticket to another
+// event are handled: ConflictMode is "warn" (the default), "block" or "off".
+// Events starting less than ConflictWindow apart count as overlapping.
+// PaymentLinkSecret signs the payment links support sends to customers and
+// defaults to the JWT secret. HoldReleaseInterval sets how often unpaid
+// bookings past their deadline are released. MaxTicketsPerUser caps the
+// tickets one user may hold per event unless the event sets its own cap;
+// zero means no cap. EventRateLimit is how many booking requests per second
+// an event takes unless it sets its own budget; zero means no budget.
+// QueueTokenSecret signs the waiting room's queue tokens and defaults to the
+// JWT secret. GatewayOutageHold is how long bookings are held while the
+// payment gateway is down; zero disables it. GatewayRecoveryInterval sets how
+// often the gateway is checked for the customers to be told it is back.
+type BookingConfig struct {
+	ConflictMode            string
+	ConflictWindow          time.Duration
+	PaymentLinkSecret       string
+	HoldReleaseInterval     time.Duration
+	MaxTicketsPerUser       int
+	EventRateLimit          int
+	QueueTokenSecret        string
+	GatewayOutageHold       time.Duration
+	GatewayRecoveryInterval time.Duration
+}
+
+// ReportConfig sets how long computed admin reports are cached and how
+// often the default reports are recomputed in the background.
+type ReportConfig struct {
+	CacheTTL        time.Duration
+	RefreshInterval time.Duration
+}
+
+// WarehouseConfig sets up the export of bookings, transactions, refunds and
+// audit logs to BI destinations. Destinations is a JSON array; empty
+// disables exporting. Each run ships up to BatchSize rows at a time and
+// skips changes younger than Lag.
+type WarehouseConfig struct {
+	Destinations string
+	Interval     time.Duration
+	BatchSize    int
+	Lag          time.Duration
+}
+
+// OAuthConfig holds the OAuth client registered with Google. Sign-in with
+// Google is off unless GoogleClientID is set; GoogleRedirectURL must be
+// the public URL of the callback route, as registered with Google.
+type OAuthConfig struct {
+	GoogleClientID     string
+	GoogleClientSecret string
+	GoogleRedirectURL  string
+}
+
+// BrokerConfig selects the message broker booking, payment and event
+// lifecycle events are published to: "none" (the default), "nats" or
+// "kafka". URL is the NATS server or the Kafka REST Proxy. Topics are named
+// TopicPrefix.booking, TopicPrefix.payment and TopicPrefix.event.
+type BrokerConfig struct {
+	Driver      string
+	URL         string
+	Username    string
+	Password    string
+	TopicPrefix string
+}
 
 type DatabaseConfig struct {
 	Host     string
@@ -33,6 +265,20 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// AutoMigrate applies pending embedded migrations when the API starts.
+	AutoMigrate bool
+	// Pool settings, shared by the primary and the replica. StatementTimeout
+	// makes the server cancel any query running longer; zero leaves it off.
+	MaxConns         int
+	MinConns         int
+	MaxConnLifetime  time.Duration
+	MaxConnIdleTime  time.Duration
+	ConnectTimeout   time.Duration
+	StatementTimeout time.Duration
+	// ReplicaDSN points at a read-only replica that serves search, seat
+	// pages and reports. Without it, or when it can't be reached at
+	// startup, those reads go to the primary.
+	ReplicaDSN string
 }
 
 // LoadConfig membaca file .env dan memasukkannya ke struct Config
@@ -47,6 +293,23 @@ func LoadConfig() (*Config, error) {
 	
 	// Mapping manual agar lebih aman
 	cfg.Server.Port = viper.GetString("PORT")
+	cfg.Server.GRPCPort = viper.GetString("GRPC_PORT")
+	if cfg.Server.GRPCPort == "" {
+		cfg.Server.GRPCPort = "9090"
+	}
+	cfg.Server.FrontendURL = viper.GetString("FRONTEND_URL")
+	if cfg.Server.FrontendURL == "" {
+		cfg.Server.FrontendURL = "http://localhost:3000"
+	}
+	cfg.Server.OpenAPIValidation = viper.GetString("OPENAPI_VALIDATION")
+	if cfg.Server.OpenAPIValidation == "" {
+		cfg.Server.OpenAPIValidation = "off"
+	}
+	cfg.Server.UsecaseTimeout = viper.GetDuration("USECASE_TIMEOUT")
+	if cfg.Server.UsecaseTimeout <= 0 {
+		cfg.Server.UsecaseTimeout = 5 * time.Second
+	}
+	cfg.Server.UsecaseTimeoutOverrides = viper.GetString("USECASE_TIMEOUT_OVERRIDES")
 	cfg.DB.Host = viper.GetString("DB_HOST")
 	cfg.DB.Port = viper.GetString("DB_PORT")
 	cfg.DB.User = viper.GetString("DB_USER")
@@ -54,15 +317,279 @@ func LoadConfig() (*Config, error) {
 	cfg.DB.Name = viper.GetString("DB_NAME")
 	cfg.JWT.Secret = viper.GetString("JWT_SECRET")
 	cfg.JWT.ExpTime = viper.GetInt("JWT_EXP_TIME")
+	cfg.JWT.Algorithm = viper.GetString("JWT_ALGORITHM")
+	if cfg.JWT.Algorithm == "" {
+		cfg.JWT.Algorithm = "HS256"
+	}
+	cfg.JWT.PrivateKeyFile = viper.GetString("JWT_PRIVATE_KEY_FILE")
+	cfg.JWT.PreviousKeyFile = viper.GetString("JWT_PREVIOUS_KEY_FILE")
 	cfg.Cache.Host = viper.GetString("CACHE_HOST")
 	cfg.Cache.Password = viper.GetString("CACHE_PASSWORD")
 	cfg.Cache.Port = viper.GetString("CACHE_PORT")
 	cfg.Cache.UseTLS = viper.GetBool("CACHE_TLS")
+	cfg.Cache.Required = true
+	if viper.IsSet("CACHE_REQUIRED") {
+		cfg.Cache.Required = viper.GetBool("CACHE_REQUIRED")
+	}
+	cfg.Cache.Timeout = viper.GetDuration("CACHE_TIMEOUT")
+	if cfg.Cache.Timeout <= 0 {
+		cfg.Cache.Timeout = time.Second
+	}
+	cfg.Cache.BreakerThreshold = viper.GetInt("CACHE_BREAKER_THRESHOLD")
+	if cfg.Cache.BreakerThreshold <= 0 {
+		cfg.Cache.BreakerThreshold = 5
+	}
+	cfg.Cache.BreakerCooldown = viper.GetDuration("CACHE_BREAKER_COOLDOWN")
+	if cfg.Cache.BreakerCooldown <= 0 {
+		cfg.Cache.BreakerCooldown = 10 * time.Second
+	}
+	cfg.Cache.EventTTL = viper.GetDuration("CACHE_EVENT_TTL")
+	if cfg.Cache.EventTTL <= 0 {
+		cfg.Cache.EventTTL = 10 * time.Minute
+	}
+	cfg.Cache.TTLJitter = 0.1
+	if viper.IsSet("CACHE_TTL_JITTER") {
+		cfg.Cache.TTLJitter = viper.GetFloat64("CACHE_TTL_JITTER")
+	}
+	cfg.Cache.StaleWhileRevalidate = viper.GetDuration("CACHE_STALE_WHILE_REVALIDATE")
+	cfg.Alert.WebhookURL = viper.GetString("ALERT_WEBHOOK_URL")
+	cfg.Alert.Rules = viper.GetString("ALERT_RULES")
+	cfg.Alert.InventoryCheckInterval = viper.GetDuration("INVENTORY_CHECK_INTERVAL")
+	if cfg.Alert.InventoryCheckInterval <= 0 {
+		cfg.Alert.InventoryCheckInterval = 30 * time.Second
+	}
+
+	cfg.Storage.LocalDir = viper.GetString("STORAGE_LOCAL_DIR")
+	if cfg.Storage.LocalDir == "" {
+		cfg.Storage.LocalDir = "uploads"
+	}
+	cfg.Storage.Driver = viper.GetString("STORAGE_DRIVER")
+	if cfg.Storage.Driver == "" {
+		cfg.Storage.Driver = "local"
+	}
+	cfg.Storage.BaseURL = viper.GetString("STORAGE_BASE_URL")
+	if cfg.Storage.BaseURL == "" && cfg.Storage.Driver == "local" {
+		cfg.Storage.BaseURL = "/uploads"
+	}
+	cfg.Storage.S3Endpoint = viper.GetString("S3_ENDPOINT")
+	cfg.Storage.S3Region = viper.GetString("AWS_REGION")
+	cfg.Storage.S3Bucket = viper.GetString("S3_BUCKET")
+	cfg.Storage.S3AccessKeyID = viper.GetString("AWS_ACCESS_KEY_ID")
+	cfg.Storage.S3SecretAccessKey = viper.GetString("AWS_SECRET_ACCESS_KEY")
+	cfg.Storage.S3SessionToken = viper.GetString("AWS_SESSION_TOKEN")
+
+	cfg.Payout.EncryptionKey = viper.GetString("PAYOUT_ENCRYPTION_KEY")
+	cfg.JWT.TwoFactorKey = viper.GetString("TWO_FACTOR_ENCRYPTION_KEY")
+	if cfg.JWT.TwoFactorKey == "" {
+		cfg.JWT.TwoFactorKey = cfg.Payout.EncryptionKey
+	}
+	cfg.Webhook.EncryptionKey = viper.GetString("WEBHOOK_ENCRYPTION_KEY")
+	if cfg.Webhook.EncryptionKey == "" {
+		cfg.Webhook.EncryptionKey = cfg.Payout.EncryptionKey
+	}
+
+	cfg.Payment.GatewayTimeout = viper.GetDuration("PAYMENT_GATEWAY_TIMEOUT")
+	if cfg.Payment.GatewayTimeout <= 0 {
+		cfg.Payment.GatewayTimeout = 2 * time.Second
+	}
+	cfg.Payment.GatewayMaxRetries = 2
+	if viper.IsSet("PAYMENT_GATEWAY_MAX_RETRIES") {
+		cfg.Payment.GatewayMaxRetries = viper.GetInt("PAYMENT_GATEWAY_MAX_RETRIES")
+	}
+	cfg.Payment.GatewayRetryBackoff = viper.GetDuration("PAYMENT_GATEWAY_RETRY_BACKOFF")
+	if cfg.Payment.GatewayRetryBackoff <= 0 {
+		cfg.Payment.GatewayRetryBackoff = 200 * time.Millisecond
+	}
+	cfg.Payment.GatewayBreakerThreshold = viper.GetInt("PAYMENT_GATEWAY_BREAKER_THRESHOLD")
+	if cfg.Payment.GatewayBreakerThreshold <= 0 {
+		cfg.Payment.GatewayBreakerThreshold = 5
+	}
+	cfg.Payment.GatewayBreakerCooldown = viper.GetDuration("PAYMENT_GATEWAY_BREAKER_COOLDOWN")
+	if cfg.Payment.GatewayBreakerCooldown <= 0 {
+		cfg.Payment.GatewayBreakerCooldown = 30 * time.Second
+	}
+
+	cfg.Worker.EnqueueTimeout = viper.GetDuration("WORKER_ENQUEUE_TIMEOUT")
+	if cfg.Worker.EnqueueTimeout <= 0 {
+		cfg.Worker.EnqueueTimeout = time.Second
+	}
+	cfg.Worker.OutboxLimit = viper.GetInt("WORKER_OUTBOX_LIMIT")
+	if cfg.Worker.OutboxLimit <= 0 {
+		cfg.Worker.OutboxLimit = 1000
+	}
+	cfg.Worker.OutboxMaxAge = viper.GetDuration("WORKER_OUTBOX_MAX_AGE")
+	if cfg.Worker.OutboxMaxAge <= 0 {
+		cfg.Worker.OutboxMaxAge = time.Minute
+	}
+	cfg.Worker.QueueOverflow = viper.GetString("WORKER_QUEUE_OVERFLOW")
+	if cfg.Worker.QueueOverflow == "" {
+		cfg.Worker.QueueOverflow = "persist"
+	}
+
+	cfg.Email.Provider = viper.GetString("EMAIL_PROVIDER")
+	if cfg.Email.Provider == "" {
+		cfg.Email.Provider = "log"
+	}
+	cfg.Email.From = viper.GetString("EMAIL_FROM")
+	if cfg.Email.From == "" {
+		cfg.Email.From = "TicRes <no-reply@ticres.local>"
+	}
+	cfg.Email.Locale = viper.GetString("EMAIL_LOCALE")
+	if cfg.Email.Locale == "" {
+		cfg.Email.Locale = "id"
+	}
+	cfg.Email.SMTPHost = viper.GetString("SMTP_HOST")
+	cfg.Email.SMTPPort = viper.GetString("SMTP_PORT")
+	if cfg.Email.SMTPPort == "" {
+		cfg.Email.SMTPPort = "587"
+	}
+	cfg.Email.SMTPUsername = viper.GetString("SMTP_USERNAME")
+	cfg.Email.SMTPPassword = viper.GetString("SMTP_PASSWORD")
+	cfg.Email.SESRegion = viper.GetString("AWS_REGION")
+	cfg.Email.SESAccessKeyID = viper.GetString("AWS_ACCESS_KEY_ID")
+	cfg.Email.SESSecretAccessKey = viper.GetString("AWS_SECRET_ACCESS_KEY")
+	cfg.Email.SESSessionToken = viper.GetString("AWS_SESSION_TOKEN")
+
+	cfg.Tracing.ServiceName = viper.GetString("OTEL_SERVICE_NAME")
+	if cfg.Tracing.ServiceName == "" {
+		cfg.Tracing.ServiceName = "ticres-api"
+	}
+	cfg.Tracing.OTLPEndpoint = viper.GetString("OTEL_EXPORTER_OTLP_ENDPOINT")
+	cfg.Tracing.SampleRatio = 1
+	if viper.IsSet("OTEL_TRACES_SAMPLER_ARG") {
+		cfg.Tracing.SampleRatio = viper.GetFloat64("OTEL_TRACES_SAMPLER_ARG")
+	}
+
+	cfg.ErrorReport.SentryDSN = viper.GetString("SENTRY_DSN")
+	cfg.ErrorReport.Environment = viper.GetString("SENTRY_ENVIRONMENT")
+	if cfg.ErrorReport.Environment == "" {
+		cfg.ErrorReport.Environment = viper.GetString("APP_MODE")
+	}
+	if cfg.ErrorReport.Environment == "" {
+		cfg.ErrorReport.Environment = "development"
+	}
+	cfg.ErrorReport.Release = viper.GetString("SENTRY_RELEASE")
 
 	cfg.DB.SSLMode = viper.GetString("SSL_MODE")
 	if cfg.DB.SSLMode == "" {
 		cfg.DB.SSLMode = "disable"
 	}
+	cfg.DB.AutoMigrate = viper.GetBool("DB_AUTO_MIGRATE")
+	cfg.DB.ReplicaDSN = viper.GetString("DB_REPLICA_DSN")
+	cfg.DB.MaxConns = viper.GetInt("DB_MAX_CONNS")
+	if cfg.DB.MaxConns <= 0 {
+		cfg.DB.MaxConns = 10
+	}
+	cfg.DB.MinConns = viper.GetInt("DB_MIN_CONNS")
+	if cfg.DB.MinConns <= 0 {
+		cfg.DB.MinConns = 2
+	}
+	cfg.DB.MaxConnLifetime = viper.GetDuration("DB_MAX_CONN_LIFETIME")
+	if cfg.DB.MaxConnLifetime <= 0 {
+		cfg.DB.MaxConnLifetime = time.Hour
+	}
+	cfg.DB.MaxConnIdleTime = viper.GetDuration("DB_MAX_CONN_IDLE_TIME")
+	if cfg.DB.MaxConnIdleTime <= 0 {
+		cfg.DB.MaxConnIdleTime = 30 * time.Minute
+	}
+	cfg.DB.ConnectTimeout = viper.GetDuration("DB_CONNECT_TIMEOUT")
+	if cfg.DB.ConnectTimeout <= 0 {
+		cfg.DB.ConnectTimeout = 5 * time.Second
+	}
+	cfg.DB.StatementTimeout = viper.GetDuration("DB_STATEMENT_TIMEOUT")
+
+	cfg.Event.MinLeadTime = viper.GetDuration("EVENT_MIN_LEAD_TIME")
+	if cfg.Event.MinLeadTime < 0 {
+		cfg.Event.MinLeadTime = 0
+	}
+	cfg.Event.SalesGoalCheckInterval = viper.GetDuration("SALES_GOAL_CHECK_INTERVAL")
+	if cfg.Event.SalesGoalCheckInterval <= 0 {
+		cfg.Event.SalesGoalCheckInterval = 15 * time.Minute
+	}
+	cfg.Event.AttendanceReportDelay = viper.GetDuration("ATTENDANCE_REPORT_DELAY")
+	if cfg.Event.AttendanceReportDelay <= 0 {
+		cfg.Event.AttendanceReportDelay = 6 * time.Hour
+	}
+	cfg.Event.AttendanceReportInterval = viper.GetDuration("ATTENDANCE_REPORT_INTERVAL")
+	if cfg.Event.AttendanceReportInterval <= 0 {
+		cfg.Event.AttendanceReportInterval = 15 * time.Minute
+	}
+
+	cfg.Booking.ConflictMode = viper.GetString("BOOKING_CONFLICT_MODE")
+	if cfg.Booking.ConflictMode == "" {
+		cfg.Booking.ConflictMode = "warn"
+	}
+	cfg.Booking.ConflictWindow = viper.GetDuration("BOOKING_CONFLICT_WINDOW")
+	if cfg.Booking.ConflictWindow <= 0 {
+		cfg.Booking.ConflictWindow = 4 * time.Hour
+	}
+	cfg.Booking.PaymentLinkSecret = viper.GetString("PAYMENT_LINK_SECRET")
+	if cfg.Booking.PaymentLinkSecret == "" {
+		cfg.Booking.PaymentLinkSecret = cfg.JWT.Secret
+	}
+	cfg.Booking.MaxTicketsPerUser = viper.GetInt("BOOKING_MAX_TICKETS_PER_USER")
+	cfg.Booking.EventRateLimit = 100
+	if viper.IsSet("BOOKING_EVENT_RATE_LIMIT") {
+		cfg.Booking.EventRateLimit = viper.GetInt("BOOKING_EVENT_RATE_LIMIT")
+	}
+	cfg.Booking.QueueTokenSecret = viper.GetString("QUEUE_TOKEN_SECRET")
+	if cfg.Booking.QueueTokenSecret == "" {
+		cfg.Booking.QueueTokenSecret = cfg.JWT.Secret
+	}
+	cfg.Booking.HoldReleaseInterval = viper.GetDuration("HOLD_RELEASE_INTERVAL")
+	if cfg.Booking.HoldReleaseInterval <= 0 {
+		cfg.Booking.HoldReleaseInterval = time.Minute
+	}
+	cfg.Booking.GatewayOutageHold = time.Hour
+	if viper.IsSet("BOOKING_GATEWAY_OUTAGE_HOLD") {
+		cfg.Booking.GatewayOutageHold = viper.GetDuration("BOOKING_GATEWAY_OUTAGE_HOLD")
+	}
+	cfg.Booking.GatewayRecoveryInterval = viper.GetDuration("GATEWAY_RECOVERY_INTERVAL")
+	if cfg.Booking.GatewayRecoveryInterval <= 0 {
+		cfg.Booking.GatewayRecoveryInterval = 30 * time.Second
+	}
+
+	cfg.Report.CacheTTL = viper.GetDuration("REPORT_CACHE_TTL")
+	if cfg.Report.CacheTTL <= 0 {
+		cfg.Report.CacheTTL = 15 * time.Minute
+	}
+	cfg.Report.RefreshInterval = viper.GetDuration("REPORT_REFRESH_INTERVAL")
+	if cfg.Report.RefreshInterval <= 0 {
+		cfg.Report.RefreshInterval = 10 * time.Minute
+	}
+
+	cfg.Warehouse.Destinations = viper.GetString("WAREHOUSE_DESTINATIONS")
+	cfg.Warehouse.Interval = viper.GetDuration("WAREHOUSE_EXPORT_INTERVAL")
+	if cfg.Warehouse.Interval <= 0 {
+		cfg.Warehouse.Interval = 5 * time.Minute
+	}
+	cfg.Warehouse.BatchSize = viper.GetInt("WAREHOUSE_EXPORT_BATCH_SIZE")
+	if cfg.Warehouse.BatchSize <= 0 {
+		cfg.Warehouse.BatchSize = 5000
+	}
+	cfg.Warehouse.Lag = viper.GetDuration("WAREHOUSE_EXPORT_LAG")
+	if cfg.Warehouse.Lag <= 0 {
+		cfg.Warehouse.Lag = time.Minute
+	}
+
+	cfg.OAuth.GoogleClientID = viper.GetString("GOOGLE_CLIENT_ID")
+	cfg.OAuth.GoogleClientSecret = viper.GetString("GOOGLE_CLIENT_SECRET")
+	cfg.OAuth.GoogleRedirectURL = viper.GetString("GOOGLE_REDIRECT_URL")
+	if cfg.OAuth.GoogleRedirectURL == "" {
+		cfg.OAuth.GoogleRedirectURL = "http://localhost:" + cfg.Server.Port + "/api/v1/auth/google/callback"
+	}
+
+	cfg.Broker.Driver = viper.GetString("EVENT_BROKER")
+	if cfg.Broker.Driver == "" {
+		cfg.Broker.Driver = "none"
+	}
+	cfg.Broker.URL = viper.GetString("EVENT_BROKER_URL")
+	cfg.Broker.Username = viper.GetString("EVENT_BROKER_USERNAME")
+	cfg.Broker.Password = viper.GetString("EVENT_BROKER_PASSWORD")
+	cfg.Broker.TopicPrefix = viper.GetString("EVENT_BROKER_TOPIC_PREFIX")
+	if cfg.Broker.TopicPrefix == "" {
+		cfg.Broker.TopicPrefix = "ticres"
+	}
 
 	return &cfg, nil
 }
\ No newline at end of file
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"ticres/internal/delivery/grpc/pb"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/jwtkeys"
+	"ticres/pkg/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// publicMethods can be called without a bearer token.
+var publicMethods = map[string]bool{
+	pb.EventService_ListEvents_FullMethodName:          true,
+	pb.EventService_GetSeatAvailability_FullMethodName: true,
+}
+
+// authInterceptor checks the "authorization: Bearer <token>" metadata and
+// its session the same way the HTTP AuthMiddleware checks the header, and
+// puts the user ID in the context as the usecase actor.
+func authInterceptor(keys *jwtkeys.KeySet, userUC usecase.UserUsecase) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		var authHeader string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				authHeader = values[0]
+			}
+		}
+		if authHeader == "" {
+			return nil, status.Error(codes.Unauthenticated, "Authorization header is required")
+		}
+
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			return nil, status.Error(codes.Unauthenticated, "Invalid authorization format")
+		}
+
+		claims, err := keys.Parse(parts[1])
+		if err != nil {
+			logger.FromContext(ctx).Warn("grpc: invalid or expired token",
+				logger.String("method", info.FullMethod),
+				logger.Err(err),
+			)
+			return nil, status.Error(codes.Unauthenticated, "Invalid or expired token")
+		}
+		userID, ok := claims["user_id"].(float64)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "Invalid token claims")
+		}
+		if sid, ok := claims["sid"].(float64); ok {
+			if err := userUC.AuthenticateSession(ctx, int64(userID), int64(sid)); err != nil {
+				if errors.Is(err, entity.ErrSessionRevoked) {
+					return nil, status.Error(codes.Unauthenticated, "Session has been revoked, please log in again")
+				}
+				logger.FromContext(ctx).Error("grpc: session check failed", logger.Err(err))
+				return nil, status.Error(codes.Internal, "Failed to check session")
+			}
+		}
+
+		return handler(usecase.WithActor(ctx, int64(userID)), req)
+	}
+}
+
+// authUserID returns the authenticated caller set by authInterceptor.
+func authUserID(ctx context.Context) (int64, error) {
+	actor := usecase.ActorFromContext(ctx)
+	if actor == nil {
+		return 0, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+	return *actor, nil
+}
@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"ticres/internal/delivery/grpc/pb"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// queueTokenKey is the metadata key carrying the caller's queue token from
+// the waiting room, like the X-Queue-Token header of the HTTP API.
+const queueTokenKey = "x-queue-token"
+
+type BookingServer struct {
+	pb.UnimplementedBookingServiceServer
+	bookingUC usecase.BookingUsecase
+	funnelUC  usecase.FunnelUsecase
+}
+
+func NewBookingServer(uc usecase.BookingUsecase, funnelUC usecase.FunnelUsecase) *BookingServer {
+	return &BookingServer{bookingUC: uc, funnelUC: funnelUC}
+}
+
+func (s *BookingServer) CreateBooking(ctx context.Context, req *pb.CreateBookingRequest) (*pb.CreateBookingResponse, error) {
+	userID, err := authUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var queueToken string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if tokens := md.Get(queueTokenKey); len(tokens) > 0 {
+			queueToken = tokens[0]
+		}
+	}
+	if err := s.funnelUC.Admit(ctx, userID, req.GetEventId(), queueToken); err != nil {
+		// Sent as a header so callers can back off like HTTP clients do
+		var busy *entity.EventBusyError
+		if errors.As(err, &busy) {
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(busy.RetryAfterSeconds())))
+		}
+		return nil, statusError(ctx, err, "Event not found")
+	}
+
+	result, err := s.bookingUC.BookSeats(ctx, userID, req.GetEventId(), req.GetSeatIds(), int(req.GetQuantity()), entity.SeatPreference{})
+	if err != nil {
+		return nil, statusError(ctx, err, "Event not found")
+	}
+
+	logger.FromContext(ctx).Info("grpc: booking created",
+		logger.Int64("user_id", userID),
+		logger.Int64("booking_id", result.BookingID),
+		logger.Int64("event_id", result.EventID),
+	)
+	resp := &pb.CreateBookingResponse{Booking: toBooking(result)}
+	if result.Warning != nil {
+		resp.Warning = result.Warning.Message
+	}
+	return resp, nil
+}
+
+func toBooking(b *entity.BookingWithPayment) *pb.Booking {
+	booking := &pb.Booking{
+		BookingId:     b.BookingID,
+		EventId:       b.EventID,
+		Status:        string(b.Status),
+		TotalAmount:   b.TotalAmount,
+		CustomerEmail: b.CustomerEmail,
+	}
+	if b.ExpiresAt != nil {
+		booking.ExpiresAt = timestamppb.New(*b.ExpiresAt)
+	}
+	if txn := b.Transaction; txn != nil {
+		booking.Transaction = &pb.Transaction{
+			PaymentId:       txn.ID,
+			Amount:          txn.Amount,
+			PaymentMethod:   txn.PaymentMethod,
+			ExternalId:      txn.ExternalID,
+			Status:          string(txn.Status),
+			TransactionDate: timestamppb.New(txn.TransactionDate),
+			InvoiceNumber:   txn.InvoiceNumber,
+		}
+	}
+	return booking
+}
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+
+	"ticres/internal/delivery/grpc/pb"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultEventPageLimit = 10
+	maxEventPageLimit     = 100
+
+	// Same as GET /events/:id/seats
+	defaultSeatPageLimit = 500
+	maxSeatPageLimit     = 1000
+)
+
+type EventServer struct {
+	pb.UnimplementedEventServiceServer
+	eventUsecase usecase.EventUsecase
+}
+
+func NewEventServer(uc usecase.EventUsecase) *EventServer {
+	return &EventServer{eventUsecase: uc}
+}
+
+func (s *EventServer) ListEvents(ctx context.Context, req *pb.ListEventsRequest) (*pb.ListEventsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit < 1 || limit > maxEventPageLimit {
+		limit = defaultEventPageLimit
+	}
+	filter := entity.EventFilter{
+		Query:    req.GetQuery(),
+		Location: req.GetLocation(),
+		Category: req.GetCategory(),
+		Status:   entity.EventStatus(req.GetStatus()),
+	}
+
+	page, err := s.eventUsecase.ListEventsByCursor(ctx, filter, req.GetCursor(), limit)
+	if err != nil {
+		return nil, statusError(ctx, err, "Event not found")
+	}
+
+	resp := &pb.ListEventsResponse{
+		Events:     make([]*pb.Event, 0, len(page.Events)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	for i := range page.Events {
+		resp.Events = append(resp.Events, toEvent(&page.Events[i]))
+	}
+	return resp, nil
+}
+
+func (s *EventServer) GetSeatAvailability(ctx context.Context, req *pb.GetSeatAvailabilityRequest) (*pb.GetSeatAvailabilityResponse, error) {
+	limit := int(req.GetLimit())
+	if limit < 1 || limit > maxSeatPageLimit {
+		limit = defaultSeatPageLimit
+	}
+	if req.GetCursor() < 0 {
+		return nil, statusError(ctx, entity.ErrInvalidCursor, "")
+	}
+	filter := entity.SeatFilter{
+		AvailableOnly: req.GetAvailableOnly(),
+		Section:       req.GetSection(),
+	}
+
+	page, err := s.eventUsecase.ListSeats(ctx, req.GetEventId(), req.GetCursor(), limit, filter)
+	if err != nil {
+		return nil, statusError(ctx, err, "Event not found")
+	}
+
+	resp := &pb.GetSeatAvailabilityResponse{
+		Seats:      make([]*pb.Seat, 0, len(page.Seats)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	for _, seat := range page.Seats {
+		resp.Seats = append(resp.Seats, &pb.Seat{
+			SeatId:     seat.ID,
+			SeatNumber: seat.SeatNumber,
+			Category:   seat.Category,
+			Price:      seat.Price,
+			IsBooked:   seat.IsBooked,
+		})
+	}
+	return resp, nil
+}
+
+func toEvent(e *entity.Event) *pb.Event {
+	return &pb.Event{
+		EventId:       e.ID,
+		Name:          e.Name,
+		Location:      e.Location,
+		Category:      e.Category,
+		Date:          timestamppb.New(e.Date),
+		Capacity:      int32(e.Capacity),
+		AdmissionMode: e.AdmissionMode,
+		GeneralPrice:  e.GeneralPrice,
+		Description:   e.Description,
+		ImageUrl:      e.ImageURL,
+	}
+}
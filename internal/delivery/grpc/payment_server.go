@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"context"
+
+	"ticres/internal/delivery/grpc/pb"
+	"ticres/internal/usecase"
+)
+
+type PaymentServer struct {
+	pb.UnimplementedPaymentServiceServer
+	paymentUC usecase.PaymentUsecase
+}
+
+func NewPaymentServer(uc usecase.PaymentUsecase) *PaymentServer {
+	return &PaymentServer{paymentUC: uc}
+}
+
+// GetPaymentStatus returns the booking with its payment. Bookings of other
+// users are PermissionDenied.
+func (s *PaymentServer) GetPaymentStatus(ctx context.Context, req *pb.GetPaymentStatusRequest) (*pb.GetPaymentStatusResponse, error) {
+	userID, err := authUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.paymentUC.GetPaymentStatus(ctx, req.GetBookingId(), userID)
+	if err != nil {
+		return nil, statusError(ctx, err, "Booking not found")
+	}
+
+	return &pb.GetPaymentStatusResponse{Booking: toBooking(result)}, nil
+}
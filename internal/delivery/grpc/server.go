@@ -0,0 +1,128 @@
+// Package grpc serves the gRPC API for service-to-service integrations. It
+// shares the usecases behind the HTTP API; the service definitions are in
+// proto/ticres/v1/ticres.proto and the generated code in package pb.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"runtime/debug"
+	"time"
+
+	"ticres/internal/delivery/grpc/pb"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/jwtkeys"
+	"ticres/pkg/logger"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDKey is the metadata key carrying the caller's request ID, the
+// gRPC counterpart of the X-Request-ID header.
+const requestIDKey = "x-request-id"
+
+// Incoming IDs end up in every log line, so only accept short, plain tokens
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// NewServer returns a gRPC server with the event, booking and payment
+// services registered. Calls to methods other than those in publicMethods
+// need a bearer token issued by the HTTP API's login.
+func NewServer(jwtKeys *jwtkeys.KeySet, userUC usecase.UserUsecase, eventUC usecase.EventUsecase, bookingUC usecase.BookingUsecase, funnelUC usecase.FunnelUsecase, paymentUC usecase.PaymentUsecase) *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		requestIDInterceptor,
+		recoveryInterceptor,
+		loggingInterceptor,
+		authInterceptor(jwtKeys, userUC),
+	))
+
+	pb.RegisterEventServiceServer(srv, NewEventServer(eventUC))
+	pb.RegisterBookingServiceServer(srv, NewBookingServer(bookingUC, funnelUC))
+	pb.RegisterPaymentServiceServer(srv, NewPaymentServer(paymentUC))
+	return srv
+}
+
+// requestIDInterceptor reuses the caller's request ID when it is sane and
+// generates a UUID otherwise, so logger.FromContext tags the call's log
+// lines like an HTTP request's. The ID is echoed in the response header.
+func requestIDInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDKey); len(ids) > 0 {
+			requestID = ids[0]
+		}
+	}
+	if !validRequestID.MatchString(requestID) {
+		requestID = uuid.NewString()
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID))
+	return handler(logger.ContextWithRequestID(ctx, requestID), req)
+}
+
+// recoveryInterceptor turns a panic in a handler into an Internal error
+// instead of taking the whole server down.
+func recoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.FromContext(ctx).Error("grpc: panic recovered",
+				logger.String("method", info.FullMethod),
+				logger.Any("panic", r),
+				logger.String("stack", string(debug.Stack())),
+			)
+			err = status.Error(codes.Internal, "Internal server error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func loggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	logger.FromContext(ctx).Info("grpc: call handled",
+		logger.String("method", info.FullMethod),
+		logger.String("code", status.Code(err).String()),
+		logger.Int64("duration_ms", time.Since(start).Milliseconds()),
+	)
+	return resp, err
+}
+
+// statusError maps the usecase errors callers can act on to gRPC codes.
+// Anything else is logged and reported as Internal without its details.
+func statusError(ctx context.Context, err error, notFound string) error {
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		return status.Error(codes.NotFound, notFound)
+	case errors.Is(err, entity.ErrUnauthorized):
+		return status.Error(codes.PermissionDenied, "You don't have access to this booking")
+	case errors.Is(err, entity.ErrInvalidEventFilter), errors.Is(err, entity.ErrInvalidCursor),
+		errors.Is(err, entity.ErrInvalidBookingRequest), errors.Is(err, entity.ErrNotGeneralAdmission):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, entity.ErrSoldOut):
+		return status.Error(codes.ResourceExhausted, "Not enough tickets left")
+	case errors.Is(err, entity.ErrSalesWaveSoldOut):
+		return status.Error(codes.ResourceExhausted, "Tickets released so far are sold out; more go on sale in the next wave")
+	case errors.Is(err, entity.ErrTicketLimitExceeded):
+		return status.Error(codes.FailedPrecondition, "You have reached the ticket limit per user for this event")
+	case errors.Is(err, entity.ErrBookingConflict):
+		return status.Error(codes.FailedPrecondition, "You already have a ticket to another event at a nearby time")
+	case errors.Is(err, entity.ErrSeatUnavailable):
+		return status.Error(codes.Aborted, "One of the selected seats is no longer available")
+	case errors.Is(err, entity.ErrNoAdjacentSeats):
+		return status.Error(codes.ResourceExhausted, "Not enough adjacent seats available")
+	case errors.Is(err, entity.ErrEventBusy):
+		return status.Error(codes.ResourceExhausted, "This event is taking too many booking requests, please try again shortly")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "Request timed out")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "Request cancelled")
+	}
+	logger.FromContext(ctx).Error("grpc: request failed", logger.Err(err))
+	return status.Error(codes.Internal, "Internal server error")
+}
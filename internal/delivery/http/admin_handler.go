@@ -1,39 +1,52 @@
 package http
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
 	"ticres/internal/usecase"
 	"ticres/pkg/logger"
+	"ticres/pkg/xlsx"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AdminHandler struct {
 	bookingUsecase usecase.BookingUsecase
+	userUsecase    usecase.UserUsecase
 }
 
-func NewAdminHandler(bookingUsecase usecase.BookingUsecase) *AdminHandler {
-	return &AdminHandler{bookingUsecase: bookingUsecase}
+func NewAdminHandler(bookingUsecase usecase.BookingUsecase, userUsecase usecase.UserUsecase) *AdminHandler {
+	return &AdminHandler{bookingUsecase: bookingUsecase, userUsecase: userUsecase}
 }
 
 // GetAllBookings godoc
 // @Summary      Get all bookings (Admin)
 // @Description  Retrieve a paginated list of all bookings across all events with filtering and sorting options. Admin access required.
+// @Description  Passing `cursor` switches from page numbers to cursor paging, which stays fast on deep pages and does not skip or repeat bookings made meanwhile. Start with an empty cursor, then pass meta.next_cursor from the previous response with the same status and sort; it is empty on the last page. Cursor pages have no total.
 // @Tags         admin
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        status query string false "Filter by booking status (e.g., pending, confirmed, cancelled, expired)"
+// @Param        status query string false "Filter by booking status, case-insensitive: PENDING, PAID, CANCELLED, EXPIRED or REFUNDED"
 // @Param        sort query string false "Sort field" default(created_at) Enums(created_at, updated_at, total_price)
 // @Param        order query string false "Sort order" default(desc) Enums(asc, desc)
 // @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        cursor query string false "Cursor from meta.next_cursor; empty for the first page"
 // @Param        limit query int false "Items per page (max 100)" default(20) minimum(1) maximum(100)
 // @Success      200 {object} map[string]interface{} "List of all bookings with pagination metadata"
-// @Failure      401 {object} map[string]string "User not authenticated"
-// @Failure      403 {object} map[string]string "Access forbidden - admin only"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Failure      400 {object} middleware.ErrorResponse "Unknown booking status or invalid cursor"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
 // @Router       /admin/bookings [get]
 func (h *AdminHandler) GetAllBookings(c *gin.Context) {
 	status := c.Query("status")
@@ -52,6 +65,24 @@ func (h *AdminHandler) GetAllBookings(c *gin.Context) {
 		limit = 20
 	}
 
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		page, err := h.bookingUsecase.GetAllBookingsByCursor(c.Request.Context(), status, sortBy, sortOrder, cursor, limit)
+		if err != nil {
+			logger.Error("handler: admin failed to page bookings", logger.Err(err))
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"data": page.Bookings,
+			"meta": gin.H{
+				"limit":       limit,
+				"next_cursor": page.NextCursor,
+				"hasMore":     page.HasMore,
+			},
+		})
+		return
+	}
+
 	logger.Debug("handler: admin fetching all bookings",
 		logger.String("status", status),
 		logger.Int("page", page),
@@ -61,7 +92,7 @@ func (h *AdminHandler) GetAllBookings(c *gin.Context) {
 	bookings, total, err := h.bookingUsecase.GetAllBookings(c.Request.Context(), status, sortBy, sortOrder, page, limit)
 	if err != nil {
 		logger.Error("handler: admin failed to get all bookings", logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -79,29 +110,55 @@ func (h *AdminHandler) GetAllBookings(c *gin.Context) {
 	})
 }
 
+// PreviewLapsedHolds godoc
+// @Summary      Preview lapsed hold expiry (Admin)
+// @Description  List the PENDING bookings the hold release job would expire if it ran now, with their tickets and amounts. Nothing is changed. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} map[string]interface{} "Bookings that would expire"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/bookings/lapsed-holds [get]
+func (h *AdminHandler) PreviewLapsedHolds(c *gin.Context) {
+	result, err := h.bookingUsecase.PreviewLapsedHolds(c.Request.Context())
+	if err != nil {
+		logger.Error("handler: admin failed to preview lapsed holds", logger.Err(err))
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Dry run: nothing was changed"),
+		"data":    result,
+	})
+}
+
 // GetEventBookings godoc
 // @Summary      Get bookings for specific event (Admin)
-// @Description  Retrieve all bookings for a specific event with filtering and sorting options. Admin access required.
+// @Description  Retrieve all bookings for a specific event with filtering and sorting options, each with its seat numbers. The summary totals the whole event regardless of the status filter: PAID tickets, occupancy of the capacity, revenue from completed payments, completed refunds, and the split by booking status and by ticket tier. Admin access required.
 // @Tags         admin
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Event ID" example(1)
-// @Param        status query string false "Filter by booking status (e.g., pending, confirmed, cancelled, expired)"
+// @Param        status query string false "Filter by booking status, case-insensitive: PENDING, PAID, CANCELLED, EXPIRED or REFUNDED"
 // @Param        sort query string false "Sort field" default(created_at) Enums(created_at, updated_at, total_price)
 // @Param        order query string false "Sort order" default(desc) Enums(asc, desc)
-// @Success      200 {object} map[string]interface{} "List of bookings for the event"
-// @Failure      400 {object} map[string]string "Invalid event ID"
-// @Failure      401 {object} map[string]string "User not authenticated"
-// @Failure      403 {object} map[string]string "Access forbidden - admin only"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Success      200 {object} map[string]interface{} "List of bookings for the event and the event's booking summary"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID or unknown booking status"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
 // @Router       /admin/events/{id}/bookings [get]
 func (h *AdminHandler) GetEventBookings(c *gin.Context) {
 	idParam := c.Param("id")
 	eventID, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
 		logger.Warn("handler: admin invalid event ID", logger.String("id", idParam))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
 		return
 	}
 
@@ -120,7 +177,17 @@ func (h *AdminHandler) GetEventBookings(c *gin.Context) {
 			logger.Int64("event_id", eventID),
 			logger.Err(err),
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	summary, err := h.bookingUsecase.GetEventBookingSummary(c.Request.Context(), eventID)
+	if err != nil {
+		logger.Error("handler: admin failed to summarize event bookings",
+			logger.Int64("event_id", eventID),
+			logger.Err(err),
+		)
+		c.Error(err)
 		return
 	}
 
@@ -129,6 +196,305 @@ func (h *AdminHandler) GetEventBookings(c *gin.Context) {
 		logger.Int("count", len(bookings)),
 	)
 	c.JSON(http.StatusOK, gin.H{
-		"data": bookings,
+		"data":    bookings,
+		"summary": summary,
+	})
+}
+
+// bookingExportFlushEvery bounds how many exported bookings sit in the
+// response buffer.
+const bookingExportFlushEvery = 500
+
+var bookingExportHeader = []string{
+	"booking_id", "status", "booked_at", "name", "email", "seats", "tickets",
+	"total_amount", "payment_status", "payment_method", "paid_at",
+}
+
+// bookingExportWriter is the CSV or XLSX encoder behind the attendee export.
+type bookingExportWriter interface {
+	write(row entity.BookingExportRow) error
+	flush() error
+	close() error
+}
+
+// newBookingExportWriter starts an export in format on w and writes the
+// header row.
+func newBookingExportWriter(w io.Writer, format string) (bookingExportWriter, error) {
+	if format == "xlsx" {
+		xw, err := xlsx.NewWriter(w, "Bookings")
+		if err != nil {
+			return nil, err
+		}
+		header := make([]interface{}, len(bookingExportHeader))
+		for i, name := range bookingExportHeader {
+			header[i] = name
+		}
+		return xlsxBookingExport{xw}, xw.WriteRow(header...)
+	}
+
+	cw := csv.NewWriter(w)
+	return csvBookingExport{cw}, cw.Write(bookingExportHeader)
+}
+
+type csvBookingExport struct{ w *csv.Writer }
+
+func (e csvBookingExport) write(row entity.BookingExportRow) error {
+	paidAt := ""
+	if row.PaidAt != nil {
+		paidAt = row.PaidAt.Format(time.RFC3339)
+	}
+	return e.w.Write([]string{
+		strconv.FormatInt(row.BookingID, 10),
+		string(row.Status),
+		row.CreatedAt.Format(time.RFC3339),
+		row.UserName,
+		row.UserEmail,
+		strings.Join(row.Seats, " "),
+		strconv.Itoa(exportTicketCount(row)),
+		strconv.FormatFloat(row.TotalAmount, 'f', 2, 64),
+		string(row.PaymentStatus),
+		row.PaymentMethod,
+		paidAt,
+	})
+}
+
+func (e csvBookingExport) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e csvBookingExport) close() error { return e.flush() }
+
+type xlsxBookingExport struct{ w *xlsx.Writer }
+
+func (e xlsxBookingExport) write(row entity.BookingExportRow) error {
+	var paidAt interface{}
+	if row.PaidAt != nil {
+		paidAt = *row.PaidAt
+	}
+	return e.w.WriteRow(
+		row.BookingID,
+		string(row.Status),
+		row.CreatedAt,
+		row.UserName,
+		row.UserEmail,
+		strings.Join(row.Seats, " "),
+		exportTicketCount(row),
+		row.TotalAmount,
+		string(row.PaymentStatus),
+		row.PaymentMethod,
+		paidAt,
+	)
+}
+
+func (e xlsxBookingExport) flush() error { return e.w.Flush() }
+func (e xlsxBookingExport) close() error { return e.w.Close() }
+
+// exportTicketCount is the number of tickets in a booking: its seats, or
+// the quantity for general admission.
+func exportTicketCount(row entity.BookingExportRow) int {
+	if len(row.Seats) > 0 {
+		return len(row.Seats)
+	}
+	return row.Quantity
+}
+
+// ExportEventBookings godoc
+// @Summary      Export an event's attendee list (Admin)
+// @Description  Download every booking of an event as CSV or XLSX with the customer, seats (space separated), ticket count, total and latest payment status. Rows are written as they are read, so large events are not held in memory. Admin access required.
+// @Tags         admin
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        format query string false "File format" default(csv) Enums(csv, xlsx)
+// @Success      200 {file} file "Attendee list, one booking per row"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID or format"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/bookings/export [get]
+func (h *AdminHandler) ExportEventBookings(c *gin.Context) {
+	idParam := c.Param("id")
+	eventID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		logger.Warn("handler: admin invalid event ID", logger.String("id", idParam))
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	var contentType string
+	switch format {
+	case "csv":
+		contentType = "text/csv; charset=utf-8"
+	case "xlsx":
+		contentType = xlsx.ContentType
+	default:
+		middleware.RespondError(c, http.StatusBadRequest, "format must be csv or xlsx")
+		return
+	}
+
+	// The encoder is created with the first row, so a missing event can
+	// still be answered with a JSON error.
+	var out bookingExportWriter
+	start := func() error {
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="event-%d-bookings.%s"`, eventID, format))
+		c.Status(http.StatusOK)
+
+		var err error
+		out, err = newBookingExportWriter(c.Writer, format)
+		return err
+	}
+
+	exported := 0
+	err = h.bookingUsecase.StreamEventBookings(c.Request.Context(), eventID, func(row entity.BookingExportRow) error {
+		if out == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		// A write error means the client went away; stop reading rows
+		if err := out.write(row); err != nil {
+			return err
+		}
+		exported++
+		if exported%bookingExportFlushEvery == 0 {
+			if err := out.flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
 	})
+
+	if err != nil && !c.Writer.Written() {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		}
+		logger.Error("handler: admin failed to export event bookings", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to export bookings")
+		return
+	}
+	if err != nil {
+		// Headers are already sent, so the client gets a truncated file
+		logger.Warn("handler: admin booking export aborted",
+			logger.Int64("event_id", eventID),
+			logger.Int("exported", exported),
+			logger.Err(err),
+		)
+		return
+	}
+
+	if out == nil {
+		if err := start(); err != nil {
+			logger.Warn("handler: admin booking export aborted", logger.Int64("event_id", eventID), logger.Err(err))
+			return
+		}
+	}
+	if err := out.close(); err != nil {
+		logger.Warn("handler: admin booking export aborted", logger.Int64("event_id", eventID), logger.Err(err))
+		return
+	}
+	c.Writer.Flush()
+
+	logger.Debug("handler: admin event bookings exported",
+		logger.Int64("event_id", eventID),
+		logger.String("format", format),
+		logger.Int("count", exported),
+	)
+}
+
+type updateRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user staff organizer admin"`
+}
+
+// UpdateUserRole godoc
+// @Summary      Change a user's role (Admin)
+// @Description  Grant or revoke the staff, organizer or admin role for a user. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "User ID" example(1)
+// @Param        request body updateRoleRequest true "New role"
+// @Success      200 {object} map[string]string "Role updated"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid user ID or role"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "User not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/role [put]
+func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
+	idParam := c.Param("id")
+	userID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		logger.Warn("handler: admin invalid user ID", logger.String("id", idParam))
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req updateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid update role request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.userUsecase.UpdateRole(c.Request.Context(), userID, req.Role); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "User not found")
+		case errors.Is(err, entity.ErrInvalidRole):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Invalid role")
+		default:
+			logger.Error("handler: admin failed to update role", logger.Int64("user_id", userID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to update role")
+		}
+		return
+	}
+
+	logger.Info("handler: user role updated", logger.Int64("user_id", userID), logger.String("role", req.Role))
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Role updated successfully")})
+}
+
+// GetUserSummary godoc
+// @Summary      Get a customer's purchase summary (Admin)
+// @Description  A customer's purchase history in one call for support and fraud review: bookings by status, tickets, attended tickets and no-shows (tickets of PAID bookings to past events never checked in), total spent, refunded and lifetime value, refund requests, and first and last booking.
+// @Description  `flags` lists the risks the history raises: `fraud_refund` (a refund issued for fraud), `frequent_refunds` (3+ refunds covering half or more of the paid bookings), `frequent_no_shows` (3+ no-shows, half or more of the tickets to past events) and `lapsed_holds` (5+ expired holds, more than were paid).
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "User ID" example(1)
+// @Success      200 {object} entity.CustomerSummary "Customer summary"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid user ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "User not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/summary [get]
+func (h *AdminHandler) GetUserSummary(c *gin.Context) {
+	idParam := c.Param("id")
+	userID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		logger.Warn("handler: admin invalid user ID", logger.String("id", idParam))
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	summary, err := h.bookingUsecase.GetCustomerSummary(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "User not found")
+			return
+		}
+		logger.Error("handler: admin failed to get customer summary", logger.Int64("user_id", userID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get customer summary")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": summary})
 }
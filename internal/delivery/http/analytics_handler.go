@@ -0,0 +1,180 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsHandler struct {
+	forecastUC   usecase.ForecastUsecase
+	analyticsUC  usecase.AnalyticsUsecase
+	attendanceUC usecase.AttendanceUsecase
+}
+
+func NewAnalyticsHandler(forecastUC usecase.ForecastUsecase, analyticsUC usecase.AnalyticsUsecase, attendanceUC usecase.AttendanceUsecase) *AnalyticsHandler {
+	return &AnalyticsHandler{forecastUC: forecastUC, analyticsUC: analyticsUC, attendanceUC: attendanceUC}
+}
+
+// actingOrganizer returns the organizer the request acts for: the one who
+// granted a staff account access (set by EventAccessMiddleware), or else the
+// caller.
+func actingOrganizer(c *gin.Context) (int64, bool) {
+	if organizerID, exists := c.Get("organizerID"); exists {
+		return organizerID.(int64), true
+	}
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		return 0, false
+	}
+	return int64(userIDFloat.(float64)), true
+}
+
+// Forecast godoc
+// @Summary      Sell-out forecast for an event
+// @Description  Project when the event sells out from the last 7 days of sales velocity and compare sales against a straight-line target. Organizer access required; only the event's organizer can view it, or staff the organizer granted the `reports` scope on the event.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} entity.SalesForecast "Sales forecast"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer or granted staff only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/forecast [get]
+// @Router       /staff/events/{id}/forecast [get]
+func (h *AnalyticsHandler) Forecast(c *gin.Context) {
+	organizerID, ok := actingOrganizer(c)
+	if !ok {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	forecast, err := h.forecastUC.GetForecast(c.Request.Context(), eventID, organizerID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		}
+		logger.Error("handler: failed to compute forecast", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to compute forecast")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": forecast})
+}
+
+// Compare godoc
+// @Summary      Compare sales with past events
+// @Description  Line the event's cumulative sales curve up against the organizer's past events from the same series or venue, normalized to days-before-event checkpoints (60, 30, 14, 7, 3, 1, 0). Organizer access required, or staff with the `reports` scope on the event.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        by query string false "Group past events by" default(venue) Enums(series, venue)
+// @Param        limit query int false "Number of past events (max 10)" default(5) minimum(1) maximum(10)
+// @Success      200 {object} entity.EventComparison "Sales curves"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID or grouping"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer or granted staff only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/comparison [get]
+// @Router       /staff/events/{id}/comparison [get]
+func (h *AnalyticsHandler) Compare(c *gin.Context) {
+	organizerID, ok := actingOrganizer(c)
+	if !ok {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	groupBy := c.DefaultQuery("by", "venue")
+	if groupBy != "series" && groupBy != "venue" {
+		middleware.RespondError(c, http.StatusBadRequest, "by must be series or venue")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if limit < 1 || limit > 10 {
+		limit = 5
+	}
+
+	comparison, err := h.analyticsUC.CompareEvents(c.Request.Context(), eventID, organizerID, groupBy, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+		case errors.Is(err, entity.ErrEventHasNoSeries):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Event is not part of a series; compare by venue instead")
+		default:
+			logger.Error("handler: failed to compare events", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to compare events")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": comparison})
+}
+
+// Attendance godoc
+// @Summary      Event attendance
+// @Description  Compare the event's PAID tickets with the ones checked in. No-shows are counted once the event has started. After the event the post-event report emailed to the organizer is returned, with `generated_at` set; before it the counts are live. Organizer access required, or staff with the `reports` scope on the event.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} entity.AttendanceReport "Attendance"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer or granted staff only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/attendance [get]
+// @Router       /staff/events/{id}/attendance [get]
+func (h *AnalyticsHandler) Attendance(c *gin.Context) {
+	organizerID, ok := actingOrganizer(c)
+	if !ok {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	report, err := h.attendanceUC.GetAttendance(c.Request.Context(), eventID, organizerID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		}
+		logger.Error("handler: failed to fetch attendance", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to fetch attendance")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	apiKeyUC usecase.APIKeyUsecase
+}
+
+func NewAPIKeyHandler(uc usecase.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyUC: uc}
+}
+
+type createAPIKeyRequest struct {
+	UserID    int64      `json:"user_id" binding:"required" example:"7"`
+	Name      string     `json:"name" binding:"required,max=100" example:"Box office sync"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1" example:"organizer:read,organizer:write"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2027-01-01T00:00:00Z"`
+}
+
+func apiKeyError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidAPIKeyRequest):
+		middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+	case errors.Is(err, entity.ErrNotFound):
+		middleware.RespondEntityError(c, http.StatusNotFound, err, "User or active API key not found")
+	default:
+		logger.Error("handler: failed to "+action, logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to "+action)
+	}
+}
+
+// Create godoc
+// @Summary      Issue an API key (Admin)
+// @Description  Issue an API key to an organizer or admin for a partner system. Scopes are `admin:read`, `admin:write`, `organizer:read` and `organizer:write`, limited to the owner's role; a read scope allows GET requests only. The key acts as its owner and is sent in the `X-API-Key` header instead of a JWT. It is shown only in this response. The issuance is recorded in the audit log.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body createAPIKeyRequest true "Owner, name, scopes and optional expiry"
+// @Success      201 {object} entity.APIKey "Key issued, with the key itself"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request, scope or expiry"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "User not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/api-keys [post]
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	adminID := int64(userIDFloat.(float64))
+
+	var req createAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key := &entity.APIKey{UserID: req.UserID, Name: req.Name, Scopes: req.Scopes, ExpiresAt: req.ExpiresAt}
+	if err := h.apiKeyUC.CreateKey(c.Request.Context(), adminID, key); err != nil {
+		apiKeyError(c, err, "create api key")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "API key created; store the key now, it is not shown again"),
+		"data":    key,
+	})
+}
+
+// List godoc
+// @Summary      List API keys (Admin)
+// @Description  API keys, newest first, including revoked and expired ones, with when each was last used. Keys themselves are not included, only their prefix.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id query int false "Only keys of this user" example(7)
+// @Success      200 {array} entity.APIKey "API keys"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid user ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/api-keys [get]
+func (h *APIKeyHandler) List(c *gin.Context) {
+	var userID *int64
+	if raw := c.Query("user_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, "Invalid user ID")
+			return
+		}
+		userID = &id
+	}
+
+	keys, err := h.apiKeyUC.ListKeys(c.Request.Context(), userID)
+	if err != nil {
+		apiKeyError(c, err, "list api keys")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": keys})
+}
+
+// Revoke godoc
+// @Summary      Revoke an API key (Admin)
+// @Description  Disable an API key; requests with it are rejected from then on. The revocation is recorded in the audit log.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "API key ID" example(3)
+// @Success      200 {object} map[string]string "API key revoked"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid key ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Active API key not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	keyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid key ID")
+		return
+	}
+
+	if err := h.apiKeyUC.RevokeKey(c.Request.Context(), keyID); err != nil {
+		apiKeyError(c, err, "revoke api key")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "API key revoked")})
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditUC usecase.AuditUsecase
+}
+
+func NewAuditHandler(uc usecase.AuditUsecase) *AuditHandler {
+	return &AuditHandler{auditUC: uc}
+}
+
+// List godoc
+// @Summary      List audit log entries (Admin)
+// @Description  Audit log of sensitive actions, newest first: who did what to which entity, and when. Changes to events, roles and booking seats also carry the state `before` and `after`. Entries show the request ID, to find the request in the logs, and the API key when the actor used one. Dates are inclusive UTC days. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        actor_id query int false "Only actions by this user" example(1)
+// @Param        action query string false "Only this action" example(event.cancel)
+// @Param        entity_type query string false "Only entries about this kind of entity" example(booking)
+// @Param        entity_id query int false "Only entries about this entity; use with entity_type" example(42)
+// @Param        from query string false "First day (YYYY-MM-DD)" example(2026-01-01)
+// @Param        to query string false "Last day (YYYY-MM-DD)" example(2026-01-31)
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        limit query int false "Items per page (max 100)" default(50) minimum(1) maximum(100)
+// @Success      200 {object} map[string]interface{} "Audit log entries with pagination metadata"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid filter"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/audit-logs [get]
+func (h *AuditHandler) List(c *gin.Context) {
+	filter := entity.AuditLogFilter{
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+	}
+	for _, f := range []struct {
+		param   string
+		dst     **int64
+		invalid string
+	}{
+		{"actor_id", &filter.ActorID, "Invalid actor ID"},
+		{"entity_id", &filter.EntityID, "Invalid entity ID"},
+	} {
+		if v := c.Query(f.param); v != "" {
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				middleware.RespondError(c, http.StatusBadRequest, f.invalid)
+				return
+			}
+			*f.dst = &id
+		}
+	}
+	if v := c.Query("from"); v != "" {
+		from, err := time.Parse(reportDateLayout, v)
+		if err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, "Invalid from date, expected YYYY-MM-DD")
+			return
+		}
+		filter.From = &from
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse(reportDateLayout, v)
+		if err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, "Invalid to date, expected YYYY-MM-DD")
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	logs, total, err := h.auditUC.ListAuditLogs(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		logger.Error("handler: admin failed to list audit logs", logger.Err(err))
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": logs,
+		"meta": gin.H{
+			"total":   total,
+			"page":    page,
+			"limit":   limit,
+			"hasMore": page*limit < total,
+		},
+	})
+}
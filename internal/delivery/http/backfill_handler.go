@@ -0,0 +1,124 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BackfillHandler struct {
+	backfillUC usecase.BackfillUsecase
+}
+
+func NewBackfillHandler(uc usecase.BackfillUsecase) *BackfillHandler {
+	return &BackfillHandler{backfillUC: uc}
+}
+
+type startBackfillRequest struct {
+	// BatchSize is how many primary keys each batch covers
+	BatchSize int `json:"batch_size" binding:"min=0,max=10000" example:"1000"`
+}
+
+// List godoc
+// @Summary      List data backfills (Admin)
+// @Description  Every registered backfill with its status (PENDING if it never ran, RUNNING, COMPLETED or FAILED), checkpoint, row count and progress in percent of the key range. Backfills change large tables such as seats in small primary key batches from the worker instead of in a migration, so booking traffic is never locked out. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} entity.Backfill "Backfills"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/backfills [get]
+func (h *BackfillHandler) List(c *gin.Context) {
+	backfills, err := h.backfillUC.ListBackfills(c.Request.Context())
+	if err != nil {
+		logger.Error("handler: failed to list backfills", logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to list backfills")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": backfills})
+}
+
+// Get godoc
+// @Summary      Get a data backfill's progress (Admin)
+// @Description  Status, checkpoint and progress of one backfill. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        name path string true "Backfill name" example(seat_layout)
+// @Success      200 {object} entity.Backfill "Backfill"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Backfill not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/backfills/{name} [get]
+func (h *BackfillHandler) Get(c *gin.Context) {
+	backfill, err := h.backfillUC.GetBackfill(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Backfill not found")
+			return
+		}
+		logger.Error("handler: failed to get backfill", logger.String("backfill", c.Param("name")), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get backfill")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": backfill})
+}
+
+// Start godoc
+// @Summary      Start a data backfill (Admin)
+// @Description  Queue a backfill for the worker, which applies it batch_size primary keys at a time (default 1000, max 10000), each batch in its own short transaction that gives up rather than wait on rows bookings hold. A FAILED backfill resumes from its checkpoint; a COMPLETED one starts over, which is safe since backfills only touch rows that still need them. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        name path string true "Backfill name" example(seat_layout)
+// @Param        request body startBackfillRequest false "Optional batch size"
+// @Success      202 {object} entity.Backfill "Backfill started"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid batch size"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Backfill not found"
+// @Failure      409 {object} middleware.ErrorResponse "Backfill is already running"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/backfills/{name}/start [post]
+func (h *BackfillHandler) Start(c *gin.Context) {
+	name := c.Param("name")
+
+	var req startBackfillRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	backfill, err := h.backfillUC.StartBackfill(c.Request.Context(), name, req.BatchSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Backfill not found")
+		case errors.Is(err, entity.ErrBackfillRunning):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Backfill is already running")
+		default:
+			logger.Error("handler: failed to start backfill", logger.String("backfill", name), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to start backfill")
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": middleware.T(c, "Backfill started"),
+		"data":    backfill,
+	})
+}
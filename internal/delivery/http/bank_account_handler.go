@@ -0,0 +1,225 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BankAccountHandler struct {
+	bankAccountUC usecase.BankAccountUsecase
+}
+
+func NewBankAccountHandler(uc usecase.BankAccountUsecase) *BankAccountHandler {
+	return &BankAccountHandler{bankAccountUC: uc}
+}
+
+type addBankAccountRequest struct {
+	BankName           string `json:"bank_name" binding:"required"`
+	AccountHolder      string `json:"account_holder" binding:"required"`
+	AccountNumber      string `json:"account_number" binding:"required,numeric,min=6,max=20"`
+	VerificationMethod string `json:"verification_method" binding:"required,oneof=micro_deposit penny_drop"`
+}
+
+type verifyBankAccountRequest struct {
+	Amounts []float64 `json:"amounts" binding:"required,len=2"`
+}
+
+// Add godoc
+// @Summary      Register a payout bank account
+// @Description  Add a bank account for payouts and start verification. micro_deposit sends two small deposits to confirm later; penny_drop verifies the holder name immediately. Organizer access required.
+// @Tags         organizer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body addBankAccountRequest true "Bank account details"
+// @Success      201 {object} map[string]interface{} "Bank account registered"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      422 {object} middleware.ErrorResponse "Account holder name does not match"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/bank-accounts [post]
+func (h *BankAccountHandler) Add(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	var req addBankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid add bank account request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	account := &entity.BankAccount{
+		OrganizerID:        organizerID,
+		BankName:           req.BankName,
+		AccountHolder:      req.AccountHolder,
+		AccountNumber:      req.AccountNumber,
+		VerificationMethod: req.VerificationMethod,
+	}
+
+	if err := h.bankAccountUC.AddBankAccount(c.Request.Context(), account); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidVerificationMethod):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Invalid verification method")
+		case errors.Is(err, entity.ErrVerificationFailed):
+			middleware.RespondEntityErrorDetails(c, http.StatusUnprocessableEntity, err, "Account holder name does not match", account)
+		default:
+			logger.Error("handler: failed to add bank account", logger.Int64("organizer_id", organizerID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to add bank account")
+		}
+		return
+	}
+
+	message := "Bank account verified"
+	if account.Status == "PENDING_VERIFICATION" {
+		message = "Two small deposits are on their way. Confirm the amounts to verify the account."
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, message),
+		"data":    account,
+	})
+}
+
+// List godoc
+// @Summary      List payout bank accounts
+// @Description  List the caller's payout bank accounts. Account numbers are masked to the last four digits. Organizer access required.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} map[string]interface{} "Bank accounts"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/bank-accounts [get]
+func (h *BankAccountHandler) List(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	accounts, err := h.bankAccountUC.ListBankAccounts(c.Request.Context(), organizerID)
+	if err != nil {
+		logger.Error("handler: failed to list bank accounts", logger.Int64("organizer_id", organizerID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to list bank accounts")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": accounts})
+}
+
+// Verify godoc
+// @Summary      Confirm micro-deposit amounts
+// @Description  Verify a bank account by entering the two micro-deposit amounts. The account fails after 3 wrong attempts. Organizer access required.
+// @Tags         organizer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Bank account ID" example(1)
+// @Param        request body verifyBankAccountRequest true "The two deposited amounts"
+// @Success      200 {object} map[string]interface{} "Bank account verified"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body or ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Bank account not found"
+// @Failure      409 {object} middleware.ErrorResponse "Bank account is not awaiting verification"
+// @Failure      422 {object} middleware.ErrorResponse "Amounts do not match"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/bank-accounts/{id}/verify [post]
+func (h *BankAccountHandler) Verify(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid bank account ID")
+		return
+	}
+
+	var req verifyBankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid verify bank account request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	account, err := h.bankAccountUC.VerifyMicroDeposits(c.Request.Context(), organizerID, accountID, req.Amounts)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Bank account not found")
+		case errors.Is(err, entity.ErrBankAccountNotPending):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Bank account is not awaiting verification")
+		case errors.Is(err, entity.ErrVerificationFailed):
+			middleware.RespondEntityErrorDetails(c, http.StatusUnprocessableEntity, err, "Amounts do not match", account)
+		default:
+			logger.Error("handler: failed to verify bank account", logger.Int64("bank_account_id", accountID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to verify bank account")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Bank account verified"),
+		"data":    account,
+	})
+}
+
+// SetDefault godoc
+// @Summary      Set default payout account
+// @Description  Choose which verified bank account receives payouts. Organizer access required.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Bank account ID" example(1)
+// @Success      200 {object} map[string]string "Default account updated"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      409 {object} middleware.ErrorResponse "Bank account is not verified"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/bank-accounts/{id}/default [put]
+func (h *BankAccountHandler) SetDefault(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid bank account ID")
+		return
+	}
+
+	if err := h.bankAccountUC.SetDefault(c.Request.Context(), organizerID, accountID); err != nil {
+		if errors.Is(err, entity.ErrBankAccountNotVerified) {
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Only a verified bank account can be the default")
+			return
+		}
+		logger.Error("handler: failed to set default bank account", logger.Int64("bank_account_id", accountID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to set default bank account")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Default bank account updated")})
+}
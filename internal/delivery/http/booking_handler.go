@@ -1,64 +1,75 @@
 package http
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
 	"ticres/internal/usecase"
 	"ticres/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
+// queueTokenHeader carries the queue token the waiting room gave a user it
+// let through, which lets their bookings skip the event's request budget.
+const queueTokenHeader = "X-Queue-Token"
+
 type BookingHandler struct {
 	bookingUC usecase.BookingUsecase
+	funnelUC  usecase.FunnelUsecase
 }
 
-func NewBookingHandler(uc usecase.BookingUsecase) *BookingHandler {
-	return &BookingHandler{bookingUC: uc}
+func NewBookingHandler(uc usecase.BookingUsecase, funnelUC usecase.FunnelUsecase) *BookingHandler {
+	return &BookingHandler{bookingUC: uc, funnelUC: funnelUC}
 }
 
 type bookRequest struct {
 	EventID int64   `json:"event_id" binding:"required"`
-	SeatIDs []int64 `json:"seat_ids" binding:"required,min=1"`
+	SeatIDs []int64 `json:"seat_ids"`
+	// Quantity books general admission tickets instead of seats, or the
+	// best available adjacent seats of a seated event
+	Quantity int `json:"quantity" binding:"min=0"`
+	// TierID and Section limit the seats picked for a quantity
+	TierID  *int64 `json:"tier_id" example:"2"`
+	Section string `json:"section" example:"VIP"`
 }
 
 // Create godoc
 // @Summary      Create a new booking
-// @Description  Create a booking for event seats. User must be authenticated. Payment must be completed within 15 minutes.
+// @Description  Create a booking for event seats. User must be authenticated. Payment must be completed within 15 minutes. The confirmation is emailed to the address on the user's account, which the response echoes as customer_email. Seated events take seat_ids; general admission events take a quantity (1-10) instead. A quantity for a seated event books the best available seats: the front-most run of that many adjacent free seats in one row, optionally limited to a tier_id or section, returned under seats. If the user already holds a PAID ticket to another event starting close to this one, the response carries a non-fatal "warning" listing the conflicts, or the booking is rejected with 409 when conflicts are configured to block. Bookings that would take the user past the event's per-user ticket limit, counting their PAID and PENDING bookings, are rejected with 409. During an on-sale each event takes a limited number of booking requests per second; requests over it are rejected with 429 and a Retry-After header, and should be retried after that many seconds. Users let through the waiting room send their queue token in X-Queue-Token to skip the limit.
 // @Tags         bookings
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        X-Queue-Token header string false "Queue token from the waiting room"
 // @Param        request body bookRequest true "Booking details with event ID and seat IDs"
 // @Success      201 {object} map[string]interface{} "Booking created successfully with payment deadline"
-// @Failure      400 {object} map[string]string "Invalid request body"
-// @Failure      401 {object} map[string]string "User not authenticated"
-// @Failure      409 {object} map[string]string "One or more seats are not available or already booked"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body, or tier_id or section given without a quantity"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      409 {object} middleware.ErrorResponse "Seats not available, no run of adjacent free seats long enough, not enough general admission tickets left, per-user ticket limit reached, or the booking overlaps another event"
+// @Failure      429 {object} middleware.ErrorResponse "Event is taking too many booking requests, retry after Retry-After seconds"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
 // @Router       /bookings [post]
 func (h *BookingHandler) Create(c *gin.Context) {
 	userIDFloat, exists := c.Get("userID")
 	if !exists {
 		logger.Warn("handler: unauthorized booking attempt")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	userID := int64(userIDFloat.(float64))
 
-	// Get email from context (set by auth middleware)
-	userEmail, _ := c.Get("userEmail")
-	email, ok := userEmail.(string)
-	if !ok || email == "" {
-		email = "customer@example.com"
-	}
-
 	logger.Debug("handler: booking request received", logger.Int64("user_id", userID))
 
 	var req bookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Warn("handler: invalid booking request", logger.Err(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -66,16 +77,49 @@ func (h *BookingHandler) Create(c *gin.Context) {
 		logger.Int64("user_id", userID),
 		logger.Int64("event_id", req.EventID),
 		logger.Int("seat_count", len(req.SeatIDs)),
+		logger.Int("quantity", req.Quantity),
 	)
 
-	result, err := h.bookingUC.BookSeats(c.Request.Context(), userID, req.EventID, req.SeatIDs, email)
+	if err := h.funnelUC.Admit(c.Request.Context(), userID, req.EventID, c.GetHeader(queueTokenHeader)); err != nil {
+		var busy *entity.EventBusyError
+		if errors.As(err, &busy) {
+			c.Header("Retry-After", strconv.Itoa(busy.RetryAfterSeconds()))
+		}
+		c.Error(err)
+		return
+	}
+
+	pref := entity.SeatPreference{TierID: req.TierID, Section: req.Section}
+	result, err := h.bookingUC.BookSeats(c.Request.Context(), userID, req.EventID, req.SeatIDs, req.Quantity, pref)
 	if err != nil {
-		if err.Error() == "seat not available or already booked" {
+		switch {
+		case errors.Is(err, entity.ErrInvalidBookingRequest), errors.Is(err, entity.ErrNotGeneralAdmission):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+			return
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		case errors.Is(err, entity.ErrSoldOut):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Not enough tickets left")
+			return
+		case errors.Is(err, entity.ErrNoAdjacentSeats):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Not enough adjacent seats available")
+			return
+		case errors.Is(err, entity.ErrSalesWaveSoldOut):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Tickets released so far are sold out; more go on sale in the next wave")
+			return
+		case errors.Is(err, entity.ErrBookingConflict):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "You already have a ticket to another event at a nearby time")
+			return
+		case errors.Is(err, entity.ErrTicketLimitExceeded):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "You have reached the ticket limit per user for this event")
+			return
+		case errors.Is(err, entity.ErrSeatUnavailable):
 			logger.Warn("handler: booking failed - seat not available",
 				logger.Int64("user_id", userID),
 				logger.Int64("event_id", req.EventID),
 			)
-			c.JSON(http.StatusConflict, gin.H{"error": "Salah satu kursi yang dipilih sudah tidak tersedia"})
+			middleware.RespondEntityError(c, http.StatusConflict, err, "One of the selected seats is no longer available")
 			return
 		}
 		logger.Error("handler: booking failed",
@@ -83,7 +127,7 @@ func (h *BookingHandler) Create(c *gin.Context) {
 			logger.Int64("event_id", req.EventID),
 			logger.Err(err),
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -92,8 +136,11 @@ func (h *BookingHandler) Create(c *gin.Context) {
 		logger.Int64("event_id", req.EventID),
 		logger.Int("seat_count", len(req.SeatIDs)),
 	)
+	if result.Warning != nil {
+		result.Warning.Message = middleware.T(c, result.Warning.Message)
+	}
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Booking created. Please complete payment within 15 minutes.",
+		"message": middleware.T(c, "Booking created. Please complete payment within 15 minutes."),
 		"data":    result,
 	})
 }
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookingModificationHandler struct {
+	modificationUC usecase.BookingModificationUsecase
+}
+
+func NewBookingModificationHandler(uc usecase.BookingModificationUsecase) *BookingModificationHandler {
+	return &BookingModificationHandler{modificationUC: uc}
+}
+
+type seatChangeRequest struct {
+	FromSeatIDs   []int64 `json:"from_seat_ids" binding:"required,min=1" example:"101,102"`
+	ToSeatIDs     []int64 `json:"to_seat_ids" binding:"required,min=1" example:"205,206"`
+	PaymentMethod string  `json:"payment_method" binding:"omitempty,oneof=credit_card bank_transfer e_wallet" example:"e_wallet"`
+}
+
+// ChangeSeats godoc
+// @Summary      Change seats on a paid booking
+// @Description  Swap seats on a PAID booking: from_seat_ids[i] is replaced by to_seat_ids[i]. The new seats must be available and cost at least as much in total; the difference is charged with payment_method (required only when there is a difference). Old seats are released and moved seats get new ticket codes, all in one transaction. Checked-in tickets cannot be moved.
+// @Tags         bookings
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Booking ID" example(1)
+// @Param        request body seatChangeRequest true "Seats to swap"
+// @Success      200 {object} entity.BookingModification "Seats changed"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid seat change, payment method, or cheaper seats"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Booking belongs to another user"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      409 {object} middleware.ErrorResponse "Booking not paid, seat unavailable, or ticket already used"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /bookings/{id}/seat-changes [post]
+func (h *BookingModificationHandler) ChangeSeats(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := int64(userIDFloat.(float64))
+
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	var req seatChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid seat change request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	mod, err := h.modificationUC.ChangeSeats(c.Request.Context(), userID, bookingID, req.FromSeatIDs, req.ToSeatIDs, req.PaymentMethod)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Booking not found")
+		case errors.Is(err, entity.ErrUnauthorized):
+			middleware.RespondEntityError(c, http.StatusForbidden, err, "You don't have access to this booking")
+		case errors.Is(err, entity.ErrInvalidSeatChange):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "from_seat_ids must be seats of this booking and match to_seat_ids one to one, without duplicates")
+		case errors.Is(err, entity.ErrSeatDowngrade):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "New seats must cost at least as much as the current seats")
+		case errors.Is(err, entity.ErrInvalidPaymentMethod):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "A payment method is required to pay the price difference. Use: credit_card, bank_transfer, or e_wallet")
+		case errors.Is(err, entity.ErrBookingNotPaid):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Only paid bookings can change seats")
+		case errors.Is(err, entity.ErrSeatUnavailable):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "One of the selected seats is no longer available")
+		case errors.Is(err, entity.ErrTicketAlreadyUsed):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "A ticket for one of the seats has already been used")
+		default:
+			logger.Error("handler: failed to change seats", logger.Int64("booking_id", bookingID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to change seats")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Seats changed"),
+		"data":    mod,
+	})
+}
+
+// List godoc
+// @Summary      List seat changes of a booking
+// @Description  Seat changes made on the caller's booking, newest first.
+// @Tags         bookings
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Booking ID" example(1)
+// @Success      200 {object} map[string]interface{} "Seat changes"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid booking ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Booking belongs to another user"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /bookings/{id}/seat-changes [get]
+func (h *BookingModificationHandler) List(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := int64(userIDFloat.(float64))
+
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	mods, err := h.modificationUC.ListModifications(c.Request.Context(), userID, bookingID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Booking not found")
+		case errors.Is(err, entity.ErrUnauthorized):
+			middleware.RespondEntityError(c, http.StatusForbidden, err, "You don't have access to this booking")
+		default:
+			logger.Error("handler: failed to list seat changes", logger.Int64("booking_id", bookingID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to list seat changes")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": mods})
+}
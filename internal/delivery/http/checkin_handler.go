@@ -0,0 +1,98 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CheckinHandler struct {
+	checkinUC usecase.CheckinUsecase
+}
+
+func NewCheckinHandler(uc usecase.CheckinUsecase) *CheckinHandler {
+	return &CheckinHandler{checkinUC: uc}
+}
+
+type checkinRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// CheckIn godoc
+// @Summary      Check in a ticket at the gate
+// @Description  Validate a scanned ticket QR code for the event and mark it as used. Admin access, or staff the event's organizer granted the `checkin` scope.
+// @Tags         checkin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body checkinRequest true "Scanned QR code"
+// @Success      200 {object} map[string]interface{} "Ticket checked in"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body or event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - no check-in access to the event"
+// @Failure      404 {object} middleware.ErrorResponse "Ticket not found"
+// @Failure      409 {object} middleware.ErrorResponse "Ticket already used"
+// @Failure      422 {object} middleware.ErrorResponse "Ticket not valid for this event, not paid or revoked"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/checkin [post]
+func (h *CheckinHandler) CheckIn(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	staffID := int64(userIDFloat.(float64))
+
+	idParam := c.Param("id")
+	eventID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		logger.Warn("handler: invalid event ID for checkin", logger.String("id", idParam))
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req checkinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid checkin request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ticket, err := h.checkinUC.CheckIn(c.Request.Context(), eventID, req.Code, staffID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Ticket not found")
+		case errors.Is(err, entity.ErrTicketAlreadyUsed):
+			middleware.RespondEntityErrorDetails(c, http.StatusConflict, err, "Ticket has already been used", ticket)
+		case errors.Is(err, entity.ErrTicketWrongEvent):
+			middleware.RespondEntityError(c, http.StatusUnprocessableEntity, err, "Ticket is not valid for this event")
+		case errors.Is(err, entity.ErrTicketNotActive):
+			middleware.RespondEntityError(c, http.StatusUnprocessableEntity, err, "Ticket booking is not paid")
+		case errors.Is(err, entity.ErrTicketRevoked):
+			middleware.RespondEntityError(c, http.StatusUnprocessableEntity, err, "Ticket code has been revoked")
+		default:
+			logger.Error("handler: checkin failed", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Check-in failed")
+		}
+		return
+	}
+
+	logger.Info("handler: ticket checked in",
+		logger.Int64("event_id", eventID),
+		logger.Int64("ticket_id", ticket.ID),
+		logger.Int64("staff_id", staffID),
+	)
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Check-in successful"),
+		"data":    ticket,
+	})
+}
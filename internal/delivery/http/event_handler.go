@@ -1,10 +1,19 @@
 package http
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"ticres/internal/delivery/http/middleware"
 	"ticres/internal/entity"
 	"ticres/internal/usecase"
 	"ticres/pkg/logger"
@@ -14,32 +23,55 @@ import (
 
 type EventHandler struct {
 	eventUsecase usecase.EventUsecase
+
+	// closing ends live seat update streams on shutdown, since they would
+	// otherwise keep the server from draining
+	closing   chan struct{}
+	closeOnce sync.Once
 }
 
 func NewEventHandler(u usecase.EventUsecase) *EventHandler {
-	return &EventHandler{eventUsecase: u}
+	return &EventHandler{eventUsecase: u, closing: make(chan struct{})}
+}
+
+// CloseStreams ends all live seat update streams.
+func (h *EventHandler) CloseStreams() {
+	h.closeOnce.Do(func() { close(h.closing) })
 }
 
 type createEventRequest struct {
 	Name        string  `json:"name" binding:"required"`
 	Location    string  `json:"location" binding:"required"`
-	Date        string  `json:"date" binding:"required"`
+	Series      string  `json:"series" binding:"max=150"`
+	Category    string  `json:"category" binding:"max=50" example:"concert"`
+	Date        string  `json:"date" binding:"required" example:"2026-12-31T19:00:00+07:00"`
 	Capacity    int     `json:"capacity" binding:"required,min=1"`
 	TicketPrice float64 `json:"ticket_price" binding:"required,min=0"`
+	// SeatNumbering is optional; without it seats are numbered "<eventID>-<n>"
+	SeatNumbering *entity.SeatNumbering `json:"seat_numbering"`
+	// AdmissionMode "general" sells capacity as unseated tickets
+	AdmissionMode string `json:"admission_mode" binding:"omitempty,oneof=seated general"`
+	Description   string `json:"description" binding:"max=2000"`
+	Terms         string `json:"terms" binding:"max=20000"`
+	OrganizerName string `json:"organizer_name" binding:"max=150"`
+	// DoorsOpen uses the same formats as Date
+	DoorsOpen string                 `json:"doors_open" example:"2026-12-31 18:00"`
+	Metadata  map[string]interface{} `json:"metadata" swaggertype:"object"`
 }
 
 // Create godoc
 // @Summary      Create a new event
-// @Description  Create a new event with details and ticket price. Authenticated user required.
+// @Description  Create a new event with details and ticket price. Admin or approved organizer required; organizer-created events are owned by the organizer. date and doors_open take RFC3339 or "YYYY-MM-DD HH:MM" (UTC), and date must be at least the configured lead time in the future; invalid dates are rejected with validation_failed listing each field under details. Optional seat_numbering picks how seats are labelled: "sequential" (1, 2, ...), "rows" (A1, A2, ... with seats_per_row) or "sections" (e.g. VIP-A1), with an optional format template using {event}, {n}, {section}, {row} and {seat}. Setting admission_mode to "general" creates a non-seated event that sells capacity tickets at ticket_price; seat_numbering is not allowed then. Optional description (plain-text summary), terms, organizer_name (the name shown to buyers), doors_open (before date) and metadata (free-form JSON object, at most 50 keys and 8KB) are returned on the event detail.
 // @Tags         events
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        request body createEventRequest true "Event creation details"
 // @Success      201 {object} entity.Event "Event created successfully"
-// @Failure      400 {object} map[string]string "Invalid request body or date format"
-// @Failure      401 {object} map[string]string "User not authenticated"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body, seat numbering, door time or metadata, or validation_failed with the invalid date fields under details"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin or organizer only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
 // @Router       /events [post]
 func (h *EventHandler) Create(c *gin.Context) {
 	logger.Debug("handler: create event request received")
@@ -47,27 +79,68 @@ func (h *EventHandler) Create(c *gin.Context) {
 	var req createEventRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Warn("handler: invalid create event request", logger.Err(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	parsedDate, err := time.Parse("2006-01-02 15:04", req.Date)
+	invalid := &entity.ValidationError{}
+	parsedDate, err := entity.ParseEventTime(req.Date)
 	if err != nil {
-		logger.Warn("handler: invalid date format", logger.String("date", req.Date))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD HH:MM"})
+		invalid.Add("date", "must be "+entity.EventTimeFormats)
+	}
+	var doorsOpen *time.Time
+	if req.DoorsOpen != "" {
+		t, err := entity.ParseEventTime(req.DoorsOpen)
+		if err != nil {
+			invalid.Add("doors_open", "must be "+entity.EventTimeFormats)
+		}
+		doorsOpen = &t
+	}
+	if err := invalid.OrNil(); err != nil {
+		logger.Warn("handler: invalid event times", logger.String("date", req.Date), logger.String("doors_open", req.DoorsOpen))
+		c.Error(err)
 		return
 	}
 
 	event := &entity.Event{
 		Name:     req.Name,
 		Location: req.Location,
+		Series:   req.Series,
+		Category: req.Category,
 		Date:     parsedDate,
 		Capacity: req.Capacity,
+
+		SeatNumbering: req.SeatNumbering,
+		AdmissionMode: req.AdmissionMode,
+		Description:   req.Description,
+		Terms:         req.Terms,
+		OrganizerName: req.OrganizerName,
+		Metadata:      req.Metadata,
+	}
+	if doorsOpen != nil {
+		event.Content = &entity.EventContent{DoorsOpenAt: doorsOpen}
+	}
+
+	// Events created by an organizer are owned by them; admin-created events have no owner
+	if role, _ := c.Get("role"); role == "organizer" {
+		if userIDFloat, ok := c.Get("userID"); ok {
+			organizerID := int64(userIDFloat.(float64))
+			event.OrganizerID = &organizerID
+		}
 	}
 
 	if err := h.eventUsecase.CreateEvent(c.Request.Context(), event, req.TicketPrice); err != nil {
+		if errors.Is(err, entity.ErrValidation) {
+			logger.Warn("handler: event rejected", logger.Err(err))
+			c.Error(err)
+			return
+		}
+		if errors.Is(err, entity.ErrInvalidSeatNumbering) || errors.Is(err, entity.ErrInvalidEventMetadata) || errors.Is(err, entity.ErrInvalidEventContent) {
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+			return
+		}
 		logger.Error("handler: failed to create event", logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -75,20 +148,118 @@ func (h *EventHandler) Create(c *gin.Context) {
 	c.JSON(http.StatusCreated, event)
 }
 
+// eventFilter reads the event list filters from the query string.
+func eventFilter(c *gin.Context) (entity.EventFilter, error) {
+	filter := entity.EventFilter{
+		Query:    strings.TrimSpace(c.Query("q")),
+		Search:   c.Query("search"),
+		Location: c.Query("location"),
+		Category: c.Query("category"),
+		Status:   entity.EventStatus(c.Query("status")),
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"date_from", &filter.DateFrom}, {"date_to", &filter.DateTo}} {
+		v := c.Query(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %s must be YYYY-MM-DD", entity.ErrInvalidEventFilter, p.name)
+		}
+		if p.name == "date_to" {
+			// The whole day is included
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		*p.dst = &t
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  **float64
+	}{{"min_price", &filter.MinPrice}, {"max_price", &filter.MaxPrice}} {
+		v := c.Query(p.name)
+		if v == "" {
+			continue
+		}
+		price, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %s must be a number", entity.ErrInvalidEventFilter, p.name)
+		}
+		*p.dst = &price
+	}
+
+	return filter, nil
+}
+
 // List godoc
 // @Summary      List events
-// @Description  Retrieve a paginated list of events with optional search filter
+// @Description  Retrieve a paginated list of events, newest first, narrowed by any combination of filters. With `q`, events are matched by full-text search over name, location and description and sorted by relevance; it accepts web search syntax (`"quoted phrase"`, `or`, `-excluded`). An event matches the price range when any of its tickets is priced within it.
+// @Description  Passing `cursor` switches from page numbers to cursor paging, which stays fast on deep pages and does not skip or repeat events added meanwhile. Start with an empty cursor, then pass meta.next_cursor from the previous response with the same filters; it is empty on the last page. Cursor pages have no total.
 // @Tags         events
 // @Accept       json
 // @Produce      json
-// @Param        search query string false "Search by event name or location"
+// @Param        q query string false "Full-text search, ranked by relevance" example(jazz jakarta)
+// @Param        search query string false "Search by event name"
+// @Param        location query string false "Location contains" example(Jakarta)
+// @Param        category query string false "Event category" example(concert)
+// @Param        status query string false "Event status" Enums(available, cancelled, completed)
+// @Param        date_from query string false "Events on or after this date (YYYY-MM-DD)" example(2026-01-01)
+// @Param        date_to query string false "Events on or before this date (YYYY-MM-DD)" example(2026-12-31)
+// @Param        min_price query number false "Lowest ticket price" example(100000)
+// @Param        max_price query number false "Highest ticket price" example(500000)
 // @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        cursor query string false "Cursor from meta.next_cursor; empty for the first page"
 // @Param        limit query int false "Items per page (max 100)" default(10) minimum(1) maximum(100)
 // @Success      200 {object} map[string]interface{} "List of events with pagination metadata"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid filter or cursor"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
 // @Router       /events [get]
 func (h *EventHandler) List(c *gin.Context) {
-	search := c.Query("search")
+	h.list(c, false)
+}
+
+// AdminList godoc
+// @Summary      List events for admins
+// @Description  Same as GET /events, with the same filters and paging. Deleted events are hidden unless include_deleted=true; they carry deleted_at. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        include_deleted query bool false "Include deleted events"
+// @Param        q query string false "Full-text search, ranked by relevance" example(jazz jakarta)
+// @Param        search query string false "Search by event name"
+// @Param        location query string false "Location contains" example(Jakarta)
+// @Param        category query string false "Event category" example(concert)
+// @Param        status query string false "Event status" Enums(available, cancelled, completed)
+// @Param        date_from query string false "Events on or after this date (YYYY-MM-DD)" example(2026-01-01)
+// @Param        date_to query string false "Events on or before this date (YYYY-MM-DD)" example(2026-12-31)
+// @Param        min_price query number false "Lowest ticket price" example(100000)
+// @Param        max_price query number false "Highest ticket price" example(500000)
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        cursor query string false "Cursor from meta.next_cursor; empty for the first page"
+// @Param        limit query int false "Items per page (max 100)" default(10) minimum(1) maximum(100)
+// @Success      200 {object} map[string]interface{} "List of events with pagination metadata"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid filter or cursor"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events [get]
+func (h *EventHandler) AdminList(c *gin.Context) {
+	h.list(c, c.Query("include_deleted") == "true")
+}
+
+func (h *EventHandler) list(c *gin.Context, includeDeleted bool) {
+	filter, err := eventFilter(c)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.IncludeDeleted = includeDeleted
+	search := filter.Search
 	pageStr := c.DefaultQuery("page", "1")
 	limitStr := c.DefaultQuery("limit", "10")
 
@@ -102,16 +273,26 @@ func (h *EventHandler) List(c *gin.Context) {
 		limit = 10
 	}
 
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		h.listEventsByCursor(c, filter, cursor, limit)
+		return
+	}
+
 	logger.Debug("handler: listing events",
+		logger.String("q", filter.Query),
 		logger.String("search", search),
 		logger.Int("page", page),
 		logger.Int("limit", limit),
 	)
 
-	events, total, err := h.eventUsecase.ListEventsWithSearch(c.Request.Context(), search, page, limit)
+	events, total, err := h.eventUsecase.ListEventsWithSearch(c.Request.Context(), filter, page, limit)
 	if err != nil {
+		if errors.Is(err, entity.ErrInvalidEventFilter) {
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+			return
+		}
 		logger.Error("handler: failed to list events", logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -129,24 +310,46 @@ func (h *EventHandler) List(c *gin.Context) {
 	})
 }
 
+func (h *EventHandler) listEventsByCursor(c *gin.Context, filter entity.EventFilter, cursor string, limit int) {
+	page, err := h.eventUsecase.ListEventsByCursor(c.Request.Context(), filter, cursor, limit)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidEventFilter) {
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+			return
+		}
+		logger.Error("handler: failed to list events by cursor", logger.Err(err))
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": page.Events,
+		"meta": gin.H{
+			"limit":       limit,
+			"next_cursor": page.NextCursor,
+			"hasMore":     page.HasMore,
+		},
+	})
+}
+
 // GetByID godoc
 // @Summary      Get event by ID
-// @Description  Retrieve detailed information about a specific event including available seats
+// @Description  Retrieve detailed information about a specific event including all of its seats. For large venues use /events/{id}/seats or /events/{id}/seats/stream instead.
 // @Tags         events
 // @Accept       json
 // @Produce      json
 // @Param        id path int true "Event ID" example(1)
 // @Success      200 {object} map[string]interface{} "Event details with seats information"
-// @Failure      400 {object} map[string]string "Invalid event ID"
-// @Failure      404 {object} map[string]string "Event not found"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
 // @Router       /events/{id} [get]
 func (h *EventHandler) GetByID(c *gin.Context) {
 	idParam := c.Param("id")
 	eventID, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
 		logger.Warn("handler: invalid event ID", logger.String("id", idParam))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
 		return
 	}
 
@@ -155,23 +358,352 @@ func (h *EventHandler) GetByID(c *gin.Context) {
 	eventWithSeats, err := h.eventUsecase.GetEventWithSeats(c.Request.Context(), eventID)
 	if err != nil {
 		logger.Warn("handler: event not found", logger.Int64("event_id", eventID), logger.Err(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		middleware.RespondError(c, http.StatusNotFound, "Event not found")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"data": eventWithSeats})
 }
 
+const (
+	defaultSeatPageLimit = 500
+	maxSeatPageLimit     = 1000
+
+	// seatStreamFlushEvery bounds how many streamed seats sit in the response buffer.
+	seatStreamFlushEvery = 500
+
+	// seatUpdateHeartbeat keeps idle live streams from being cut by proxies.
+	seatUpdateHeartbeat = 15 * time.Second
+)
+
+// ListSections godoc
+// @Summary      Seat availability per section
+// @Description  Available, held, sold and total seats plus the price range of each section, in layout order, so a seat picker can show an overview before loading one section's seats. Seats without a section are grouped under an empty section name.
+// @Tags         events
+// @Produce      json
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} map[string]interface{} "Sections with availability"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /events/{id}/sections [get]
+func (h *EventHandler) ListSections(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	sections, err := h.eventUsecase.ListSections(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		}
+		logger.Error("handler: failed to list sections", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to list sections")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sections})
+}
+
+// Capacity godoc
+// @Summary      Event capacity breakdown (Admin)
+// @Description  Sold, held and available tickets of an event. Held tickets belong to bookings awaiting payment; lapsed_holds are past their payment deadline and are released shortly. Seated events also get the breakdown per section. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} entity.EventCapacity "Capacity breakdown"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/capacity [get]
+func (h *EventHandler) Capacity(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	capacity, err := h.eventUsecase.GetEventCapacity(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		}
+		logger.Error("handler: failed to get event capacity", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get event capacity")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": capacity})
+}
+
+// availabilityETag identifies the availability figures, leaving out AsOf so
+// a poll that finds nothing changed gets 304 Not Modified.
+func availabilityETag(a *entity.PublicAvailability) string {
+	price := func(p *float64) string {
+		if p == nil {
+			return "-"
+		}
+		return strconv.FormatFloat(*p, 'f', 2, 64)
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%d|%d|%s|%s",
+		a.EventID, a.Status, a.Capacity, a.Available, price(a.MinPrice), price(a.MaxPrice))))
+	return `W/"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header lists etag.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// PublicAvailability godoc
+// @Summary      Public event availability
+// @Description  Tickets left and the price range of those still for sale, for ticket aggregators to poll. No authentication. Figures are cached for 30 seconds; send the `ETag` back in `If-None-Match` to get `304 Not Modified` when nothing changed. Prices are null when the event is sold out.
+// @Tags         public
+// @Produce      json
+// @Param        id path int true "Event ID" example(1)
+// @Param        If-None-Match header string false "ETag of the last response"
+// @Success      200 {object} entity.PublicAvailability "Availability"
+// @Success      304 "Not modified"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /public/events/{id}/availability [get]
+func (h *EventHandler) PublicAvailability(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	availability, err := h.eventUsecase.GetPublicAvailability(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		}
+		logger.Error("handler: failed to get public availability", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get availability")
+		return
+	}
+
+	etag := availabilityETag(availability)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=30")
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": availability})
+}
+
+// ListSeats godoc
+// @Summary      List event seats by cursor
+// @Description  Page through an event's seats in seat ID order. Pass meta.next_cursor from the previous response as cursor to get the next page.
+// @Tags         events
+// @Produce      json
+// @Param        id path int true "Event ID" example(1)
+// @Param        cursor query int false "Seat ID to continue after" default(0)
+// @Param        limit query int false "Seats per page (max 1000)" default(500) minimum(1) maximum(1000)
+// @Param        available query bool false "Only return seats that are not booked"
+// @Param        section query string false "Only return seats in this section" example(VIP)
+// @Success      200 {object} map[string]interface{} "Seats with cursor metadata"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID or cursor"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /events/{id}/seats [get]
+func (h *EventHandler) ListSeats(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	cursor, err := strconv.ParseInt(c.DefaultQuery("cursor", "0"), 10, 64)
+	if err != nil || cursor < 0 {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultSeatPageLimit)))
+	if limit < 1 || limit > maxSeatPageLimit {
+		limit = defaultSeatPageLimit
+	}
+	filter := entity.SeatFilter{
+		AvailableOnly: c.Query("available") == "true",
+		Section:       c.Query("section"),
+	}
+
+	page, err := h.eventUsecase.ListSeats(c.Request.Context(), eventID, cursor, limit, filter)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		}
+		logger.Error("handler: failed to list seats", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to list seats")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": page.Seats,
+		"meta": gin.H{
+			"limit":       limit,
+			"next_cursor": page.NextCursor,
+			"hasMore":     page.HasMore,
+		},
+	})
+}
+
+// StreamSeats godoc
+// @Summary      Stream event seats as NDJSON or live updates as SSE
+// @Description  Stream every seat of an event as newline-delimited JSON, one seat object per line in seat ID order. Seats are written as they are read, so full stadium maps are not held in memory.
+// @Description  With "Accept: text/event-stream" the endpoint instead stays open as a Server-Sent Events stream: a "ready" event once subscribed, then a "seats" event ({"event_id", "seat_ids", "is_booked"}) every time seats are booked or released. Open the stream before loading the seat map so no change is missed. The available and section filters do not apply to live updates.
+// @Tags         events
+// @Produce      application/x-ndjson
+// @Produce      text/event-stream
+// @Param        id path int true "Event ID" example(1)
+// @Param        available query bool false "Only stream seats that are not booked"
+// @Param        section query string false "Only stream seats in this section" example(VIP)
+// @Success      200 {object} entity.Seat "One seat per line"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /events/{id}/seats/stream [get]
+func (h *EventHandler) StreamSeats(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	if strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		h.streamSeatUpdates(c, eventID)
+		return
+	}
+
+	filter := entity.SeatFilter{
+		AvailableOnly: c.Query("available") == "true",
+		Section:       c.Query("section"),
+	}
+
+	enc := json.NewEncoder(c.Writer)
+	streamed := 0
+	err = h.eventUsecase.StreamSeats(c.Request.Context(), eventID, filter, func(seat entity.Seat) error {
+		if streamed == 0 {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Status(http.StatusOK)
+		}
+		// A write error means the client went away; stop reading rows
+		if err := enc.Encode(seat); err != nil {
+			return err
+		}
+		streamed++
+		if streamed%seatStreamFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+
+	if err != nil && !c.Writer.Written() {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		}
+		logger.Error("handler: failed to stream seats", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to stream seats")
+		return
+	}
+	if err != nil {
+		// Headers are already sent, so the client sees a truncated stream
+		logger.Warn("handler: seat stream aborted",
+			logger.Int64("event_id", eventID),
+			logger.Int("streamed", streamed),
+			logger.Err(err),
+		)
+		return
+	}
+
+	if streamed == 0 {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+	}
+	c.Writer.Flush()
+}
+
+// streamSeatUpdates pushes seat updates to the client as Server-Sent Events
+// until it disconnects or the server shuts down.
+func (h *EventHandler) streamSeatUpdates(c *gin.Context, eventID int64) {
+	updates, err := h.eventUsecase.SubscribeSeatUpdates(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		}
+		logger.Error("handler: failed to subscribe to seat updates", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to stream seat updates")
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.SSEvent("ready", gin.H{"event_id": eventID})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(seatUpdateHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			c.SSEvent("seats", update)
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		case <-h.closing:
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
 type updateEventRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Location string `json:"location" binding:"required"`
-	Date     string `json:"date" binding:"required"`
+	Date     string `json:"date" binding:"required" example:"2026-12-31T19:00:00+07:00"`
 	Capacity int    `json:"capacity" binding:"required,min=1"`
+	// Category and the fields below are kept when omitted; an empty string
+	// (or empty object for metadata) clears them
+	Category      *string                `json:"category" binding:"omitempty,max=50"`
+	Description   *string                `json:"description" binding:"omitempty,max=2000"`
+	Terms         *string                `json:"terms" binding:"omitempty,max=20000"`
+	OrganizerName *string                `json:"organizer_name" binding:"omitempty,max=150"`
+	DoorsOpen     *string                `json:"doors_open" example:"2026-12-31 18:00"`
+	Metadata      map[string]interface{} `json:"metadata" swaggertype:"object"`
 }
 
 // Update godoc
 // @Summary      Update an event
-// @Description  Update event details. Admin access required. Capacity changes will create/delete seats accordingly.
+// @Description  Update event details. Admin access required. Dates take the same formats as on create; a changed date must be at least the configured lead time in the future. An omitted category, description, terms, organizer_name, doors_open or metadata keeps the current value; an empty string (or `{}` for metadata) clears it. Raising the capacity creates seats that follow the event's seat numbering scheme. Lowering it deletes the highest numbered seats that were never booked; it is rejected with 409 and the lowest allowed capacity when too few seats are free. For general admission events the remaining ticket count moves with the capacity, which cannot drop below the tickets already sold.
 // @Tags         events
 // @Accept       json
 // @Produce      json
@@ -179,18 +711,19 @@ type updateEventRequest struct {
 // @Param        id path int true "Event ID" example(1)
 // @Param        request body updateEventRequest true "Event update details"
 // @Success      200 {object} map[string]interface{} "Event updated successfully"
-// @Failure      400 {object} map[string]string "Invalid request or date format"
-// @Failure      401 {object} map[string]string "User not authenticated"
-// @Failure      403 {object} map[string]string "Access forbidden - admin only"
-// @Failure      404 {object} map[string]string "Event not found"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request, seat numbering, door time or metadata, or validation_failed with the invalid date fields under details"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      409 {object} middleware.ErrorResponse "Capacity below the seats in use or tickets sold"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
 // @Router       /events/{id} [put]
 func (h *EventHandler) Update(c *gin.Context) {
 	idParam := c.Param("id")
 	eventID, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
 		logger.Warn("handler: invalid event ID for update", logger.String("id", idParam))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
 		return
 	}
 
@@ -199,21 +732,33 @@ func (h *EventHandler) Update(c *gin.Context) {
 	existingEvent, err := h.eventUsecase.GetEventByID(c.Request.Context(), eventID)
 	if err != nil {
 		logger.Warn("handler: event not found for update", logger.Int64("event_id", eventID))
-		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		middleware.RespondError(c, http.StatusNotFound, "Event not found")
 		return
 	}
 
 	var req updateEventRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Warn("handler: invalid update event request", logger.Err(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	parsedDate, err := time.Parse("2006-01-02 15:04", req.Date)
+	invalid := &entity.ValidationError{}
+	parsedDate, err := entity.ParseEventTime(req.Date)
 	if err != nil {
-		logger.Warn("handler: invalid date format for update", logger.String("date", req.Date))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD HH:MM"})
+		invalid.Add("date", "must be "+entity.EventTimeFormats)
+	}
+	var doorsOpen *time.Time
+	if req.DoorsOpen != nil && *req.DoorsOpen != "" {
+		t, err := entity.ParseEventTime(*req.DoorsOpen)
+		if err != nil {
+			invalid.Add("doors_open", "must be "+entity.EventTimeFormats)
+		}
+		doorsOpen = &t
+	}
+	if err := invalid.OrNil(); err != nil {
+		logger.Warn("handler: invalid event times for update", logger.Int64("event_id", eventID), logger.String("date", req.Date))
+		c.Error(err)
 		return
 	}
 
@@ -223,42 +768,559 @@ func (h *EventHandler) Update(c *gin.Context) {
 		Location:  req.Location,
 		Date:      parsedDate,
 		Capacity:  req.Capacity,
+		Category:  existingEvent.Category,
 		UpdatedAt: time.Now(),
+
+		SeatNumbering: existingEvent.SeatNumbering,
+		AdmissionMode: existingEvent.AdmissionMode,
+		Content:       existingEvent.Content,
+		Description:   existingEvent.Description,
+		Terms:         existingEvent.Terms,
+		OrganizerName: existingEvent.OrganizerName,
+		Metadata:      existingEvent.Metadata,
+	}
+	for _, f := range []struct {
+		src *string
+		dst *string
+	}{
+		{req.Category, &event.Category},
+		{req.Description, &event.Description},
+		{req.Terms, &event.Terms},
+		{req.OrganizerName, &event.OrganizerName},
+	} {
+		if f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+	if req.Metadata != nil {
+		event.Metadata = req.Metadata
+	}
+	if req.DoorsOpen != nil {
+		// Copy the content so only the door time changes
+		content := entity.EventContent{}
+		if existingEvent.Content != nil {
+			content = *existingEvent.Content
+		}
+		content.DoorsOpenAt = doorsOpen
+		event.Content = &content
 	}
 
 	if err := h.eventUsecase.EditEvent(c.Request.Context(), event, int64(existingEvent.Capacity)); err != nil {
+		if errors.Is(err, entity.ErrValidation) {
+			logger.Warn("handler: event update rejected", logger.Int64("event_id", eventID), logger.Err(err))
+			c.Error(err)
+			return
+		}
+		if errors.Is(err, entity.ErrInvalidSeatNumbering) || errors.Is(err, entity.ErrInvalidEventMetadata) || errors.Is(err, entity.ErrInvalidEventContent) {
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+			return
+		}
+		var belowBooked *entity.CapacityBelowBookedError
+		if errors.As(err, &belowBooked) {
+			middleware.RespondErrorDetails(c, http.StatusConflict, err.Error(), gin.H{"min_capacity": belowBooked.Minimum})
+			return
+		}
+		if errors.Is(err, entity.ErrCapacityBelowSold) {
+			middleware.RespondEntityError(c, http.StatusConflict, err, err.Error())
+			return
+		}
 		logger.Error("handler: failed to update event", logger.Int64("event_id", eventID), logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	logger.Info("handler: event updated", logger.Int64("event_id", eventID))
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Event updated successfully",
+		"message": middleware.T(c, "Event updated successfully"),
 		"data":    event,
 	})
 }
 
+// UpdateContent godoc
+// @Summary      Update event page content
+// @Description  Replace the FAQ, door opening time, prohibited items and description blocks shown on the event detail endpoint. Block types: heading and paragraph (text), list (items) and image (image_url, optional text caption). Send an empty object to clear the content. Organizer access required; only the event's organizer can edit it.
+// @Tags         organizer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body entity.EventContent true "Event content"
+// @Success      200 {object} entity.EventContent "Content updated"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID or content"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/content [put]
+func (h *EventHandler) UpdateContent(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var content entity.EventContent
+	if err := c.ShouldBindJSON(&content); err != nil {
+		logger.Warn("handler: invalid event content request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.eventUsecase.UpdateEventContent(c.Request.Context(), eventID, organizerID, &content); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+		case errors.Is(err, entity.ErrInvalidEventContent):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+		default:
+			logger.Error("handler: failed to update event content", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to update event content")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Event content updated"),
+		"data":    content,
+	})
+}
+
+// SetRefundPolicy godoc
+// @Summary      Set the event's refund policy
+// @Description  Set how much customers get back when they ask for a refund: everything up to full_refund_days before the event, partial_refund_percent up to partial_refund_days before it, and nothing after that or once the event starts. Leave partial_refund_percent at 0 for no partial tier. The policy is shown as refund_policy on the event detail and applied to refund requests filed from now on; requests already filed keep their amount. Organizer access required; only the event's organizer can set it.
+// @Tags         organizer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body entity.RefundPolicy true "Refund policy"
+// @Success      200 {object} entity.RefundPolicy "Refund policy set"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID or policy"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/refund-policy [put]
+func (h *EventHandler) SetRefundPolicy(c *gin.Context) {
+	var policy entity.RefundPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		logger.Warn("handler: invalid refund policy request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.setRefundPolicy(c, &policy, "Refund policy set")
+}
+
+// ClearRefundPolicy godoc
+// @Summary      Remove the event's refund policy
+// @Description  Remove the event's refund policy so customers can ask for a full refund again. Organizer access required; only the event's organizer can remove it.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} map[string]interface{} "Refund policy removed"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/refund-policy [delete]
+func (h *EventHandler) ClearRefundPolicy(c *gin.Context) {
+	h.setRefundPolicy(c, nil, "Refund policy removed")
+}
+
+func (h *EventHandler) setRefundPolicy(c *gin.Context, policy *entity.RefundPolicy, message string) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	if err := h.eventUsecase.SetRefundPolicy(c.Request.Context(), eventID, organizerID, policy); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+		case errors.Is(err, entity.ErrInvalidRefundPolicy):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+		default:
+			logger.Error("handler: failed to set refund policy", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to set refund policy")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, message),
+		"data":    gin.H{"event_id": eventID, "refund_policy": policy},
+	})
+}
+
+// UploadImage godoc
+// @Summary      Upload an event poster
+// @Description  Set the event's poster image (JPEG, PNG or WebP, max 5MB), replacing any previous one. The poster's URL is returned as `image_url` on the event list and detail. Admins can set any event's poster; organizers only their own events'.
+// @Tags         events
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        file formData file true "Image file"
+// @Success      201 {object} map[string]interface{} "Poster uploaded"
+// @Failure      400 {object} middleware.ErrorResponse "Missing or invalid image"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin or organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/image [put]
+// @Router       /organizer/events/{id}/image [put]
+func (h *EventHandler) UploadImage(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := int64(userIDFloat.(float64))
+
+	// Admins manage every event, organizers only their own
+	var organizerID *int64
+	if role, _ := c.Get("role"); role != "admin" {
+		organizerID = &userID
+	}
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "File is required")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error("handler: failed to open uploaded file", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, "Could not read file")
+		return
+	}
+	defer file.Close()
+
+	// Sniff the content type instead of trusting the client-supplied header
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		logger.Error("handler: failed to rewind uploaded file", logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to upload image")
+		return
+	}
+
+	url, err := h.eventUsecase.UploadEventImage(c.Request.Context(), eventID, organizerID, http.DetectContentType(head[:n]), fileHeader.Size, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidEventImage):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+		default:
+			logger.Error("handler: failed to upload event image", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to upload image")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Event image uploaded"),
+		"data":    gin.H{"event_id": eventID, "image_url": url},
+	})
+}
+
+// UpdateLayout godoc
+// @Summary      Define the seat map layout (Admin)
+// @Description  Place existing seats on the seat map by seat number: section, row, column and x/y coordinates. Seats not listed keep their placement. The whole layout is rejected if any seat number is unknown. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body entity.SeatLayout true "Seat placements"
+// @Success      200 {object} map[string]interface{} "Layout updated"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID or layout"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/layout [put]
+func (h *EventHandler) UpdateLayout(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var layout entity.SeatLayout
+	if err := c.ShouldBindJSON(&layout); err != nil {
+		logger.Warn("handler: invalid seat layout request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.eventUsecase.UpdateSeatLayout(c.Request.Context(), eventID, &layout); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+		case errors.Is(err, entity.ErrInvalidSeatLayout):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+		default:
+			logger.Error("handler: failed to update seat layout", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to update seat layout")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Seat layout updated"),
+		"data":    gin.H{"seats_placed": len(layout.Seats)},
+	})
+}
+
+type ticketLimitRequest struct {
+	// MaxTicketsPerUser null falls back to the configured default
+	MaxTicketsPerUser *int `json:"max_tickets_per_user" binding:"omitempty,min=1" example:"4"`
+}
+
+// SetTicketLimit godoc
+// @Summary      Set the per-user ticket limit (Admin)
+// @Description  Cap how many tickets one user may hold for the event, counting seats and general admission tickets in PAID and PENDING bookings. Bookings that would go past it are rejected with 409. Send null to fall back to the default limit (BOOKING_MAX_TICKETS_PER_USER). Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body ticketLimitRequest true "Ticket limit"
+// @Success      200 {object} map[string]interface{} "Ticket limit set"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID or limit"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/ticket-limit [put]
+func (h *EventHandler) SetTicketLimit(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req ticketLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid ticket limit request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.eventUsecase.SetTicketLimit(c.Request.Context(), eventID, req.MaxTicketsPerUser); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidTicketLimit):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+		default:
+			logger.Error("handler: failed to set ticket limit", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to set ticket limit")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Ticket limit set"),
+		"data":    gin.H{"event_id": eventID, "max_tickets_per_user": req.MaxTicketsPerUser},
+	})
+}
+
+type bookingRateLimitRequest struct {
+	// BookingRateLimit null falls back to the configured default
+	BookingRateLimit *int `json:"booking_rate_limit" binding:"omitempty,min=1" example:"200"`
+}
+
+// SetBookingRateLimit godoc
+// @Summary      Set the booking request budget (Admin)
+// @Description  Set how many booking requests per second the event takes, counted across all API instances, so one busy on-sale can't starve the rest of the platform. Requests over it are rejected with 429 and a Retry-After header; users holding a queue token from the waiting room skip it. Send null to fall back to the default budget (BOOKING_EVENT_RATE_LIMIT). Changes take effect within 10 seconds. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body bookingRateLimitRequest true "Booking requests per second"
+// @Success      200 {object} map[string]interface{} "Booking rate limit set"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID or limit"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/booking-rate-limit [put]
+func (h *EventHandler) SetBookingRateLimit(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req bookingRateLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid booking rate limit request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.eventUsecase.SetBookingRateLimit(c.Request.Context(), eventID, req.BookingRateLimit); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidBookingRateLimit):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+		default:
+			logger.Error("handler: failed to set booking rate limit", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to set booking rate limit")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Booking rate limit set"),
+		"data":    gin.H{"event_id": eventID, "booking_rate_limit": req.BookingRateLimit},
+	})
+}
+
+type salesWavesRequest struct {
+	Waves []salesWaveInput `json:"waves" binding:"max=50,dive"`
+}
+
+type salesWaveInput struct {
+	StartsAt time.Time `json:"starts_at" binding:"required" example:"2026-03-01T10:00:00Z"`
+	Quantity int       `json:"quantity" binding:"required,min=1" example:"1000"`
+}
+
+// GetSalesWaves godoc
+// @Summary      Get the sales waves of an event (Admin)
+// @Description  The waves the event's tickets go on sale in, with how many tickets are released so far, how many are taken by PAID and PENDING bookings, and when the next wave starts. release is omitted when the event has no waves. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} entity.SalesWaveSchedule "Sales waves"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/sales-waves [get]
+func (h *EventHandler) GetSalesWaves(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	schedule, err := h.eventUsecase.GetSalesWaves(c.Request.Context(), eventID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+// SetSalesWaves godoc
+// @Summary      Set the sales waves of an event (Admin)
+// @Description  Replace the schedule the event's tickets go on sale in, e.g. 1000 tickets at 10:00 and 1000 more at 12:00. The tickets on sale at a moment are the quantities of the waves started by then, and a booking that would take more than that is rejected with 409 sales_wave_sold_out; seats and general admission tickets in PAID and PENDING bookings count. Send an empty list to put all tickets on sale. At most 50 waves. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body salesWavesRequest true "Sales waves"
+// @Success      200 {object} entity.SalesWaveSchedule "Sales waves set"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID or waves"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/sales-waves [put]
+func (h *EventHandler) SetSalesWaves(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req salesWavesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid sales waves request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	waves := make([]entity.SalesWave, len(req.Waves))
+	for i, w := range req.Waves {
+		waves[i] = entity.SalesWave{StartsAt: w.StartsAt, Quantity: w.Quantity}
+	}
+	schedule, err := h.eventUsecase.SetSalesWaves(c.Request.Context(), eventID, waves)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
 // Delete godoc
 // @Summary      Cancel an event
-// @Description  Cancel an event and start automatic refund process for all bookings. Admin access required.
+// @Description  Cancel and delete an event and start automatic refund process for all bookings. The deleted event disappears from the event list and detail pages; admins still see it with include_deleted=true on GET /admin/events and can restore it. With dry_run=true nothing is changed; the response lists the bookings that would be refunded (PAID) or cancelled (PENDING) and the amounts involved. Admin access required.
 // @Tags         events
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Event ID" example(1)
-// @Success      200 {object} map[string]string "Event cancelled successfully, refund process started"
-// @Failure      400 {object} map[string]string "Invalid event ID"
-// @Failure      401 {object} map[string]string "User not authenticated"
-// @Failure      403 {object} map[string]string "Access forbidden - admin only"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Param        dry_run query bool false "Preview the affected bookings without cancelling"
+// @Success      200 {object} map[string]interface{} "Event cancelled successfully, refund process started, or the dry run result"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found or already deleted"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
 // @Router       /events/{id} [delete]
 func (h *EventHandler) Delete(c *gin.Context) {
 	idParam := c.Param("id")
 	eventID, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
 		logger.Warn("handler: invalid event ID for delete", logger.String("id", idParam))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	if c.Query("dry_run") == "true" {
+		result, err := h.eventUsecase.PreviewCancellation(c.Request.Context(), eventID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"message": middleware.T(c, "Dry run: nothing was changed"),
+			"data":    result,
+		})
 		return
 	}
 
@@ -267,12 +1329,45 @@ func (h *EventHandler) Delete(c *gin.Context) {
 	err = h.eventUsecase.CancelEvent(c.Request.Context(), eventID)
 	if err != nil {
 		logger.Error("handler: failed to cancel event", logger.Int64("event_id", eventID), logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	logger.Info("handler: event cancelled", logger.Int64("event_id", eventID))
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Event cancelled. Refund process started in background.",
+		"message": middleware.T(c, "Event cancelled. Refund process started in background."),
+	})
+}
+
+// Restore godoc
+// @Summary      Restore a deleted event
+// @Description  List a deleted event again with the status it had before it was deleted. Refunds made when it was cancelled are not undone. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} map[string]interface{} "Event restored"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found or not deleted"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/restore [post]
+func (h *EventHandler) Restore(c *gin.Context) {
+	idParam := c.Param("id")
+	eventID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		logger.Warn("handler: invalid event ID for restore", logger.String("id", idParam))
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	if err := h.eventUsecase.RestoreEvent(c.Request.Context(), eventID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Event restored"),
 	})
 }
@@ -0,0 +1,198 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EventStaffHandler struct {
+	staffUC usecase.EventStaffUsecase
+}
+
+func NewEventStaffHandler(uc usecase.EventStaffUsecase) *EventStaffHandler {
+	return &EventStaffHandler{staffUC: uc}
+}
+
+type grantStaffRequest struct {
+	UserID int64  `json:"user_id" binding:"required" example:"7"`
+	Scope  string `json:"scope" binding:"required" example:"checkin"`
+}
+
+func eventStaffError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidStaffScope), errors.Is(err, entity.ErrNotStaffAccount):
+		middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+	case errors.Is(err, entity.ErrNotFound):
+		middleware.RespondEntityError(c, http.StatusNotFound, err, "Event or staff access not found")
+	case errors.Is(err, entity.ErrStaffAccessExists):
+		middleware.RespondEntityError(c, http.StatusConflict, err, err.Error())
+	default:
+		logger.Error("handler: failed to "+action, logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to "+action)
+	}
+}
+
+// Grant godoc
+// @Summary      Grant staff access to an event (Organizer)
+// @Description  Give a staff account one scope on an event of the organizer: `checkin` to scan tickets at the gate, `reports` to view the event's sales forecast and comparison. Call once per scope. The grant is recorded in the audit log.
+// @Tags         organizer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body grantStaffRequest true "Staff account and scope"
+// @Success      201 {object} entity.EventStaff "Access granted"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request, scope, or user is not staff"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event or user not found"
+// @Failure      409 {object} middleware.ErrorResponse "Scope already granted"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/staff [post]
+func (h *EventStaffHandler) Grant(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req grantStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	grant := &entity.EventStaff{EventID: eventID, UserID: req.UserID, Scope: req.Scope}
+	if err := h.staffUC.GrantAccess(c.Request.Context(), organizerID, grant); err != nil {
+		eventStaffError(c, err, "grant staff access")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Staff access granted"),
+		"data":    grant,
+	})
+}
+
+// Revoke godoc
+// @Summary      Revoke staff access to an event (Organizer)
+// @Description  Take one scope on the event away from a staff account. The revocation is recorded in the audit log.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        user_id path int true "Staff user ID" example(7)
+// @Param        scope query string true "Scope to revoke" Enums(checkin, reports)
+// @Success      200 {object} map[string]string "Access revoked"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid ID or scope"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event or staff access not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/staff/{user_id} [delete]
+func (h *EventStaffHandler) Revoke(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	staffID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.staffUC.RevokeAccess(c.Request.Context(), organizerID, eventID, staffID, c.Query("scope")); err != nil {
+		eventStaffError(c, err, "revoke staff access")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Staff access revoked")})
+}
+
+// List godoc
+// @Summary      List event staff (Organizer)
+// @Description  Staff accounts with access to an event of the organizer, one entry per granted scope.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {array} entity.EventStaff "Event staff"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/staff [get]
+func (h *EventStaffHandler) List(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	staff, err := h.staffUC.ListEventStaff(c.Request.Context(), organizerID, eventID)
+	if err != nil {
+		eventStaffError(c, err, "list event staff")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": staff})
+}
+
+// ListMine godoc
+// @Summary      List assigned events (Staff)
+// @Description  Events the calling staff account was given access to, with the granted scopes, soonest event first.
+// @Tags         staff
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} entity.EventStaff "Assigned events"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - staff only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /staff/events [get]
+func (h *EventStaffHandler) ListMine(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	staffID := int64(userIDFloat.(float64))
+
+	events, err := h.staffUC.ListMyEvents(c.Request.Context(), staffID)
+	if err != nil {
+		eventStaffError(c, err, "list assigned events")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": events})
+}
@@ -0,0 +1,242 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExperimentHandler struct {
+	experimentUC usecase.ExperimentUsecase
+}
+
+func NewExperimentHandler(uc usecase.ExperimentUsecase) *ExperimentHandler {
+	return &ExperimentHandler{experimentUC: uc}
+}
+
+type pricingVariantRequest struct {
+	Key             string  `json:"key" binding:"required,max=50" example:"discount_10"`
+	Weight          int     `json:"weight" binding:"required,min=1" example:"50"`
+	PriceMultiplier float64 `json:"price_multiplier" binding:"required" example:"0.9"`
+	FeeDisplay      string  `json:"fee_display" binding:"omitempty,oneof=inclusive itemized" example:"itemized"`
+}
+
+type createExperimentRequest struct {
+	Name     string                  `json:"name" binding:"required,max=150" example:"Early bird discount"`
+	Variants []pricingVariantRequest `json:"variants" binding:"required,min=2,dive"`
+}
+
+// Create godoc
+// @Summary      Start a pricing experiment
+// @Description  Start an A/B pricing experiment on an event. Buyers are bucketed into variants by weight; each variant sets a price multiplier (0.5-2.0) and whether fees are shown inclusive or itemized. Only one experiment may run per event. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body createExperimentRequest true "Experiment definition"
+// @Success      201 {object} map[string]interface{} "Experiment started"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request or variants"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      409 {object} middleware.ErrorResponse "Event already has an active experiment"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/experiments [post]
+func (h *ExperimentHandler) Create(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	adminID := int64(userIDFloat.(float64))
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req createExperimentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid create experiment request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	exp := &entity.PricingExperiment{
+		EventID:   eventID,
+		Name:      req.Name,
+		CreatedBy: adminID,
+	}
+	for _, v := range req.Variants {
+		exp.Variants = append(exp.Variants, entity.PricingVariant{
+			Key:             v.Key,
+			Weight:          v.Weight,
+			PriceMultiplier: v.PriceMultiplier,
+			FeeDisplay:      v.FeeDisplay,
+		})
+	}
+
+	if err := h.experimentUC.CreateExperiment(c.Request.Context(), exp); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidExperiment):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "An experiment needs at least two variants with unique keys, positive weights and a price multiplier between 0.5 and 2.0")
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+		case errors.Is(err, entity.ErrExperimentActive):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Event already has an active experiment")
+		default:
+			logger.Error("handler: failed to create experiment", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to create experiment")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Experiment started"),
+		"data":    exp,
+	})
+}
+
+// List godoc
+// @Summary      List pricing experiments
+// @Description  List all pricing experiments run on an event, newest first. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} map[string]interface{} "Experiments"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/experiments [get]
+func (h *ExperimentHandler) List(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	experiments, err := h.experimentUC.ListExperiments(c.Request.Context(), eventID)
+	if err != nil {
+		logger.Error("handler: failed to list experiments", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to list experiments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": experiments})
+}
+
+// Stop godoc
+// @Summary      Stop a pricing experiment
+// @Description  End an active experiment. New bookings go back to list price; results remain available. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Experiment ID" example(1)
+// @Success      200 {object} map[string]string "Experiment stopped"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid experiment ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      409 {object} middleware.ErrorResponse "Experiment is not active"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/experiments/{id}/stop [post]
+func (h *ExperimentHandler) Stop(c *gin.Context) {
+	experimentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid experiment ID")
+		return
+	}
+
+	if err := h.experimentUC.StopExperiment(c.Request.Context(), experimentID); err != nil {
+		if errors.Is(err, entity.ErrExperimentNotActive) {
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Experiment is not active")
+			return
+		}
+		logger.Error("handler: failed to stop experiment", logger.Int64("experiment_id", experimentID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to stop experiment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Experiment stopped")})
+}
+
+// Results godoc
+// @Summary      Pricing experiment results
+// @Description  Exposures, bookings, paid bookings, revenue, conversion rate and revenue per exposed user for each variant. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Experiment ID" example(1)
+// @Success      200 {object} entity.ExperimentResults "Experiment results"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid experiment ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Experiment not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/experiments/{id}/results [get]
+func (h *ExperimentHandler) Results(c *gin.Context) {
+	experimentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid experiment ID")
+		return
+	}
+
+	results, err := h.experimentUC.GetResults(c.Request.Context(), experimentID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Experiment not found")
+			return
+		}
+		logger.Error("handler: failed to get experiment results", logger.Int64("experiment_id", experimentID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get experiment results")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": results})
+}
+
+// Pricing godoc
+// @Summary      Get my pricing variant for an event
+// @Description  Return the pricing variant the caller is assigned to for the event's running experiment, so the client can show the matching price and fee display. The assignment is stable per user. Returns null data when no experiment is running.
+// @Tags         events
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} map[string]interface{} "Assigned variant or null"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /events/{id}/pricing [get]
+func (h *ExperimentHandler) Pricing(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := int64(userIDFloat.(float64))
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	variant, err := h.experimentUC.AssignVariant(c.Request.Context(), eventID, userID)
+	if err != nil {
+		logger.Error("handler: failed to assign pricing variant", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get pricing")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": variant})
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FunnelHandler struct {
+	funnelUC usecase.FunnelUsecase
+}
+
+func NewFunnelHandler(uc usecase.FunnelUsecase) *FunnelHandler {
+	return &FunnelHandler{funnelUC: uc}
+}
+
+type issueQueueTokensRequest struct {
+	UserIDs []int64 `json:"user_ids" binding:"required,min=1,max=1000" example:"42,43"`
+	// TTLSeconds is how long the tokens stay valid, 900 when left out
+	TTLSeconds int `json:"ttl_seconds" binding:"min=0,max=3600" example:"900"`
+}
+
+// IssueQueueTokens godoc
+// @Summary      Issue waiting room queue tokens (Admin)
+// @Description  Admit users let through the event's waiting room to its booking funnel. Each token is bound to one user and the event; while it is valid, sending it in the X-Queue-Token header of POST /bookings skips the event's booking requests per second budget. Up to 1000 users per request; tokens last ttl_seconds (default 900, max 3600). Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body issueQueueTokensRequest true "Users to admit"
+// @Success      201 {array} entity.QueueToken "Queue tokens"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID, users or TTL"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/queue-tokens [post]
+func (h *FunnelHandler) IssueQueueTokens(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req issueQueueTokensRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid queue token request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ttl := time.Duration(req.TTLSeconds) * time.Second
+	tokens, err := h.funnelUC.IssueQueueTokens(c.Request.Context(), eventID, req.UserIDs, ttl)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidQueueToken):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+		default:
+			logger.Error("handler: failed to issue queue tokens", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to issue queue tokens")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Queue tokens issued"),
+		"data":    tokens,
+	})
+}
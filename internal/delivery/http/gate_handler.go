@@ -0,0 +1,205 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GateHandler struct {
+	gateUC usecase.GateUsecase
+}
+
+func NewGateHandler(uc usecase.GateUsecase) *GateHandler {
+	return &GateHandler{gateUC: uc}
+}
+
+type createGateRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"North Stand Turnstile 3"`
+}
+
+type scanBatchRequest struct {
+	Codes []string `json:"codes" binding:"required,min=1,max=500" example:"TCK-9f86d081884c7d659a2feaa0c55ad015,TCK-3e23e8160039594a33894f6564e1b134"`
+}
+
+func gateError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidScanBatch):
+		middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+	case errors.Is(err, entity.ErrNotFound):
+		middleware.RespondEntityError(c, http.StatusNotFound, err, "Event or gate not found")
+	default:
+		logger.Error("handler: failed to "+action, logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to "+action)
+	}
+}
+
+// Create godoc
+// @Summary      Register a turnstile gate (Organizer)
+// @Description  Create a gate for an event of the organizer and return its API key. The key is shown only in this response; turnstiles send it in the `X-Gate-Key` header to submit scans. The creation is recorded in the audit log.
+// @Tags         organizer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body createGateRequest true "Gate name"
+// @Success      201 {object} entity.Gate "Gate created, with its key"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request or event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/gates [post]
+func (h *GateHandler) Create(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req createGateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	gate := &entity.Gate{EventID: eventID, Name: req.Name}
+	if err := h.gateUC.CreateGate(c.Request.Context(), organizerID, gate); err != nil {
+		gateError(c, err, "create gate")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Gate created; store the key now, it is not shown again"),
+		"data":    gate,
+	})
+}
+
+// List godoc
+// @Summary      List turnstile gates (Organizer)
+// @Description  Gates of an event of the organizer, with when each last submitted scans. Keys are not included.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {array} entity.Gate "Gates"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/gates [get]
+func (h *GateHandler) List(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	gates, err := h.gateUC.ListGates(c.Request.Context(), organizerID, eventID)
+	if err != nil {
+		gateError(c, err, "list gates")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gates})
+}
+
+// Revoke godoc
+// @Summary      Revoke a turnstile gate (Organizer)
+// @Description  Disable a gate's API key; its scans are rejected from then on. Tickets it admitted stay checked in. The revocation is recorded in the audit log.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        gate_id path int true "Gate ID" example(3)
+// @Success      200 {object} map[string]string "Gate revoked"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event or active gate not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/gates/{gate_id} [delete]
+func (h *GateHandler) Revoke(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	gateID, err := strconv.ParseInt(c.Param("gate_id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid gate ID")
+		return
+	}
+
+	if err := h.gateUC.RevokeGate(c.Request.Context(), organizerID, eventID, gateID); err != nil {
+		gateError(c, err, "revoke gate")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Gate revoked")})
+}
+
+// ScanBatch godoc
+// @Summary      Validate a batch of scanned tickets (Turnstile)
+// @Description  Check in up to 500 scanned ticket codes at the gate's event in one round trip. Every code gets a verdict, in the order sent: `admitted`, `already_used`, `wrong_event`, `not_paid`, `revoked` or `unknown`. A code sent twice is admitted at most once. Authenticated with the gate's API key in `X-Gate-Key`.
+// @Tags         checkin
+// @Accept       json
+// @Produce      json
+// @Security     GateKey
+// @Param        request body scanBatchRequest true "Scanned codes"
+// @Success      200 {array} entity.ScanVerdict "Verdicts"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request or batch size"
+// @Failure      401 {object} middleware.ErrorResponse "Missing, invalid or revoked gate key"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /gate/scans [post]
+func (h *GateHandler) ScanBatch(c *gin.Context) {
+	value, exists := c.Get("gate")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	gate := value.(*entity.Gate)
+
+	var req scanBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	verdicts, err := h.gateUC.ScanBatch(c.Request.Context(), gate, req.Codes)
+	if err != nil {
+		gateError(c, err, "validate scans")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": verdicts})
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// invoiceLocation is where invoice years begin and end.
+var invoiceLocation = time.FixedZone("WIB", 7*60*60)
+
+type InvoiceHandler struct {
+	invoiceUC usecase.InvoiceUsecase
+}
+
+func NewInvoiceHandler(uc usecase.InvoiceUsecase) *InvoiceHandler {
+	return &InvoiceHandler{invoiceUC: uc}
+}
+
+// invoiceYear reads the year query parameter, defaulting to the current year.
+func invoiceYear(c *gin.Context) (int, bool) {
+	v := c.Query("year")
+	if v == "" {
+		return time.Now().In(invoiceLocation).Year(), true
+	}
+	year, err := strconv.Atoi(v)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid year")
+		return 0, false
+	}
+	return year, true
+}
+
+func invoiceError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidInvoiceYear):
+		middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+	default:
+		logger.Error("handler: failed to "+action, logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to "+action)
+	}
+}
+
+// List godoc
+// @Summary      List invoices (Admin)
+// @Description  Invoices of a year in number order, of every issuer or of one (`issuer_id`, 0 for the platform). With `format=csv` the list is downloaded as a CSV export. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        year query int false "Invoice year, defaults to the current year" example(2026)
+// @Param        issuer_id query int false "Organizer user ID, 0 for the platform"
+// @Param        format query string false "Response format" Enums(json, csv)
+// @Success      200 {array} entity.Invoice "Invoices"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid year or issuer"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/invoices [get]
+func (h *InvoiceHandler) List(c *gin.Context) {
+	year, ok := invoiceYear(c)
+	if !ok {
+		return
+	}
+
+	var issuerID *int64
+	if v := c.Query("issuer_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, "Invalid issuer ID")
+			return
+		}
+		issuerID = &id
+	}
+
+	h.respond(c, issuerID, year)
+}
+
+// ListMine godoc
+// @Summary      List own invoices (Organizer)
+// @Description  Invoices issued for the organizer's events in a year, in number order. With `format=csv` the list is downloaded as a CSV export.
+// @Tags         organizer
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        year query int false "Invoice year, defaults to the current year" example(2026)
+// @Param        format query string false "Response format" Enums(json, csv)
+// @Success      200 {array} entity.Invoice "Invoices"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid year"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/invoices [get]
+func (h *InvoiceHandler) ListMine(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	year, ok := invoiceYear(c)
+	if !ok {
+		return
+	}
+
+	h.respond(c, &organizerID, year)
+}
+
+func (h *InvoiceHandler) respond(c *gin.Context, issuerID *int64, year int) {
+	invoices, err := h.invoiceUC.ListInvoices(c.Request.Context(), issuerID, year)
+	if err != nil {
+		invoiceError(c, err, "list invoices")
+		return
+	}
+
+	if c.Query("format") != "csv" {
+		c.JSON(http.StatusOK, gin.H{"data": invoices})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="invoices-%d.csv"`, year))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"invoice_number", "issuer_id", "year", "sequence", "payment_id", "booking_id", "event_id", "amount", "issued_at"})
+	for _, inv := range invoices {
+		w.Write([]string{
+			inv.Number,
+			strconv.FormatInt(inv.IssuerID, 10),
+			strconv.Itoa(inv.Year),
+			strconv.Itoa(inv.Sequence),
+			strconv.FormatInt(inv.PaymentID, 10),
+			strconv.FormatInt(inv.BookingID, 10),
+			strconv.FormatInt(inv.EventID, 10),
+			strconv.FormatFloat(inv.Amount, 'f', 2, 64),
+			inv.IssuedAt.Format(time.RFC3339),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		logger.Error("handler: failed to write invoice export", logger.Int("year", year), logger.Err(err))
+	}
+}
+
+// Gaps godoc
+// @Summary      Invoice numbering gaps (Admin)
+// @Description  Numbers missing from a year's invoice series, per issuer. Numbers are taken in the transaction that completes the payment, so the list should be empty; anything listed needs to be explained to the tax office. At most 1000 gaps are returned. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        year query int false "Invoice year, defaults to the current year" example(2026)
+// @Success      200 {array} entity.InvoiceGap "Missing invoice numbers"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid year"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/invoices/gaps [get]
+func (h *InvoiceHandler) Gaps(c *gin.Context) {
+	year, ok := invoiceYear(c)
+	if !ok {
+		return
+	}
+
+	gaps, err := h.invoiceUC.FindGaps(c.Request.Context(), year)
+	if err != nil {
+		invoiceError(c, err, "check invoice numbering")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gaps})
+}
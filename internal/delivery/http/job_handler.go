@@ -0,0 +1,119 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	jobUC usecase.JobUsecase
+}
+
+func NewJobHandler(uc usecase.JobUsecase) *JobHandler {
+	return &JobHandler{jobUC: uc}
+}
+
+// ListDead godoc
+// @Summary      List dead-letter jobs (Admin)
+// @Description  Background jobs (emails, refunds) that failed every retry, most recent first, with the last error. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        limit query int false "Items per page (max 100)" default(20) minimum(1) maximum(100)
+// @Success      200 {object} map[string]interface{} "List of dead jobs with pagination metadata"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/jobs/dead [get]
+func (h *JobHandler) ListDead(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	jobs, total, err := h.jobUC.ListDeadJobs(c.Request.Context(), page, limit)
+	if err != nil {
+		logger.Error("handler: failed to list dead jobs", logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to list dead jobs")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": jobs,
+		"meta": gin.H{
+			"total":   total,
+			"page":    page,
+			"limit":   limit,
+			"hasMore": (page * limit) < total,
+		},
+	})
+}
+
+// Requeue godoc
+// @Summary      Retry a dead-letter job (Admin)
+// @Description  Move a dead job back to the queue with a fresh set of retry attempts. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Job ID" example(1)
+// @Success      200 {object} map[string]string "Job requeued"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid job ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Dead job not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/jobs/{id}/retry [post]
+func (h *JobHandler) Requeue(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid job ID")
+		return
+	}
+
+	if err := h.jobUC.RequeueDeadJob(c.Request.Context(), jobID); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Dead job not found")
+			return
+		}
+		logger.Error("handler: failed to requeue job", logger.Int64("job_id", jobID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to requeue job")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Job requeued")})
+}
+
+// QueueStats godoc
+// @Summary      Job queue backlog (Admin)
+// @Description  How many stored background jobs are due, scheduled, running or dead, how many are still in the outbox, and when the longest-waiting due job became due. Also the outbox limits, whether the outbox is backed up, the overflow mode (`persist` or `drop`), and how many jobs overflowed or were dropped since the API started. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} entity.JobQueueStats "Queue statistics"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/jobs/queue [get]
+func (h *JobHandler) QueueStats(c *gin.Context) {
+	stats, err := h.jobUC.QueueStats(c.Request.Context())
+	if err != nil {
+		logger.Error("handler: failed to count jobs", logger.Err(err))
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": stats})
+}
@@ -0,0 +1,29 @@
+package http
+
+import (
+	"net/http"
+
+	"ticres/pkg/jwtkeys"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	keys *jwtkeys.KeySet
+}
+
+func NewJWKSHandler(keys *jwtkeys.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// Get godoc
+// @Summary      Token signing keys
+// @Description  Public keys access tokens are signed with, as a JSON Web Key Set, so other services can verify tokens without the API's secret. Each RS256 token names its key in the kid header. During a key rotation the previous key is listed after the current one until the tokens it signed have expired. The set is empty while tokens are signed with HS256. Responses may be cached for 5 minutes.
+// @Tags         auth
+// @Produce      json
+// @Success      200 {object} jwtkeys.JWKS "Signing keys"
+// @Router       /.well-known/jwks.json [get]
+func (h *JWKSHandler) Get(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keys.JWKS())
+}
@@ -15,8 +15,7 @@ func AdminMiddleware(jwtSecret string) gin.HandlerFunc {
 			logger.Warn("middleware: admin check failed - no role in context",
 				logger.String("path", c.Request.URL.Path),
 			)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
-			c.Abort()
+			AbortWithError(c, http.StatusUnauthorized, "unauthorized")
 			return
 		}
 
@@ -25,8 +24,7 @@ func AdminMiddleware(jwtSecret string) gin.HandlerFunc {
 				logger.Any("role", userRole),
 				logger.String("path", c.Request.URL.Path),
 			)
-			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
-			c.Abort()
+			AbortWithError(c, http.StatusForbidden, "forbidden")
 			return
 		}
 
@@ -35,4 +33,4 @@ func AdminMiddleware(jwtSecret string) gin.HandlerFunc {
 		)
 		c.Next()
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries a machine client's API key.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyMiddleware authenticates requests that carry an API key instead of
+// a JWT, as the key's owner, when the key has a scope for area ("admin" or
+// "organizer"). GET and HEAD need a read scope, anything else a write
+// scope. Requests without the header are left to AuthMiddleware, which
+// skips its own check once a key was accepted.
+func APIKeyMiddleware(apiKeyUC usecase.APIKeyUsecase, area string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := c.GetHeader(APIKeyHeader)
+		if raw == "" {
+			c.Next()
+			return
+		}
+
+		key, err := apiKeyUC.AuthenticateAPIKey(c.Request.Context(), raw)
+		if err != nil {
+			if errors.Is(err, entity.ErrInvalidAPIKey) {
+				logger.Warn("middleware: invalid api key",
+					logger.String("path", c.Request.URL.Path),
+					logger.String("client_ip", c.ClientIP()),
+				)
+				AbortWithError(c, http.StatusUnauthorized, "invalid api key")
+			} else {
+				logger.Error("middleware: api key check failed", logger.Err(err))
+				AbortWithError(c, http.StatusInternalServerError, "Failed to check api key")
+			}
+			return
+		}
+
+		write := c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead
+		if !key.Allows(area, write) {
+			logger.Warn("middleware: api key scope denied",
+				logger.Int64("key_id", key.ID),
+				logger.String("method", c.Request.Method),
+				logger.String("path", c.Request.URL.Path),
+			)
+			AbortWithError(c, http.StatusForbidden, entity.ErrInsufficientScope.Error())
+			return
+		}
+
+		c.Set("userID", float64(key.UserID))
+		c.Set("role", key.OwnerRole)
+		c.Set("apiKeyID", key.ID)
+		ctx := usecase.WithActor(c.Request.Context(), key.UserID)
+		c.Request = c.Request.WithContext(usecase.WithAPIKey(ctx, key.ID))
+
+		logger.Debug("middleware: api key authenticated",
+			logger.Int64("key_id", key.ID),
+			logger.Int64("user_id", key.UserID),
+			logger.String("path", c.Request.URL.Path),
+		)
+		c.Next()
+	}
+}
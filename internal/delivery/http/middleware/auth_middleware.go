@@ -1,26 +1,36 @@
 package middleware
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strings"
 
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/jwtkeys"
 	"ticres/pkg/logger"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
+// AuthMiddleware accepts a bearer token signed with keys whose session is
+// still active. Tokens issued before sessions were tracked carry no session
+// and are accepted until they expire.
+func AuthMiddleware(keys *jwtkeys.KeySet, userUC usecase.UserUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Already authenticated by APIKeyMiddleware
+		if _, ok := c.Get("apiKeyID"); ok {
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			logger.Debug("middleware: missing authorization header",
 				logger.String("path", c.Request.URL.Path),
 				logger.String("method", c.Request.Method),
 			)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
-			c.Abort()
+			AbortWithError(c, http.StatusUnauthorized, "Authorization header is required")
 			return
 		}
 
@@ -29,50 +39,51 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			logger.Warn("middleware: invalid authorization format",
 				logger.String("path", c.Request.URL.Path),
 			)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization format"})
-			c.Abort()
+			AbortWithError(c, http.StatusUnauthorized, "Invalid authorization format")
 			return
 		}
 
 		tokenString := parts[1]
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !token.Valid {
+		claims, err := keys.Parse(tokenString)
+		if err != nil {
 			logger.Warn("middleware: invalid or expired token",
 				logger.String("path", c.Request.URL.Path),
 				logger.Err(err),
 			)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
-			c.Abort()
+			AbortWithError(c, http.StatusUnauthorized, "Invalid or expired token")
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			userID := claims["user_id"]
-			role := claims["role"]
+		userID := claims["user_id"]
+		role := claims["role"]
 
-			c.Set("userID", userID)
-			c.Set("role", role)
-
-			logger.Debug("middleware: user authenticated",
-				logger.Any("user_id", userID),
-				logger.Any("role", role),
-				logger.String("path", c.Request.URL.Path),
-			)
+		if sid, ok := claims["sid"].(float64); ok {
+			id, _ := userID.(float64)
+			if err := userUC.AuthenticateSession(c.Request.Context(), int64(id), int64(sid)); err != nil {
+				if errors.Is(err, entity.ErrSessionRevoked) {
+					AbortWithError(c, http.StatusUnauthorized, "Session has been revoked, please log in again")
+				} else {
+					logger.Error("middleware: session check failed", logger.Err(err))
+					AbortWithError(c, http.StatusInternalServerError, "Failed to check session")
+				}
+				return
+			}
+			c.Set("sessionID", int64(sid))
+		}
 
-			c.Next()
-		} else {
-			logger.Warn("middleware: invalid token claims",
-				logger.String("path", c.Request.URL.Path),
-			)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			c.Abort()
+		c.Set("userID", userID)
+		c.Set("role", role)
+		if id, ok := userID.(float64); ok {
+			c.Request = c.Request.WithContext(usecase.WithActor(c.Request.Context(), int64(id)))
 		}
+
+		logger.Debug("middleware: user authenticated",
+			logger.Any("user_id", userID),
+			logger.Any("role", role),
+			logger.String("path", c.Request.URL.Path),
+		)
+
+		c.Next()
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,270 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"ticres/internal/entity"
+	"ticres/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the body of every error the API returns. Code is a stable
+// machine-readable identifier; the human-readable message stays under
+// "error" so existing clients keep working. Details is optional extra
+// context, such as the field that failed validation.
+type ErrorResponse struct {
+	Code    string      `json:"code" example:"not_found"`
+	Message string      `json:"error" example:"data not found"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+}
+
+// errorMappings translates entity errors into HTTP statuses and codes.
+// Errors are matched with errors.Is in order, so wrapped errors map too.
+var errorMappings = []errorMapping{
+	{entity.ErrNotFound, http.StatusNotFound, "not_found"},
+	{entity.ErrUnauthorized, http.StatusForbidden, "forbidden"},
+	{entity.ErrEventAccessDenied, http.StatusForbidden, "event_access_denied"},
+	{entity.ErrInvalidGateKey, http.StatusUnauthorized, "invalid_gate_key"},
+	{entity.ErrInvalidResetToken, http.StatusBadRequest, "invalid_reset_token"},
+	{entity.ErrInvalidRole, http.StatusBadRequest, "invalid_role"},
+	{entity.ErrUserAlreadyExsist, http.StatusConflict, "user_exists"},
+	{entity.ErrWrongPassword, http.StatusBadRequest, "wrong_password"},
+	{entity.ErrInvalidProfile, http.StatusBadRequest, "invalid_profile"},
+	{entity.ErrUsernameTaken, http.StatusConflict, "username_taken"},
+	{entity.ErrAccountNotDeletable, http.StatusConflict, "account_not_deletable"},
+	{entity.ErrTwoFactorEnabled, http.StatusConflict, "two_factor_enabled"},
+	{entity.ErrTwoFactorNotSetUp, http.StatusConflict, "two_factor_not_set_up"},
+	{entity.ErrInvalidTwoFactorCode, http.StatusUnauthorized, "invalid_two_factor_code"},
+	{entity.ErrTooManyTwoFactorAttempts, http.StatusTooManyRequests, "too_many_two_factor_attempts"},
+	{entity.ErrInvalidLoginChallenge, http.StatusUnauthorized, "invalid_login_challenge"},
+	{entity.ErrOAuthProviderUnavailable, http.StatusNotFound, "oauth_provider_unavailable"},
+	{entity.ErrInvalidOAuthState, http.StatusBadRequest, "invalid_oauth_state"},
+	{entity.ErrInvalidOAuthCode, http.StatusBadRequest, "invalid_oauth_code"},
+	{entity.ErrOAuthEmailUnverified, http.StatusForbidden, "oauth_email_unverified"},
+	{entity.ErrOAuthAccountConflict, http.StatusConflict, "oauth_account_conflict"},
+	{entity.ErrInvalidAPIKey, http.StatusUnauthorized, "invalid_api_key"},
+	{entity.ErrInvalidAPIKeyRequest, http.StatusBadRequest, "invalid_api_key_request"},
+	{entity.ErrInsufficientScope, http.StatusForbidden, "insufficient_scope"},
+	{entity.ErrInvalidAuditFilter, http.StatusBadRequest, "invalid_audit_filter"},
+	{entity.ErrInvalidWebhook, http.StatusBadRequest, "invalid_webhook"},
+	{entity.ErrBackfillRunning, http.StatusConflict, "backfill_running"},
+
+	{entity.ErrInvalidBookingRequest, http.StatusBadRequest, "invalid_booking_request"},
+	{entity.ErrNotGeneralAdmission, http.StatusBadRequest, "not_general_admission"},
+	{entity.ErrSeatUnavailable, http.StatusConflict, "seat_unavailable"},
+	{entity.ErrSoldOut, http.StatusConflict, "sold_out"},
+	{entity.ErrNoAdjacentSeats, http.StatusConflict, "no_adjacent_seats"},
+	{entity.ErrSalesWaveSoldOut, http.StatusConflict, "sales_wave_sold_out"},
+	{entity.ErrBookingConflict, http.StatusConflict, "booking_conflict"},
+	{entity.ErrTicketLimitExceeded, http.StatusConflict, "ticket_limit_exceeded"},
+	{entity.ErrEventBusy, http.StatusTooManyRequests, "event_busy"},
+	{entity.ErrInvalidQueueToken, http.StatusBadRequest, "invalid_queue_token"},
+	{entity.ErrBookingNotPending, http.StatusConflict, "booking_not_pending"},
+	{entity.ErrBookingNotPaid, http.StatusConflict, "booking_not_paid"},
+	{entity.ErrBookingExpired, http.StatusGone, "booking_expired"},
+
+	{entity.ErrPaymentAlreadyMade, http.StatusConflict, "payment_already_made"},
+	{entity.ErrInvalidPaymentMethod, http.StatusBadRequest, "invalid_payment_method"},
+	{entity.ErrInvalidPaymentLink, http.StatusNotFound, "invalid_payment_link"},
+	{entity.ErrPaymentLinkExpired, http.StatusGone, "payment_link_expired"},
+	{entity.ErrPaymentDeclined, http.StatusPaymentRequired, "payment_declined"},
+	{entity.ErrGatewayUnavailable, http.StatusServiceUnavailable, "gateway_unavailable"},
+
+	{entity.ErrTicketWrongEvent, http.StatusUnprocessableEntity, "ticket_wrong_event"},
+	{entity.ErrTicketNotActive, http.StatusUnprocessableEntity, "ticket_not_active"},
+	{entity.ErrTicketAlreadyUsed, http.StatusConflict, "ticket_already_used"},
+	{entity.ErrTicketRevoked, http.StatusUnprocessableEntity, "ticket_revoked"},
+	{entity.ErrInvalidScanBatch, http.StatusBadRequest, "invalid_scan_batch"},
+
+	{entity.ErrApplicationExists, http.StatusConflict, "application_exists"},
+	{entity.ErrApplicationNotPending, http.StatusConflict, "application_not_pending"},
+	{entity.ErrInvalidDocument, http.StatusBadRequest, "invalid_document"},
+	{entity.ErrInvalidVerificationMethod, http.StatusBadRequest, "invalid_verification_method"},
+	{entity.ErrBankAccountNotPending, http.StatusConflict, "bank_account_not_pending"},
+	{entity.ErrBankAccountNotVerified, http.StatusConflict, "bank_account_not_verified"},
+	{entity.ErrVerificationFailed, http.StatusUnprocessableEntity, "verification_failed"},
+
+	{entity.ErrEventHasNoSeries, http.StatusBadRequest, "event_has_no_series"},
+	{entity.ErrExperimentActive, http.StatusConflict, "experiment_active"},
+	{entity.ErrExperimentNotActive, http.StatusConflict, "experiment_not_active"},
+	{entity.ErrInvalidExperiment, http.StatusBadRequest, "invalid_experiment"},
+	{entity.ErrInvalidSeatNumbering, http.StatusBadRequest, "invalid_seat_numbering"},
+	{entity.ErrInvalidSeatLayout, http.StatusBadRequest, "invalid_seat_layout"},
+	{entity.ErrInvalidSeatChange, http.StatusBadRequest, "invalid_seat_change"},
+	{entity.ErrSeatDowngrade, http.StatusConflict, "seat_downgrade"},
+	{entity.ErrUpgradeOfferClosed, http.StatusConflict, "upgrade_offer_closed"},
+	{entity.ErrInvalidEventContent, http.StatusBadRequest, "invalid_event_content"},
+	{entity.ErrInvalidEventMetadata, http.StatusBadRequest, "invalid_event_metadata"},
+	{entity.ErrInvalidSectionImage, http.StatusBadRequest, "invalid_section_image"},
+	{entity.ErrInvalidEventImage, http.StatusBadRequest, "invalid_event_image"},
+	{entity.ErrInvalidEventFilter, http.StatusBadRequest, "invalid_event_filter"},
+	{entity.ErrInvalidTicketLimit, http.StatusBadRequest, "invalid_ticket_limit"},
+	{entity.ErrInvalidBookingRateLimit, http.StatusBadRequest, "invalid_booking_rate_limit"},
+	{entity.ErrInvalidSalesWave, http.StatusBadRequest, "invalid_sales_wave"},
+	{entity.ErrInvalidSalesGoal, http.StatusBadRequest, "invalid_sales_goal"},
+	{entity.ErrInvalidTicketTier, http.StatusBadRequest, "invalid_ticket_tier"},
+	{entity.ErrTierQuotaExceeded, http.StatusConflict, "tier_quota_exceeded"},
+	{entity.ErrTierNameTaken, http.StatusConflict, "tier_name_taken"},
+	{entity.ErrCapacityBelowSold, http.StatusConflict, "capacity_below_sold"},
+
+	{entity.ErrInvalidRefundReason, http.StatusBadRequest, "invalid_refund_reason"},
+	{entity.ErrRefundReasonExists, http.StatusConflict, "refund_reason_exists"},
+	{entity.ErrInvalidReportRange, http.StatusBadRequest, "invalid_report_range"},
+	{entity.ErrRefundRequestExists, http.StatusConflict, "refund_request_exists"},
+	{entity.ErrRefundNotReviewable, http.StatusConflict, "refund_not_reviewable"},
+	{entity.ErrInvalidRefundStatus, http.StatusBadRequest, "invalid_refund_status"},
+	{entity.ErrInvalidRefundPolicy, http.StatusBadRequest, "invalid_refund_policy"},
+	{entity.ErrRefundWindowClosed, http.StatusConflict, "refund_window_closed"},
+	{entity.ErrInvalidBookingStatus, http.StatusBadRequest, "invalid_booking_status"},
+	{entity.ErrInvalidCursor, http.StatusBadRequest, "invalid_cursor"},
+	{entity.ErrInvalidInvoiceYear, http.StatusBadRequest, "invalid_invoice_year"},
+
+	{entity.ErrInvalidStaffScope, http.StatusBadRequest, "invalid_staff_scope"},
+	{entity.ErrNotStaffAccount, http.StatusBadRequest, "not_staff_account"},
+	{entity.ErrStaffAccessExists, http.StatusConflict, "staff_access_exists"},
+}
+
+// statusCodes is the fallback code for responses that are not built from an
+// entity error, such as a malformed request body.
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            "invalid_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusPaymentRequired:       "payment_required",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusConflict:              "conflict",
+	http.StatusGone:                  "gone",
+	http.StatusRequestEntityTooLarge: "payload_too_large",
+	http.StatusUnprocessableEntity:   "unprocessable",
+	http.StatusTooManyRequests:       "too_many_requests",
+	http.StatusServiceUnavailable:    "unavailable",
+}
+
+// StatusCode returns the generic error code for an HTTP status.
+func StatusCode(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return "internal_error"
+	}
+	return "error"
+}
+
+// MapError turns err into a status and response body. Unknown errors become
+// a 500 with a generic message, so database and driver errors never reach
+// the client.
+func MapError(err error) (int, ErrorResponse) {
+	var capacityErr *entity.CapacityBelowBookedError
+	if errors.As(err, &capacityErr) {
+		return http.StatusConflict, ErrorResponse{
+			Code:    "capacity_below_sold",
+			Message: err.Error(),
+			Details: gin.H{"requested": capacityErr.Requested, "min_capacity": capacityErr.Minimum},
+		}
+	}
+
+	var busyErr *entity.EventBusyError
+	if errors.As(err, &busyErr) {
+		return http.StatusTooManyRequests, ErrorResponse{
+			Code:    "event_busy",
+			Message: entity.ErrEventBusy.Error(),
+			Details: gin.H{"event_id": busyErr.EventID, "retry_after": busyErr.RetryAfterSeconds()},
+		}
+	}
+
+	var validationErr *entity.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest, ErrorResponse{
+			Code:    "validation_failed",
+			Message: entity.ErrValidation.Error(),
+			Details: validationErr.Fields,
+		}
+	}
+
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			return m.status, ErrorResponse{Code: m.code, Message: m.err.Error()}
+		}
+	}
+
+	return http.StatusInternalServerError, ErrorResponse{
+		Code:    StatusCode(http.StatusInternalServerError),
+		Message: entity.ErrInternalServer.Error(),
+	}
+}
+
+// localize translates resp, including the messages of invalid fields, into
+// the request's language.
+func localize(c *gin.Context, resp ErrorResponse) ErrorResponse {
+	resp.Message = T(c, resp.Message)
+	if fields, ok := resp.Details.([]entity.FieldError); ok {
+		translated := make([]entity.FieldError, len(fields))
+		for i, f := range fields {
+			msg := i18n.Message{Text: f.Message, Args: f.Args}
+			translated[i] = entity.FieldError{Field: f.Field, Message: msg.In(c.GetString("lang"))}
+		}
+		resp.Details = translated
+	}
+	return resp
+}
+
+// RespondError writes an error response with the generic code for status.
+// The message is translated into the request's language.
+func RespondError(c *gin.Context, status int, message string) {
+	c.JSON(status, localize(c, ErrorResponse{Code: StatusCode(status), Message: message}))
+}
+
+// RespondErrorDetails is RespondError with extra context under "details".
+func RespondErrorDetails(c *gin.Context, status int, message string, details interface{}) {
+	c.JSON(status, localize(c, ErrorResponse{Code: StatusCode(status), Message: message, Details: details}))
+}
+
+// RespondEntityError is RespondError for a known entity error: the code is
+// the specific one MapError gives err, such as "seat_unavailable", rather
+// than the generic code for status. Unmapped errors get the generic code.
+func RespondEntityError(c *gin.Context, status int, err error, message string) {
+	c.JSON(status, localize(c, ErrorResponse{Code: entityCode(err, status), Message: message}))
+}
+
+// RespondEntityErrorDetails is RespondEntityError with extra context under
+// "details".
+func RespondEntityErrorDetails(c *gin.Context, status int, err error, message string, details interface{}) {
+	c.JSON(status, localize(c, ErrorResponse{Code: entityCode(err, status), Message: message, Details: details}))
+}
+
+func entityCode(err error, status int) string {
+	if mapped, resp := MapError(err); mapped != http.StatusInternalServerError {
+		return resp.Code
+	}
+	return StatusCode(status)
+}
+
+// AbortWithError writes an error response and stops the handler chain.
+func AbortWithError(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, localize(c, ErrorResponse{Code: StatusCode(status), Message: message}))
+}
+
+// ErrorHandler renders errors that handlers attach with c.Error instead of
+// writing a response themselves. The last error wins and is mapped with
+// MapError; handlers log the raw error before attaching it.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		status, resp := MapError(c.Errors.Last().Err)
+		c.JSON(status, localize(c, resp))
+	}
+}
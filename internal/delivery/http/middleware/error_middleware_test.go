@@ -0,0 +1,68 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapError_Codes(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"Seat Unavailable", entity.ErrSeatUnavailable, http.StatusConflict, "seat_unavailable"},
+		{"Wrapped Sold Out", fmt.Errorf("book: %w", entity.ErrSoldOut), http.StatusConflict, "sold_out"},
+		{"Not Found", entity.ErrNotFound, http.StatusNotFound, "not_found"},
+		{"Unknown Error", errors.New("connection reset"), http.StatusInternalServerError, "internal_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := middleware.MapError(tt.err)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantCode, resp.Code)
+		})
+	}
+}
+
+func TestRespondEntityError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+	}{
+		{"Specific Code", entity.ErrSeatUnavailable, "seat_unavailable"},
+		{"Wrapped Error", fmt.Errorf("%w: 4 left", entity.ErrTicketLimitExceeded), "ticket_limit_exceeded"},
+		{"Unmapped Error Falls Back", errors.New("boom"), "conflict"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			middleware.RespondEntityError(c, http.StatusConflict, tt.err, "One of the selected seats is no longer available")
+
+			var body middleware.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusConflict, w.Code)
+			assert.Equal(t, tt.wantCode, body.Code)
+			assert.Equal(t, "One of the selected seats is no longer available", body.Message)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EventAccessMiddleware lets staff accounts through only to the events
+// (the :id route parameter) an organizer granted them scope on, and puts
+// that organizer's ID in the context as "organizerID". Other roles are left
+// to RoleMiddleware. It must run after AuthMiddleware.
+func EventAccessMiddleware(staffUC usecase.EventStaffUsecase, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if role, _ := c.Get("role"); role != "staff" {
+			c.Next()
+			return
+		}
+
+		userIDFloat, exists := c.Get("userID")
+		if !exists {
+			AbortWithError(c, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		staffID := int64(userIDFloat.(float64))
+
+		eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			AbortWithError(c, http.StatusBadRequest, "Invalid event ID")
+			return
+		}
+
+		organizerID, err := staffUC.AuthorizeStaff(c.Request.Context(), eventID, staffID, scope)
+		if err != nil {
+			if errors.Is(err, entity.ErrEventAccessDenied) || errors.Is(err, entity.ErrNotFound) {
+				logger.Warn("middleware: event access denied",
+					logger.Int64("event_id", eventID),
+					logger.Int64("user_id", staffID),
+					logger.String("scope", scope),
+				)
+				AbortWithError(c, http.StatusForbidden, "forbidden")
+			} else {
+				logger.Error("middleware: event access check failed", logger.Int64("event_id", eventID), logger.Err(err))
+				AbortWithError(c, http.StatusInternalServerError, "Failed to check event access")
+			}
+			return
+		}
+
+		c.Set("organizerID", organizerID)
+		c.Next()
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GateKeyHeader carries a turnstile's API key.
+const GateKeyHeader = "X-Gate-Key"
+
+// GateKeyMiddleware authenticates turnstiles by their per-gate API key and
+// puts the gate in the context as "gate".
+func GateKeyMiddleware(gateUC usecase.GateUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(GateKeyHeader)
+		if key == "" {
+			AbortWithError(c, http.StatusUnauthorized, "gate key required")
+			return
+		}
+
+		gate, err := gateUC.AuthenticateGate(c.Request.Context(), key)
+		if err != nil {
+			if errors.Is(err, entity.ErrInvalidGateKey) {
+				logger.Warn("middleware: invalid gate key", logger.String("client_ip", c.ClientIP()))
+				AbortWithError(c, http.StatusUnauthorized, "invalid gate key")
+			} else {
+				logger.Error("middleware: gate key check failed", logger.Err(err))
+				AbortWithError(c, http.StatusInternalServerError, "Failed to check gate key")
+			}
+			return
+		}
+
+		c.Set("gate", gate)
+		c.Next()
+	}
+}
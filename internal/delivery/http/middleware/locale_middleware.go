@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"ticres/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware picks the response language from the Accept-Language
+// header, stores it in the gin context as "lang" and echoes it in
+// Content-Language. Error responses are translated into it, and handlers
+// translate their own messages with T.
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Match(c.GetHeader("Accept-Language"))
+		c.Set("lang", lang)
+		c.Header("Content-Language", lang)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// T translates message into the request's language and fills in args.
+func T(c *gin.Context, message string, args ...interface{}) string {
+	return i18n.T(c.GetString("lang"), message, args...)
+}
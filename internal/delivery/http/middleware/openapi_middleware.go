@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"ticres/pkg/logger"
+	"ticres/pkg/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxValidatedBody caps how much of a request or response body is held in
+// memory for validation. Larger bodies, such as uploads and streamed
+// exports, are passed through unchecked.
+const maxValidatedBody = 1 << 20
+
+// responseRecorder copies what a handler writes so it can be validated
+// after the handler returns.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.record(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.record([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *responseRecorder) record(b []byte) {
+	if w.overflow {
+		return
+	}
+	if w.body.Len()+len(b) > maxValidatedBody {
+		w.overflow = true
+		w.body.Reset()
+		return
+	}
+	w.body.Write(b)
+}
+
+// OpenAPIValidation rejects requests whose parameters or JSON body do not
+// match the API document with a 400. With validateResponses it also checks
+// what handlers write and logs any mismatch, so drift between handlers and
+// their annotations shows up while developing; the response itself is sent
+// unchanged. Routes missing from the document pass through.
+func OpenAPIValidation(v *openapi.Validator, validateResponses bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := openapi.Route{Method: c.Request.Method, Path: c.FullPath()}
+		if route.Path == "" || !v.Documents(route) {
+			c.Next()
+			return
+		}
+		route.PathParams = make(map[string]string, len(c.Params))
+		for _, p := range c.Params {
+			route.PathParams[p.Key] = p.Value
+		}
+
+		var body []byte
+		if c.Request.Body != nil && c.Request.ContentLength <= maxValidatedBody {
+			var err error
+			body, err = io.ReadAll(io.LimitReader(c.Request.Body, maxValidatedBody+1))
+			if err != nil {
+				AbortWithError(c, http.StatusBadRequest, "failed to read request body")
+				return
+			}
+			c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+			if len(body) > maxValidatedBody {
+				body = nil
+			}
+		}
+
+		if errs := v.ValidateRequest(route, c.Request, body); len(errs) > 0 {
+			details := make([]string, len(errs))
+			for i, err := range errs {
+				details[i] = err.Error()
+			}
+			logger.FromContext(c.Request.Context()).Debug("openapi: request rejected",
+				logger.String("route", route.Path),
+				logger.Any("errors", details),
+			)
+			RespondErrorDetails(c, http.StatusBadRequest, "request does not match the API specification", details)
+			c.Abort()
+			return
+		}
+
+		if !validateResponses {
+			c.Next()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		if recorder.overflow {
+			return
+		}
+		errs := v.ValidateResponse(route, recorder.Status(), recorder.Header().Get("Content-Type"), recorder.body.Bytes())
+		if len(errs) == 0 {
+			return
+		}
+		details := make([]string, len(errs))
+		for i, err := range errs {
+			details[i] = err.Error()
+		}
+		logger.FromContext(c.Request.Context()).Warn("openapi: response does not match the API specification",
+			logger.String("method", route.Method),
+			logger.String("route", route.Path),
+			logger.Int("status", recorder.Status()),
+			logger.Any("errors", details),
+		)
+	}
+}
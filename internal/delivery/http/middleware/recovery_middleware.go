@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+	"time"
+
+	"ticres/internal/usecase"
+	"ticres/pkg/errorreport"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecoveryMiddleware turns a panic in a handler into the standard 500 error
+// envelope instead of gin's bare 500. The panic and its stack are logged
+// through zap with the request ID, and reported to the error tracker. A
+// client hanging up mid-response is only logged. Register it after
+// RequestIDMiddleware and AccessLogMiddleware so both see the request.
+func RecoveryMiddleware(reporter errorreport.Reporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// net/http's way of aborting a response; it must reach the server
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			ctx := c.Request.Context()
+			if err, ok := rec.(error); ok && isBrokenConnection(err) {
+				logger.FromContext(ctx).Warn("http: client connection lost",
+					logger.String("path", c.Request.URL.Path),
+					logger.Err(err),
+				)
+				c.Error(err)
+				c.Abort()
+				return
+			}
+
+			message := fmt.Sprint(rec)
+			requestID := logger.RequestIDFromContext(ctx)
+			logger.FromContext(ctx).Error("http: panic recovered",
+				logger.String("panic", message),
+				logger.String("method", c.Request.Method),
+				logger.String("path", c.Request.URL.Path),
+				logger.String("route", c.FullPath()),
+				logger.String("stack", string(debug.Stack())),
+			)
+			reporter.Report(errorreport.Event{
+				Message:   message,
+				Frames:    errorreport.Callers(1),
+				RequestID: requestID,
+				Method:    c.Request.Method,
+				// The query string can carry tokens, as in the access log
+				URL:    c.Request.URL.Path,
+				Route:  c.FullPath(),
+				UserID: usecase.ActorFromContext(ctx),
+				Time:   time.Now(),
+			})
+
+			// Part of a response is already out; the status can't change
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, localize(c, ErrorResponse{
+				Code:    StatusCode(http.StatusInternalServerError),
+				Message: "Internal server error",
+				Details: gin.H{"request_id": requestID},
+			}))
+		}()
+		c.Next()
+	}
+}
+
+// isBrokenConnection reports a write to a client that already went away.
+func isBrokenConnection(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"regexp"
+
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// Incoming IDs end up in every log line, so only accept short, plain tokens
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware reuses the caller's X-Request-ID when it is sane and
+// generates a UUID otherwise. The ID is echoed in the response, stored in
+// the gin context as "requestID" and in the request context, where
+// logger.FromContext picks it up in usecases and repositories.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Set("requestID", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), requestID))
+
+		c.Next()
+	}
+}
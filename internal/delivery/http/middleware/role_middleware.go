@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleMiddleware only lets through users whose role is one of the given roles.
+// It must run after AuthMiddleware, which puts the role in the context.
+func RoleMiddleware(roles ...string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
+	return func(c *gin.Context) {
+		userRole, exists := c.Get("role")
+		if !exists {
+			logger.Warn("middleware: role check failed - no role in context",
+				logger.String("path", c.Request.URL.Path),
+			)
+			AbortWithError(c, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		role, _ := userRole.(string)
+		if !allowed[role] {
+			logger.Warn("middleware: role access denied",
+				logger.String("role", role),
+				logger.String("path", c.Request.URL.Path),
+			)
+			AbortWithError(c, http.StatusForbidden, "forbidden")
+			return
+		}
+
+		c.Next()
+	}
+}
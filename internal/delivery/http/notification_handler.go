@@ -0,0 +1,122 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/i18n"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationUC usecase.NotificationUsecase
+}
+
+func NewNotificationHandler(uc usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{notificationUC: uc}
+}
+
+// List godoc
+// @Summary      List the current user's notifications
+// @Description  In-app inbox, newest first: the notifications also sent by email, such as booking confirmations, payment receipts and upgrade offers. Messages are in the request's language. `meta.unread` counts every unread notification, whatever the page.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        unread query bool false "Only unread notifications" example(true)
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        limit query int false "Items per page (max 100)" default(20) minimum(1) maximum(100)
+// @Success      200 {object} map[string]interface{} "Notifications with pagination metadata and the unread count"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me/notifications [get]
+func (h *NotificationHandler) List(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		logger.Warn("handler: user not authenticated for /me/notifications endpoint")
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	unreadOnly := c.Query("unread") == "true"
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	result, err := h.notificationUC.ListNotifications(c.Request.Context(), uid, unreadOnly, page, limit)
+	if err != nil {
+		logger.Error("handler: failed to list notifications", logger.Int64("user_id", uid), logger.Err(err))
+		c.Error(err)
+		return
+	}
+
+	lang := c.GetString("lang")
+	for i, n := range result.Notifications {
+		result.Notifications[i].Message = i18n.Message{Text: n.Message, Args: n.MessageArgs}.In(lang)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result.Notifications,
+		"meta": gin.H{
+			"total":   result.Total,
+			"page":    page,
+			"limit":   limit,
+			"hasMore": page*limit < result.Total,
+			"unread":  result.Unread,
+		},
+	})
+}
+
+// MarkRead godoc
+// @Summary      Mark a notification read
+// @Description  Marks one of the current user's notifications read. Marking it again keeps the time it was first read.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Notification ID" example(1)
+// @Success      200 {object} map[string]interface{} "Notification marked read"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid notification ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      404 {object} middleware.ErrorResponse "Notification not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		logger.Warn("handler: user not authenticated for /me/notifications/:id/read endpoint")
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	notificationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+
+	if err := h.notificationUC.MarkRead(c.Request.Context(), uid, notificationID); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Notification not found")
+			return
+		}
+		logger.Error("handler: failed to mark notification read", logger.Int64("notification_id", notificationID), logger.Err(err))
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Notification marked as read"),
+	})
+}
@@ -0,0 +1,411 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizerHandler struct {
+	organizerUC usecase.OrganizerUsecase
+}
+
+func NewOrganizerHandler(uc usecase.OrganizerUsecase) *OrganizerHandler {
+	return &OrganizerHandler{organizerUC: uc}
+}
+
+type organizerApplicationRequest struct {
+	BusinessName      string `json:"business_name" binding:"required"`
+	BusinessType      string `json:"business_type" binding:"required,oneof=individual company community"`
+	BusinessAddress   string `json:"business_address" binding:"required"`
+	TaxID             string `json:"tax_id"`
+	Phone             string `json:"phone" binding:"required"`
+	BankName          string `json:"bank_name" binding:"required"`
+	BankAccountName   string `json:"bank_account_name" binding:"required"`
+	BankAccountNumber string `json:"bank_account_number" binding:"required,numeric"`
+}
+
+type reviewApplicationRequest struct {
+	Note string `json:"note"`
+}
+
+// Apply godoc
+// @Summary      Apply to become an organizer
+// @Description  Submit business and payout details for admin review. Only one pending or approved application is allowed per user.
+// @Tags         organizer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body organizerApplicationRequest true "Business and payout details"
+// @Success      201 {object} map[string]interface{} "Application submitted"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      409 {object} middleware.ErrorResponse "Application already submitted"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/applications [post]
+func (h *OrganizerHandler) Apply(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := int64(userIDFloat.(float64))
+
+	var req organizerApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid organizer application request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	app := &entity.OrganizerApplication{
+		UserID:            userID,
+		BusinessName:      req.BusinessName,
+		BusinessType:      req.BusinessType,
+		BusinessAddress:   req.BusinessAddress,
+		TaxID:             req.TaxID,
+		Phone:             req.Phone,
+		BankName:          req.BankName,
+		BankAccountName:   req.BankAccountName,
+		BankAccountNumber: req.BankAccountNumber,
+	}
+
+	if err := h.organizerUC.SubmitApplication(c.Request.Context(), app); err != nil {
+		if errors.Is(err, entity.ErrApplicationExists) {
+			middleware.RespondEntityError(c, http.StatusConflict, err, "You already have a pending or approved application")
+			return
+		}
+		logger.Error("handler: failed to submit organizer application", logger.Int64("user_id", userID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to submit application")
+		return
+	}
+
+	logger.Info("handler: organizer application submitted", logger.Int64("application_id", app.ID))
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Application submitted. Upload your supporting documents and wait for review."),
+		"data":    app,
+	})
+}
+
+// MyApplication godoc
+// @Summary      Get my organizer application
+// @Description  Retrieve the status of the caller's latest organizer application with its uploaded documents
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} map[string]interface{} "Application detail"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      404 {object} middleware.ErrorResponse "No application found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/applications/me [get]
+func (h *OrganizerHandler) MyApplication(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := int64(userIDFloat.(float64))
+
+	app, err := h.organizerUC.GetMyApplication(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "No application found")
+			return
+		}
+		logger.Error("handler: failed to get organizer application", logger.Int64("user_id", userID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get application")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": app})
+}
+
+// UploadDocument godoc
+// @Summary      Upload a supporting document
+// @Description  Attach an ID card, business license or other document (PDF, JPEG or PNG, max 10MB) to the caller's pending application
+// @Tags         organizer
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        doc_type formData string true "Document type" Enums(id_card, business_license, tax_certificate, bank_statement, other)
+// @Param        file formData file true "Document file"
+// @Success      201 {object} map[string]interface{} "Document uploaded"
+// @Failure      400 {object} middleware.ErrorResponse "Missing or invalid file"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      404 {object} middleware.ErrorResponse "No application found"
+// @Failure      409 {object} middleware.ErrorResponse "Application already reviewed"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/applications/me/documents [post]
+func (h *OrganizerHandler) UploadDocument(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := int64(userIDFloat.(float64))
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "File is required")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error("handler: failed to open uploaded file", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, "Could not read file")
+		return
+	}
+	defer file.Close()
+
+	// Sniff the content type instead of trusting the client-supplied header
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		logger.Error("handler: failed to rewind uploaded file", logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to upload document")
+		return
+	}
+
+	doc := &entity.OrganizerDocument{
+		DocType:     c.PostForm("doc_type"),
+		FileName:    fileHeader.Filename,
+		ContentType: http.DetectContentType(head[:n]),
+		SizeBytes:   fileHeader.Size,
+	}
+
+	if err := h.organizerUC.UploadDocument(c.Request.Context(), userID, doc, file); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidDocument):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Invalid document. Allowed types: PDF, JPEG, PNG up to 10MB")
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "No application found")
+		case errors.Is(err, entity.ErrApplicationNotPending):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Application has already been reviewed")
+		default:
+			logger.Error("handler: failed to upload organizer document", logger.Int64("user_id", userID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to upload document")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Document uploaded"),
+		"data":    doc,
+	})
+}
+
+// ListApplications godoc
+// @Summary      List organizer applications (Admin)
+// @Description  Review queue of organizer applications, oldest first. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status query string false "Filter by status" Enums(PENDING, APPROVED, REJECTED)
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        limit query int false "Items per page (max 100)" default(20) minimum(1) maximum(100)
+// @Success      200 {object} map[string]interface{} "List of applications with pagination metadata"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/organizer-applications [get]
+func (h *OrganizerHandler) ListApplications(c *gin.Context) {
+	status := c.Query("status")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	apps, total, err := h.organizerUC.ListApplications(c.Request.Context(), status, page, limit)
+	if err != nil {
+		logger.Error("handler: failed to list organizer applications", logger.Err(err))
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": apps,
+		"meta": gin.H{
+			"total":   total,
+			"page":    page,
+			"limit":   limit,
+			"hasMore": (page * limit) < total,
+		},
+	})
+}
+
+// GetApplication godoc
+// @Summary      Get organizer application (Admin)
+// @Description  Retrieve an organizer application with its documents. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Application ID" example(1)
+// @Success      200 {object} map[string]interface{} "Application detail"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid application ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Application not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/organizer-applications/{id} [get]
+func (h *OrganizerHandler) GetApplication(c *gin.Context) {
+	applicationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid application ID")
+		return
+	}
+
+	app, err := h.organizerUC.GetApplication(c.Request.Context(), applicationID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Application not found")
+			return
+		}
+		logger.Error("handler: failed to get organizer application", logger.Int64("application_id", applicationID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get application")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": app})
+}
+
+// DownloadDocument godoc
+// @Summary      Download an application document (Admin)
+// @Description  Stream a supporting document uploaded by the applicant. Admin access required.
+// @Tags         admin
+// @Produce      octet-stream
+// @Security     BearerAuth
+// @Param        id path int true "Application ID" example(1)
+// @Param        doc_id path int true "Document ID" example(1)
+// @Success      200 {file} file "Document content"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Document not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/organizer-applications/{id}/documents/{doc_id} [get]
+func (h *OrganizerHandler) DownloadDocument(c *gin.Context) {
+	applicationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid application ID")
+		return
+	}
+	documentID, err := strconv.ParseInt(c.Param("doc_id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid document ID")
+		return
+	}
+
+	doc, content, err := h.organizerUC.OpenDocument(c.Request.Context(), applicationID, documentID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Document not found")
+			return
+		}
+		logger.Error("handler: failed to open organizer document", logger.Int64("document_id", documentID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to download document")
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, doc.SizeBytes, doc.ContentType, content, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", doc.FileName),
+	})
+}
+
+// Approve godoc
+// @Summary      Approve organizer application (Admin)
+// @Description  Approve a pending application and grant the applicant the organizer role. The applicant must log in again to use it. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Application ID" example(1)
+// @Param        request body reviewApplicationRequest false "Optional review note"
+// @Success      200 {object} map[string]string "Application approved"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid application ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Application not found"
+// @Failure      409 {object} middleware.ErrorResponse "Application already reviewed"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/organizer-applications/{id}/approve [post]
+func (h *OrganizerHandler) Approve(c *gin.Context) {
+	h.review(c, true)
+}
+
+// Reject godoc
+// @Summary      Reject organizer application (Admin)
+// @Description  Reject a pending application. The applicant may submit a new one afterwards. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Application ID" example(1)
+// @Param        request body reviewApplicationRequest false "Reason shown to the applicant"
+// @Success      200 {object} map[string]string "Application rejected"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid application ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Application not found"
+// @Failure      409 {object} middleware.ErrorResponse "Application already reviewed"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/organizer-applications/{id}/reject [post]
+func (h *OrganizerHandler) Reject(c *gin.Context) {
+	h.review(c, false)
+}
+
+func (h *OrganizerHandler) review(c *gin.Context, approve bool) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	reviewerID := int64(userIDFloat.(float64))
+
+	applicationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid application ID")
+		return
+	}
+
+	// The note is optional, so an empty body is fine
+	var req reviewApplicationRequest
+	_ = c.ShouldBindJSON(&req)
+
+	if err := h.organizerUC.ReviewApplication(c.Request.Context(), applicationID, reviewerID, approve, req.Note); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Application not found")
+		case errors.Is(err, entity.ErrApplicationNotPending):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Application has already been reviewed")
+		default:
+			logger.Error("handler: failed to review organizer application",
+				logger.Int64("application_id", applicationID),
+				logger.Err(err),
+			)
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to review application")
+		}
+		return
+	}
+
+	if approve {
+		c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Application approved. The applicant must log in again to use organizer features.")})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Application rejected")})
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
+	"ticres/internal/delivery/http/middleware"
 	"ticres/internal/entity"
 	"ticres/internal/usecase"
 	"ticres/pkg/logger"
@@ -12,6 +14,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// gatewayRetryAfter is the Retry-After, in seconds, sent while the payment
+// gateway is unavailable.
+const gatewayRetryAfter = "30"
+
 type PaymentHandler struct {
 	paymentUC usecase.PaymentUsecase
 }
@@ -25,6 +31,20 @@ type payRequest struct {
 	PaymentMethod string `json:"payment_method" binding:"required,oneof=credit_card bank_transfer e_wallet"`
 }
 
+type createPaymentLinkRequest struct {
+	// ExtendMinutes moves the payment deadline to this many minutes from now
+	ExtendMinutes int `json:"extend_minutes" binding:"min=0,max=1440" example:"60"`
+}
+
+type paymentLinkTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type payWithLinkRequest struct {
+	Token         string `json:"token" binding:"required"`
+	PaymentMethod string `json:"payment_method" binding:"required,oneof=credit_card bank_transfer e_wallet"`
+}
+
 // ProcessPayment godoc
 // @Summary      Process payment for booking
 // @Description  Process payment for a booking. User must own the booking. Payment must be completed within the booking's expiration time (15 minutes from booking creation).
@@ -34,18 +54,20 @@ type payRequest struct {
 // @Security     BearerAuth
 // @Param        request body payRequest true "Payment processing details"
 // @Success      200 {object} map[string]interface{} "Payment processed successfully"
-// @Failure      400 {object} map[string]string "Invalid request, booking not in payable state, or invalid payment method"
-// @Failure      401 {object} map[string]string "User not authenticated"
-// @Failure      403 {object} map[string]string "Access forbidden - booking belongs to another user"
-// @Failure      404 {object} map[string]string "Booking not found"
-// @Failure      409 {object} map[string]string "Payment has already been completed for this booking"
-// @Failure      410 {object} map[string]string "Booking has expired - create new booking"
-// @Failure      500 {object} map[string]string "Payment processing failed"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request, booking not in payable state, or invalid payment method"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - booking belongs to another user"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      409 {object} middleware.ErrorResponse "Payment has already been completed for this booking"
+// @Failure      410 {object} middleware.ErrorResponse "Booking has expired - create new booking"
+// @Failure      402 {object} middleware.ErrorResponse "Payment declined"
+// @Failure      500 {object} middleware.ErrorResponse "Payment processing failed"
+// @Failure      503 {object} middleware.ErrorResponse "Payment gateway unavailable, retry later (see Retry-After)"
 // @Router       /payments [post]
 func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 	userIDFloat, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 	userID := int64(userIDFloat.(float64))
@@ -53,7 +75,7 @@ func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 	var req payRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Warn("handler: invalid payment request", logger.Err(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -67,20 +89,25 @@ func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 	if err != nil {
 		switch {
 		case errors.Is(err, entity.ErrNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Booking not found")
 		case errors.Is(err, entity.ErrUnauthorized):
-			c.JSON(http.StatusForbidden, gin.H{"error": "You don't have access to this booking"})
+			middleware.RespondEntityError(c, http.StatusForbidden, err, "You don't have access to this booking")
 		case errors.Is(err, entity.ErrBookingExpired):
-			c.JSON(http.StatusGone, gin.H{"error": "Booking has expired. Please create a new booking."})
+			middleware.RespondEntityError(c, http.StatusGone, err, "Booking has expired. Please create a new booking.")
 		case errors.Is(err, entity.ErrPaymentAlreadyMade):
-			c.JSON(http.StatusConflict, gin.H{"error": "Payment has already been completed for this booking"})
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Payment has already been completed for this booking")
 		case errors.Is(err, entity.ErrBookingNotPending):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Booking is not in a payable state"})
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Booking is not in a payable state")
 		case errors.Is(err, entity.ErrInvalidPaymentMethod):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment method. Use: credit_card, bank_transfer, or e_wallet"})
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Invalid payment method. Use: credit_card, bank_transfer, or e_wallet")
+		case errors.Is(err, entity.ErrPaymentDeclined):
+			middleware.RespondEntityError(c, http.StatusPaymentRequired, err, "Payment was declined")
+		case errors.Is(err, entity.ErrGatewayUnavailable):
+			c.Header("Retry-After", gatewayRetryAfter)
+			middleware.RespondEntityError(c, http.StatusServiceUnavailable, err, "Payment gateway unavailable, please retry later")
 		default:
 			logger.Error("handler: payment processing failed", logger.Err(err))
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Payment processing failed"})
+			middleware.RespondError(c, http.StatusInternalServerError, "Payment processing failed")
 		}
 		return
 	}
@@ -90,7 +117,7 @@ func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 		logger.String("external_id", txn.ExternalID),
 	)
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Payment successful",
+		"message": middleware.T(c, "Payment successful"),
 		"data":    txn,
 	})
 }
@@ -104,16 +131,16 @@ func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        booking_id path int true "Booking ID" example(123)
 // @Success      200 {object} map[string]interface{} "Payment status retrieved successfully"
-// @Failure      400 {object} map[string]string "Invalid booking ID"
-// @Failure      401 {object} map[string]string "User not authenticated"
-// @Failure      403 {object} map[string]string "Access forbidden - booking belongs to another user"
-// @Failure      404 {object} map[string]string "Booking not found"
-// @Failure      500 {object} map[string]string "Failed to get payment status"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid booking ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - booking belongs to another user"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      500 {object} middleware.ErrorResponse "Failed to get payment status"
 // @Router       /payments/{booking_id} [get]
 func (h *PaymentHandler) GetPaymentStatus(c *gin.Context) {
 	userIDFloat, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 	userID := int64(userIDFloat.(float64))
@@ -121,7 +148,7 @@ func (h *PaymentHandler) GetPaymentStatus(c *gin.Context) {
 	bookingIDStr := c.Param("booking_id")
 	bookingID, err := strconv.ParseInt(bookingIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID"})
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid booking ID")
 		return
 	}
 
@@ -129,15 +156,176 @@ func (h *PaymentHandler) GetPaymentStatus(c *gin.Context) {
 	if err != nil {
 		switch {
 		case errors.Is(err, entity.ErrNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Booking not found")
 		case errors.Is(err, entity.ErrUnauthorized):
-			c.JSON(http.StatusForbidden, gin.H{"error": "You don't have access to this booking"})
+			middleware.RespondEntityError(c, http.StatusForbidden, err, "You don't have access to this booking")
 		default:
 			logger.Error("handler: failed to get payment status", logger.Err(err))
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get payment status"})
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to get payment status")
 		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"data": result})
 }
+
+// CreateLink godoc
+// @Summary      Generate a payment link for a booking
+// @Description  Sign a link support can send to a customer whose session expired, so they can pay a PENDING booking without logging in. The link expires with the booking's payment deadline; extend_minutes (up to 1440) first moves the deadline to that many minutes from now. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Booking ID" example(123)
+// @Param        request body createPaymentLinkRequest false "Optional deadline extension"
+// @Success      201 {object} entity.PaymentLink "Payment link created"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid booking ID or extension, or booking not in a payable state"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      409 {object} middleware.ErrorResponse "Booking has already been paid"
+// @Failure      410 {object} middleware.ErrorResponse "Booking has expired and no extension was given, or its seats were released"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/bookings/{id}/payment-link [post]
+func (h *PaymentHandler) CreateLink(c *gin.Context) {
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	var req createPaymentLinkRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	link, err := h.paymentUC.CreatePaymentLink(c.Request.Context(), bookingID, time.Duration(req.ExtendMinutes)*time.Minute)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Booking not found")
+		case errors.Is(err, entity.ErrPaymentAlreadyMade):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Payment has already been completed for this booking")
+		case errors.Is(err, entity.ErrBookingExpired):
+			middleware.RespondEntityError(c, http.StatusGone, err, "Booking has expired. Extend the deadline or ask the customer to book again.")
+		case errors.Is(err, entity.ErrBookingNotPending):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Booking is not in a payable state")
+		case errors.Is(err, entity.ErrInvalidPaymentLink):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+		default:
+			logger.Error("handler: failed to create payment link", logger.Int64("booking_id", bookingID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to create payment link")
+		}
+		return
+	}
+
+	logger.Info("handler: payment link created", logger.Int64("booking_id", bookingID))
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Payment link created"),
+		"data":    link,
+	})
+}
+
+// LookupLink godoc
+// @Summary      View the booking behind a payment link
+// @Description  Booking status, amount and payment deadline for the token in a payment link. No login required.
+// @Tags         payments
+// @Accept       json
+// @Produce      json
+// @Param        request body paymentLinkTokenRequest true "Payment link token"
+// @Success      200 {object} entity.BookingWithPayment "Booking"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body or link"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      410 {object} middleware.ErrorResponse "Payment link has expired"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /payment-links/lookup [post]
+func (h *PaymentHandler) LookupLink(c *gin.Context) {
+	var req paymentLinkTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	booking, err := h.paymentUC.GetPaymentLinkBooking(c.Request.Context(), req.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidPaymentLink):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Invalid payment link")
+		case errors.Is(err, entity.ErrPaymentLinkExpired):
+			middleware.RespondEntityError(c, http.StatusGone, err, "Payment link has expired")
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Booking not found")
+		default:
+			logger.Error("handler: failed to look up payment link", logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to get booking")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": booking})
+}
+
+// PayWithLink godoc
+// @Summary      Pay a booking with a payment link
+// @Description  Pay the booking behind a payment link token. No login required; the signed token is the only credential. The booking's payment deadline still applies.
+// @Tags         payments
+// @Accept       json
+// @Produce      json
+// @Param        request body payWithLinkRequest true "Payment link token and payment method"
+// @Success      200 {object} map[string]interface{} "Payment processed successfully"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request, link or payment method, or booking not in a payable state"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      409 {object} middleware.ErrorResponse "Payment has already been completed for this booking"
+// @Failure      410 {object} middleware.ErrorResponse "Payment link or booking has expired"
+// @Failure      402 {object} middleware.ErrorResponse "Payment declined"
+// @Failure      500 {object} middleware.ErrorResponse "Payment processing failed"
+// @Failure      503 {object} middleware.ErrorResponse "Payment gateway unavailable, retry later (see Retry-After)"
+// @Router       /payment-links/pay [post]
+func (h *PaymentHandler) PayWithLink(c *gin.Context) {
+	var req payWithLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	txn, err := h.paymentUC.PayWithLink(c.Request.Context(), req.Token, req.PaymentMethod)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidPaymentLink):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Invalid payment link")
+		case errors.Is(err, entity.ErrPaymentLinkExpired):
+			middleware.RespondEntityError(c, http.StatusGone, err, "Payment link has expired")
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Booking not found")
+		case errors.Is(err, entity.ErrBookingExpired):
+			middleware.RespondEntityError(c, http.StatusGone, err, "Booking has expired. Please create a new booking.")
+		case errors.Is(err, entity.ErrPaymentAlreadyMade):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Payment has already been completed for this booking")
+		case errors.Is(err, entity.ErrBookingNotPending):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Booking is not in a payable state")
+		case errors.Is(err, entity.ErrInvalidPaymentMethod):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Invalid payment method. Use: credit_card, bank_transfer, or e_wallet")
+		case errors.Is(err, entity.ErrPaymentDeclined):
+			middleware.RespondEntityError(c, http.StatusPaymentRequired, err, "Payment was declined")
+		case errors.Is(err, entity.ErrGatewayUnavailable):
+			c.Header("Retry-After", gatewayRetryAfter)
+			middleware.RespondEntityError(c, http.StatusServiceUnavailable, err, "Payment gateway unavailable, please retry later")
+		default:
+			logger.Error("handler: payment link payment failed", logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Payment processing failed")
+		}
+		return
+	}
+
+	logger.Info("handler: payment link payment successful",
+		logger.Int64("booking_id", txn.BookingID),
+		logger.String("external_id", txn.ExternalID),
+	)
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Payment successful"),
+		"data":    txn,
+	})
+}
@@ -0,0 +1,404 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const reportDateLayout = "2006-01-02"
+
+type RefundHandler struct {
+	refundUC usecase.RefundUsecase
+}
+
+func NewRefundHandler(uc usecase.RefundUsecase) *RefundHandler {
+	return &RefundHandler{refundUC: uc}
+}
+
+type createRefundReasonRequest struct {
+	Code  string `json:"code" binding:"required" example:"chargeback"`
+	Label string `json:"label" binding:"required" example:"Chargeback"`
+}
+
+type updateRefundReasonRequest struct {
+	Label  string `json:"label" binding:"required" example:"Customer request"`
+	Active *bool  `json:"active" binding:"required" example:"true"`
+}
+
+type refundBookingRequest struct {
+	Reason string `json:"reason" binding:"required" example:"customer_request"`
+	Note   string `json:"note" example:"Customer can no longer attend"`
+}
+
+type reviewRefundRequest struct {
+	Note string `json:"note" example:"Tickets are non-refundable within 24 hours of the event"`
+}
+
+// refundError maps refund errors shared by every refund endpoint.
+func refundError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		middleware.RespondEntityError(c, http.StatusNotFound, err, "Booking or refund not found")
+	case errors.Is(err, entity.ErrUnauthorized):
+		middleware.RespondEntityError(c, http.StatusForbidden, err, "You don't have access to this booking")
+	case errors.Is(err, entity.ErrInvalidRefundReason), errors.Is(err, entity.ErrInvalidReportRange),
+		errors.Is(err, entity.ErrInvalidRefundStatus):
+		middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+	case errors.Is(err, entity.ErrRefundReasonExists), errors.Is(err, entity.ErrBookingNotPaid),
+		errors.Is(err, entity.ErrRefundRequestExists), errors.Is(err, entity.ErrRefundNotReviewable),
+		errors.Is(err, entity.ErrRefundWindowClosed):
+		middleware.RespondEntityError(c, http.StatusConflict, err, err.Error())
+	default:
+		logger.Error("handler: failed to "+action, logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to "+action)
+	}
+}
+
+// ListReasons godoc
+// @Summary      List refund reasons (Admin)
+// @Description  List the refund reason taxonomy, including deactivated reasons. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} map[string]interface{} "Refund reasons"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/refund-reasons [get]
+func (h *RefundHandler) ListReasons(c *gin.Context) {
+	reasons, err := h.refundUC.ListReasons(c.Request.Context())
+	if err != nil {
+		refundError(c, err, "list refund reasons")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reasons})
+}
+
+// CreateReason godoc
+// @Summary      Add a refund reason (Admin)
+// @Description  Add a reason to the refund taxonomy. The code is permanent and used in refunds and reports; the label can be changed later. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body createRefundReasonRequest true "Reason code and label"
+// @Success      201 {object} map[string]interface{} "Refund reason created"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      409 {object} middleware.ErrorResponse "Reason code already exists"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/refund-reasons [post]
+func (h *RefundHandler) CreateReason(c *gin.Context) {
+	var req createRefundReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid refund reason request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reason := &entity.RefundReason{Code: req.Code, Label: req.Label}
+	if err := h.refundUC.CreateReason(c.Request.Context(), reason); err != nil {
+		refundError(c, err, "create refund reason")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Refund reason created"),
+		"data":    reason,
+	})
+}
+
+// UpdateReason godoc
+// @Summary      Update a refund reason (Admin)
+// @Description  Rename a refund reason or deactivate it. Deactivated reasons cannot be used for new refunds but stay on existing refunds and in reports. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        code path string true "Reason code" example(customer_request)
+// @Param        request body updateRefundReasonRequest true "Label and active flag"
+// @Success      200 {object} map[string]interface{} "Refund reason updated"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Reason not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/refund-reasons/{code} [put]
+func (h *RefundHandler) UpdateReason(c *gin.Context) {
+	var req updateRefundReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid refund reason request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reason := &entity.RefundReason{Code: c.Param("code"), Label: req.Label, Active: *req.Active}
+	if err := h.refundUC.UpdateReason(c.Request.Context(), reason); err != nil {
+		refundError(c, err, "update refund reason")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Refund reason updated"),
+		"data":    reason,
+	})
+}
+
+// RefundBooking godoc
+// @Summary      Refund a booking (Admin)
+// @Description  Refund a PAID booking under an active refund reason. A customer_request refund returns the share the event's refund policy allows today and is refused once it allows nothing; other reasons refund in full. Its payments are marked REFUNDED, the booking becomes REFUNDED and its seats are released. With dry_run=true the request is checked the same way but nothing is changed, and the response shows the booking and the amount that would be refunded. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Booking ID" example(1)
+// @Param        dry_run query bool false "Preview the refund without making it"
+// @Param        request body refundBookingRequest true "Refund reason and optional note"
+// @Success      200 {object} map[string]interface{} "Dry run result"
+// @Success      201 {object} map[string]interface{} "Booking refunded"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request or unknown reason"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      409 {object} middleware.ErrorResponse "Booking is not paid or the refund policy allows no refund"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/bookings/{id}/refund [post]
+func (h *RefundHandler) RefundBooking(c *gin.Context) {
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	var req refundBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid refund request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if c.Query("dry_run") == "true" {
+		result, err := h.refundUC.PreviewRefund(c.Request.Context(), bookingID, req.Reason, req.Note)
+		if err != nil {
+			refundError(c, err, "preview refund")
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"message": middleware.T(c, "Dry run: nothing was changed"),
+			"data":    result,
+		})
+		return
+	}
+
+	refund, err := h.refundUC.RefundBooking(c.Request.Context(), bookingID, req.Reason, req.Note)
+	if err != nil {
+		refundError(c, err, "refund booking")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Booking refunded"),
+		"data":    refund,
+	})
+}
+
+// Report godoc
+// @Summary      Refund report by reason (Admin)
+// @Description  Count and total amount of refunds per reason for a date range, for finance. Both dates are inclusive UTC days; the range defaults to the last 30 days and is limited to a year. Active reasons without refunds are listed with zero totals. Reports are cached for REPORT_CACHE_TTL and the default 30-day report is recomputed in the background; generated_at tells when the report was computed and refresh=true recomputes it now. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from query string false "First day (YYYY-MM-DD)" example(2026-01-01)
+// @Param        to query string false "Last day (YYYY-MM-DD), defaults to today" example(2026-01-31)
+// @Param        refresh query bool false "Recompute instead of serving the cached report"
+// @Success      200 {object} entity.RefundReport "Refund totals by reason"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid date range"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/reports/refunds [get]
+func (h *RefundHandler) Report(c *gin.Context) {
+	lastDay := time.Now().UTC().Truncate(24 * time.Hour)
+	if v := c.Query("to"); v != "" {
+		parsed, err := time.Parse(reportDateLayout, v)
+		if err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, "Invalid to date, expected YYYY-MM-DD")
+			return
+		}
+		lastDay = parsed
+	}
+	from := lastDay.AddDate(0, 0, 1-usecase.DefaultRefundReportDays)
+	if v := c.Query("from"); v != "" {
+		parsed, err := time.Parse(reportDateLayout, v)
+		if err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, "Invalid from date, expected YYYY-MM-DD")
+			return
+		}
+		from = parsed
+	}
+
+	refresh := c.Query("refresh") == "true"
+	report, err := h.refundUC.GetRefundReport(c.Request.Context(), from, lastDay.AddDate(0, 0, 1), refresh)
+	if err != nil {
+		refundError(c, err, "build refund report")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// RequestRefund godoc
+// @Summary      Request a refund
+// @Description  Ask for a refund of your PAID booking. The reason must be an active refund reason other than event_cancelled. The amount follows the event's refund policy (refund_policy on the event detail) on the day you ask; once the policy allows no refund the request is refused. An admin reviews the request; once approved the refund is processed in the background and you get an email. A booking can have one open request at a time.
+// @Tags         bookings
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Booking ID" example(1)
+// @Param        request body refundBookingRequest true "Refund reason and optional note"
+// @Success      201 {object} map[string]interface{} "Refund requested"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request or reason"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Booking belongs to another user"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      409 {object} middleware.ErrorResponse "Booking not paid, already has an open request or past the refund policy's deadline"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me/bookings/{id}/refund-request [post]
+func (h *RefundHandler) RequestRefund(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID := int64(userIDFloat.(float64))
+
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	var req refundBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid refund request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	refund, err := h.refundUC.RequestRefund(c.Request.Context(), userID, bookingID, req.Reason, req.Note)
+	if err != nil {
+		refundError(c, err, "request refund")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Refund requested"),
+		"data":    refund,
+	})
+}
+
+// ListRequests godoc
+// @Summary      List refund requests (Admin)
+// @Description  Customer refund requests with the booking, customer and event, oldest first. Filter by status to get the review queue. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status query string false "Filter by status" Enums(REQUESTED, APPROVED, REJECTED, COMPLETED)
+// @Success      200 {object} map[string]interface{} "Refund requests"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid status"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/refund-requests [get]
+func (h *RefundHandler) ListRequests(c *gin.Context) {
+	requests, err := h.refundUC.ListRefundRequests(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		refundError(c, err, "list refund requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": requests})
+}
+
+// ApproveRequest godoc
+// @Summary      Approve a refund request (Admin)
+// @Description  Approve a REQUESTED refund. The worker then refunds the share of the booking set when it was requested, releases its seats and emails the customer. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Refund ID" example(1)
+// @Param        request body reviewRefundRequest false "Optional review note"
+// @Success      200 {object} map[string]interface{} "Refund request approved"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Refund request not found"
+// @Failure      409 {object} middleware.ErrorResponse "Request already reviewed"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/refund-requests/{id}/approve [post]
+func (h *RefundHandler) ApproveRequest(c *gin.Context) {
+	h.reviewRequest(c, h.refundUC.ApproveRefundRequest, "approve refund request", "Refund request approved")
+}
+
+// RejectRequest godoc
+// @Summary      Reject a refund request (Admin)
+// @Description  Reject a REQUESTED or not yet processed APPROVED refund. The note is required and sent to the customer. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Refund ID" example(1)
+// @Param        request body reviewRefundRequest true "Note for the customer"
+// @Success      200 {object} map[string]interface{} "Refund request rejected"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request or missing note"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Refund request not found"
+// @Failure      409 {object} middleware.ErrorResponse "Request already completed or rejected"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/refund-requests/{id}/reject [post]
+func (h *RefundHandler) RejectRequest(c *gin.Context) {
+	h.reviewRequest(c, h.refundUC.RejectRefundRequest, "reject refund request", "Refund request rejected")
+}
+
+func (h *RefundHandler) reviewRequest(c *gin.Context, review func(ctx context.Context, refundID int64, note string) (*entity.RefundRequestDetails, error), action, message string) {
+	refundID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid refund ID")
+		return
+	}
+
+	// The note is optional for approvals, so an empty body is allowed
+	var req reviewRefundRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.Warn("handler: invalid refund review request", logger.Err(err))
+			middleware.RespondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	request, err := review(c.Request.Context(), refundID, req.Note)
+	if err != nil {
+		refundError(c, err, action)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, message),
+		"data":    request,
+	})
+}
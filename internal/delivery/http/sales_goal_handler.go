@@ -0,0 +1,161 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SalesGoalHandler struct {
+	goalUC usecase.SalesGoalUsecase
+}
+
+func NewSalesGoalHandler(uc usecase.SalesGoalUsecase) *SalesGoalHandler {
+	return &SalesGoalHandler{goalUC: uc}
+}
+
+type salesGoalRequest struct {
+	TargetTickets int `json:"target_tickets" binding:"required,min=1" example:"800"`
+	// Thresholds are percentages of the target; empty uses 50 and 100
+	Thresholds []int `json:"thresholds" example:"25,50,100"`
+	// NotifySoldOut defaults to true
+	NotifySoldOut *bool `json:"notify_sold_out"`
+	// StallAfterHours of 0 turns stall alerts off
+	StallAfterHours int `json:"stall_after_hours" example:"48"`
+}
+
+func salesGoalError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidSalesGoal):
+		middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+	case errors.Is(err, entity.ErrNotFound):
+		middleware.RespondEntityError(c, http.StatusNotFound, err, "Event or sales goal not found")
+	default:
+		logger.Error("handler: failed to "+action, logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to "+action)
+	}
+}
+
+// organizerEvent reads the calling organizer and the event ID path
+// parameter, responding with an error when either is missing.
+func organizerEvent(c *gin.Context) (organizerID, eventID int64, ok bool) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return 0, 0, false
+	}
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return 0, 0, false
+	}
+	return int64(userIDFloat.(float64)), eventID, true
+}
+
+// Set godoc
+// @Summary      Set an event's sales goal (Organizer)
+// @Description  Set a ticket sales target for an event of the organizer, replacing any previous goal. A scheduled job emails the organizer once as PAID tickets pass each threshold (percentages of the target, 50 and 100 by default), when the event sells out (notify_sold_out, on by default) and when no ticket has sold for stall_after_hours (0, the default, turns this off). The target may not exceed the event's capacity. Setting the goal again re-arms all alerts.
+// @Tags         organizer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body salesGoalRequest true "Sales goal"
+// @Success      200 {object} entity.SalesGoalProgress "Goal set, with sales so far"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request, event ID or goal"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/sales-goal [put]
+func (h *SalesGoalHandler) Set(c *gin.Context) {
+	organizerID, eventID, ok := organizerEvent(c)
+	if !ok {
+		return
+	}
+
+	var req salesGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	goal := &entity.SalesGoal{
+		EventID:         eventID,
+		TargetTickets:   req.TargetTickets,
+		Thresholds:      req.Thresholds,
+		NotifySoldOut:   req.NotifySoldOut == nil || *req.NotifySoldOut,
+		StallAfterHours: req.StallAfterHours,
+	}
+	progress, err := h.goalUC.SetSalesGoal(c.Request.Context(), organizerID, goal)
+	if err != nil {
+		salesGoalError(c, err, "set sales goal")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": progress})
+}
+
+// Get godoc
+// @Summary      Get an event's sales goal (Organizer)
+// @Description  The event's sales goal with the PAID tickets sold against it, the last sale and the alerts already sent.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} entity.SalesGoalProgress "Goal and sales so far"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found or no goal set"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/sales-goal [get]
+func (h *SalesGoalHandler) Get(c *gin.Context) {
+	organizerID, eventID, ok := organizerEvent(c)
+	if !ok {
+		return
+	}
+
+	progress, err := h.goalUC.GetSalesGoal(c.Request.Context(), organizerID, eventID)
+	if err != nil {
+		salesGoalError(c, err, "get sales goal")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": progress})
+}
+
+// Delete godoc
+// @Summary      Delete an event's sales goal (Organizer)
+// @Description  Remove the event's sales goal; no more alerts are sent for it.
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} map[string]string "Goal deleted"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found or no goal set"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/sales-goal [delete]
+func (h *SalesGoalHandler) Delete(c *gin.Context) {
+	organizerID, eventID, ok := organizerEvent(c)
+	if !ok {
+		return
+	}
+
+	if err := h.goalUC.DeleteSalesGoal(c.Request.Context(), organizerID, eventID); err != nil {
+		salesGoalError(c, err, "delete sales goal")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Sales goal deleted")})
+}
@@ -0,0 +1,213 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SectionImageHandler struct {
+	imageUC usecase.SectionImageUsecase
+}
+
+func NewSectionImageHandler(uc usecase.SectionImageUsecase) *SectionImageHandler {
+	return &SectionImageHandler{imageUC: uc}
+}
+
+// Upload godoc
+// @Summary      Upload a section view image (Organizer)
+// @Description  Set the view-from-seat image (JPEG, PNG or WebP, max 5MB) of a section of the caller's event. Replaces any existing image of that section.
+// @Tags         organizer
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        section path string true "Seat section" example(VIP)
+// @Param        file formData file true "Image file"
+// @Success      201 {object} map[string]interface{} "Image uploaded"
+// @Failure      400 {object} middleware.ErrorResponse "Missing or invalid image, or unknown section"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/sections/{section}/image [put]
+func (h *SectionImageHandler) Upload(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "File is required")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error("handler: failed to open uploaded file", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, "Could not read file")
+		return
+	}
+	defer file.Close()
+
+	// Sniff the content type instead of trusting the client-supplied header
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		logger.Error("handler: failed to rewind uploaded file", logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to upload image")
+		return
+	}
+
+	img := &entity.SectionImage{
+		EventID:     eventID,
+		Section:     c.Param("section"),
+		ContentType: http.DetectContentType(head[:n]),
+		SizeBytes:   fileHeader.Size,
+	}
+
+	if err := h.imageUC.UploadSectionImage(c.Request.Context(), organizerID, img, file); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidSectionImage):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+		default:
+			logger.Error("handler: failed to upload section image", logger.Int64("event_id", eventID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to upload image")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Section image uploaded"),
+		"data":    img,
+	})
+}
+
+// Delete godoc
+// @Summary      Remove a section view image (Organizer)
+// @Description  Remove the view-from-seat image of a section of the caller's event
+// @Tags         organizer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        section path string true "Seat section" example(VIP)
+// @Success      200 {object} map[string]string "Image removed"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - organizer only"
+// @Failure      404 {object} middleware.ErrorResponse "Event or image not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /organizer/events/{id}/sections/{section}/image [delete]
+func (h *SectionImageHandler) Delete(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	organizerID := int64(userIDFloat.(float64))
+
+	if err := h.imageUC.DeleteSectionImage(c.Request.Context(), eventID, organizerID, c.Param("section")); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Section image not found")
+			return
+		}
+		logger.Error("handler: failed to delete section image", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to delete image")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Section image removed")})
+}
+
+// List godoc
+// @Summary      List section view images
+// @Description  List the view-from-seat images of an event's sections
+// @Tags         events
+// @Produce      json
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} map[string]interface{} "Section images"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /events/{id}/section-images [get]
+func (h *SectionImageHandler) List(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	images, err := h.imageUC.ListSectionImages(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Event not found")
+			return
+		}
+		logger.Error("handler: failed to list section images", logger.Int64("event_id", eventID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to list section images")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": images})
+}
+
+// Download godoc
+// @Summary      Get a section view image
+// @Description  Stream a view-from-seat image. Image IDs change on every upload, so responses are cacheable.
+// @Tags         events
+// @Produce      octet-stream
+// @Param        id path int true "Image ID" example(1)
+// @Success      200 {file} file "Image content"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid image ID"
+// @Failure      404 {object} middleware.ErrorResponse "Image not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /section-images/{id} [get]
+func (h *SectionImageHandler) Download(c *gin.Context) {
+	imageID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid image ID")
+		return
+	}
+
+	img, content, err := h.imageUC.OpenSectionImage(c.Request.Context(), imageID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Image not found")
+			return
+		}
+		logger.Error("handler: failed to open section image", logger.Int64("image_id", imageID), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to load image")
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, img.SizeBytes, img.ContentType, content, map[string]string{
+		"Cache-Control":          "public, max-age=31536000, immutable",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
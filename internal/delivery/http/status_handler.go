@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http"
+
+	"ticres/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatusHandler struct {
+	statusUC usecase.StatusUsecase
+}
+
+func NewStatusHandler(uc usecase.StatusUsecase) *StatusHandler {
+	return &StatusHandler{statusUC: uc}
+}
+
+// Get godoc
+// @Summary      Platform status
+// @Description  Health of each component for an external status page: database and Redis (with round trip time), the payment gateway's circuit breaker, the background worker queue (due, scheduled, running and dead jobs, outbox backlog and how long the oldest due job has waited) and the last inventory reconciliation run. Each component is operational, degraded or down; the overall status is down when a critical component is down and degraded when any component is not operational. Always answers 200 so the status page can read the body, and is cached for 5 seconds, so it is not meant as a liveness probe.
+// @Tags         status
+// @Produce      json
+// @Success      200 {object} entity.SystemStatus "Platform status"
+// @Router       /status [get]
+func (h *StatusHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": h.statusUC.GetStatus(c.Request.Context())})
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TicketHandler struct {
+	ticketUC usecase.TicketUsecase
+}
+
+func NewTicketHandler(uc usecase.TicketUsecase) *TicketHandler {
+	return &TicketHandler{ticketUC: uc}
+}
+
+// ticketError maps errors shared by the ticket admin endpoints.
+func ticketError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		middleware.RespondEntityError(c, http.StatusNotFound, err, "Booking not found")
+	case errors.Is(err, entity.ErrBookingNotPaid):
+		middleware.RespondEntityError(c, http.StatusConflict, err, err.Error())
+	default:
+		logger.Error("handler: failed to "+action, logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to "+action)
+	}
+}
+
+// ResendTickets godoc
+// @Summary      Resend tickets (Admin)
+// @Description  Email the receipt with the current ticket codes of a PAID booking to its holder again. The codes are not changed. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Booking ID" example(1)
+// @Success      202 {object} map[string]string "Tickets queued for delivery"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid booking ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      409 {object} middleware.ErrorResponse "Booking is not paid"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/bookings/{id}/resend-tickets [post]
+func (h *TicketHandler) ResendTickets(c *gin.Context) {
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	if err := h.ticketUC.ResendTickets(c.Request.Context(), bookingID); err != nil {
+		ticketError(c, err, "resend tickets")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": middleware.T(c, "Tickets will be emailed to the holder")})
+}
+
+// RegenerateTickets godoc
+// @Summary      Regenerate ticket codes (Admin)
+// @Description  Issue new ticket codes for a PAID booking, e.g. after the QR codes leaked. The old codes are revoked and rejected at check-in, check-in state is kept, and the holder is emailed the new tickets. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Booking ID" example(1)
+// @Success      200 {object} map[string]interface{} "New tickets"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid booking ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      409 {object} middleware.ErrorResponse "Booking is not paid"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/bookings/{id}/regenerate-tickets [post]
+func (h *TicketHandler) RegenerateTickets(c *gin.Context) {
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	tickets, err := h.ticketUC.RegenerateTickets(c.Request.Context(), bookingID)
+	if err != nil {
+		ticketError(c, err, "regenerate tickets")
+		return
+	}
+
+	logger.Info("handler: tickets regenerated", logger.Int64("booking_id", bookingID), logger.Int("count", len(tickets)))
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Tickets regenerated"),
+		"data":    tickets,
+	})
+}
@@ -0,0 +1,249 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TicketTierHandler struct {
+	tierUC usecase.TicketTierUsecase
+}
+
+func NewTicketTierHandler(uc usecase.TicketTierUsecase) *TicketTierHandler {
+	return &TicketTierHandler{tierUC: uc}
+}
+
+type ticketTierRequest struct {
+	Name  string  `json:"name" binding:"required" example:"VIP"`
+	Price float64 `json:"price" binding:"min=0" example:"750000"`
+	Quota int     `json:"quota" binding:"required,min=1" example:"200"`
+}
+
+// tierError maps tier errors shared by every tier endpoint.
+func tierError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		middleware.RespondEntityError(c, http.StatusNotFound, err, "Event or ticket tier not found")
+	case errors.Is(err, entity.ErrInvalidTicketTier):
+		middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+	case errors.Is(err, entity.ErrTierNameTaken), errors.Is(err, entity.ErrTierQuotaExceeded):
+		middleware.RespondEntityError(c, http.StatusConflict, err, err.Error())
+	default:
+		logger.Error("handler: failed to "+action, logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to "+action)
+	}
+}
+
+// Create godoc
+// @Summary      Create a ticket tier (Admin)
+// @Description  Add a named price level to an event. Seats assigned to the tier are sold at its price; quota caps how many seats it may hold. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        request body ticketTierRequest true "Tier details"
+// @Success      201 {object} map[string]interface{} "Tier created"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      409 {object} middleware.ErrorResponse "Tier name already used"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/tiers [post]
+func (h *TicketTierHandler) Create(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req ticketTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid ticket tier request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tier := &entity.TicketTier{EventID: eventID, Name: req.Name, Price: req.Price, Quota: req.Quota}
+	if err := h.tierUC.CreateTier(c.Request.Context(), tier); err != nil {
+		tierError(c, err, "create ticket tier")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Ticket tier created"),
+		"data":    tier,
+	})
+}
+
+// List godoc
+// @Summary      List ticket tiers (Admin)
+// @Description  List an event's ticket tiers with assigned and sold seat counts. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Success      200 {object} map[string]interface{} "Ticket tiers"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid event ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Event not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/tiers [get]
+func (h *TicketTierHandler) List(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	tiers, err := h.tierUC.ListTiers(c.Request.Context(), eventID)
+	if err != nil {
+		tierError(c, err, "list ticket tiers")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": tiers})
+}
+
+// Update godoc
+// @Summary      Update a ticket tier (Admin)
+// @Description  Change a tier's name, price and quota. A new price applies to the tier's unsold seats; the quota cannot drop below the seats already assigned. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        tier_id path int true "Tier ID" example(1)
+// @Param        request body ticketTierRequest true "Tier details"
+// @Success      200 {object} map[string]interface{} "Tier updated"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Tier not found"
+// @Failure      409 {object} middleware.ErrorResponse "Tier name already used or quota below assigned seats"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/tiers/{tier_id} [put]
+func (h *TicketTierHandler) Update(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	tierID, err := strconv.ParseInt(c.Param("tier_id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid tier ID")
+		return
+	}
+
+	var req ticketTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid ticket tier request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tier := &entity.TicketTier{ID: tierID, EventID: eventID, Name: req.Name, Price: req.Price, Quota: req.Quota}
+	if err := h.tierUC.UpdateTier(c.Request.Context(), tier); err != nil {
+		tierError(c, err, "update ticket tier")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Ticket tier updated"),
+		"data":    tier,
+	})
+}
+
+// Delete godoc
+// @Summary      Delete a ticket tier (Admin)
+// @Description  Remove a tier. Its seats leave the tier and keep their current price. Admin access required.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        tier_id path int true "Tier ID" example(1)
+// @Success      200 {object} map[string]string "Tier deleted"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Tier not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/tiers/{tier_id} [delete]
+func (h *TicketTierHandler) Delete(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	tierID, err := strconv.ParseInt(c.Param("tier_id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid tier ID")
+		return
+	}
+
+	if err := h.tierUC.DeleteTier(c.Request.Context(), eventID, tierID); err != nil {
+		tierError(c, err, "delete ticket tier")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Ticket tier deleted")})
+}
+
+// AssignSeats godoc
+// @Summary      Assign seats to a ticket tier (Admin)
+// @Description  Move unsold seats into the tier at its price, by seat ID and/or by section. Sold seats are skipped. Fails without changes if the tier would exceed its quota. Admin access required.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Event ID" example(1)
+// @Param        tier_id path int true "Tier ID" example(1)
+// @Param        request body entity.TierSeatAssignment true "Seats to assign"
+// @Success      200 {object} map[string]interface{} "Seats assigned"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Tier not found"
+// @Failure      409 {object} middleware.ErrorResponse "Tier quota exceeded"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/events/{id}/tiers/{tier_id}/seats [post]
+func (h *TicketTierHandler) AssignSeats(c *gin.Context) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	tierID, err := strconv.ParseInt(c.Param("tier_id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid tier ID")
+		return
+	}
+
+	var req entity.TierSeatAssignment
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid tier seat assignment", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	moved, err := h.tierUC.AssignSeats(c.Request.Context(), eventID, tierID, &req)
+	if err != nil {
+		tierError(c, err, "assign seats to ticket tier")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Seats assigned to ticket tier"),
+		"data":    gin.H{"seats_assigned": moved},
+	})
+}
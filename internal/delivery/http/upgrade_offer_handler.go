@@ -0,0 +1,110 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpgradeOfferHandler struct {
+	offerUC usecase.UpgradeOfferUsecase
+}
+
+func NewUpgradeOfferHandler(uc usecase.UpgradeOfferUsecase) *UpgradeOfferHandler {
+	return &UpgradeOfferHandler{offerUC: uc}
+}
+
+// The token travels in the body rather than the URL so it stays out of access logs
+type upgradeOfferTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type acceptUpgradeOfferRequest struct {
+	Token         string `json:"token" binding:"required"`
+	PaymentMethod string `json:"payment_method" binding:"omitempty,oneof=credit_card bank_transfer e_wallet" example:"e_wallet"`
+}
+
+// Lookup godoc
+// @Summary      View an upgrade offer
+// @Description  Offer details for the token from the upgrade offer email: event, current and premium seat, price difference and expiry.
+// @Tags         upgrade-offers
+// @Accept       json
+// @Produce      json
+// @Param        request body upgradeOfferTokenRequest true "Offer token"
+// @Success      200 {object} entity.UpgradeOffer "Upgrade offer"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body"
+// @Failure      404 {object} middleware.ErrorResponse "Unknown token"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /upgrade-offers/lookup [post]
+func (h *UpgradeOfferHandler) Lookup(c *gin.Context) {
+	var req upgradeOfferTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	offer, err := h.offerUC.GetOffer(c.Request.Context(), req.Token)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Upgrade offer not found")
+			return
+		}
+		logger.Error("handler: failed to get upgrade offer", logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get upgrade offer")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": offer})
+}
+
+// Accept godoc
+// @Summary      Accept an upgrade offer
+// @Description  One-click accept with the emailed token: the ticket moves to the premium seat and the price difference is charged, by default to the booking's original payment method. A new ticket code is issued and the receipt re-sent.
+// @Tags         upgrade-offers
+// @Accept       json
+// @Produce      json
+// @Param        request body acceptUpgradeOfferRequest true "Offer token and optional payment method"
+// @Success      200 {object} entity.BookingModification "Seat upgraded"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body or payment method"
+// @Failure      404 {object} middleware.ErrorResponse "Unknown token"
+// @Failure      409 {object} middleware.ErrorResponse "Offer closed, seat taken, or ticket already used"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /upgrade-offers/accept [post]
+func (h *UpgradeOfferHandler) Accept(c *gin.Context) {
+	var req acceptUpgradeOfferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	mod, err := h.offerUC.AcceptOffer(c.Request.Context(), req.Token, req.PaymentMethod)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Upgrade offer not found")
+		case errors.Is(err, entity.ErrInvalidPaymentMethod):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Invalid payment method. Use: credit_card, bank_transfer, or e_wallet")
+		case errors.Is(err, entity.ErrUpgradeOfferClosed):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "This upgrade offer has already been accepted or has expired")
+		case errors.Is(err, entity.ErrSeatUnavailable):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "This premium seat is no longer available")
+		case errors.Is(err, entity.ErrTicketAlreadyUsed), errors.Is(err, entity.ErrBookingNotPaid), errors.Is(err, entity.ErrInvalidSeatChange):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "The ticket can no longer be upgraded")
+		default:
+			logger.Error("handler: failed to accept upgrade offer", logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to accept upgrade offer")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Seat upgraded"),
+		"data":    mod,
+	})
+}
@@ -1,8 +1,13 @@
 package http
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"ticres/internal/delivery/http/middleware"
 	"ticres/internal/entity"
 	"ticres/internal/usecase"
 	"ticres/pkg/logger"
@@ -28,11 +33,6 @@ type registerRequest struct {
 	Password string `json:"password" binding:"required,min=6"`
 }
 
-// errorResponse represents an error response
-type errorResponse struct {
-	Error string `json:"error" example:"Invalid request"`
-}
-
 // successResponse represents a success response
 type successResponse struct {
 	Message string      `json:"message" example:"Success"`
@@ -47,9 +47,9 @@ type successResponse struct {
 // @Produce      json
 // @Param        request body registerRequest true "User registration details"
 // @Success      201 {object} map[string]interface{} "User registered successfully"
-// @Failure      400 {object} map[string]string "Invalid request body"
-// @Failure      409 {object} map[string]string "Email already registered"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body"
+// @Failure      409 {object} middleware.ErrorResponse "Email already registered"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
 // @Router       /register [post]
 func (h *UserHandler) Register(c *gin.Context) {
 	logger.Debug("handler: register request received")
@@ -57,7 +57,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 	var req registerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Warn("handler: invalid register request", logger.Err(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -70,11 +70,11 @@ func (h *UserHandler) Register(c *gin.Context) {
 	if err := h.userUsecase.Register(c.Request.Context(), user); err != nil {
 		if err == entity.ErrUserAlreadyExsist {
 			logger.Warn("handler: registration failed - email already exists", logger.String("email", req.Email))
-			c.JSON(http.StatusConflict, gin.H{"error": "Email already registered"})
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Email already registered")
 			return
 		}
 		logger.Error("handler: registration failed", logger.String("email", req.Email), logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal registrasi user: " + err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -83,7 +83,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 		logger.String("email", user.Email),
 	)
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "User registered successfully",
+		"message": middleware.T(c, "User registered successfully"),
 		"data": gin.H{
 			"id":         user.ID,
 			"name":       user.Name,
@@ -100,15 +100,15 @@ type loginRequest struct {
 
 // Login godoc
 // @Summary      User login
-// @Description  Authenticate user and return JWT token
+// @Description  Authenticate user and return JWT token. Users with two-factor authentication get no token yet: the response has two_factor_required set and a challenge_token, valid for 5 minutes, to send with their code to /auth/login/2fa.
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Param        request body loginRequest true "User login credentials"
-// @Success      200 {object} map[string]interface{} "Login successful, JWT token returned"
-// @Failure      400 {object} map[string]string "Invalid request body"
-// @Failure      401 {object} map[string]string "Invalid email or password"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Success      200 {object} entity.LoginResult "Login successful, JWT token or two-factor challenge returned"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body"
+// @Failure      401 {object} middleware.ErrorResponse "Invalid email or password"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
 // @Router       /login [post]
 func (h *UserHandler) Login(c *gin.Context) {
 	logger.Debug("handler: login request received")
@@ -116,28 +116,226 @@ func (h *UserHandler) Login(c *gin.Context) {
 	var req loginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Warn("handler: invalid login request", logger.Err(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	token, err := h.userUsecase.Login(c.Request.Context(), req.Email, req.Password)
+	result, err := h.userUsecase.Login(clientContext(c), req.Email, req.Password)
 	if err != nil {
 		if err.Error() == "invalid email or password" {
 			logger.Warn("handler: login failed - invalid credentials", logger.String("email", req.Email))
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+			middleware.RespondError(c, http.StatusUnauthorized, "Invalid email or password")
 		} else {
 			logger.Error("handler: login failed", logger.String("email", req.Email), logger.Err(err))
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Login failed"})
+			middleware.RespondError(c, http.StatusInternalServerError, "Login failed")
 		}
 		return
 	}
 
+	if result.TwoFactorRequired {
+		logger.Info("handler: login awaiting two-factor code", logger.String("email", req.Email))
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
 	logger.Info("handler: user logged in", logger.String("email", req.Email))
+	c.JSON(http.StatusOK, gin.H{
+		"token": result.Token,
+	})
+}
+
+type completeLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required" example:"123456"`
+}
+
+// CompleteLogin godoc
+// @Summary      Complete login with a two-factor code
+// @Description  Exchange the challenge token from /login and a code from the user's authenticator app, or one of their recovery codes, for a JWT token. Each code works once, and after 5 wrong codes two-factor logins are locked for 15 minutes.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        request body completeLoginRequest true "Login challenge and code"
+// @Success      200 {object} map[string]interface{} "Login successful, JWT token returned"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body"
+// @Failure      401 {object} middleware.ErrorResponse "Invalid code or expired challenge"
+// @Failure      429 {object} middleware.ErrorResponse "Too many wrong codes"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /auth/login/2fa [post]
+func (h *UserHandler) CompleteLogin(c *gin.Context) {
+	var req completeLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid two-factor login request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token, err := h.userUsecase.CompleteLogin(clientContext(c), req.ChallengeToken, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidTwoFactorCode):
+			middleware.RespondEntityError(c, http.StatusUnauthorized, err, "Invalid two-factor code")
+		case errors.Is(err, entity.ErrInvalidLoginChallenge):
+			middleware.RespondEntityError(c, http.StatusUnauthorized, err, "Login has expired, please log in again")
+		case errors.Is(err, entity.ErrTooManyTwoFactorAttempts):
+			middleware.RespondEntityError(c, http.StatusTooManyRequests, err, "Too many wrong codes, please try again later")
+		default:
+			logger.Error("handler: two-factor login failed", logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Login failed")
+		}
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"token": token,
 	})
 }
 
+// GoogleLogin godoc
+// @Summary      Sign in with Google
+// @Description  Redirect to Google to sign in. Google sends the user back to /auth/google/callback, which answers like /login.
+// @Tags         users
+// @Success      302 "Redirect to Google"
+// @Failure      404 {object} middleware.ErrorResponse "Google sign-in not configured"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /auth/google [get]
+func (h *UserHandler) GoogleLogin(c *gin.Context) {
+	url, err := h.userUsecase.OAuthURL(c.Request.Context(), "google")
+	if err != nil {
+		if errors.Is(err, entity.ErrOAuthProviderUnavailable) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Google sign-in is not available")
+			return
+		}
+		logger.Error("handler: failed to start google sign-in", logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Login failed")
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+// GoogleCallback godoc
+// @Summary      Google sign-in callback
+// @Description  Finish signing in with Google: exchange the code for the user's Google profile and return a JWT token, or a two-factor challenge like /login. A first sign-in creates an account, or links the one registered with the same verified email.
+// @Tags         users
+// @Produce      json
+// @Param        code   query string false "Authorization code from Google"
+// @Param        state  query string false "State from /auth/google"
+// @Param        error  query string false "Set by Google when the user cancelled"
+// @Success      200 {object} entity.LoginResult "Login successful, JWT token or two-factor challenge returned"
+// @Failure      400 {object} middleware.ErrorResponse "Cancelled, expired or invalid sign-in"
+// @Failure      403 {object} middleware.ErrorResponse "Google email not verified"
+// @Failure      404 {object} middleware.ErrorResponse "Google sign-in not configured"
+// @Failure      409 {object} middleware.ErrorResponse "Account linked to another Google account"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /auth/google/callback [get]
+func (h *UserHandler) GoogleCallback(c *gin.Context) {
+	if reason := c.Query("error"); reason != "" {
+		logger.Info("handler: google sign-in cancelled", logger.String("reason", reason))
+		middleware.RespondError(c, http.StatusBadRequest, "Google sign-in was cancelled")
+		return
+	}
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		middleware.RespondError(c, http.StatusBadRequest, "code and state are required")
+		return
+	}
+
+	result, err := h.userUsecase.OAuthLogin(clientContext(c), "google", code, state)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidOAuthState), errors.Is(err, entity.ErrInvalidOAuthCode):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Google sign-in has expired, please try again")
+		case errors.Is(err, entity.ErrOAuthEmailUnverified):
+			middleware.RespondEntityError(c, http.StatusForbidden, err, "Your Google account's email is not verified")
+		case errors.Is(err, entity.ErrOAuthAccountConflict):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "This account is linked to another Google account")
+		case errors.Is(err, entity.ErrOAuthProviderUnavailable):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Google sign-in is not available")
+		default:
+			logger.Error("handler: google sign-in failed", logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Login failed")
+		}
+		return
+	}
+
+	if result.TwoFactorRequired {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"token": result.Token,
+	})
+}
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// ForgotPassword godoc
+// @Summary      Request a password reset email
+// @Description  Email a single-use reset link valid for 30 minutes. Always responds with success so registered emails can't be discovered.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        request body forgotPasswordRequest true "Account email"
+// @Success      200 {object} map[string]string "Reset email sent if the account exists"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /auth/forgot-password [post]
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var req forgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid forgot password request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.userUsecase.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		logger.Error("handler: forgot password failed", logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to process request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "If the email is registered, a reset link has been sent")})
+}
+
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Set a new password using the token from the reset email. Each token works once.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        request body resetPasswordRequest true "Reset token and new password"
+// @Success      200 {object} map[string]string "Password reset"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body or token"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /auth/reset-password [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req resetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid reset password request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.userUsecase.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		if errors.Is(err, entity.ErrInvalidResetToken) {
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Reset link is invalid or has expired")
+			return
+		}
+		logger.Error("handler: reset password failed", logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Password has been reset. Please log in with your new password.")})
+}
+
 // Me godoc
 // @Summary      Get current user profile
 // @Description  Get the profile of the currently authenticated user
@@ -146,14 +344,14 @@ func (h *UserHandler) Login(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200 {object} map[string]interface{} "User profile retrieved successfully"
-// @Failure      401 {object} map[string]string "User not authenticated"
-// @Failure      500 {object} map[string]string "Failed to get user profile"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      500 {object} middleware.ErrorResponse "Failed to get user profile"
 // @Router       /me [get]
 func (h *UserHandler) Me(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
 		logger.Warn("handler: user not authenticated for /me endpoint")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
@@ -163,7 +361,73 @@ func (h *UserHandler) Me(c *gin.Context) {
 	user, err := h.userUsecase.GetProfile(c.Request.Context(), uid)
 	if err != nil {
 		logger.Error("handler: failed to get user profile", logger.Int("user_id", uid), logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": user,
+	})
+}
+
+type updateProfileRequest struct {
+	Name     string `json:"name" binding:"required" example:"Budi Santoso"`
+	Username string `json:"username" example:"budi.s"`
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+type deleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type verifyTwoFactorRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// UpdateMe godoc
+// @Summary      Update current user profile
+// @Description  Set the current user's name and username. The username is optional, 3 to 30 letters, digits, dots or underscores, and unique regardless of case; leaving it empty removes it. The email can't be changed here.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body updateProfileRequest true "Profile"
+// @Success      200 {object} entity.User "Updated profile"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid name or username"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      409 {object} middleware.ErrorResponse "Username already taken"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me [put]
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	var req updateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid profile update request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.userUsecase.UpdateProfile(c.Request.Context(), uid, req.Name, req.Username)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidProfile):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+		case errors.Is(err, entity.ErrUsernameTaken):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Username is already taken")
+		default:
+			logger.Error("handler: failed to update profile", logger.Int64("user_id", uid), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to update profile")
+		}
 		return
 	}
 
@@ -172,6 +436,301 @@ func (h *UserHandler) Me(c *gin.Context) {
 	})
 }
 
+// ChangePassword godoc
+// @Summary      Change password
+// @Description  Set a new password after checking the current one. Password reset links emailed earlier stop working, and every other session of the user is revoked.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body changePasswordRequest true "Current and new password"
+// @Success      200 {object} map[string]string "Password changed"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body or wrong current password"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me/password [put]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	var req changePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid change password request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.userUsecase.ChangePassword(c.Request.Context(), uid, c.GetInt64("sessionID"), req.CurrentPassword, req.NewPassword); err != nil {
+		if errors.Is(err, entity.ErrWrongPassword) {
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Current password is incorrect")
+			return
+		}
+		logger.Error("handler: failed to change password", logger.Int64("user_id", uid), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to change password")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Password changed")})
+}
+
+// DeleteMe godoc
+// @Summary      Delete current user account
+// @Description  Delete the current user's account after checking their password. The name, username, email and password are wiped and the account can no longer sign in; bookings, payments and invoices are kept for the books under an anonymous name. Organizer and admin accounts must have that role revoked first.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body deleteAccountRequest true "Current password"
+// @Success      200 {object} map[string]string "Account deleted"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body or wrong password"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      409 {object} middleware.ErrorResponse "Organizer or admin account"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me [delete]
+func (h *UserHandler) DeleteMe(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	var req deleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid delete account request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.userUsecase.DeleteAccount(c.Request.Context(), uid, req.Password); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrWrongPassword):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Password is incorrect")
+		case errors.Is(err, entity.ErrAccountNotDeletable):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Organizer and admin accounts can't be deleted")
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "User not found")
+		default:
+			logger.Error("handler: failed to delete account", logger.Int64("user_id", uid), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to delete account")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Account deleted")})
+}
+
+// ExportMe godoc
+// @Summary      Export current user data
+// @Description  Download a JSON archive of the current user's profile, bookings and payments. The archive is built in the background: the first call answers 202 with the export's status, and the user is emailed once it is ready. Calls while it is building answer 202 too; once READY the archive itself is returned, for a day, after which the next call builds a fresh one.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} entity.UserDataArchive "Data archive"
+// @Success      202 {object} entity.UserDataExport "Export being built"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me/export [get]
+func (h *UserHandler) ExportMe(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	export, err := h.userUsecase.RequestDataExport(c.Request.Context(), uid)
+	if err != nil {
+		logger.Error("handler: failed to export user data", logger.Int64("user_id", uid), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to export user data")
+		return
+	}
+
+	if export.Status != entity.UserExportReady {
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": middleware.T(c, "Your data export is being prepared"),
+			"data":    export,
+		})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="ticres-export-%d.json"`, export.ID))
+	c.Data(http.StatusOK, "application/json", export.Archive)
+}
+
+// EnableTwoFactor godoc
+// @Summary      Start setting up two-factor authentication
+// @Description  Generate a TOTP secret for the current user's authenticator app, returned both as is and as an otpauth:// provisioning URI to show as a QR code. Two-factor authentication only takes effect once a code is confirmed with /me/2fa/verify; calling this again before then replaces the secret.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} entity.TwoFactorSetup "Secret to add to the authenticator app"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      409 {object} middleware.ErrorResponse "Two-factor authentication already enabled"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me/2fa/enable [post]
+func (h *UserHandler) EnableTwoFactor(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	setup, err := h.userUsecase.EnableTwoFactor(c.Request.Context(), uid)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrTwoFactorEnabled):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Two-factor authentication is already enabled")
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "User not found")
+		default:
+			logger.Error("handler: failed to start two-factor setup", logger.Int64("user_id", uid), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to set up two-factor authentication")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Scan the code with your authenticator app, then confirm a code to finish"),
+		"data":    setup,
+	})
+}
+
+// VerifyTwoFactor godoc
+// @Summary      Confirm two-factor authentication
+// @Description  Turn two-factor authentication on with a code from the authenticator app set up by /me/2fa/enable. The response holds 10 single-use recovery codes for signing in without the app; they are not shown again.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body verifyTwoFactorRequest true "Code from the authenticator app"
+// @Success      200 {object} map[string]interface{} "Two-factor authentication enabled, recovery codes returned"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid request body or code"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      409 {object} middleware.ErrorResponse "Already enabled or not set up"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me/2fa/verify [post]
+func (h *UserHandler) VerifyTwoFactor(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	var req verifyTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("handler: invalid two-factor verify request", logger.Err(err))
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	codes, err := h.userUsecase.VerifyTwoFactor(c.Request.Context(), uid, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidTwoFactorCode):
+			middleware.RespondEntityError(c, http.StatusBadRequest, err, "Invalid two-factor code")
+		case errors.Is(err, entity.ErrTwoFactorEnabled):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Two-factor authentication is already enabled")
+		case errors.Is(err, entity.ErrTwoFactorNotSetUp):
+			middleware.RespondEntityError(c, http.StatusConflict, err, "Set up two-factor authentication first")
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "User not found")
+		default:
+			logger.Error("handler: failed to verify two-factor", logger.Int64("user_id", uid), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to set up two-factor authentication")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": middleware.T(c, "Two-factor authentication enabled. Keep your recovery codes somewhere safe, they won't be shown again."),
+		"data":    gin.H{"recovery_codes": codes},
+	})
+}
+
+// ListSessions godoc
+// @Summary      List signed-in devices
+// @Description  The current user's active sessions, one per login, with the user agent and IP address it was made from and when it was last used, most recent first. current marks the session of this request.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} entity.Session "Active sessions"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me/sessions [get]
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	sessions, err := h.userUsecase.ListSessions(c.Request.Context(), uid)
+	if err != nil {
+		logger.Error("handler: failed to list sessions", logger.Int64("user_id", uid), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get sessions")
+		return
+	}
+
+	current := c.GetInt64("sessionID")
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == current
+	}
+	c.JSON(http.StatusOK, gin.H{"data": sessions})
+}
+
+// RevokeSession godoc
+// @Summary      Sign out a device
+// @Description  Revoke one of the current user's sessions. Its token stops working on the next request, over HTTP and gRPC. Revoking the current session logs this client out.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Session ID"
+// @Success      200 {object} map[string]string "Session revoked"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid session ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      404 {object} middleware.ErrorResponse "Session not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /me/sessions/{id} [delete]
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	if err := h.userUsecase.RevokeSession(c.Request.Context(), uid, sessionID); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Session not found")
+			return
+		}
+		logger.Error("handler: failed to revoke session", logger.Int64("user_id", uid), logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to revoke session")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Session revoked")})
+}
+
+// clientContext is the request context with the client's user agent and
+// IP address, recorded with the session a login opens.
+func clientContext(c *gin.Context) context.Context {
+	return usecase.WithClient(c.Request.Context(), c.Request.UserAgent(), c.ClientIP())
+}
+
 // GetMyBookings godoc
 // @Summary      Get current user's bookings
 // @Description  Retrieve all bookings made by the currently authenticated user
@@ -180,14 +739,14 @@ func (h *UserHandler) Me(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200 {object} map[string]interface{} "User bookings retrieved successfully"
-// @Failure      401 {object} map[string]string "User not authenticated"
-// @Failure      500 {object} map[string]string "Failed to get user bookings"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      500 {object} middleware.ErrorResponse "Failed to get user bookings"
 // @Router       /me/bookings [get]
 func (h *UserHandler) GetMyBookings(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
 		logger.Warn("handler: user not authenticated for /me/bookings endpoint")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
@@ -197,7 +756,7 @@ func (h *UserHandler) GetMyBookings(c *gin.Context) {
 	bookings, err := h.bookingUsecase.GetBookingsByUserID(c.Request.Context(), uid)
 	if err != nil {
 		logger.Error("handler: failed to get user bookings", logger.Int64("user_id", uid), logger.Err(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get bookings"})
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to get bookings")
 		return
 	}
 
@@ -206,3 +765,51 @@ func (h *UserHandler) GetMyBookings(c *gin.Context) {
 		"data": bookings,
 	})
 }
+
+// GetMyBooking godoc
+// @Summary      Get one of the current user's bookings
+// @Description  Booking detail with the event, every seat (number, category, price), the latest payment, the latest refund or refund request, and the payment deadline
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Booking ID" example(1)
+// @Success      200 {object} entity.BookingDetail "Booking detail"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid booking ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Booking belongs to another user"
+// @Failure      404 {object} middleware.ErrorResponse "Booking not found"
+// @Failure      500 {object} middleware.ErrorResponse "Failed to get booking"
+// @Router       /me/bookings/{id} [get]
+func (h *UserHandler) GetMyBooking(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		logger.Warn("handler: user not authenticated for /me/bookings/:id endpoint")
+		middleware.RespondError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+	uid := int64(userID.(float64))
+
+	bookingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	booking, err := h.bookingUsecase.GetBookingDetail(c.Request.Context(), uid, bookingID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			middleware.RespondEntityError(c, http.StatusNotFound, err, "Booking not found")
+		case errors.Is(err, entity.ErrUnauthorized):
+			middleware.RespondEntityError(c, http.StatusForbidden, err, "You don't have access to this booking")
+		default:
+			logger.Error("handler: failed to get booking detail", logger.Int64("booking_id", bookingID), logger.Err(err))
+			middleware.RespondError(c, http.StatusInternalServerError, "Failed to get booking")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": booking,
+	})
+}
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ticres/internal/delivery/http/middleware"
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookUC usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(uc usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{webhookUC: uc}
+}
+
+type createWebhookRequest struct {
+	OrganizerID *int64   `json:"organizer_id" example:"7"`
+	URL         string   `json:"url" binding:"required" example:"https://crm.example.com/hooks/ticres"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1" example:"booking.created,payment.completed,booking.refunded"`
+}
+
+func webhookError(c *gin.Context, err error, action, notFound string) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidWebhook):
+		middleware.RespondEntityError(c, http.StatusBadRequest, err, err.Error())
+	case errors.Is(err, entity.ErrNotFound):
+		middleware.RespondEntityError(c, http.StatusNotFound, err, notFound)
+	default:
+		logger.Error("handler: failed to "+action, logger.Err(err))
+		middleware.RespondError(c, http.StatusInternalServerError, "Failed to "+action)
+	}
+}
+
+// Create godoc
+// @Summary      Add a webhook (Admin)
+// @Description  Subscribe a URL to booking lifecycle events: `booking.created`, `payment.completed` and `booking.refunded`. With `organizer_id` it receives the events of that organizer's events, otherwise of every event. Each event is POSTed as JSON with an `X-TicRes-Signature` header `t=<unix time>,v1=<hex HMAC-SHA256 of "<t>.<body>">` made with the webhook's secret, which is shown only in this response. Failed deliveries are retried with backoff. The webhook is recorded in the audit log.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body createWebhookRequest true "Organizer, URL and event types"
+// @Success      201 {object} entity.Webhook "Webhook added, with its signing secret"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid URL, event type or organizer"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Organizer not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	userIDFloat, exists := c.Get("userID")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	adminID := int64(userIDFloat.(float64))
+
+	var req createWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook := &entity.Webhook{OrganizerID: req.OrganizerID, URL: req.URL, EventTypes: req.EventTypes}
+	if err := h.webhookUC.CreateWebhook(c.Request.Context(), adminID, webhook); err != nil {
+		webhookError(c, err, "create webhook", "Organizer not found")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": middleware.T(c, "Webhook created; store the secret now, it is not shown again"),
+		"data":    webhook,
+	})
+}
+
+// List godoc
+// @Summary      List webhooks (Admin)
+// @Description  Webhooks, newest first, including disabled ones. Secrets are not included.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        organizer_id query int false "Only webhooks of this organizer" example(7)
+// @Success      200 {array} entity.Webhook "Webhooks"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid organizer ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks [get]
+func (h *WebhookHandler) List(c *gin.Context) {
+	var organizerID *int64
+	if raw := c.Query("organizer_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, "Invalid organizer ID")
+			return
+		}
+		organizerID = &id
+	}
+
+	webhooks, err := h.webhookUC.ListWebhooks(c.Request.Context(), organizerID)
+	if err != nil {
+		webhookError(c, err, "list webhooks", "Webhook not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": webhooks})
+}
+
+// Update godoc
+// @Summary      Update a webhook (Admin)
+// @Description  Change a webhook's URL or event types, or disable it with `"active": false`. Fields left out keep their value. Deliveries still pending for a disabled webhook are marked FAILED at their next attempt. The change is recorded in the audit log.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Webhook ID" example(3)
+// @Param        request body entity.WebhookUpdate true "Fields to change"
+// @Success      200 {object} entity.Webhook "Updated webhook"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid webhook ID, URL or event type"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Webhook not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks/{id} [patch]
+func (h *WebhookHandler) Update(c *gin.Context) {
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	var req entity.WebhookUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.webhookUC.UpdateWebhook(c.Request.Context(), webhookID, req)
+	if err != nil {
+		webhookError(c, err, "update webhook", "Webhook not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": webhook})
+}
+
+// Delete godoc
+// @Summary      Delete a webhook (Admin)
+// @Description  Remove a webhook with its delivery log. Pending deliveries are dropped. The deletion is recorded in the audit log.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Webhook ID" example(3)
+// @Success      200 {object} map[string]string "Webhook deleted"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid webhook ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Webhook not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	if err := h.webhookUC.DeleteWebhook(c.Request.Context(), webhookID); err != nil {
+		webhookError(c, err, "delete webhook", "Webhook not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": middleware.T(c, "Webhook deleted")})
+}
+
+// Deliveries godoc
+// @Summary      List a webhook's deliveries (Admin)
+// @Description  Delivery log of a webhook, newest first: each event sent with its payload, status (`PENDING` while retried, `SUCCEEDED`, or `FAILED` once out of attempts), number of attempts, and the response status and error of the last attempt.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Webhook ID" example(3)
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        limit query int false "Items per page (max 100)" default(50) minimum(1) maximum(100)
+// @Success      200 {object} map[string]interface{} "Deliveries with pagination metadata"
+// @Failure      400 {object} middleware.ErrorResponse "Invalid webhook ID"
+// @Failure      401 {object} middleware.ErrorResponse "User not authenticated"
+// @Failure      403 {object} middleware.ErrorResponse "Access forbidden - admin only"
+// @Failure      404 {object} middleware.ErrorResponse "Webhook not found"
+// @Failure      500 {object} middleware.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	deliveries, total, err := h.webhookUC.ListDeliveries(c.Request.Context(), webhookID, page, limit)
+	if err != nil {
+		webhookError(c, err, "list webhook deliveries", "Webhook not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": deliveries,
+		"meta": gin.H{
+			"total":   total,
+			"page":    page,
+			"limit":   limit,
+			"hasMore": page*limit < total,
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// SalesStats summarizes paid seat sales for an event.
+type SalesStats struct {
+	TotalSold    int
+	SoldInWindow int
+	FirstSaleAt  *time.Time
+}
+
+// SalesForecast projects when an event will sell out based on recent sales velocity.
+type SalesForecast struct {
+	EventID             int64      `json:"event_id"`
+	Capacity            int        `json:"capacity"`
+	Sold                int        `json:"sold"`
+	Remaining           int        `json:"remaining"`
+	VelocityPerHour     float64    `json:"velocity_per_hour"`
+	SellOutETA          *time.Time `json:"sell_out_eta,omitempty"`
+	SoldOut             bool       `json:"sold_out"`
+	SellsOutBeforeEvent bool       `json:"sells_out_before_event"`
+	TargetSold          int        `json:"target_sold"`
+	LaggingTarget       bool       `json:"lagging_target"`
+	GeneratedAt         time.Time  `json:"generated_at"`
+}
+
+// SalesCurvePoint is cumulative sales at a normalized time-to-event bucket,
+// e.g. DaysBefore 7 means "sold by one week before the event".
+type SalesCurvePoint struct {
+	DaysBefore     int     `json:"days_before"`
+	Sold           int     `json:"sold"`
+	CumulativeSold int     `json:"cumulative_sold"`
+	PercentSold    float64 `json:"percent_sold"`
+}
+
+// EventSalesCurve is the sales curve of one event on the shared bucket scale.
+type EventSalesCurve struct {
+	EventID  int64             `json:"event_id"`
+	Name     string            `json:"name"`
+	Location string            `json:"location"`
+	Series   string            `json:"series,omitempty"`
+	Date     time.Time         `json:"date"`
+	Capacity int               `json:"capacity"`
+	Points   []SalesCurvePoint `json:"points"`
+}
+
+// EventComparison lines an event's sales curve up against past events from the
+// same series or venue.
+type EventComparison struct {
+	GroupBy string            `json:"group_by"`
+	Buckets []int             `json:"buckets"`
+	Current EventSalesCurve   `json:"current"`
+	Past    []EventSalesCurve `json:"past"`
+}
@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+// API key scopes. A key works on the admin or organizer routes, read only
+// (GET) or read and write, and only for an owner with that role.
+const (
+	ScopeAdminRead      = "admin:read"
+	ScopeAdminWrite     = "admin:write"
+	ScopeOrganizerRead  = "organizer:read"
+	ScopeOrganizerWrite = "organizer:write"
+)
+
+// APIKeyScopesByRole lists the scopes a key can get for each owner role.
+var APIKeyScopesByRole = map[string][]string{
+	"admin":     {ScopeAdminRead, ScopeAdminWrite},
+	"organizer": {ScopeOrganizerRead, ScopeOrganizerWrite},
+}
+
+// APIKey lets a machine client call the API as UserID. The key itself is
+// only returned in Key when it is created.
+type APIKey struct {
+	ID         int64      `json:"key_id"`
+	UserID     int64      `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix" example:"tk_1a2b3c4d"`
+	Key        string     `json:"key,omitempty"`
+	Scopes     []string   `json:"scopes" example:"organizer:read"`
+	CreatedBy  int64      `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	// OwnerRole is the owner's current role, loaded when the key is used.
+	OwnerRole string `json:"-"`
+}
+
+// Allows reports whether the key may call the routes of area, "admin" or
+// "organizer". A write scope covers reads too.
+func (k *APIKey) Allows(area string, write bool) bool {
+	for _, s := range k.Scopes {
+		if s == area+":write" || (!write && s == area+":read") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// AttendanceReport compares an event's PAID tickets with the ones checked
+// in at the door. Until the post-event report is generated the counts are
+// live and GeneratedAt is nil; after it they are the ones sent to the
+// organizer.
+type AttendanceReport struct {
+	EventID     int64     `json:"event_id"`
+	EventName   string    `json:"event_name"`
+	EventDate   time.Time `json:"event_date"`
+	OrganizerID int64     `json:"-"`
+	TicketsSold int       `json:"tickets_sold" example:"480"`
+	CheckedIn   int       `json:"checked_in" example:"432"`
+	// NoShows are tickets not checked in, counted once the event started.
+	NoShows int `json:"no_shows" example:"48"`
+	// AttendanceRate is the percentage of tickets sold that checked in.
+	AttendanceRate float64    `json:"attendance_rate" example:"90"`
+	GeneratedAt    *time.Time `json:"generated_at,omitempty"`
+}
+
+// Summarize fills in NoShows and AttendanceRate from the counts.
+func (r *AttendanceReport) Summarize(now time.Time) {
+	r.NoShows = 0
+	if !now.Before(r.EventDate) {
+		r.NoShows = r.TicketsSold - r.CheckedIn
+	}
+	r.AttendanceRate = 0
+	if r.TicketsSold > 0 {
+		r.AttendanceRate = math.Round(float64(r.CheckedIn)/float64(r.TicketsSold)*10000) / 100
+	}
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog records a sensitive action. ActorID is nil for system actions
+// performed by the background worker. Before and After hold the changed
+// state for actions that modify an entity; APIKeyID is set when the actor
+// authenticated with an API key.
+type AuditLog struct {
+	ID         int64                  `json:"audit_id"`
+	ActorID    *int64                 `json:"actor_id,omitempty"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   int64                  `json:"entity_id"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	Before     json.RawMessage        `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage        `json:"after,omitempty" swaggertype:"object"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	APIKeyID   *int64                 `json:"api_key_id,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// AuditLogFilter narrows an audit log listing. Zero fields match anything;
+// From is inclusive and To exclusive.
+type AuditLogFilter struct {
+	ActorID    *int64
+	Action     string
+	EntityType string
+	EntityID   *int64
+	From       *time.Time
+	To         *time.Time
+}
+
+// AuditAggregate is the count and summed "amount" detail of matching audit entries.
+type AuditAggregate struct {
+	Count  int     `json:"count"`
+	Amount float64 `json:"amount"`
+}
+
+// AlertRule describes an unusual pattern in the audit stream that should page ops.
+// A rule fires when an entry for Action (whose details contain every Match pair)
+// pushes the number of matching entries within the window to Threshold, or
+// pushes the sum of their "amount" detail past AmountThreshold.
+type AlertRule struct {
+	Name            string            `json:"name"`
+	Action          string            `json:"action"`
+	Match           map[string]string `json:"match,omitempty"`
+	PerActor        bool              `json:"per_actor"`
+	WindowMinutes   int               `json:"window_minutes"`
+	Threshold       int               `json:"threshold,omitempty"`
+	AmountThreshold float64           `json:"amount_threshold,omitempty"`
+}
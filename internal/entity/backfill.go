@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// Backfill statuses. A FAILED backfill resumes from its checkpoint when it
+// is started again; a COMPLETED one starts over.
+const (
+	BackfillPending   = "PENDING"
+	BackfillRunning   = "RUNNING"
+	BackfillCompleted = "COMPLETED"
+	BackfillFailed    = "FAILED"
+)
+
+// Backfill is a data change applied to a large table in primary key ranges
+// of BatchSize, so no batch holds row locks long enough to stall bookings.
+// LastID is the highest key done and MaxID the highest key when the run
+// started; rows added later are written in the new shape already. A
+// backfill that never ran is PENDING.
+type Backfill struct {
+	Name        string `json:"name" example:"seat_layout"`
+	Description string `json:"description"`
+	Table       string `json:"table" example:"seats"`
+	Status      string `json:"status" example:"RUNNING"`
+	BatchSize   int    `json:"batch_size,omitempty" example:"1000"`
+	LastID      int64  `json:"last_id"`
+	MaxID       int64  `json:"max_id"`
+	RowsUpdated int64  `json:"rows_updated"`
+	Batches     int    `json:"batches"`
+	// Progress is Percent, filled in for API responses
+	Progress    float64    `json:"progress" example:"42.5"`
+	LastError   string     `json:"last_error,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// Percent is how far through the key range the backfill is, 0 to 100.
+func (b *Backfill) Percent() float64 {
+	switch {
+	case b.Status == BackfillCompleted:
+		return 100
+	case b.MaxID <= 0 || b.LastID <= 0:
+		return 0
+	case b.LastID >= b.MaxID:
+		return 100
+	}
+	return float64(b.LastID) * 100 / float64(b.MaxID)
+}
+
+const (
+	// DefaultBackfillBatchSize keeps each batch's transaction to a few
+	// milliseconds on the seats table.
+	DefaultBackfillBatchSize = 1000
+	MaxBackfillBatchSize     = 10000
+)
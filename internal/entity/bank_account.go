@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// BankAccount is an organizer's payout destination. The account number is
+// kept encrypted at rest; only the last four digits are ever returned to clients.
+type BankAccount struct {
+	ID                 int64      `json:"bank_account_id"`
+	OrganizerID        int64      `json:"organizer_id"`
+	BankName           string     `json:"bank_name"`
+	AccountHolder      string     `json:"account_holder"`
+	AccountNumber      string     `json:"-"`
+	AccountNumberEnc   string     `json:"-"`
+	AccountLast4       string     `json:"account_last4"`
+	VerificationMethod string     `json:"verification_method"`
+	Status             string     `json:"status"`
+	DepositAmounts     []float64  `json:"-"`
+	VerifyAttempts     int        `json:"-"`
+	IsDefault          bool       `json:"is_default"`
+	VerifiedAt         *time.Time `json:"verified_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
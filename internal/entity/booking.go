@@ -1,15 +1,19 @@
 package entity
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 type Booking struct {
-	ID          int64      `json:"booking_id"`
-	UserID      int64      `json:"user_id"`
-	EventID     int64      `json:"event_id"`
-	Status      string     `json:"status"`
-	TotalAmount float64    `json:"total_amount"`
-	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
+	ID          int64         `json:"booking_id"`
+	UserID      int64         `json:"user_id"`
+	EventID     int64         `json:"event_id"`
+	Status      BookingStatus `json:"status"`
+	TotalAmount float64       `json:"total_amount"`
+	ExpiresAt   *time.Time    `json:"expires_at,omitempty"`
+	VariantID   *int64        `json:"variant_id,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
 }
 
 type Seat struct {
@@ -20,51 +24,290 @@ type Seat struct {
 	Price      float64 `json:"price"`
 	IsBooked   bool    `json:"is_booked"`
 	Version    int     `json:"-"`
+	TierID     *int64  `json:"tier_id,omitempty"`
+
+	// Seat map placement; X and Y are nil until an admin places the seat
+	Row    string   `json:"row,omitempty"`
+	Column int      `json:"column,omitempty"`
+	X      *float64 `json:"x,omitempty"`
+	Y      *float64 `json:"y,omitempty"`
+}
+
+// SeatUpdate announces that seats of an event were booked or released.
+type SeatUpdate struct {
+	EventID  int64   `json:"event_id"`
+	SeatIDs  []int64 `json:"seat_ids"`
+	IsBooked bool    `json:"is_booked"`
 }
 
+// Transaction is a booking's payment. InvoiceNumber is set once the
+// payment completes.
 type Transaction struct {
-	ID              int64     `json:"payment_id"`
-	Amount          float64   `json:"amount"`
-	PaymentMethod   string    `json:"payment_method"`
-	BookingID       int64     `json:"booking_id"`
-	TransactionDate time.Time `json:"transaction_date"`
-	ExternalID      string    `json:"external_id"`
-	Status          string    `json:"status"`
+	ID              int64         `json:"payment_id"`
+	Amount          float64       `json:"amount"`
+	PaymentMethod   string        `json:"payment_method"`
+	BookingID       int64         `json:"booking_id"`
+	TransactionDate time.Time     `json:"transaction_date"`
+	ExternalID      string        `json:"external_id"`
+	Status          PaymentStatus `json:"status"`
+	InvoiceNumber   string        `json:"invoice_number,omitempty"`
 }
 
+// Refund records money returned for a booking, or a customer's request
+// for it. Reason is a RefundReason code; Note holds optional free text.
+// RefundDate is set once the money is returned.
 type Refund struct {
-	ID         int64     `json:"refund_id"`
-	BookingID  int64     `json:"booking_id"`
-	Amount     float64   `json:"amount"`
-	RefundDate time.Time `json:"refund_date"`
-	Reason     string    `json:"reason"`
-	Status     string    `json:"status"`
+	ID          int64      `json:"refund_id"`
+	BookingID   int64      `json:"booking_id"`
+	Amount      float64    `json:"amount"`
+	RefundDate  *time.Time `json:"refund_date,omitempty"`
+	Reason      string     `json:"reason"`
+	Note        string     `json:"note,omitempty"`
+	Status      string     `json:"status"`
+	RequestedAt *time.Time `json:"requested_at,omitempty"`
+	ReviewedBy  *int64     `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote  string     `json:"review_note,omitempty"`
+	// Percent is the share of what was paid that is returned, set by the
+	// event's refund policy when the refund is asked for
+	Percent int `json:"percent" example:"100"`
 }
 
 // BookingWithPayment is the response for booking + payment info
 type BookingWithPayment struct {
-	BookingID   int64        `json:"booking_id"`
-	EventID     int64        `json:"event_id"`
-	Status      string       `json:"status"`
-	TotalAmount float64      `json:"total_amount"`
-	ExpiresAt   *time.Time   `json:"expires_at,omitempty"`
-	Transaction *Transaction `json:"transaction,omitempty"`
+	BookingID   int64         `json:"booking_id"`
+	EventID     int64         `json:"event_id"`
+	Status      BookingStatus `json:"status"`
+	TotalAmount float64       `json:"total_amount"`
+	ExpiresAt   *time.Time    `json:"expires_at,omitempty"`
+	// CustomerEmail is where the booking confirmation was sent
+	CustomerEmail string       `json:"customer_email,omitempty"`
+	Transaction   *Transaction `json:"transaction,omitempty"`
+	Tickets       []Ticket     `json:"tickets,omitempty"`
+	// Warning is set when the booking went through despite a conflict
+	Warning *BookingWarning `json:"warning,omitempty"`
+	// PaymentUnavailable is set when the booking was made while payments
+	// were down. Its deadline is extended and the customer is emailed once
+	// payments work again.
+	PaymentUnavailable bool `json:"payment_unavailable,omitempty"`
+	// Seats are the seats picked for a best available booking
+	Seats []BookedSeat `json:"seats,omitempty"`
 }
 
-// BookingWithDetails includes event and user info for API responses
-type BookingWithDetails struct {
-	ID        int64     `json:"booking_id"`
-	UserID    int64     `json:"user_id"`
-	UserName  string    `json:"user_name"`
-	UserEmail string    `json:"user_email"`
+// SeatPreference narrows the seats a best available booking may be given
+// to a ticket tier or a section. Empty fields match every seat.
+type SeatPreference struct {
+	TierID  *int64
+	Section string
+}
+
+// IsZero reports whether the preference matches every seat.
+func (p SeatPreference) IsZero() bool {
+	return p.TierID == nil && p.Section == ""
+}
+
+// PaymentLink lets a customer pay a PENDING booking without logging in.
+// The link is signed and stops working at ExpiresAt, the booking's
+// payment deadline when the link was made.
+type PaymentLink struct {
+	BookingID int64     `json:"booking_id"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// BookingConflict is a PAID booking of the same user for another event
+// starting close enough to count as overlapping.
+type BookingConflict struct {
+	BookingID int64     `json:"booking_id"`
 	EventID   int64     `json:"event_id"`
 	EventName string    `json:"event_name"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
+	EventDate time.Time `json:"event_date"`
+}
+
+// TicketAllowance is how many tickets to an event a user holds in PAID or
+// PENDING bookings, and the event's own per-user cap if it has one.
+type TicketAllowance struct {
+	Limit *int
+	Held  int
+}
+
+type BookingWarning struct {
+	Message   string            `json:"message"`
+	Conflicts []BookingConflict `json:"conflicts"`
+}
+
+// BookingWithDetails includes event and user info for API responses
+type BookingWithDetails struct {
+	ID        int64         `json:"booking_id"`
+	UserID    int64         `json:"user_id"`
+	UserName  string        `json:"user_name"`
+	UserEmail string        `json:"user_email"`
+	EventID   int64         `json:"event_id"`
+	EventName string        `json:"event_name"`
+	Status    BookingStatus `json:"status"`
+	CreatedAt time.Time     `json:"created_at"`
+	// Seats are the booked seat numbers. Only an event's booking list loads
+	// them, and general admission bookings have none.
+	Seats []string `json:"seats,omitempty"`
+}
+
+// EventBookingSummary totals an event's bookings for the admin booking list.
+// Tickets are booking items, so a general admission booking counts one per
+// ticket. Revenue is what completed payments took and Refunds what completed
+// refunds paid back.
+type EventBookingSummary struct {
+	EventID     int64 `json:"event_id"`
+	Capacity    int   `json:"capacity" example:"500"`
+	TicketsSold int   `json:"tickets_sold" example:"480"`
+	// OccupancyPercent is the share of the capacity sold.
+	OccupancyPercent float64              `json:"occupancy_percent" example:"96"`
+	Revenue          float64              `json:"revenue" example:"24000000"`
+	Refunds          float64              `json:"refunds" example:"500000"`
+	ByStatus         []BookingStatusTotal `json:"by_status"`
+	ByTier           []TierSalesTotal     `json:"by_tier"`
+}
+
+// Summarize fills in OccupancyPercent from the counts.
+func (s *EventBookingSummary) Summarize() {
+	s.OccupancyPercent = 0
+	if s.Capacity > 0 {
+		s.OccupancyPercent = math.Round(float64(s.TicketsSold)/float64(s.Capacity)*10000) / 100
+	}
+}
+
+// BookingStatusTotal counts an event's bookings in one status. Amount is
+// their booked total.
+type BookingStatusTotal struct {
+	Status   BookingStatus `json:"status"`
+	Bookings int           `json:"bookings"`
+	Tickets  int           `json:"tickets"`
+	Amount   float64       `json:"amount"`
+}
+
+// TierSalesTotal counts the PAID tickets of one ticket tier at face value.
+// General admission tickets and seats without a tier have no TierID.
+type TierSalesTotal struct {
+	TierID      *int64  `json:"tier_id"`
+	TierName    string  `json:"tier_name"`
+	TicketsSold int     `json:"tickets_sold"`
+	Revenue     float64 `json:"revenue"`
+}
+
+// BookingExportRow is one booking of an event's attendee export: the
+// customer, every seat (or the general admission ticket count) and the
+// latest payment.
+type BookingExportRow struct {
+	BookingID     int64
+	Status        BookingStatus
+	CreatedAt     time.Time
+	UserName      string
+	UserEmail     string
+	Seats         []string
+	Quantity      int
+	TotalAmount   float64
+	PaymentStatus PaymentStatus
+	PaymentMethod string
+	PaidAt        *time.Time
+}
+
+// BookingDetail is a booking as its owner sees it on the booking page: the
+// event, every seat or general admission ticket, the payment and the latest
+// refund or refund request.
+type BookingDetail struct {
+	ID            int64         `json:"booking_id"`
+	UserID        int64         `json:"user_id"`
+	EventID       int64         `json:"event_id"`
+	EventName     string        `json:"event_name"`
+	EventDate     time.Time     `json:"event_date"`
+	EventLocation string        `json:"event_location"`
+	Status        BookingStatus `json:"status"`
+	TotalAmount   float64       `json:"total_amount"`
+	ExpiresAt     *time.Time    `json:"expires_at,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	Seats         []BookedSeat  `json:"seats"`
+	Transaction   *Transaction  `json:"transaction,omitempty"`
+	Refund        *Refund       `json:"refund,omitempty"`
+}
+
+// BookedSeat is one booking item. General admission tickets have no seat
+// and an empty category. Price is the item's list price with the booking's
+// pricing experiment multiplier applied.
+type BookedSeat struct {
+	SeatID     int64   `json:"seat_id,omitempty"`
+	SeatNumber string  `json:"seat_number,omitempty"`
+	Category   string  `json:"category,omitempty"`
+	Price      float64 `json:"price"`
 }
 
 // EventWithSeats includes seats info for booking page
 type EventWithSeats struct {
 	Event Event  `json:"event"`
 	Seats []Seat `json:"seats"`
+	// Remaining is the unsold capacity of a general admission event
+	Remaining *int `json:"remaining,omitempty"`
+}
+
+// SeatFilter narrows seat listings. An empty Section matches every section.
+type SeatFilter struct {
+	AvailableOnly bool
+	Section       string
+}
+
+// SectionAvailability summarises one section's seats for the zoomed-out
+// seat picker. Seats without a section are grouped under an empty name.
+// Held seats are taken by bookings awaiting payment and may free up again;
+// Sold seats are paid for.
+type SectionAvailability struct {
+	Section   string  `json:"section"`
+	Total     int     `json:"total"`
+	Available int     `json:"available"`
+	Held      int     `json:"held"`
+	Sold      int     `json:"sold"`
+	MinPrice  float64 `json:"min_price"`
+	MaxPrice  float64 `json:"max_price"`
+	// ImageURL is the section's view-from-seat image, if one was uploaded.
+	ImageURL string `json:"image_url,omitempty"`
+	ImageID  int64  `json:"-"`
+}
+
+// EventCapacity splits an event's capacity into sold tickets, holds and
+// tickets still free to book. Holds are soft: they count against capacity
+// until paid or released, and LapsedHolds are past their payment deadline
+// and waiting to be released. Release is set when the event sells in waves.
+type EventCapacity struct {
+	EventID       int64                 `json:"event_id"`
+	AdmissionMode string                `json:"admission_mode"`
+	Capacity      int                   `json:"capacity"`
+	Sold          int                   `json:"sold"`
+	Held          int                   `json:"held"`
+	LapsedHolds   int                   `json:"lapsed_holds"`
+	Available     int                   `json:"available"`
+	Release       *SalesRelease         `json:"release,omitempty"`
+	Sections      []SectionAvailability `json:"sections,omitempty"`
+}
+
+// PublicAvailability is what ticket aggregators see of an event: how many
+// tickets are left and the price range of those still for sale. Prices are
+// nil when nothing is left. For an event selling in waves, Available counts
+// only released tickets and NextReleaseAt is when the next wave starts. AsOf
+// is when the figures were computed; they may be cached for a short while.
+type PublicAvailability struct {
+	EventID       int64       `json:"event_id"`
+	Status        EventStatus `json:"status"`
+	Capacity      int         `json:"capacity"`
+	Available     int         `json:"available"`
+	MinPrice      *float64    `json:"min_price"`
+	MaxPrice      *float64    `json:"max_price"`
+	NextReleaseAt *time.Time  `json:"next_release_at,omitempty"`
+	AsOf          time.Time   `json:"as_of"`
+}
+
+// SeatPage is one keyset page of an event's seats. NextCursor is the seat_id
+// to pass as the cursor for the following page.
+type SeatPage struct {
+	Seats      []Seat `json:"seats"`
+	NextCursor int64  `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	// SectionImages maps the sections on this page to their view-from-seat image URL.
+	SectionImages map[string]string `json:"section_images,omitempty"`
 }
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// BookingModification records a seat change on a paid booking. FromSeatIDs[i]
+// was swapped for ToSeatIDs[i]. AmountCharged is the price difference the user
+// paid; it is zero for a like-for-like move.
+type BookingModification struct {
+	ID             int64     `json:"modification_id"`
+	BookingID      int64     `json:"booking_id"`
+	FromSeatIDs    []int64   `json:"from_seat_ids"`
+	ToSeatIDs      []int64   `json:"to_seat_ids"`
+	PreviousAmount float64   `json:"previous_amount"`
+	NewAmount      float64   `json:"new_amount"`
+	AmountCharged  float64   `json:"amount_charged"`
+	PaymentMethod  string    `json:"payment_method,omitempty"`
+	ExternalID     string    `json:"external_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	// Tickets are the booking's tickets after the change, with new codes for moved seats.
+	Tickets []Ticket `json:"tickets,omitempty"`
+}
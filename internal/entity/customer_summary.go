@@ -0,0 +1,78 @@
+package entity
+
+import "time"
+
+// Customer risk flags raised by CustomerSummary.RiskFlags.
+const (
+	// CustomerFlagFraudRefund: a refund was issued for fraud
+	CustomerFlagFraudRefund = "fraud_refund"
+	// CustomerFlagFrequentRefunds: at least 3 refunds, covering half or
+	// more of the paid bookings
+	CustomerFlagFrequentRefunds = "frequent_refunds"
+	// CustomerFlagFrequentNoShows: at least 3 tickets to past events never
+	// scanned, half or more of them
+	CustomerFlagFrequentNoShows = "frequent_no_shows"
+	// CustomerFlagLapsedHolds: at least 5 holds left to expire, more than
+	// were paid, which is how seats are hoarded
+	CustomerFlagLapsedHolds = "lapsed_holds"
+)
+
+// CustomerBookingCounts counts a customer's bookings by status.
+type CustomerBookingCounts struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Paid      int `json:"paid"`
+	Expired   int `json:"expired"`
+	Cancelled int `json:"cancelled"`
+	Refunded  int `json:"refunded"`
+}
+
+// CustomerSummary is a customer's purchase history in one place for
+// support and fraud review. Tickets count the seats of PAID bookings; a
+// no-show is one of them for a past, not cancelled event that was never
+// checked in.
+type CustomerSummary struct {
+	UserID      int64     `json:"user_id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	MemberSince time.Time `json:"member_since"`
+
+	Bookings        CustomerBookingCounts `json:"bookings"`
+	Tickets         int                   `json:"tickets"`
+	TicketsAttended int                   `json:"tickets_attended"`
+	NoShows         int                   `json:"no_shows"`
+
+	// TotalSpent sums the bookings that were paid, refunded or not;
+	// LifetimeValue is what is left of it after refunds.
+	TotalSpent    float64 `json:"total_spent"`
+	TotalRefunded float64 `json:"total_refunded"`
+	LifetimeValue float64 `json:"lifetime_value"`
+
+	Refunds            int `json:"refunds"`
+	OpenRefundRequests int `json:"open_refund_requests"`
+	FraudRefunds       int `json:"fraud_refunds"`
+
+	FirstBookingAt *time.Time `json:"first_booking_at,omitempty"`
+	LastBookingAt  *time.Time `json:"last_booking_at,omitempty"`
+
+	Flags []string `json:"flags"`
+}
+
+// RiskFlags returns the customer flags the summary raises, never nil.
+func (s *CustomerSummary) RiskFlags() []string {
+	flags := []string{}
+	if s.FraudRefunds > 0 {
+		flags = append(flags, CustomerFlagFraudRefund)
+	}
+	if s.Refunds >= 3 && s.Refunds*2 >= s.Bookings.Paid+s.Bookings.Refunded {
+		flags = append(flags, CustomerFlagFrequentRefunds)
+	}
+	if s.NoShows >= 3 && s.NoShows*2 >= s.NoShows+s.TicketsAttended {
+		flags = append(flags, CustomerFlagFrequentNoShows)
+	}
+	if s.Bookings.Expired >= 5 && s.Bookings.Expired > s.Bookings.Paid {
+		flags = append(flags, CustomerFlagLapsedHolds)
+	}
+	return flags
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Domain events published to the message broker. The part before the dot
+// is the aggregate the event is about; each aggregate has its own topic.
+const (
+	DomainEventBookingCreated   = "booking.created"
+	DomainEventBookingRefunded  = "booking.refunded"
+	DomainEventPaymentCompleted = "payment.completed"
+	DomainEventEventCreated     = "event.created"
+	DomainEventEventUpdated     = "event.updated"
+	DomainEventEventCancelled   = "event.cancelled"
+	DomainEventEventRestored    = "event.restored"
+)
+
+// DomainEvent is the message published for a change. Delivery is at least
+// once, so consumers should deduplicate on ID. AggregateID is the booking or
+// event ID and is used as the message key.
+type DomainEvent struct {
+	ID          string                 `json:"id"`
+	Type        string                 `json:"type"`
+	AggregateID int64                  `json:"aggregate_id"`
+	OccurredAt  time.Time              `json:"occurred_at"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+}
+
+// Aggregate returns what the event is about: "booking", "payment" or
+// "event".
+func (e DomainEvent) Aggregate() string {
+	aggregate, _, _ := strings.Cut(e.Type, ".")
+	return aggregate
+}
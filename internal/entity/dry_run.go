@@ -0,0 +1,66 @@
+package entity
+
+import "time"
+
+// What a destructive admin action would do to a booking.
+const (
+	DryRunRefund = "refund"
+	DryRunCancel = "cancel"
+	DryRunExpire = "expire"
+)
+
+// AffectedBooking is a booking as an admin action would find it. PaidAmount
+// is the total of its completed payments, which is what a refund returns
+// unless RefundAmount says otherwise.
+type AffectedBooking struct {
+	BookingID  int64         `json:"booking_id"`
+	UserID     int64         `json:"user_id"`
+	UserEmail  string        `json:"user_email"`
+	Status     BookingStatus `json:"status"`
+	Tickets    int           `json:"tickets"`
+	PaidAmount float64       `json:"paid_amount"`
+	ExpiresAt  *time.Time    `json:"expires_at,omitempty"`
+	// RefundAmount is set when the event's refund policy returns less than
+	// PaidAmount
+	RefundAmount *float64 `json:"refund_amount,omitempty"`
+	// Action is what the admin action would do to the booking
+	Action string `json:"action"`
+}
+
+// DryRunResult lists the bookings an admin action would change and totals
+// the money and tickets involved. Nothing is changed to compute it, so a
+// booking paid or expired in between can still change the real outcome.
+type DryRunResult struct {
+	DryRun          bool              `json:"dry_run"`
+	Bookings        []AffectedBooking `json:"bookings"`
+	Refunded        int               `json:"refunded"`
+	RefundAmount    float64           `json:"refund_amount"`
+	Cancelled       int               `json:"cancelled"`
+	Expired         int               `json:"expired"`
+	TicketsReleased int               `json:"tickets_released"`
+}
+
+// NewDryRunResult tallies the bookings, whose Action must be set.
+func NewDryRunResult(bookings []AffectedBooking) *DryRunResult {
+	result := &DryRunResult{DryRun: true, Bookings: bookings}
+	if result.Bookings == nil {
+		result.Bookings = []AffectedBooking{}
+	}
+	for _, b := range bookings {
+		switch b.Action {
+		case DryRunRefund:
+			result.Refunded++
+			if b.RefundAmount != nil {
+				result.RefundAmount += *b.RefundAmount
+			} else {
+				result.RefundAmount += b.PaidAmount
+			}
+		case DryRunCancel:
+			result.Cancelled++
+		case DryRunExpire:
+			result.Expired++
+		}
+		result.TicketsReleased += b.Tickets
+	}
+	return result
+}
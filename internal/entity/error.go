@@ -1,14 +1,205 @@
 package entity
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
 
 var (
-	ErrUserAlreadyExsist   = errors.New("user with this email already exisist")
-	ErrInternalServer      = errors.New("internal server error")
-	ErrNotFound            = errors.New("data not found")
-	ErrBookingNotPending   = errors.New("booking is not in PENDING state")
-	ErrBookingExpired      = errors.New("booking has expired")
-	ErrPaymentAlreadyMade  = errors.New("payment has already been completed")
-	ErrInvalidPaymentMethod = errors.New("invalid payment method")
-	ErrUnauthorized        = errors.New("unauthorized access")
-)
\ No newline at end of file
+	ErrUserAlreadyExsist         = errors.New("user with this email already exisist")
+	ErrInternalServer            = errors.New("internal server error")
+	ErrNotFound                  = errors.New("data not found")
+	ErrBookingNotPending         = errors.New("booking is not in PENDING state")
+	ErrBookingExpired            = errors.New("booking has expired")
+	ErrPaymentAlreadyMade        = errors.New("payment has already been completed")
+	ErrInvalidPaymentMethod      = errors.New("invalid payment method")
+	ErrUnauthorized              = errors.New("unauthorized access")
+	ErrInvalidRole               = errors.New("invalid role")
+	ErrTicketWrongEvent          = errors.New("ticket is not valid for this event")
+	ErrTicketNotActive           = errors.New("ticket booking is not paid")
+	ErrTicketAlreadyUsed         = errors.New("ticket has already been used")
+	ErrTicketRevoked             = errors.New("ticket code has been revoked")
+	ErrApplicationExists         = errors.New("an organizer application is already pending or approved")
+	ErrApplicationNotPending     = errors.New("organizer application is not pending")
+	ErrInvalidDocument           = errors.New("invalid document")
+	ErrInvalidVerificationMethod = errors.New("invalid bank account verification method")
+	ErrBankAccountNotPending     = errors.New("bank account is not awaiting verification")
+	ErrBankAccountNotVerified    = errors.New("bank account is not verified")
+	ErrInvalidResetToken         = errors.New("password reset token is invalid or expired")
+	ErrWrongPassword             = errors.New("current password is incorrect")
+	ErrInvalidProfile            = errors.New("invalid profile")
+	ErrUsernameTaken             = errors.New("username is already taken")
+	ErrAccountNotDeletable       = errors.New("organizer and admin accounts can't be deleted")
+	ErrTwoFactorEnabled          = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotSetUp         = errors.New("two-factor authentication has not been set up")
+	ErrInvalidTwoFactorCode      = errors.New("two-factor code is invalid")
+	ErrTooManyTwoFactorAttempts  = errors.New("too many wrong two-factor codes")
+	ErrInvalidLoginChallenge     = errors.New("login challenge is invalid or expired")
+	ErrOAuthProviderUnavailable  = errors.New("sign-in provider is not available")
+	ErrInvalidOAuthState         = errors.New("sign-in state is invalid or expired")
+	ErrInvalidOAuthCode          = errors.New("sign-in code was rejected by the provider")
+	ErrOAuthEmailUnverified      = errors.New("sign-in provider has not verified the email address")
+	ErrOAuthAccountConflict      = errors.New("account is linked to another sign-in")
+	ErrSessionRevoked            = errors.New("session has been revoked or has expired")
+	ErrInvalidAPIKey             = errors.New("api key is invalid, expired or revoked")
+	ErrInvalidAPIKeyRequest      = errors.New("invalid api key request")
+	ErrInsufficientScope         = errors.New("api key lacks the scope for this request")
+	ErrInvalidAuditFilter        = errors.New("invalid audit log filter")
+	ErrInvalidWebhook            = errors.New("invalid webhook")
+	ErrEventHasNoSeries          = errors.New("event is not part of a series")
+	ErrVerificationFailed        = errors.New("bank account verification failed")
+	ErrExperimentActive          = errors.New("event already has an active pricing experiment")
+	ErrExperimentNotActive       = errors.New("pricing experiment is not active")
+	ErrInvalidExperiment         = errors.New("invalid pricing experiment")
+	ErrInvalidSeatNumbering      = errors.New("invalid seat numbering")
+	ErrInvalidSeatLayout         = errors.New("invalid seat layout")
+	ErrSeatUnavailable           = errors.New("seat not available or already booked")
+	ErrBookingNotPaid            = errors.New("booking is not PAID")
+	ErrInvalidSeatChange         = errors.New("invalid seat change")
+	ErrSeatDowngrade             = errors.New("new seats cost less than the current seats")
+	ErrUpgradeOfferClosed        = errors.New("upgrade offer already accepted or expired")
+	ErrInvalidEventContent       = errors.New("invalid event content")
+	ErrInvalidEventMetadata      = errors.New("invalid event metadata")
+	ErrInvalidSectionImage       = errors.New("invalid section image")
+	ErrInvalidEventImage         = errors.New("invalid event image")
+	ErrInvalidEventFilter        = errors.New("invalid event filter")
+	ErrBookingConflict           = errors.New("user already holds a ticket to an overlapping event")
+	ErrTicketLimitExceeded       = errors.New("ticket limit per user exceeded")
+	ErrInvalidTicketLimit        = errors.New("invalid ticket limit")
+	ErrInvalidTicketTier         = errors.New("invalid ticket tier")
+	ErrTierQuotaExceeded         = errors.New("ticket tier quota exceeded")
+	ErrTierNameTaken             = errors.New("ticket tier name already used for this event")
+	ErrInvalidBookingRequest     = errors.New("invalid booking request")
+	ErrNotGeneralAdmission       = errors.New("event is not general admission")
+	ErrSoldOut                   = errors.New("not enough tickets left")
+	ErrNoAdjacentSeats           = errors.New("not enough adjacent seats available")
+	ErrSalesWaveSoldOut          = errors.New("tickets released so far are sold out")
+	ErrInvalidSalesWave          = errors.New("invalid sales wave")
+	ErrInvalidSalesGoal          = errors.New("invalid sales goal")
+	ErrCapacityBelowSold         = errors.New("capacity is below the tickets already sold")
+	ErrInvalidPaymentLink        = errors.New("payment link is invalid")
+	ErrPaymentLinkExpired        = errors.New("payment link has expired")
+	ErrInvalidRefundReason       = errors.New("invalid refund reason")
+	ErrRefundReasonExists        = errors.New("refund reason code already exists")
+	ErrInvalidReportRange        = errors.New("invalid report date range")
+	ErrRefundRequestExists       = errors.New("booking already has an open refund request")
+	ErrRefundNotReviewable       = errors.New("refund request cannot move to that status")
+	ErrInvalidRefundStatus       = errors.New("invalid refund status")
+	ErrInvalidRefundPolicy       = errors.New("invalid refund policy")
+	ErrRefundWindowClosed        = errors.New("the event's refund policy no longer allows a refund")
+	ErrInvalidBookingStatus      = errors.New("invalid booking status")
+	ErrInvalidInvoiceYear        = errors.New("invalid invoice year")
+	ErrInvalidStaffScope         = errors.New("invalid staff scope")
+	ErrNotStaffAccount           = errors.New("user is not a staff account")
+	ErrStaffAccessExists         = errors.New("staff already has this access to the event")
+	ErrEventAccessDenied         = errors.New("no access to this event")
+	ErrInvalidGateKey            = errors.New("gate key is invalid or revoked")
+	ErrInvalidScanBatch          = errors.New("invalid scan batch")
+	ErrGatewayUnavailable        = errors.New("payment gateway unavailable, retry later")
+	ErrPaymentDeclined           = errors.New("payment declined")
+	ErrValidation                = errors.New("validation failed")
+	ErrInvalidCursor             = errors.New("invalid cursor")
+	ErrBackfillRunning           = errors.New("backfill is already running")
+	ErrEventBusy                 = errors.New("event is taking too many booking requests")
+	ErrInvalidBookingRateLimit   = errors.New("invalid booking rate limit")
+	ErrInvalidQueueToken         = errors.New("invalid queue token")
+)
+
+// CapacityBelowBookedError rejects a capacity reduction that would have to
+// remove seats still in use: booked, offered as an upgrade, or part of a past
+// booking whose history is kept. Minimum is the lowest capacity the event
+// can be cut to. It matches ErrCapacityBelowSold.
+type CapacityBelowBookedError struct {
+	Requested int
+	Minimum   int
+}
+
+func (e *CapacityBelowBookedError) Error() string {
+	return fmt.Sprintf("capacity %d is below the %d seats in use", e.Requested, e.Minimum)
+}
+
+func (e *CapacityBelowBookedError) Is(target error) bool {
+	return target == ErrCapacityBelowSold
+}
+
+// EventBusyError rejects a booking request over the event's per-second
+// budget. RetryAfter is how long the client should wait before trying
+// again. It matches ErrEventBusy.
+type EventBusyError struct {
+	EventID    int64
+	RetryAfter time.Duration
+}
+
+func (e *EventBusyError) Error() string {
+	return fmt.Sprintf("event %d is taking too many booking requests, retry in %s", e.EventID, e.RetryAfter)
+}
+
+func (e *EventBusyError) Is(target error) bool {
+	return target == ErrEventBusy
+}
+
+// RetryAfterSeconds is RetryAfter rounded up to whole seconds, at least one,
+// as sent in a Retry-After header.
+func (e *EventBusyError) RetryAfterSeconds() int {
+	secs := int(math.Ceil(e.RetryAfter.Seconds()))
+	if secs < 1 {
+		return 1
+	}
+	return secs
+}
+
+// FieldError is one invalid field in a request, named as it appears in the
+// JSON body. Args fill the %s placeholders in Message; they are kept apart
+// so the message can be translated before it is filled in.
+type FieldError struct {
+	Field   string   `json:"field" example:"date"`
+	Message string   `json:"message" example:"must be in the future"`
+	Args    []string `json:"-"`
+}
+
+// Text is the message with its arguments filled in.
+func (f FieldError) Text() string {
+	if len(f.Args) == 0 {
+		return f.Message
+	}
+	args := make([]interface{}, len(f.Args))
+	for i, arg := range f.Args {
+		args[i] = arg
+	}
+	return fmt.Sprintf(f.Message, args...)
+}
+
+// ValidationError lists every invalid field of a request so clients can
+// show them all at once. It matches ErrValidation.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Field + ": " + f.Text()
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Add records an invalid field.
+func (e *ValidationError) Add(field, message string, args ...string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message, Args: args})
+}
+
+// OrNil returns e, or nil when no field was added, so callers can collect
+// problems and return the result directly.
+func (e *ValidationError) OrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
@@ -1,14 +1,177 @@
 package entity
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 type Event struct{
 	ID		int64	`json:"event_id"`
 	Name	string 	`json:"name"`
 	Location	string	`json:"location"`
+	Series    string    `json:"series,omitempty"`
+	Category  string    `json:"category,omitempty"`
 	Date      time.Time `json:"date"`
 	Capacity  int       `json:"capacity"`
+	OrganizerID *int64  `json:"organizer_id,omitempty"`
+	SeatNumbering *SeatNumbering `json:"seat_numbering,omitempty"`
+	Content   *EventContent `json:"content,omitempty"`
+	// AdmissionMode is "seated" (the default) or "general". General admission
+	// events have no seats; tickets sell at GeneralPrice up to Capacity.
+	AdmissionMode string  `json:"admission_mode"`
+	GeneralPrice  float64 `json:"general_price,omitempty"`
+	// MinPrice and MaxPrice are the lowest and highest ticket price, sold or
+	// not. They are filled in on event lists and the event detail, and are
+	// nil for an event without priced tickets.
+	MinPrice *float64 `json:"min_price,omitempty" example:"150000"`
+	MaxPrice *float64 `json:"max_price,omitempty" example:"750000"`
+	// ImageURL is where clients load the event's poster from.
+	ImageURL  string    `json:"image_url,omitempty"`
+	// Description is a plain-text summary for listings; the detail page body
+	// is in Content.
+	Description   string `json:"description,omitempty" example:"Konser tahunan dengan bintang tamu spesial."`
+	Terms         string `json:"terms,omitempty"`
+	// OrganizerName is the name shown to buyers, which may differ from the
+	// owning organizer account.
+	OrganizerName string `json:"organizer_name,omitempty" example:"Java Festival Production"`
+	// Metadata holds free-form attributes, such as an age rating or dress
+	// code, that the API stores and returns as-is.
+	Metadata map[string]interface{} `json:"metadata,omitempty" swaggertype:"object"`
+	// MaxTicketsPerUser caps the tickets one user may hold; nil uses the
+	// configured default.
+	MaxTicketsPerUser *int `json:"max_tickets_per_user,omitempty"`
+	// BookingRateLimit is how many booking requests per second the event
+	// takes during an on-sale; nil uses the configured default.
+	BookingRateLimit *int `json:"booking_rate_limit,omitempty"`
+	// RefundPolicy is how much of what was paid customers get back when they
+	// ask for a refund; nil refunds in full.
+	RefundPolicy *RefundPolicy `json:"refund_policy,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt is set once an admin deletes the event. Deleted events are
+	// hidden from buyers but kept for their bookings and can be restored.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
+// IsDeleted reports whether the event has been deleted.
+func (e *Event) IsDeleted() bool {
+	return e.DeletedAt != nil
+}
+
+const (
+	AdmissionSeated  = "seated"
+	AdmissionGeneral = "general"
+
+	// MaxGeneralAdmissionQuantity caps the tickets in one general admission booking.
+	MaxGeneralAdmissionQuantity = 10
+)
+
+func (e *Event) IsGeneralAdmission() bool {
+	return e.AdmissionMode == AdmissionGeneral
+}
+
+// SetPriceRange fills in MinPrice and MaxPrice from the general admission
+// price or the prices of seats.
+func (e *Event) SetPriceRange(seats []Seat) {
+	e.MinPrice, e.MaxPrice = nil, nil
+	if e.IsGeneralAdmission() {
+		if e.GeneralPrice > 0 {
+			price := e.GeneralPrice
+			e.MinPrice, e.MaxPrice = &price, &price
+		}
+		return
+	}
+	for i := range seats {
+		price := &seats[i].Price
+		if e.MinPrice == nil || *price < *e.MinPrice {
+			e.MinPrice = price
+		}
+		if e.MaxPrice == nil || *price > *e.MaxPrice {
+			e.MaxPrice = price
+		}
+	}
+}
+
+const (
+	maxMetadataKeys  = 50
+	maxMetadataBytes = 8 << 10
+)
+
+// ValidateMetadata bounds the number of metadata keys and their encoded size.
+func (e *Event) ValidateMetadata() error {
+	if len(e.Metadata) > maxMetadataKeys {
+		return fmt.Errorf("%w: at most %d metadata keys", ErrInvalidEventMetadata, maxMetadataKeys)
+	}
+	raw, err := json.Marshal(e.Metadata)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEventMetadata, err)
+	}
+	if len(raw) > maxMetadataBytes {
+		return fmt.Errorf("%w: metadata is larger than %d bytes", ErrInvalidEventMetadata, maxMetadataBytes)
+	}
+	return nil
+}
+
+// eventTimeLayouts are the accepted forms of event and door times: RFC3339
+// with an offset, and a local date and time without one.
+var eventTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// EventTimeFormats describes eventTimeLayouts for error messages.
+const EventTimeFormats = "RFC3339 (2026-12-31T19:00:00+07:00) or YYYY-MM-DD HH:MM"
+
+// ParseEventTime reads an event or door time. Times are stored in UTC, so
+// one with an offset is converted and one without is taken as UTC.
+func ParseEventTime(s string) (time.Time, error) {
+	for _, layout := range eventTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a valid time, use %s", s, EventTimeFormats)
+}
+
+// EventFilter narrows the event list. Zero fields don't filter. An event
+// matches the price range when any of its tickets is priced within it.
+type EventFilter struct {
+	// Query switches to ranked full-text search over name, location and
+	// description; Search only matches the name.
+	Query    string
+	Search   string
+	Location string
+	Category string
+	Status   EventStatus
+	DateFrom *time.Time
+	DateTo   *time.Time
+	MinPrice *float64
+	MaxPrice *float64
+	// IncludeDeleted lists deleted events too; only admins may set it.
+	IncludeDeleted bool
+}
+
+// maxEventQueryLength bounds the full-text query a visitor can send.
+const maxEventQueryLength = 200
+
+// Validate rejects unknown statuses, overlong queries and empty ranges.
+func (f EventFilter) Validate() error {
+	if f.Status != "" && !f.Status.Valid() {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidEventFilter, f.Status)
+	}
+	if len(f.Query) > maxEventQueryLength {
+		return fmt.Errorf("%w: q is longer than %d characters", ErrInvalidEventFilter, maxEventQueryLength)
+	}
+	if f.DateFrom != nil && f.DateTo != nil && f.DateTo.Before(*f.DateFrom) {
+		return fmt.Errorf("%w: date_to is before date_from", ErrInvalidEventFilter)
+	}
+	if (f.MinPrice != nil && *f.MinPrice < 0) || (f.MaxPrice != nil && *f.MaxPrice < 0) {
+		return fmt.Errorf("%w: prices must not be negative", ErrInvalidEventFilter)
+	}
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MaxPrice < *f.MinPrice {
+		return fmt.Errorf("%w: max_price is below min_price", ErrInvalidEventFilter)
+	}
+	return nil
+}
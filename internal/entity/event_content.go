@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	ContentBlockHeading   = "heading"
+	ContentBlockParagraph = "paragraph"
+	ContentBlockList      = "list"
+	ContentBlockImage     = "image"
+
+	maxFAQEntries      = 50
+	maxProhibitedItems = 50
+	maxContentBlocks   = 30
+	maxListItems       = 50
+	maxShortTextLength = 300
+	maxLongTextLength  = 5000
+)
+
+// EventContent is the structured detail page content of an event, so
+// frontends can render FAQs, door times and rich descriptions without a
+// separate CMS.
+type EventContent struct {
+	DoorsOpenAt     *time.Time     `json:"doors_open_at,omitempty" example:"2026-12-31T18:00:00Z"`
+	FAQ             []FAQEntry     `json:"faq,omitempty"`
+	ProhibitedItems []string       `json:"prohibited_items,omitempty" example:"Kamera profesional,Makanan dari luar"`
+	Blocks          []ContentBlock `json:"blocks,omitempty"`
+}
+
+type FAQEntry struct {
+	Question string `json:"question" example:"Apakah anak-anak boleh masuk?"`
+	Answer   string `json:"answer" example:"Ya, anak di bawah 5 tahun gratis tanpa kursi."`
+}
+
+// ContentBlock is one piece of the event description, rendered in order.
+// Headings and paragraphs use Text, lists use Items and images use
+// ImageURL with Text as the caption.
+type ContentBlock struct {
+	Type     string   `json:"type" example:"paragraph"`
+	Text     string   `json:"text,omitempty" example:"Konser tahunan dengan bintang tamu spesial."`
+	Items    []string `json:"items,omitempty"`
+	ImageURL string   `json:"image_url,omitempty"`
+}
+
+// Validate checks the content against size limits and the event date.
+func (c *EventContent) Validate(eventDate time.Time) error {
+	if c.DoorsOpenAt != nil && c.DoorsOpenAt.After(eventDate) {
+		return fmt.Errorf("%w: doors must open before the event starts", ErrInvalidEventContent)
+	}
+
+	if len(c.FAQ) > maxFAQEntries {
+		return fmt.Errorf("%w: at most %d FAQ entries", ErrInvalidEventContent, maxFAQEntries)
+	}
+	for i, f := range c.FAQ {
+		if strings.TrimSpace(f.Question) == "" || strings.TrimSpace(f.Answer) == "" {
+			return fmt.Errorf("%w: FAQ entry %d needs a question and an answer", ErrInvalidEventContent, i+1)
+		}
+		if len(f.Question) > maxShortTextLength || len(f.Answer) > maxLongTextLength {
+			return fmt.Errorf("%w: FAQ entry %d is too long", ErrInvalidEventContent, i+1)
+		}
+	}
+
+	if err := validateTextItems(c.ProhibitedItems, maxProhibitedItems, "prohibited items"); err != nil {
+		return err
+	}
+
+	if len(c.Blocks) > maxContentBlocks {
+		return fmt.Errorf("%w: at most %d content blocks", ErrInvalidEventContent, maxContentBlocks)
+	}
+	for i, b := range c.Blocks {
+		if err := b.validate(); err != nil {
+			return fmt.Errorf("%w (block %d)", err, i+1)
+		}
+	}
+	return nil
+}
+
+func (b ContentBlock) validate() error {
+	switch b.Type {
+	case ContentBlockHeading:
+		if strings.TrimSpace(b.Text) == "" || len(b.Text) > maxShortTextLength {
+			return fmt.Errorf("%w: heading needs text of at most %d characters", ErrInvalidEventContent, maxShortTextLength)
+		}
+	case ContentBlockParagraph:
+		if strings.TrimSpace(b.Text) == "" || len(b.Text) > maxLongTextLength {
+			return fmt.Errorf("%w: paragraph needs text of at most %d characters", ErrInvalidEventContent, maxLongTextLength)
+		}
+	case ContentBlockList:
+		if len(b.Items) == 0 {
+			return fmt.Errorf("%w: list needs items", ErrInvalidEventContent)
+		}
+		return validateTextItems(b.Items, maxListItems, "list items")
+	case ContentBlockImage:
+		u, err := url.Parse(b.ImageURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("%w: image needs an http(s) image_url", ErrInvalidEventContent)
+		}
+		if len(b.Text) > maxShortTextLength {
+			return fmt.Errorf("%w: image caption is too long", ErrInvalidEventContent)
+		}
+	default:
+		return fmt.Errorf("%w: unknown block type %q", ErrInvalidEventContent, b.Type)
+	}
+	return nil
+}
+
+func validateTextItems(items []string, max int, what string) error {
+	if len(items) > max {
+		return fmt.Errorf("%w: at most %d %s", ErrInvalidEventContent, max, what)
+	}
+	for _, item := range items {
+		if strings.TrimSpace(item) == "" || len(item) > maxShortTextLength {
+			return fmt.Errorf("%w: %s must be non-empty and at most %d characters", ErrInvalidEventContent, what, maxShortTextLength)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Scopes an organizer can grant a staff account on one of their events.
+const (
+	StaffScopeCheckin = "checkin"
+	StaffScopeReports = "reports"
+)
+
+// IsStaffScope reports whether scope is a known staff scope.
+func IsStaffScope(scope string) bool {
+	return scope == StaffScopeCheckin || scope == StaffScopeReports
+}
+
+// EventStaff is a staff account's access to one event, limited to Scope.
+type EventStaff struct {
+	EventID   int64     `json:"event_id"`
+	EventName string    `json:"event_name,omitempty"`
+	UserID    int64     `json:"user_id"`
+	UserName  string    `json:"user_name,omitempty"`
+	UserEmail string    `json:"user_email,omitempty"`
+	Scope     string    `json:"scope"`
+	GrantedBy int64     `json:"granted_by"`
+	GrantedAt time.Time `json:"granted_at"`
+}
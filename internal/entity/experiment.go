@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+// PricingExperiment splits an event's buyers across pricing variants so the
+// revenue impact of a price or fee presentation change can be measured.
+type PricingExperiment struct {
+	ID        int64            `json:"experiment_id"`
+	EventID   int64            `json:"event_id"`
+	Name      string           `json:"name"`
+	Status    string           `json:"status"`
+	CreatedBy int64            `json:"created_by"`
+	Variants  []PricingVariant `json:"variants"`
+	CreatedAt time.Time        `json:"created_at"`
+	EndedAt   *time.Time       `json:"ended_at,omitempty"`
+}
+
+// PricingVariant is one arm of an experiment. Weight is the variant's share of
+// traffic relative to the other variants. FeeDisplay tells clients whether to
+// show prices fee-inclusive or with the fee itemized.
+type PricingVariant struct {
+	ID              int64   `json:"variant_id"`
+	ExperimentID    int64   `json:"experiment_id"`
+	Key             string  `json:"key"`
+	Weight          int     `json:"weight"`
+	PriceMultiplier float64 `json:"price_multiplier"`
+	FeeDisplay      string  `json:"fee_display"`
+}
+
+// VariantResult aggregates exposures, bookings and revenue for one variant.
+type VariantResult struct {
+	VariantID          int64   `json:"variant_id"`
+	Key                string  `json:"key"`
+	PriceMultiplier    float64 `json:"price_multiplier"`
+	FeeDisplay         string  `json:"fee_display"`
+	Exposures          int     `json:"exposures"`
+	Bookings           int     `json:"bookings"`
+	PaidBookings       int     `json:"paid_bookings"`
+	Revenue            float64 `json:"revenue"`
+	ConversionRate     float64 `json:"conversion_rate"`
+	RevenuePerExposure float64 `json:"revenue_per_exposure"`
+}
+
+type ExperimentResults struct {
+	Experiment PricingExperiment `json:"experiment"`
+	Variants   []VariantResult   `json:"variants"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// MaxScanBatch caps the codes a turnstile can submit in one request.
+const MaxScanBatch = 500
+
+// Verdicts a turnstile gets for each scanned code.
+const (
+	ScanAdmitted    = "admitted"
+	ScanAlreadyUsed = "already_used"
+	ScanWrongEvent  = "wrong_event"
+	ScanNotPaid     = "not_paid"
+	ScanRevoked     = "revoked"
+	ScanUnknown     = "unknown"
+)
+
+// Gate is a turnstile at one event. It authenticates with its own API key,
+// which is only returned in Key when the gate is created.
+type Gate struct {
+	ID         int64      `json:"gate_id"`
+	EventID    int64      `json:"event_id"`
+	Name       string     `json:"name"`
+	Key        string     `json:"key,omitempty"`
+	CreatedBy  int64      `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// ScannedCode is what a batch check-in found for one distinct code: the
+// ticket, if the code belongs to one, whether this batch admitted it, and
+// whether the code was revoked by a regeneration.
+type ScannedCode struct {
+	Code     string
+	Ticket   *Ticket
+	Admitted bool
+	Revoked  bool
+}
+
+// ScanVerdict is the answer for one code of a batch, in the order scanned.
+type ScanVerdict struct {
+	Code        string     `json:"code"`
+	Verdict     string     `json:"verdict" example:"admitted"`
+	TicketID    int64      `json:"ticket_id,omitempty"`
+	SeatNumber  string     `json:"seat_number,omitempty"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+}
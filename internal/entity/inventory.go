@@ -0,0 +1,80 @@
+package entity
+
+import "fmt"
+
+// InventorySnapshot is one event's seat accounting at a point in time.
+// Active bookings are PENDING or PAID; both hold their seats.
+type InventorySnapshot struct {
+	EventID       int64
+	EventName     string
+	AdmissionMode string
+	Capacity      int
+
+	// BookedSeats counts seats flagged is_booked. HeldItems counts the seat
+	// items of active bookings and HeldSeats the distinct seats among them;
+	// UnflaggedSeats are held seats that are not flagged booked.
+	BookedSeats    int
+	HeldItems      int
+	HeldSeats      int
+	UnflaggedSeats int
+
+	// General admission tickets in active bookings and left on the counter
+	GeneralSold      int
+	GeneralRemaining int
+}
+
+// InventoryViolation is a broken seat accounting invariant. Oversell
+// violations mean a seat or ticket was, or can be, sold twice; the others
+// are leaks that undersell the event.
+type InventoryViolation struct {
+	EventID   int64  `json:"event_id"`
+	EventName string `json:"event_name"`
+	Check     string `json:"check"`
+	Detail    string `json:"detail"`
+	Oversell  bool   `json:"oversell"`
+}
+
+// Violations checks the invariants: every held seat is flagged booked and
+// held by one booking, every booked seat is held, and nothing exceeds capacity.
+func (s InventorySnapshot) Violations() []InventoryViolation {
+	var out []InventoryViolation
+	add := func(check string, oversell bool, format string, args ...interface{}) {
+		out = append(out, InventoryViolation{
+			EventID:   s.EventID,
+			EventName: s.EventName,
+			Check:     check,
+			Detail:    fmt.Sprintf(format, args...),
+			Oversell:  oversell,
+		})
+	}
+
+	if s.AdmissionMode == AdmissionGeneral {
+		if s.GeneralSold > s.Capacity {
+			add("ga_sold_over_capacity", true, "%d tickets sold for capacity %d", s.GeneralSold, s.Capacity)
+		}
+		switch total := s.GeneralSold + s.GeneralRemaining; {
+		case total > s.Capacity:
+			add("ga_counter_over_capacity", true, "%d sold + %d remaining exceeds capacity %d", s.GeneralSold, s.GeneralRemaining, s.Capacity)
+		case total < s.Capacity:
+			add("ga_counter_leak", false, "%d sold + %d remaining is below capacity %d", s.GeneralSold, s.GeneralRemaining, s.Capacity)
+		}
+		return out
+	}
+
+	if s.HeldItems > s.HeldSeats {
+		add("seat_held_twice", true, "%d seat items in active bookings cover only %d distinct seats", s.HeldItems, s.HeldSeats)
+	}
+	if s.UnflaggedSeats > 0 {
+		add("held_seat_not_booked", true, "%d seats in active bookings are not flagged booked", s.UnflaggedSeats)
+	}
+	if s.HeldSeats > s.Capacity {
+		add("held_over_capacity", true, "%d seats held for capacity %d", s.HeldSeats, s.Capacity)
+	}
+	if s.BookedSeats > s.Capacity {
+		add("booked_over_capacity", true, "%d seats booked for capacity %d", s.BookedSeats, s.Capacity)
+	}
+	if leaked := s.BookedSeats - (s.HeldSeats - s.UnflaggedSeats); leaked > 0 {
+		add("booked_seat_not_held", false, "%d booked seats are not in an active booking", leaked)
+	}
+	return out
+}
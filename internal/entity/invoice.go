@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// PlatformIssuerID issues the invoices of events without an organizer,
+// which the platform sells itself.
+const PlatformIssuerID int64 = 0
+
+// Invoice is the legal invoice issued when a payment completes. Numbers run
+// per issuer (the event's organizer, or the platform) and calendar year,
+// starting at 1 and without gaps.
+type Invoice struct {
+	ID        int64     `json:"invoice_id"`
+	Number    string    `json:"invoice_number"`
+	IssuerID  int64     `json:"issuer_id"`
+	Year      int       `json:"year"`
+	Sequence  int       `json:"sequence"`
+	PaymentID int64     `json:"payment_id"`
+	BookingID int64     `json:"booking_id"`
+	EventID   int64     `json:"event_id"`
+	Amount    float64   `json:"amount"`
+	IssuedAt  time.Time `json:"issued_at"`
+}
+
+// InvoiceGap is a number missing from an issuer's series, which would have
+// to be explained to the tax office.
+type InvoiceGap struct {
+	IssuerID int64 `json:"issuer_id"`
+	Year     int   `json:"year"`
+	Sequence int   `json:"sequence"`
+}
+
+// FormatInvoiceNumber renders an invoice number, e.g. INV/2026/000042 for
+// the platform or INV/ORG12/2026/000042 for organizer 12.
+func FormatInvoiceNumber(issuerID int64, year, sequence int) string {
+	if issuerID == PlatformIssuerID {
+		return fmt.Sprintf("INV/%d/%06d", year, sequence)
+	}
+	return fmt.Sprintf("INV/ORG%d/%d/%06d", issuerID, year, sequence)
+}
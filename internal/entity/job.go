@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Job is a unit of background work persisted in the job queue.
+type Job struct {
+	ID          int64           `json:"job_id"`
+	Type        string          `json:"job_type"`
+	Lane        string          `json:"lane" example:"transactional"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedAt    *time.Time      `json:"locked_at,omitempty"`
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// Job lanes, highest priority first. Each lane has its own workers, so a
+// lower lane can fall behind without holding up a higher one.
+const (
+	// JobLaneTransactional is email a user is waiting for, such as receipts
+	// and password resets, and refunds.
+	JobLaneTransactional = "transactional"
+	// JobLaneReminder is time-sensitive but not awaited, such as upgrade offers.
+	JobLaneReminder = "reminder"
+	// JobLaneBulk is long-running background work, such as backfills and
+	// data exports.
+	JobLaneBulk = "bulk"
+)
+
+// JobQueueStats is the job backlog together with the limits the worker
+// holds the outbox to. Backlogged is set while the outbox is over a limit.
+// Overflowed counts the jobs queued while it was since the process started,
+// Dropped the ones of those that were discarded.
+type JobQueueStats struct {
+	JobBacklog
+	OutboxLimit         int    `json:"outbox_limit"`
+	OutboxMaxAgeSeconds int64  `json:"outbox_max_age_seconds"`
+	Overflow            string `json:"overflow" example:"persist"`
+	Backlogged          bool   `json:"backlogged"`
+	Overflowed          int64  `json:"overflowed"`
+	Dropped             int64  `json:"dropped"`
+}
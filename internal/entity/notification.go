@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// Notification is an entry in a user's in-app inbox. Message is stored
+// untranslated with MessageArgs and translated when it is read.
+type Notification struct {
+	ID          int64      `json:"notification_id"`
+	UserID      int64      `json:"-"`
+	Kind        string     `json:"kind" example:"payment_receipt"`
+	Message     string     `json:"message" example:"Payment received for booking #42"`
+	MessageArgs []string   `json:"-"`
+	BookingID   *int64     `json:"booking_id,omitempty"`
+	JobID       *int64     `json:"-"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+}
+
+// NotificationPage is a page of a user's inbox with their unread count.
+type NotificationPage struct {
+	Notifications []Notification `json:"notifications"`
+	Total         int            `json:"total"`
+	Unread        int            `json:"unread"`
+}
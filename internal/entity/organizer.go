@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// OrganizerApplication is a user's request to become an event organizer.
+// Status moves from PENDING to APPROVED or REJECTED after admin review. The
+// bank account number is kept encrypted at rest like a BankAccount's; only
+// the last four digits are returned to clients.
+type OrganizerApplication struct {
+	ID                   int64               `json:"application_id"`
+	UserID               int64               `json:"user_id"`
+	UserName             string              `json:"user_name,omitempty"`
+	UserEmail            string              `json:"user_email,omitempty"`
+	BusinessName         string              `json:"business_name"`
+	BusinessType         string              `json:"business_type"`
+	BusinessAddress      string              `json:"business_address"`
+	TaxID                string              `json:"tax_id"`
+	Phone                string              `json:"phone"`
+	BankName             string              `json:"bank_name"`
+	BankAccountName      string              `json:"bank_account_name"`
+	BankAccountNumber    string              `json:"-"`
+	BankAccountNumberEnc string              `json:"-"`
+	BankAccountLast4     string              `json:"bank_account_last4"`
+	Status               string              `json:"status"`
+	ReviewNote           string              `json:"review_note,omitempty"`
+	ReviewedBy           *int64              `json:"reviewed_by,omitempty"`
+	ReviewedAt           *time.Time          `json:"reviewed_at,omitempty"`
+	Documents            []OrganizerDocument `json:"documents,omitempty"`
+	CreatedAt            time.Time           `json:"created_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+}
+
+// OrganizerDocument is a supporting file (ID card, business license, ...) attached to an application.
+type OrganizerDocument struct {
+	ID            int64     `json:"document_id"`
+	ApplicationID int64     `json:"application_id"`
+	DocType       string    `json:"doc_type"`
+	FileName      string    `json:"file_name"`
+	StorageKey    string    `json:"-"`
+	ContentType   string    `json:"content_type"`
+	SizeBytes     int64     `json:"size_bytes"`
+	UploadedAt    time.Time `json:"uploaded_at"`
+}
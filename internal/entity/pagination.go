@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PageCursor marks the last row of a keyset page: its sort keys and ID.
+// Clients get it as an opaque string and send it back to continue after
+// that row, so rows written meanwhile don't shift the following pages the
+// way they shift OFFSET pages. Sort names the order the cursor was made
+// for; a cursor is only valid for that order.
+type PageCursor struct {
+	Sort      string    `json:"s"`
+	CreatedAt time.Time `json:"c"`
+	Status    string    `json:"st,omitempty"`
+	Rank      float32   `json:"r,omitempty"`
+	ID        int64     `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c PageCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor reads a cursor made by Encode. An empty string is the start
+// of the list and decodes to nil.
+func DecodeCursor(s string) (*PageCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c PageCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.Sort == "" || c.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// CheckSort rejects a cursor made for another order than sort.
+func (c *PageCursor) CheckSort(sort string) error {
+	if c != nil && c.Sort != sort {
+		return fmt.Errorf("%w: it was made for sort %q", ErrInvalidCursor, c.Sort)
+	}
+	return nil
+}
+
+// EventPage is one keyset page of the event list. NextCursor is empty on
+// the last page.
+type EventPage struct {
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	HasMore    bool    `json:"has_more"`
+}
+
+// BookingPage is one keyset page of the admin booking list. NextCursor is
+// empty on the last page.
+type BookingPage struct {
+	Bookings   []BookingWithDetails `json:"bookings"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+	HasMore    bool                 `json:"has_more"`
+}
@@ -0,0 +1,82 @@
+package entity
+
+import "math"
+
+// Prices are DECIMAL(10,2) and pricing variant multipliers DECIMAL(6,4) in
+// the database. The pricing math below works on whole cents and multipliers
+// in ten-thousandths, so totals are exact and don't depend on the order the
+// prices are added in.
+
+// toCents converts an amount to whole cents.
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+// scaleCents applies a multiplier to cents, rounding half away from zero to
+// a whole cent. A zero multiplier means no variant and leaves cents as is.
+func scaleCents(cents int64, multiplier float64) int64 {
+	if multiplier == 0 {
+		return cents
+	}
+	m := int64(math.Round(multiplier * 10000))
+	scaled := cents * m
+	if scaled < 0 {
+		return -((-scaled + 5000) / 10000)
+	}
+	return (scaled + 5000) / 10000
+}
+
+// AddAmounts adds money amounts in cents.
+func AddAmounts(amounts ...float64) float64 {
+	var cents int64
+	for _, a := range amounts {
+		cents += toCents(a)
+	}
+	return fromCents(cents)
+}
+
+// PriceQuote is what a set of tickets costs. Subtotal is the list price,
+// Adjustment what the pricing variant changes it by (negative for a
+// discount) and Total what the buyer pays.
+type PriceQuote struct {
+	Subtotal   float64 `json:"subtotal"`
+	Adjustment float64 `json:"adjustment"`
+	Total      float64 `json:"total"`
+}
+
+// QuoteTickets prices tickets at the given list prices, each its tier
+// price or the seat's own, under a pricing variant multiplier; zero means
+// no variant. The multiplier applies to the subtotal, so the total is
+// rounded once.
+func QuoteTickets(prices []float64, multiplier float64) PriceQuote {
+	var subtotal int64
+	for _, p := range prices {
+		subtotal += toCents(p)
+	}
+	total := scaleCents(subtotal, multiplier)
+	return PriceQuote{
+		Subtotal:   fromCents(subtotal),
+		Adjustment: fromCents(total - subtotal),
+		Total:      fromCents(total),
+	}
+}
+
+// QuoteGeneralAdmission prices quantity general admission tickets.
+func QuoteGeneralAdmission(price float64, quantity int, multiplier float64) PriceQuote {
+	prices := make([]float64, quantity)
+	for i := range prices {
+		prices[i] = price
+	}
+	return QuoteTickets(prices, multiplier)
+}
+
+// SeatChangeCharge is what moving a booking from seats listed at fromTotal
+// to seats listed at toTotal costs, under the variant multiplier it was
+// bought with. A negative charge is a downgrade.
+func SeatChangeCharge(fromTotal, toTotal, multiplier float64) float64 {
+	return fromCents(scaleCents(toCents(toTotal)-toCents(fromTotal), multiplier))
+}
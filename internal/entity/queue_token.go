@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	// MaxQueueTokenTTL bounds how long an admission from the waiting room
+	// lasts, so a leaked token stops skipping the booking budget soon.
+	MaxQueueTokenTTL = time.Hour
+	// DefaultQueueTokenTTL is used when no TTL is given.
+	DefaultQueueTokenTTL = 15 * time.Minute
+	// MaxQueueTokensPerRequest caps one batch of admissions.
+	MaxQueueTokensPerRequest = 1000
+)
+
+// QueueToken admits a user let through the waiting room of an on-sale. It
+// is bound to the user and the event, and while it is valid the user's
+// booking requests for that event skip the event's per-second budget.
+type QueueToken struct {
+	UserID    int64     `json:"user_id" example:"42"`
+	Token     string    `json:"token" example:"7.42.1767225600.gSg2bX0Yq1l0d3c5lHk9p9x4wXJ6c2f1mN8aVtQ0eRk"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
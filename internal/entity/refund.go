@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Built-in refund reasons. Admins can add more and deactivate any of them.
+const (
+	RefundReasonEventCancelled  = "event_cancelled"
+	RefundReasonCustomerRequest = "customer_request"
+	RefundReasonDuplicateCharge = "duplicate_charge"
+	RefundReasonFraud           = "fraud"
+)
+
+// Refund statuses. A customer request starts REQUESTED and is APPROVED or
+// REJECTED by an admin; approved requests are COMPLETED once the worker
+// returns the money. Refunds issued by admins or on event cancellation are
+// recorded COMPLETED directly.
+const (
+	RefundRequested = "REQUESTED"
+	RefundApproved  = "APPROVED"
+	RefundRejected  = "REJECTED"
+	RefundCompleted = "COMPLETED"
+)
+
+var refundTransitions = map[string][]string{
+	RefundRequested: {RefundApproved, RefundRejected},
+	RefundApproved:  {RefundCompleted, RefundRejected},
+}
+
+// CanRefundTransition reports whether a refund in status from may move to to.
+func CanRefundTransition(from, to string) bool {
+	for _, next := range refundTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRefundStatus reports whether status is one of the refund statuses.
+func IsRefundStatus(status string) bool {
+	switch status {
+	case RefundRequested, RefundApproved, RefundRejected, RefundCompleted:
+		return true
+	}
+	return false
+}
+
+const (
+	maxRefundReasonLabelLength = 100
+	maxRefundNoteLength        = 255
+)
+
+var refundReasonCode = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// RefundReason is one entry of the refund reason taxonomy. Inactive reasons
+// can no longer be used for new refunds but still appear in reports.
+type RefundReason struct {
+	Code      string    `json:"code" example:"customer_request"`
+	Label     string    `json:"label" example:"Customer request"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (r *RefundReason) Validate() error {
+	r.Label = strings.TrimSpace(r.Label)
+	if !refundReasonCode.MatchString(r.Code) {
+		return fmt.Errorf("%w: code must be 2-50 lowercase letters, digits or underscores", ErrInvalidRefundReason)
+	}
+	if r.Label == "" || len(r.Label) > maxRefundReasonLabelLength {
+		return fmt.Errorf("%w: label is required and at most %d characters", ErrInvalidRefundReason, maxRefundReasonLabelLength)
+	}
+	return nil
+}
+
+// ValidateRefundNote trims the free-text note given with a refund.
+func ValidateRefundNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if len(note) > maxRefundNoteLength {
+		return "", fmt.Errorf("%w: note must be at most %d characters", ErrInvalidRefundReason, maxRefundNoteLength)
+	}
+	return note, nil
+}
+
+// RefundReasonSummary totals the refunds issued for one reason.
+type RefundReasonSummary struct {
+	Reason string  `json:"reason"`
+	Label  string  `json:"label"`
+	Count  int     `json:"count"`
+	Amount float64 `json:"amount"`
+}
+
+// RefundReport aggregates the refunds issued in [From, To) by reason.
+// Reports are served from cache, so GeneratedAt tells how fresh it is.
+type RefundReport struct {
+	From        time.Time             `json:"from"`
+	To          time.Time             `json:"to"`
+	TotalCount  int                   `json:"total_count"`
+	TotalAmount float64               `json:"total_amount"`
+	ByReason    []RefundReasonSummary `json:"by_reason"`
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
+// RefundRequestDetails is a refund request with the booking, customer and
+// event an admin needs to review it.
+type RefundRequestDetails struct {
+	Refund
+	UserID    int64  `json:"user_id"`
+	UserName  string `json:"user_name"`
+	UserEmail string `json:"user_email"`
+	EventID   int64  `json:"event_id"`
+	EventName string `json:"event_name"`
+}
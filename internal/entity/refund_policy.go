@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxRefundPolicyDays caps how far before an event a refund tier can reach.
+const MaxRefundPolicyDays = 365
+
+// RefundPolicy is an organizer's rule for the refunds customers ask for. A
+// refund asked for at least FullRefundDays before the event returns all that
+// was paid; one asked for at least PartialRefundDays before returns
+// PartialRefundPercent of it, and later ones nothing. A zero
+// PartialRefundPercent leaves out the partial tier. Events without a policy
+// refund in full.
+type RefundPolicy struct {
+	FullRefundDays       int `json:"full_refund_days" example:"14"`
+	PartialRefundDays    int `json:"partial_refund_days" example:"3"`
+	PartialRefundPercent int `json:"partial_refund_percent" example:"50"`
+}
+
+func (p *RefundPolicy) Validate() error {
+	if p.FullRefundDays < 0 || p.FullRefundDays > MaxRefundPolicyDays {
+		return fmt.Errorf("%w: full_refund_days must be between 0 and %d", ErrInvalidRefundPolicy, MaxRefundPolicyDays)
+	}
+	if p.PartialRefundPercent < 0 || p.PartialRefundPercent > 99 {
+		return fmt.Errorf("%w: partial_refund_percent must be between 0 and 99", ErrInvalidRefundPolicy)
+	}
+	if p.PartialRefundDays < 0 || p.PartialRefundDays > p.FullRefundDays {
+		return fmt.Errorf("%w: partial_refund_days must be between 0 and full_refund_days", ErrInvalidRefundPolicy)
+	}
+	if p.PartialRefundPercent == 0 && p.PartialRefundDays != 0 {
+		return fmt.Errorf("%w: partial_refund_days needs a partial_refund_percent", ErrInvalidRefundPolicy)
+	}
+	return nil
+}
+
+// RefundPercent returns the share of what was paid, in percent, that a
+// refund asked for at at returns for an event starting at eventDate. A nil
+// policy always returns 100.
+func (p *RefundPolicy) RefundPercent(eventDate, at time.Time) int {
+	if p == nil {
+		return 100
+	}
+	left := eventDate.Sub(at)
+	switch {
+	case left < 0:
+		return 0
+	case left >= policyDays(p.FullRefundDays):
+		return 100
+	case p.PartialRefundPercent > 0 && left >= policyDays(p.PartialRefundDays):
+		return p.PartialRefundPercent
+	}
+	return 0
+}
+
+func policyDays(n int) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
+
+// RefundShare returns percent of a paid amount, rounded to a whole cent.
+func RefundShare(paid float64, percent int) float64 {
+	switch {
+	case percent <= 0:
+		return 0
+	case percent >= 100:
+		return paid
+	}
+	return fromCents(scaleCents(toCents(paid), float64(percent)/100))
+}
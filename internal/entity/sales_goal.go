@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	maxSalesGoalThresholds = 10
+	// MaxSalesGoalStallHours bounds how long sales may stall before the
+	// organizer is told.
+	MaxSalesGoalStallHours = 30 * 24
+)
+
+// DefaultSalesGoalThresholds are the progress alerts of a goal set without
+// any: halfway and the goal itself.
+var DefaultSalesGoalThresholds = []int{50, 100}
+
+// SalesGoal is an organizer's ticket sales target for an event and the
+// alerts it sends. Thresholds are percentages of TargetTickets, each
+// reported once when sales pass it. NotifySoldOut also reports the event
+// selling out, and a StallAfterHours above zero reports no ticket selling
+// for that long.
+type SalesGoal struct {
+	EventID         int64 `json:"event_id"`
+	TargetTickets   int   `json:"target_tickets" example:"800"`
+	Thresholds      []int `json:"thresholds" example:"25,50,100"`
+	NotifySoldOut   bool  `json:"notify_sold_out"`
+	StallAfterHours int   `json:"stall_after_hours" example:"48"`
+
+	// ReachedThresholds, SoldOutAlertedAt and StallAlertedAt record the
+	// alerts already sent, so each goes out once.
+	ReachedThresholds []int      `json:"reached_thresholds"`
+	SoldOutAlertedAt  *time.Time `json:"sold_out_alerted_at,omitempty"`
+	StallAlertedAt    *time.Time `json:"stall_alerted_at,omitempty"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// Validate checks the goal's settings and sorts its thresholds, filling in
+// DefaultSalesGoalThresholds when there are none.
+func (g *SalesGoal) Validate() error {
+	if g.TargetTickets < 1 {
+		return fmt.Errorf("%w: target_tickets must be at least 1", ErrInvalidSalesGoal)
+	}
+	if g.StallAfterHours < 0 || g.StallAfterHours > MaxSalesGoalStallHours {
+		return fmt.Errorf("%w: stall_after_hours must be between 0 and %d", ErrInvalidSalesGoal, MaxSalesGoalStallHours)
+	}
+	if len(g.Thresholds) == 0 {
+		g.Thresholds = append([]int(nil), DefaultSalesGoalThresholds...)
+	}
+	if len(g.Thresholds) > maxSalesGoalThresholds {
+		return fmt.Errorf("%w: at most %d thresholds", ErrInvalidSalesGoal, maxSalesGoalThresholds)
+	}
+	sort.Ints(g.Thresholds)
+	for i, t := range g.Thresholds {
+		if t < 1 || t > 100 {
+			return fmt.Errorf("%w: thresholds are percentages from 1 to 100", ErrInvalidSalesGoal)
+		}
+		if i > 0 && t == g.Thresholds[i-1] {
+			return fmt.Errorf("%w: threshold %d is given twice", ErrInvalidSalesGoal, t)
+		}
+	}
+	return nil
+}
+
+// SalesGoalProgress is a goal with the event's PAID tickets counted
+// against it.
+type SalesGoalProgress struct {
+	SalesGoal
+	EventName   string     `json:"event_name"`
+	OrganizerID int64      `json:"-"`
+	Capacity    int        `json:"capacity"`
+	Sold        int        `json:"sold"`
+	LastSaleAt  *time.Time `json:"last_sale_at,omitempty"`
+}
+
+// Percent is how much of the target has sold, in whole percent.
+func (p SalesGoalProgress) Percent() int {
+	return p.Sold * 100 / p.TargetTickets
+}
+
+// SalesGoalAlerts are the alerts a goal is due to send.
+type SalesGoalAlerts struct {
+	// Reached holds the thresholds passed since the last check
+	Reached []int
+	SoldOut bool
+	Stalled bool
+}
+
+// Any reports whether there is an alert to send.
+func (a SalesGoalAlerts) Any() bool {
+	return len(a.Reached) > 0 || a.SoldOut || a.Stalled
+}
+
+// DueAlerts works out which alerts the goal has not sent yet. Sales stall
+// when nothing has sold for StallAfterHours since the last sale, or since
+// the goal was set if nothing has sold since; a stall is reported once
+// until sales pick up again. A sold out event does not stall.
+func (p SalesGoalProgress) DueAlerts(now time.Time) SalesGoalAlerts {
+	var a SalesGoalAlerts
+
+	reached := make(map[int]bool, len(p.ReachedThresholds))
+	for _, t := range p.ReachedThresholds {
+		reached[t] = true
+	}
+	for _, t := range p.Thresholds {
+		if !reached[t] && p.Sold*100 >= t*p.TargetTickets {
+			a.Reached = append(a.Reached, t)
+		}
+	}
+
+	soldOut := p.Sold >= p.Capacity
+	if soldOut && p.NotifySoldOut && p.SoldOutAlertedAt == nil {
+		a.SoldOut = true
+	}
+
+	if p.StallAfterHours > 0 && !soldOut {
+		since := p.UpdatedAt
+		if p.LastSaleAt != nil && p.LastSaleAt.After(since) {
+			since = *p.LastSaleAt
+		}
+		stalled := now.Sub(since) >= time.Duration(p.StallAfterHours)*time.Hour
+		if stalled && (p.StallAlertedAt == nil || p.StallAlertedAt.Before(since)) {
+			a.Stalled = true
+		}
+	}
+	return a
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxSalesWaves bounds how many waves an event's release schedule may have.
+const MaxSalesWaves = 50
+
+// SalesWave puts Quantity more of an event's tickets on sale at StartsAt.
+type SalesWave struct {
+	ID       int64     `json:"wave_id"`
+	EventID  int64     `json:"event_id"`
+	StartsAt time.Time `json:"starts_at" example:"2026-03-01T10:00:00Z"`
+	Quantity int       `json:"quantity" example:"1000"`
+}
+
+// ValidateSalesWaves checks a release schedule and sorts it by start time.
+// An empty schedule is valid: the event then sells without waves.
+func ValidateSalesWaves(waves []SalesWave) error {
+	if len(waves) > MaxSalesWaves {
+		return fmt.Errorf("%w: at most %d waves", ErrInvalidSalesWave, MaxSalesWaves)
+	}
+	sort.Slice(waves, func(i, j int) bool { return waves[i].StartsAt.Before(waves[j].StartsAt) })
+	for i, w := range waves {
+		if w.StartsAt.IsZero() {
+			return fmt.Errorf("%w: starts_at is required", ErrInvalidSalesWave)
+		}
+		if w.Quantity < 1 {
+			return fmt.Errorf("%w: quantity must be at least 1", ErrInvalidSalesWave)
+		}
+		if i > 0 && w.StartsAt.Equal(waves[i-1].StartsAt) {
+			return fmt.Errorf("%w: two waves start at %s", ErrInvalidSalesWave, w.StartsAt.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// SalesRelease is how far an event's release schedule has got. Taken counts
+// tickets in PAID and PENDING bookings against the released total, so held
+// tickets use up a wave until they are paid or released again.
+type SalesRelease struct {
+	Released      int        `json:"released"`
+	Taken         int        `json:"taken"`
+	NextReleaseAt *time.Time `json:"next_release_at,omitempty"`
+}
+
+// Remaining is how many released tickets can still be booked.
+func (r SalesRelease) Remaining() int {
+	if r.Taken >= r.Released {
+		return 0
+	}
+	return r.Released - r.Taken
+}
+
+// SalesWaveSchedule is an event's waves with the current release. Release is
+// nil when the event has no waves.
+type SalesWaveSchedule struct {
+	EventID int64         `json:"event_id"`
+	Waves   []SalesWave   `json:"waves"`
+	Release *SalesRelease `json:"release,omitempty"`
+}
@@ -0,0 +1,57 @@
+package entity
+
+import "fmt"
+
+const (
+	maxSeatRowLength = 10
+	maxSeatPlacement = 1_000_000
+)
+
+// SeatLayout places existing seats of an event on the seat map. Seats are
+// matched by seat number; seats left out keep their current placement.
+type SeatLayout struct {
+	Seats []SeatPlacement `json:"seats"`
+}
+
+// SeatPlacement positions one seat. Section replaces the seat's category
+// when set; X and Y are map coordinates in whatever unit the frontend
+// renders, and must be given together.
+type SeatPlacement struct {
+	SeatNumber string   `json:"seat_number" example:"VIP-A1"`
+	Section    string   `json:"section,omitempty" example:"VIP"`
+	Row        string   `json:"row,omitempty" example:"A"`
+	Column     int      `json:"column,omitempty" example:"1"`
+	X          *float64 `json:"x,omitempty" example:"120.5"`
+	Y          *float64 `json:"y,omitempty" example:"48"`
+}
+
+func (l *SeatLayout) Validate() error {
+	if l == nil || len(l.Seats) == 0 {
+		return fmt.Errorf("%w: at least one seat is required", ErrInvalidSeatLayout)
+	}
+
+	seen := make(map[string]bool, len(l.Seats))
+	for _, p := range l.Seats {
+		if p.SeatNumber == "" {
+			return fmt.Errorf("%w: seat_number is required", ErrInvalidSeatLayout)
+		}
+		if seen[p.SeatNumber] {
+			return fmt.Errorf("%w: seat %q is placed twice", ErrInvalidSeatLayout, p.SeatNumber)
+		}
+		seen[p.SeatNumber] = true
+
+		if len(p.Section) > maxSeatLabelLength || len(p.Row) > maxSeatRowLength {
+			return fmt.Errorf("%w: section or row of seat %q is too long", ErrInvalidSeatLayout, p.SeatNumber)
+		}
+		if p.Column < 0 {
+			return fmt.Errorf("%w: column of seat %q must not be negative", ErrInvalidSeatLayout, p.SeatNumber)
+		}
+		if (p.X == nil) != (p.Y == nil) {
+			return fmt.Errorf("%w: seat %q needs both x and y", ErrInvalidSeatLayout, p.SeatNumber)
+		}
+		if p.X != nil && (*p.X < 0 || *p.Y < 0 || *p.X > maxSeatPlacement || *p.Y > maxSeatPlacement) {
+			return fmt.Errorf("%w: coordinates of seat %q are out of range", ErrInvalidSeatLayout, p.SeatNumber)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	SeatSchemeSequential = "sequential"
+	SeatSchemeRows       = "rows"
+	SeatSchemeSections   = "sections"
+
+	maxSeatLabelLength = 50
+)
+
+// SeatNumbering describes how seat numbers are generated when seats are
+// created. Format is a template using {event}, {n}, {section}, {row} and
+// {seat}; each scheme has a default. Events created before numbering schemes
+// existed have none and keep their "<eventID>-<n>" seat numbers.
+type SeatNumbering struct {
+	Scheme      string        `json:"scheme" example:"rows"`
+	Format      string        `json:"format,omitempty" example:"{row}{seat}"`
+	SeatsPerRow int           `json:"seats_per_row,omitempty" example:"20"`
+	Sections    []SeatSection `json:"sections,omitempty"`
+}
+
+// SeatSection is a block of rows, e.g. a VIP area. Rows restart at A in every section.
+type SeatSection struct {
+	Name        string `json:"name" example:"VIP"`
+	Rows        int    `json:"rows" example:"5"`
+	SeatsPerRow int    `json:"seats_per_row" example:"10"`
+}
+
+func (n *SeatNumbering) format() string {
+	if n.Format != "" {
+		return n.Format
+	}
+	switch n.Scheme {
+	case SeatSchemeRows:
+		return "{row}{seat}"
+	case SeatSchemeSections:
+		return "{section}-{row}{seat}"
+	}
+	return "{n}"
+}
+
+// SeatLabel returns the seat number and section name of the i-th seat (1-based).
+func (n *SeatNumbering) SeatLabel(eventID int64, i int) (string, string, error) {
+	if n == nil {
+		return fmt.Sprintf("%d-%d", eventID, i), "", nil
+	}
+
+	section, row, seat, err := n.position(i)
+	if err != nil {
+		return "", "", err
+	}
+
+	label := strings.NewReplacer(
+		"{event}", strconv.FormatInt(eventID, 10),
+		"{n}", strconv.Itoa(i),
+		"{section}", section,
+		"{row}", row,
+		"{seat}", seat,
+	).Replace(n.format())
+	return label, section, nil
+}
+
+// SeatRowColumn returns the row label and 1-based column of the i-th seat,
+// or "" and 0 when the scheme has no rows.
+func (n *SeatNumbering) SeatRowColumn(i int) (string, int) {
+	if n == nil {
+		return "", 0
+	}
+	_, row, seat, err := n.position(i)
+	if err != nil || row == "" {
+		return "", 0
+	}
+	column, _ := strconv.Atoi(seat)
+	return row, column
+}
+
+func (n *SeatNumbering) position(i int) (section, row, seat string, err error) {
+	switch n.Scheme {
+	case SeatSchemeSequential:
+	case SeatSchemeRows:
+		row = rowLetters((i - 1) / n.SeatsPerRow)
+		seat = strconv.Itoa((i-1)%n.SeatsPerRow + 1)
+	case SeatSchemeSections:
+		offset := i - 1
+		for _, s := range n.Sections {
+			size := s.Rows * s.SeatsPerRow
+			if offset < size {
+				return s.Name, rowLetters(offset / s.SeatsPerRow), strconv.Itoa(offset%s.SeatsPerRow + 1), nil
+			}
+			offset -= size
+		}
+		return "", "", "", fmt.Errorf("%w: seat %d is outside the section layout", ErrInvalidSeatNumbering, i)
+	default:
+		return "", "", "", fmt.Errorf("%w: unknown scheme %q", ErrInvalidSeatNumbering, n.Scheme)
+	}
+	return section, row, seat, nil
+}
+
+// Validate checks the scheme can number capacity seats with unique labels.
+// Section layouts must have room for every seat.
+func (n *SeatNumbering) Validate(capacity int) error {
+	if n == nil {
+		return nil
+	}
+
+	switch n.Scheme {
+	case SeatSchemeSequential:
+	case SeatSchemeRows:
+		if n.SeatsPerRow < 1 {
+			return fmt.Errorf("%w: seats_per_row must be at least 1", ErrInvalidSeatNumbering)
+		}
+	case SeatSchemeSections:
+		if len(n.Sections) == 0 {
+			return fmt.Errorf("%w: at least one section is required", ErrInvalidSeatNumbering)
+		}
+		total := 0
+		for _, s := range n.Sections {
+			if s.Name == "" || s.Rows < 1 || s.SeatsPerRow < 1 {
+				return fmt.Errorf("%w: every section needs a name, rows and seats_per_row", ErrInvalidSeatNumbering)
+			}
+			total += s.Rows * s.SeatsPerRow
+		}
+		if capacity > total {
+			return fmt.Errorf("%w: capacity %d exceeds the %d seats in the section layout", ErrInvalidSeatNumbering, capacity, total)
+		}
+	default:
+		return fmt.Errorf("%w: scheme must be sequential, rows or sections", ErrInvalidSeatNumbering)
+	}
+
+	// A custom format may drop the placeholders that make labels unique
+	seen := make(map[string]bool, capacity)
+	for i := 1; i <= capacity; i++ {
+		label, _, err := n.SeatLabel(0, i)
+		if err != nil {
+			return err
+		}
+		if len(label) > maxSeatLabelLength {
+			return fmt.Errorf("%w: seat number %q is longer than %d characters", ErrInvalidSeatNumbering, label, maxSeatLabelLength)
+		}
+		if seen[label] {
+			return fmt.Errorf("%w: format produces duplicate seat number %q", ErrInvalidSeatNumbering, label)
+		}
+		seen[label] = true
+	}
+	return nil
+}
+
+// rowLetters converts a 0-based row index to A, B, ..., Z, AA, AB, ...
+func rowLetters(idx int) string {
+	var b []byte
+	for idx >= 0 {
+		b = append([]byte{byte('A' + idx%26)}, b...)
+		idx = idx/26 - 1
+	}
+	return string(b)
+}
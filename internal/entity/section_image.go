@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// SectionImage is the view-from-seat picture of one section of an event.
+// URL is where clients download it; each upload gets a new URL, so it can be
+// cached indefinitely.
+type SectionImage struct {
+	ID          int64     `json:"image_id"`
+	EventID     int64     `json:"event_id"`
+	Section     string    `json:"section"`
+	StorageKey  string    `json:"-"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
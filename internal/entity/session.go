@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// Session is a device a user is signed in on: one per issued access token,
+// with the user agent and IP address it was issued to. Current marks the
+// session of the request listing them.
+type Session struct {
+	ID         int64     `json:"session_id"`
+	UserID     int64     `json:"-"`
+	UserAgent  string    `json:"user_agent" example:"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X)"`
+	IPAddress  string    `json:"ip_address" example:"203.0.113.7"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
@@ -0,0 +1,71 @@
+package entity
+
+import "strings"
+
+// EventStatus is the lifecycle state of an event. It is stored in the
+// status_event Postgres enum, which is lowercase.
+type EventStatus string
+
+const (
+	EventStatusAvailable EventStatus = "available"
+	EventStatusCancelled EventStatus = "cancelled"
+	EventStatusCompleted EventStatus = "completed"
+)
+
+// Valid reports whether s is one of the event statuses.
+func (s EventStatus) Valid() bool {
+	switch s {
+	case EventStatusAvailable, EventStatusCancelled, EventStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// BookingStatus is the state of a booking. A booking starts PENDING and is
+// PAID once its payment completes; an unpaid one is EXPIRED when its hold
+// lapses or CANCELLED with its event, and a paid one may be REFUNDED.
+type BookingStatus string
+
+const (
+	BookingPending   BookingStatus = "PENDING"
+	BookingPaid      BookingStatus = "PAID"
+	BookingCancelled BookingStatus = "CANCELLED"
+	BookingExpired   BookingStatus = "EXPIRED"
+	BookingRefunded  BookingStatus = "REFUNDED"
+)
+
+// Valid reports whether s is one of the booking statuses.
+func (s BookingStatus) Valid() bool {
+	switch s {
+	case BookingPending, BookingPaid, BookingCancelled, BookingExpired, BookingRefunded:
+		return true
+	}
+	return false
+}
+
+// ParseBookingStatus reads a booking status in any case, as older clients
+// filter by lowercase statuses.
+func ParseBookingStatus(s string) (BookingStatus, bool) {
+	status := BookingStatus(strings.ToUpper(strings.TrimSpace(s)))
+	return status, status.Valid()
+}
+
+// PaymentStatus is the state of a booking's payment transaction.
+type PaymentStatus string
+
+const (
+	PaymentPending   PaymentStatus = "PENDING"
+	PaymentCompleted PaymentStatus = "COMPLETED"
+	PaymentFailed    PaymentStatus = "FAILED"
+	PaymentCancelled PaymentStatus = "CANCELLED"
+	PaymentRefunded  PaymentStatus = "REFUNDED"
+)
+
+// Valid reports whether s is one of the payment statuses.
+func (s PaymentStatus) Valid() bool {
+	switch s {
+	case PaymentPending, PaymentCompleted, PaymentFailed, PaymentCancelled, PaymentRefunded:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+// Component statuses on the status page, from best to worst.
+const (
+	ComponentOperational = "operational"
+	ComponentDegraded    = "degraded"
+	ComponentDown        = "down"
+)
+
+// ComponentStatus is the health of one part of the platform. Details holds
+// its measurements, such as queue depth, for the status page to show.
+type ComponentStatus struct {
+	Name      string                 `json:"name" example:"database"`
+	Status    string                 `json:"status" example:"operational"`
+	LatencyMS *int64                 `json:"latency_ms,omitempty" example:"3"`
+	Message   string                 `json:"message,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty" swaggertype:"object"`
+	// Critical components take the whole platform down when they are down;
+	// the others only degrade it.
+	Critical bool `json:"critical"`
+}
+
+// SystemStatus summarizes the health of the platform for an external status
+// page. Status is "down" when a critical component is down and "degraded"
+// when any component is not operational.
+type SystemStatus struct {
+	Status     string            `json:"status" example:"operational"`
+	Components []ComponentStatus `json:"components"`
+	CheckedAt  time.Time         `json:"checked_at"`
+}
+
+// Summarize sets Status from the components.
+func (s *SystemStatus) Summarize() {
+	s.Status = ComponentOperational
+	for _, c := range s.Components {
+		switch {
+		case c.Status == ComponentDown && c.Critical:
+			s.Status = ComponentDown
+			return
+		case c.Status != ComponentOperational:
+			s.Status = ComponentDegraded
+		}
+	}
+}
+
+// JobBacklog counts the stored background jobs. Due jobs are waiting for a
+// worker, OldestDueAt is when the longest-waiting one became due, and Outbox
+// counts jobs committed but not yet moved to the queue.
+type JobBacklog struct {
+	Due         int        `json:"due"`
+	Scheduled   int        `json:"scheduled"`
+	Running     int        `json:"running"`
+	Dead        int        `json:"dead"`
+	Outbox      int        `json:"outbox"`
+	OldestDueAt *time.Time `json:"oldest_due_at,omitempty"`
+}
+
+// ReconciliationRun is the outcome of the last run of a periodic check that
+// compares stored state against its invariants, such as seat inventory.
+type ReconciliationRun struct {
+	Name       string    `json:"name" example:"inventory"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Violations int       `json:"violations"`
+	Error      string    `json:"error,omitempty"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// Ticket is a single admission issued for a seat in a PAID booking.
+// Code is the value encoded in the QR code shown at the gate.
+type Ticket struct {
+	ID            int64         `json:"ticket_id"`
+	BookingID     int64         `json:"booking_id"`
+	EventID       int64         `json:"event_id"`
+	SeatID        int64         `json:"seat_id,omitempty"`
+	SeatNumber    string        `json:"seat_number,omitempty"`
+	Code          string        `json:"code"`
+	BookingStatus BookingStatus `json:"-"`
+	CheckedInAt   *time.Time    `json:"checked_in_at,omitempty"`
+	CheckedInBy   *int64        `json:"checked_in_by,omitempty"`
+	CheckedInGate *int64        `json:"checked_in_gate,omitempty"`
+}
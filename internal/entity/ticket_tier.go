@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const maxTierNameLength = 100
+
+// TicketTier is a named price level of an event, e.g. "Early Bird" or
+// "VIP". Seats assigned to the tier are sold at its price, and at most
+// Quota seats can be assigned.
+type TicketTier struct {
+	ID        int64     `json:"tier_id"`
+	EventID   int64     `json:"event_id"`
+	Name      string    `json:"name" example:"VIP"`
+	Price     float64   `json:"price" example:"750000"`
+	Quota     int       `json:"quota" example:"200"`
+	Assigned  int       `json:"assigned_seats"`
+	Sold      int       `json:"sold_seats"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (t *TicketTier) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" || len(t.Name) > maxTierNameLength {
+		return fmt.Errorf("%w: name is required and at most %d characters", ErrInvalidTicketTier, maxTierNameLength)
+	}
+	if t.Price < 0 {
+		return fmt.Errorf("%w: price must not be negative", ErrInvalidTicketTier)
+	}
+	if t.Quota < 1 {
+		return fmt.Errorf("%w: quota must be at least 1", ErrInvalidTicketTier)
+	}
+	return nil
+}
+
+// TierSeatAssignment selects the seats to move into a tier: explicit seat
+// IDs, every seat of a section, or both.
+type TierSeatAssignment struct {
+	SeatIDs []int64 `json:"seat_ids,omitempty"`
+	Section string  `json:"section,omitempty" example:"VIP"`
+}
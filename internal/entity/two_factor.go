@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// TwoFactorSetup is the secret a user adds to their authenticator app,
+// either by scanning ProvisioningURI as a QR code or typing Secret.
+type TwoFactorSetup struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/TicRes:jane@mail.com?issuer=TicRes&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+// TwoFactor is a user's stored TOTP state. Secret is encrypted, and empty
+// until the user starts setting 2FA up. RecentFailures counts the wrong
+// codes entered lately.
+type TwoFactor struct {
+	Secret         string
+	Enabled        bool
+	LastStep       int64
+	RecentFailures int
+}
+
+// LoginResult is the outcome of signing in with a password: a token, or,
+// for users with 2FA, a challenge to complete with a code.
+type LoginResult struct {
+	Token             string     `json:"token,omitempty"`
+	TwoFactorRequired bool       `json:"two_factor_required,omitempty"`
+	ChallengeToken    string     `json:"challenge_token,omitempty"`
+	ChallengeExpires  *time.Time `json:"challenge_expires_at,omitempty"`
+}
@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+const (
+	UpgradeOfferPending  = "PENDING"
+	UpgradeOfferAccepted = "ACCEPTED"
+	UpgradeOfferExpired  = "EXPIRED"
+)
+
+// UpgradeOffer proposes moving one ticket of a paid booking to an unsold
+// premium seat for PriceDifference. The token in the emailed link is the
+// only credential needed to accept it.
+type UpgradeOffer struct {
+	ID              int64      `json:"offer_id"`
+	BookingID       int64      `json:"booking_id"`
+	FromSeatID      int64      `json:"from_seat_id"`
+	ToSeatID        int64      `json:"to_seat_id"`
+	PriceDifference float64    `json:"price_difference"`
+	Status          string     `json:"status"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	AcceptedAt      *time.Time `json:"accepted_at,omitempty"`
+	ModificationID  *int64     `json:"modification_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+
+	// Display details, filled on reads
+	EventID        int64     `json:"event_id"`
+	EventName      string    `json:"event_name"`
+	EventDate      time.Time `json:"event_date"`
+	FromSeatNumber string    `json:"from_seat_number"`
+	ToSeatNumber   string    `json:"to_seat_number"`
+}
+
+// UpgradeCandidate pairs a ticket holder with an unsold premium seat of the
+// same event. PriceDifference already includes the booking's pricing variant.
+type UpgradeCandidate struct {
+	BookingID       int64
+	UserEmail       string
+	EventID         int64
+	EventDate       time.Time
+	FromSeatID      int64
+	ToSeatID        int64
+	PriceDifference float64
+}
@@ -1,6 +1,17 @@
 package entity
 
-import "time"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxNameLength = 100
+
+// usernamePattern is 3 to 30 letters, digits, dots and underscores.
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9._]{3,30}$`)
 
 type User struct {
 	ID        int64     `json:"user_id"`
@@ -9,5 +20,24 @@ type User struct {
 	Email     string    `json:"email"`
 	Password  string    `json:"-"` // "-" agar password tidak ikut terkirim saat return JSON ke frontend
 	Role 	  string 	`json:"role"`
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+	// OAuthProvider is the identity provider the user signs in with, if
+	// any, and OAuthSubject the provider's ID for their account.
+	OAuthProvider string `json:"oauth_provider,omitempty"`
+	OAuthSubject  string `json:"-"`
 	CreatedAt time.Time `json:"created_at"`
-}
\ No newline at end of file
+}
+
+// ValidateProfile trims and checks the fields users can change on their
+// own profile. A user need not have a username.
+func (u *User) ValidateProfile() error {
+	u.Name = strings.TrimSpace(u.Name)
+	u.UserName = strings.TrimSpace(u.UserName)
+	if u.Name == "" || utf8.RuneCountInString(u.Name) > maxNameLength {
+		return fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidProfile, maxNameLength)
+	}
+	if u.UserName != "" && !usernamePattern.MatchString(u.UserName) {
+		return fmt.Errorf("%w: username must be 3 to 30 letters, digits, dots or underscores", ErrInvalidProfile)
+	}
+	return nil
+}
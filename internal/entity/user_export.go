@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// User data export statuses. A FAILED export is replaced by a new one the
+// next time the user asks.
+const (
+	UserExportPending = "PENDING"
+	UserExportReady   = "READY"
+	UserExportFailed  = "FAILED"
+)
+
+// UserDataExport is a user's request for a copy of their personal data. The
+// worker builds the archive; Archive is only loaded once it is READY.
+type UserDataExport struct {
+	ID          int64           `json:"export_id"`
+	UserID      int64           `json:"-"`
+	Status      string          `json:"status" example:"PENDING"`
+	RequestedAt time.Time       `json:"requested_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	Archive     json.RawMessage `json:"-"`
+}
+
+// UserDataArchive is the downloadable copy of a user's personal data.
+type UserDataArchive struct {
+	GeneratedAt  time.Time         `json:"generated_at"`
+	Profile      User              `json:"profile"`
+	Bookings     []ExportedBooking `json:"bookings"`
+	Transactions []Transaction     `json:"transactions"`
+}
+
+// ExportedBooking is one booking in a user's data archive.
+type ExportedBooking struct {
+	BookingID   int64         `json:"booking_id"`
+	EventID     int64         `json:"event_id"`
+	EventName   string        `json:"event_name"`
+	EventDate   time.Time     `json:"event_date"`
+	Status      BookingStatus `json:"status"`
+	TotalAmount float64       `json:"total_amount"`
+	Tickets     int           `json:"tickets"`
+	Seats       []string      `json:"seats,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
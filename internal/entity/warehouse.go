@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Datasets shipped to the data warehouse.
+const (
+	DatasetBookings     = "bookings"
+	DatasetTransactions = "transactions"
+	DatasetRefunds      = "refunds"
+	DatasetAuditLogs    = "audit_logs"
+)
+
+// WarehouseDatasets lists every dataset in export order.
+var WarehouseDatasets = []string{DatasetBookings, DatasetTransactions, DatasetRefunds, DatasetAuditLogs}
+
+// ExportWatermark is the last row of a dataset shipped to a destination.
+// Rows are exported in (updated_at, id) order, so the next batch starts
+// strictly after (At, ID).
+type ExportWatermark struct {
+	Destination  string    `json:"destination"`
+	Dataset      string    `json:"dataset"`
+	At           time.Time `json:"watermark_at"`
+	ID           int64     `json:"watermark_id"`
+	ExportedRows int64     `json:"exported_rows"`
+}
+
+// ExportRecord is one changed row, with the whole row as a JSON object.
+type ExportRecord struct {
+	ID        int64
+	UpdatedAt time.Time
+	Data      json.RawMessage
+}
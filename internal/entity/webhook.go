@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Lifecycle events sent to webhooks.
+const (
+	WebhookBookingCreated   = "booking.created"
+	WebhookPaymentCompleted = "payment.completed"
+	WebhookBookingRefunded  = "booking.refunded"
+)
+
+// WebhookEventTypes lists the lifecycle events a webhook can subscribe to.
+var WebhookEventTypes = []string{WebhookBookingCreated, WebhookPaymentCompleted, WebhookBookingRefunded}
+
+// Delivery statuses. A delivery stays PENDING while it is being retried and
+// becomes FAILED once its job runs out of attempts.
+const (
+	WebhookDeliveryPending   = "PENDING"
+	WebhookDeliverySucceeded = "SUCCEEDED"
+	WebhookDeliveryFailed    = "FAILED"
+)
+
+// Webhook sends the lifecycle events of an organizer's events to URL, or
+// of every event when OrganizerID is nil. The signing secret is only
+// returned in Secret when the webhook is created.
+type Webhook struct {
+	ID          int64     `json:"webhook_id"`
+	OrganizerID *int64    `json:"organizer_id,omitempty"`
+	URL         string    `json:"url" example:"https://crm.example.com/hooks/ticres"`
+	EventTypes  []string  `json:"event_types" example:"booking.created,payment.completed"`
+	Secret      string    `json:"secret,omitempty"`
+	Active      bool      `json:"active"`
+	CreatedBy   int64     `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// SecretEncrypted is the signing secret as stored.
+	SecretEncrypted string `json:"-"`
+}
+
+// Subscribes reports whether the webhook is sent eventType.
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookUpdate changes the fields of a webhook that are set.
+type WebhookUpdate struct {
+	URL        *string  `json:"url,omitempty" example:"https://crm.example.com/hooks/ticres"`
+	EventTypes []string `json:"event_types,omitempty" example:"booking.refunded"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+// WebhookEvent is the body POSTed to a webhook. ID stays the same across
+// retries, so receivers can drop events they already handled.
+type WebhookEvent struct {
+	ID        string             `json:"id" example:"booking.created:42"`
+	Type      string             `json:"type" example:"booking.created"`
+	CreatedAt time.Time          `json:"created_at"`
+	Data      WebhookBookingData `json:"data"`
+}
+
+// WebhookBookingData describes the booking a lifecycle event is about.
+type WebhookBookingData struct {
+	BookingID     int64         `json:"booking_id"`
+	EventID       int64         `json:"event_id"`
+	EventName     string        `json:"event_name"`
+	Status        BookingStatus `json:"status" example:"PAID"`
+	TotalAmount   float64       `json:"total_amount"`
+	CustomerName  string        `json:"customer_name,omitempty"`
+	CustomerEmail string        `json:"customer_email,omitempty"`
+	BookedAt      time.Time     `json:"booked_at"`
+	PaymentMethod string        `json:"payment_method,omitempty" example:"credit_card"`
+	PaymentRef    string        `json:"payment_reference,omitempty"`
+	InvoiceNumber string        `json:"invoice_number,omitempty" example:"INV/2026/000042"`
+}
+
+// WebhookDelivery is one lifecycle event sent to a webhook, with the
+// outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID             int64           `json:"delivery_id"`
+	WebhookID      int64           `json:"webhook_id"`
+	EventKey       string          `json:"event_id" example:"booking.created:42"`
+	EventType      string          `json:"event_type" example:"booking.created"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status" example:"SUCCEEDED"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus *int            `json:"response_status,omitempty" example:"200"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
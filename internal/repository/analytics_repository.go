@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AnalyticsRepository interface {
+	GetSalesStats(ctx context.Context, eventID int64, windowStart time.Time) (*entity.SalesStats, error)
+	GetBoostCandidates(ctx context.Context, cooldown time.Duration) ([]entity.Event, error)
+	MarkMarketingBoostSent(ctx context.Context, eventID int64) error
+	GetComparableEvents(ctx context.Context, event *entity.Event, groupBy string, limit int) ([]entity.Event, error)
+	GetDailySalesBeforeEvent(ctx context.Context, eventIDs []int64) (map[int64]map[int]int, error)
+}
+
+type analyticsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAnalyticsRepository(db *pgxpool.Pool) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// GetSalesStats counts seats in paid bookings, overall and since windowStart.
+func (r *analyticsRepository) GetSalesStats(ctx context.Context, eventID int64, windowStart time.Time) (*entity.SalesStats, error) {
+	logger.FromContext(ctx).Debug("fetching sales stats", logger.Int64("event_id", eventID))
+
+	query := `
+		SELECT COUNT(bi.id),
+			COUNT(bi.id) FILTER (WHERE b.created_at >= $2),
+			MIN(b.created_at)
+		FROM booking b
+		JOIN booking_items bi ON bi.booking_id = b.booking_id
+		WHERE b.event_id = $1 AND b.status = 'PAID'
+	`
+	var stats entity.SalesStats
+	err := r.db.QueryRow(ctx, query, eventID, windowStart).Scan(&stats.TotalSold, &stats.SoldInWindow, &stats.FirstSaleAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to fetch sales stats", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetBoostCandidates lists upcoming organizer events that have not been sent a
+// marketing boost within the cooldown.
+func (r *analyticsRepository) GetBoostCandidates(ctx context.Context, cooldown time.Duration) ([]entity.Event, error) {
+	logger.FromContext(ctx).Debug("fetching marketing boost candidates")
+
+	query := `
+		SELECT event_id, name, location, date, capacity, organizer_id, created_at
+		FROM events
+		WHERE status = 'available' AND date > NOW() AND organizer_id IS NOT NULL
+			AND (marketing_boost_sent_at IS NULL OR marketing_boost_sent_at < $1)
+		ORDER BY date
+	`
+	rows, err := r.db.Query(ctx, query, time.Now().Add(-cooldown))
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query boost candidates", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []entity.Event
+	for rows.Next() {
+		var evt entity.Event
+		if err := rows.Scan(&evt.ID, &evt.Name, &evt.Location, &evt.Date, &evt.Capacity, &evt.OrganizerID, &evt.CreatedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan boost candidate row", logger.Err(err))
+			return nil, err
+		}
+		events = append(events, evt)
+	}
+
+	return events, nil
+}
+
+func (r *analyticsRepository) MarkMarketingBoostSent(ctx context.Context, eventID int64) error {
+	_, err := r.db.Exec(ctx, `UPDATE events SET marketing_boost_sent_at = NOW() WHERE event_id = $1`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to mark marketing boost sent", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+// GetComparableEvents returns the organizer's past events sharing the given
+// event's series ("series") or location ("venue"), most recent first.
+func (r *analyticsRepository) GetComparableEvents(ctx context.Context, event *entity.Event, groupBy string, limit int) ([]entity.Event, error) {
+	logger.FromContext(ctx).Debug("fetching comparable events",
+		logger.Int64("event_id", event.ID),
+		logger.String("group_by", groupBy),
+	)
+
+	column, key := "location", event.Location
+	if groupBy == "series" {
+		column, key = "series", event.Series
+	}
+
+	query := fmt.Sprintf(`
+		SELECT event_id, name, location, COALESCE(series, ''), date, capacity, organizer_id, created_at
+		FROM events
+		WHERE organizer_id = $1 AND event_id <> $2 AND %s = $3 AND date < NOW() AND status <> 'cancelled'
+		ORDER BY date DESC
+		LIMIT $4
+	`, column)
+	rows, err := r.db.Query(ctx, query, event.OrganizerID, event.ID, key, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query comparable events", logger.Int64("event_id", event.ID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []entity.Event
+	for rows.Next() {
+		var evt entity.Event
+		if err := rows.Scan(&evt.ID, &evt.Name, &evt.Location, &evt.Series, &evt.Date, &evt.Capacity, &evt.OrganizerID, &evt.CreatedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan comparable event row", logger.Err(err))
+			return nil, err
+		}
+		events = append(events, evt)
+	}
+
+	return events, nil
+}
+
+// GetDailySalesBeforeEvent counts paid seats per event keyed by whole days
+// between the sale and the event date. Sales on the event day are day 0.
+func (r *analyticsRepository) GetDailySalesBeforeEvent(ctx context.Context, eventIDs []int64) (map[int64]map[int]int, error) {
+	logger.FromContext(ctx).Debug("fetching daily sales before event", logger.Int("events", len(eventIDs)))
+
+	query := `
+		SELECT b.event_id,
+			GREATEST(FLOOR(EXTRACT(EPOCH FROM (e.date - b.created_at)) / 86400), 0)::int AS days_before,
+			COUNT(bi.id)
+		FROM booking b
+		JOIN booking_items bi ON bi.booking_id = b.booking_id
+		JOIN events e ON e.event_id = b.event_id
+		WHERE b.event_id = ANY($1) AND b.status = 'PAID'
+		GROUP BY b.event_id, days_before
+	`
+	rows, err := r.db.Query(ctx, query, eventIDs)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query daily sales", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	sales := make(map[int64]map[int]int, len(eventIDs))
+	for rows.Next() {
+		var eventID int64
+		var daysBefore, sold int
+		if err := rows.Scan(&eventID, &daysBefore, &sold); err != nil {
+			logger.FromContext(ctx).Error("failed to scan daily sales row", logger.Err(err))
+			return nil, err
+		}
+		if sales[eventID] == nil {
+			sales[eventID] = make(map[int]int)
+		}
+		sales[eventID][daysBefore] = sold
+	}
+
+	return sales, nil
+}
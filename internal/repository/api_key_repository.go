@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type APIKeyRepository interface {
+	// CreateKey stores a key with the SHA-256 of its secret.
+	CreateKey(ctx context.Context, key *entity.APIKey, keyHash string) error
+	// ListKeys returns the keys of a user, or of everyone when userID is
+	// nil, newest first.
+	ListKeys(ctx context.Context, userID *int64) ([]entity.APIKey, error)
+	// RevokeKey disables a key, or returns ErrNotFound if there is no such
+	// active key.
+	RevokeKey(ctx context.Context, keyID int64) error
+	// UseKey returns the active, unexpired key with the hash, with its
+	// owner's role, and stamps its last use. Returns ErrNotFound if there is
+	// none or the owner's account is deleted.
+	UseKey(ctx context.Context, keyHash string) (*entity.APIKey, error)
+}
+
+type apiKeyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(db *pgxpool.Pool) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) CreateKey(ctx context.Context, key *entity.APIKey, keyHash string) error {
+	logger.FromContext(ctx).Debug("creating api key", logger.Int64("user_id", key.UserID), logger.String("name", key.Name))
+
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO api_keys (user_id, name, key_prefix, key_hash, scopes, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING key_id, created_at
+	`, key.UserID, key.Name, key.Prefix, keyHash, key.Scopes, key.CreatedBy, key.ExpiresAt).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to insert api key", logger.Int64("user_id", key.UserID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) ListKeys(ctx context.Context, userID *int64) ([]entity.APIKey, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT key_id, user_id, name, key_prefix, scopes, created_by, created_at, expires_at, last_used_at, revoked_at
+		FROM api_keys
+		WHERE $1::bigint IS NULL OR user_id = $1
+		ORDER BY key_id DESC
+	`, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query api keys", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []entity.APIKey{}
+	for rows.Next() {
+		var k entity.APIKey
+		if err := rows.Scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.Scopes, &k.CreatedBy, &k.CreatedAt, &k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan api key row", logger.Err(err))
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *apiKeyRepository) RevokeKey(ctx context.Context, keyID int64) error {
+	tag, err := r.db.Exec(ctx, `UPDATE api_keys SET revoked_at = NOW() WHERE key_id = $1 AND revoked_at IS NULL`, keyID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to revoke api key", logger.Int64("key_id", keyID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// UseKey looks the key up and records the use in one statement, so
+// authenticating a request costs a single round trip.
+func (r *apiKeyRepository) UseKey(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	var k entity.APIKey
+	err := r.db.QueryRow(ctx, `
+		UPDATE api_keys k SET last_used_at = NOW()
+		FROM users u
+		WHERE k.key_hash = $1 AND k.revoked_at IS NULL AND (k.expires_at IS NULL OR k.expires_at > NOW())
+			AND u.user_id = k.user_id AND u.deleted_at IS NULL
+		RETURNING k.key_id, k.user_id, k.name, k.key_prefix, k.scopes, k.created_by, k.created_at, k.expires_at, k.last_used_at, u.role
+	`, keyHash).Scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.Scopes, &k.CreatedBy, &k.CreatedAt, &k.ExpiresAt, &k.LastUsedAt, &k.OwnerRole)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to look up api key", logger.Err(err))
+		return nil, err
+	}
+	return &k, nil
+}
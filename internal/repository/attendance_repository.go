@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttendanceRepository interface {
+	// GetAttendanceReport returns the event's post-event report, or its live
+	// attendance if none was generated yet. Returns ErrNotFound if there is
+	// no such event.
+	GetAttendanceReport(ctx context.Context, eventID int64) (*entity.AttendanceReport, error)
+	// CreateAttendanceReports generates the reports of the organizer events
+	// that started between endedAfter and endedBefore and have none yet, and
+	// returns them. Each report is returned to one caller only.
+	CreateAttendanceReports(ctx context.Context, endedAfter, endedBefore time.Time) ([]entity.AttendanceReport, error)
+}
+
+type attendanceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAttendanceRepository(db *pgxpool.Pool) AttendanceRepository {
+	return &attendanceRepository{db: db}
+}
+
+func (r *attendanceRepository) GetAttendanceReport(ctx context.Context, eventID int64) (*entity.AttendanceReport, error) {
+	logger.FromContext(ctx).Debug("fetching attendance report", logger.Int64("event_id", eventID))
+
+	// Seatless general admission items count as tickets too
+	query := `
+		SELECT e.event_id, e.name, e.date, COALESCE(e.organizer_id, 0),
+			COALESCE(r.tickets_sold, a.sold), COALESCE(r.checked_in, a.checked_in), r.generated_at
+		FROM events e
+		LEFT JOIN event_attendance_reports r ON r.event_id = e.event_id
+		LEFT JOIN LATERAL (
+			SELECT COUNT(bi.id) AS sold,
+				COUNT(bi.id) FILTER (WHERE bi.checked_in_at IS NOT NULL) AS checked_in
+			FROM booking b
+			JOIN booking_items bi ON bi.booking_id = b.booking_id
+			WHERE b.event_id = e.event_id AND b.status = 'PAID'
+		) a ON r.event_id IS NULL
+		WHERE e.event_id = $1
+	`
+	var report entity.AttendanceReport
+	err := r.db.QueryRow(ctx, query, eventID).Scan(
+		&report.EventID, &report.EventName, &report.EventDate, &report.OrganizerID,
+		&report.TicketsSold, &report.CheckedIn, &report.GeneratedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch attendance report", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *attendanceRepository) CreateAttendanceReports(ctx context.Context, endedAfter, endedBefore time.Time) ([]entity.AttendanceReport, error) {
+	logger.FromContext(ctx).Debug("generating attendance reports")
+
+	// ON CONFLICT leaves a report another instance just wrote to it, so only
+	// one of them sends it
+	query := `
+		WITH ended AS (
+			SELECT e.event_id FROM events e
+			WHERE e.date > $1 AND e.date <= $2
+				AND e.status <> 'cancelled' AND e.deleted_at IS NULL AND e.organizer_id IS NOT NULL
+				AND NOT EXISTS (SELECT 1 FROM event_attendance_reports r WHERE r.event_id = e.event_id)
+		), inserted AS (
+			INSERT INTO event_attendance_reports (event_id, tickets_sold, checked_in)
+			SELECT ended.event_id, COUNT(bi.id), COUNT(bi.id) FILTER (WHERE bi.checked_in_at IS NOT NULL)
+			FROM ended
+			LEFT JOIN booking b ON b.event_id = ended.event_id AND b.status = 'PAID'
+			LEFT JOIN booking_items bi ON bi.booking_id = b.booking_id
+			GROUP BY ended.event_id
+			ON CONFLICT (event_id) DO NOTHING
+			RETURNING event_id, tickets_sold, checked_in, generated_at
+		)
+		SELECT i.event_id, e.name, e.date, e.organizer_id, i.tickets_sold, i.checked_in, i.generated_at
+		FROM inserted i
+		JOIN events e ON e.event_id = i.event_id
+		ORDER BY e.date
+	`
+	rows, err := r.db.Query(ctx, query, endedAfter, endedBefore)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to generate attendance reports", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []entity.AttendanceReport
+	for rows.Next() {
+		var report entity.AttendanceReport
+		if err := rows.Scan(
+			&report.EventID, &report.EventName, &report.EventDate, &report.OrganizerID,
+			&report.TicketsSold, &report.CheckedIn, &report.GeneratedAt,
+		); err != nil {
+			logger.FromContext(ctx).Error("failed to scan attendance report row", logger.Err(err))
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditRepository interface {
+	CreateAuditLog(ctx context.Context, log *entity.AuditLog) error
+	// ListAuditLogs returns a page of matching entries, newest first, and
+	// how many match in total.
+	ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, page, limit int) ([]entity.AuditLog, int, error)
+	AggregateActions(ctx context.Context, action string, match map[string]string, actorID *int64, since time.Time) (*entity.AuditAggregate, error)
+}
+
+type auditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) CreateAuditLog(ctx context.Context, log *entity.AuditLog) error {
+	logger.FromContext(ctx).Debug("creating audit log",
+		logger.String("action", log.Action),
+		logger.String("entity_type", log.EntityType),
+		logger.Int64("entity_id", log.EntityID),
+	)
+
+	details := log.Details
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	payload, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	var requestID *string
+	if log.RequestID != "" {
+		requestID = &log.RequestID
+	}
+
+	query := `
+		INSERT INTO audit_logs (actor_id, action, entity_type, entity_id, details, before, after, request_id, api_key_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING audit_id, created_at
+	`
+	err = r.db.QueryRow(ctx, query, log.ActorID, log.Action, log.EntityType, log.EntityID, payload,
+		nullJSON(log.Before), nullJSON(log.After), requestID, log.APIKeyID).
+		Scan(&log.ID, &log.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create audit log", logger.String("action", log.Action), logger.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *auditRepository) ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, page, limit int) ([]entity.AuditLog, int, error) {
+	logger.FromContext(ctx).Debug("listing audit logs",
+		logger.String("action", filter.Action),
+		logger.String("entity_type", filter.EntityType),
+		logger.Int("page", page),
+	)
+
+	where := `
+		WHERE ($1::bigint IS NULL OR actor_id = $1)
+		  AND ($2 = '' OR action = $2)
+		  AND ($3 = '' OR entity_type = $3)
+		  AND ($4::bigint IS NULL OR entity_id = $4)
+		  AND ($5::timestamp IS NULL OR created_at >= $5)
+		  AND ($6::timestamp IS NULL OR created_at < $6)
+	`
+	args := []interface{}{filter.ActorID, filter.Action, filter.EntityType, filter.EntityID, filter.From, filter.To}
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM audit_logs"+where, args...).Scan(&total); err != nil {
+		logger.FromContext(ctx).Error("failed to count audit logs", logger.Err(err))
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT audit_id, actor_id, action, COALESCE(entity_type, ''), COALESCE(entity_id, 0), details,
+			before, after, COALESCE(request_id, ''), api_key_id, created_at
+		FROM audit_logs`+where+`
+		ORDER BY created_at DESC, audit_id DESC
+		LIMIT $7 OFFSET $8
+	`, append(args, limit, (page-1)*limit)...)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query audit logs", logger.Err(err))
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	logs := []entity.AuditLog{}
+	for rows.Next() {
+		var l entity.AuditLog
+		var before, after []byte
+		if err := rows.Scan(&l.ID, &l.ActorID, &l.Action, &l.EntityType, &l.EntityID, &l.Details,
+			&before, &after, &l.RequestID, &l.APIKeyID, &l.CreatedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan audit log row", logger.Err(err))
+			return nil, 0, err
+		}
+		l.Before, l.After = before, after
+		logs = append(logs, l)
+	}
+	return logs, total, rows.Err()
+}
+
+// nullJSON stores an absent snapshot as NULL rather than a JSON null.
+func nullJSON(raw json.RawMessage) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
+
+// AggregateActions counts audit entries for an action since the given time and
+// sums their numeric "amount" detail. Entries must contain every match pair in
+// their details, and belong to actorID when it is set.
+func (r *auditRepository) AggregateActions(ctx context.Context, action string, match map[string]string, actorID *int64, since time.Time) (*entity.AuditAggregate, error) {
+	if match == nil {
+		match = map[string]string{}
+	}
+	matchJSON, err := json.Marshal(match)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT COUNT(*), COALESCE(SUM((details->>'amount')::numeric), 0)
+		FROM audit_logs
+		WHERE action = $1
+		  AND created_at >= $2
+		  AND details @> $3::jsonb
+		  AND ($4::int IS NULL OR actor_id = $4)
+	`
+
+	var agg entity.AuditAggregate
+	err = r.db.QueryRow(ctx, query, action, since, matchJSON, actorID).Scan(&agg.Count, &agg.Amount)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to aggregate audit logs", logger.String("action", action), logger.Err(err))
+		return nil, err
+	}
+
+	return &agg, nil
+}
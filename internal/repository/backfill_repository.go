@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BackfillRepository runs the registered backfills one batch at a time and
+// tracks their progress in the backfills table.
+type BackfillRepository interface {
+	// ListBackfills returns every registered backfill, PENDING when it never ran.
+	ListBackfills(ctx context.Context) ([]entity.Backfill, error)
+	// GetBackfill returns a registered backfill, or entity.ErrNotFound.
+	GetBackfill(ctx context.Context, name string) (*entity.Backfill, error)
+	// StartBackfill marks the backfill RUNNING, inside the unit of work in
+	// ctx if there is one. A FAILED one resumes from its checkpoint;
+	// otherwise it starts at the lowest key, up to the highest key now. A
+	// RUNNING one is entity.ErrBackfillRunning.
+	StartBackfill(ctx context.Context, name string, batchSize int) (*entity.Backfill, error)
+	// RunBackfillBatch applies the backfill to its next key range and
+	// moves the checkpoint past it in one short transaction, marking the
+	// backfill COMPLETED after the last range. It returns the backfill as
+	// it is afterwards; a backfill that is not RUNNING is left alone.
+	RunBackfillBatch(ctx context.Context, name string) (*entity.Backfill, error)
+	// FailBackfill records the error of a run that gave up. The checkpoint
+	// is kept so it can be resumed.
+	FailBackfill(ctx context.Context, name, lastError string) error
+}
+
+// backfillSpec is a registered backfill. update changes the rows of table
+// with key in ($1, $2] that still need it. It must be safe to run again on
+// rows already done, since a backfill that completed can be started over.
+type backfillSpec struct {
+	name        string
+	description string
+	table       string
+	key         string
+	update      string
+}
+
+// backfills are the registered backfills. To change a large table without
+// downtime, a migration adds the new columns as nullable without a default,
+// the code starts writing them for new rows, a backfill registered here
+// fills in existing rows, and a later migration adds constraints once it
+// has COMPLETED.
+var backfills = []backfillSpec{
+	{
+		name: "seat_layout",
+		description: "Fill in row_label and col_number of seats generated before seats had a layout, " +
+			"for events numbered by rows or sections with the default seat number format",
+		table: "seats",
+		key:   "seat_id",
+		update: `
+			UPDATE seats s
+			SET row_label = substring(s.seat_number from '([A-Z]+)[0-9]+$'),
+				col_number = substring(s.seat_number from '([0-9]+)$')::INTEGER
+			FROM events e
+			WHERE s.seat_id > $1 AND s.seat_id <= $2
+				AND s.row_label IS NULL
+				AND s.seat_number ~ '[A-Z]+[0-9]+$'
+				AND e.event_id = s.event_id
+				AND e.seat_numbering->>'scheme' IN ('rows', 'sections')
+				AND COALESCE(e.seat_numbering->>'format', '') = ''
+		`,
+	},
+}
+
+func findBackfill(name string) (backfillSpec, bool) {
+	for _, spec := range backfills {
+		if spec.name == name {
+			return spec, true
+		}
+	}
+	return backfillSpec{}, false
+}
+
+// backfillLockTimeout bounds how long a batch waits for a row a booking has
+// locked. Giving up and retrying later is better than holding the locks it
+// already took while bookings queue behind them.
+const backfillLockTimeout = "2s"
+
+type backfillRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBackfillRepository(db *pgxpool.Pool) BackfillRepository {
+	return &backfillRepository{db: db}
+}
+
+const backfillColumns = `status, batch_size, last_id, max_id, rows_updated, batches, COALESCE(last_error, ''),
+	started_at, completed_at, updated_at`
+
+func scanBackfill(row pgx.Row, spec backfillSpec) (*entity.Backfill, error) {
+	b := entity.Backfill{Name: spec.name, Description: spec.description, Table: spec.table}
+	err := row.Scan(&b.Status, &b.BatchSize, &b.LastID, &b.MaxID, &b.RowsUpdated, &b.Batches, &b.LastError,
+		&b.StartedAt, &b.CompletedAt, &b.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func pendingBackfill(spec backfillSpec) entity.Backfill {
+	return entity.Backfill{Name: spec.name, Description: spec.description, Table: spec.table, Status: entity.BackfillPending}
+}
+
+func (r *backfillRepository) ListBackfills(ctx context.Context) ([]entity.Backfill, error) {
+	result := make([]entity.Backfill, 0, len(backfills))
+	for _, spec := range backfills {
+		b, err := r.GetBackfill(ctx, spec.name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *b)
+	}
+	return result, nil
+}
+
+func (r *backfillRepository) GetBackfill(ctx context.Context, name string) (*entity.Backfill, error) {
+	spec, ok := findBackfill(name)
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	query := `SELECT ` + backfillColumns + ` FROM backfills WHERE name = $1`
+	b, err := scanBackfill(r.db.QueryRow(ctx, query, name), spec)
+	if errors.Is(err, pgx.ErrNoRows) {
+		pending := pendingBackfill(spec)
+		return &pending, nil
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to get backfill", logger.String("backfill", name), logger.Err(err))
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *backfillRepository) StartBackfill(ctx context.Context, name string, batchSize int) (*entity.Backfill, error) {
+	spec, ok := findBackfill(name)
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	// The table and key come from the registry, never from the request
+	query := fmt.Sprintf(`
+		INSERT INTO backfills (name, batch_size, max_id)
+		VALUES ($1, $2, (SELECT COALESCE(MAX(%[1]s), 0) FROM %[2]s))
+		ON CONFLICT (name) DO UPDATE SET
+			status = 'RUNNING',
+			batch_size = EXCLUDED.batch_size,
+			last_id = CASE WHEN backfills.status = 'FAILED' THEN backfills.last_id ELSE 0 END,
+			max_id = CASE WHEN backfills.status = 'FAILED' THEN backfills.max_id ELSE EXCLUDED.max_id END,
+			rows_updated = CASE WHEN backfills.status = 'FAILED' THEN backfills.rows_updated ELSE 0 END,
+			batches = CASE WHEN backfills.status = 'FAILED' THEN backfills.batches ELSE 0 END,
+			last_error = NULL,
+			started_at = CASE WHEN backfills.status = 'FAILED' THEN backfills.started_at ELSE NOW() END,
+			completed_at = NULL,
+			updated_at = NOW()
+		WHERE backfills.status <> 'RUNNING'
+		RETURNING `+backfillColumns, spec.key, spec.table)
+
+	b, err := scanBackfill(conn(ctx, r.db).QueryRow(ctx, query, name, batchSize), spec)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrBackfillRunning
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to start backfill", logger.String("backfill", name), logger.Err(err))
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("backfill started",
+		logger.String("backfill", name),
+		logger.Int64("last_id", b.LastID),
+		logger.Int64("max_id", b.MaxID),
+		logger.Int("batch_size", b.BatchSize),
+	)
+	return b, nil
+}
+
+func (r *backfillRepository) RunBackfillBatch(ctx context.Context, name string) (*entity.Backfill, error) {
+	spec, ok := findBackfill(name)
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SET LOCAL lock_timeout = '`+backfillLockTimeout+`'`); err != nil {
+		return nil, err
+	}
+
+	// Locking the progress row keeps two runs of the same backfill from
+	// applying the same range
+	b, err := scanBackfill(tx.QueryRow(ctx, `SELECT `+backfillColumns+` FROM backfills WHERE name = $1 FOR UPDATE`, name), spec)
+	if errors.Is(err, pgx.ErrNoRows) {
+		pending := pendingBackfill(spec)
+		return &pending, nil
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to lock backfill", logger.String("backfill", name), logger.Err(err))
+		return nil, err
+	}
+	if b.Status != entity.BackfillRunning {
+		return b, nil
+	}
+
+	from, to := b.LastID, b.LastID+int64(b.BatchSize)
+	if to > b.MaxID {
+		to = b.MaxID
+	}
+
+	var updated int64
+	if from < to {
+		tag, err := tx.Exec(ctx, spec.update, from, to)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to run backfill batch",
+				logger.String("backfill", name),
+				logger.Int64("from_id", from),
+				logger.Int64("to_id", to),
+				logger.Err(err),
+			)
+			return nil, err
+		}
+		updated = tag.RowsAffected()
+	}
+
+	query := `
+		UPDATE backfills
+		SET last_id = $2, rows_updated = rows_updated + $3, batches = batches + 1, last_error = NULL,
+			status = CASE WHEN $2 >= max_id THEN 'COMPLETED' ELSE status END,
+			completed_at = CASE WHEN $2 >= max_id THEN NOW() END,
+			updated_at = NOW()
+		WHERE name = $1
+		RETURNING ` + backfillColumns
+	b, err = scanBackfill(tx.QueryRow(ctx, query, name, to, updated), spec)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to checkpoint backfill", logger.String("backfill", name), logger.Err(err))
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit backfill batch", logger.String("backfill", name), logger.Err(err))
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Debug("backfill batch done",
+		logger.String("backfill", name),
+		logger.Int64("last_id", b.LastID),
+		logger.Int64("max_id", b.MaxID),
+		logger.Int64("rows_updated", updated),
+	)
+	return b, nil
+}
+
+func (r *backfillRepository) FailBackfill(ctx context.Context, name, lastError string) error {
+	query := `UPDATE backfills SET status = 'FAILED', last_error = $2, updated_at = NOW() WHERE name = $1 AND status = 'RUNNING'`
+	if _, err := r.db.Exec(ctx, query, name, lastError); err != nil {
+		logger.FromContext(ctx).Error("failed to mark backfill failed", logger.String("backfill", name), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Warn("backfill failed", logger.String("backfill", name), logger.String("error", lastError))
+	return nil
+}
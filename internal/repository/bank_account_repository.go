@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BankAccountRepository interface {
+	CreateBankAccount(ctx context.Context, account *entity.BankAccount) error
+	GetBankAccountByID(ctx context.Context, accountID int64) (*entity.BankAccount, error)
+	GetBankAccountsByOrganizer(ctx context.Context, organizerID int64) ([]entity.BankAccount, error)
+	GetDefaultBankAccount(ctx context.Context, organizerID int64) (*entity.BankAccount, error)
+	UpdateVerification(ctx context.Context, accountID int64, status string, attempts int) error
+	SetDefaultBankAccount(ctx context.Context, organizerID, accountID int64) error
+}
+
+type bankAccountRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBankAccountRepository(db *pgxpool.Pool) BankAccountRepository {
+	return &bankAccountRepository{db: db}
+}
+
+const bankAccountSelect = `
+	SELECT bank_account_id, organizer_id, bank_name, account_holder, account_number_enc, account_last4,
+		verification_method, status, COALESCE(deposit_amount_1, 0), COALESCE(deposit_amount_2, 0),
+		verify_attempts, is_default, verified_at, created_at, updated_at
+	FROM bank_accounts
+`
+
+func scanBankAccount(row pgx.Row) (*entity.BankAccount, error) {
+	var a entity.BankAccount
+	var deposit1, deposit2 float64
+	err := row.Scan(&a.ID, &a.OrganizerID, &a.BankName, &a.AccountHolder, &a.AccountNumberEnc, &a.AccountLast4,
+		&a.VerificationMethod, &a.Status, &deposit1, &deposit2,
+		&a.VerifyAttempts, &a.IsDefault, &a.VerifiedAt, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if deposit1 > 0 || deposit2 > 0 {
+		a.DepositAmounts = []float64{deposit1, deposit2}
+	}
+	return &a, nil
+}
+
+func (r *bankAccountRepository) CreateBankAccount(ctx context.Context, account *entity.BankAccount) error {
+	logger.FromContext(ctx).Debug("creating bank account", logger.Int64("organizer_id", account.OrganizerID))
+
+	var deposit1, deposit2 *float64
+	if len(account.DepositAmounts) == 2 {
+		deposit1, deposit2 = &account.DepositAmounts[0], &account.DepositAmounts[1]
+	}
+
+	query := `
+		INSERT INTO bank_accounts (organizer_id, bank_name, account_holder, account_number_enc, account_last4,
+			verification_method, status, deposit_amount_1, deposit_amount_2)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING bank_account_id, created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, account.OrganizerID, account.BankName, account.AccountHolder,
+		account.AccountNumberEnc, account.AccountLast4, account.VerificationMethod, account.Status,
+		deposit1, deposit2,
+	).Scan(&account.ID, &account.CreatedAt, &account.UpdatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create bank account", logger.Int64("organizer_id", account.OrganizerID), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("bank account created",
+		logger.Int64("bank_account_id", account.ID),
+		logger.Int64("organizer_id", account.OrganizerID),
+	)
+	return nil
+}
+
+func (r *bankAccountRepository) GetBankAccountByID(ctx context.Context, accountID int64) (*entity.BankAccount, error) {
+	logger.FromContext(ctx).Debug("fetching bank account", logger.Int64("bank_account_id", accountID))
+
+	account, err := scanBankAccount(r.db.QueryRow(ctx, bankAccountSelect+` WHERE bank_account_id = $1`, accountID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch bank account", logger.Int64("bank_account_id", accountID), logger.Err(err))
+		return nil, err
+	}
+	return account, nil
+}
+
+func (r *bankAccountRepository) GetBankAccountsByOrganizer(ctx context.Context, organizerID int64) ([]entity.BankAccount, error) {
+	logger.FromContext(ctx).Debug("fetching bank accounts", logger.Int64("organizer_id", organizerID))
+
+	rows, err := r.db.Query(ctx, bankAccountSelect+` WHERE organizer_id = $1 ORDER BY is_default DESC, created_at DESC`, organizerID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query bank accounts", logger.Int64("organizer_id", organizerID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []entity.BankAccount
+	for rows.Next() {
+		account, err := scanBankAccount(rows)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to scan bank account row", logger.Err(err))
+			return nil, err
+		}
+		accounts = append(accounts, *account)
+	}
+
+	return accounts, nil
+}
+
+// GetDefaultBankAccount returns the verified account payouts for the organizer go to.
+func (r *bankAccountRepository) GetDefaultBankAccount(ctx context.Context, organizerID int64) (*entity.BankAccount, error) {
+	logger.FromContext(ctx).Debug("fetching default bank account", logger.Int64("organizer_id", organizerID))
+
+	query := bankAccountSelect + ` WHERE organizer_id = $1 AND is_default AND status = 'VERIFIED'`
+	account, err := scanBankAccount(r.db.QueryRow(ctx, query, organizerID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch default bank account", logger.Int64("organizer_id", organizerID), logger.Err(err))
+		return nil, err
+	}
+	return account, nil
+}
+
+func (r *bankAccountRepository) UpdateVerification(ctx context.Context, accountID int64, status string, attempts int) error {
+	logger.FromContext(ctx).Debug("updating bank account verification",
+		logger.Int64("bank_account_id", accountID),
+		logger.String("status", status),
+		logger.Int("attempts", attempts),
+	)
+
+	query := `
+		UPDATE bank_accounts
+		SET status = $1, verify_attempts = $2,
+			verified_at = CASE WHEN $1 = 'VERIFIED' THEN NOW() ELSE verified_at END,
+			updated_at = NOW()
+		WHERE bank_account_id = $3
+	`
+	cmdTag, err := r.db.Exec(ctx, query, status, attempts, accountID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to update bank account verification", logger.Int64("bank_account_id", accountID), logger.Err(err))
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// SetDefaultBankAccount moves the default flag to the given verified account.
+func (r *bankAccountRepository) SetDefaultBankAccount(ctx context.Context, organizerID, accountID int64) error {
+	logger.FromContext(ctx).Debug("setting default bank account",
+		logger.Int64("organizer_id", organizerID),
+		logger.Int64("bank_account_id", accountID),
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE bank_accounts SET is_default = FALSE, updated_at = NOW() WHERE organizer_id = $1 AND is_default`, organizerID); err != nil {
+		logger.FromContext(ctx).Error("failed to clear default bank account", logger.Int64("organizer_id", organizerID), logger.Err(err))
+		return err
+	}
+
+	cmdTag, err := tx.Exec(ctx, `
+		UPDATE bank_accounts SET is_default = TRUE, updated_at = NOW()
+		WHERE bank_account_id = $1 AND organizer_id = $2 AND status = 'VERIFIED'
+	`, accountID, organizerID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to set default bank account", logger.Int64("bank_account_id", accountID), logger.Err(err))
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrBankAccountNotVerified
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit default bank account", logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("default bank account set",
+		logger.Int64("organizer_id", organizerID),
+		logger.Int64("bank_account_id", accountID),
+	)
+	return nil
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BookingModificationRepository interface {
+	// ApplySeatChange moves a paid booking from mod.FromSeatIDs to mod.ToSeatIDs
+	// in one transaction: the new seats are locked, the old ones released, the
+	// moved tickets get new codes and the booking total grows by the price
+	// difference. mod.PaymentMethod and mod.ExternalID are kept only when a
+	// difference is charged.
+	ApplySeatChange(ctx context.Context, mod *entity.BookingModification) error
+	GetModificationsByBookingID(ctx context.Context, bookingID int64) ([]entity.BookingModification, error)
+}
+
+type bookingModificationRepository struct {
+	db          *pgxpool.Pool
+	seatUpdates SeatUpdatePublisher
+}
+
+func NewBookingModificationRepository(db *pgxpool.Pool, seatUpdates SeatUpdatePublisher) BookingModificationRepository {
+	return &bookingModificationRepository{db: db, seatUpdates: seatUpdates}
+}
+
+func (r *bookingModificationRepository) ApplySeatChange(ctx context.Context, mod *entity.BookingModification) error {
+	logger.FromContext(ctx).Debug("applying seat change",
+		logger.Int64("booking_id", mod.BookingID),
+		logger.Int("seat_count", len(mod.ToSeatIDs)),
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	eventID, err := applySeatChange(ctx, tx, mod)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit seat change", logger.Err(err))
+		return err
+	}
+	publishSeatChange(ctx, r.seatUpdates, eventID, mod)
+
+	logger.FromContext(ctx).Info("seat change applied",
+		logger.Int64("booking_id", mod.BookingID),
+		logger.Int64("modification_id", mod.ID),
+		logger.Int("seat_count", len(mod.ToSeatIDs)),
+		logger.Float64("amount_charged", mod.AmountCharged),
+	)
+	return nil
+}
+
+// applySeatChange does the work of ApplySeatChange inside tx so callers can
+// combine it with their own writes, e.g. accepting an upgrade offer. It
+// returns the booking's event ID.
+func applySeatChange(ctx context.Context, tx pgx.Tx, mod *entity.BookingModification) (int64, error) {
+	// Lock the booking so concurrent changes or refunds serialize
+	var (
+		eventID    int64
+		status     entity.BookingStatus
+		variantID  *int64
+		multiplier = 1.0
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT event_id, status, total_amount, variant_id FROM booking WHERE booking_id = $1 FOR UPDATE
+	`, mod.BookingID).Scan(&eventID, &status, &mod.PreviousAmount, &variantID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to lock booking", logger.Int64("booking_id", mod.BookingID), logger.Err(err))
+		return 0, err
+	}
+	if status != entity.BookingPaid {
+		return 0, entity.ErrBookingNotPaid
+	}
+	if variantID != nil {
+		if err := tx.QueryRow(ctx, `SELECT price_multiplier FROM pricing_variants WHERE variant_id = $1`, *variantID).Scan(&multiplier); err != nil {
+			logger.FromContext(ctx).Error("failed to load pricing variant", logger.Int64("variant_id", *variantID), logger.Err(err))
+			return 0, err
+		}
+	}
+
+	// Every seat being given up must belong to the booking and not be used yet
+	itemIDs := make(map[int64]int64, len(mod.FromSeatIDs))
+	rows, err := tx.Query(ctx, `
+		SELECT id, seat_id, checked_in_at IS NOT NULL FROM booking_items
+		WHERE booking_id = $1 AND seat_id = ANY($2)
+		FOR UPDATE
+	`, mod.BookingID, mod.FromSeatIDs)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to lock booking items", logger.Int64("booking_id", mod.BookingID), logger.Err(err))
+		return 0, err
+	}
+	for rows.Next() {
+		var itemID, seatID int64
+		var checkedIn bool
+		if err := rows.Scan(&itemID, &seatID, &checkedIn); err != nil {
+			rows.Close()
+			logger.FromContext(ctx).Error("failed to scan booking item", logger.Err(err))
+			return 0, err
+		}
+		if checkedIn {
+			rows.Close()
+			return 0, entity.ErrTicketAlreadyUsed
+		}
+		itemIDs[seatID] = itemID
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(itemIDs) != len(mod.FromSeatIDs) {
+		return 0, entity.ErrInvalidSeatChange
+	}
+
+	var fromTotal float64
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(SUM(price), 0) FROM seats WHERE seat_id = ANY($1)`, mod.FromSeatIDs).Scan(&fromTotal); err != nil {
+		logger.FromContext(ctx).Error("failed to price current seats", logger.Err(err))
+		return 0, err
+	}
+
+	// Same pessimistic lock as a new booking: only free seats of this event flip
+	var toTotal float64
+	var locked int
+	err = tx.QueryRow(ctx, `
+		WITH locked AS (
+			UPDATE seats SET is_booked = TRUE
+			WHERE seat_id = ANY($1) AND event_id = $2 AND is_booked = FALSE
+			RETURNING COALESCE(price, 0) AS price
+		)
+		SELECT COUNT(*), COALESCE(SUM(price), 0) FROM locked
+	`, mod.ToSeatIDs, eventID).Scan(&locked, &toTotal)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to lock new seats", logger.Int64("booking_id", mod.BookingID), logger.Err(err))
+		return 0, err
+	}
+	if locked != len(mod.ToSeatIDs) {
+		logger.FromContext(ctx).Warn("seat change target not available", logger.Int64("booking_id", mod.BookingID))
+		return 0, entity.ErrSeatUnavailable
+	}
+
+	// The booking keeps the pricing variant it was bought under
+	diff := entity.SeatChangeCharge(fromTotal, toTotal, multiplier)
+	if diff < 0 {
+		return 0, entity.ErrSeatDowngrade
+	}
+	if diff > 0 && mod.PaymentMethod == "" {
+		return 0, entity.ErrInvalidPaymentMethod
+	}
+	if diff == 0 {
+		mod.PaymentMethod = ""
+		mod.ExternalID = ""
+	}
+	mod.AmountCharged = diff
+	mod.NewAmount = entity.AddAmounts(mod.PreviousAmount, diff)
+
+	if _, err := tx.Exec(ctx, `UPDATE seats SET is_booked = FALSE WHERE seat_id = ANY($1)`, mod.FromSeatIDs); err != nil {
+		logger.FromContext(ctx).Error("failed to release old seats", logger.Int64("booking_id", mod.BookingID), logger.Err(err))
+		return 0, err
+	}
+
+	// Moved seats get a new ticket code so the old QR no longer admits anyone
+	for i, fromSeatID := range mod.FromSeatIDs {
+		code, err := generateTicketCode()
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to generate ticket code", logger.Err(err))
+			return 0, err
+		}
+		_, err = tx.Exec(ctx, `UPDATE booking_items SET seat_id = $1, ticket_code = $2 WHERE id = $3`,
+			mod.ToSeatIDs[i], code, itemIDs[fromSeatID])
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to move booking item", logger.Int64("item_id", itemIDs[fromSeatID]), logger.Err(err))
+			return 0, err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE booking SET total_amount = $1 WHERE booking_id = $2`, mod.NewAmount, mod.BookingID); err != nil {
+		logger.FromContext(ctx).Error("failed to update booking total", logger.Int64("booking_id", mod.BookingID), logger.Err(err))
+		return 0, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO booking_modifications
+			(booking_id, from_seat_ids, to_seat_ids, previous_amount, new_amount, amount_charged, payment_method, external_id)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''))
+		RETURNING modification_id, created_at
+	`, mod.BookingID, mod.FromSeatIDs, mod.ToSeatIDs, mod.PreviousAmount, mod.NewAmount, mod.AmountCharged,
+		mod.PaymentMethod, mod.ExternalID).Scan(&mod.ID, &mod.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to record booking modification", logger.Int64("booking_id", mod.BookingID), logger.Err(err))
+		return 0, err
+	}
+	return eventID, nil
+}
+
+func (r *bookingModificationRepository) GetModificationsByBookingID(ctx context.Context, bookingID int64) ([]entity.BookingModification, error) {
+	logger.FromContext(ctx).Debug("fetching booking modifications", logger.Int64("booking_id", bookingID))
+
+	rows, err := r.db.Query(ctx, `
+		SELECT modification_id, booking_id, from_seat_ids, to_seat_ids, previous_amount, new_amount, amount_charged,
+			COALESCE(payment_method, ''), COALESCE(external_id, ''), created_at
+		FROM booking_modifications
+		WHERE booking_id = $1
+		ORDER BY created_at DESC
+	`, bookingID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query booking modifications", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mods []entity.BookingModification
+	for rows.Next() {
+		var m entity.BookingModification
+		if err := rows.Scan(&m.ID, &m.BookingID, &m.FromSeatIDs, &m.ToSeatIDs, &m.PreviousAmount, &m.NewAmount,
+			&m.AmountCharged, &m.PaymentMethod, &m.ExternalID, &m.CreatedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan booking modification row", logger.Err(err))
+			return nil, err
+		}
+		mods = append(mods, m)
+	}
+
+	return mods, rows.Err()
+}
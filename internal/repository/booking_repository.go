@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"ticres/internal/entity"
@@ -14,121 +15,520 @@ import (
 )
 
 type BookingRepository interface {
-	CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64) (int64, float64, error)
+	// CreateBooking reserves the seats and records the pricing variant the user
+	// was assigned, if any. The variant's multiplier is applied to the seat prices.
+	// The booking is PENDING until expiresAt.
+	CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64, variant *entity.PricingVariant, expiresAt time.Time) (int64, float64, error)
+	// CreateBestAvailableBooking books quantity adjacent free seats in one
+	// row of a seated event, the front-most that match pref, and returns
+	// them. It is entity.ErrNoAdjacentSeats when no such run is left.
+	CreateBestAvailableBooking(ctx context.Context, userID, eventID int64, quantity int, pref entity.SeatPreference, variant *entity.PricingVariant, expiresAt time.Time) (int64, float64, []entity.BookedSeat, error)
+	// CreateGeneralBooking takes quantity tickets off a general admission
+	// event's counter and creates one seatless booking item per ticket.
+	CreateGeneralBooking(ctx context.Context, userID, eventID int64, quantity int, variant *entity.PricingVariant, expiresAt time.Time) (int64, float64, error)
 	GetBookingByID(ctx context.Context, bookingID int64) (*entity.Booking, error)
 	GetBookingsByEventID(ctx context.Context, eventID int64) ([]entity.Booking, error)
 	GetBookingsByUserID(ctx context.Context, userID int64) ([]entity.BookingWithDetails, error)
-	GetAllBookings(ctx context.Context, status, sortBy, sortOrder string, page, limit int) ([]entity.BookingWithDetails, int, error)
-	GetBookingsWithDetailsByEventID(ctx context.Context, eventID int64, status, sortBy, sortOrder string) ([]entity.BookingWithDetails, error)
-	UpdateBookingStatus(ctx context.Context, bookingID int64, status string) error
+	// GetBookingDetail returns the booking with its event, seats, latest
+	// payment and latest refund or refund request.
+	GetBookingDetail(ctx context.Context, bookingID int64) (*entity.BookingDetail, error)
+	GetAllBookings(ctx context.Context, status entity.BookingStatus, sortBy, sortOrder string, page, limit int) ([]entity.BookingWithDetails, int, error)
+	// GetAllBookingsAfter returns up to limit bookings that follow the after
+	// cursor in the given order, and the cursor of the last one when more
+	// follow. A nil after starts at the top.
+	GetAllBookingsAfter(ctx context.Context, status entity.BookingStatus, sortBy, sortOrder string, after *entity.PageCursor, limit int) ([]entity.BookingWithDetails, *entity.PageCursor, error)
+	GetBookingsWithDetailsByEventID(ctx context.Context, eventID int64, status entity.BookingStatus, sortBy, sortOrder string) ([]entity.BookingWithDetails, error)
+	// GetEventBookingSummary totals the event's bookings, payments, refunds
+	// and PAID tickets per tier. It returns ErrNotFound for an unknown event.
+	GetEventBookingSummary(ctx context.Context, eventID int64) (*entity.EventBookingSummary, error)
+	// StreamEventBookings passes every booking of the event to fn in booking
+	// order, reading rows as fn consumes them.
+	StreamEventBookings(ctx context.Context, eventID int64, fn func(entity.BookingExportRow) error) error
+	UpdateBookingStatus(ctx context.Context, bookingID int64, status entity.BookingStatus) error
+	// ExtendBookingExpiry moves a PENDING booking's payment deadline out to
+	// expiresAt, never earlier, and returns the resulting deadline.
+	ExtendBookingExpiry(ctx context.Context, bookingID int64, expiresAt time.Time) (time.Time, error)
+	// HoldForGateway extends a PENDING booking's deadline like
+	// ExtendBookingExpiry and marks it as waiting for the payment gateway to
+	// come back.
+	HoldForGateway(ctx context.Context, bookingID int64, expiresAt time.Time) (time.Time, error)
+	// ClaimGatewayWaiters unmarks up to limit PENDING bookings marked waiting
+	// for the gateway before recoveredAt, moves their deadline out to at
+	// least expiresAt and returns them with the new deadline. Claimed
+	// bookings are skipped by concurrent callers.
+	ClaimGatewayWaiters(ctx context.Context, recoveredAt, expiresAt time.Time, limit int) ([]entity.AffectedBooking, error)
+	// ExpireLapsedBookings marks up to limit PENDING bookings whose payment
+	// deadline passed before the given time EXPIRED and returns their IDs.
+	ExpireLapsedBookings(ctx context.Context, before time.Time, limit int) ([]int64, error)
+	// ReleaseSeatsByBookingID frees the booking's seats, or returns its
+	// general admission tickets to the event counter exactly once.
 	ReleaseSeatsByBookingID(ctx context.Context, bookingID int64) error
+	// GetOverlappingPaidBookings returns the user's PAID bookings for other,
+	// non-cancelled events starting less than window before or after eventID.
+	GetOverlappingPaidBookings(ctx context.Context, userID, eventID int64, window time.Duration) ([]entity.BookingConflict, error)
+	// GetTicketAllowance counts the user's tickets to the event in PAID or
+	// PENDING bookings and reads the event's per-user cap. Inside a
+	// transaction it first takes a lock on the (user, event) pair, held
+	// until commit, so concurrent bookings by the same user are counted one
+	// after the other.
+	GetTicketAllowance(ctx context.Context, userID, eventID int64) (*entity.TicketAllowance, error)
+	// GetAffectedBooking reads a booking for an admin dry run, without
+	// locking it.
+	GetAffectedBooking(ctx context.Context, bookingID int64) (*entity.AffectedBooking, error)
+	// GetLapsedBookings returns PENDING bookings whose deadline passed
+	// before the given time, oldest deadline first.
+	GetLapsedBookings(ctx context.Context, before time.Time) ([]entity.AffectedBooking, error)
+	// GetCustomerSummary totals the user's bookings, tickets, spend and
+	// refunds, or returns ErrNotFound for an unknown user.
+	GetCustomerSummary(ctx context.Context, userID int64) (*entity.CustomerSummary, error)
 }
 
 type bookingRepository struct {
 	db *pgxpool.Pool
+	// replica serves admin listings and exports, which tolerate lag
+	replica     *pgxpool.Pool
+	seatUpdates SeatUpdatePublisher
 }
 
-func NewBookingRepository(db *pgxpool.Pool) BookingRepository {
-	return &bookingRepository{db: db}
+func NewBookingRepository(db, replica *pgxpool.Pool, seatUpdates SeatUpdatePublisher) BookingRepository {
+	return &bookingRepository{db: db, replica: replica, seatUpdates: seatUpdates}
 }
 
-func (r *bookingRepository) CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64) (int64, float64, error) {
-	logger.Debug("creating booking",
+func (r *bookingRepository) CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64, variant *entity.PricingVariant, expiresAt time.Time) (int64, float64, error) {
+	logger.FromContext(ctx).Debug("creating booking",
 		logger.Int64("user_id", userID),
 		logger.Int64("event_id", eventID),
 		logger.Int("seat_count", len(seatIDs)),
 	)
 
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
-		logger.Error("failed to begin transaction", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
 		return 0, 0, err
 	}
 	defer tx.Rollback(ctx)
 
-	// Calculate total amount from seat prices
-	var totalAmount float64
-	queryPrice := `SELECT COALESCE(SUM(price), 0) FROM seats WHERE seat_id = ANY($1)`
-	err = tx.QueryRow(ctx, queryPrice, seatIDs).Scan(&totalAmount)
+	// One statement locks all the seats, in seat_id order, so concurrent
+	// bookings of overlapping seats queue behind each other instead of
+	// deadlocking. Seats in a ticket tier sell at the tier price; others at
+	// their own price. Another event's seats are left out, so they count as
+	// unavailable below.
+	rows, err := tx.Query(ctx, `
+		SELECT s.seat_id, s.is_booked, COALESCE(t.price, s.price, 0)
+		FROM seats s
+		LEFT JOIN ticket_tiers t ON t.tier_id = s.tier_id
+		WHERE s.seat_id = ANY($1) AND s.event_id = $2
+		ORDER BY s.seat_id
+		FOR UPDATE OF s
+	`, seatIDs, eventID)
 	if err != nil {
-		logger.Error("failed to calculate total amount", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to lock seats", logger.Err(err))
 		return 0, 0, err
 	}
+	prices := make([]float64, 0, len(seatIDs))
+	var unavailable []int64
+	for rows.Next() {
+		var seatID int64
+		var isBooked bool
+		var price float64
+		if err := rows.Scan(&seatID, &isBooked, &price); err != nil {
+			rows.Close()
+			logger.FromContext(ctx).Error("failed to scan seat", logger.Err(err))
+			return 0, 0, err
+		}
+		if isBooked {
+			unavailable = append(unavailable, seatID)
+		}
+		prices = append(prices, price)
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("failed to lock seats", logger.Err(err))
+		return 0, 0, err
+	}
+	// Unknown, repeated and other events' seat IDs leave fewer rows than
+	// were asked for
+	if len(unavailable) > 0 || len(prices) != len(seatIDs) {
+		logger.FromContext(ctx).Warn("seats not available",
+			logger.Int64("event_id", eventID),
+			logger.Any("seat_ids", unavailable),
+			logger.Int("found", len(prices)),
+			logger.Int("requested", len(seatIDs)),
+		)
+		return 0, 0, entity.ErrSeatUnavailable
+	}
 
-	// Set expiry to 15 minutes from now
-	expiresAt := time.Now().Add(15 * time.Minute)
-
-	var bookingID int64
-	queryBooking := `
-		INSERT INTO booking (user_id, event_id, status, total_amount, expires_at, created_at)
-		VALUES ($1, $2, 'PENDING', $3, $4, NOW())
-		RETURNING booking_id
-	`
-	err = tx.QueryRow(ctx, queryBooking, userID, eventID, totalAmount, expiresAt).Scan(&bookingID)
+	bookingID, totalAmount, err := insertSeatBooking(ctx, tx, userID, eventID, seatIDs, prices, variant, expiresAt)
 	if err != nil {
-		logger.Error("failed to insert booking", logger.Err(err))
 		return 0, 0, err
 	}
 
-	queryLockSeat := `UPDATE seats SET is_booked = True WHERE seat_id = $1 AND is_booked = False`
-	queryInsertItem := `INSERT INTO booking_items (booking_id, seat_id) VALUES ($1, $2)`
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit booking transaction", logger.Err(err))
+		return 0, 0, err
+	}
+	afterCommit(ctx, func() {
+		r.seatUpdates.PublishSeatUpdate(ctx, entity.SeatUpdate{EventID: eventID, SeatIDs: seatIDs, IsBooked: true})
+	})
 
-	for _, seatID := range seatIDs {
-		cmdTag, err := tx.Exec(ctx, queryLockSeat, seatID)
+	logger.FromContext(ctx).Info("booking created successfully",
+		logger.Int64("booking_id", bookingID),
+		logger.Int64("user_id", userID),
+		logger.Int64("event_id", eventID),
+		logger.Int("seat_count", len(seatIDs)),
+		logger.Float64("total_amount", totalAmount),
+	)
+	return bookingID, totalAmount, nil
+}
+
+// bestAvailableCandidates caps the runs of seats tried per best available
+// booking. Concurrent buyers skip the runs others are taking, so each needs
+// a few to fall back on.
+const bestAvailableCandidates = 20
+
+// bestAvailableQuery finds runs of quantity free seats without locking them.
+// Seats are adjacent when they share a section and row and their columns
+// follow each other; seats not placed on a layout go by seat_id, the order
+// they were numbered in. Long runs are cut into several candidates, and the
+// runs holding the lowest seat_id, the front of the venue, come first.
+const bestAvailableQuery = `
+	WITH free AS (
+		SELECT s.seat_id,
+			COALESCE(s.category, '') AS section,
+			COALESCE(s.row_label, '') AS row_label,
+			COALESCE(s.col_number, s.seat_id) AS pos
+		FROM seats s
+		WHERE s.event_id = $1 AND s.is_booked = FALSE
+			AND ($3::bigint IS NULL OR s.tier_id = $3)
+			AND ($4::text = '' OR COALESCE(s.category, '') = $4)
+	), runs AS (
+		SELECT array_agg(seat_id ORDER BY pos) AS seat_ids, MIN(seat_id) AS first_seat
+		FROM (
+			SELECT seat_id, section, row_label, pos,
+				pos - ROW_NUMBER() OVER (PARTITION BY section, row_label ORDER BY pos) AS run
+			FROM free
+		) f
+		GROUP BY section, row_label, run
+		HAVING COUNT(*) >= $2::int
+	)
+	SELECT r.seat_ids[g * $2::int + 1 : (g + 1) * $2::int]
+	FROM runs r, generate_series(0, cardinality(r.seat_ids) / $2::int - 1) g
+	ORDER BY r.first_seat, g
+	LIMIT $5
+`
+
+func (r *bookingRepository) CreateBestAvailableBooking(ctx context.Context, userID, eventID int64, quantity int, pref entity.SeatPreference, variant *entity.PricingVariant, expiresAt time.Time) (int64, float64, []entity.BookedSeat, error) {
+	logger.FromContext(ctx).Debug("creating best available booking",
+		logger.Int64("user_id", userID),
+		logger.Int64("event_id", eventID),
+		logger.Int("quantity", quantity),
+	)
+
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return 0, 0, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, bestAvailableQuery, eventID, quantity, pref.TierID, pref.Section, bestAvailableCandidates)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find best available seats", logger.Int64("event_id", eventID), logger.Err(err))
+		return 0, 0, nil, err
+	}
+	var candidates [][]int64
+	for rows.Next() {
+		var candidate []int64
+		if err := rows.Scan(&candidate); err != nil {
+			rows.Close()
+			logger.FromContext(ctx).Error("failed to scan best available seats", logger.Int64("event_id", eventID), logger.Err(err))
+			return 0, 0, nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("failed to find best available seats", logger.Int64("event_id", eventID), logger.Err(err))
+		return 0, 0, nil, err
+	}
+
+	var seats []entity.BookedSeat
+	for _, candidate := range candidates {
+		seats, err = lockFreeSeats(ctx, tx, candidate)
 		if err != nil {
-			logger.Error("failed to lock seat",
-				logger.Int64("seat_id", seatID),
-				logger.Err(err),
-			)
-			return 0, 0, err
+			return 0, 0, nil, err
 		}
-		if cmdTag.RowsAffected() == 0 {
-			logger.Warn("seat not available",
-				logger.Int64("seat_id", seatID),
-				logger.Int64("booking_id", bookingID),
-			)
-			return 0, 0, errors.New("seat not available or already booked")
+		if seats != nil {
+			break
 		}
-		_, err = tx.Exec(ctx, queryInsertItem, bookingID, seatID)
-		if err != nil {
-			logger.Error("failed to insert booking item", logger.Err(err))
+	}
+	if seats == nil {
+		logger.FromContext(ctx).Warn("no adjacent seats available",
+			logger.Int64("event_id", eventID),
+			logger.Int("quantity", quantity),
+			logger.Int("candidates", len(candidates)),
+		)
+		return 0, 0, nil, entity.ErrNoAdjacentSeats
+	}
+
+	seatIDs := make([]int64, len(seats))
+	prices := make([]float64, len(seats))
+	for i, seat := range seats {
+		seatIDs[i], prices[i] = seat.SeatID, seat.Price
+	}
+	bookingID, totalAmount, err := insertSeatBooking(ctx, tx, userID, eventID, seatIDs, prices, variant, expiresAt)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit booking transaction", logger.Err(err))
+		return 0, 0, nil, err
+	}
+	afterCommit(ctx, func() {
+		r.seatUpdates.PublishSeatUpdate(ctx, entity.SeatUpdate{EventID: eventID, SeatIDs: seatIDs, IsBooked: true})
+	})
+
+	logger.FromContext(ctx).Info("best available booking created",
+		logger.Int64("booking_id", bookingID),
+		logger.Int64("event_id", eventID),
+		logger.Any("seat_ids", seatIDs),
+		logger.Float64("total_amount", totalAmount),
+	)
+	return bookingID, totalAmount, seats, nil
+}
+
+// lockFreeSeats locks the seats if every one of them is still free and not
+// being taken by another booking, skipping rows others have locked rather
+// than waiting on them. It returns nil, holding no locks, when any seat is
+// gone: the attempt runs in a savepoint that is rolled back.
+func lockFreeSeats(ctx context.Context, tx pgx.Tx, seatIDs []int64) ([]entity.BookedSeat, error) {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sp.Rollback(ctx)
+
+	rows, err := sp.Query(ctx, `
+		SELECT s.seat_id, s.seat_number, COALESCE(s.category, ''), COALESCE(t.price, s.price, 0)
+		FROM seats s
+		LEFT JOIN ticket_tiers t ON t.tier_id = s.tier_id
+		WHERE s.seat_id = ANY($1) AND s.is_booked = FALSE
+		ORDER BY s.seat_id
+		FOR UPDATE OF s SKIP LOCKED
+	`, seatIDs)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to lock seats", logger.Err(err))
+		return nil, err
+	}
+	var seats []entity.BookedSeat
+	for rows.Next() {
+		var seat entity.BookedSeat
+		if err := rows.Scan(&seat.SeatID, &seat.SeatNumber, &seat.Category, &seat.Price); err != nil {
+			rows.Close()
+			logger.FromContext(ctx).Error("failed to scan seat", logger.Err(err))
+			return nil, err
+		}
+		seats = append(seats, seat)
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("failed to lock seats", logger.Err(err))
+		return nil, err
+	}
+	if len(seats) < len(seatIDs) {
+		return nil, nil
+	}
+
+	if err := sp.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return seats, nil
+}
+
+func (r *bookingRepository) CreateGeneralBooking(ctx context.Context, userID, eventID int64, quantity int, variant *entity.PricingVariant, expiresAt time.Time) (int64, float64, error) {
+	logger.FromContext(ctx).Debug("creating general admission booking",
+		logger.Int64("user_id", userID),
+		logger.Int64("event_id", eventID),
+		logger.Int("quantity", quantity),
+	)
+
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return 0, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// The conditional decrement is the lock: concurrent buyers can never take
+	// the counter below zero
+	var price float64
+	err = tx.QueryRow(ctx, `
+		UPDATE events SET ga_remaining = ga_remaining - $2
+		WHERE event_id = $1 AND admission_mode = 'general' AND ga_remaining >= $2
+		RETURNING COALESCE(general_price, 0)
+	`, eventID, quantity).Scan(&price)
+	if err != nil {
+		if err != pgx.ErrNoRows {
+			logger.FromContext(ctx).Error("failed to reserve general admission tickets", logger.Int64("event_id", eventID), logger.Err(err))
 			return 0, 0, err
 		}
+		var mode string
+		if err := tx.QueryRow(ctx, `SELECT admission_mode FROM events WHERE event_id = $1`, eventID).Scan(&mode); err != nil {
+			if err == pgx.ErrNoRows {
+				return 0, 0, entity.ErrNotFound
+			}
+			return 0, 0, err
+		}
+		if mode != entity.AdmissionGeneral {
+			return 0, 0, entity.ErrNotGeneralAdmission
+		}
+		logger.FromContext(ctx).Warn("general admission sold out",
+			logger.Int64("event_id", eventID),
+			logger.Int("quantity", quantity),
+		)
+		return 0, 0, entity.ErrSoldOut
+	}
+
+	var variantID *int64
+	var multiplier float64
+	if variant != nil {
+		variantID = &variant.ID
+		multiplier = variant.PriceMultiplier
+	}
+	totalAmount := entity.QuoteGeneralAdmission(price, quantity, multiplier).Total
+
+	var bookingID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO booking (user_id, event_id, status, total_amount, expires_at, variant_id, ga_quantity, created_at)
+		VALUES ($1, $2, 'PENDING', $3, $4, $5, $6, NOW())
+		RETURNING booking_id
+	`, userID, eventID, totalAmount, expiresAt, variantID, quantity).Scan(&bookingID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to insert booking", logger.Err(err))
+		return 0, 0, err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO booking_items (booking_id, seat_id)
+		SELECT $1, NULL FROM generate_series(1, $2)
+	`, bookingID, quantity); err != nil {
+		logger.FromContext(ctx).Error("failed to insert booking items", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return 0, 0, err
+	}
+
+	if err := checkSalesRelease(ctx, tx, eventID); err != nil {
+		return 0, 0, err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		logger.Error("failed to commit booking transaction", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to commit booking transaction", logger.Err(err))
 		return 0, 0, err
 	}
 
-	logger.Info("booking created successfully",
+	logger.FromContext(ctx).Info("general admission booking created",
 		logger.Int64("booking_id", bookingID),
-		logger.Int64("user_id", userID),
 		logger.Int64("event_id", eventID),
-		logger.Int("seat_count", len(seatIDs)),
+		logger.Int("quantity", quantity),
 		logger.Float64("total_amount", totalAmount),
 	)
 	return bookingID, totalAmount, nil
 }
 
+// insertSeatBooking books seats already locked by the transaction: it
+// creates the PENDING booking priced from the seats' prices, marks the seats
+// booked and adds one booking item per seat.
+func insertSeatBooking(ctx context.Context, tx pgx.Tx, userID, eventID int64, seatIDs []int64, prices []float64, variant *entity.PricingVariant, expiresAt time.Time) (int64, float64, error) {
+	var variantID *int64
+	var multiplier float64
+	if variant != nil {
+		variantID = &variant.ID
+		multiplier = variant.PriceMultiplier
+	}
+	totalAmount := entity.QuoteTickets(prices, multiplier).Total
+
+	var bookingID int64
+	queryBooking := `
+		INSERT INTO booking (user_id, event_id, status, total_amount, expires_at, variant_id, created_at)
+		VALUES ($1, $2, 'PENDING', $3, $4, $5, NOW())
+		RETURNING booking_id
+	`
+	err := tx.QueryRow(ctx, queryBooking, userID, eventID, totalAmount, expiresAt, variantID).Scan(&bookingID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to insert booking", logger.Err(err))
+		return 0, 0, err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE seats SET is_booked = TRUE WHERE seat_id = ANY($1)`, seatIDs); err != nil {
+		logger.FromContext(ctx).Error("failed to book seats", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return 0, 0, err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO booking_items (booking_id, seat_id)
+		SELECT $1, unnest($2::bigint[])
+	`, bookingID, seatIDs); err != nil {
+		logger.FromContext(ctx).Error("failed to insert booking items", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return 0, 0, err
+	}
+
+	if err := checkSalesRelease(ctx, tx, eventID); err != nil {
+		return 0, 0, err
+	}
+	return bookingID, totalAmount, nil
+}
+
+// checkSalesRelease rejects a booking that takes the event past the tickets
+// its waves have released so far. It runs after the booking's items are
+// inserted, so they count as taken, and locks the event row first so that
+// concurrent bookings of the event are counted one after the other.
+func checkSalesRelease(ctx context.Context, tx pgx.Tx, eventID int64) error {
+	var waves int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM event_sales_waves WHERE event_id = $1`, eventID).Scan(&waves); err != nil {
+		logger.FromContext(ctx).Error("failed to count sales waves", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if waves == 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM events WHERE event_id = $1 FOR UPDATE`, eventID); err != nil {
+		logger.FromContext(ctx).Error("failed to lock event", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	release, err := scanSalesRelease(tx.QueryRow(ctx, salesReleaseQuery, eventID))
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to fetch sales release", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if release == nil || release.Taken <= release.Released {
+		return nil
+	}
+
+	logger.FromContext(ctx).Warn("released tickets sold out",
+		logger.Int64("event_id", eventID),
+		logger.Int("released", release.Released),
+	)
+	if release.NextReleaseAt != nil {
+		return fmt.Errorf("%w; more go on sale at %s", entity.ErrSalesWaveSoldOut, release.NextReleaseAt.Format(time.RFC3339))
+	}
+	return entity.ErrSalesWaveSoldOut
+}
+
 func (r *bookingRepository) GetBookingByID(ctx context.Context, bookingID int64) (*entity.Booking, error) {
-	logger.Debug("fetching booking by ID", logger.Int64("booking_id", bookingID))
+	logger.FromContext(ctx).Debug("fetching booking by ID", logger.Int64("booking_id", bookingID))
 
 	query := `
-		SELECT booking_id, user_id, event_id, status, COALESCE(total_amount, 0), expires_at, created_at
+		SELECT booking_id, user_id, event_id, status, COALESCE(total_amount, 0), expires_at, variant_id, created_at
 		FROM booking
 		WHERE booking_id = $1
 	`
 
 	var b entity.Booking
 	err := r.db.QueryRow(ctx, query, bookingID).Scan(
-		&b.ID, &b.UserID, &b.EventID, &b.Status, &b.TotalAmount, &b.ExpiresAt, &b.CreatedAt,
+		&b.ID, &b.UserID, &b.EventID, &b.Status, &b.TotalAmount, &b.ExpiresAt, &b.VariantID, &b.CreatedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, entity.ErrNotFound
 		}
-		logger.Error("failed to fetch booking", logger.Int64("booking_id", bookingID), logger.Err(err))
+		logger.FromContext(ctx).Error("failed to fetch booking", logger.Int64("booking_id", bookingID), logger.Err(err))
 		return nil, err
 	}
 
@@ -136,7 +536,7 @@ func (r *bookingRepository) GetBookingByID(ctx context.Context, bookingID int64)
 }
 
 func (r *bookingRepository) GetBookingsByEventID(ctx context.Context, eventID int64) ([]entity.Booking, error) {
-	logger.Debug("fetching bookings by event ID", logger.Int64("event_id", eventID))
+	logger.FromContext(ctx).Debug("fetching bookings by event ID", logger.Int64("event_id", eventID))
 
 	query := `
 		SELECT booking_id, user_id, event_id, status, created_at
@@ -145,7 +545,7 @@ func (r *bookingRepository) GetBookingsByEventID(ctx context.Context, eventID in
 	`
 	rows, err := r.db.Query(ctx, query, eventID)
 	if err != nil {
-		logger.Error("failed to query bookings by event ID", logger.Int64("event_id", eventID), logger.Err(err))
+		logger.FromContext(ctx).Error("failed to query bookings by event ID", logger.Int64("event_id", eventID), logger.Err(err))
 		return nil, err
 	}
 	defer rows.Close()
@@ -154,13 +554,13 @@ func (r *bookingRepository) GetBookingsByEventID(ctx context.Context, eventID in
 	for rows.Next() {
 		var b entity.Booking
 		if err := rows.Scan(&b.ID, &b.UserID, &b.EventID, &b.Status, &b.CreatedAt); err != nil {
-			logger.Error("failed to scan booking row", logger.Err(err))
+			logger.FromContext(ctx).Error("failed to scan booking row", logger.Err(err))
 			return nil, err
 		}
 		bookings = append(bookings, b)
 	}
 
-	logger.Debug("bookings fetched by event ID",
+	logger.FromContext(ctx).Debug("bookings fetched by event ID",
 		logger.Int64("event_id", eventID),
 		logger.Int("count", len(bookings)),
 	)
@@ -168,7 +568,7 @@ func (r *bookingRepository) GetBookingsByEventID(ctx context.Context, eventID in
 }
 
 func (r *bookingRepository) GetBookingsByUserID(ctx context.Context, userID int64) ([]entity.BookingWithDetails, error) {
-	logger.Debug("fetching bookings by user ID", logger.Int64("user_id", userID))
+	logger.FromContext(ctx).Debug("fetching bookings by user ID", logger.Int64("user_id", userID))
 
 	query := `
 		SELECT b.booking_id, b.user_id, u.name, u.email, b.event_id, e.name, b.status, b.created_at
@@ -180,7 +580,7 @@ func (r *bookingRepository) GetBookingsByUserID(ctx context.Context, userID int6
 	`
 	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
-		logger.Error("failed to query bookings by user ID", logger.Int64("user_id", userID), logger.Err(err))
+		logger.FromContext(ctx).Error("failed to query bookings by user ID", logger.Int64("user_id", userID), logger.Err(err))
 		return nil, err
 	}
 	defer rows.Close()
@@ -189,22 +589,137 @@ func (r *bookingRepository) GetBookingsByUserID(ctx context.Context, userID int6
 	for rows.Next() {
 		var b entity.BookingWithDetails
 		if err := rows.Scan(&b.ID, &b.UserID, &b.UserName, &b.UserEmail, &b.EventID, &b.EventName, &b.Status, &b.CreatedAt); err != nil {
-			logger.Error("failed to scan booking row", logger.Err(err))
+			logger.FromContext(ctx).Error("failed to scan booking row", logger.Err(err))
 			return nil, err
 		}
 		bookings = append(bookings, b)
 	}
 
-	logger.Debug("bookings fetched by user ID",
+	logger.FromContext(ctx).Debug("bookings fetched by user ID",
 		logger.Int64("user_id", userID),
 		logger.Int("count", len(bookings)),
 	)
 	return bookings, nil
 }
 
-func (r *bookingRepository) GetAllBookings(ctx context.Context, status, sortBy, sortOrder string, page, limit int) ([]entity.BookingWithDetails, int, error) {
-	logger.Debug("fetching all bookings",
-		logger.String("status", status),
+func (r *bookingRepository) GetBookingDetail(ctx context.Context, bookingID int64) (*entity.BookingDetail, error) {
+	logger.FromContext(ctx).Debug("fetching booking detail", logger.Int64("booking_id", bookingID))
+
+	query := `
+		SELECT b.booking_id, b.user_id, b.event_id, e.name, e.date, COALESCE(e.location, ''), b.status,
+			COALESCE(b.total_amount, 0), b.expires_at, b.created_at,
+			t.payment_id, t.amount, t.payment_method, t.transaction_date, t.external_id, t.status, t.invoice_number,
+			r.refund_id, r.amount, r.refund_date, r.reason, r.status, r.requested_at, r.review_note
+		FROM booking b
+		JOIN events e ON e.event_id = b.event_id
+		LEFT JOIN LATERAL (
+			SELECT payment_id, amount, COALESCE(payment_method, '') AS payment_method, transaction_date,
+				COALESCE(external_id, '') AS external_id, status,
+				COALESCE((SELECT invoice_number FROM invoices i WHERE i.payment_id = transactions.payment_id), '') AS invoice_number
+			FROM transactions
+			WHERE booking_id = b.booking_id
+			ORDER BY transaction_date DESC, payment_id DESC
+			LIMIT 1
+		) t ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT refund_id, COALESCE(amount, 0) AS amount, refund_date, reason, status, requested_at,
+				COALESCE(review_note, '') AS review_note
+			FROM refund
+			WHERE booking_id = b.booking_id
+			ORDER BY refund_id DESC
+			LIMIT 1
+		) r ON TRUE
+		WHERE b.booking_id = $1
+	`
+
+	var (
+		d                                    entity.BookingDetail
+		paymentID, refundID                  *int64
+		paymentAmount, refundAmount          *float64
+		paymentMethod, externalID, paymentSt *string
+		invoiceNumber, refundReason          *string
+		paidAt, refundDate, requestedAt      *time.Time
+		refundSt, refundReview               *string
+	)
+	err := r.db.QueryRow(ctx, query, bookingID).Scan(
+		&d.ID, &d.UserID, &d.EventID, &d.EventName, &d.EventDate, &d.EventLocation, &d.Status,
+		&d.TotalAmount, &d.ExpiresAt, &d.CreatedAt,
+		&paymentID, &paymentAmount, &paymentMethod, &paidAt, &externalID, &paymentSt, &invoiceNumber,
+		&refundID, &refundAmount, &refundDate, &refundReason, &refundSt, &requestedAt, &refundReview,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch booking detail", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return nil, err
+	}
+
+	if paymentID != nil {
+		d.Transaction = &entity.Transaction{
+			ID:              *paymentID,
+			Amount:          *paymentAmount,
+			PaymentMethod:   *paymentMethod,
+			BookingID:       d.ID,
+			TransactionDate: *paidAt,
+			ExternalID:      *externalID,
+			Status:          entity.PaymentStatus(*paymentSt),
+			InvoiceNumber:   *invoiceNumber,
+		}
+	}
+	if refundID != nil {
+		d.Refund = &entity.Refund{
+			ID:          *refundID,
+			BookingID:   d.ID,
+			Amount:      *refundAmount,
+			RefundDate:  refundDate,
+			Reason:      *refundReason,
+			Status:      *refundSt,
+			RequestedAt: requestedAt,
+			ReviewNote:  *refundReview,
+		}
+	}
+
+	// Tier prices override seat prices, as when the booking was priced
+	seatQuery := `
+		SELECT COALESCE(bi.seat_id, 0), COALESCE(s.seat_number, ''), COALESCE(s.category, ''),
+			ROUND(COALESCE(t.price, s.price, e.general_price, 0) * COALESCE(v.price_multiplier, 1), 2)::float8
+		FROM booking_items bi
+		JOIN booking b ON b.booking_id = bi.booking_id
+		JOIN events e ON e.event_id = b.event_id
+		LEFT JOIN seats s ON s.seat_id = bi.seat_id
+		LEFT JOIN ticket_tiers t ON t.tier_id = s.tier_id
+		LEFT JOIN pricing_variants v ON v.variant_id = b.variant_id
+		WHERE bi.booking_id = $1
+		ORDER BY bi.id
+	`
+	rows, err := r.db.Query(ctx, seatQuery, bookingID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query booking seats", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	d.Seats = []entity.BookedSeat{}
+	for rows.Next() {
+		var seat entity.BookedSeat
+		if err := rows.Scan(&seat.SeatID, &seat.SeatNumber, &seat.Category, &seat.Price); err != nil {
+			logger.FromContext(ctx).Error("failed to scan booking seat row", logger.Err(err))
+			return nil, err
+		}
+		d.Seats = append(d.Seats, seat)
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("failed to iterate booking seats", logger.Err(err))
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+func (r *bookingRepository) GetAllBookings(ctx context.Context, status entity.BookingStatus, sortBy, sortOrder string, page, limit int) ([]entity.BookingWithDetails, int, error) {
+	logger.FromContext(ctx).Debug("fetching all bookings",
+		logger.String("status", string(status)),
 		logger.String("sort_by", sortBy),
 		logger.String("sort_order", sortOrder),
 		logger.Int("page", page),
@@ -228,37 +743,27 @@ func (r *bookingRepository) GetAllBookings(ctx context.Context, status, sortBy,
 
 	countQuery := "SELECT COUNT(*) " + baseQuery + whereClause
 	var total int
-	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err := r.replica.QueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
-		logger.Error("failed to count bookings", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to count bookings", logger.Err(err))
 		return nil, 0, err
 	}
 
-	validSortFields := map[string]string{
-		"created_at": "b.created_at",
-		"status":     "b.status",
-	}
-	sortField, ok := validSortFields[sortBy]
-	if !ok {
-		sortField = "b.created_at"
-	}
-	if sortOrder != "asc" && sortOrder != "desc" {
-		sortOrder = "desc"
-	}
+	_, sortField, sortOrder := bookingListOrder(sortBy, sortOrder)
 
 	offset := (page - 1) * limit
 	dataQuery := fmt.Sprintf(`
 		SELECT b.booking_id, b.user_id, u.name, u.email, b.event_id, e.name, b.status, b.created_at
 		%s%s
-		ORDER BY %s %s
+		ORDER BY %s %s, b.booking_id %s
 		LIMIT $%d OFFSET $%d
-	`, baseQuery, whereClause, sortField, sortOrder, argIndex, argIndex+1)
+	`, baseQuery, whereClause, sortField, sortOrder, sortOrder, argIndex, argIndex+1)
 
 	args = append(args, limit, offset)
 
-	rows, err := r.db.Query(ctx, dataQuery, args...)
+	rows, err := r.replica.Query(ctx, dataQuery, args...)
 	if err != nil {
-		logger.Error("failed to query all bookings", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to query all bookings", logger.Err(err))
 		return nil, 0, err
 	}
 	defer rows.Close()
@@ -267,32 +772,134 @@ func (r *bookingRepository) GetAllBookings(ctx context.Context, status, sortBy,
 	for rows.Next() {
 		var b entity.BookingWithDetails
 		if err := rows.Scan(&b.ID, &b.UserID, &b.UserName, &b.UserEmail, &b.EventID, &b.EventName, &b.Status, &b.CreatedAt); err != nil {
-			logger.Error("failed to scan booking row", logger.Err(err))
+			logger.FromContext(ctx).Error("failed to scan booking row", logger.Err(err))
 			return nil, 0, err
 		}
 		bookings = append(bookings, b)
 	}
 
-	logger.Debug("all bookings fetched",
+	logger.FromContext(ctx).Debug("all bookings fetched",
 		logger.Int("total", total),
 		logger.Int("returned", len(bookings)),
 	)
 	return bookings, total, nil
 }
 
-func (r *bookingRepository) GetBookingsWithDetailsByEventID(ctx context.Context, eventID int64, status, sortBy, sortOrder string) ([]entity.BookingWithDetails, error) {
-	logger.Debug("fetching bookings with details by event ID",
+// bookingListOrder resolves the admin booking list order, newest first
+// unless another is asked for. It returns the order's cursor name, its
+// column and direction; the booking ID breaks ties.
+func bookingListOrder(sortBy, sortOrder string) (name, column, direction string) {
+	validSortFields := map[string]string{
+		"created_at": "b.created_at",
+		"status":     "b.status",
+	}
+	column, ok := validSortFields[sortBy]
+	if !ok {
+		sortBy, column = "created_at", "b.created_at"
+	}
+	if sortOrder != "asc" && sortOrder != "desc" {
+		sortOrder = "desc"
+	}
+	return sortBy + ":" + sortOrder, column, sortOrder
+}
+
+func (r *bookingRepository) GetAllBookingsAfter(ctx context.Context, status entity.BookingStatus, sortBy, sortOrder string, after *entity.PageCursor, limit int) ([]entity.BookingWithDetails, *entity.PageCursor, error) {
+	logger.FromContext(ctx).Debug("paging all bookings by cursor",
+		logger.String("status", string(status)),
+		logger.String("sort_by", sortBy),
+		logger.String("sort_order", sortOrder),
+		logger.Int("limit", limit),
+	)
+
+	sort, sortField, sortOrder := bookingListOrder(sortBy, sortOrder)
+	if err := after.CheckSort(sort); err != nil {
+		return nil, nil, err
+	}
+
+	var conds []string
+	var args []interface{}
+	if status != "" {
+		args = append(args, status)
+		conds = append(conds, fmt.Sprintf("b.status = $%d", len(args)))
+	}
+	if after != nil {
+		op := "<"
+		if sortOrder == "asc" {
+			op = ">"
+		}
+		var cond string
+		if sortField == "b.status" {
+			cond, args = keysetAfter("b.status, b.booking_id", op, args, after.Status, after.ID)
+		} else {
+			cond, args = keysetAfter("b.created_at, b.booking_id", op, args, after.CreatedAt, after.ID)
+		}
+		conds = append(conds, cond)
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	// One extra row tells whether another page exists
+	query := fmt.Sprintf(`
+		SELECT b.booking_id, b.user_id, u.name, u.email, b.event_id, e.name, b.status, b.created_at
+		FROM booking b
+		JOIN users u ON b.user_id = u.user_id
+		JOIN events e ON b.event_id = e.event_id
+		%s
+		ORDER BY %s %s, b.booking_id %s
+		LIMIT $%d
+	`, where, sortField, sortOrder, sortOrder, len(args)+1)
+
+	rows, err := r.replica.Query(ctx, query, append(args, limit+1)...)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to page all bookings", logger.Err(err))
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var bookings []entity.BookingWithDetails
+	for rows.Next() {
+		var b entity.BookingWithDetails
+		if err := rows.Scan(&b.ID, &b.UserID, &b.UserName, &b.UserEmail, &b.EventID, &b.EventName, &b.Status, &b.CreatedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan booking row", logger.Err(err))
+			return nil, nil, err
+		}
+		bookings = append(bookings, b)
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("failed to page all bookings", logger.Err(err))
+		return nil, nil, err
+	}
+
+	if len(bookings) <= limit {
+		return bookings, nil, nil
+	}
+	bookings = bookings[:limit]
+	last := bookings[limit-1]
+	return bookings, &entity.PageCursor{Sort: sort, CreatedAt: last.CreatedAt, Status: string(last.Status), ID: last.ID}, nil
+}
+
+func (r *bookingRepository) GetBookingsWithDetailsByEventID(ctx context.Context, eventID int64, status entity.BookingStatus, sortBy, sortOrder string) ([]entity.BookingWithDetails, error) {
+	logger.FromContext(ctx).Debug("fetching bookings with details by event ID",
 		logger.Int64("event_id", eventID),
-		logger.String("status", status),
+		logger.String("status", string(status)),
 		logger.String("sort_by", sortBy),
 		logger.String("sort_order", sortOrder),
 	)
 
 	baseQuery := `
-		SELECT b.booking_id, b.user_id, u.name, u.email, b.event_id, e.name, b.status, b.created_at
+		SELECT b.booking_id, b.user_id, u.name, u.email, b.event_id, e.name, b.status, b.created_at,
+			COALESCE(s.seats, '{}')
 		FROM booking b
 		JOIN users u ON b.user_id = u.user_id
 		JOIN events e ON b.event_id = e.event_id
+		LEFT JOIN LATERAL (
+			SELECT array_agg(st.seat_number ORDER BY st.seat_id) AS seats
+			FROM booking_items bi
+			JOIN seats st ON st.seat_id = bi.seat_id
+			WHERE bi.booking_id = b.booking_id
+		) s ON TRUE
 		WHERE b.event_id = $1
 	`
 	args := []interface{}{eventID}
@@ -317,9 +924,9 @@ func (r *bookingRepository) GetBookingsWithDetailsByEventID(ctx context.Context,
 
 	baseQuery += fmt.Sprintf(" ORDER BY %s %s", sortField, sortOrder)
 
-	rows, err := r.db.Query(ctx, baseQuery, args...)
+	rows, err := r.replica.Query(ctx, baseQuery, args...)
 	if err != nil {
-		logger.Error("failed to query bookings with details by event ID",
+		logger.FromContext(ctx).Error("failed to query bookings with details by event ID",
 			logger.Int64("event_id", eventID),
 			logger.Err(err),
 		)
@@ -330,62 +937,590 @@ func (r *bookingRepository) GetBookingsWithDetailsByEventID(ctx context.Context,
 	var bookings []entity.BookingWithDetails
 	for rows.Next() {
 		var b entity.BookingWithDetails
-		if err := rows.Scan(&b.ID, &b.UserID, &b.UserName, &b.UserEmail, &b.EventID, &b.EventName, &b.Status, &b.CreatedAt); err != nil {
-			logger.Error("failed to scan booking row", logger.Err(err))
+		if err := rows.Scan(&b.ID, &b.UserID, &b.UserName, &b.UserEmail, &b.EventID, &b.EventName, &b.Status, &b.CreatedAt, &b.Seats); err != nil {
+			logger.FromContext(ctx).Error("failed to scan booking row", logger.Err(err))
 			return nil, err
 		}
 		bookings = append(bookings, b)
 	}
 
-	logger.Debug("bookings with details fetched by event ID",
+	logger.FromContext(ctx).Debug("bookings with details fetched by event ID",
 		logger.Int64("event_id", eventID),
 		logger.Int("count", len(bookings)),
 	)
 	return bookings, nil
 }
 
-func (r *bookingRepository) UpdateBookingStatus(ctx context.Context, bookingID int64, status string) error {
-	logger.Debug("updating booking status",
+func (r *bookingRepository) GetEventBookingSummary(ctx context.Context, eventID int64) (*entity.EventBookingSummary, error) {
+	logger.FromContext(ctx).Debug("fetching event booking summary", logger.Int64("event_id", eventID))
+
+	summary := &entity.EventBookingSummary{EventID: eventID, ByStatus: []entity.BookingStatusTotal{}, ByTier: []entity.TierSalesTotal{}}
+	err := r.replica.QueryRow(ctx, `
+		SELECT COALESCE(e.capacity, 0),
+			(SELECT COUNT(*) FROM booking_items bi JOIN booking b ON b.booking_id = bi.booking_id
+				WHERE b.event_id = e.event_id AND b.status = 'PAID'),
+			(SELECT COALESCE(SUM(t.amount), 0) FROM transactions t JOIN booking b ON b.booking_id = t.booking_id
+				WHERE b.event_id = e.event_id AND t.status = 'COMPLETED'),
+			(SELECT COALESCE(SUM(rf.amount), 0) FROM refund rf JOIN booking b ON b.booking_id = rf.booking_id
+				WHERE b.event_id = e.event_id AND rf.status = 'COMPLETED')
+		FROM events e
+		WHERE e.event_id = $1
+	`, eventID).Scan(&summary.Capacity, &summary.TicketsSold, &summary.Revenue, &summary.Refunds)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to total event bookings", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+
+	rows, err := r.replica.Query(ctx, `
+		SELECT b.status, COUNT(*), COALESCE(SUM(i.tickets), 0), COALESCE(SUM(b.total_amount), 0)
+		FROM booking b
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS tickets FROM booking_items bi WHERE bi.booking_id = b.booking_id
+		) i ON TRUE
+		WHERE b.event_id = $1
+		GROUP BY b.status
+		ORDER BY b.status
+	`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to total event bookings by status", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s entity.BookingStatusTotal
+		if err := rows.Scan(&s.Status, &s.Bookings, &s.Tickets, &s.Amount); err != nil {
+			logger.FromContext(ctx).Error("failed to scan booking status total", logger.Err(err))
+			return nil, err
+		}
+		summary.ByStatus = append(summary.ByStatus, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// A seat is sold at its own price, a general admission ticket at the
+	// event's
+	rows, err = r.replica.Query(ctx, `
+		SELECT tt.tier_id, COALESCE(tt.name, ''), COUNT(*), COALESCE(SUM(COALESCE(st.price, e.general_price)), 0)
+		FROM booking_items bi
+		JOIN booking b ON b.booking_id = bi.booking_id
+		JOIN events e ON e.event_id = b.event_id
+		LEFT JOIN seats st ON st.seat_id = bi.seat_id
+		LEFT JOIN ticket_tiers tt ON tt.tier_id = st.tier_id
+		WHERE b.event_id = $1 AND b.status = 'PAID'
+		GROUP BY tt.tier_id, tt.name
+		ORDER BY tt.tier_id NULLS LAST
+	`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to total event tickets by tier", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t entity.TierSalesTotal
+		if err := rows.Scan(&t.TierID, &t.TierName, &t.TicketsSold, &t.Revenue); err != nil {
+			logger.FromContext(ctx).Error("failed to scan tier sales total", logger.Err(err))
+			return nil, err
+		}
+		summary.ByTier = append(summary.ByTier, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	summary.Summarize()
+	return summary, nil
+}
+
+func (r *bookingRepository) StreamEventBookings(ctx context.Context, eventID int64, fn func(entity.BookingExportRow) error) error {
+	logger.FromContext(ctx).Debug("streaming event bookings", logger.Int64("event_id", eventID))
+
+	var exists bool
+	if err := r.replica.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM events WHERE event_id = $1)`, eventID).Scan(&exists); err != nil {
+		logger.FromContext(ctx).Error("failed to check event for booking export", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if !exists {
+		return entity.ErrNotFound
+	}
+
+	query := `
+		SELECT b.booking_id, b.status, b.created_at, COALESCE(u.name, ''), COALESCE(u.email, ''),
+			COALESCE(s.seats, '{}'), COALESCE(b.ga_quantity, 0), COALESCE(b.total_amount, 0),
+			COALESCE(t.status, ''), COALESCE(t.payment_method, ''), t.transaction_date
+		FROM booking b
+		LEFT JOIN users u ON u.user_id = b.user_id
+		LEFT JOIN LATERAL (
+			SELECT array_agg(st.seat_number ORDER BY st.seat_id) AS seats
+			FROM booking_items bi
+			JOIN seats st ON st.seat_id = bi.seat_id
+			WHERE bi.booking_id = b.booking_id
+		) s ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT status, payment_method, transaction_date
+			FROM transactions
+			WHERE booking_id = b.booking_id
+			ORDER BY transaction_date DESC, payment_id DESC
+			LIMIT 1
+		) t ON TRUE
+		WHERE b.event_id = $1
+		ORDER BY b.booking_id
+	`
+
+	rows, err := r.replica.Query(ctx, query, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query event bookings for export", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var row entity.BookingExportRow
+		if err := rows.Scan(&row.BookingID, &row.Status, &row.CreatedAt, &row.UserName, &row.UserEmail,
+			&row.Seats, &row.Quantity, &row.TotalAmount, &row.PaymentStatus, &row.PaymentMethod, &row.PaidAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan booking export row", logger.Err(err))
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("booking export interrupted", logger.Int64("event_id", eventID), logger.Int("streamed", count), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Debug("event bookings streamed", logger.Int64("event_id", eventID), logger.Int("count", count))
+	return nil
+}
+
+func (r *bookingRepository) UpdateBookingStatus(ctx context.Context, bookingID int64, status entity.BookingStatus) error {
+	logger.FromContext(ctx).Debug("updating booking status",
 		logger.Int64("booking_id", bookingID),
-		logger.String("status", status),
+		logger.String("status", string(status)),
 	)
 
 	query := `UPDATE booking SET status = $1 WHERE booking_id = $2`
 	_, err := r.db.Exec(ctx, query, status, bookingID)
 	if err != nil {
-		logger.Error("failed to update booking status",
+		logger.FromContext(ctx).Error("failed to update booking status",
 			logger.Int64("booking_id", bookingID),
-			logger.String("status", status),
+			logger.String("status", string(status)),
 			logger.Err(err),
 		)
 		return err
 	}
 
-	logger.Info("booking status updated",
+	logger.FromContext(ctx).Info("booking status updated",
 		logger.Int64("booking_id", bookingID),
-		logger.String("status", status),
+		logger.String("status", string(status)),
 	)
 	return nil
 }
 
+func (r *bookingRepository) ExtendBookingExpiry(ctx context.Context, bookingID int64, expiresAt time.Time) (time.Time, error) {
+	query := `
+		UPDATE booking SET expires_at = GREATEST(COALESCE(expires_at, $2), $2)
+		WHERE booking_id = $1 AND status = 'PENDING'
+		RETURNING expires_at`
+
+	var deadline time.Time
+	err := r.db.QueryRow(ctx, query, bookingID, expiresAt).Scan(&deadline)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, entity.ErrBookingNotPending
+		}
+		logger.FromContext(ctx).Error("failed to extend booking expiry", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return time.Time{}, err
+	}
+
+	logger.FromContext(ctx).Info("booking expiry extended",
+		logger.Int64("booking_id", bookingID),
+		logger.Any("expires_at", deadline),
+	)
+	return deadline, nil
+}
+
+func (r *bookingRepository) HoldForGateway(ctx context.Context, bookingID int64, expiresAt time.Time) (time.Time, error) {
+	query := `
+		UPDATE booking SET expires_at = GREATEST(COALESCE(expires_at, $2), $2),
+			awaiting_gateway_since = COALESCE(awaiting_gateway_since, NOW())
+		WHERE booking_id = $1 AND status = 'PENDING'
+		RETURNING expires_at`
+
+	var deadline time.Time
+	err := r.db.QueryRow(ctx, query, bookingID, expiresAt).Scan(&deadline)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, entity.ErrBookingNotPending
+		}
+		logger.FromContext(ctx).Error("failed to hold booking for gateway", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return time.Time{}, err
+	}
+
+	logger.FromContext(ctx).Info("booking held for payment gateway",
+		logger.Int64("booking_id", bookingID),
+		logger.Any("expires_at", deadline),
+	)
+	return deadline, nil
+}
+
+func (r *bookingRepository) ClaimGatewayWaiters(ctx context.Context, recoveredAt, expiresAt time.Time, limit int) ([]entity.AffectedBooking, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE booking SET awaiting_gateway_since = NULL,
+				expires_at = GREATEST(COALESCE(expires_at, $2), $2)
+			WHERE booking_id IN (
+				SELECT booking_id FROM booking
+				WHERE status = 'PENDING' AND awaiting_gateway_since < $1
+				ORDER BY awaiting_gateway_since
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING booking_id, user_id, status, expires_at
+		)
+		SELECT c.booking_id, c.user_id, COALESCE(u.email, ''), c.status, c.expires_at
+		FROM claimed c
+		LEFT JOIN users u ON u.user_id = c.user_id
+		ORDER BY c.booking_id`
+
+	rows, err := r.db.Query(ctx, query, recoveredAt, expiresAt, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to claim gateway waiters", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookings []entity.AffectedBooking
+	for rows.Next() {
+		var b entity.AffectedBooking
+		if err := rows.Scan(&b.BookingID, &b.UserID, &b.UserEmail, &b.Status, &b.ExpiresAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan gateway waiter", logger.Err(err))
+			return nil, err
+		}
+		bookings = append(bookings, b)
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("failed to read gateway waiters", logger.Err(err))
+		return nil, err
+	}
+	return bookings, nil
+}
+
+func (r *bookingRepository) ExpireLapsedBookings(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	// The status check is repeated in the UPDATE so a booking paid or
+	// extended since it was picked is left alone
+	query := `
+		UPDATE booking SET status = 'EXPIRED'
+		WHERE status = 'PENDING' AND expires_at < $1 AND booking_id IN (
+			SELECT booking_id FROM booking
+			WHERE status = 'PENDING' AND expires_at < $1
+			ORDER BY expires_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING booking_id
+	`
+	rows, err := r.db.Query(ctx, query, before, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to expire lapsed bookings", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("failed to expire lapsed bookings", logger.Err(err))
+		return nil, err
+	}
+
+	if len(ids) > 0 {
+		logger.FromContext(ctx).Info("lapsed bookings expired", logger.Int("count", len(ids)))
+	}
+	return ids, nil
+}
+
 func (r *bookingRepository) ReleaseSeatsByBookingID(ctx context.Context, bookingID int64) error {
-	logger.Debug("releasing seats for booking", logger.Int64("booking_id", bookingID))
+	logger.FromContext(ctx).Debug("releasing seats for booking", logger.Int64("booking_id", bookingID))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
 
+	// Only seats that were still booked are announced as freed
 	query := `
 		UPDATE seats SET is_booked = False
-		WHERE seat_id IN (
+		WHERE is_booked AND seat_id IN (
 			SELECT seat_id FROM booking_items WHERE booking_id = $1
 		)
+		RETURNING event_id, seat_id
 	`
-	_, err := r.db.Exec(ctx, query, bookingID)
+	rows, err := tx.Query(ctx, query, bookingID)
 	if err != nil {
-		logger.Error("failed to release seats",
+		logger.FromContext(ctx).Error("failed to release seats",
+			logger.Int64("booking_id", bookingID),
+			logger.Err(err),
+		)
+		return err
+	}
+	released := entity.SeatUpdate{IsBooked: false}
+	for rows.Next() {
+		var seatID int64
+		if err := rows.Scan(&released.EventID, &seatID); err != nil {
+			rows.Close()
+			return err
+		}
+		released.SeatIDs = append(released.SeatIDs, seatID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("failed to release seats",
 			logger.Int64("booking_id", bookingID),
 			logger.Err(err),
 		)
 		return err
 	}
 
-	logger.Info("seats released for booking", logger.Int64("booking_id", bookingID))
+	// Releasing can happen more than once per booking (expiry, then event
+	// cancellation); ga_released keeps the counter from being refilled twice
+	_, err = tx.Exec(ctx, `
+		WITH released AS (
+			UPDATE booking SET ga_released = TRUE
+			WHERE booking_id = $1 AND ga_quantity > 0 AND NOT ga_released
+			RETURNING event_id, ga_quantity
+		)
+		UPDATE events e SET ga_remaining = e.ga_remaining + released.ga_quantity
+		FROM released WHERE e.event_id = released.event_id
+	`, bookingID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to release general admission tickets",
+			logger.Int64("booking_id", bookingID),
+			logger.Err(err),
+		)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit seat release", logger.Err(err))
+		return err
+	}
+	r.seatUpdates.PublishSeatUpdate(ctx, released)
+
+	logger.FromContext(ctx).Info("seats released for booking", logger.Int64("booking_id", bookingID))
 	return nil
 }
+
+func (r *bookingRepository) GetOverlappingPaidBookings(ctx context.Context, userID, eventID int64, window time.Duration) ([]entity.BookingConflict, error) {
+	logger.FromContext(ctx).Debug("checking overlapping bookings",
+		logger.Int64("user_id", userID),
+		logger.Int64("event_id", eventID),
+	)
+
+	query := `
+		SELECT b.booking_id, e.event_id, e.name, e.date
+		FROM booking b
+		JOIN events e ON e.event_id = b.event_id
+		JOIN events target ON target.event_id = $2
+		WHERE b.user_id = $1
+			AND b.status = 'PAID'
+			AND e.event_id <> target.event_id
+			AND e.status <> 'cancelled'
+			AND e.date > target.date - make_interval(secs => $3)
+			AND e.date < target.date + make_interval(secs => $3)
+		ORDER BY e.date, b.booking_id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, eventID, window.Seconds())
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query overlapping bookings", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conflicts []entity.BookingConflict
+	for rows.Next() {
+		var c entity.BookingConflict
+		if err := rows.Scan(&c.BookingID, &c.EventID, &c.EventName, &c.EventDate); err != nil {
+			logger.FromContext(ctx).Error("failed to scan overlapping booking", logger.Err(err))
+			return nil, err
+		}
+		conflicts = append(conflicts, c)
+	}
+
+	return conflicts, rows.Err()
+}
+
+func (r *bookingRepository) GetTicketAllowance(ctx context.Context, userID, eventID int64) (*entity.TicketAllowance, error) {
+	// Two bookings that both read the count before either inserts would
+	// each pass the cap. The lock key is a hash of the pair; a collision
+	// only serializes two unrelated bookings.
+	if _, err := conn(ctx, r.db).Exec(ctx,
+		`SELECT pg_advisory_xact_lock(hashtextextended('ticket_limit:' || $1::text || ':' || $2::text, 0))`,
+		userID, eventID,
+	); err != nil {
+		logger.FromContext(ctx).Error("failed to lock ticket allowance",
+			logger.Int64("user_id", userID),
+			logger.Int64("event_id", eventID),
+			logger.Err(err),
+		)
+		return nil, err
+	}
+
+	query := `
+		SELECT e.max_tickets_per_user, (
+			SELECT COUNT(*)
+			FROM booking b
+			JOIN booking_items bi ON bi.booking_id = b.booking_id
+			WHERE b.user_id = $1 AND b.event_id = e.event_id AND b.status IN ('PAID', 'PENDING')
+		)
+		FROM events e
+		WHERE e.event_id = $2
+	`
+	var allowance entity.TicketAllowance
+	if err := conn(ctx, r.db).QueryRow(ctx, query, userID, eventID).Scan(&allowance.Limit, &allowance.Held); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to read ticket allowance",
+			logger.Int64("user_id", userID),
+			logger.Int64("event_id", eventID),
+			logger.Err(err),
+		)
+		return nil, err
+	}
+	return &allowance, nil
+}
+
+// affectedBookingSelect reads bookings with their customer, ticket count and
+// completed payments. General admission bookings carry one seatless item
+// per ticket, so counting items works for both admission modes.
+const affectedBookingSelect = `
+	SELECT b.booking_id, b.user_id, COALESCE(u.email, ''), b.status, b.expires_at,
+		(SELECT COUNT(*) FROM booking_items bi WHERE bi.booking_id = b.booking_id),
+		(SELECT COALESCE(SUM(t.amount), 0) FROM transactions t WHERE t.booking_id = b.booking_id AND t.status = 'COMPLETED')
+	FROM booking b
+	LEFT JOIN users u ON u.user_id = b.user_id
+`
+
+func queryAffectedBookings(ctx context.Context, db *pgxpool.Pool, where string, args ...interface{}) ([]entity.AffectedBooking, error) {
+	rows, err := db.Query(ctx, affectedBookingSelect+where, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query affected bookings", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookings []entity.AffectedBooking
+	for rows.Next() {
+		var b entity.AffectedBooking
+		if err := rows.Scan(&b.BookingID, &b.UserID, &b.UserEmail, &b.Status, &b.ExpiresAt, &b.Tickets, &b.PaidAmount); err != nil {
+			logger.FromContext(ctx).Error("failed to scan affected booking", logger.Err(err))
+			return nil, err
+		}
+		bookings = append(bookings, b)
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("failed to read affected bookings", logger.Err(err))
+		return nil, err
+	}
+	return bookings, nil
+}
+
+func (r *bookingRepository) GetAffectedBooking(ctx context.Context, bookingID int64) (*entity.AffectedBooking, error) {
+	logger.FromContext(ctx).Debug("fetching affected booking", logger.Int64("booking_id", bookingID))
+
+	bookings, err := queryAffectedBookings(ctx, r.db, `WHERE b.booking_id = $1`, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if len(bookings) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return &bookings[0], nil
+}
+
+func (r *bookingRepository) GetLapsedBookings(ctx context.Context, before time.Time) ([]entity.AffectedBooking, error) {
+	logger.FromContext(ctx).Debug("fetching lapsed bookings", logger.Any("before", before))
+
+	return queryAffectedBookings(ctx, r.db, `
+		WHERE b.status = 'PENDING' AND b.expires_at < $1
+		ORDER BY b.expires_at
+	`, before)
+}
+
+func (r *bookingRepository) GetCustomerSummary(ctx context.Context, userID int64) (*entity.CustomerSummary, error) {
+	logger.FromContext(ctx).Debug("fetching customer summary", logger.Int64("user_id", userID))
+
+	// Seatless general admission items count as tickets too
+	query := `
+		SELECT u.user_id, u.name, u.email, u.role, u.created_at,
+			COALESCE(b.total, 0), COALESCE(b.pending, 0), COALESCE(b.paid, 0), COALESCE(b.expired, 0),
+			COALESCE(b.cancelled, 0), COALESCE(b.refunded, 0), COALESCE(b.spent, 0), b.first_at, b.last_at,
+			COALESCE(t.tickets, 0), COALESCE(t.attended, 0), COALESCE(t.no_shows, 0),
+			COALESCE(r.refunds, 0), COALESCE(r.refunded, 0), COALESCE(r.open_requests, 0), COALESCE(r.fraud, 0)
+		FROM users u
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS total,
+				COUNT(*) FILTER (WHERE status = 'PENDING') AS pending,
+				COUNT(*) FILTER (WHERE status = 'PAID') AS paid,
+				COUNT(*) FILTER (WHERE status = 'EXPIRED') AS expired,
+				COUNT(*) FILTER (WHERE status = 'CANCELLED') AS cancelled,
+				COUNT(*) FILTER (WHERE status = 'REFUNDED') AS refunded,
+				SUM(total_amount) FILTER (WHERE status IN ('PAID', 'REFUNDED')) AS spent,
+				MIN(created_at) AS first_at, MAX(created_at) AS last_at
+			FROM booking
+			WHERE user_id = u.user_id
+		) b ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS tickets,
+				COUNT(*) FILTER (WHERE bi.checked_in_at IS NOT NULL) AS attended,
+				COUNT(*) FILTER (WHERE bi.checked_in_at IS NULL AND e.date < NOW() AND e.status <> 'cancelled') AS no_shows
+			FROM booking bk
+			JOIN booking_items bi ON bi.booking_id = bk.booking_id
+			JOIN events e ON e.event_id = bk.event_id
+			WHERE bk.user_id = u.user_id AND bk.status = 'PAID'
+		) t ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) FILTER (WHERE rf.status = 'COMPLETED') AS refunds,
+				SUM(rf.amount) FILTER (WHERE rf.status = 'COMPLETED') AS refunded,
+				COUNT(*) FILTER (WHERE rf.status IN ('REQUESTED', 'APPROVED')) AS open_requests,
+				COUNT(*) FILTER (WHERE rf.status = 'COMPLETED' AND rf.reason = 'fraud') AS fraud
+			FROM refund rf
+			JOIN booking bk ON bk.booking_id = rf.booking_id
+			WHERE bk.user_id = u.user_id
+		) r ON TRUE
+		WHERE u.user_id = $1
+	`
+
+	var s entity.CustomerSummary
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&s.UserID, &s.Name, &s.Email, &s.Role, &s.MemberSince,
+		&s.Bookings.Total, &s.Bookings.Pending, &s.Bookings.Paid, &s.Bookings.Expired,
+		&s.Bookings.Cancelled, &s.Bookings.Refunded, &s.TotalSpent, &s.FirstBookingAt, &s.LastBookingAt,
+		&s.Tickets, &s.TicketsAttended, &s.NoShows,
+		&s.Refunds, &s.TotalRefunded, &s.OpenRefundRequests, &s.FraudRefunds,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch customer summary", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+	return &s, nil
+}
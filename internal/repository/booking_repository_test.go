@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noSeatUpdates struct{}
+
+func (noSeatUpdates) PublishSeatUpdate(ctx context.Context, update entity.SeatUpdate) {}
+
+// TestCreateBooking_OtherEventSeat books a seat of one event under another.
+// It needs a migrated database:
+//
+//	TEST_DATABASE_URL=postgres://... go test ./internal/repository -run CreateBooking
+//
+// Everything runs in one transaction that is rolled back.
+func TestCreateBooking_OtherEventSeat(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+	ctx = context.WithValue(ctx, txKey{}, &unitOfWork{tx: tx})
+
+	var eventA, eventB, seatB int64
+	for _, id := range []*int64{&eventA, &eventB} {
+		err := tx.QueryRow(ctx, `INSERT INTO events (name, date, capacity) VALUES ('Test', $1, 1) RETURNING event_id`,
+			time.Now().Add(24*time.Hour)).Scan(id)
+		require.NoError(t, err)
+	}
+	err = tx.QueryRow(ctx, `INSERT INTO seats (event_id, seat_number, price, is_booked) VALUES ($1, 'B-1', 100000, FALSE) RETURNING seat_id`,
+		eventB).Scan(&seatB)
+	require.NoError(t, err)
+
+	repo := NewBookingRepository(pool, pool, noSeatUpdates{})
+	_, _, err = repo.CreateBooking(ctx, 1, eventA, []int64{seatB}, nil, time.Now().Add(15*time.Minute))
+	assert.ErrorIs(t, err, entity.ErrSeatUnavailable)
+
+	var booked bool
+	require.NoError(t, tx.QueryRow(ctx, `SELECT is_booked FROM seats WHERE seat_id = $1`, seatB).Scan(&booked))
+	assert.False(t, booked, "the other event's seat was booked")
+}
@@ -3,12 +3,17 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"ticres/internal/entity"
+	"ticres/pkg/cache"
 	"ticres/pkg/logger"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
@@ -16,27 +21,96 @@ import (
 type EventRepository interface {
 	CreateEvent(ctx context.Context, event *entity.Event, ticketPrice float64) error
 	GetAllEvents(ctx context.Context) ([]entity.Event, error)
-	GetEventsWithSearch(ctx context.Context, search string, page, limit int) ([]entity.Event, int, error)
+	// GetEventsWithSearch returns one page of the events matching filter,
+	// newest first, and the number of matching events.
+	GetEventsWithSearch(ctx context.Context, filter entity.EventFilter, page, limit int) ([]entity.Event, int, error)
+	// GetEventsAfter returns up to limit events matching filter that follow
+	// the after cursor in the same order as GetEventsWithSearch, and the
+	// cursor of the last one when more follow. A nil after starts at the top.
+	GetEventsAfter(ctx context.Context, filter entity.EventFilter, after *entity.PageCursor, limit int) ([]entity.Event, *entity.PageCursor, error)
 	GetEventByID(ctx context.Context, eventID int64) (*entity.Event, error)
 	GetEventWithSeats(ctx context.Context, eventID int64) (*entity.EventWithSeats, error)
 	GetSeatsByEventID(ctx context.Context, eventID int64) ([]entity.Seat, error)
+	// GetSeatsPage returns up to limit seats with seat_id greater than afterID,
+	// so large venues are paged with a cursor instead of OFFSET scans.
+	GetSeatsPage(ctx context.Context, eventID, afterID int64, limit int, filter entity.SeatFilter) ([]entity.Seat, error)
+	// StreamSeats calls fn for each seat in seat_id order without buffering the
+	// result set. Iteration stops at the first error returned by fn.
+	StreamSeats(ctx context.Context, eventID int64, filter entity.SeatFilter, fn func(entity.Seat) error) error
+	// GetSectionAvailability aggregates seats per section in layout order.
+	GetSectionAvailability(ctx context.Context, eventID int64) ([]entity.SectionAvailability, error)
+	// GetEventCapacity counts the event's sold, held and available tickets.
+	GetEventCapacity(ctx context.Context, eventID int64) (*entity.EventCapacity, error)
+	// GetCancellableBookings returns the PAID and PENDING bookings of the
+	// event, the ones cancelling it refunds or cancels.
+	GetCancellableBookings(ctx context.Context, eventID int64) ([]entity.AffectedBooking, error)
+	// GetPublicAvailability returns the event's ticket count and price range
+	// for aggregators, cached for publicAvailabilityTTL.
+	GetPublicAvailability(ctx context.Context, eventID int64) (*entity.PublicAvailability, error)
+	// UpdateSeatLayout applies the placements in one transaction. It fails
+	// with ErrInvalidSeatLayout if any seat number is not a seat of the event.
+	UpdateSeatLayout(ctx context.Context, eventID int64, layout *entity.SeatLayout) error
+	// GetSectionImageIDs maps each section with a view-from-seat image to the image ID.
+	GetSectionImageIDs(ctx context.Context, eventID int64) (map[string]int64, error)
 	UpdateEvent(ctx context.Context, event *entity.Event, preCapacity int64) error
-	UpdateEventStatus(ctx context.Context, eventID int64, status string) error
+	// DeleteEvent cancels the event and hides it from buyers. It returns
+	// ErrNotFound when the event does not exist or is already deleted.
+	DeleteEvent(ctx context.Context, eventID int64) error
+	// IsEventCancelled reports whether the event is still cancelled and
+	// deleted, read from the database rather than the cache. ErrNotFound
+	// when the event does not exist.
+	IsEventCancelled(ctx context.Context, eventID int64) (bool, error)
+	// RestoreEvent lists a deleted event again with the status it had before
+	// it was deleted. It returns ErrNotFound when the event does not exist or
+	// is not deleted.
+	RestoreEvent(ctx context.Context, eventID int64) error
+	// SetTicketLimit sets the event's per-user ticket cap; nil clears it.
+	SetTicketLimit(ctx context.Context, eventID int64, limit *int) error
+	// SetBookingRateLimit sets the event's booking requests per second; nil
+	// clears it.
+	SetBookingRateLimit(ctx context.Context, eventID int64, limit *int) error
+	// SetRefundPolicy sets the event's refund policy; nil clears it.
+	SetRefundPolicy(ctx context.Context, eventID int64, policy *entity.RefundPolicy) error
+	// UpdateEventContent replaces the event's detail page content.
+	UpdateEventContent(ctx context.Context, eventID int64, content *entity.EventContent) error
+	// SetEventImage points the event at a new poster and returns the storage
+	// key of the poster it replaced, if any.
+	SetEventImage(ctx context.Context, eventID int64, key, url string) (string, error)
+	// SubscribeSeatUpdates delivers the event's seat updates published after
+	// the subscription is confirmed. The channel closes when ctx is done.
+	SubscribeSeatUpdates(ctx context.Context, eventID int64) (<-chan entity.SeatUpdate, error)
+	// GetSalesWaves returns the event's release schedule, oldest wave first.
+	GetSalesWaves(ctx context.Context, eventID int64) (*entity.SalesWaveSchedule, error)
+	// ReplaceSalesWaves replaces the event's release schedule; an empty
+	// schedule puts all its tickets on sale.
+	ReplaceSalesWaves(ctx context.Context, eventID int64, waves []entity.SalesWave) error
 }
 
 type eventRepository struct {
 	db *pgxpool.Pool
+	// replica serves search and seat pages. Cache fills stay on db: a
+	// lagging read stored in the cache would outlive the lag.
+	replica *pgxpool.Pool
 	redis *redis.Client
+	// cache invalidates cached events on every instance
+	cache *cache.Bus
+	// events reads the event list and details through the cache
+	events *cache.Loader
+	seats SeatAvailabilityCache
 }
 
-func NewEventRepository(db *pgxpool.Pool, rdb *redis.Client) EventRepository {
-	return &eventRepository{db:db, redis:rdb}
+func NewEventRepository(db, replica *pgxpool.Pool, rdb *redis.Client, bus *cache.Bus, events *cache.Loader, seats SeatAvailabilityCache) EventRepository {
+	return &eventRepository{db:db, replica:replica, redis:rdb, cache:bus, events:events, seats:seats}
 }
 
 const eventsCacheKey = "events:list_all"
 
+// publicAvailabilityTTL bounds how often aggregator polling reaches the
+// database: once per event per TTL, however many clients poll.
+const publicAvailabilityTTL = 30 * time.Second
+
 func (r *eventRepository) CreateEvent(ctx context.Context, event *entity.Event, ticketPrice float64) error {
-	logger.Debug("creating event",
+	logger.FromContext(ctx).Debug("creating event",
 		logger.String("name", event.Name),
 		logger.String("location", event.Location),
 		logger.Int("capacity", event.Capacity),
@@ -44,45 +118,50 @@ func (r *eventRepository) CreateEvent(ctx context.Context, event *entity.Event,
 
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		logger.Error("failed to begin transaction", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
 		return err
 	}
 	defer tx.Rollback(ctx)
 
+	if event.AdmissionMode == "" {
+		event.AdmissionMode = entity.AdmissionSeated
+	}
+	// General admission tracks capacity as a counter instead of seat rows
+	var generalPrice *float64
+	var gaRemaining *int
+	if event.IsGeneralAdmission() {
+		event.GeneralPrice = ticketPrice
+		generalPrice, gaRemaining = &ticketPrice, &event.Capacity
+	}
+
 	queryEvent := `
-		INSERT INTO events (name, location, date, capacity, created_at)
-		VALUES ($1, $2, $3, $4, NOW())
+		INSERT INTO events (name, location, series, category, date, capacity, organizer_id, seat_numbering,
+			admission_mode, general_price, ga_remaining, content, description, terms, organizer_name, metadata, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, ''), $16, NOW())
 		RETURNING event_id, created_at
 	`
-	err = tx.QueryRow(ctx, queryEvent, event.Name, event.Location, event.Date, event.Capacity).Scan(&event.ID, &event.CreatedAt)
+	err = tx.QueryRow(ctx, queryEvent, event.Name, event.Location, event.Series, event.Category, event.Date, event.Capacity, event.OrganizerID, event.SeatNumbering,
+		event.AdmissionMode, generalPrice, gaRemaining, event.Content, event.Description, event.Terms, event.OrganizerName, event.Metadata,
+	).Scan(&event.ID, &event.CreatedAt)
 	if err != nil {
-		logger.Error("failed to insert event", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to insert event", logger.Err(err))
 		return err
 	}
 
-	querySeat := `INSERT INTO seats (event_id, seat_number, price, is_booked) VALUES ($1, $2, $3, False)`
-
-	for i := 1; i <= event.Capacity; i++ {
-		seatNum := fmt.Sprintf("%d-%d", event.ID, i)
-		_, err := tx.Exec(ctx, querySeat, event.ID, seatNum, ticketPrice)
-		if err != nil {
-			logger.Error("failed to create seat",
-				logger.Int64("event_id", event.ID),
-				logger.Int("seat_number", i),
-				logger.Err(err),
-			)
+	if !event.IsGeneralAdmission() {
+		if err := copySeats(ctx, tx, event, 1, event.Capacity, &ticketPrice); err != nil {
 			return err
 		}
 	}
 
-	r.redis.Del(ctx, eventsCacheKey)
+	r.cache.Invalidate(ctx, eventsCacheKey)
 
 	if err := tx.Commit(ctx); err != nil {
-		logger.Error("failed to commit transaction", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to commit transaction", logger.Err(err))
 		return err
 	}
 
-	logger.Info("event created successfully",
+	logger.FromContext(ctx).Info("event created successfully",
 		logger.Int64("event_id", event.ID),
 		logger.String("name", event.Name),
 		logger.Int("capacity", event.Capacity),
@@ -90,23 +169,128 @@ func (r *eventRepository) CreateEvent(ctx context.Context, event *entity.Event,
 	return nil
 }
 
-func (r *eventRepository) GetAllEvents(ctx context.Context) ([]entity.Event, error) {
-	logger.Debug("fetching all events")
-
-	cachedData, err := r.redis.Get(ctx, eventsCacheKey).Result()
-	if err == nil {
-		var events []entity.Event
-		if err := json.Unmarshal([]byte(cachedData), &events); err == nil {
-			logger.Debug("events fetched from cache", logger.Int("count", len(events)))
-			return events, nil
+// seatColumns are the columns filled by copySeats. Seats created without a
+// price take the column default.
+var seatColumns = []string{"event_id", "seat_number", "category", "row_label", "col_number", "is_booked"}
+
+// copySeats creates seats first through last of the event with a single COPY,
+// so large venues don't hold the transaction open for one round trip per seat.
+// Empty sections and row labels and zero columns are stored as NULL.
+func copySeats(ctx context.Context, tx pgx.Tx, event *entity.Event, first, last int, price *float64) error {
+	columns := seatColumns
+	if price != nil {
+		columns = append(append([]string{}, seatColumns...), "price")
+	}
+
+	rows := make([][]interface{}, 0, last-first+1)
+	for i := first; i <= last; i++ {
+		seatNum, section, err := event.SeatNumbering.SeatLabel(event.ID, i)
+		if err != nil {
+			return err
+		}
+		row, column := event.SeatNumbering.SeatRowColumn(i)
+		values := []interface{}{event.ID, seatNum, nullIfEmpty(section), nullIfEmpty(row), nullIfZero(column), false}
+		if price != nil {
+			values = append(values, *price)
 		}
+		rows = append(rows, values)
 	}
 
-	query := `SELECT event_id ,name, location, date, capacity, created_at FROM events`
+	copied, err := tx.CopyFrom(ctx, pgx.Identifier{"seats"}, columns, pgx.CopyFromRows(rows))
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create seats",
+			logger.Int64("event_id", event.ID),
+			logger.Int("first_seat", first),
+			logger.Int("last_seat", last),
+			logger.Err(err),
+		)
+		return err
+	}
+
+	logger.FromContext(ctx).Debug("seats created", logger.Int64("event_id", event.ID), logger.Int64("count", copied))
+	return nil
+}
+
+// removeSeats cuts the event down to capacity seats by deleting the highest
+// numbered seats not in use. A seat is in use when it is booked, offered as an
+// upgrade, or referenced by any booking, including expired ones, whose items
+// must keep pointing at it. Seats locked by a concurrent booking are skipped,
+// so the reduction fails instead of waiting for it.
+func removeSeats(ctx context.Context, tx pgx.Tx, eventID int64, capacity int) error {
+	var total int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM seats WHERE event_id = $1`, eventID).Scan(&total); err != nil {
+		logger.FromContext(ctx).Error("failed to count seats", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	excess := total - capacity
+	if excess <= 0 {
+		return nil
+	}
+
+	const inUse = `(s.is_booked
+		OR EXISTS (SELECT 1 FROM booking_items bi WHERE bi.seat_id = s.seat_id)
+		OR EXISTS (SELECT 1 FROM upgrade_offers o WHERE o.to_seat_id = s.seat_id AND o.status = 'PENDING'))`
+
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM seats WHERE seat_id IN (
+			SELECT s.seat_id FROM seats s
+			WHERE s.event_id = $1 AND NOT `+inUse+`
+			ORDER BY s.seat_id DESC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`, eventID, excess)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to remove seats", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if removed := int(tag.RowsAffected()); removed < excess {
+		logger.FromContext(ctx).Warn("capacity reduction blocked by seats in use",
+			logger.Int64("event_id", eventID),
+			logger.Int("capacity", capacity),
+			logger.Int("removable", removed),
+		)
+		return &entity.CapacityBelowBookedError{Requested: capacity, Minimum: total - removed}
+	}
+
+	logger.FromContext(ctx).Info("seats removed", logger.Int64("event_id", eventID), logger.Int("count", excess))
+	return nil
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func nullIfZero(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+func (r *eventRepository) GetAllEvents(ctx context.Context) ([]entity.Event, error) {
+	logger.FromContext(ctx).Debug("fetching all events")
+
+	var events []entity.Event
+	err := r.events.Get(ctx, eventsCacheKey, &events, func(ctx context.Context) (interface{}, error) {
+		return r.loadAllEvents(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).Debug("events fetched", logger.Int("count", len(events)))
+	return events, nil
+}
+
+func (r *eventRepository) loadAllEvents(ctx context.Context) ([]entity.Event, error) {
+	query := `SELECT event_id ,name, location, date, capacity, COALESCE(image_url, ''), created_at FROM events WHERE deleted_at IS NULL`
 
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
-		logger.Error("failed to query events", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to query events", logger.Err(err))
 		return nil, err
 	}
 	defer rows.Close()
@@ -114,58 +298,76 @@ func (r *eventRepository) GetAllEvents(ctx context.Context) ([]entity.Event, err
 	var events []entity.Event
 	for rows.Next() {
 		var evt entity.Event
-		err := rows.Scan(&evt.ID, &evt.Name, &evt.Location, &evt.Date, &evt.Capacity, &evt.CreatedAt)
+		err := rows.Scan(&evt.ID, &evt.Name, &evt.Location, &evt.Date, &evt.Capacity, &evt.ImageURL, &evt.CreatedAt)
 		if err != nil {
-			logger.Error("failed to scan event row", logger.Err(err))
+			logger.FromContext(ctx).Error("failed to scan event row", logger.Err(err))
 			return nil, err
 		}
 		events = append(events, evt)
 	}
 
-	if data, err := json.Marshal(events); err == nil {
-		r.redis.Set(ctx, eventsCacheKey, data, 10*time.Minute)
-		logger.Debug("events cached", logger.Int("count", len(events)))
-	}
-
-	logger.Debug("events fetched from database", logger.Int("count", len(events)))
+	logger.FromContext(ctx).Debug("events fetched from database", logger.Int("count", len(events)))
 	return events, nil
 }
 
 func (r *eventRepository) GetEventByID(ctx context.Context, eventID int64) (*entity.Event, error) {
-	logger.Debug("fetching event by ID", logger.Int64("event_id", eventID))
+	logger.FromContext(ctx).Debug("fetching event by ID", logger.Int64("event_id", eventID))
 
-	key := fmt.Sprintf("events:detail:%d", eventID)
 	var event entity.Event
-	cachedData, err := r.redis.Get(ctx, key).Result()
-	if err == nil {
-		if err := json.Unmarshal([]byte(cachedData), &event); err == nil {
-			logger.Debug("event fetched from cache", logger.Int64("event_id", eventID))
-			return &event, nil
-		}
+	err := r.events.Get(ctx, fmt.Sprintf("events:detail:%d", eventID), &event, func(ctx context.Context) (interface{}, error) {
+		return r.loadEvent(ctx, eventID)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &event, nil
+}
 
-	query := `SELECT event_id ,name, location, date, capacity, created_at FROM events WHERE event_id=$1`
+func (r *eventRepository) loadEvent(ctx context.Context, eventID int64) (*entity.Event, error) {
+	var event entity.Event
+	query := `
+		SELECT event_id ,name, location, COALESCE(series, ''), COALESCE(category, ''), date, capacity, organizer_id, seat_numbering, content,
+			admission_mode, COALESCE(general_price, 0), COALESCE(image_url, ''),
+			COALESCE(description, ''), COALESCE(terms, ''), COALESCE(organizer_name, ''), metadata, max_tickets_per_user, booking_rate_limit, refund_policy, created_at, deleted_at
+		FROM events WHERE event_id=$1
+	`
 
-	err = r.db.QueryRow(ctx, query, eventID).Scan(
+	err := r.db.QueryRow(ctx, query, eventID).Scan(
 		&event.ID,
 		&event.Name,
 		&event.Location,
+		&event.Series,
+		&event.Category,
 		&event.Date,
 		&event.Capacity,
+		&event.OrganizerID,
+		&event.SeatNumbering,
+		&event.Content,
+		&event.AdmissionMode,
+		&event.GeneralPrice,
+		&event.ImageURL,
+		&event.Description,
+		&event.Terms,
+		&event.OrganizerName,
+		&event.Metadata,
+		&event.MaxTicketsPerUser,
+		&event.BookingRateLimit,
+		&event.RefundPolicy,
 		&event.CreatedAt,
+		&event.DeletedAt,
 	)
 
 	if err != nil {
-		logger.Warn("event not found", logger.Int64("event_id", eventID), logger.Err(err))
+		logger.FromContext(ctx).Warn("event not found", logger.Int64("event_id", eventID), logger.Err(err))
 		return nil, err
 	}
 
-	logger.Debug("event fetched from database", logger.Int64("event_id", eventID))
+	logger.FromContext(ctx).Debug("event fetched from database", logger.Int64("event_id", eventID))
 	return &event, nil
 }
 
 func (r *eventRepository) UpdateEvent(ctx context.Context, event *entity.Event, prevCapacity int64) error {
-	logger.Debug("updating event",
+	logger.FromContext(ctx).Debug("updating event",
 		logger.Int64("event_id", event.ID),
 		logger.String("name", event.Name),
 		logger.Int64("prev_capacity", prevCapacity),
@@ -174,104 +376,293 @@ func (r *eventRepository) UpdateEvent(ctx context.Context, event *entity.Event,
 
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		logger.Error("failed to begin transaction", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
 		return err
 	}
 	defer tx.Rollback(ctx)
 
 	queryEvent := `
 		UPDATE events
-		SET name = $1, location = $2, date = $3, capacity = $4, updated_at = $5
+		SET name = $1, location = $2, date = $3, capacity = $4, updated_at = $5, category = NULLIF($7, ''),
+			content = $8, description = NULLIF($9, ''), terms = NULLIF($10, ''), organizer_name = NULLIF($11, ''), metadata = $12
 		WHERE event_id = $6
 	`
 
-	_, err = tx.Exec(ctx, queryEvent, event.Name, event.Location, event.Date, event.Capacity, event.UpdatedAt, event.ID)
+	_, err = tx.Exec(ctx, queryEvent, event.Name, event.Location, event.Date, event.Capacity, event.UpdatedAt, event.ID, event.Category,
+		event.Content, event.Description, event.Terms, event.OrganizerName, event.Metadata,
+	)
 	if err != nil {
-		logger.Error("failed to update event", logger.Int64("event_id", event.ID), logger.Err(err))
+		logger.FromContext(ctx).Error("failed to update event", logger.Int64("event_id", event.ID), logger.Err(err))
 		return err
 	}
 
-	querySeats := `INSERT INTO seats (event_id, seat_number, is_booked) VALUES ($1, $2, False)`
-
-	for i := prevCapacity + 1; i <= int64(event.Capacity); i++ {
-		seatNum := fmt.Sprintf("%d-%d", event.ID, i)
-		_, err = tx.Exec(ctx, querySeats, event.ID, seatNum)
+	if event.IsGeneralAdmission() {
+		// The counter moves by the capacity change; it can't drop below zero
+		// because sold tickets are already taken out of it
+		_, err = tx.Exec(ctx, `UPDATE events SET ga_remaining = ga_remaining + $1 WHERE event_id = $2`, int64(event.Capacity)-prevCapacity, event.ID)
 		if err != nil {
-			logger.Error("failed to create new seat",
-				logger.Int64("event_id", event.ID),
-				logger.Int64("seat_number", i),
-				logger.Err(err),
-			)
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23514" {
+				return entity.ErrCapacityBelowSold
+			}
+			logger.FromContext(ctx).Error("failed to resize general admission capacity", logger.Int64("event_id", event.ID), logger.Err(err))
 			return err
 		}
 	}
 
-	r.redis.Del(ctx, "events:list_all")
+	// New seats continue the event's numbering scheme; legacy events keep "<id>-<n>"
+	if !event.IsGeneralAdmission() && int64(event.Capacity) > prevCapacity {
+		if err := copySeats(ctx, tx, event, int(prevCapacity)+1, event.Capacity, nil); err != nil {
+			return err
+		}
+	}
+	if !event.IsGeneralAdmission() && int64(event.Capacity) < prevCapacity {
+		if err := removeSeats(ctx, tx, event.ID, event.Capacity); err != nil {
+			return err
+		}
+	}
 
 	if err := tx.Commit(ctx); err != nil {
-		logger.Error("failed to commit transaction", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to commit transaction", logger.Err(err))
 		return err
 	}
+	r.cache.Invalidate(ctx, eventsCacheKey, fmt.Sprintf("events:detail:%d", event.ID))
+	if int64(event.Capacity) != prevCapacity {
+		r.seats.Invalidate(ctx, event.ID)
+	}
 
-	logger.Info("event updated successfully", logger.Int64("event_id", event.ID))
+	logger.FromContext(ctx).Info("event updated successfully", logger.Int64("event_id", event.ID))
 	return nil
 }
 
-func (r *eventRepository) UpdateEventStatus(ctx context.Context, eventID int64, status string) error {
-	logger.Debug("updating event status",
-		logger.Int64("event_id", eventID),
-		logger.String("status", status),
-	)
+func (r *eventRepository) DeleteEvent(ctx context.Context, eventID int64) error {
+	logger.FromContext(ctx).Debug("deleting event", logger.Int64("event_id", eventID))
+
+	query := `
+		UPDATE events SET status_before_delete = status, status = $1, deleted_at = NOW(), updated_at = NOW()
+		WHERE event_id = $2 AND deleted_at IS NULL
+	`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, entity.EventStatusCancelled, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to delete event", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
 
-	query := `UPDATE events SET status = $1, updated_at = NOW() WHERE event_id = $2`
-	_, err := r.db.Exec(ctx, query, status, eventID)
+	afterCommit(ctx, func() { r.invalidateEvent(ctx, eventID) })
+	logger.FromContext(ctx).Info("event deleted", logger.Int64("event_id", eventID))
+	return nil
+}
+
+func (r *eventRepository) RestoreEvent(ctx context.Context, eventID int64) error {
+	logger.FromContext(ctx).Debug("restoring event", logger.Int64("event_id", eventID))
+
+	// Events deleted before the previous status was kept stay cancelled
+	query := `
+		UPDATE events SET status = COALESCE(status_before_delete, status), status_before_delete = NULL,
+			deleted_at = NULL, updated_at = NOW()
+		WHERE event_id = $1 AND deleted_at IS NOT NULL
+	`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, eventID)
 	if err != nil {
-		logger.Error("failed to update event status",
-			logger.Int64("event_id", eventID),
-			logger.String("status", status),
-			logger.Err(err),
-		)
+		logger.FromContext(ctx).Error("failed to restore event", logger.Int64("event_id", eventID), logger.Err(err))
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
 
-	r.redis.Del(ctx, "events:list_all")
+	afterCommit(ctx, func() { r.invalidateEvent(ctx, eventID) })
+	logger.FromContext(ctx).Info("event restored", logger.Int64("event_id", eventID))
+	return nil
+}
 
-	logger.Info("event status updated",
-		logger.Int64("event_id", eventID),
-		logger.String("status", status),
+func (r *eventRepository) IsEventCancelled(ctx context.Context, eventID int64) (bool, error) {
+	var cancelled bool
+	query := `SELECT status = $1 AND deleted_at IS NOT NULL FROM events WHERE event_id = $2`
+	if err := conn(ctx, r.db).QueryRow(ctx, query, entity.EventStatusCancelled, eventID).Scan(&cancelled); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to read event cancellation", logger.Int64("event_id", eventID), logger.Err(err))
+		return false, err
+	}
+	return cancelled, nil
+}
+
+// invalidateEvent drops every cached view of the event, including the list.
+func (r *eventRepository) invalidateEvent(ctx context.Context, eventID int64) {
+	r.cache.Invalidate(ctx,
+		eventsCacheKey,
+		fmt.Sprintf("events:detail:%d", eventID),
+		fmt.Sprintf("events:availability:%d", eventID),
 	)
+}
+
+func (r *eventRepository) SetTicketLimit(ctx context.Context, eventID int64, limit *int) error {
+	logger.FromContext(ctx).Debug("setting event ticket limit", logger.Int64("event_id", eventID))
+
+	tag, err := r.db.Exec(ctx, `UPDATE events SET max_tickets_per_user = $1, updated_at = NOW() WHERE event_id = $2`, limit, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to set event ticket limit", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	r.cache.Invalidate(ctx, fmt.Sprintf("events:detail:%d", eventID))
+	logger.FromContext(ctx).Info("event ticket limit set", logger.Int64("event_id", eventID))
+	return nil
+}
+
+func (r *eventRepository) SetBookingRateLimit(ctx context.Context, eventID int64, limit *int) error {
+	logger.FromContext(ctx).Debug("setting event booking rate limit", logger.Int64("event_id", eventID))
+
+	tag, err := r.db.Exec(ctx, `UPDATE events SET booking_rate_limit = $1, updated_at = NOW() WHERE event_id = $2`, limit, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to set event booking rate limit", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	r.cache.Invalidate(ctx, fmt.Sprintf("events:detail:%d", eventID))
+	logger.FromContext(ctx).Info("event booking rate limit set", logger.Int64("event_id", eventID))
 	return nil
 }
 
-func (r *eventRepository) GetEventsWithSearch(ctx context.Context, search string, page, limit int) ([]entity.Event, int, error) {
-	logger.Debug("searching events",
-		logger.String("search", search),
+func (r *eventRepository) SetRefundPolicy(ctx context.Context, eventID int64, policy *entity.RefundPolicy) error {
+	logger.FromContext(ctx).Debug("setting event refund policy", logger.Int64("event_id", eventID))
+
+	tag, err := r.db.Exec(ctx, `UPDATE events SET refund_policy = $1, updated_at = NOW() WHERE event_id = $2`, policy, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to set event refund policy", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	r.cache.Invalidate(ctx, fmt.Sprintf("events:detail:%d", eventID))
+	logger.FromContext(ctx).Info("event refund policy set", logger.Int64("event_id", eventID))
+	return nil
+}
+
+// eventFilterWhere builds the WHERE clause of filter with numbered
+// placeholders for its values.
+func eventFilterWhere(filter entity.EventFilter) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, strings.ReplaceAll(cond, "?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	if !filter.IncludeDeleted {
+		conds = append(conds, "e.deleted_at IS NULL")
+	}
+	if filter.Query != "" {
+		add("e.search_vector @@ websearch_to_tsquery('simple', ?)", filter.Query)
+	}
+	if filter.Search != "" {
+		add("e.name ILIKE ?", "%"+filter.Search+"%")
+	}
+	if filter.Location != "" {
+		add("e.location ILIKE ?", "%"+filter.Location+"%")
+	}
+	if filter.Category != "" {
+		add("LOWER(e.category) = LOWER(?)", filter.Category)
+	}
+	if filter.Status != "" {
+		add("e.status::text = ?", string(filter.Status))
+	}
+	if filter.DateFrom != nil {
+		add("e.date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		add("e.date <= ?", *filter.DateTo)
+	}
+	if filter.MinPrice != nil || filter.MaxPrice != nil {
+		// General admission events have one price, seated events one per seat
+		var minPrice, maxPrice float64
+		if filter.MinPrice != nil {
+			minPrice = *filter.MinPrice
+		}
+		if filter.MaxPrice != nil {
+			maxPrice = *filter.MaxPrice
+		}
+		args = append(args, minPrice, filter.MaxPrice != nil, maxPrice)
+		lo, hasHi, hi := len(args)-2, len(args)-1, len(args)
+		conds = append(conds, fmt.Sprintf(`CASE WHEN e.admission_mode = 'general'
+			THEN e.general_price >= $%[1]d AND (NOT $%[2]d OR e.general_price <= $%[3]d)
+			ELSE EXISTS (
+				SELECT 1 FROM seats s
+				WHERE s.event_id = e.event_id AND s.price >= $%[1]d AND (NOT $%[2]d OR s.price <= $%[3]d)
+			) END`, lo, hasHi, hi))
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// eventPriceRange joins the lowest and highest ticket price of e as
+// p.min_price and p.max_price: the general admission price, or the range of
+// the seat prices.
+const eventPriceRange = `
+		LEFT JOIN LATERAL (
+			SELECT CASE WHEN e.admission_mode = 'general' THEN NULLIF(e.general_price, 0) ELSE MIN(st.price) END AS min_price,
+				CASE WHEN e.admission_mode = 'general' THEN NULLIF(e.general_price, 0) ELSE MAX(st.price) END AS max_price
+			FROM seats st
+			WHERE st.event_id = e.event_id
+		) p ON TRUE`
+
+func (r *eventRepository) GetEventsWithSearch(ctx context.Context, filter entity.EventFilter, page, limit int) ([]entity.Event, int, error) {
+	logger.FromContext(ctx).Debug("searching events",
+		logger.String("q", filter.Query),
+		logger.String("search", filter.Search),
 		logger.Int("page", page),
 		logger.Int("limit", limit),
 	)
 
-	countQuery := `SELECT COUNT(*) FROM events WHERE name ILIKE $1`
-	searchPattern := "%" + search + "%"
+	where, args := eventFilterWhere(filter)
 
 	var total int
-	err := r.db.QueryRow(ctx, countQuery, searchPattern).Scan(&total)
+	err := r.replica.QueryRow(ctx, `SELECT COUNT(*) FROM events e `+where, args...).Scan(&total)
 	if err != nil {
-		logger.Error("failed to count events", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to count events", logger.Err(err))
 		return nil, 0, err
 	}
 
+	// A full-text query ranks the matches, best first
+	orderBy := "e.created_at DESC, e.event_id DESC"
+	if filter.Query != "" {
+		args = append(args, filter.Query)
+		orderBy = fmt.Sprintf("ts_rank_cd(e.search_vector, websearch_to_tsquery('simple', $%d)) DESC, e.created_at DESC, e.event_id DESC", len(args))
+	}
+
 	offset := (page - 1) * limit
-	query := `
-		SELECT event_id, name, location, date, capacity, COALESCE(status, 'available') as status, created_at, COALESCE(updated_at, created_at) as updated_at
-		FROM events
-		WHERE name ILIKE $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`
+	query := fmt.Sprintf(`
+		SELECT e.event_id, e.name, e.location, COALESCE(e.category, ''), e.date, e.capacity,
+			e.status::text as status, COALESCE(e.image_url, ''),
+			COALESCE(e.description, ''), COALESCE(e.organizer_name, ''),
+			e.created_at, COALESCE(e.updated_at, e.created_at) as updated_at, e.deleted_at,
+			p.min_price, p.max_price
+		FROM events e
+		%s
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, eventPriceRange, where, orderBy, len(args)+1, len(args)+2)
 
-	rows, err := r.db.Query(ctx, query, searchPattern, limit, offset)
+	rows, err := r.replica.Query(ctx, query, append(args, limit, offset)...)
 	if err != nil {
-		logger.Error("failed to query events with search", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to query events with search", logger.Err(err))
 		return nil, 0, err
 	}
 	defer rows.Close()
@@ -280,36 +671,141 @@ func (r *eventRepository) GetEventsWithSearch(ctx context.Context, search string
 	for rows.Next() {
 		var evt entity.Event
 		var status string
-		err := rows.Scan(&evt.ID, &evt.Name, &evt.Location, &evt.Date, &evt.Capacity, &status, &evt.CreatedAt, &evt.UpdatedAt)
+		err := rows.Scan(&evt.ID, &evt.Name, &evt.Location, &evt.Category, &evt.Date, &evt.Capacity, &status, &evt.ImageURL, &evt.Description, &evt.OrganizerName, &evt.CreatedAt, &evt.UpdatedAt, &evt.DeletedAt, &evt.MinPrice, &evt.MaxPrice)
 		if err != nil {
-			logger.Error("failed to scan event row", logger.Err(err))
+			logger.FromContext(ctx).Error("failed to scan event row", logger.Err(err))
 			return nil, 0, err
 		}
 		events = append(events, evt)
 	}
 
-	logger.Debug("events search completed",
-		logger.String("search", search),
+	logger.FromContext(ctx).Debug("events search completed",
+		logger.String("search", filter.Search),
 		logger.Int("total", total),
 		logger.Int("returned", len(events)),
 	)
 	return events, total, nil
 }
 
+// Event list keyset orders. A full-text query sorts by rank first.
+const (
+	eventSortNewest = "created_at"
+	eventSortRank   = "rank"
+)
+
+func (r *eventRepository) GetEventsAfter(ctx context.Context, filter entity.EventFilter, after *entity.PageCursor, limit int) ([]entity.Event, *entity.PageCursor, error) {
+	logger.FromContext(ctx).Debug("paging events by cursor",
+		logger.String("q", filter.Query),
+		logger.String("search", filter.Search),
+		logger.Int("limit", limit),
+	)
+
+	sort := eventSortNewest
+	if filter.Query != "" {
+		sort = eventSortRank
+	}
+	if err := after.CheckSort(sort); err != nil {
+		return nil, nil, err
+	}
+
+	where, args := eventFilterWhere(filter)
+	rank := "0::real"
+	orderBy := "e.created_at DESC, e.event_id DESC"
+	if filter.Query != "" {
+		args = append(args, filter.Query)
+		rank = fmt.Sprintf("ts_rank_cd(e.search_vector, websearch_to_tsquery('simple', $%d))", len(args))
+		orderBy = "rank DESC, " + orderBy
+	}
+	if after != nil {
+		var cond string
+		if sort == eventSortRank {
+			cond, args = keysetAfter(rank+", e.created_at, e.event_id", "<", args, after.Rank, after.CreatedAt, after.ID)
+		} else {
+			cond, args = keysetAfter("e.created_at, e.event_id", "<", args, after.CreatedAt, after.ID)
+		}
+		if where == "" {
+			where = "WHERE " + cond
+		} else {
+			where += " AND " + cond
+		}
+	}
+
+	// One extra row tells whether another page exists
+	query := fmt.Sprintf(`
+		SELECT e.event_id, e.name, e.location, COALESCE(e.category, ''), e.date, e.capacity,
+			COALESCE(e.image_url, ''), COALESCE(e.description, ''), COALESCE(e.organizer_name, ''),
+			e.created_at, COALESCE(e.updated_at, e.created_at) as updated_at, e.deleted_at,
+			p.min_price, p.max_price, %[1]s AS rank
+		FROM events e
+		%[2]s
+		%[3]s
+		ORDER BY %[4]s
+		LIMIT $%[5]d
+	`, rank, eventPriceRange, where, orderBy, len(args)+1)
+
+	rows, err := r.replica.Query(ctx, query, append(args, limit+1)...)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to page events", logger.Err(err))
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var events []entity.Event
+	var ranks []float32
+	for rows.Next() {
+		var evt entity.Event
+		var eventRank float32
+		err := rows.Scan(&evt.ID, &evt.Name, &evt.Location, &evt.Category, &evt.Date, &evt.Capacity, &evt.ImageURL, &evt.Description, &evt.OrganizerName, &evt.CreatedAt, &evt.UpdatedAt, &evt.DeletedAt, &evt.MinPrice, &evt.MaxPrice, &eventRank)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to scan event row", logger.Err(err))
+			return nil, nil, err
+		}
+		events = append(events, evt)
+		ranks = append(ranks, eventRank)
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("failed to page events", logger.Err(err))
+		return nil, nil, err
+	}
+
+	if len(events) <= limit {
+		return events, nil, nil
+	}
+	events = events[:limit]
+	last := events[limit-1]
+	next := &entity.PageCursor{Sort: sort, CreatedAt: last.CreatedAt, ID: last.ID}
+	if sort == eventSortRank {
+		next.Rank = ranks[limit-1]
+	}
+	return events, next, nil
+}
+
 func (r *eventRepository) GetEventWithSeats(ctx context.Context, eventID int64) (*entity.EventWithSeats, error) {
-	logger.Debug("fetching event with seats", logger.Int64("event_id", eventID))
+	logger.FromContext(ctx).Debug("fetching event with seats", logger.Int64("event_id", eventID))
 
 	event, err := r.GetEventByID(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
 
+	// The counter changes with every sale, so it is never served from the event cache
+	if event.IsGeneralAdmission() {
+		var remaining int
+		if err := r.db.QueryRow(ctx, `SELECT COALESCE(ga_remaining, 0) FROM events WHERE event_id = $1`, eventID).Scan(&remaining); err != nil {
+			logger.FromContext(ctx).Error("failed to read general admission capacity", logger.Int64("event_id", eventID), logger.Err(err))
+			return nil, err
+		}
+		event.SetPriceRange(nil)
+		return &entity.EventWithSeats{Event: *event, Seats: []entity.Seat{}, Remaining: &remaining}, nil
+	}
+
 	seats, err := r.GetSeatsByEventID(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
+	event.SetPriceRange(seats)
 
-	logger.Debug("event with seats fetched",
+	logger.FromContext(ctx).Debug("event with seats fetched",
 		logger.Int64("event_id", eventID),
 		logger.Int("seat_count", len(seats)),
 	)
@@ -320,10 +816,18 @@ func (r *eventRepository) GetEventWithSeats(ctx context.Context, eventID int64)
 }
 
 func (r *eventRepository) GetSeatsByEventID(ctx context.Context, eventID int64) ([]entity.Seat, error) {
-	logger.Debug("fetching seats by event ID", logger.Int64("event_id", eventID))
+	return r.seats.GetSeats(ctx, eventID, func(ctx context.Context) ([]entity.Seat, error) {
+		return r.loadSeats(ctx, eventID)
+	})
+}
+
+// loadSeats reads the seat map from the database when it isn't cached.
+func (r *eventRepository) loadSeats(ctx context.Context, eventID int64) ([]entity.Seat, error) {
+	logger.FromContext(ctx).Debug("fetching seats by event ID", logger.Int64("event_id", eventID))
 
 	query := `
-		SELECT seat_id, event_id, seat_number, COALESCE(category, ''), COALESCE(price, 0), is_booked
+		SELECT seat_id, event_id, seat_number, COALESCE(category, ''), COALESCE(price, 0), is_booked,
+			COALESCE(row_label, ''), COALESCE(col_number, 0), pos_x, pos_y, tier_id
 		FROM seats
 		WHERE event_id = $1
 		ORDER BY seat_id
@@ -331,7 +835,7 @@ func (r *eventRepository) GetSeatsByEventID(ctx context.Context, eventID int64)
 
 	rows, err := r.db.Query(ctx, query, eventID)
 	if err != nil {
-		logger.Error("failed to query seats", logger.Int64("event_id", eventID), logger.Err(err))
+		logger.FromContext(ctx).Error("failed to query seats", logger.Int64("event_id", eventID), logger.Err(err))
 		return nil, err
 	}
 	defer rows.Close()
@@ -339,14 +843,538 @@ func (r *eventRepository) GetSeatsByEventID(ctx context.Context, eventID int64)
 	var seats []entity.Seat
 	for rows.Next() {
 		var seat entity.Seat
-		err := rows.Scan(&seat.ID, &seat.EventID, &seat.SeatNumber, &seat.Category, &seat.Price, &seat.IsBooked)
+		err := rows.Scan(&seat.ID, &seat.EventID, &seat.SeatNumber, &seat.Category, &seat.Price, &seat.IsBooked,
+			&seat.Row, &seat.Column, &seat.X, &seat.Y, &seat.TierID)
 		if err != nil {
-			logger.Error("failed to scan seat row", logger.Err(err))
+			logger.FromContext(ctx).Error("failed to scan seat row", logger.Err(err))
 			return nil, err
 		}
 		seats = append(seats, seat)
 	}
 
-	logger.Debug("seats fetched", logger.Int64("event_id", eventID), logger.Int("count", len(seats)))
+	logger.FromContext(ctx).Debug("seats fetched", logger.Int64("event_id", eventID), logger.Int("count", len(seats)))
 	return seats, nil
-}
\ No newline at end of file
+}
+
+func (r *eventRepository) GetSeatsPage(ctx context.Context, eventID, afterID int64, limit int, filter entity.SeatFilter) ([]entity.Seat, error) {
+	logger.FromContext(ctx).Debug("fetching seat page",
+		logger.Int64("event_id", eventID),
+		logger.Int64("after_seat_id", afterID),
+		logger.Int("limit", limit),
+	)
+
+	query := `
+		SELECT seat_id, event_id, seat_number, COALESCE(category, ''), COALESCE(price, 0), is_booked,
+			COALESCE(row_label, ''), COALESCE(col_number, 0), pos_x, pos_y, tier_id
+		FROM seats
+		WHERE event_id = $1 AND seat_id > $2 AND (NOT $3 OR is_booked = FALSE)
+			AND ($4 = '' OR COALESCE(category, '') = $4)
+		ORDER BY seat_id
+		LIMIT $5
+	`
+
+	rows, err := r.replica.Query(ctx, query, eventID, afterID, filter.AvailableOnly, filter.Section, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query seat page", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	seats := make([]entity.Seat, 0, limit)
+	for rows.Next() {
+		var seat entity.Seat
+		if err := rows.Scan(&seat.ID, &seat.EventID, &seat.SeatNumber, &seat.Category, &seat.Price, &seat.IsBooked,
+			&seat.Row, &seat.Column, &seat.X, &seat.Y, &seat.TierID); err != nil {
+			logger.FromContext(ctx).Error("failed to scan seat row", logger.Err(err))
+			return nil, err
+		}
+		seats = append(seats, seat)
+	}
+
+	return seats, rows.Err()
+}
+
+func (r *eventRepository) StreamSeats(ctx context.Context, eventID int64, filter entity.SeatFilter, fn func(entity.Seat) error) error {
+	logger.FromContext(ctx).Debug("streaming seats", logger.Int64("event_id", eventID))
+
+	query := `
+		SELECT seat_id, event_id, seat_number, COALESCE(category, ''), COALESCE(price, 0), is_booked,
+			COALESCE(row_label, ''), COALESCE(col_number, 0), pos_x, pos_y, tier_id
+		FROM seats
+		WHERE event_id = $1 AND (NOT $2 OR is_booked = FALSE)
+			AND ($3 = '' OR COALESCE(category, '') = $3)
+		ORDER BY seat_id
+	`
+
+	rows, err := r.replica.Query(ctx, query, eventID, filter.AvailableOnly, filter.Section)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query seats for streaming", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var seat entity.Seat
+		if err := rows.Scan(&seat.ID, &seat.EventID, &seat.SeatNumber, &seat.Category, &seat.Price, &seat.IsBooked,
+			&seat.Row, &seat.Column, &seat.X, &seat.Y, &seat.TierID); err != nil {
+			logger.FromContext(ctx).Error("failed to scan seat row", logger.Err(err))
+			return err
+		}
+		if err := fn(seat); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("seat stream interrupted", logger.Int64("event_id", eventID), logger.Int("streamed", count), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Debug("seats streamed", logger.Int64("event_id", eventID), logger.Int("count", count))
+	return nil
+}
+
+func (r *eventRepository) GetSectionAvailability(ctx context.Context, eventID int64) ([]entity.SectionAvailability, error) {
+	logger.FromContext(ctx).Debug("fetching section availability", logger.Int64("event_id", eventID))
+
+	query := `
+		SELECT s.section, s.total, s.available, s.held, s.sold, s.min_price, s.max_price, COALESCE(i.image_id, 0)
+		FROM (
+			SELECT COALESCE(st.category, '') AS section,
+				COUNT(*) AS total,
+				COUNT(*) FILTER (WHERE st.is_booked = FALSE) AS available,
+				COUNT(*) FILTER (WHERE st.is_booked AND p.seat_id IS NULL) AS held,
+				COUNT(p.seat_id) AS sold,
+				COALESCE(MIN(st.price), 0) AS min_price,
+				COALESCE(MAX(st.price), 0) AS max_price,
+				MIN(st.seat_id) AS first_seat
+			FROM seats st
+			LEFT JOIN (
+				SELECT DISTINCT bi.seat_id
+				FROM booking_items bi
+				JOIN booking b ON b.booking_id = bi.booking_id
+				WHERE b.event_id = $1 AND b.status = 'PAID'
+			) p ON p.seat_id = st.seat_id AND st.is_booked
+			WHERE st.event_id = $1
+			GROUP BY COALESCE(st.category, '')
+		) s
+		LEFT JOIN section_images i ON i.event_id = $1 AND i.section = s.section
+		ORDER BY s.first_seat
+	`
+
+	rows, err := r.replica.Query(ctx, query, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query section availability", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sections []entity.SectionAvailability
+	for rows.Next() {
+		var sa entity.SectionAvailability
+		if err := rows.Scan(&sa.Section, &sa.Total, &sa.Available, &sa.Held, &sa.Sold, &sa.MinPrice, &sa.MaxPrice, &sa.ImageID); err != nil {
+			logger.FromContext(ctx).Error("failed to scan section availability row", logger.Err(err))
+			return nil, err
+		}
+		sections = append(sections, sa)
+	}
+
+	return sections, rows.Err()
+}
+
+func (r *eventRepository) GetCancellableBookings(ctx context.Context, eventID int64) ([]entity.AffectedBooking, error) {
+	logger.FromContext(ctx).Debug("fetching cancellable bookings", logger.Int64("event_id", eventID))
+
+	return queryAffectedBookings(ctx, r.db, `
+		WHERE b.event_id = $1 AND b.status IN ('PAID', 'PENDING')
+		ORDER BY b.booking_id
+	`, eventID)
+}
+
+func (r *eventRepository) GetEventCapacity(ctx context.Context, eventID int64) (*entity.EventCapacity, error) {
+	logger.FromContext(ctx).Debug("fetching event capacity", logger.Int64("event_id", eventID))
+
+	// General admission bookings carry one seatless item per ticket, so
+	// counting items works for both admission modes. Available comes from
+	// what a new booking can actually take: unflagged seats or the counter.
+	query := `
+		SELECT e.event_id, e.admission_mode, e.capacity,
+			COALESCE(h.sold, 0), COALESCE(h.held, 0), COALESCE(h.lapsed, 0),
+			CASE WHEN e.admission_mode = 'general' THEN COALESCE(e.ga_remaining, 0)
+				ELSE (SELECT COUNT(*) FROM seats s WHERE s.event_id = e.event_id AND s.is_booked = FALSE)
+			END
+		FROM events e
+		LEFT JOIN (
+			SELECT b.event_id,
+				COUNT(*) FILTER (WHERE b.status = 'PAID') AS sold,
+				COUNT(*) FILTER (WHERE b.status = 'PENDING' AND (b.expires_at IS NULL OR b.expires_at > NOW())) AS held,
+				COUNT(*) FILTER (WHERE b.status = 'PENDING' AND b.expires_at <= NOW()) AS lapsed
+			FROM booking b
+			JOIN booking_items bi ON bi.booking_id = b.booking_id
+			WHERE b.event_id = $1 AND NOT b.ga_released
+			GROUP BY b.event_id
+		) h ON h.event_id = e.event_id
+		WHERE e.event_id = $1
+	`
+
+	var c entity.EventCapacity
+	err := r.db.QueryRow(ctx, query, eventID).Scan(
+		&c.EventID, &c.AdmissionMode, &c.Capacity,
+		&c.Sold, &c.Held, &c.LapsedHolds, &c.Available,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch event capacity", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+
+	c.Release, err = scanSalesRelease(r.db.QueryRow(ctx, salesReleaseQuery, eventID))
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to fetch sales release", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	if c.Release != nil && c.Release.Remaining() < c.Available {
+		c.Available = c.Release.Remaining()
+	}
+	return &c, nil
+}
+
+func (r *eventRepository) GetPublicAvailability(ctx context.Context, eventID int64) (*entity.PublicAvailability, error) {
+	key := fmt.Sprintf("events:availability:%d", eventID)
+	var a entity.PublicAvailability
+	if cachedData, err := r.redis.Get(ctx, key).Result(); err == nil {
+		if err := json.Unmarshal([]byte(cachedData), &a); err == nil {
+			return &a, nil
+		}
+	}
+
+	logger.FromContext(ctx).Debug("computing public availability", logger.Int64("event_id", eventID))
+
+	query := `
+		SELECT e.event_id, e.status::text, e.capacity,
+			CASE WHEN e.admission_mode = 'general' THEN COALESCE(e.ga_remaining, 0) ELSE COALESCE(s.available, 0) END,
+			CASE WHEN e.admission_mode = 'general' THEN e.general_price ELSE s.min_price END,
+			CASE WHEN e.admission_mode = 'general' THEN e.general_price ELSE s.max_price END,
+			NOW()
+		FROM events e
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS available, MIN(price) AS min_price, MAX(price) AS max_price
+			FROM seats
+			WHERE event_id = e.event_id AND is_booked = FALSE
+		) s ON e.admission_mode <> 'general'
+		WHERE e.event_id = $1 AND e.deleted_at IS NULL
+	`
+	err := r.db.QueryRow(ctx, query, eventID).Scan(&a.EventID, &a.Status, &a.Capacity, &a.Available, &a.MinPrice, &a.MaxPrice, &a.AsOf)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to compute public availability", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	release, err := scanSalesRelease(r.db.QueryRow(ctx, salesReleaseQuery, eventID))
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to fetch sales release", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	if release != nil {
+		if release.Remaining() < a.Available {
+			a.Available = release.Remaining()
+		}
+		a.NextReleaseAt = release.NextReleaseAt
+	}
+	if a.Available == 0 {
+		a.MinPrice, a.MaxPrice = nil, nil
+	}
+
+	if data, err := json.Marshal(a); err == nil {
+		r.redis.Set(ctx, key, data, publicAvailabilityTTL)
+	}
+	return &a, nil
+}
+
+// salesReleaseQuery reads how far an event's waves have got: the tickets
+// of the waves started so far, the tickets in PAID and PENDING bookings and
+// when the next wave starts. It returns no row when the event has no waves.
+const salesReleaseQuery = `
+	SELECT COALESCE(SUM(w.quantity) FILTER (WHERE w.starts_at <= NOW()), 0),
+		(
+			SELECT COUNT(*)
+			FROM booking b
+			JOIN booking_items bi ON bi.booking_id = b.booking_id
+			WHERE b.event_id = $1 AND b.status IN ('PAID', 'PENDING')
+		),
+		MIN(w.starts_at) FILTER (WHERE w.starts_at > NOW())
+	FROM event_sales_waves w
+	WHERE w.event_id = $1
+	HAVING COUNT(*) > 0
+`
+
+// scanSalesRelease reads a salesReleaseQuery row, or nil when the event
+// sells without waves.
+func scanSalesRelease(row pgx.Row) (*entity.SalesRelease, error) {
+	var release entity.SalesRelease
+	if err := row.Scan(&release.Released, &release.Taken, &release.NextReleaseAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &release, nil
+}
+
+func (r *eventRepository) GetSalesWaves(ctx context.Context, eventID int64) (*entity.SalesWaveSchedule, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM events WHERE event_id = $1)`, eventID).Scan(&exists); err != nil {
+		logger.FromContext(ctx).Error("failed to check event", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	if !exists {
+		return nil, entity.ErrNotFound
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT wave_id, event_id, starts_at, quantity
+		FROM event_sales_waves
+		WHERE event_id = $1
+		ORDER BY starts_at
+	`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query sales waves", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedule := &entity.SalesWaveSchedule{EventID: eventID, Waves: []entity.SalesWave{}}
+	for rows.Next() {
+		var w entity.SalesWave
+		if err := rows.Scan(&w.ID, &w.EventID, &w.StartsAt, &w.Quantity); err != nil {
+			logger.FromContext(ctx).Error("failed to scan sales wave", logger.Err(err))
+			return nil, err
+		}
+		schedule.Waves = append(schedule.Waves, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	schedule.Release, err = scanSalesRelease(r.db.QueryRow(ctx, salesReleaseQuery, eventID))
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to fetch sales release", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (r *eventRepository) ReplaceSalesWaves(ctx context.Context, eventID int64, waves []entity.SalesWave) error {
+	logger.FromContext(ctx).Debug("replacing sales waves", logger.Int64("event_id", eventID), logger.Int("waves", len(waves)))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Bookings of the event check the release under the same lock
+	tag, err := tx.Exec(ctx, `SELECT 1 FROM events WHERE event_id = $1 FOR UPDATE`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to lock event", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM event_sales_waves WHERE event_id = $1`, eventID); err != nil {
+		logger.FromContext(ctx).Error("failed to clear sales waves", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	for i := range waves {
+		waves[i].EventID = eventID
+		err := tx.QueryRow(ctx, `
+			INSERT INTO event_sales_waves (event_id, starts_at, quantity)
+			VALUES ($1, $2, $3)
+			RETURNING wave_id
+		`, eventID, waves[i].StartsAt, waves[i].Quantity).Scan(&waves[i].ID)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to insert sales wave", logger.Int64("event_id", eventID), logger.Err(err))
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	r.redis.Del(ctx, fmt.Sprintf("events:availability:%d", eventID))
+	return nil
+}
+
+func (r *eventRepository) GetSectionImageIDs(ctx context.Context, eventID int64) (map[string]int64, error) {
+	rows, err := r.db.Query(ctx, `SELECT section, image_id FROM section_images WHERE event_id = $1`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query section image IDs", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]int64)
+	for rows.Next() {
+		var section string
+		var id int64
+		if err := rows.Scan(&section, &id); err != nil {
+			logger.FromContext(ctx).Error("failed to scan section image ID", logger.Err(err))
+			return nil, err
+		}
+		ids[section] = id
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *eventRepository) UpdateSeatLayout(ctx context.Context, eventID int64, layout *entity.SeatLayout) error {
+	logger.FromContext(ctx).Debug("updating seat layout",
+		logger.Int64("event_id", eventID),
+		logger.Int("seats", len(layout.Seats)),
+	)
+
+	numbers := make([]string, len(layout.Seats))
+	sections := make([]string, len(layout.Seats))
+	rowLabels := make([]string, len(layout.Seats))
+	columns := make([]int32, len(layout.Seats))
+	xs := make([]*float64, len(layout.Seats))
+	ys := make([]*float64, len(layout.Seats))
+	for i, p := range layout.Seats {
+		numbers[i] = p.SeatNumber
+		sections[i] = p.Section
+		rowLabels[i] = p.Row
+		columns[i] = int32(p.Column)
+		xs[i] = p.X
+		ys[i] = p.Y
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// One statement for the whole map; a stadium layout is tens of thousands of seats
+	tag, err := tx.Exec(ctx, `
+		UPDATE seats s
+		SET category = COALESCE(NULLIF(l.section, ''), s.category),
+			row_label = NULLIF(l.row_label, ''),
+			col_number = NULLIF(l.col_number, 0),
+			pos_x = l.pos_x,
+			pos_y = l.pos_y
+		FROM unnest($2::text[], $3::text[], $4::text[], $5::int[], $6::float8[], $7::float8[])
+			AS l(seat_number, section, row_label, col_number, pos_x, pos_y)
+		WHERE s.event_id = $1 AND s.seat_number = l.seat_number
+	`, eventID, numbers, sections, rowLabels, columns, xs, ys)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to update seat layout", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() != int64(len(layout.Seats)) {
+		logger.FromContext(ctx).Warn("seat layout references unknown seats",
+			logger.Int64("event_id", eventID),
+			logger.Int("placements", len(layout.Seats)),
+			logger.Int64("matched", tag.RowsAffected()),
+		)
+		return fmt.Errorf("%w: %d of %d seats are not seats of this event", entity.ErrInvalidSeatLayout, int64(len(layout.Seats))-tag.RowsAffected(), len(layout.Seats))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit transaction", logger.Err(err))
+		return err
+	}
+	r.seats.Invalidate(ctx, eventID)
+
+	logger.FromContext(ctx).Info("seat layout updated", logger.Int64("event_id", eventID), logger.Int("seats", len(layout.Seats)))
+	return nil
+}
+
+func (r *eventRepository) UpdateEventContent(ctx context.Context, eventID int64, content *entity.EventContent) error {
+	logger.FromContext(ctx).Debug("updating event content", logger.Int64("event_id", eventID))
+
+	tag, err := r.db.Exec(ctx, `UPDATE events SET content = $1, updated_at = NOW() WHERE event_id = $2`, content, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to update event content", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	r.cache.Invalidate(ctx, fmt.Sprintf("events:detail:%d", eventID))
+
+	logger.FromContext(ctx).Info("event content updated", logger.Int64("event_id", eventID))
+	return nil
+}
+
+func (r *eventRepository) SetEventImage(ctx context.Context, eventID int64, key, url string) (string, error) {
+	logger.FromContext(ctx).Debug("setting event image", logger.Int64("event_id", eventID))
+
+	query := `
+		UPDATE events e SET image_key = $1, image_url = $2, updated_at = NOW()
+		FROM (SELECT image_key FROM events WHERE event_id = $3 FOR UPDATE) old
+		WHERE e.event_id = $3
+		RETURNING COALESCE(old.image_key, '')
+	`
+	var oldKey string
+	err := r.db.QueryRow(ctx, query, key, url, eventID).Scan(&oldKey)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to set event image", logger.Int64("event_id", eventID), logger.Err(err))
+		return "", err
+	}
+
+	r.cache.Invalidate(ctx, eventsCacheKey, fmt.Sprintf("events:detail:%d", eventID))
+
+	logger.FromContext(ctx).Info("event image set", logger.Int64("event_id", eventID))
+	return oldKey, nil
+}
+
+func (r *eventRepository) SubscribeSeatUpdates(ctx context.Context, eventID int64) (<-chan entity.SeatUpdate, error) {
+	sub := r.redis.Subscribe(ctx, seatUpdatesChannel(eventID))
+	// Wait for the confirmation so no update published from here on is missed
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		logger.FromContext(ctx).Error("failed to subscribe to seat updates", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+
+	updates := make(chan entity.SeatUpdate)
+	go func() {
+		defer close(updates)
+		defer sub.Close()
+
+		messages := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var update entity.SeatUpdate
+				if err := json.Unmarshal([]byte(msg.Payload), &update); err != nil {
+					logger.FromContext(ctx).Warn("invalid seat update message", logger.Int64("event_id", eventID), logger.Err(err))
+					continue
+				}
+				select {
+				case updates <- update:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return updates, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BenchmarkSeatCreation compares creating a venue's seats one INSERT at a
+// time, as events used to, with copySeats. It needs a migrated database:
+//
+//	BENCH_DATABASE_URL=postgres://... go test ./internal/repository -run '^$' -bench SeatCreation
+//
+// Every iteration rolls back, so the database is left untouched.
+func BenchmarkSeatCreation(b *testing.B) {
+	dsn := os.Getenv("BENCH_DATABASE_URL")
+	if dsn == "" {
+		b.Skip("BENCH_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer pool.Close()
+
+	const capacity = 5000
+	price := 150000.0
+	numbering := &entity.SeatNumbering{
+		Scheme:   entity.SeatSchemeSections,
+		Sections: []entity.SeatSection{{Name: "Floor", Rows: 50, SeatsPerRow: 100}},
+	}
+
+	run := func(b *testing.B, insert func(tx pgx.Tx, event *entity.Event) error) {
+		for i := 0; i < b.N; i++ {
+			tx, err := pool.Begin(ctx)
+			if err != nil {
+				b.Fatal(err)
+			}
+			event := &entity.Event{Name: "Benchmark", Capacity: capacity, SeatNumbering: numbering}
+			err = tx.QueryRow(ctx, `INSERT INTO events (name, date, capacity) VALUES ($1, $2, $3) RETURNING event_id`,
+				event.Name, time.Now().Add(24*time.Hour), event.Capacity).Scan(&event.ID)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := insert(tx, event); err != nil {
+				b.Fatal(err)
+			}
+			tx.Rollback(ctx)
+		}
+	}
+
+	b.Run("RowByRow", func(b *testing.B) {
+		run(b, func(tx pgx.Tx, event *entity.Event) error {
+			query := `
+				INSERT INTO seats (event_id, seat_number, category, row_label, col_number, price, is_booked)
+				VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, 0), $6, False)
+			`
+			for i := 1; i <= event.Capacity; i++ {
+				seatNum, section, err := event.SeatNumbering.SeatLabel(event.ID, i)
+				if err != nil {
+					return err
+				}
+				row, column := event.SeatNumbering.SeatRowColumn(i)
+				if _, err := tx.Exec(ctx, query, event.ID, seatNum, section, row, column, price); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+
+	b.Run("Copy", func(b *testing.B) {
+		run(b, func(tx pgx.Tx, event *entity.Event) error {
+			return copySeats(ctx, tx, event, 1, event.Capacity, &price)
+		})
+	})
+}
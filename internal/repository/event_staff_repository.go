@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EventStaffRepository interface {
+	// GrantAccess gives a staff account one scope on an event. It fails with
+	// ErrNotFound if the user does not exist, ErrNotStaffAccount if the user
+	// is not staff and ErrStaffAccessExists if the scope is already granted.
+	GrantAccess(ctx context.Context, grant *entity.EventStaff) error
+	// RevokeAccess removes one scope, or ErrNotFound if it was not granted.
+	RevokeAccess(ctx context.Context, eventID, userID int64, scope string) error
+	ListEventStaff(ctx context.Context, eventID int64) ([]entity.EventStaff, error)
+	// ListStaffEvents returns the grants of one staff account, soonest event
+	// first.
+	ListStaffEvents(ctx context.Context, userID int64) ([]entity.EventStaff, error)
+	HasAccess(ctx context.Context, eventID, userID int64, scope string) (bool, error)
+}
+
+type eventStaffRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewEventStaffRepository(db *pgxpool.Pool) EventStaffRepository {
+	return &eventStaffRepository{db: db}
+}
+
+func (r *eventStaffRepository) GrantAccess(ctx context.Context, grant *entity.EventStaff) error {
+	logger.FromContext(ctx).Debug("granting event staff access",
+		logger.Int64("event_id", grant.EventID),
+		logger.Int64("user_id", grant.UserID),
+		logger.String("scope", grant.Scope),
+	)
+
+	var role string
+	err := r.db.QueryRow(ctx, `SELECT role FROM users WHERE user_id = $1`, grant.UserID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to load staff user", logger.Int64("user_id", grant.UserID), logger.Err(err))
+		return err
+	}
+	if role != "staff" {
+		return entity.ErrNotStaffAccount
+	}
+
+	query := `
+		INSERT INTO event_staff (event_id, user_id, scope, granted_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, user_id, scope) DO NOTHING
+		RETURNING granted_at
+	`
+	err = r.db.QueryRow(ctx, query, grant.EventID, grant.UserID, grant.Scope, grant.GrantedBy).Scan(&grant.GrantedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrStaffAccessExists
+		}
+		logger.FromContext(ctx).Error("failed to insert event staff", logger.Int64("event_id", grant.EventID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *eventStaffRepository) RevokeAccess(ctx context.Context, eventID, userID int64, scope string) error {
+	logger.FromContext(ctx).Debug("revoking event staff access",
+		logger.Int64("event_id", eventID),
+		logger.Int64("user_id", userID),
+		logger.String("scope", scope),
+	)
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM event_staff WHERE event_id = $1 AND user_id = $2 AND scope = $3`, eventID, userID, scope)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to delete event staff", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+const eventStaffSelect = `
+	SELECT es.event_id, e.name, es.user_id, u.name, u.email, es.scope, es.granted_by, es.granted_at
+	FROM event_staff es
+	JOIN events e ON e.event_id = es.event_id
+	JOIN users u ON u.user_id = es.user_id
+`
+
+func (r *eventStaffRepository) listGrants(ctx context.Context, query string, arg int64) ([]entity.EventStaff, error) {
+	rows, err := r.db.Query(ctx, query, arg)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query event staff", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := []entity.EventStaff{}
+	for rows.Next() {
+		var g entity.EventStaff
+		if err := rows.Scan(&g.EventID, &g.EventName, &g.UserID, &g.UserName, &g.UserEmail, &g.Scope, &g.GrantedBy, &g.GrantedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan event staff row", logger.Err(err))
+			return nil, err
+		}
+		grants = append(grants, g)
+	}
+
+	return grants, rows.Err()
+}
+
+func (r *eventStaffRepository) ListEventStaff(ctx context.Context, eventID int64) ([]entity.EventStaff, error) {
+	logger.FromContext(ctx).Debug("listing event staff", logger.Int64("event_id", eventID))
+	return r.listGrants(ctx, eventStaffSelect+` WHERE es.event_id = $1 ORDER BY u.name, es.scope`, eventID)
+}
+
+func (r *eventStaffRepository) ListStaffEvents(ctx context.Context, userID int64) ([]entity.EventStaff, error) {
+	logger.FromContext(ctx).Debug("listing staff events", logger.Int64("user_id", userID))
+	return r.listGrants(ctx, eventStaffSelect+` WHERE es.user_id = $1 ORDER BY e.date, es.scope`, userID)
+}
+
+func (r *eventStaffRepository) HasAccess(ctx context.Context, eventID, userID int64, scope string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM event_staff WHERE event_id = $1 AND user_id = $2 AND scope = $3)
+	`, eventID, userID, scope).Scan(&ok)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to check event staff access", logger.Int64("event_id", eventID), logger.Err(err))
+		return false, err
+	}
+	return ok, nil
+}
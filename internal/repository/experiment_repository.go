@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExperimentRepository interface {
+	CreateExperiment(ctx context.Context, exp *entity.PricingExperiment) error
+	GetExperimentByID(ctx context.Context, experimentID int64) (*entity.PricingExperiment, error)
+	GetActiveExperimentByEvent(ctx context.Context, eventID int64) (*entity.PricingExperiment, error)
+	GetExperimentsByEvent(ctx context.Context, eventID int64) ([]entity.PricingExperiment, error)
+	StopExperiment(ctx context.Context, experimentID int64) error
+	RecordExposure(ctx context.Context, experimentID, variantID, userID int64) error
+	GetVariantResults(ctx context.Context, experimentID int64) ([]entity.VariantResult, error)
+}
+
+type experimentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewExperimentRepository(db *pgxpool.Pool) ExperimentRepository {
+	return &experimentRepository{db: db}
+}
+
+const experimentSelect = `
+	SELECT experiment_id, event_id, name, status, COALESCE(created_by, 0), created_at, ended_at
+	FROM pricing_experiments
+`
+
+func scanExperiment(row pgx.Row) (*entity.PricingExperiment, error) {
+	var e entity.PricingExperiment
+	if err := row.Scan(&e.ID, &e.EventID, &e.Name, &e.Status, &e.CreatedBy, &e.CreatedAt, &e.EndedAt); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// CreateExperiment inserts the experiment and its variants in one transaction.
+func (r *experimentRepository) CreateExperiment(ctx context.Context, exp *entity.PricingExperiment) error {
+	logger.FromContext(ctx).Debug("creating pricing experiment", logger.Int64("event_id", exp.EventID), logger.String("name", exp.Name))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO pricing_experiments (event_id, name, status, created_by)
+		VALUES ($1, $2, 'ACTIVE', NULLIF($3, 0))
+		RETURNING experiment_id, status, created_at
+	`, exp.EventID, exp.Name, exp.CreatedBy).Scan(&exp.ID, &exp.Status, &exp.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			logger.FromContext(ctx).Warn("event already has an active experiment", logger.Int64("event_id", exp.EventID))
+			return entity.ErrExperimentActive
+		}
+		logger.FromContext(ctx).Error("failed to create pricing experiment", logger.Int64("event_id", exp.EventID), logger.Err(err))
+		return err
+	}
+
+	for i := range exp.Variants {
+		v := &exp.Variants[i]
+		v.ExperimentID = exp.ID
+		err := tx.QueryRow(ctx, `
+			INSERT INTO pricing_variants (experiment_id, variant_key, weight, price_multiplier, fee_display)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING variant_id
+		`, exp.ID, v.Key, v.Weight, v.PriceMultiplier, v.FeeDisplay).Scan(&v.ID)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to create pricing variant",
+				logger.Int64("experiment_id", exp.ID),
+				logger.String("key", v.Key),
+				logger.Err(err),
+			)
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit pricing experiment", logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("pricing experiment created",
+		logger.Int64("experiment_id", exp.ID),
+		logger.Int64("event_id", exp.EventID),
+		logger.Int("variants", len(exp.Variants)),
+	)
+	return nil
+}
+
+func (r *experimentRepository) GetExperimentByID(ctx context.Context, experimentID int64) (*entity.PricingExperiment, error) {
+	logger.FromContext(ctx).Debug("fetching pricing experiment", logger.Int64("experiment_id", experimentID))
+
+	exp, err := scanExperiment(r.db.QueryRow(ctx, experimentSelect+` WHERE experiment_id = $1`, experimentID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch pricing experiment", logger.Int64("experiment_id", experimentID), logger.Err(err))
+		return nil, err
+	}
+	if err := r.loadVariants(ctx, exp); err != nil {
+		return nil, err
+	}
+	return exp, nil
+}
+
+func (r *experimentRepository) GetActiveExperimentByEvent(ctx context.Context, eventID int64) (*entity.PricingExperiment, error) {
+	exp, err := scanExperiment(r.db.QueryRow(ctx, experimentSelect+` WHERE event_id = $1 AND status = 'ACTIVE'`, eventID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch active pricing experiment", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	if err := r.loadVariants(ctx, exp); err != nil {
+		return nil, err
+	}
+	return exp, nil
+}
+
+func (r *experimentRepository) GetExperimentsByEvent(ctx context.Context, eventID int64) ([]entity.PricingExperiment, error) {
+	logger.FromContext(ctx).Debug("fetching pricing experiments", logger.Int64("event_id", eventID))
+
+	rows, err := r.db.Query(ctx, experimentSelect+` WHERE event_id = $1 ORDER BY created_at DESC`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query pricing experiments", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+
+	var experiments []entity.PricingExperiment
+	for rows.Next() {
+		exp, err := scanExperiment(rows)
+		if err != nil {
+			rows.Close()
+			logger.FromContext(ctx).Error("failed to scan pricing experiment row", logger.Err(err))
+			return nil, err
+		}
+		experiments = append(experiments, *exp)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range experiments {
+		if err := r.loadVariants(ctx, &experiments[i]); err != nil {
+			return nil, err
+		}
+	}
+	return experiments, nil
+}
+
+func (r *experimentRepository) StopExperiment(ctx context.Context, experimentID int64) error {
+	logger.FromContext(ctx).Debug("stopping pricing experiment", logger.Int64("experiment_id", experimentID))
+
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE pricing_experiments SET status = 'STOPPED', ended_at = NOW()
+		WHERE experiment_id = $1 AND status = 'ACTIVE'
+	`, experimentID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to stop pricing experiment", logger.Int64("experiment_id", experimentID), logger.Err(err))
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrExperimentNotActive
+	}
+
+	logger.FromContext(ctx).Info("pricing experiment stopped", logger.Int64("experiment_id", experimentID))
+	return nil
+}
+
+// RecordExposure stores the first time a user was shown a variant. Later
+// exposures of the same user are ignored.
+func (r *experimentRepository) RecordExposure(ctx context.Context, experimentID, variantID, userID int64) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO pricing_exposures (experiment_id, user_id, variant_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (experiment_id, user_id) DO NOTHING
+	`, experimentID, userID, variantID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to record pricing exposure",
+			logger.Int64("experiment_id", experimentID),
+			logger.Int64("user_id", userID),
+			logger.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// GetVariantResults returns raw exposure, booking and revenue counts per
+// variant. Revenue counts PAID bookings only.
+func (r *experimentRepository) GetVariantResults(ctx context.Context, experimentID int64) ([]entity.VariantResult, error) {
+	logger.FromContext(ctx).Debug("fetching pricing experiment results", logger.Int64("experiment_id", experimentID))
+
+	query := `
+		SELECT v.variant_id, v.variant_key, v.price_multiplier, v.fee_display,
+			(SELECT COUNT(*) FROM pricing_exposures pe WHERE pe.variant_id = v.variant_id),
+			COUNT(b.booking_id),
+			COUNT(b.booking_id) FILTER (WHERE b.status = 'PAID'),
+			COALESCE(SUM(b.total_amount) FILTER (WHERE b.status = 'PAID'), 0)
+		FROM pricing_variants v
+		LEFT JOIN booking b ON b.variant_id = v.variant_id
+		WHERE v.experiment_id = $1
+		GROUP BY v.variant_id
+		ORDER BY v.variant_id
+	`
+	rows, err := r.db.Query(ctx, query, experimentID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query pricing experiment results", logger.Int64("experiment_id", experimentID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.VariantResult
+	for rows.Next() {
+		var res entity.VariantResult
+		if err := rows.Scan(&res.VariantID, &res.Key, &res.PriceMultiplier, &res.FeeDisplay,
+			&res.Exposures, &res.Bookings, &res.PaidBookings, &res.Revenue); err != nil {
+			logger.FromContext(ctx).Error("failed to scan pricing experiment result row", logger.Err(err))
+			return nil, err
+		}
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
+
+func (r *experimentRepository) loadVariants(ctx context.Context, exp *entity.PricingExperiment) error {
+	rows, err := r.db.Query(ctx, `
+		SELECT variant_id, experiment_id, variant_key, weight, price_multiplier, fee_display
+		FROM pricing_variants
+		WHERE experiment_id = $1
+		ORDER BY variant_id
+	`, exp.ID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query pricing variants", logger.Int64("experiment_id", exp.ID), logger.Err(err))
+		return err
+	}
+	defer rows.Close()
+
+	exp.Variants = nil
+	for rows.Next() {
+		var v entity.PricingVariant
+		if err := rows.Scan(&v.ID, &v.ExperimentID, &v.Key, &v.Weight, &v.PriceMultiplier, &v.FeeDisplay); err != nil {
+			logger.FromContext(ctx).Error("failed to scan pricing variant row", logger.Err(err))
+			return err
+		}
+		exp.Variants = append(exp.Variants, v)
+	}
+	return rows.Err()
+}
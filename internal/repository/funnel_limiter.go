@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ticres/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// FunnelLimiter counts booking requests per event in Redis, so every API
+// instance spends the same budget.
+type FunnelLimiter interface {
+	// Take counts a request against the event's budget of perSecond requests
+	// in the current second. It returns how long until the budget refills
+	// when it is already spent, or zero when the request may go ahead.
+	Take(ctx context.Context, eventID int64, perSecond int) (time.Duration, error)
+}
+
+// funnelWindow is the length of a budget window. Counters outlive their
+// window by a little so a slow request can't recreate one without expiry.
+const funnelWindow = time.Second
+
+type funnelLimiter struct {
+	redis *redis.Client
+}
+
+func NewFunnelLimiter(rdb *redis.Client) FunnelLimiter {
+	return &funnelLimiter{redis: rdb}
+}
+
+func funnelKey(eventID int64, window int64) string {
+	return fmt.Sprintf("funnel:event:%d:%d", eventID, window)
+}
+
+func (r *funnelLimiter) Take(ctx context.Context, eventID int64, perSecond int) (time.Duration, error) {
+	now := time.Now()
+	window := now.Truncate(funnelWindow)
+	key := funnelKey(eventID, window.Unix())
+
+	pipe := r.redis.TxPipeline()
+	count := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, 2*funnelWindow)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.FromContext(ctx).Warn("failed to count funnel request", logger.Int64("event_id", eventID), logger.Err(err))
+		return 0, err
+	}
+
+	if count.Val() <= int64(perSecond) {
+		return 0, nil
+	}
+	return window.Add(funnelWindow).Sub(now), nil
+}
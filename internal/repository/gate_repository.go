@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type GateRepository interface {
+	// CreateGate stores a gate with the SHA-256 of its key.
+	CreateGate(ctx context.Context, gate *entity.Gate, keyHash string) error
+	ListGates(ctx context.Context, eventID int64) ([]entity.Gate, error)
+	// RevokeGate disables a gate's key, or returns ErrNotFound if the event
+	// has no such active gate.
+	RevokeGate(ctx context.Context, eventID, gateID int64) error
+	// UseGate returns the active gate with the key hash and stamps its last
+	// use, or ErrNotFound.
+	UseGate(ctx context.Context, keyHash string) (*entity.Gate, error)
+}
+
+type gateRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewGateRepository(db *pgxpool.Pool) GateRepository {
+	return &gateRepository{db: db}
+}
+
+func (r *gateRepository) CreateGate(ctx context.Context, gate *entity.Gate, keyHash string) error {
+	logger.FromContext(ctx).Debug("creating gate", logger.Int64("event_id", gate.EventID), logger.String("name", gate.Name))
+
+	query := `
+		INSERT INTO gates (event_id, name, key_hash, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING gate_id, created_at
+	`
+	err := r.db.QueryRow(ctx, query, gate.EventID, gate.Name, keyHash, gate.CreatedBy).Scan(&gate.ID, &gate.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to insert gate", logger.Int64("event_id", gate.EventID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *gateRepository) ListGates(ctx context.Context, eventID int64) ([]entity.Gate, error) {
+	logger.FromContext(ctx).Debug("listing gates", logger.Int64("event_id", eventID))
+
+	query := `
+		SELECT gate_id, event_id, name, created_by, created_at, last_used_at, revoked_at
+		FROM gates
+		WHERE event_id = $1
+		ORDER BY gate_id
+	`
+	rows, err := r.db.Query(ctx, query, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query gates", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	gates := []entity.Gate{}
+	for rows.Next() {
+		var g entity.Gate
+		if err := rows.Scan(&g.ID, &g.EventID, &g.Name, &g.CreatedBy, &g.CreatedAt, &g.LastUsedAt, &g.RevokedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan gate row", logger.Err(err))
+			return nil, err
+		}
+		gates = append(gates, g)
+	}
+
+	return gates, rows.Err()
+}
+
+func (r *gateRepository) RevokeGate(ctx context.Context, eventID, gateID int64) error {
+	logger.FromContext(ctx).Debug("revoking gate", logger.Int64("event_id", eventID), logger.Int64("gate_id", gateID))
+
+	tag, err := r.db.Exec(ctx, `
+		UPDATE gates SET revoked_at = NOW()
+		WHERE gate_id = $1 AND event_id = $2 AND revoked_at IS NULL
+	`, gateID, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to revoke gate", logger.Int64("gate_id", gateID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// UseGate looks the key up and records the use in one statement, so
+// authenticating a turnstile costs a single round trip.
+func (r *gateRepository) UseGate(ctx context.Context, keyHash string) (*entity.Gate, error) {
+	query := `
+		UPDATE gates SET last_used_at = NOW()
+		WHERE key_hash = $1 AND revoked_at IS NULL
+		RETURNING gate_id, event_id, name, created_by, created_at, last_used_at
+	`
+	var g entity.Gate
+	err := r.db.QueryRow(ctx, query, keyHash).Scan(&g.ID, &g.EventID, &g.Name, &g.CreatedBy, &g.CreatedAt, &g.LastUsedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to look up gate key", logger.Err(err))
+		return nil, err
+	}
+	return &g, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"ticres/pkg/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// HealthRepository checks that the stores the API depends on answer.
+type HealthRepository interface {
+	PingDatabase(ctx context.Context) error
+	PingCache(ctx context.Context) error
+	// CacheCircuitState reports the Redis circuit breaker's state.
+	CacheCircuitState() string
+}
+
+type healthRepository struct {
+	db      *pgxpool.Pool
+	redis   *redis.Client
+	breaker *database.RedisBreaker
+}
+
+func NewHealthRepository(db *pgxpool.Pool, rdb *redis.Client, breaker *database.RedisBreaker) HealthRepository {
+	return &healthRepository{db: db, redis: rdb, breaker: breaker}
+}
+
+func (r *healthRepository) PingDatabase(ctx context.Context) error {
+	return r.db.Ping(ctx)
+}
+
+func (r *healthRepository) PingCache(ctx context.Context) error {
+	return r.redis.Ping(ctx).Err()
+}
+
+func (r *healthRepository) CacheCircuitState() string {
+	return r.breaker.CircuitState()
+}
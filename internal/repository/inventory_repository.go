@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type InventoryRepository interface {
+	// GetInventorySnapshots returns the seat accounting of every event that
+	// is not cancelled and starts after since.
+	GetInventorySnapshots(ctx context.Context, since time.Time) ([]entity.InventorySnapshot, error)
+}
+
+type inventoryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewInventoryRepository(db *pgxpool.Pool) InventoryRepository {
+	return &inventoryRepository{db: db}
+}
+
+func (r *inventoryRepository) GetInventorySnapshots(ctx context.Context, since time.Time) ([]entity.InventorySnapshot, error) {
+	logger.FromContext(ctx).Debug("fetching inventory snapshots")
+
+	// Every aggregate is read in one statement so the counts come from the
+	// same snapshot and can be compared with each other.
+	query := `
+		WITH scope AS (
+			SELECT event_id FROM events
+			WHERE status IS DISTINCT FROM 'cancelled' AND date > $1
+		),
+		held AS (
+			SELECT b.event_id,
+				COUNT(*) AS items,
+				COUNT(DISTINCT bi.seat_id) AS seats,
+				COUNT(DISTINCT bi.seat_id) FILTER (WHERE NOT s.is_booked) AS unflagged
+			FROM booking b
+			JOIN booking_items bi ON bi.booking_id = b.booking_id
+			JOIN seats s ON s.seat_id = bi.seat_id
+			WHERE b.status IN ('PENDING', 'PAID') AND b.event_id IN (SELECT event_id FROM scope)
+			GROUP BY b.event_id
+		),
+		booked AS (
+			SELECT event_id, COUNT(*) AS seats
+			FROM seats
+			WHERE is_booked = TRUE AND event_id IN (SELECT event_id FROM scope)
+			GROUP BY event_id
+		),
+		general AS (
+			SELECT event_id, SUM(ga_quantity) AS sold
+			FROM booking
+			WHERE status IN ('PENDING', 'PAID') AND ga_quantity > 0 AND event_id IN (SELECT event_id FROM scope)
+			GROUP BY event_id
+		)
+		SELECT e.event_id, e.name, e.admission_mode, e.capacity,
+			COALESCE(bk.seats, 0), COALESCE(h.items, 0), COALESCE(h.seats, 0), COALESCE(h.unflagged, 0),
+			COALESCE(g.sold, 0), COALESCE(e.ga_remaining, 0)
+		FROM events e
+		JOIN scope USING (event_id)
+		LEFT JOIN held h ON h.event_id = e.event_id
+		LEFT JOIN booked bk ON bk.event_id = e.event_id
+		LEFT JOIN general g ON g.event_id = e.event_id
+		ORDER BY e.event_id
+	`
+	rows, err := r.db.Query(ctx, query, since)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to fetch inventory snapshots", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []entity.InventorySnapshot
+	for rows.Next() {
+		var s entity.InventorySnapshot
+		if err := rows.Scan(
+			&s.EventID, &s.EventName, &s.AdmissionMode, &s.Capacity,
+			&s.BookedSeats, &s.HeldItems, &s.HeldSeats, &s.UnflaggedSeats,
+			&s.GeneralSold, &s.GeneralRemaining,
+		); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, rows.Err()
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type InvoiceRepository interface {
+	// ListInvoices returns the year's invoices in number order, of one
+	// issuer or, with a nil issuerID, of every issuer.
+	ListInvoices(ctx context.Context, issuerID *int64, year int) ([]entity.Invoice, error)
+	// FindInvoiceGaps returns up to limit numbers missing from the year's
+	// series of every issuer.
+	FindInvoiceGaps(ctx context.Context, year, limit int) ([]entity.InvoiceGap, error)
+}
+
+type invoiceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewInvoiceRepository(db *pgxpool.Pool) InvoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+// issueInvoice gives a completed payment the next number of its issuer's
+// series within tx. The counter row stays locked until tx ends, so numbers
+// are handed out in commit order and a rollback returns the number. A payment
+// that already has an invoice keeps it.
+func issueInvoice(ctx context.Context, tx pgx.Tx, paymentID int64) error {
+	var (
+		amount   float64
+		issuerID int64
+		issued   bool
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT COALESCE(t.amount, 0), COALESCE(e.organizer_id, 0),
+			EXISTS (SELECT 1 FROM invoices i WHERE i.payment_id = t.payment_id)
+		FROM transactions t
+		JOIN booking b ON b.booking_id = t.booking_id
+		JOIN events e ON e.event_id = b.event_id
+		WHERE t.payment_id = $1
+		FOR UPDATE OF t
+	`, paymentID).Scan(&amount, &issuerID, &issued)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to load payment for invoice", logger.Int64("payment_id", paymentID), logger.Err(err))
+		return err
+	}
+	if issued {
+		return nil
+	}
+
+	var year, sequence int
+	err = tx.QueryRow(ctx, `
+		INSERT INTO invoice_sequences (issuer_id, year, last_number)
+		VALUES ($1, EXTRACT(YEAR FROM NOW() AT TIME ZONE 'Asia/Jakarta')::int, 1)
+		ON CONFLICT (issuer_id, year) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+		RETURNING year, last_number
+	`, issuerID).Scan(&year, &sequence)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to take invoice number", logger.Int64("issuer_id", issuerID), logger.Err(err))
+		return err
+	}
+
+	number := entity.FormatInvoiceNumber(issuerID, year, sequence)
+	_, err = tx.Exec(ctx, `
+		INSERT INTO invoices (invoice_number, issuer_id, year, sequence, payment_id, amount)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, number, issuerID, year, sequence, paymentID, amount)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to insert invoice", logger.Int64("payment_id", paymentID), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("invoice issued",
+		logger.Int64("payment_id", paymentID),
+		logger.String("invoice_number", number),
+	)
+	return nil
+}
+
+func (r *invoiceRepository) ListInvoices(ctx context.Context, issuerID *int64, year int) ([]entity.Invoice, error) {
+	logger.FromContext(ctx).Debug("listing invoices", logger.Int("year", year))
+
+	query := `
+		SELECT i.invoice_id, i.invoice_number, i.issuer_id, i.year, i.sequence, i.payment_id,
+			t.booking_id, b.event_id, i.amount, i.issued_at
+		FROM invoices i
+		JOIN transactions t ON t.payment_id = i.payment_id
+		JOIN booking b ON b.booking_id = t.booking_id
+		WHERE i.year = $1 AND ($2::int IS NULL OR i.issuer_id = $2)
+		ORDER BY i.issuer_id, i.sequence
+	`
+	rows, err := r.db.Query(ctx, query, year, issuerID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query invoices", logger.Int("year", year), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	invoices := []entity.Invoice{}
+	for rows.Next() {
+		var inv entity.Invoice
+		if err := rows.Scan(&inv.ID, &inv.Number, &inv.IssuerID, &inv.Year, &inv.Sequence, &inv.PaymentID,
+			&inv.BookingID, &inv.EventID, &inv.Amount, &inv.IssuedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan invoice row", logger.Err(err))
+			return nil, err
+		}
+		invoices = append(invoices, inv)
+	}
+
+	return invoices, rows.Err()
+}
+
+func (r *invoiceRepository) FindInvoiceGaps(ctx context.Context, year, limit int) ([]entity.InvoiceGap, error) {
+	logger.FromContext(ctx).Debug("checking invoice series for gaps", logger.Int("year", year))
+
+	query := `
+		SELECT s.issuer_id, s.year, n
+		FROM invoice_sequences s
+		CROSS JOIN LATERAL generate_series(1, s.last_number) AS n
+		WHERE s.year = $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM invoices i
+			WHERE i.issuer_id = s.issuer_id AND i.year = s.year AND i.sequence = n
+		  )
+		ORDER BY s.issuer_id, n
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, year, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query invoice gaps", logger.Int("year", year), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	gaps := []entity.InvoiceGap{}
+	for rows.Next() {
+		var g entity.InvoiceGap
+		if err := rows.Scan(&g.IssuerID, &g.Year, &g.Sequence); err != nil {
+			logger.FromContext(ctx).Error("failed to scan invoice gap row", logger.Err(err))
+			return nil, err
+		}
+		gaps = append(gaps, g)
+	}
+
+	if len(gaps) > 0 {
+		logger.FromContext(ctx).Warn("invoice series has gaps", logger.Int("year", year), logger.Int("count", len(gaps)))
+	}
+	return gaps, rows.Err()
+}
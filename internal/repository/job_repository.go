@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type JobRepository interface {
+	Enqueue(ctx context.Context, job *entity.Job) error
+	// ClaimJobs locks up to limit due jobs of the lane for this worker.
+	// RUNNING jobs whose lease has expired are claimed again, so work
+	// survives a worker crash.
+	ClaimJobs(ctx context.Context, lane string, limit int, lease time.Duration) ([]entity.Job, error)
+	CompleteJob(ctx context.Context, jobID int64) error
+	RetryJob(ctx context.Context, jobID int64, runAt time.Time, lastError string) error
+	KillJob(ctx context.Context, jobID int64, lastError string) error
+	ListDeadJobs(ctx context.Context, page, limit int) ([]entity.Job, int, error)
+	RequeueDeadJob(ctx context.Context, jobID int64) error
+	// GetJobBacklog counts the stored jobs by state and the outbox rows not
+	// yet dispatched.
+	GetJobBacklog(ctx context.Context) (*entity.JobBacklog, error)
+}
+
+type jobRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewJobRepository(db *pgxpool.Pool) JobRepository {
+	return &jobRepository{db: db}
+}
+
+const jobColumns = `job_id, job_type, lane, payload, status, attempts, max_attempts, run_at, locked_at,
+	COALESCE(last_error, ''), created_at, updated_at`
+
+func scanJob(row pgx.Row) (*entity.Job, error) {
+	var j entity.Job
+	err := row.Scan(&j.ID, &j.Type, &j.Lane, &j.Payload, &j.Status, &j.Attempts, &j.MaxAttempts, &j.RunAt, &j.LockedAt,
+		&j.LastError, &j.CreatedAt, &j.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+func (r *jobRepository) Enqueue(ctx context.Context, job *entity.Job) error {
+	query := `
+		INSERT INTO jobs (job_type, lane, payload, max_attempts)
+		VALUES ($1, $2, $3, $4)
+		RETURNING job_id, status, run_at, created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, job.Type, job.Lane, job.Payload, job.MaxAttempts).
+		Scan(&job.ID, &job.Status, &job.RunAt, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to enqueue job", logger.String("job_type", job.Type), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Debug("job enqueued", logger.Int64("job_id", job.ID), logger.String("job_type", job.Type))
+	return nil
+}
+
+func (r *jobRepository) ClaimJobs(ctx context.Context, lane string, limit int, lease time.Duration) ([]entity.Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'RUNNING', attempts = attempts + 1, locked_at = NOW(), updated_at = NOW()
+		WHERE job_id IN (
+			SELECT job_id FROM jobs
+			WHERE lane = $3 AND ((status = 'PENDING' AND run_at <= NOW())
+				OR (status = 'RUNNING' AND locked_at < NOW() - make_interval(secs => $2)))
+			ORDER BY run_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	rows, err := r.db.Query(ctx, query, limit, lease.Seconds(), lane)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to claim jobs", logger.String("lane", lane), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []entity.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to scan job row", logger.Err(err))
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// CompleteJob removes a finished job. Payloads can hold credentials such as
+// password reset links, so they are not kept once delivered.
+func (r *jobRepository) CompleteJob(ctx context.Context, jobID int64) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM jobs WHERE job_id = $1`, jobID); err != nil {
+		logger.FromContext(ctx).Error("failed to complete job", logger.Int64("job_id", jobID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *jobRepository) RetryJob(ctx context.Context, jobID int64, runAt time.Time, lastError string) error {
+	query := `
+		UPDATE jobs
+		SET status = 'PENDING', run_at = $2, locked_at = NULL, last_error = $3, updated_at = NOW()
+		WHERE job_id = $1
+	`
+	if _, err := r.db.Exec(ctx, query, jobID, runAt, lastError); err != nil {
+		logger.FromContext(ctx).Error("failed to reschedule job", logger.Int64("job_id", jobID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *jobRepository) KillJob(ctx context.Context, jobID int64, lastError string) error {
+	query := `
+		UPDATE jobs
+		SET status = 'DEAD', locked_at = NULL, last_error = $2, updated_at = NOW()
+		WHERE job_id = $1
+	`
+	if _, err := r.db.Exec(ctx, query, jobID, lastError); err != nil {
+		logger.FromContext(ctx).Error("failed to move job to dead letter", logger.Int64("job_id", jobID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *jobRepository) ListDeadJobs(ctx context.Context, page, limit int) ([]entity.Job, int, error) {
+	logger.FromContext(ctx).Debug("listing dead jobs", logger.Int("page", page), logger.Int("limit", limit))
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM jobs WHERE status = 'DEAD'`).Scan(&total); err != nil {
+		logger.FromContext(ctx).Error("failed to count dead jobs", logger.Err(err))
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE status = 'DEAD' ORDER BY updated_at DESC LIMIT $1 OFFSET $2`
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query dead jobs", logger.Err(err))
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var jobs []entity.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to scan job row", logger.Err(err))
+			return nil, 0, err
+		}
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, total, nil
+}
+
+// RequeueDeadJob gives a dead job a fresh set of attempts.
+func (r *jobRepository) RequeueDeadJob(ctx context.Context, jobID int64) error {
+	query := `
+		UPDATE jobs
+		SET status = 'PENDING', attempts = 0, run_at = NOW(), updated_at = NOW()
+		WHERE job_id = $1 AND status = 'DEAD'
+	`
+	cmdTag, err := r.db.Exec(ctx, query, jobID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to requeue dead job", logger.Int64("job_id", jobID), logger.Err(err))
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	logger.FromContext(ctx).Info("dead job requeued", logger.Int64("job_id", jobID))
+	return nil
+}
+
+func (r *jobRepository) GetJobBacklog(ctx context.Context) (*entity.JobBacklog, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'PENDING' AND run_at <= NOW()),
+			COUNT(*) FILTER (WHERE status = 'PENDING' AND run_at > NOW()),
+			COUNT(*) FILTER (WHERE status = 'RUNNING'),
+			COUNT(*) FILTER (WHERE status = 'DEAD'),
+			(SELECT COUNT(*) FROM outbox),
+			MIN(run_at) FILTER (WHERE status = 'PENDING' AND run_at <= NOW())
+		FROM jobs
+	`
+	var b entity.JobBacklog
+	if err := r.db.QueryRow(ctx, query).Scan(&b.Due, &b.Scheduled, &b.Running, &b.Dead, &b.Outbox, &b.OldestDueAt); err != nil {
+		logger.FromContext(ctx).Error("failed to count job backlog", logger.Err(err))
+		return nil, err
+	}
+	return &b, nil
+}
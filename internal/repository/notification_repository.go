@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NotificationRepository interface {
+	// CreateNotification adds a notification to the user's inbox. A second
+	// notification for the same job is ignored, so retried jobs add theirs once.
+	CreateNotification(ctx context.Context, n *entity.Notification) error
+	// ListNotifications returns a page of the user's inbox, newest first,
+	// with the total and unread counts.
+	ListNotifications(ctx context.Context, userID int64, unreadOnly bool, page, limit int) (*entity.NotificationPage, error)
+	// MarkRead marks a notification of the user read. Returns ErrNotFound if
+	// the user has no such notification.
+	MarkRead(ctx context.Context, userID, notificationID int64) error
+}
+
+type notificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) CreateNotification(ctx context.Context, n *entity.Notification) error {
+	args := n.MessageArgs
+	if args == nil {
+		args = []string{}
+	}
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO notifications (user_id, kind, message, message_args, booking_id, job_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (job_id) DO NOTHING
+	`, n.UserID, n.Kind, n.Message, args, n.BookingID, n.JobID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create notification",
+			logger.Int64("user_id", n.UserID),
+			logger.String("kind", n.Kind),
+			logger.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *notificationRepository) ListNotifications(ctx context.Context, userID int64, unreadOnly bool, page, limit int) (*entity.NotificationPage, error) {
+	result := &entity.NotificationPage{Notifications: []entity.Notification{}}
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE NOT $2 OR read_at IS NULL), COUNT(*) FILTER (WHERE read_at IS NULL)
+		FROM notifications
+		WHERE user_id = $1
+	`, userID, unreadOnly).Scan(&result.Total, &result.Unread)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to count notifications", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT notification_id, user_id, kind, message, message_args, booking_id, created_at, read_at
+		FROM notifications
+		WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY notification_id DESC
+		LIMIT $3 OFFSET $4
+	`, userID, unreadOnly, limit, (page-1)*limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query notifications", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var n entity.Notification
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Kind, &n.Message, &n.MessageArgs, &n.BookingID, &n.CreatedAt, &n.ReadAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan notification row", logger.Err(err))
+			return nil, err
+		}
+		result.Notifications = append(result.Notifications, n)
+	}
+	return result, rows.Err()
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, notificationID int64) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE notification_id = $1 AND user_id = $2
+	`, notificationID, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to mark notification read", logger.Int64("notification_id", notificationID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
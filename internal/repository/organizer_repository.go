@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OrganizerRepository interface {
+	CreateApplication(ctx context.Context, app *entity.OrganizerApplication) error
+	GetApplicationByID(ctx context.Context, applicationID int64) (*entity.OrganizerApplication, error)
+	GetLatestApplicationByUserID(ctx context.Context, userID int64) (*entity.OrganizerApplication, error)
+	ListApplications(ctx context.Context, status string, page, limit int) ([]entity.OrganizerApplication, int, error)
+	AddDocument(ctx context.Context, doc *entity.OrganizerDocument) error
+	GetDocumentsByApplicationID(ctx context.Context, applicationID int64) ([]entity.OrganizerDocument, error)
+	ApproveApplication(ctx context.Context, applicationID, reviewerID int64, note string) error
+	RejectApplication(ctx context.Context, applicationID, reviewerID int64, note string) error
+	// ListPlaintextAccountNumbers returns up to limit applications whose bank
+	// account number was stored before numbers were encrypted, with only ID
+	// and BankAccountNumber set.
+	ListPlaintextAccountNumbers(ctx context.Context, limit int) ([]entity.OrganizerApplication, error)
+	// SetEncryptedAccountNumber stores the encrypted number of an application
+	// and clears the plaintext one.
+	SetEncryptedAccountNumber(ctx context.Context, applicationID int64, enc, last4 string) error
+}
+
+type organizerRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOrganizerRepository(db *pgxpool.Pool) OrganizerRepository {
+	return &organizerRepository{db: db}
+}
+
+const applicationSelect = `
+	SELECT a.application_id, a.user_id, u.name, u.email, a.business_name, COALESCE(a.business_type, ''),
+		COALESCE(a.business_address, ''), COALESCE(a.tax_id, ''), COALESCE(a.phone, ''),
+		COALESCE(a.bank_name, ''), COALESCE(a.bank_account_name, ''), COALESCE(a.bank_account_number_enc, ''),
+		COALESCE(a.bank_account_last4, ''),
+		a.status, COALESCE(a.review_note, ''), a.reviewed_by, a.reviewed_at, a.created_at, a.updated_at
+	FROM organizer_applications a
+	JOIN users u ON a.user_id = u.user_id
+`
+
+func scanApplication(row pgx.Row) (*entity.OrganizerApplication, error) {
+	var a entity.OrganizerApplication
+	err := row.Scan(&a.ID, &a.UserID, &a.UserName, &a.UserEmail, &a.BusinessName, &a.BusinessType,
+		&a.BusinessAddress, &a.TaxID, &a.Phone, &a.BankName, &a.BankAccountName, &a.BankAccountNumberEnc,
+		&a.BankAccountLast4, &a.Status, &a.ReviewNote, &a.ReviewedBy, &a.ReviewedAt, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *organizerRepository) CreateApplication(ctx context.Context, app *entity.OrganizerApplication) error {
+	logger.FromContext(ctx).Debug("creating organizer application", logger.Int64("user_id", app.UserID))
+
+	query := `
+		INSERT INTO organizer_applications (user_id, business_name, business_type, business_address, tax_id, phone,
+			bank_name, bank_account_name, bank_account_number_enc, bank_account_last4, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 'PENDING')
+		RETURNING application_id, status, created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, app.UserID, app.BusinessName, app.BusinessType, app.BusinessAddress,
+		app.TaxID, app.Phone, app.BankName, app.BankAccountName, app.BankAccountNumberEnc, app.BankAccountLast4,
+	).Scan(&app.ID, &app.Status, &app.CreatedAt, &app.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			logger.FromContext(ctx).Warn("organizer application already active", logger.Int64("user_id", app.UserID))
+			return entity.ErrApplicationExists
+		}
+		logger.FromContext(ctx).Error("failed to create organizer application", logger.Int64("user_id", app.UserID), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("organizer application created",
+		logger.Int64("application_id", app.ID),
+		logger.Int64("user_id", app.UserID),
+	)
+	return nil
+}
+
+func (r *organizerRepository) GetApplicationByID(ctx context.Context, applicationID int64) (*entity.OrganizerApplication, error) {
+	logger.FromContext(ctx).Debug("fetching organizer application", logger.Int64("application_id", applicationID))
+
+	app, err := scanApplication(r.db.QueryRow(ctx, applicationSelect+` WHERE a.application_id = $1`, applicationID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch organizer application", logger.Int64("application_id", applicationID), logger.Err(err))
+		return nil, err
+	}
+	return app, nil
+}
+
+func (r *organizerRepository) GetLatestApplicationByUserID(ctx context.Context, userID int64) (*entity.OrganizerApplication, error) {
+	logger.FromContext(ctx).Debug("fetching latest organizer application", logger.Int64("user_id", userID))
+
+	query := applicationSelect + ` WHERE a.user_id = $1 ORDER BY a.created_at DESC LIMIT 1`
+	app, err := scanApplication(r.db.QueryRow(ctx, query, userID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch organizer application", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+	return app, nil
+}
+
+func (r *organizerRepository) ListApplications(ctx context.Context, status string, page, limit int) ([]entity.OrganizerApplication, int, error) {
+	logger.FromContext(ctx).Debug("listing organizer applications",
+		logger.String("status", status),
+		logger.Int("page", page),
+		logger.Int("limit", limit),
+	)
+
+	whereClause := ""
+	args := []interface{}{}
+	argIndex := 1
+	if status != "" {
+		whereClause = fmt.Sprintf(" WHERE a.status = $%d", argIndex)
+		args = append(args, status)
+		argIndex++
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM organizer_applications a` + whereClause
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		logger.FromContext(ctx).Error("failed to count organizer applications", logger.Err(err))
+		return nil, 0, err
+	}
+
+	// Oldest first so the review queue is worked in submission order
+	offset := (page - 1) * limit
+	query := applicationSelect + whereClause + fmt.Sprintf(` ORDER BY a.created_at ASC LIMIT $%d OFFSET $%d`, argIndex, argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query organizer applications", logger.Err(err))
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var apps []entity.OrganizerApplication
+	for rows.Next() {
+		app, err := scanApplication(rows)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to scan organizer application row", logger.Err(err))
+			return nil, 0, err
+		}
+		apps = append(apps, *app)
+	}
+
+	return apps, total, nil
+}
+
+func (r *organizerRepository) AddDocument(ctx context.Context, doc *entity.OrganizerDocument) error {
+	logger.FromContext(ctx).Debug("adding organizer document",
+		logger.Int64("application_id", doc.ApplicationID),
+		logger.String("doc_type", doc.DocType),
+	)
+
+	query := `
+		INSERT INTO organizer_documents (application_id, doc_type, file_name, storage_key, content_type, size_bytes)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING document_id, uploaded_at
+	`
+	err := r.db.QueryRow(ctx, query, doc.ApplicationID, doc.DocType, doc.FileName, doc.StorageKey,
+		doc.ContentType, doc.SizeBytes,
+	).Scan(&doc.ID, &doc.UploadedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to add organizer document", logger.Int64("application_id", doc.ApplicationID), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("organizer document added",
+		logger.Int64("document_id", doc.ID),
+		logger.Int64("application_id", doc.ApplicationID),
+	)
+	return nil
+}
+
+func (r *organizerRepository) GetDocumentsByApplicationID(ctx context.Context, applicationID int64) ([]entity.OrganizerDocument, error) {
+	logger.FromContext(ctx).Debug("fetching organizer documents", logger.Int64("application_id", applicationID))
+
+	query := `
+		SELECT document_id, application_id, doc_type, COALESCE(file_name, ''), storage_key,
+			COALESCE(content_type, ''), COALESCE(size_bytes, 0), uploaded_at
+		FROM organizer_documents
+		WHERE application_id = $1
+		ORDER BY document_id
+	`
+	rows, err := r.db.Query(ctx, query, applicationID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query organizer documents", logger.Int64("application_id", applicationID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var docs []entity.OrganizerDocument
+	for rows.Next() {
+		var d entity.OrganizerDocument
+		if err := rows.Scan(&d.ID, &d.ApplicationID, &d.DocType, &d.FileName, &d.StorageKey,
+			&d.ContentType, &d.SizeBytes, &d.UploadedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan organizer document row", logger.Err(err))
+			return nil, err
+		}
+		docs = append(docs, d)
+	}
+
+	return docs, nil
+}
+
+// ApproveApplication marks the application approved and grants the organizer
+// role in the same transaction, so an approved applicant can never be left
+// without the role.
+func (r *organizerRepository) ApproveApplication(ctx context.Context, applicationID, reviewerID int64, note string) error {
+	logger.FromContext(ctx).Debug("approving organizer application",
+		logger.Int64("application_id", applicationID),
+		logger.Int64("reviewer_id", reviewerID),
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	query := `
+		UPDATE organizer_applications
+		SET status = 'APPROVED', review_note = $1, reviewed_by = $2, reviewed_at = NOW(), updated_at = NOW()
+		WHERE application_id = $3 AND status = 'PENDING'
+		RETURNING user_id
+	`
+	if err := tx.QueryRow(ctx, query, note, reviewerID, applicationID).Scan(&userID); err != nil {
+		if err == pgx.ErrNoRows {
+			return entity.ErrApplicationNotPending
+		}
+		logger.FromContext(ctx).Error("failed to approve organizer application", logger.Int64("application_id", applicationID), logger.Err(err))
+		return err
+	}
+
+	// Admins keep their role; everyone else becomes an organizer
+	if _, err := tx.Exec(ctx, `UPDATE users SET role = 'organizer' WHERE user_id = $1 AND role <> 'admin'`, userID); err != nil {
+		logger.FromContext(ctx).Error("failed to grant organizer role", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit application approval", logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("organizer application approved",
+		logger.Int64("application_id", applicationID),
+		logger.Int64("user_id", userID),
+	)
+	return nil
+}
+
+func (r *organizerRepository) RejectApplication(ctx context.Context, applicationID, reviewerID int64, note string) error {
+	logger.FromContext(ctx).Debug("rejecting organizer application",
+		logger.Int64("application_id", applicationID),
+		logger.Int64("reviewer_id", reviewerID),
+	)
+
+	query := `
+		UPDATE organizer_applications
+		SET status = 'REJECTED', review_note = $1, reviewed_by = $2, reviewed_at = NOW(), updated_at = NOW()
+		WHERE application_id = $3 AND status = 'PENDING'
+	`
+	cmdTag, err := r.db.Exec(ctx, query, note, reviewerID, applicationID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to reject organizer application", logger.Int64("application_id", applicationID), logger.Err(err))
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrApplicationNotPending
+	}
+
+	logger.FromContext(ctx).Info("organizer application rejected", logger.Int64("application_id", applicationID))
+	return nil
+}
+
+func (r *organizerRepository) ListPlaintextAccountNumbers(ctx context.Context, limit int) ([]entity.OrganizerApplication, error) {
+	query := `
+		SELECT application_id, bank_account_number
+		FROM organizer_applications
+		WHERE bank_account_number IS NOT NULL
+		ORDER BY application_id
+		LIMIT $1
+	`
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query plaintext account numbers", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apps []entity.OrganizerApplication
+	for rows.Next() {
+		var a entity.OrganizerApplication
+		if err := rows.Scan(&a.ID, &a.BankAccountNumber); err != nil {
+			logger.FromContext(ctx).Error("failed to scan plaintext account number row", logger.Err(err))
+			return nil, err
+		}
+		apps = append(apps, a)
+	}
+	return apps, rows.Err()
+}
+
+func (r *organizerRepository) SetEncryptedAccountNumber(ctx context.Context, applicationID int64, enc, last4 string) error {
+	query := `
+		UPDATE organizer_applications
+		SET bank_account_number_enc = $1, bank_account_last4 = $2, bank_account_number = NULL
+		WHERE application_id = $3
+	`
+	if _, err := r.db.Exec(ctx, query, enc, last4, applicationID); err != nil {
+		logger.FromContext(ctx).Error("failed to store encrypted account number", logger.Int64("application_id", applicationID), logger.Err(err))
+		return err
+	}
+	return nil
+}
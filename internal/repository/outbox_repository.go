@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepository interface {
+	// AddJob stores a job in the outbox. Within a unit of work it commits
+	// or rolls back with the state change that triggered it.
+	AddJob(ctx context.Context, job *entity.Job) error
+	// Dispatch moves up to limit outbox entries into the job queue, oldest
+	// first, and returns how many were moved.
+	Dispatch(ctx context.Context, limit int) (int, error)
+	// Backlog counts the outbox entries not yet dispatched and returns when
+	// the oldest was written, nil when the outbox is empty.
+	Backlog(ctx context.Context) (int, *time.Time, error)
+}
+
+type outboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) AddJob(ctx context.Context, job *entity.Job) error {
+	query := `INSERT INTO outbox (job_type, lane, payload, max_attempts) VALUES ($1, $2, $3, $4)`
+	if _, err := conn(ctx, r.db).Exec(ctx, query, job.Type, job.Lane, job.Payload, job.MaxAttempts); err != nil {
+		logger.FromContext(ctx).Error("failed to add job to outbox", logger.String("job_type", job.Type), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Debug("job added to outbox", logger.String("job_type", job.Type))
+	return nil
+}
+
+// Dispatch deletes the entries and inserts their jobs in one statement, so
+// an entry becomes exactly one job. Locked entries are skipped, letting
+// several instances dispatch at once.
+func (r *outboxRepository) Dispatch(ctx context.Context, limit int) (int, error) {
+	query := `
+		WITH moved AS (
+			DELETE FROM outbox
+			WHERE outbox_id IN (
+				SELECT outbox_id FROM outbox
+				ORDER BY outbox_id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING outbox_id, job_type, lane, payload, max_attempts
+		)
+		INSERT INTO jobs (job_type, lane, payload, max_attempts)
+		SELECT job_type, lane, payload, max_attempts FROM moved ORDER BY outbox_id
+	`
+	tag, err := r.db.Exec(ctx, query, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to dispatch outbox", logger.Err(err))
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *outboxRepository) Backlog(ctx context.Context) (int, *time.Time, error) {
+	var count int
+	var oldest *time.Time
+	query := `SELECT COUNT(*), MIN(created_at) FROM outbox`
+	if err := r.db.QueryRow(ctx, query).Scan(&count, &oldest); err != nil {
+		logger.FromContext(ctx).Error("failed to count outbox backlog", logger.Err(err))
+		return 0, nil, err
+	}
+	return count, oldest, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keysetAfter builds the condition that selects the rows past a keyset
+// cursor, comparing the row of columns with op ("<" for descending order,
+// ">" for ascending) to values. The values are appended to args and
+// numbered after the placeholders already in it.
+func keysetAfter(columns, op string, args []interface{}, values ...interface{}) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	for i, v := range values {
+		args = append(args, v)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+	return fmt.Sprintf("(%s) %s (%s)", columns, op, strings.Join(placeholders, ", ")), args
+}
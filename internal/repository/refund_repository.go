@@ -2,51 +2,138 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"ticres/internal/entity"
 	"ticres/pkg/logger"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type RefundRepository interface {
 	CreateRefund(ctx context.Context, refund *entity.Refund) error
 	GetRefundByBookingID(ctx context.Context, bookingID int64) (*entity.Refund, error)
+	// RefundBooking refunds a PAID booking in one transaction: its completed
+	// payments are marked REFUNDED, the booking REFUNDED and a refund for
+	// Percent of their total is recorded. Amount, ID and date are set on
+	// refund.
+	RefundBooking(ctx context.Context, refund *entity.Refund) error
+
+	ListRefundReasons(ctx context.Context) ([]entity.RefundReason, error)
+	GetRefundReason(ctx context.Context, code string) (*entity.RefundReason, error)
+	CreateRefundReason(ctx context.Context, reason *entity.RefundReason) error
+	// UpdateRefundReason changes the label and active flag of a reason.
+	UpdateRefundReason(ctx context.Context, reason *entity.RefundReason) error
+	// GetRefundSummary totals completed refunds issued in [from, to) per
+	// reason. Active reasons without refunds are included with zero totals.
+	GetRefundSummary(ctx context.Context, from, to time.Time) ([]entity.RefundReasonSummary, error)
+
+	// CreateRefundRequest records a customer's REQUESTED refund. The amount
+	// is Percent of what was paid at request time; it fails with
+	// ErrRefundRequestExists if the booking already has an open request.
+	CreateRefundRequest(ctx context.Context, refund *entity.Refund) error
+	GetRefundRequest(ctx context.Context, refundID int64) (*entity.RefundRequestDetails, error)
+	// ListRefundRequests returns customer refund requests, oldest first,
+	// optionally filtered by status.
+	ListRefundRequests(ctx context.Context, status string) ([]entity.RefundRequestDetails, error)
+	// ReviewRefundRequest moves a request from one status to another and
+	// records the reviewer. It fails with ErrRefundNotReviewable if the
+	// request is no longer in status from.
+	ReviewRefundRequest(ctx context.Context, refundID int64, from, to string, reviewerID *int64, note string) error
+	// CompleteRefundRequest refunds the booking of an APPROVED request in one
+	// transaction, returning the request's percent of what was paid, and
+	// marks the request COMPLETED. If the booking is no
+	// longer PAID the request is REJECTED instead and ErrBookingNotPaid is
+	// returned. A request that is not APPROVED fails with
+	// ErrRefundNotReviewable without changes.
+	CompleteRefundRequest(ctx context.Context, refundID int64) (*entity.Refund, error)
 }
 
 type refundRepository struct {
 	db *pgxpool.Pool
+	// replica serves the refund report
+	replica *pgxpool.Pool
+}
+
+func NewRefundRepository(db, replica *pgxpool.Pool) RefundRepository {
+	return &refundRepository{db: db, replica: replica}
+}
+
+const refundSelect = `
+	SELECT r.refund_id, r.booking_id, COALESCE(r.amount, 0), r.refund_date, r.reason, COALESCE(r.note, ''), r.status,
+		r.requested_at, r.reviewed_by, r.reviewed_at, COALESCE(r.review_note, ''), r.percent
+	FROM refund r
+`
+
+const refundRequestSelect = `
+	SELECT r.refund_id, r.booking_id, COALESCE(r.amount, 0), r.refund_date, r.reason, COALESCE(r.note, ''), r.status,
+		r.requested_at, r.reviewed_by, r.reviewed_at, COALESCE(r.review_note, ''), r.percent,
+		b.user_id, COALESCE(u.name, ''), COALESCE(u.email, ''), b.event_id, COALESCE(e.name, '')
+	FROM refund r
+	JOIN booking b ON b.booking_id = r.booking_id
+	LEFT JOIN users u ON u.user_id = b.user_id
+	LEFT JOIN events e ON e.event_id = b.event_id
+	WHERE r.requested_at IS NOT NULL
+`
+
+func scanRefund(row pgx.Row) (*entity.Refund, error) {
+	var r entity.Refund
+	err := row.Scan(&r.ID, &r.BookingID, &r.Amount, &r.RefundDate, &r.Reason, &r.Note, &r.Status,
+		&r.RequestedAt, &r.ReviewedBy, &r.ReviewedAt, &r.ReviewNote, &r.Percent)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
 }
 
-func NewRefundRepository(db *pgxpool.Pool) RefundRepository {
-	return &refundRepository{db: db}
+func scanRefundRequest(row pgx.Row) (*entity.RefundRequestDetails, error) {
+	var d entity.RefundRequestDetails
+	r := &d.Refund
+	err := row.Scan(&r.ID, &r.BookingID, &r.Amount, &r.RefundDate, &r.Reason, &r.Note, &r.Status,
+		&r.RequestedAt, &r.ReviewedBy, &r.ReviewedAt, &r.ReviewNote, &r.Percent,
+		&d.UserID, &d.UserName, &d.UserEmail, &d.EventID, &d.EventName)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// isUnknownRefundReason reports a refund whose reason is not in the taxonomy.
+func isUnknownRefundReason(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
 }
 
 func (r *refundRepository) CreateRefund(ctx context.Context, refund *entity.Refund) error {
-	logger.Debug("creating refund",
+	logger.FromContext(ctx).Debug("creating refund",
 		logger.Int64("booking_id", refund.BookingID),
 		logger.Float64("amount", refund.Amount),
 		logger.String("reason", refund.Reason),
 	)
 
 	query := `
-		INSERT INTO refund (booking_id, amount, reason, status)
-		VALUES ($1, $2, $3, $4)
-		RETURNING refund_id, refund_date
+		INSERT INTO refund (booking_id, amount, reason, note, status, refund_date)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, NOW())
+		RETURNING refund_id, refund_date, percent
 	`
 
 	err := r.db.QueryRow(ctx, query,
-		refund.BookingID, refund.Amount, refund.Reason, "COMPLETED",
-	).Scan(&refund.ID, &refund.RefundDate)
+		refund.BookingID, refund.Amount, refund.Reason, refund.Note, entity.RefundCompleted,
+	).Scan(&refund.ID, &refund.RefundDate, &refund.Percent)
 	if err != nil {
-		logger.Error("failed to create refund", logger.Err(err))
+		if isUnknownRefundReason(err) {
+			return entity.ErrInvalidRefundReason
+		}
+		logger.FromContext(ctx).Error("failed to create refund", logger.Err(err))
 		return err
 	}
 
-	refund.Status = "COMPLETED"
+	refund.Status = entity.RefundCompleted
 
-	logger.Info("refund created",
+	logger.FromContext(ctx).Info("refund created",
 		logger.Int64("refund_id", refund.ID),
 		logger.Int64("booking_id", refund.BookingID),
 		logger.Float64("amount", refund.Amount),
@@ -55,26 +142,354 @@ func (r *refundRepository) CreateRefund(ctx context.Context, refund *entity.Refu
 }
 
 func (r *refundRepository) GetRefundByBookingID(ctx context.Context, bookingID int64) (*entity.Refund, error) {
-	logger.Debug("fetching refund by booking ID", logger.Int64("booking_id", bookingID))
+	logger.FromContext(ctx).Debug("fetching refund by booking ID", logger.Int64("booking_id", bookingID))
+
+	// A booking can have rejected requests before its refund; the latest wins
+	refund, err := scanRefund(r.db.QueryRow(ctx, refundSelect+`
+		WHERE r.booking_id = $1
+		ORDER BY r.refund_id DESC
+		LIMIT 1
+	`, bookingID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		logger.FromContext(ctx).Error("failed to fetch refund", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// refundPaidBooking returns the money of a PAID booking inside tx: its
+// completed payments become REFUNDED and the booking REFUNDED. It returns
+// the refunded amount, or ErrBookingNotPaid without changes.
+func refundPaidBooking(ctx context.Context, tx pgx.Tx, bookingID int64) (float64, error) {
+	// Lock the booking so concurrent refunds or seat changes serialize
+	var status entity.BookingStatus
+	err := tx.QueryRow(ctx, `SELECT status FROM booking WHERE booking_id = $1 FOR UPDATE`, bookingID).Scan(&status)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to lock booking", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return 0, err
+	}
+	if status != entity.BookingPaid {
+		return 0, entity.ErrBookingNotPaid
+	}
+
+	var amount float64
+	err = tx.QueryRow(ctx, `
+		WITH refunded AS (
+			UPDATE transactions SET status = 'REFUNDED'
+			WHERE booking_id = $1 AND status = 'COMPLETED'
+			RETURNING amount
+		)
+		SELECT COALESCE(SUM(amount), 0) FROM refunded
+	`, bookingID).Scan(&amount)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to refund transactions", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE booking SET status = 'REFUNDED' WHERE booking_id = $1`, bookingID); err != nil {
+		logger.FromContext(ctx).Error("failed to update booking status", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return 0, err
+	}
+	return amount, nil
+}
+
+func (r *refundRepository) RefundBooking(ctx context.Context, refund *entity.Refund) error {
+	logger.FromContext(ctx).Debug("refunding booking",
+		logger.Int64("booking_id", refund.BookingID),
+		logger.String("reason", refund.Reason),
+	)
 
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	paid, err := refundPaidBooking(ctx, tx, refund.BookingID)
+	if err != nil {
+		return err
+	}
+	refund.Amount = entity.RefundShare(paid, refund.Percent)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO refund (booking_id, amount, reason, note, status, refund_date, percent)
+		VALUES ($1, $2, $3, NULLIF($4, ''), 'COMPLETED', NOW(), $5)
+		RETURNING refund_id, refund_date
+	`, refund.BookingID, refund.Amount, refund.Reason, refund.Note, refund.Percent).Scan(&refund.ID, &refund.RefundDate)
+	if err != nil {
+		if isUnknownRefundReason(err) {
+			return entity.ErrInvalidRefundReason
+		}
+		logger.FromContext(ctx).Error("failed to create refund", logger.Int64("booking_id", refund.BookingID), logger.Err(err))
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit refund", logger.Int64("booking_id", refund.BookingID), logger.Err(err))
+		return err
+	}
+	refund.Status = entity.RefundCompleted
+
+	logger.FromContext(ctx).Info("booking refunded",
+		logger.Int64("refund_id", refund.ID),
+		logger.Int64("booking_id", refund.BookingID),
+		logger.Float64("amount", refund.Amount),
+		logger.String("reason", refund.Reason),
+	)
+	return nil
+}
+
+func (r *refundRepository) ListRefundReasons(ctx context.Context) ([]entity.RefundReason, error) {
+	rows, err := r.db.Query(ctx, `SELECT code, label, active, created_at FROM refund_reasons ORDER BY created_at, code`)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to list refund reasons", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reasons []entity.RefundReason
+	for rows.Next() {
+		var reason entity.RefundReason
+		if err := rows.Scan(&reason.Code, &reason.Label, &reason.Active, &reason.CreatedAt); err != nil {
+			return nil, err
+		}
+		reasons = append(reasons, reason)
+	}
+	return reasons, rows.Err()
+}
+
+func (r *refundRepository) GetRefundReason(ctx context.Context, code string) (*entity.RefundReason, error) {
+	var reason entity.RefundReason
+	err := r.db.QueryRow(ctx, `SELECT code, label, active, created_at FROM refund_reasons WHERE code = $1`, code).
+		Scan(&reason.Code, &reason.Label, &reason.Active, &reason.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch refund reason", logger.String("code", code), logger.Err(err))
+		return nil, err
+	}
+	return &reason, nil
+}
+
+func (r *refundRepository) CreateRefundReason(ctx context.Context, reason *entity.RefundReason) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO refund_reasons (code, label, active)
+		VALUES ($1, $2, $3)
+		RETURNING created_at
+	`, reason.Code, reason.Label, reason.Active).Scan(&reason.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.ErrRefundReasonExists
+		}
+		logger.FromContext(ctx).Error("failed to create refund reason", logger.String("code", reason.Code), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("refund reason created", logger.String("code", reason.Code))
+	return nil
+}
+
+func (r *refundRepository) UpdateRefundReason(ctx context.Context, reason *entity.RefundReason) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE refund_reasons SET label = $2, active = $3
+		WHERE code = $1
+		RETURNING created_at
+	`, reason.Code, reason.Label, reason.Active).Scan(&reason.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to update refund reason", logger.String("code", reason.Code), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("refund reason updated", logger.String("code", reason.Code))
+	return nil
+}
+
+func (r *refundRepository) GetRefundSummary(ctx context.Context, from, to time.Time) ([]entity.RefundReasonSummary, error) {
 	query := `
-		SELECT refund_id, booking_id, amount, refund_date, COALESCE(reason, ''), COALESCE(status, 'PENDING')
-		FROM refund
-		WHERE booking_id = $1
+		SELECT rr.code, rr.label, COUNT(r.refund_id), COALESCE(SUM(r.amount), 0)
+		FROM refund_reasons rr
+		LEFT JOIN refund r ON r.reason = rr.code
+			AND r.status = 'COMPLETED'
+			AND r.refund_date >= $1 AND r.refund_date < $2
+		GROUP BY rr.code, rr.label, rr.active
+		HAVING rr.active OR COUNT(r.refund_id) > 0
+		ORDER BY COALESCE(SUM(r.amount), 0) DESC, rr.code
 	`
+	rows, err := r.replica.Query(ctx, query, from, to)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to fetch refund summary", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
 
-	var refund entity.Refund
-	err := r.db.QueryRow(ctx, query, bookingID).Scan(
-		&refund.ID, &refund.BookingID, &refund.Amount,
-		&refund.RefundDate, &refund.Reason, &refund.Status,
+	var summary []entity.RefundReasonSummary
+	for rows.Next() {
+		var s entity.RefundReasonSummary
+		if err := rows.Scan(&s.Reason, &s.Label, &s.Count, &s.Amount); err != nil {
+			return nil, err
+		}
+		summary = append(summary, s)
+	}
+	return summary, rows.Err()
+}
+
+func (r *refundRepository) CreateRefundRequest(ctx context.Context, refund *entity.Refund) error {
+	logger.FromContext(ctx).Debug("creating refund request",
+		logger.Int64("booking_id", refund.BookingID),
+		logger.String("reason", refund.Reason),
 	)
+
+	query := `
+		INSERT INTO refund (booking_id, amount, reason, note, status, refund_date, requested_at, percent)
+		SELECT $1, ROUND(COALESCE(SUM(t.amount), 0) * $4 / 100.0, 2), $2, NULLIF($3, ''), 'REQUESTED', NULL, NOW(), $4
+		FROM transactions t
+		WHERE t.booking_id = $1 AND t.status = 'COMPLETED'
+		RETURNING refund_id, amount, status, requested_at
+	`
+	err := r.db.QueryRow(ctx, query, refund.BookingID, refund.Reason, refund.Note, refund.Percent).
+		Scan(&refund.ID, &refund.Amount, &refund.Status, &refund.RequestedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.ErrRefundRequestExists
+		}
+		if isUnknownRefundReason(err) {
+			return entity.ErrInvalidRefundReason
+		}
+		logger.FromContext(ctx).Error("failed to create refund request", logger.Int64("booking_id", refund.BookingID), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("refund requested",
+		logger.Int64("refund_id", refund.ID),
+		logger.Int64("booking_id", refund.BookingID),
+	)
+	return nil
+}
+
+func (r *refundRepository) GetRefundRequest(ctx context.Context, refundID int64) (*entity.RefundRequestDetails, error) {
+	d, err := scanRefundRequest(r.db.QueryRow(ctx, refundRequestSelect+` AND r.refund_id = $1`, refundID))
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, nil
+			return nil, entity.ErrNotFound
 		}
-		logger.Error("failed to fetch refund", logger.Int64("booking_id", bookingID), logger.Err(err))
+		logger.FromContext(ctx).Error("failed to fetch refund request", logger.Int64("refund_id", refundID), logger.Err(err))
 		return nil, err
 	}
+	return d, nil
+}
 
-	return &refund, nil
+func (r *refundRepository) ListRefundRequests(ctx context.Context, status string) ([]entity.RefundRequestDetails, error) {
+	rows, err := r.db.Query(ctx, refundRequestSelect+`
+		AND ($1 = '' OR r.status = $1)
+		ORDER BY r.requested_at, r.refund_id
+	`, status)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to list refund requests", logger.String("status", status), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []entity.RefundRequestDetails
+	for rows.Next() {
+		d, err := scanRefundRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, *d)
+	}
+	return requests, rows.Err()
+}
+
+func (r *refundRepository) ReviewRefundRequest(ctx context.Context, refundID int64, from, to string, reviewerID *int64, note string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE refund SET status = $3, reviewed_by = $4, reviewed_at = NOW(), review_note = NULLIF($5, '')
+		WHERE refund_id = $1 AND status = $2 AND requested_at IS NOT NULL
+	`, refundID, from, to, reviewerID, note)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to review refund request", logger.Int64("refund_id", refundID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrRefundNotReviewable
+	}
+
+	logger.FromContext(ctx).Info("refund request reviewed",
+		logger.Int64("refund_id", refundID),
+		logger.String("status", to),
+	)
+	return nil
+}
+
+func (r *refundRepository) CompleteRefundRequest(ctx context.Context, refundID int64) (*entity.Refund, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	refund, err := scanRefund(tx.QueryRow(ctx, refundSelect+` WHERE r.refund_id = $1 FOR UPDATE`, refundID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to lock refund request", logger.Int64("refund_id", refundID), logger.Err(err))
+		return nil, err
+	}
+	if refund.Status != entity.RefundApproved {
+		return nil, entity.ErrRefundNotReviewable
+	}
+
+	paid, err := refundPaidBooking(ctx, tx, refund.BookingID)
+	if errors.Is(err, entity.ErrBookingNotPaid) {
+		// Refunded or cancelled some other way since it was approved
+		if _, err := tx.Exec(ctx, `
+			UPDATE refund SET status = 'REJECTED', review_note = 'Booking is no longer paid'
+			WHERE refund_id = $1
+		`, refundID); err != nil {
+			logger.FromContext(ctx).Error("failed to reject refund request", logger.Int64("refund_id", refundID), logger.Err(err))
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+		return nil, entity.ErrBookingNotPaid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE refund SET status = 'COMPLETED', amount = $2, refund_date = NOW()
+		WHERE refund_id = $1
+		RETURNING status, amount, refund_date
+	`, refundID, entity.RefundShare(paid, refund.Percent)).Scan(&refund.Status, &refund.Amount, &refund.RefundDate)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to complete refund request", logger.Int64("refund_id", refundID), logger.Err(err))
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit refund", logger.Int64("refund_id", refundID), logger.Err(err))
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("refund request completed",
+		logger.Int64("refund_id", refundID),
+		logger.Int64("booking_id", refund.BookingID),
+		logger.Float64("amount", refund.Amount),
+	)
+	return refund, nil
 }
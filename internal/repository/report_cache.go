@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ReportCache keeps computed admin reports in Redis so dashboards don't rerun
+// heavy aggregate queries on every page load. A miss or a Redis failure only
+// means the report is computed again.
+type ReportCache interface {
+	GetRefundReport(ctx context.Context, from, to time.Time) (*entity.RefundReport, bool)
+	SetRefundReport(ctx context.Context, report *entity.RefundReport, ttl time.Duration)
+}
+
+type reportCache struct {
+	redis *redis.Client
+}
+
+func NewReportCache(rdb *redis.Client) ReportCache {
+	return &reportCache{redis: rdb}
+}
+
+func refundReportKey(from, to time.Time) string {
+	return fmt.Sprintf("reports:refunds:%s:%s", from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+}
+
+func (r *reportCache) GetRefundReport(ctx context.Context, from, to time.Time) (*entity.RefundReport, bool) {
+	cachedData, err := r.redis.Get(ctx, refundReportKey(from, to)).Result()
+	if err != nil {
+		return nil, false
+	}
+	var report entity.RefundReport
+	if err := json.Unmarshal([]byte(cachedData), &report); err != nil {
+		return nil, false
+	}
+	return &report, true
+}
+
+func (r *reportCache) SetRefundReport(ctx context.Context, report *entity.RefundReport, ttl time.Duration) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return
+	}
+	if err := r.redis.Set(ctx, refundReportKey(report.From, report.To), data, ttl).Err(); err != nil {
+		logger.FromContext(ctx).Warn("failed to cache refund report", logger.Err(err))
+	}
+}
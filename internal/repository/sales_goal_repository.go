@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SalesGoalRepository interface {
+	// UpsertSalesGoal sets the event's goal, replacing any previous one and
+	// clearing the alerts it sent.
+	UpsertSalesGoal(ctx context.Context, goal *entity.SalesGoal) error
+	// GetSalesGoalProgress returns the event's goal with its sales, or
+	// ErrNotFound if the event has no goal.
+	GetSalesGoalProgress(ctx context.Context, eventID int64) (*entity.SalesGoalProgress, error)
+	// DeleteSalesGoal removes the event's goal, or returns ErrNotFound.
+	DeleteSalesGoal(ctx context.Context, eventID int64) error
+	// GetActiveSalesGoals returns the goals of upcoming events that are on
+	// sale, with their sales.
+	GetActiveSalesGoals(ctx context.Context) ([]entity.SalesGoalProgress, error)
+	// MarkSalesGoalAlerts records alerts as sent.
+	MarkSalesGoalAlerts(ctx context.Context, eventID int64, alerts entity.SalesGoalAlerts) error
+}
+
+type salesGoalRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSalesGoalRepository(db *pgxpool.Pool) SalesGoalRepository {
+	return &salesGoalRepository{db: db}
+}
+
+func (r *salesGoalRepository) UpsertSalesGoal(ctx context.Context, goal *entity.SalesGoal) error {
+	logger.FromContext(ctx).Debug("setting sales goal", logger.Int64("event_id", goal.EventID))
+
+	query := `
+		INSERT INTO event_sales_goals (event_id, target_tickets, thresholds, notify_sold_out, stall_after_hours)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (event_id) DO UPDATE SET
+			target_tickets = EXCLUDED.target_tickets,
+			thresholds = EXCLUDED.thresholds,
+			notify_sold_out = EXCLUDED.notify_sold_out,
+			stall_after_hours = EXCLUDED.stall_after_hours,
+			reached_thresholds = '{}',
+			sold_out_alerted_at = NULL,
+			stall_alerted_at = NULL,
+			updated_at = NOW()
+		RETURNING updated_at
+	`
+	err := r.db.QueryRow(ctx, query, goal.EventID, goal.TargetTickets, goal.Thresholds, goal.NotifySoldOut, goal.StallAfterHours).Scan(&goal.UpdatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to set sales goal", logger.Int64("event_id", goal.EventID), logger.Err(err))
+		return err
+	}
+	goal.ReachedThresholds = []int{}
+	goal.SoldOutAlertedAt, goal.StallAlertedAt = nil, nil
+	return nil
+}
+
+// salesGoalProgressQuery reads goals with their event and the PAID tickets
+// sold for it, seated and general admission alike.
+const salesGoalProgressQuery = `
+	SELECT g.event_id, g.target_tickets, g.thresholds, g.notify_sold_out, g.stall_after_hours,
+		g.reached_thresholds, g.sold_out_alerted_at, g.stall_alerted_at, g.updated_at,
+		e.name, COALESCE(e.organizer_id, 0), e.capacity, COALESCE(s.sold, 0), s.last_sale_at
+	FROM event_sales_goals g
+	JOIN events e ON e.event_id = g.event_id
+	LEFT JOIN LATERAL (
+		SELECT COUNT(bi.id) + COALESCE(SUM(b.ga_quantity), 0) AS sold, MAX(b.created_at) AS last_sale_at
+		FROM booking b
+		LEFT JOIN booking_items bi ON bi.booking_id = b.booking_id
+		WHERE b.event_id = g.event_id AND b.status = 'PAID'
+	) s ON TRUE
+`
+
+func scanSalesGoalProgress(row pgx.Row) (*entity.SalesGoalProgress, error) {
+	var p entity.SalesGoalProgress
+	err := row.Scan(
+		&p.EventID, &p.TargetTickets, &p.Thresholds, &p.NotifySoldOut, &p.StallAfterHours,
+		&p.ReachedThresholds, &p.SoldOutAlertedAt, &p.StallAlertedAt, &p.UpdatedAt,
+		&p.EventName, &p.OrganizerID, &p.Capacity, &p.Sold, &p.LastSaleAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *salesGoalRepository) GetSalesGoalProgress(ctx context.Context, eventID int64) (*entity.SalesGoalProgress, error) {
+	p, err := scanSalesGoalProgress(r.db.QueryRow(ctx, salesGoalProgressQuery+` WHERE g.event_id = $1`, eventID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch sales goal", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *salesGoalRepository) DeleteSalesGoal(ctx context.Context, eventID int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM event_sales_goals WHERE event_id = $1`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to delete sales goal", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+func (r *salesGoalRepository) GetActiveSalesGoals(ctx context.Context) ([]entity.SalesGoalProgress, error) {
+	logger.FromContext(ctx).Debug("fetching active sales goals")
+
+	query := salesGoalProgressQuery + `
+		WHERE e.status = 'available' AND e.deleted_at IS NULL AND e.date > NOW() AND e.organizer_id IS NOT NULL
+		ORDER BY g.event_id
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query active sales goals", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var goals []entity.SalesGoalProgress
+	for rows.Next() {
+		p, err := scanSalesGoalProgress(rows)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to scan sales goal row", logger.Err(err))
+			return nil, err
+		}
+		goals = append(goals, *p)
+	}
+	return goals, rows.Err()
+}
+
+func (r *salesGoalRepository) MarkSalesGoalAlerts(ctx context.Context, eventID int64, alerts entity.SalesGoalAlerts) error {
+	query := `
+		UPDATE event_sales_goals SET
+			reached_thresholds = ARRAY(SELECT DISTINCT unnest(reached_thresholds || $2::int[]) ORDER BY 1),
+			sold_out_alerted_at = CASE WHEN $3 THEN NOW() ELSE sold_out_alerted_at END,
+			stall_alerted_at = CASE WHEN $4 THEN NOW() ELSE stall_alerted_at END
+		WHERE event_id = $1
+	`
+	reached := alerts.Reached
+	if reached == nil {
+		reached = []int{}
+	}
+	_, err := r.db.Exec(ctx, query, eventID, reached, alerts.SoldOut, alerts.Stalled)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to mark sales goal alerts", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// seatMapTTL bounds how long a seat map is served without being rebuilt,
+// which also covers layout changes made outside the repositories, such as
+// backfills.
+const seatMapTTL = 10 * time.Minute
+
+// SeatAvailabilityCache keeps each event's seat map in Redis: the layout as
+// JSON and which seats are booked as a bitmap indexed by seat ID. Bookings
+// and releases flip bits in place, so the map stays warm through a sale;
+// layout changes drop it. A cold cache or a Redis failure only means the map
+// is read from the database.
+type SeatAvailabilityCache interface {
+	// GetSeats returns the event's seats from the cache, or from load when
+	// the cache is cold, caching what load returns.
+	GetSeats(ctx context.Context, eventID int64, load func(ctx context.Context) ([]entity.Seat, error)) ([]entity.Seat, error)
+	// ApplySeatUpdate marks committed seat changes in a cached map.
+	ApplySeatUpdate(ctx context.Context, update entity.SeatUpdate)
+	// Invalidate drops the event's seat map after its layout, prices or
+	// tiers changed.
+	Invalidate(ctx context.Context, eventID int64)
+}
+
+type seatAvailabilityCache struct {
+	redis *redis.Client
+}
+
+func NewSeatAvailabilityCache(rdb *redis.Client) SeatAvailabilityCache {
+	return &seatAvailabilityCache{redis: rdb}
+}
+
+// Each map is three keys. meta holds the lowest seat ID, which is bit 0 of
+// the bitmap, and a version bumped by every change, so a map read from the
+// database is only stored if nothing changed while it was being read.
+func seatMapKeys(eventID int64) (layout, booked, meta string) {
+	prefix := fmt.Sprintf("events:seats:%d", eventID)
+	return prefix + ":layout", prefix + ":booked", prefix + ":meta"
+}
+
+var storeSeatMap = redis.NewScript(`
+if tonumber(redis.call("HGET", KEYS[3], "version") or "0") ~= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[3], "EX", ARGV[5])
+redis.call("SET", KEYS[2], ARGV[4], "EX", ARGV[5])
+redis.call("HSET", KEYS[3], "base", ARGV[2])
+redis.call("EXPIRE", KEYS[3], ARGV[5])
+return 1
+`)
+
+// applySeatUpdate sets ARGV[3..] to ARGV[2] in a warm bitmap. A seat below
+// the base can't be placed, so the map is dropped instead.
+var applySeatUpdate = redis.NewScript(`
+redis.call("HINCRBY", KEYS[3], "version", 1)
+redis.call("EXPIRE", KEYS[3], ARGV[1])
+local base = redis.call("HGET", KEYS[3], "base")
+if not base or redis.call("EXISTS", KEYS[2]) == 0 then
+	return 0
+end
+base = tonumber(base)
+for i = 3, #ARGV do
+	local offset = tonumber(ARGV[i]) - base
+	if offset < 0 then
+		redis.call("DEL", KEYS[1], KEYS[2])
+		return 0
+	end
+	redis.call("SETBIT", KEYS[2], offset, ARGV[2])
+end
+return 1
+`)
+
+var dropSeatMap = redis.NewScript(`
+redis.call("HINCRBY", KEYS[3], "version", 1)
+redis.call("EXPIRE", KEYS[3], ARGV[1])
+return redis.call("DEL", KEYS[1], KEYS[2])
+`)
+
+func (c *seatAvailabilityCache) GetSeats(ctx context.Context, eventID int64, load func(ctx context.Context) ([]entity.Seat, error)) ([]entity.Seat, error) {
+	layoutKey, bookedKey, metaKey := seatMapKeys(eventID)
+
+	pipe := c.redis.Pipeline()
+	layoutCmd := pipe.Get(ctx, layoutKey)
+	bookedCmd := pipe.Get(ctx, bookedKey)
+	metaCmd := pipe.HMGet(ctx, metaKey, "base", "version")
+	// A missing layout or bitmap only makes Exec report redis.Nil
+	_, err := pipe.Exec(ctx)
+	if err == redis.Nil {
+		err = metaCmd.Err()
+	}
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to read cached seat map", logger.Int64("event_id", eventID), logger.Err(err))
+		return load(ctx)
+	}
+
+	meta := metaCmd.Val()
+	version, _ := meta[1].(string)
+	if seats, ok := decodeSeatMap(layoutCmd, bookedCmd, meta[0]); ok {
+		logger.FromContext(ctx).Debug("seats fetched from cache", logger.Int64("event_id", eventID), logger.Int("count", len(seats)))
+		return seats, nil
+	}
+
+	seats, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.store(ctx, eventID, version, seats)
+	return seats, nil
+}
+
+func decodeSeatMap(layoutCmd, bookedCmd *redis.StringCmd, base interface{}) ([]entity.Seat, bool) {
+	if layoutCmd.Err() != nil || bookedCmd.Err() != nil {
+		return nil, false
+	}
+	baseID, err := strconv.ParseInt(fmt.Sprint(base), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	var seats []entity.Seat
+	if err := json.Unmarshal([]byte(layoutCmd.Val()), &seats); err != nil {
+		return nil, false
+	}
+	bits := []byte(bookedCmd.Val())
+	for i := range seats {
+		seats[i].IsBooked = bitSet(bits, seats[i].ID-baseID)
+	}
+	return seats, true
+}
+
+// store caches seats read from the database at version. Seats are ordered by
+// ID, so the first one is the base.
+func (c *seatAvailabilityCache) store(ctx context.Context, eventID int64, version string, seats []entity.Seat) {
+	var base int64
+	if len(seats) > 0 {
+		base = seats[0].ID
+	}
+	layout := make([]entity.Seat, len(seats))
+	copy(layout, seats)
+	var bits []byte
+	for i := range layout {
+		if layout[i].IsBooked {
+			bits = setBit(bits, layout[i].ID-base)
+			layout[i].IsBooked = false
+		}
+	}
+	data, err := json.Marshal(layout)
+	if err != nil {
+		return
+	}
+	if version == "" {
+		version = "0"
+	}
+
+	layoutKey, bookedKey, metaKey := seatMapKeys(eventID)
+	err = storeSeatMap.Run(ctx, c.redis, []string{layoutKey, bookedKey, metaKey},
+		version, base, data, bits, int(seatMapTTL.Seconds())).Err()
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to cache seat map", logger.Int64("event_id", eventID), logger.Err(err))
+	}
+}
+
+func (c *seatAvailabilityCache) ApplySeatUpdate(ctx context.Context, update entity.SeatUpdate) {
+	if len(update.SeatIDs) == 0 {
+		return
+	}
+	bit := 0
+	if update.IsBooked {
+		bit = 1
+	}
+	args := make([]interface{}, 0, len(update.SeatIDs)+2)
+	args = append(args, int(seatMapTTL.Seconds()), bit)
+	for _, id := range update.SeatIDs {
+		args = append(args, id)
+	}
+
+	layoutKey, bookedKey, metaKey := seatMapKeys(update.EventID)
+	if err := applySeatUpdate.Run(ctx, c.redis, []string{layoutKey, bookedKey, metaKey}, args...).Err(); err != nil {
+		// The map may now show these seats wrongly; drop it so the next read
+		// goes to the database
+		logger.FromContext(ctx).Warn("failed to update cached seat map", logger.Int64("event_id", update.EventID), logger.Err(err))
+		c.Invalidate(ctx, update.EventID)
+	}
+}
+
+func (c *seatAvailabilityCache) Invalidate(ctx context.Context, eventID int64) {
+	layoutKey, bookedKey, metaKey := seatMapKeys(eventID)
+	if err := dropSeatMap.Run(ctx, c.redis, []string{layoutKey, bookedKey, metaKey}, int(seatMapTTL.Seconds())).Err(); err != nil {
+		logger.FromContext(ctx).Warn("failed to drop cached seat map", logger.Int64("event_id", eventID), logger.Err(err))
+	}
+}
+
+// Redis numbers bitmap bits from the most significant bit of the first byte.
+func setBit(bits []byte, offset int64) []byte {
+	for int64(len(bits)) <= offset/8 {
+		bits = append(bits, 0)
+	}
+	bits[offset/8] |= 0x80 >> (offset % 8)
+	return bits
+}
+
+func bitSet(bits []byte, offset int64) bool {
+	if offset < 0 || offset/8 >= int64(len(bits)) {
+		return false
+	}
+	return bits[offset/8]&(0x80>>(offset%8)) != 0
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetBit(t *testing.T) {
+	tests := []struct {
+		name    string
+		bits    []byte
+		offsets []int64
+		want    []byte
+	}{
+		{name: "First Bit Is The High Bit", offsets: []int64{0}, want: []byte{0x80}},
+		{name: "Last Bit Of A Byte", offsets: []int64{7}, want: []byte{0x01}},
+		{name: "Grows To The Offset", offsets: []int64{17}, want: []byte{0x00, 0x00, 0x40}},
+		// SETBIT k 1 1 and SETBIT k 3 1 leave "P" (0x50) in Redis
+		{name: "Matches Redis SETBIT", offsets: []int64{1, 3}, want: []byte("P")},
+		{name: "Keeps Other Bits", bits: []byte{0x01, 0xff}, offsets: []int64{0, 8}, want: []byte{0x81, 0xff}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bits := tt.bits
+			for _, offset := range tt.offsets {
+				bits = setBit(bits, offset)
+			}
+			assert.Equal(t, tt.want, bits)
+		})
+	}
+}
+
+func TestBitSet(t *testing.T) {
+	bits := []byte{0x80, 0x01}
+
+	tests := []struct {
+		offset int64
+		want   bool
+	}{
+		{0, true},
+		{1, false},
+		{7, false},
+		{8, false},
+		{15, true},
+		// Past the end and negative offsets read as unset, like GETBIT
+		{16, false},
+		{-1, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, bitSet(bits, tt.offset), "offset %d", tt.offset)
+	}
+}
+
+func TestBitSet_RoundTrip(t *testing.T) {
+	var bits []byte
+	booked := map[int64]bool{0: true, 5: true, 9: true, 63: true}
+	for offset := range booked {
+		bits = setBit(bits, offset)
+	}
+	for offset := int64(0); offset < 64; offset++ {
+		assert.Equal(t, booked[offset], bitSet(bits, offset), "offset %d", offset)
+	}
+}
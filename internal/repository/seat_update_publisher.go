@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SeatUpdatePublisher announces booked and released seats on Redis pub/sub
+// so open seat maps can update live, and marks them in the cached seat map.
+// Publishing happens after the change is committed and never fails the
+// change; a missed update only leaves a seat map stale until it is reloaded.
+type SeatUpdatePublisher interface {
+	PublishSeatUpdate(ctx context.Context, update entity.SeatUpdate)
+}
+
+type seatUpdatePublisher struct {
+	redis *redis.Client
+	seats SeatAvailabilityCache
+}
+
+func NewSeatUpdatePublisher(rdb *redis.Client, seats SeatAvailabilityCache) SeatUpdatePublisher {
+	return &seatUpdatePublisher{redis: rdb, seats: seats}
+}
+
+func seatUpdatesChannel(eventID int64) string {
+	return fmt.Sprintf("events:%d:seat_updates", eventID)
+}
+
+func (p *seatUpdatePublisher) PublishSeatUpdate(ctx context.Context, update entity.SeatUpdate) {
+	if len(update.SeatIDs) == 0 {
+		return
+	}
+	// The cache is updated first, so a client reloading on the message
+	// already sees the change
+	p.seats.ApplySeatUpdate(ctx, update)
+
+	data, err := json.Marshal(update)
+	if err != nil {
+		return
+	}
+	if err := p.redis.Publish(ctx, seatUpdatesChannel(update.EventID), data).Err(); err != nil {
+		logger.FromContext(ctx).Warn("failed to publish seat update",
+			logger.Int64("event_id", update.EventID),
+			logger.Int("seat_count", len(update.SeatIDs)),
+			logger.Err(err),
+		)
+	}
+}
+
+// publishSeatChange announces a seat change: the new seats are booked and
+// the old ones free again.
+func publishSeatChange(ctx context.Context, p SeatUpdatePublisher, eventID int64, mod *entity.BookingModification) {
+	p.PublishSeatUpdate(ctx, entity.SeatUpdate{EventID: eventID, SeatIDs: mod.ToSeatIDs, IsBooked: true})
+	p.PublishSeatUpdate(ctx, entity.SeatUpdate{EventID: eventID, SeatIDs: mod.FromSeatIDs, IsBooked: false})
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SectionImageRepository interface {
+	// ReplaceSectionImage stores img as the section's image and returns the
+	// storage key of the image it replaced, or "" when there was none. The
+	// new row gets a new ID so clients never see a stale cached image.
+	ReplaceSectionImage(ctx context.Context, img *entity.SectionImage) (string, error)
+	GetSectionImages(ctx context.Context, eventID int64) ([]entity.SectionImage, error)
+	GetSectionImageByID(ctx context.Context, imageID int64) (*entity.SectionImage, error)
+	// DeleteSectionImage removes the section's image and returns its storage key.
+	DeleteSectionImage(ctx context.Context, eventID int64, section string) (string, error)
+}
+
+type sectionImageRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSectionImageRepository(db *pgxpool.Pool) SectionImageRepository {
+	return &sectionImageRepository{db: db}
+}
+
+func (r *sectionImageRepository) ReplaceSectionImage(ctx context.Context, img *entity.SectionImage) (string, error) {
+	logger.FromContext(ctx).Debug("replacing section image",
+		logger.Int64("event_id", img.EventID),
+		logger.String("section", img.Section),
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var oldKey string
+	err = tx.QueryRow(ctx, `
+		DELETE FROM section_images WHERE event_id = $1 AND section = $2
+		RETURNING storage_key
+	`, img.EventID, img.Section).Scan(&oldKey)
+	if err != nil && err != pgx.ErrNoRows {
+		logger.FromContext(ctx).Error("failed to remove previous section image", logger.Int64("event_id", img.EventID), logger.Err(err))
+		return "", err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO section_images (event_id, section, storage_key, content_type, size_bytes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING image_id, created_at
+	`, img.EventID, img.Section, img.StorageKey, img.ContentType, img.SizeBytes).Scan(&img.ID, &img.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to insert section image", logger.Int64("event_id", img.EventID), logger.Err(err))
+		return "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit section image", logger.Err(err))
+		return "", err
+	}
+
+	logger.FromContext(ctx).Info("section image stored",
+		logger.Int64("event_id", img.EventID),
+		logger.Int64("image_id", img.ID),
+	)
+	return oldKey, nil
+}
+
+func (r *sectionImageRepository) GetSectionImages(ctx context.Context, eventID int64) ([]entity.SectionImage, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT image_id, event_id, section, storage_key, content_type, size_bytes, created_at
+		FROM section_images
+		WHERE event_id = $1
+		ORDER BY section
+	`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query section images", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var images []entity.SectionImage
+	for rows.Next() {
+		var img entity.SectionImage
+		if err := rows.Scan(&img.ID, &img.EventID, &img.Section, &img.StorageKey, &img.ContentType, &img.SizeBytes, &img.CreatedAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan section image row", logger.Err(err))
+			return nil, err
+		}
+		images = append(images, img)
+	}
+
+	return images, rows.Err()
+}
+
+func (r *sectionImageRepository) GetSectionImageByID(ctx context.Context, imageID int64) (*entity.SectionImage, error) {
+	var img entity.SectionImage
+	err := r.db.QueryRow(ctx, `
+		SELECT image_id, event_id, section, storage_key, content_type, size_bytes, created_at
+		FROM section_images
+		WHERE image_id = $1
+	`, imageID).Scan(&img.ID, &img.EventID, &img.Section, &img.StorageKey, &img.ContentType, &img.SizeBytes, &img.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to get section image", logger.Int64("image_id", imageID), logger.Err(err))
+		return nil, err
+	}
+	return &img, nil
+}
+
+func (r *sectionImageRepository) DeleteSectionImage(ctx context.Context, eventID int64, section string) (string, error) {
+	var key string
+	err := r.db.QueryRow(ctx, `
+		DELETE FROM section_images WHERE event_id = $1 AND section = $2
+		RETURNING storage_key
+	`, eventID, section).Scan(&key)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to delete section image", logger.Int64("event_id", eventID), logger.Err(err))
+		return "", err
+	}
+
+	logger.FromContext(ctx).Info("section image deleted", logger.Int64("event_id", eventID), logger.String("section", section))
+	return key, nil
+}
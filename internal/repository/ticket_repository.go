@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TicketRepository interface {
+	IssueTickets(ctx context.Context, bookingID int64) ([]entity.Ticket, error)
+	GetTicketsByBookingID(ctx context.Context, bookingID int64) ([]entity.Ticket, error)
+	GetTicketByCode(ctx context.Context, code string) (*entity.Ticket, error)
+	MarkCheckedIn(ctx context.Context, ticketID, staffID int64) (*time.Time, error)
+	// RegenerateTickets gives every ticket of a PAID booking a new code and
+	// revokes the old ones.
+	RegenerateTickets(ctx context.Context, bookingID int64, revokedBy *int64) ([]entity.Ticket, error)
+	// CheckInBatch admits every unused ticket of the event among codes on
+	// behalf of a gate and reports what it found for each distinct code.
+	CheckInBatch(ctx context.Context, eventID, gateID int64, codes []string) ([]entity.ScannedCode, error)
+}
+
+type ticketRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTicketRepository(db *pgxpool.Pool) TicketRepository {
+	return &ticketRepository{db: db}
+}
+
+const ticketSelect = `
+	SELECT bi.id, bi.booking_id, b.event_id, COALESCE(bi.seat_id, 0), COALESCE(s.seat_number, ''), COALESCE(bi.ticket_code, ''),
+		b.status, bi.checked_in_at, bi.checked_in_by, bi.checked_in_gate
+	FROM booking_items bi
+	JOIN booking b ON bi.booking_id = b.booking_id
+	LEFT JOIN seats s ON bi.seat_id = s.seat_id
+`
+
+// generateTicketCode returns a random, unguessable code that is encoded in the ticket QR.
+func generateTicketCode() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "TCK-" + hex.EncodeToString(buf), nil
+}
+
+func scanTicket(row pgx.Row) (*entity.Ticket, error) {
+	var t entity.Ticket
+	err := row.Scan(&t.ID, &t.BookingID, &t.EventID, &t.SeatID, &t.SeatNumber, &t.Code,
+		&t.BookingStatus, &t.CheckedInAt, &t.CheckedInBy, &t.CheckedInGate)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *ticketRepository) IssueTickets(ctx context.Context, bookingID int64) ([]entity.Ticket, error) {
+	logger.FromContext(ctx).Debug("issuing tickets", logger.Int64("booking_id", bookingID))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT id FROM booking_items WHERE booking_id = $1 AND ticket_code IS NULL FOR UPDATE`, bookingID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query booking items", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return nil, err
+	}
+	var itemIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			logger.FromContext(ctx).Error("failed to scan booking item", logger.Err(err))
+			return nil, err
+		}
+		itemIDs = append(itemIDs, id)
+	}
+	rows.Close()
+
+	for _, itemID := range itemIDs {
+		code, err := generateTicketCode()
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to generate ticket code", logger.Err(err))
+			return nil, err
+		}
+		if _, err := tx.Exec(ctx, `UPDATE booking_items SET ticket_code = $1 WHERE id = $2`, code, itemID); err != nil {
+			logger.FromContext(ctx).Error("failed to assign ticket code", logger.Int64("item_id", itemID), logger.Err(err))
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit ticket issuance", logger.Err(err))
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("tickets issued", logger.Int64("booking_id", bookingID), logger.Int("count", len(itemIDs)))
+	return r.GetTicketsByBookingID(ctx, bookingID)
+}
+
+func (r *ticketRepository) GetTicketsByBookingID(ctx context.Context, bookingID int64) ([]entity.Ticket, error) {
+	logger.FromContext(ctx).Debug("fetching tickets by booking ID", logger.Int64("booking_id", bookingID))
+
+	query := ticketSelect + ` WHERE bi.booking_id = $1 AND bi.ticket_code IS NOT NULL ORDER BY bi.id`
+	rows, err := r.db.Query(ctx, query, bookingID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query tickets", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []entity.Ticket
+	for rows.Next() {
+		t, err := scanTicket(rows)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to scan ticket row", logger.Err(err))
+			return nil, err
+		}
+		tickets = append(tickets, *t)
+	}
+
+	return tickets, nil
+}
+
+func (r *ticketRepository) GetTicketByCode(ctx context.Context, code string) (*entity.Ticket, error) {
+	logger.FromContext(ctx).Debug("fetching ticket by code")
+
+	t, err := scanTicket(r.db.QueryRow(ctx, ticketSelect+` WHERE bi.ticket_code = $1`, code))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, r.unknownCodeError(ctx, code)
+		}
+		logger.FromContext(ctx).Error("failed to fetch ticket by code", logger.Err(err))
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// unknownCodeError tells a code that was revoked by a regeneration apart from
+// one that never existed.
+func (r *ticketRepository) unknownCodeError(ctx context.Context, code string) error {
+	var revoked bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM revoked_ticket_codes WHERE code = $1)`, code).Scan(&revoked)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to check revoked ticket codes", logger.Err(err))
+		return err
+	}
+	if revoked {
+		logger.FromContext(ctx).Warn("revoked ticket code presented")
+		return entity.ErrTicketRevoked
+	}
+	return entity.ErrNotFound
+}
+
+// MarkCheckedIn flags the ticket as used. The update only succeeds for a ticket
+// that has not been checked in yet, so two gates scanning the same code at the
+// same moment cannot both admit it.
+func (r *ticketRepository) MarkCheckedIn(ctx context.Context, ticketID, staffID int64) (*time.Time, error) {
+	logger.FromContext(ctx).Debug("checking in ticket", logger.Int64("ticket_id", ticketID), logger.Int64("staff_id", staffID))
+
+	query := `
+		UPDATE booking_items SET checked_in_at = NOW(), checked_in_by = $1
+		WHERE id = $2 AND checked_in_at IS NULL
+		RETURNING checked_in_at
+	`
+	var checkedInAt time.Time
+	err := r.db.QueryRow(ctx, query, staffID, ticketID).Scan(&checkedInAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			logger.FromContext(ctx).Warn("ticket already checked in", logger.Int64("ticket_id", ticketID))
+			return nil, entity.ErrTicketAlreadyUsed
+		}
+		logger.FromContext(ctx).Error("failed to check in ticket", logger.Int64("ticket_id", ticketID), logger.Err(err))
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("ticket checked in", logger.Int64("ticket_id", ticketID), logger.Int64("staff_id", staffID))
+	return &checkedInAt, nil
+}
+
+// RegenerateTickets locks the booking so a concurrent refund or seat change
+// cannot interleave, then replaces each ticket code and records the old one as
+// revoked. Items without a code yet get their first one. Check-in state is kept.
+func (r *ticketRepository) RegenerateTickets(ctx context.Context, bookingID int64, revokedBy *int64) ([]entity.Ticket, error) {
+	logger.FromContext(ctx).Debug("regenerating tickets", logger.Int64("booking_id", bookingID))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var status entity.BookingStatus
+	err = tx.QueryRow(ctx, `SELECT status FROM booking WHERE booking_id = $1 FOR UPDATE`, bookingID).Scan(&status)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to lock booking", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return nil, err
+	}
+	if status != entity.BookingPaid {
+		return nil, entity.ErrBookingNotPaid
+	}
+
+	rows, err := tx.Query(ctx, `SELECT id, ticket_code FROM booking_items WHERE booking_id = $1 FOR UPDATE`, bookingID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query booking items", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return nil, err
+	}
+	type item struct {
+		id   int64
+		code *string
+	}
+	var items []item
+	for rows.Next() {
+		var it item
+		if err := rows.Scan(&it.id, &it.code); err != nil {
+			rows.Close()
+			logger.FromContext(ctx).Error("failed to scan booking item", logger.Err(err))
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	rows.Close()
+
+	for _, it := range items {
+		if it.code != nil {
+			_, err := tx.Exec(ctx, `INSERT INTO revoked_ticket_codes (code, booking_item_id, revoked_by) VALUES ($1, $2, $3)`,
+				*it.code, it.id, revokedBy)
+			if err != nil {
+				logger.FromContext(ctx).Error("failed to revoke ticket code", logger.Int64("item_id", it.id), logger.Err(err))
+				return nil, err
+			}
+		}
+
+		code, err := generateTicketCode()
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to generate ticket code", logger.Err(err))
+			return nil, err
+		}
+		if _, err := tx.Exec(ctx, `UPDATE booking_items SET ticket_code = $1 WHERE id = $2`, code, it.id); err != nil {
+			logger.FromContext(ctx).Error("failed to assign ticket code", logger.Int64("item_id", it.id), logger.Err(err))
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit ticket regeneration", logger.Err(err))
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("tickets regenerated", logger.Int64("booking_id", bookingID), logger.Int("count", len(items)))
+	return r.GetTicketsByBookingID(ctx, bookingID)
+}
+
+// CheckInBatch does the whole batch in one statement: the data-modifying CTE
+// admits the PAID, unused tickets of the event and the outer select looks up
+// every code, so a turnstile costs one round trip however many codes it
+// sends. As with MarkCheckedIn, a ticket is only admitted while its
+// checked_in_at is still empty.
+func (r *ticketRepository) CheckInBatch(ctx context.Context, eventID, gateID int64, codes []string) ([]entity.ScannedCode, error) {
+	logger.FromContext(ctx).Debug("checking in ticket batch",
+		logger.Int64("event_id", eventID),
+		logger.Int64("gate_id", gateID),
+		logger.Int("count", len(codes)),
+	)
+
+	query := `
+		WITH scanned AS (
+			SELECT DISTINCT code FROM unnest($1::text[]) AS code
+		), admitted AS (
+			UPDATE booking_items bi SET checked_in_at = NOW(), checked_in_gate = $3
+			FROM scanned s, booking b
+			WHERE bi.ticket_code = s.code AND b.booking_id = bi.booking_id
+				AND b.event_id = $2 AND b.status = 'PAID' AND bi.checked_in_at IS NULL
+			RETURNING bi.id, bi.checked_in_at
+		)
+		SELECT s.code, bi.id, bi.booking_id, b.event_id, COALESCE(bi.seat_id, 0), COALESCE(st.seat_number, ''),
+			b.status, COALESCE(a.checked_in_at, bi.checked_in_at), bi.checked_in_by,
+			CASE WHEN a.id IS NOT NULL THEN $3 ELSE bi.checked_in_gate END,
+			a.id IS NOT NULL,
+			bi.id IS NULL AND EXISTS (SELECT 1 FROM revoked_ticket_codes rc WHERE rc.code = s.code)
+		FROM scanned s
+		LEFT JOIN booking_items bi ON bi.ticket_code = s.code
+		LEFT JOIN booking b ON b.booking_id = bi.booking_id
+		LEFT JOIN seats st ON st.seat_id = bi.seat_id
+		LEFT JOIN admitted a ON a.id = bi.id
+	`
+	rows, err := r.db.Query(ctx, query, codes, eventID, gateID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to check in ticket batch", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	scanned := make([]entity.ScannedCode, 0, len(codes))
+	for rows.Next() {
+		var (
+			sc          entity.ScannedCode
+			id          *int64
+			bookingID   *int64
+			ticketEvent *int64
+			seatID      *int64
+			seatNumber  *string
+			status      *string
+			t           entity.Ticket
+		)
+		if err := rows.Scan(&sc.Code, &id, &bookingID, &ticketEvent, &seatID, &seatNumber,
+			&status, &t.CheckedInAt, &t.CheckedInBy, &t.CheckedInGate, &sc.Admitted, &sc.Revoked); err != nil {
+			logger.FromContext(ctx).Error("failed to scan ticket batch row", logger.Err(err))
+			return nil, err
+		}
+		if id != nil {
+			t.ID, t.BookingID, t.EventID = *id, *bookingID, *ticketEvent
+			t.SeatID, t.SeatNumber, t.BookingStatus = *seatID, *seatNumber, entity.BookingStatus(*status)
+			t.Code = sc.Code
+			sc.Ticket = &t
+		}
+		scanned = append(scanned, sc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return scanned, nil
+}
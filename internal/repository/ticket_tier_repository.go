@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TicketTierRepository interface {
+	CreateTier(ctx context.Context, tier *entity.TicketTier) error
+	GetTiersByEventID(ctx context.Context, eventID int64) ([]entity.TicketTier, error)
+	GetTierByID(ctx context.Context, tierID int64) (*entity.TicketTier, error)
+	// UpdateTier changes name, price and quota. The new price is applied to
+	// the tier's unsold seats; sold seats keep the price they were bought at.
+	UpdateTier(ctx context.Context, tier *entity.TicketTier) error
+	// DeleteTier removes the tier. Its seats keep their current price.
+	DeleteTier(ctx context.Context, tierID int64) error
+	// AssignSeats moves unsold seats of the event into the tier at the tier's
+	// price and returns how many moved. It fails with ErrTierQuotaExceeded,
+	// changing nothing, if the tier would hold more seats than its quota.
+	AssignSeats(ctx context.Context, tierID, eventID int64, assignment *entity.TierSeatAssignment) (int64, error)
+}
+
+type ticketTierRepository struct {
+	db *pgxpool.Pool
+	// seats drops cached seat maps whose prices or tiers changed
+	seats SeatAvailabilityCache
+}
+
+func NewTicketTierRepository(db *pgxpool.Pool, seats SeatAvailabilityCache) TicketTierRepository {
+	return &ticketTierRepository{db: db, seats: seats}
+}
+
+const ticketTierSelect = `
+	SELECT t.tier_id, t.event_id, t.name, t.price, t.quota,
+		COUNT(s.seat_id), COUNT(s.seat_id) FILTER (WHERE s.is_booked),
+		t.created_at, t.updated_at
+	FROM ticket_tiers t
+	LEFT JOIN seats s ON s.tier_id = t.tier_id
+`
+
+func scanTicketTier(row pgx.Row) (*entity.TicketTier, error) {
+	var t entity.TicketTier
+	err := row.Scan(&t.ID, &t.EventID, &t.Name, &t.Price, &t.Quota, &t.Assigned, &t.Sold, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func (r *ticketTierRepository) CreateTier(ctx context.Context, tier *entity.TicketTier) error {
+	logger.FromContext(ctx).Debug("creating ticket tier", logger.Int64("event_id", tier.EventID), logger.String("name", tier.Name))
+
+	query := `
+		INSERT INTO ticket_tiers (event_id, name, price, quota)
+		VALUES ($1, $2, $3, $4)
+		RETURNING tier_id, created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, tier.EventID, tier.Name, tier.Price, tier.Quota).Scan(&tier.ID, &tier.CreatedAt, &tier.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.ErrTierNameTaken
+		}
+		logger.FromContext(ctx).Error("failed to create ticket tier", logger.Int64("event_id", tier.EventID), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("ticket tier created", logger.Int64("tier_id", tier.ID), logger.Int64("event_id", tier.EventID))
+	return nil
+}
+
+func (r *ticketTierRepository) GetTiersByEventID(ctx context.Context, eventID int64) ([]entity.TicketTier, error) {
+	rows, err := r.db.Query(ctx, ticketTierSelect+` WHERE t.event_id = $1 GROUP BY t.tier_id ORDER BY t.price DESC, t.tier_id`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query ticket tiers", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	tiers := []entity.TicketTier{}
+	for rows.Next() {
+		tier, err := scanTicketTier(rows)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to scan ticket tier row", logger.Err(err))
+			return nil, err
+		}
+		tiers = append(tiers, *tier)
+	}
+
+	return tiers, rows.Err()
+}
+
+func (r *ticketTierRepository) GetTierByID(ctx context.Context, tierID int64) (*entity.TicketTier, error) {
+	tier, err := scanTicketTier(r.db.QueryRow(ctx, ticketTierSelect+` WHERE t.tier_id = $1 GROUP BY t.tier_id`, tierID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch ticket tier", logger.Int64("tier_id", tierID), logger.Err(err))
+		return nil, err
+	}
+	return tier, nil
+}
+
+func (r *ticketTierRepository) UpdateTier(ctx context.Context, tier *entity.TicketTier) error {
+	logger.FromContext(ctx).Debug("updating ticket tier", logger.Int64("tier_id", tier.ID))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the tier so a concurrent assignment can't slip past the new quota
+	var assigned int
+	err = tx.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM seats WHERE tier_id = t.tier_id)
+		FROM ticket_tiers t WHERE t.tier_id = $1
+		FOR UPDATE
+	`, tier.ID).Scan(&assigned)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to lock ticket tier", logger.Int64("tier_id", tier.ID), logger.Err(err))
+		return err
+	}
+	if assigned > tier.Quota {
+		return entity.ErrTierQuotaExceeded
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE ticket_tiers SET name = $1, price = $2, quota = $3, updated_at = NOW()
+		WHERE tier_id = $4
+		RETURNING event_id, updated_at
+	`, tier.Name, tier.Price, tier.Quota, tier.ID).Scan(&tier.EventID, &tier.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.ErrTierNameTaken
+		}
+		logger.FromContext(ctx).Error("failed to update ticket tier", logger.Int64("tier_id", tier.ID), logger.Err(err))
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE seats SET price = $1 WHERE tier_id = $2 AND is_booked = FALSE`, tier.Price, tier.ID); err != nil {
+		logger.FromContext(ctx).Error("failed to reprice tier seats", logger.Int64("tier_id", tier.ID), logger.Err(err))
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit transaction", logger.Err(err))
+		return err
+	}
+	r.seats.Invalidate(ctx, tier.EventID)
+
+	logger.FromContext(ctx).Info("ticket tier updated", logger.Int64("tier_id", tier.ID))
+	return nil
+}
+
+func (r *ticketTierRepository) DeleteTier(ctx context.Context, tierID int64) error {
+	var eventID int64
+	err := r.db.QueryRow(ctx, `DELETE FROM ticket_tiers WHERE tier_id = $1 RETURNING event_id`, tierID).Scan(&eventID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to delete ticket tier", logger.Int64("tier_id", tierID), logger.Err(err))
+		return err
+	}
+	r.seats.Invalidate(ctx, eventID)
+
+	logger.FromContext(ctx).Info("ticket tier deleted", logger.Int64("tier_id", tierID))
+	return nil
+}
+
+func (r *ticketTierRepository) AssignSeats(ctx context.Context, tierID, eventID int64, assignment *entity.TierSeatAssignment) (int64, error) {
+	logger.FromContext(ctx).Debug("assigning seats to ticket tier",
+		logger.Int64("tier_id", tierID),
+		logger.Int("seat_ids", len(assignment.SeatIDs)),
+		logger.String("section", assignment.Section),
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var price float64
+	var quota int
+	err = tx.QueryRow(ctx, `SELECT price, quota FROM ticket_tiers WHERE tier_id = $1 AND event_id = $2 FOR UPDATE`, tierID, eventID).Scan(&price, &quota)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to lock ticket tier", logger.Int64("tier_id", tierID), logger.Err(err))
+		return 0, err
+	}
+
+	// Sold seats keep the tier and price they were bought under
+	tag, err := tx.Exec(ctx, `
+		UPDATE seats SET tier_id = $1, price = $2
+		WHERE event_id = $3 AND is_booked = FALSE
+			AND (seat_id = ANY($4) OR ($5 <> '' AND category = $5))
+	`, tierID, price, eventID, assignment.SeatIDs, assignment.Section)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to assign seats to tier", logger.Int64("tier_id", tierID), logger.Err(err))
+		return 0, err
+	}
+
+	var assigned int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM seats WHERE tier_id = $1`, tierID).Scan(&assigned); err != nil {
+		logger.FromContext(ctx).Error("failed to count tier seats", logger.Int64("tier_id", tierID), logger.Err(err))
+		return 0, err
+	}
+	if assigned > quota {
+		logger.FromContext(ctx).Warn("tier assignment exceeds quota",
+			logger.Int64("tier_id", tierID),
+			logger.Int("assigned", assigned),
+			logger.Int("quota", quota),
+		)
+		return 0, entity.ErrTierQuotaExceeded
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit transaction", logger.Err(err))
+		return 0, err
+	}
+	r.seats.Invalidate(ctx, eventID)
+
+	logger.FromContext(ctx).Info("seats assigned to ticket tier", logger.Int64("tier_id", tierID), logger.Int64("seats", tag.RowsAffected()))
+	return tag.RowsAffected(), nil
+}
@@ -16,7 +16,7 @@ type TransactionRepository interface {
 	CreateTransaction(ctx context.Context, txn *entity.Transaction) error
 	GetTransactionByBookingID(ctx context.Context, bookingID int64) (*entity.Transaction, error)
 	GetTransactionByExternalID(ctx context.Context, externalID string) (*entity.Transaction, error)
-	UpdateTransactionStatus(ctx context.Context, paymentID int64, status, externalID string) error
+	UpdateTransactionStatus(ctx context.Context, paymentID int64, status entity.PaymentStatus, externalID string) error
 }
 
 type transactionRepository struct {
@@ -28,7 +28,7 @@ func NewTransactionRepository(db *pgxpool.Pool) TransactionRepository {
 }
 
 func (r *transactionRepository) CreateTransaction(ctx context.Context, txn *entity.Transaction) error {
-	logger.Debug("creating transaction",
+	logger.FromContext(ctx).Debug("creating transaction",
 		logger.Int64("booking_id", txn.BookingID),
 		logger.Float64("amount", txn.Amount),
 	)
@@ -41,18 +41,18 @@ func (r *transactionRepository) CreateTransaction(ctx context.Context, txn *enti
 
 	externalID := fmt.Sprintf("TXN-%d-%d", txn.BookingID, time.Now().UnixMilli())
 
-	err := r.db.QueryRow(ctx, query,
-		txn.Amount, txn.PaymentMethod, txn.BookingID, externalID, "PENDING",
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		txn.Amount, txn.PaymentMethod, txn.BookingID, externalID, entity.PaymentPending,
 	).Scan(&txn.ID, &txn.TransactionDate)
 	if err != nil {
-		logger.Error("failed to create transaction", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to create transaction", logger.Err(err))
 		return err
 	}
 
 	txn.ExternalID = externalID
-	txn.Status = "PENDING"
+	txn.Status = entity.PaymentPending
 
-	logger.Info("transaction created",
+	logger.FromContext(ctx).Info("transaction created",
 		logger.Int64("payment_id", txn.ID),
 		logger.Int64("booking_id", txn.BookingID),
 		logger.String("external_id", externalID),
@@ -61,24 +61,26 @@ func (r *transactionRepository) CreateTransaction(ctx context.Context, txn *enti
 }
 
 func (r *transactionRepository) GetTransactionByBookingID(ctx context.Context, bookingID int64) (*entity.Transaction, error) {
-	logger.Debug("fetching transaction by booking ID", logger.Int64("booking_id", bookingID))
+	logger.FromContext(ctx).Debug("fetching transaction by booking ID", logger.Int64("booking_id", bookingID))
 
 	query := `
-		SELECT payment_id, amount, COALESCE(payment_method, ''), booking_id, transaction_date, COALESCE(external_id, ''), COALESCE(status, 'PENDING')
-		FROM transactions
-		WHERE booking_id = $1
+		SELECT t.payment_id, t.amount, COALESCE(t.payment_method, ''), t.booking_id, t.transaction_date, COALESCE(t.external_id, ''),
+			t.status, COALESCE(i.invoice_number, '')
+		FROM transactions t
+		LEFT JOIN invoices i ON i.payment_id = t.payment_id
+		WHERE t.booking_id = $1
 	`
 
 	var txn entity.Transaction
 	err := r.db.QueryRow(ctx, query, bookingID).Scan(
 		&txn.ID, &txn.Amount, &txn.PaymentMethod, &txn.BookingID,
-		&txn.TransactionDate, &txn.ExternalID, &txn.Status,
+		&txn.TransactionDate, &txn.ExternalID, &txn.Status, &txn.InvoiceNumber,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
 		}
-		logger.Error("failed to fetch transaction", logger.Int64("booking_id", bookingID), logger.Err(err))
+		logger.FromContext(ctx).Error("failed to fetch transaction", logger.Int64("booking_id", bookingID), logger.Err(err))
 		return nil, err
 	}
 
@@ -86,49 +88,72 @@ func (r *transactionRepository) GetTransactionByBookingID(ctx context.Context, b
 }
 
 func (r *transactionRepository) GetTransactionByExternalID(ctx context.Context, externalID string) (*entity.Transaction, error) {
-	logger.Debug("fetching transaction by external ID", logger.String("external_id", externalID))
+	logger.FromContext(ctx).Debug("fetching transaction by external ID", logger.String("external_id", externalID))
 
 	query := `
-		SELECT payment_id, amount, COALESCE(payment_method, ''), booking_id, transaction_date, COALESCE(external_id, ''), COALESCE(status, 'PENDING')
-		FROM transactions
-		WHERE external_id = $1
+		SELECT t.payment_id, t.amount, COALESCE(t.payment_method, ''), t.booking_id, t.transaction_date, COALESCE(t.external_id, ''),
+			t.status, COALESCE(i.invoice_number, '')
+		FROM transactions t
+		LEFT JOIN invoices i ON i.payment_id = t.payment_id
+		WHERE t.external_id = $1
 	`
 
 	var txn entity.Transaction
 	err := r.db.QueryRow(ctx, query, externalID).Scan(
 		&txn.ID, &txn.Amount, &txn.PaymentMethod, &txn.BookingID,
-		&txn.TransactionDate, &txn.ExternalID, &txn.Status,
+		&txn.TransactionDate, &txn.ExternalID, &txn.Status, &txn.InvoiceNumber,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
 		}
-		logger.Error("failed to fetch transaction by external ID", logger.String("external_id", externalID), logger.Err(err))
+		logger.FromContext(ctx).Error("failed to fetch transaction by external ID", logger.String("external_id", externalID), logger.Err(err))
 		return nil, err
 	}
 
 	return &txn, nil
 }
 
-func (r *transactionRepository) UpdateTransactionStatus(ctx context.Context, paymentID int64, status, externalID string) error {
-	logger.Debug("updating transaction status",
+// UpdateTransactionStatus sets the payment's status. Completing a payment
+// issues its invoice in the same transaction, so a completed payment always
+// has an invoice number.
+func (r *transactionRepository) UpdateTransactionStatus(ctx context.Context, paymentID int64, status entity.PaymentStatus, externalID string) error {
+	logger.FromContext(ctx).Debug("updating transaction status",
 		logger.Int64("payment_id", paymentID),
-		logger.String("status", status),
+		logger.String("status", string(status)),
 	)
 
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `UPDATE transactions SET status = $1, payment_method = COALESCE(NULLIF($2, ''), payment_method), external_id = COALESCE(NULLIF($3, ''), external_id) WHERE payment_id = $4`
-	_, err := r.db.Exec(ctx, query, status, "", externalID, paymentID)
+	_, err = tx.Exec(ctx, query, status, "", externalID, paymentID)
 	if err != nil {
-		logger.Error("failed to update transaction status",
+		logger.FromContext(ctx).Error("failed to update transaction status",
 			logger.Int64("payment_id", paymentID),
 			logger.Err(err),
 		)
 		return err
 	}
 
-	logger.Info("transaction status updated",
+	if status == entity.PaymentCompleted {
+		if err := issueInvoice(ctx, tx, paymentID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit transaction status", logger.Int64("payment_id", paymentID), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("transaction status updated",
 		logger.Int64("payment_id", paymentID),
-		logger.String("status", status),
+		logger.String("status", string(status)),
 	)
 	return nil
 }
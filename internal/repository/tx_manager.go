@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TxManager runs work spanning several repositories as one unit: repository
+// calls made with the context passed to fn share a single Postgres
+// transaction, committed when fn returns nil and rolled back otherwise.
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// dbtx is what repositories need from a connection, met by both the pool
+// and a transaction. Begin on a transaction opens a savepoint, so methods
+// that manage their own transaction nest inside a unit of work.
+type dbtx interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type txKey struct{}
+
+// unitOfWork is the transaction of a WithinTransaction call and the work
+// deferred until it commits.
+type unitOfWork struct {
+	tx          pgx.Tx
+	afterCommit []func()
+}
+
+type txManager struct {
+	db *pgxpool.Pool
+}
+
+func NewTxManager(db *pgxpool.Pool) TxManager {
+	return &txManager{db: db}
+}
+
+// WithinTransaction joins the caller's transaction when ctx already carries
+// one, so units of work can be composed.
+func (m *txManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*unitOfWork); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	uow := &unitOfWork{tx: tx}
+	if err := fn(context.WithValue(ctx, txKey{}, uow)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit transaction", logger.Err(err))
+		return err
+	}
+
+	for _, f := range uow.afterCommit {
+		f()
+	}
+	return nil
+}
+
+// conn returns the transaction of the unit of work in ctx, or the pool when
+// there is none.
+func conn(ctx context.Context, db *pgxpool.Pool) dbtx {
+	if uow, ok := ctx.Value(txKey{}).(*unitOfWork); ok {
+		return uow.tx
+	}
+	return db
+}
+
+// afterCommit runs fn once the unit of work in ctx commits, or right away
+// when there is none. Side effects such as seat update broadcasts go here so
+// they are never announced for a rolled back write.
+func afterCommit(ctx context.Context, fn func()) {
+	if uow, ok := ctx.Value(txKey{}).(*unitOfWork); ok {
+		uow.afterCommit = append(uow.afterCommit, fn)
+		return
+	}
+	fn()
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UpgradeOfferRepository interface {
+	// ExpireOffers closes pending offers past their expiry so their premium
+	// seats can be offered to someone else.
+	ExpireOffers(ctx context.Context) (int64, error)
+	// GetUpgradeCandidates pairs unsold premium seats (the event's top price)
+	// of events starting within the given window with cheaper, unused
+	// tickets that have never been offered an upgrade, earliest bookings first.
+	GetUpgradeCandidates(ctx context.Context, within time.Duration, limit int) ([]entity.UpgradeCandidate, error)
+	CreateOffer(ctx context.Context, offer *entity.UpgradeOffer, tokenHash string) error
+	GetOfferByID(ctx context.Context, offerID int64) (*entity.UpgradeOffer, error)
+	GetOfferByTokenHash(ctx context.Context, tokenHash string) (*entity.UpgradeOffer, error)
+	// AcceptOffer claims the pending offer and applies its seat change in the
+	// same transaction. mod carries the payment method and external ID; the
+	// booking and seats are taken from the offer.
+	AcceptOffer(ctx context.Context, tokenHash string, mod *entity.BookingModification) (*entity.UpgradeOffer, error)
+}
+
+type upgradeOfferRepository struct {
+	db          *pgxpool.Pool
+	seatUpdates SeatUpdatePublisher
+}
+
+func NewUpgradeOfferRepository(db *pgxpool.Pool, seatUpdates SeatUpdatePublisher) UpgradeOfferRepository {
+	return &upgradeOfferRepository{db: db, seatUpdates: seatUpdates}
+}
+
+const upgradeOfferSelect = `
+	SELECT o.offer_id, o.booking_id, o.from_seat_id, o.to_seat_id, o.price_difference, o.status,
+		o.expires_at, o.accepted_at, o.modification_id, o.created_at,
+		e.event_id, e.name, e.date, COALESCE(fs.seat_number, ''), COALESCE(ts.seat_number, '')
+	FROM upgrade_offers o
+	JOIN booking b ON b.booking_id = o.booking_id
+	JOIN events e ON e.event_id = b.event_id
+	LEFT JOIN seats fs ON fs.seat_id = o.from_seat_id
+	LEFT JOIN seats ts ON ts.seat_id = o.to_seat_id
+`
+
+func (r *upgradeOfferRepository) ExpireOffers(ctx context.Context) (int64, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE upgrade_offers SET status = 'EXPIRED'
+		WHERE status = 'PENDING' AND expires_at <= NOW()
+	`)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to expire upgrade offers", logger.Err(err))
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *upgradeOfferRepository) GetUpgradeCandidates(ctx context.Context, within time.Duration, limit int) ([]entity.UpgradeCandidate, error) {
+	logger.FromContext(ctx).Debug("fetching upgrade offer candidates")
+
+	// Premium seats and ticket holders are each numbered per event and
+	// matched by that number, so every seat goes to at most one holder
+	query := `
+		WITH upcoming AS (
+			SELECT e.event_id, e.date, MAX(s.price) AS premium_price
+			FROM events e
+			JOIN seats s ON s.event_id = e.event_id
+			WHERE e.status = 'available' AND e.date > NOW() AND e.date <= $1
+			GROUP BY e.event_id, e.date
+		),
+		premium AS (
+			SELECT s.seat_id, s.event_id, s.price,
+				ROW_NUMBER() OVER (PARTITION BY s.event_id ORDER BY s.seat_id) AS rn
+			FROM seats s
+			JOIN upcoming u ON u.event_id = s.event_id
+			WHERE s.is_booked = FALSE AND s.price = u.premium_price
+				AND NOT EXISTS (
+					SELECT 1 FROM upgrade_offers o WHERE o.to_seat_id = s.seat_id AND o.status = 'PENDING'
+				)
+		),
+		holders AS (
+			SELECT bi.booking_id, bi.seat_id, b.event_id, u.date, usr.email, s.price,
+				COALESCE(pv.price_multiplier, 1) AS multiplier,
+				ROW_NUMBER() OVER (PARTITION BY b.event_id ORDER BY b.created_at, bi.id) AS rn
+			FROM booking_items bi
+			JOIN booking b ON b.booking_id = bi.booking_id
+			JOIN upcoming u ON u.event_id = b.event_id
+			JOIN seats s ON s.seat_id = bi.seat_id
+			JOIN users usr ON usr.user_id = b.user_id
+			LEFT JOIN pricing_variants pv ON pv.variant_id = b.variant_id
+			WHERE b.status = 'PAID' AND bi.checked_in_at IS NULL
+				AND COALESCE(s.price, 0) < u.premium_price
+				AND NOT EXISTS (
+					SELECT 1 FROM upgrade_offers o WHERE o.booking_id = bi.booking_id AND o.from_seat_id = bi.seat_id
+				)
+		)
+		SELECT h.booking_id, h.email, h.event_id, h.date, h.seat_id, p.seat_id,
+			ROUND((p.price - COALESCE(h.price, 0)) * h.multiplier, 2)
+		FROM holders h
+		JOIN premium p ON p.event_id = h.event_id AND p.rn = h.rn
+		ORDER BY h.date, h.rn
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, time.Now().Add(within), limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query upgrade candidates", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []entity.UpgradeCandidate
+	for rows.Next() {
+		var c entity.UpgradeCandidate
+		if err := rows.Scan(&c.BookingID, &c.UserEmail, &c.EventID, &c.EventDate, &c.FromSeatID, &c.ToSeatID, &c.PriceDifference); err != nil {
+			logger.FromContext(ctx).Error("failed to scan upgrade candidate row", logger.Err(err))
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+func (r *upgradeOfferRepository) CreateOffer(ctx context.Context, offer *entity.UpgradeOffer, tokenHash string) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO upgrade_offers (booking_id, from_seat_id, to_seat_id, price_difference, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING offer_id, status, created_at
+	`, offer.BookingID, offer.FromSeatID, offer.ToSeatID, offer.PriceDifference, tokenHash, offer.ExpiresAt).
+		Scan(&offer.ID, &offer.Status, &offer.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create upgrade offer", logger.Int64("booking_id", offer.BookingID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *upgradeOfferRepository) GetOfferByID(ctx context.Context, offerID int64) (*entity.UpgradeOffer, error) {
+	return r.getOffer(ctx, upgradeOfferSelect+` WHERE o.offer_id = $1`, offerID)
+}
+
+func (r *upgradeOfferRepository) GetOfferByTokenHash(ctx context.Context, tokenHash string) (*entity.UpgradeOffer, error) {
+	return r.getOffer(ctx, upgradeOfferSelect+` WHERE o.token_hash = $1`, tokenHash)
+}
+
+func (r *upgradeOfferRepository) getOffer(ctx context.Context, query string, arg interface{}) (*entity.UpgradeOffer, error) {
+	var o entity.UpgradeOffer
+	err := r.db.QueryRow(ctx, query, arg).Scan(&o.ID, &o.BookingID, &o.FromSeatID, &o.ToSeatID, &o.PriceDifference, &o.Status,
+		&o.ExpiresAt, &o.AcceptedAt, &o.ModificationID, &o.CreatedAt,
+		&o.EventID, &o.EventName, &o.EventDate, &o.FromSeatNumber, &o.ToSeatNumber)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to get upgrade offer", logger.Err(err))
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *upgradeOfferRepository) AcceptOffer(ctx context.Context, tokenHash string, mod *entity.BookingModification) (*entity.UpgradeOffer, error) {
+	logger.FromContext(ctx).Debug("accepting upgrade offer")
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var offer entity.UpgradeOffer
+	err = tx.QueryRow(ctx, `
+		SELECT offer_id, booking_id, from_seat_id, to_seat_id, price_difference, status, expires_at
+		FROM upgrade_offers WHERE token_hash = $1
+		FOR UPDATE
+	`, tokenHash).Scan(&offer.ID, &offer.BookingID, &offer.FromSeatID, &offer.ToSeatID, &offer.PriceDifference, &offer.Status, &offer.ExpiresAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to lock upgrade offer", logger.Err(err))
+		return nil, err
+	}
+	if offer.Status != entity.UpgradeOfferPending || !offer.ExpiresAt.After(time.Now()) {
+		return nil, entity.ErrUpgradeOfferClosed
+	}
+
+	mod.BookingID = offer.BookingID
+	mod.FromSeatIDs = []int64{offer.FromSeatID}
+	mod.ToSeatIDs = []int64{offer.ToSeatID}
+	eventID, err := applySeatChange(ctx, tx, mod)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE upgrade_offers SET status = 'ACCEPTED', accepted_at = NOW(), modification_id = $1
+		WHERE offer_id = $2
+		RETURNING status, accepted_at, modification_id
+	`, mod.ID, offer.ID).Scan(&offer.Status, &offer.AcceptedAt, &offer.ModificationID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to mark upgrade offer accepted", logger.Int64("offer_id", offer.ID), logger.Err(err))
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit upgrade offer", logger.Err(err))
+		return nil, err
+	}
+	publishSeatChange(ctx, r.seatUpdates, eventID, mod)
+
+	logger.FromContext(ctx).Info("upgrade offer accepted",
+		logger.Int64("offer_id", offer.ID),
+		logger.Int64("booking_id", offer.BookingID),
+		logger.Float64("amount_charged", mod.AmountCharged),
+	)
+	return &offer, nil
+}
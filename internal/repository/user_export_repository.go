@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserExportRepository interface {
+	// CreateExport records a PENDING export for the user in place of their
+	// earlier ones, so only the latest archive is kept.
+	CreateExport(ctx context.Context, userID int64) (*entity.UserDataExport, error)
+	// GetExport returns an export without its archive. Returns ErrNotFound
+	// if there is no such export.
+	GetExport(ctx context.Context, exportID int64) (*entity.UserDataExport, error)
+	// GetLatestExport returns the user's most recent export, with its
+	// archive once READY. Returns ErrNotFound if the user never asked.
+	GetLatestExport(ctx context.Context, userID int64) (*entity.UserDataExport, error)
+	// CollectUserData gathers the user's profile, bookings and payments.
+	CollectUserData(ctx context.Context, userID int64) (*entity.UserDataArchive, error)
+	// CompleteExport stores the archive and marks a PENDING export READY.
+	CompleteExport(ctx context.Context, exportID int64, archive []byte) error
+	// FailExport marks a PENDING export FAILED.
+	FailExport(ctx context.Context, exportID int64, reason string) error
+}
+
+type userExportRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUserExportRepository(db *pgxpool.Pool) UserExportRepository {
+	return &userExportRepository{db: db}
+}
+
+func (r *userExportRepository) CreateExport(ctx context.Context, userID int64) (*entity.UserDataExport, error) {
+	export := &entity.UserDataExport{UserID: userID, Status: entity.UserExportPending}
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		WITH replaced AS (
+			DELETE FROM user_data_exports WHERE user_id = $1
+		)
+		INSERT INTO user_data_exports (user_id) VALUES ($1)
+		RETURNING export_id, requested_at
+	`, userID).Scan(&export.ID, &export.RequestedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create user data export", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("user data export requested", logger.Int64("user_id", userID), logger.Int64("export_id", export.ID))
+	return export, nil
+}
+
+func (r *userExportRepository) GetExport(ctx context.Context, exportID int64) (*entity.UserDataExport, error) {
+	var export entity.UserDataExport
+	err := r.db.QueryRow(ctx, `
+		SELECT export_id, user_id, status, requested_at, completed_at
+		FROM user_data_exports
+		WHERE export_id = $1
+	`, exportID).Scan(&export.ID, &export.UserID, &export.Status, &export.RequestedAt, &export.CompletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to get user data export", logger.Int64("export_id", exportID), logger.Err(err))
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *userExportRepository) GetLatestExport(ctx context.Context, userID int64) (*entity.UserDataExport, error) {
+	var export entity.UserDataExport
+	var archive []byte
+	err := r.db.QueryRow(ctx, `
+		SELECT export_id, user_id, status, requested_at, completed_at,
+			CASE WHEN status = 'READY' THEN archive END
+		FROM user_data_exports
+		WHERE user_id = $1
+		ORDER BY export_id DESC
+		LIMIT 1
+	`, userID).Scan(&export.ID, &export.UserID, &export.Status, &export.RequestedAt, &export.CompletedAt, &archive)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to get latest user data export", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+	export.Archive = archive
+	return &export, nil
+}
+
+func (r *userExportRepository) CollectUserData(ctx context.Context, userID int64) (*entity.UserDataArchive, error) {
+	logger.FromContext(ctx).Debug("collecting user data", logger.Int64("user_id", userID))
+
+	archive := &entity.UserDataArchive{Bookings: []entity.ExportedBooking{}, Transactions: []entity.Transaction{}}
+	p := &archive.Profile
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id, COALESCE(name, ''), COALESCE(username, ''), COALESCE(email, ''), role, created_at, NOW()
+		FROM users
+		WHERE user_id = $1
+	`, userID).Scan(&p.ID, &p.Name, &p.UserName, &p.Email, &p.Role, &p.CreatedAt, &archive.GeneratedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to collect user profile", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+
+	// Seatless general admission items count as tickets too
+	rows, err := r.db.Query(ctx, `
+		SELECT b.booking_id, b.event_id, e.name, e.date, b.status, COALESCE(b.total_amount, 0), b.created_at,
+			COUNT(bi.id), COALESCE(ARRAY_AGG(s.seat_number ORDER BY s.seat_number) FILTER (WHERE s.seat_number IS NOT NULL), '{}')
+		FROM booking b
+		JOIN events e ON e.event_id = b.event_id
+		LEFT JOIN booking_items bi ON bi.booking_id = b.booking_id
+		LEFT JOIN seats s ON s.seat_id = bi.seat_id
+		WHERE b.user_id = $1
+		GROUP BY b.booking_id, e.event_id
+		ORDER BY b.booking_id
+	`, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to collect user bookings", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var b entity.ExportedBooking
+		if err := rows.Scan(&b.BookingID, &b.EventID, &b.EventName, &b.EventDate, &b.Status, &b.TotalAmount, &b.CreatedAt, &b.Tickets, &b.Seats); err != nil {
+			logger.FromContext(ctx).Error("failed to scan exported booking row", logger.Err(err))
+			return nil, err
+		}
+		archive.Bookings = append(archive.Bookings, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.Query(ctx, `
+		SELECT t.payment_id, t.amount, COALESCE(t.payment_method, ''), t.booking_id, t.transaction_date, COALESCE(t.external_id, ''),
+			t.status, COALESCE(i.invoice_number, '')
+		FROM transactions t
+		JOIN booking b ON b.booking_id = t.booking_id
+		LEFT JOIN invoices i ON i.payment_id = t.payment_id
+		WHERE b.user_id = $1
+		ORDER BY t.payment_id
+	`, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to collect user transactions", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t entity.Transaction
+		if err := rows.Scan(&t.ID, &t.Amount, &t.PaymentMethod, &t.BookingID, &t.TransactionDate, &t.ExternalID, &t.Status, &t.InvoiceNumber); err != nil {
+			logger.FromContext(ctx).Error("failed to scan exported transaction row", logger.Err(err))
+			return nil, err
+		}
+		archive.Transactions = append(archive.Transactions, t)
+	}
+	return archive, rows.Err()
+}
+
+func (r *userExportRepository) CompleteExport(ctx context.Context, exportID int64, archive []byte) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE user_data_exports SET status = 'READY', archive = $2, completed_at = NOW()
+		WHERE export_id = $1 AND status = 'PENDING'
+	`, exportID, archive)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to complete user data export", logger.Int64("export_id", exportID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	logger.FromContext(ctx).Info("user data export ready", logger.Int64("export_id", exportID), logger.Int("bytes", len(archive)))
+	return nil
+}
+
+func (r *userExportRepository) FailExport(ctx context.Context, exportID int64, reason string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_data_exports SET status = 'FAILED', last_error = $2, completed_at = NOW()
+		WHERE export_id = $1 AND status = 'PENDING'
+	`, exportID, reason)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to mark user data export failed", logger.Int64("export_id", exportID), logger.Err(err))
+	}
+	return err
+}
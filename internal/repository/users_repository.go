@@ -3,10 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"ticres/internal/entity"
 	"ticres/pkg/logger"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -15,6 +17,59 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user *entity.User) error
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
 	GetUserByID(ctx context.Context, id int) (*entity.User, error)
+	UpdateUserRole(ctx context.Context, id int64, role string) error
+	CreatePasswordResetToken(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int64, error)
+	// UpdateProfile sets the user's name and username and fills in the rest
+	// of user. Returns ErrUsernameTaken if another account has the username.
+	UpdateProfile(ctx context.Context, user *entity.User) error
+	// UpdatePassword sets the user's password and revokes unused reset
+	// links, so one emailed before the change can't undo it. Every session
+	// of the user but keepSessionID is revoked too; 0 revokes them all.
+	UpdatePassword(ctx context.Context, userID, keepSessionID int64, passwordHash string) error
+	// AnonymizeUser wipes the personal data of a user's account and deletes
+	// their reset links, data exports and 2FA secrets, leaving bookings and payments in
+	// place. Returns ErrNotFound if the account is gone already.
+	AnonymizeUser(ctx context.Context, userID int64) error
+	// GetTwoFactor returns the user's TOTP state. Returns ErrNotFound if
+	// there is no such account.
+	GetTwoFactor(ctx context.Context, userID int64) (*entity.TwoFactor, error)
+	// SetTwoFactorSecret stores the encrypted secret of a 2FA setup in
+	// progress. Returns ErrTwoFactorEnabled if 2FA is on already.
+	SetTwoFactorSecret(ctx context.Context, userID int64, secret string) error
+	// EnableTwoFactor turns 2FA on once the code of step verified the
+	// secret, and replaces the user's recovery codes with codeHashes.
+	// Returns ErrTwoFactorEnabled if 2FA is on already.
+	EnableTwoFactor(ctx context.Context, userID int64, step int64, codeHashes []string) error
+	// UseTwoFactorStep records that a code of step was accepted. Returns
+	// ErrInvalidTwoFactorCode if a code of that step, or a later one, was
+	// used already.
+	UseTwoFactorStep(ctx context.Context, userID int64, step int64) error
+	// UseRecoveryCode spends an unused recovery code. Returns
+	// ErrInvalidTwoFactorCode if there is none with the hash.
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) error
+	// RecordTwoFactorFailure counts a wrong code against the user.
+	RecordTwoFactorFailure(ctx context.Context, userID int64) error
+	// GetUserByOAuth returns the account linked to the provider's subject.
+	// Returns ErrNotFound if there is none.
+	GetUserByOAuth(ctx context.Context, provider, subject string) (*entity.User, error)
+	// LinkOAuth links an account to the provider's subject. Returns
+	// ErrOAuthAccountConflict if the account is linked to another already.
+	LinkOAuth(ctx context.Context, userID int64, provider, subject string) error
+	// CreateSession records the session of a token about to be issued and
+	// fills in its ID and times.
+	CreateSession(ctx context.Context, session *entity.Session) error
+	// GetActiveSession returns the user's session. Returns ErrNotFound if
+	// there is none, or it was revoked or has expired.
+	GetActiveSession(ctx context.Context, userID, sessionID int64) (*entity.Session, error)
+	// TouchSession records that the session was just used.
+	TouchSession(ctx context.Context, sessionID int64) error
+	// ListSessions returns the user's active sessions, most recently seen
+	// first.
+	ListSessions(ctx context.Context, userID int64) ([]entity.Session, error)
+	// RevokeSession revokes one of the user's active sessions. Returns
+	// ErrNotFound if there is no such session.
+	RevokeSession(ctx context.Context, userID, sessionID int64) error
 }
 
 type userRepository struct {
@@ -27,22 +82,22 @@ func NewUserRepository(db *pgxpool.Pool) UserRepository {
 
 func (r *userRepository) CreateUser(ctx context.Context, user *entity.User) error {
 	query := `
-		INSERT INTO users (name, username, email, password, created_at)
-		VALUES ($1, $2, $3, $4, NOW())
+		INSERT INTO users (name, username, email, password, oauth_provider, oauth_subject, created_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''), NULLIF($6, ''), NOW())
 		RETURNING user_id, created_at
 	`
 
-	logger.Debug("creating user",
+	logger.FromContext(ctx).Debug("creating user",
 		logger.String("email", user.Email),
 		logger.String("name", user.Name),
 	)
 
-	err := r.db.QueryRow(ctx, query, user.Name, user.UserName, user.Email, user.Password).Scan(&user.ID, &user.CreatedAt)
+	err := r.db.QueryRow(ctx, query, user.Name, user.UserName, user.Email, user.Password, user.OAuthProvider, user.OAuthSubject).Scan(&user.ID, &user.CreatedAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" {
-				logger.Warn("user creation failed: duplicate email",
+				logger.FromContext(ctx).Warn("user creation failed: duplicate email",
 					logger.String("email", user.Email),
 					logger.String("pg_code", pgErr.Code),
 				)
@@ -50,14 +105,14 @@ func (r *userRepository) CreateUser(ctx context.Context, user *entity.User) erro
 			}
 		}
 
-		logger.Error("user creation failed",
+		logger.FromContext(ctx).Error("user creation failed",
 			logger.String("email", user.Email),
 			logger.Err(err),
 		)
 		return err
 	}
 
-	logger.Info("user created successfully",
+	logger.FromContext(ctx).Info("user created successfully",
 		logger.Int64("user_id", user.ID),
 		logger.String("email", user.Email),
 	)
@@ -67,9 +122,9 @@ func (r *userRepository) CreateUser(ctx context.Context, user *entity.User) erro
 func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
 
-	query := `SELECT user_id, name, username, email, password, role, created_at FROM users WHERE email = $1`
+	query := `SELECT user_id, name, COALESCE(username, ''), email, password, role, totp_enabled, COALESCE(oauth_provider, ''), created_at FROM users WHERE email = $1`
 
-	logger.Debug("fetching user by email", logger.String("email", email))
+	logger.FromContext(ctx).Debug("fetching user by email", logger.String("email", email))
 
 	err := r.db.QueryRow(ctx, query, email).Scan(
 		&user.ID,
@@ -78,27 +133,29 @@ func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*ent
 		&user.Email,
 		&user.Password,
 		&user.Role,
+		&user.TwoFactorEnabled,
+		&user.OAuthProvider,
 		&user.CreatedAt,
 	)
 
 	if err != nil {
-		logger.Warn("user not found by email",
+		logger.FromContext(ctx).Warn("user not found by email",
 			logger.String("email", email),
 			logger.Err(err),
 		)
 		return nil, err
 	}
 
-	logger.Debug("user found", logger.Int64("user_id", user.ID))
+	logger.FromContext(ctx).Debug("user found", logger.Int64("user_id", user.ID))
 	return &user, nil
 }
 
 func (r *userRepository) GetUserByID(ctx context.Context, ID int) (*entity.User, error) {
-	query := `SELECT user_id, name, username, email, password, role, created_at FROM users WHERE user_id = $1`
+	query := `SELECT user_id, name, COALESCE(username, ''), email, password, role, totp_enabled, COALESCE(oauth_provider, ''), created_at FROM users WHERE user_id = $1`
 
 	var user entity.User
 
-	logger.Debug("fetching user by ID", logger.Int("user_id", ID))
+	logger.FromContext(ctx).Debug("fetching user by ID", logger.Int("user_id", ID))
 
 	err := r.db.QueryRow(ctx, query, ID).Scan(
 		&user.ID,
@@ -107,17 +164,485 @@ func (r *userRepository) GetUserByID(ctx context.Context, ID int) (*entity.User,
 		&user.Email,
 		&user.Password,
 		&user.Role,
+		&user.TwoFactorEnabled,
+		&user.OAuthProvider,
 		&user.CreatedAt,
 	)
 
 	if err != nil {
-		logger.Warn("user not found by ID",
+		logger.FromContext(ctx).Warn("user not found by ID",
 			logger.Int("user_id", ID),
 			logger.Err(err),
 		)
 		return nil, err
 	}
 
-	logger.Debug("user found", logger.Int64("user_id", user.ID))
+	logger.FromContext(ctx).Debug("user found", logger.Int64("user_id", user.ID))
 	return &user, nil
 }
+
+func (r *userRepository) UpdateUserRole(ctx context.Context, id int64, role string) error {
+	logger.FromContext(ctx).Debug("updating user role", logger.Int64("user_id", id), logger.String("role", role))
+
+	query := `UPDATE users SET role = $1 WHERE user_id = $2`
+	cmdTag, err := r.db.Exec(ctx, query, role, id)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to update user role",
+			logger.Int64("user_id", id),
+			logger.String("role", role),
+			logger.Err(err),
+		)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	logger.FromContext(ctx).Info("user role updated", logger.Int64("user_id", id), logger.String("role", role))
+	return nil
+}
+
+// CreatePasswordResetToken stores a new reset token and revokes any earlier
+// unused ones, so only the most recent email link works.
+func (r *userRepository) CreatePasswordResetToken(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error {
+	logger.FromContext(ctx).Debug("creating password reset token", logger.Int64("user_id", userID))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`, userID); err != nil {
+		logger.FromContext(ctx).Error("failed to revoke old reset tokens", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+
+	query := `INSERT INTO password_reset_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, $3)`
+	if _, err := tx.Exec(ctx, query, userID, tokenHash, expiresAt); err != nil {
+		logger.FromContext(ctx).Error("failed to create reset token", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit reset token", logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("password reset token created", logger.Int64("user_id", userID))
+	return nil
+}
+
+// ResetPassword consumes the token and sets the new password in one
+// transaction. The token is single-use: the conditional update only succeeds
+// for an unused, unexpired token.
+func (r *userRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int64, error) {
+	logger.FromContext(ctx).Debug("resetting password with token")
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int64
+	query := `
+		UPDATE password_reset_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id
+	`
+	if err := tx.QueryRow(ctx, query, tokenHash).Scan(&userID); err != nil {
+		if err == pgx.ErrNoRows {
+			logger.FromContext(ctx).Warn("password reset with invalid or expired token")
+			return 0, entity.ErrInvalidResetToken
+		}
+		logger.FromContext(ctx).Error("failed to consume reset token", logger.Err(err))
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE users SET password = $1 WHERE user_id = $2 AND deleted_at IS NULL`, passwordHash, userID); err != nil {
+		logger.FromContext(ctx).Error("failed to update password", logger.Int64("user_id", userID), logger.Err(err))
+		return 0, err
+	}
+
+	// Whoever had the old password is signed out everywhere
+	if _, err := tx.Exec(ctx, `UPDATE user_sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID); err != nil {
+		logger.FromContext(ctx).Error("failed to revoke sessions", logger.Int64("user_id", userID), logger.Err(err))
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit password reset", logger.Err(err))
+		return 0, err
+	}
+
+	logger.FromContext(ctx).Info("password reset", logger.Int64("user_id", userID))
+	return userID, nil
+}
+
+func (r *userRepository) UpdateProfile(ctx context.Context, user *entity.User) error {
+	logger.FromContext(ctx).Debug("updating user profile", logger.Int64("user_id", user.ID))
+
+	query := `
+		UPDATE users SET name = $1, username = NULLIF($2, '')
+		WHERE user_id = $3 AND deleted_at IS NULL
+		RETURNING email, role, created_at
+	`
+	err := r.db.QueryRow(ctx, query, user.Name, user.UserName, user.ID).Scan(&user.Email, &user.Role, &user.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			logger.FromContext(ctx).Warn("profile update failed: username taken", logger.Int64("user_id", user.ID))
+			return entity.ErrUsernameTaken
+		}
+		logger.FromContext(ctx).Error("failed to update user profile", logger.Int64("user_id", user.ID), logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("user profile updated", logger.Int64("user_id", user.ID))
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, userID, keepSessionID int64, passwordHash string) error {
+	logger.FromContext(ctx).Debug("updating password", logger.Int64("user_id", userID))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE users SET password = $1 WHERE user_id = $2 AND deleted_at IS NULL`, passwordHash, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to update password", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`, userID); err != nil {
+		logger.FromContext(ctx).Error("failed to revoke reset tokens", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+
+	// A stolen session doesn't outlive the password; the device making the
+	// change stays signed in
+	if _, err := tx.Exec(ctx, `
+		UPDATE user_sessions SET revoked_at = NOW()
+		WHERE user_id = $1 AND session_id <> $2 AND revoked_at IS NULL
+	`, userID, keepSessionID); err != nil {
+		logger.FromContext(ctx).Error("failed to revoke sessions", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit password update", logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("password updated", logger.Int64("user_id", userID))
+	return nil
+}
+
+func (r *userRepository) AnonymizeUser(ctx context.Context, userID int64) error {
+	logger.FromContext(ctx).Debug("anonymizing user", logger.Int64("user_id", userID))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// The .invalid address keeps email unique and can never receive mail;
+	// an empty password hash matches no password
+	tag, err := tx.Exec(ctx, `
+		UPDATE users
+		SET name = 'Deleted user', username = NULL, email = 'deleted-' || user_id || '@users.invalid',
+			password = '', totp_secret = NULL, totp_enabled = FALSE, oauth_provider = NULL, oauth_subject = NULL,
+			deleted_at = NOW()
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to anonymize user", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	for _, query := range []string{
+		`DELETE FROM password_reset_tokens WHERE user_id = $1`,
+		`DELETE FROM user_data_exports WHERE user_id = $1`,
+		`DELETE FROM user_recovery_codes WHERE user_id = $1`,
+		`DELETE FROM notifications WHERE user_id = $1`,
+		`DELETE FROM user_sessions WHERE user_id = $1`,
+	} {
+		if _, err := tx.Exec(ctx, query, userID); err != nil {
+			logger.FromContext(ctx).Error("failed to delete user data", logger.Int64("user_id", userID), logger.Err(err))
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit user anonymization", logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("user anonymized", logger.Int64("user_id", userID))
+	return nil
+}
+
+// twoFactorFailureWindow is how long a wrong 2FA code counts against the
+// user.
+const twoFactorFailureWindow = "15 minutes"
+
+func (r *userRepository) GetTwoFactor(ctx context.Context, userID int64) (*entity.TwoFactor, error) {
+	var tf entity.TwoFactor
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(totp_secret, ''), totp_enabled, totp_last_step,
+			CASE WHEN totp_failed_at > NOW() - $2::interval THEN totp_failures ELSE 0 END
+		FROM users
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`, userID, twoFactorFailureWindow).Scan(&tf.Secret, &tf.Enabled, &tf.LastStep, &tf.RecentFailures)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to get two-factor state", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+	return &tf, nil
+}
+
+func (r *userRepository) SetTwoFactorSecret(ctx context.Context, userID int64, secret string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE users SET totp_secret = $2
+		WHERE user_id = $1 AND NOT totp_enabled AND deleted_at IS NULL
+	`, userID, secret)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to store two-factor secret", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrTwoFactorEnabled
+	}
+	return nil
+}
+
+func (r *userRepository) EnableTwoFactor(ctx context.Context, userID int64, step int64, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin transaction", logger.Err(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE users SET totp_enabled = TRUE, totp_last_step = $2, totp_failures = 0
+		WHERE user_id = $1 AND NOT totp_enabled AND totp_secret IS NOT NULL AND deleted_at IS NULL
+	`, userID, step)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to enable two-factor", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrTwoFactorEnabled
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		logger.FromContext(ctx).Error("failed to delete recovery codes", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO user_recovery_codes (user_id, code_hash)
+		SELECT $1, UNNEST($2::text[])
+	`, userID, codeHashes); err != nil {
+		logger.FromContext(ctx).Error("failed to store recovery codes", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit two-factor enable", logger.Err(err))
+		return err
+	}
+
+	logger.FromContext(ctx).Info("two-factor enabled", logger.Int64("user_id", userID), logger.Int("recovery_codes", len(codeHashes)))
+	return nil
+}
+
+func (r *userRepository) UseTwoFactorStep(ctx context.Context, userID int64, step int64) error {
+	// The step only moves forward, so a code seen by someone looking over
+	// the user's shoulder can't be replayed
+	tag, err := r.db.Exec(ctx, `
+		UPDATE users SET totp_last_step = $2, totp_failures = 0
+		WHERE user_id = $1 AND totp_enabled AND totp_last_step < $2
+	`, userID, step)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to record two-factor step", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func (r *userRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE user_recovery_codes SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`, userID, codeHash)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to use recovery code", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrInvalidTwoFactorCode
+	}
+
+	if _, err := r.db.Exec(ctx, `UPDATE users SET totp_failures = 0 WHERE user_id = $1`, userID); err != nil {
+		logger.FromContext(ctx).Warn("failed to reset two-factor failures", logger.Int64("user_id", userID), logger.Err(err))
+	}
+	logger.FromContext(ctx).Info("recovery code used", logger.Int64("user_id", userID))
+	return nil
+}
+
+func (r *userRepository) RecordTwoFactorFailure(ctx context.Context, userID int64) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE users
+		SET totp_failures = CASE WHEN totp_failed_at > NOW() - $2::interval THEN totp_failures + 1 ELSE 1 END,
+			totp_failed_at = NOW()
+		WHERE user_id = $1
+	`, userID, twoFactorFailureWindow)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to record two-factor failure", logger.Int64("user_id", userID), logger.Err(err))
+	}
+	return err
+}
+
+func (r *userRepository) GetUserByOAuth(ctx context.Context, provider, subject string) (*entity.User, error) {
+	user := entity.User{OAuthSubject: subject}
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id, name, COALESCE(username, ''), email, password, role, totp_enabled, oauth_provider, created_at
+		FROM users
+		WHERE oauth_provider = $1 AND oauth_subject = $2 AND deleted_at IS NULL
+	`, provider, subject).Scan(&user.ID, &user.Name, &user.UserName, &user.Email, &user.Password, &user.Role,
+		&user.TwoFactorEnabled, &user.OAuthProvider, &user.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to get user by oauth subject", logger.String("provider", provider), logger.Err(err))
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) LinkOAuth(ctx context.Context, userID int64, provider, subject string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE users SET oauth_provider = $2, oauth_subject = $3
+		WHERE user_id = $1 AND deleted_at IS NULL
+			AND (oauth_subject IS NULL OR (oauth_provider = $2 AND oauth_subject = $3))
+	`, userID, provider, subject)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return entity.ErrOAuthAccountConflict
+		}
+		logger.FromContext(ctx).Error("failed to link oauth account", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrOAuthAccountConflict
+	}
+
+	logger.FromContext(ctx).Info("oauth account linked", logger.Int64("user_id", userID), logger.String("provider", provider))
+	return nil
+}
+
+func (r *userRepository) CreateSession(ctx context.Context, session *entity.Session) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO user_sessions (user_id, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING session_id, created_at, last_seen_at
+	`, session.UserID, session.UserAgent, session.IPAddress, session.ExpiresAt).Scan(&session.ID, &session.CreatedAt, &session.LastSeenAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create session", logger.Int64("user_id", session.UserID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *userRepository) GetActiveSession(ctx context.Context, userID, sessionID int64) (*entity.Session, error) {
+	s := entity.Session{ID: sessionID, UserID: userID}
+	err := r.db.QueryRow(ctx, `
+		SELECT user_agent, ip_address, created_at, last_seen_at, expires_at
+		FROM user_sessions
+		WHERE session_id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+	`, sessionID, userID).Scan(&s.UserAgent, &s.IPAddress, &s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to get session", logger.Int64("session_id", sessionID), logger.Err(err))
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *userRepository) TouchSession(ctx context.Context, sessionID int64) error {
+	if _, err := r.db.Exec(ctx, `UPDATE user_sessions SET last_seen_at = NOW() WHERE session_id = $1`, sessionID); err != nil {
+		logger.FromContext(ctx).Error("failed to touch session", logger.Int64("session_id", sessionID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *userRepository) ListSessions(ctx context.Context, userID int64) ([]entity.Session, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT session_id, user_agent, ip_address, created_at, last_seen_at, expires_at
+		FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_seen_at DESC, session_id DESC
+	`, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to list sessions", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []entity.Session{}
+	for rows.Next() {
+		s := entity.Session{UserID: userID}
+		if err := rows.Scan(&s.ID, &s.UserAgent, &s.IPAddress, &s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt); err != nil {
+			logger.FromContext(ctx).Error("failed to scan session", logger.Err(err))
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *userRepository) RevokeSession(ctx context.Context, userID, sessionID int64) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE user_sessions SET revoked_at = NOW()
+		WHERE session_id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+	`, sessionID, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to revoke session", logger.Int64("session_id", sessionID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	logger.FromContext(ctx).Info("session revoked", logger.Int64("user_id", userID), logger.Int64("session_id", sessionID))
+	return nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WarehouseRepository interface {
+	// GetWatermark returns where the dataset's export to destination left
+	// off, or a zero watermark when it never ran.
+	GetWatermark(ctx context.Context, destination, dataset string) (*entity.ExportWatermark, error)
+	// SaveWatermark moves the watermark forward and adds rows to its count.
+	SaveWatermark(ctx context.Context, w *entity.ExportWatermark, rows int) error
+	// FetchChanges returns up to limit rows of the dataset changed after the
+	// watermark and before until, oldest first.
+	FetchChanges(ctx context.Context, dataset string, after *entity.ExportWatermark, until time.Time, limit int) ([]entity.ExportRecord, error)
+}
+
+type warehouseRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWarehouseRepository(db *pgxpool.Pool) WarehouseRepository {
+	return &warehouseRepository{db: db}
+}
+
+// exportSources maps each dataset to its table, key and change timestamp.
+var exportSources = map[string]struct{ table, id, updatedAt string }{
+	entity.DatasetBookings:     {"booking", "booking_id", "updated_at"},
+	entity.DatasetTransactions: {"transactions", "payment_id", "updated_at"},
+	entity.DatasetRefunds:      {"refund", "refund_id", "updated_at"},
+	entity.DatasetAuditLogs:    {"audit_logs", "audit_id", "created_at"},
+}
+
+func (r *warehouseRepository) GetWatermark(ctx context.Context, destination, dataset string) (*entity.ExportWatermark, error) {
+	w := &entity.ExportWatermark{Destination: destination, Dataset: dataset}
+	err := r.db.QueryRow(ctx, `
+		SELECT watermark_at, watermark_id, exported_rows
+		FROM warehouse_export_watermarks
+		WHERE destination = $1 AND dataset = $2
+	`, destination, dataset).Scan(&w.At, &w.ID, &w.ExportedRows)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		logger.FromContext(ctx).Error("failed to get export watermark",
+			logger.String("destination", destination),
+			logger.String("dataset", dataset),
+			logger.Err(err),
+		)
+		return nil, err
+	}
+	return w, nil
+}
+
+func (r *warehouseRepository) SaveWatermark(ctx context.Context, w *entity.ExportWatermark, rows int) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO warehouse_export_watermarks (destination, dataset, watermark_at, watermark_id, exported_rows)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (destination, dataset) DO UPDATE
+		SET watermark_at = EXCLUDED.watermark_at,
+			watermark_id = EXCLUDED.watermark_id,
+			exported_rows = warehouse_export_watermarks.exported_rows + EXCLUDED.exported_rows,
+			updated_at = CURRENT_TIMESTAMP
+	`, w.Destination, w.Dataset, w.At, w.ID, rows)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to save export watermark",
+			logger.String("destination", w.Destination),
+			logger.String("dataset", w.Dataset),
+			logger.Err(err),
+		)
+		return err
+	}
+	w.ExportedRows += int64(rows)
+	return nil
+}
+
+func (r *warehouseRepository) FetchChanges(ctx context.Context, dataset string, after *entity.ExportWatermark, until time.Time, limit int) ([]entity.ExportRecord, error) {
+	src, ok := exportSources[dataset]
+	if !ok {
+		return nil, fmt.Errorf("unknown export dataset %q", dataset)
+	}
+
+	// The row comparison lets the (updated_at, id) index drive the scan
+	query := fmt.Sprintf(`
+		SELECT t.%[2]s, t.%[3]s, row_to_json(t)
+		FROM %[1]s t
+		WHERE (t.%[3]s, t.%[2]s) > ($1, $2) AND t.%[3]s < $3
+		ORDER BY t.%[3]s, t.%[2]s
+		LIMIT $4
+	`, src.table, src.id, src.updatedAt)
+
+	rows, err := r.db.Query(ctx, query, after.At, after.ID, until, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to fetch export changes", logger.String("dataset", dataset), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []entity.ExportRecord
+	for rows.Next() {
+		var rec entity.ExportRecord
+		if err := rows.Scan(&rec.ID, &rec.UpdatedAt, &rec.Data); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *entity.Webhook) error
+	// ListWebhooks returns the webhooks of an organizer, or all webhooks
+	// when organizerID is nil, newest first.
+	ListWebhooks(ctx context.Context, organizerID *int64) ([]entity.Webhook, error)
+	// GetWebhook returns a webhook with its encrypted secret, or ErrNotFound.
+	GetWebhook(ctx context.Context, webhookID int64) (*entity.Webhook, error)
+	// UpdateWebhook stores the URL, event types and active flag of webhook.
+	// Returns ErrNotFound if there is no such webhook.
+	UpdateWebhook(ctx context.Context, webhook *entity.Webhook) error
+	// DeleteWebhook removes a webhook with its delivery log, or returns
+	// ErrNotFound.
+	DeleteWebhook(ctx context.Context, webhookID int64) error
+	// ListSubscribers returns the active webhooks subscribed to eventType
+	// for the events of organizerID, including those without an organizer.
+	// A nil organizerID matches only webhooks without one.
+	ListSubscribers(ctx context.Context, organizerID *int64, eventType string) ([]entity.Webhook, error)
+
+	// CreateDelivery stores a pending delivery. If the webhook already has
+	// one for the event key, that one is returned in delivery instead and
+	// created is false.
+	CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (created bool, err error)
+	// GetDelivery returns a delivery, or ErrNotFound.
+	GetDelivery(ctx context.Context, deliveryID int64) (*entity.WebhookDelivery, error)
+	// RecordAttempt counts an attempt of a delivery and stores its outcome.
+	// responseStatus is nil when no response was received.
+	RecordAttempt(ctx context.Context, deliveryID int64, status string, responseStatus *int, lastError string) error
+	// ListDeliveries returns a page of a webhook's deliveries, newest first,
+	// with the total count.
+	ListDeliveries(ctx context.Context, webhookID int64, page, limit int) ([]entity.WebhookDelivery, int, error)
+}
+
+type webhookRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+const webhookColumns = `webhook_id, organizer_id, url, event_types, secret_encrypted, active, created_by, created_at, updated_at`
+
+func scanWebhook(row pgx.Row, w *entity.Webhook) error {
+	return row.Scan(&w.ID, &w.OrganizerID, &w.URL, &w.EventTypes, &w.SecretEncrypted, &w.Active, &w.CreatedBy, &w.CreatedAt, &w.UpdatedAt)
+}
+
+func (r *webhookRepository) CreateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	logger.FromContext(ctx).Debug("creating webhook", logger.String("url", webhook.URL))
+
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO webhooks (organizer_id, url, event_types, secret_encrypted, active, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING webhook_id, created_at, updated_at
+	`, webhook.OrganizerID, webhook.URL, webhook.EventTypes, webhook.SecretEncrypted, webhook.Active, webhook.CreatedBy).
+		Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to insert webhook", logger.String("url", webhook.URL), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *webhookRepository) ListWebhooks(ctx context.Context, organizerID *int64) ([]entity.Webhook, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE $1::bigint IS NULL OR organizer_id = $1
+		ORDER BY webhook_id DESC
+	`, organizerID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query webhooks", logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []entity.Webhook{}
+	for rows.Next() {
+		var w entity.Webhook
+		if err := scanWebhook(rows, &w); err != nil {
+			logger.FromContext(ctx).Error("failed to scan webhook row", logger.Err(err))
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+func (r *webhookRepository) GetWebhook(ctx context.Context, webhookID int64) (*entity.Webhook, error) {
+	var w entity.Webhook
+	err := scanWebhook(r.db.QueryRow(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE webhook_id = $1`, webhookID), &w)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch webhook", logger.Int64("webhook_id", webhookID), logger.Err(err))
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *webhookRepository) UpdateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE webhooks SET url = $2, event_types = $3, active = $4, updated_at = NOW()
+		WHERE webhook_id = $1
+		RETURNING updated_at
+	`, webhook.ID, webhook.URL, webhook.EventTypes, webhook.Active).Scan(&webhook.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to update webhook", logger.Int64("webhook_id", webhook.ID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *webhookRepository) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM webhooks WHERE webhook_id = $1`, webhookID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to delete webhook", logger.Int64("webhook_id", webhookID), logger.Err(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) ListSubscribers(ctx context.Context, organizerID *int64, eventType string) ([]entity.Webhook, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE active
+			AND $2 = ANY(event_types)
+			AND (organizer_id IS NULL OR organizer_id = $1::bigint)
+		ORDER BY webhook_id
+	`, organizerID, eventType)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query webhook subscribers", logger.String("event_type", eventType), logger.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []entity.Webhook
+	for rows.Next() {
+		var w entity.Webhook
+		if err := scanWebhook(rows, &w); err != nil {
+			logger.FromContext(ctx).Error("failed to scan webhook row", logger.Err(err))
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+const webhookDeliveryColumns = `delivery_id, webhook_id, event_key, event_type, payload, status, attempts, response_status, COALESCE(last_error, ''), created_at, last_attempt_at, delivered_at`
+
+func scanWebhookDelivery(row pgx.Row, d *entity.WebhookDelivery) error {
+	return row.Scan(&d.ID, &d.WebhookID, &d.EventKey, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.ResponseStatus, &d.LastError, &d.CreatedAt, &d.LastAttemptAt, &d.DeliveredAt)
+}
+
+// CreateDelivery updates nothing on conflict, so the existing row is
+// returned by the same statement without a second round trip.
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (bool, error) {
+	var created bool
+	err := r.db.QueryRow(ctx, `
+		WITH inserted AS (
+			INSERT INTO webhook_deliveries (webhook_id, event_key, event_type, payload)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (webhook_id, event_key) DO NOTHING
+			RETURNING *
+		)
+		SELECT `+webhookDeliveryColumns+`, TRUE FROM inserted
+		UNION ALL
+		SELECT `+webhookDeliveryColumns+`, FALSE FROM webhook_deliveries
+		WHERE webhook_id = $1 AND event_key = $2 AND NOT EXISTS (SELECT 1 FROM inserted)
+	`, delivery.WebhookID, delivery.EventKey, delivery.EventType, delivery.Payload).Scan(
+		&delivery.ID, &delivery.WebhookID, &delivery.EventKey, &delivery.EventType, &delivery.Payload, &delivery.Status,
+		&delivery.Attempts, &delivery.ResponseStatus, &delivery.LastError, &delivery.CreatedAt, &delivery.LastAttemptAt,
+		&delivery.DeliveredAt, &created,
+	)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create webhook delivery",
+			logger.Int64("webhook_id", delivery.WebhookID),
+			logger.String("event_key", delivery.EventKey),
+			logger.Err(err),
+		)
+		return false, err
+	}
+	return created, nil
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, deliveryID int64) (*entity.WebhookDelivery, error) {
+	var d entity.WebhookDelivery
+	err := scanWebhookDelivery(r.db.QueryRow(ctx, `SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE delivery_id = $1`, deliveryID), &d)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		logger.FromContext(ctx).Error("failed to fetch webhook delivery", logger.Int64("delivery_id", deliveryID), logger.Err(err))
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *webhookRepository) RecordAttempt(ctx context.Context, deliveryID int64, status string, responseStatus *int, lastError string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2,
+			attempts = attempts + 1,
+			response_status = $3,
+			last_error = NULLIF($4, ''),
+			last_attempt_at = NOW(),
+			delivered_at = CASE WHEN $2 = 'SUCCEEDED' THEN NOW() ELSE delivered_at END
+		WHERE delivery_id = $1
+	`, deliveryID, status, responseStatus, lastError)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to record webhook delivery attempt", logger.Int64("delivery_id", deliveryID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, webhookID int64, page, limit int) ([]entity.WebhookDelivery, int, error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id = $1`, webhookID).Scan(&total); err != nil {
+		logger.FromContext(ctx).Error("failed to count webhook deliveries", logger.Int64("webhook_id", webhookID), logger.Err(err))
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY delivery_id DESC
+		LIMIT $2 OFFSET $3
+	`, webhookID, limit, (page-1)*limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to query webhook deliveries", logger.Int64("webhook_id", webhookID), logger.Err(err))
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	deliveries := []entity.WebhookDelivery{}
+	for rows.Next() {
+		var d entity.WebhookDelivery
+		if err := scanWebhookDelivery(rows, &d); err != nil {
+			logger.FromContext(ctx).Error("failed to scan webhook delivery row", logger.Err(err))
+			return nil, 0, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, total, rows.Err()
+}
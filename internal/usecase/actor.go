@@ -0,0 +1,55 @@
+package usecase
+
+import "context"
+
+type actorCtxKey struct{}
+
+// WithActor stores the ID of the authenticated user performing the request,
+// so usecases can attribute audit entries without extra parameters.
+func WithActor(ctx context.Context, actorID int64) context.Context {
+	return context.WithValue(ctx, actorCtxKey{}, actorID)
+}
+
+// ActorFromContext returns the acting user ID, or nil for system actions.
+func ActorFromContext(ctx context.Context) *int64 {
+	if actorID, ok := ctx.Value(actorCtxKey{}).(int64); ok {
+		return &actorID
+	}
+	return nil
+}
+
+type apiKeyCtxKey struct{}
+
+// WithAPIKey records that the actor authenticated with an API key rather
+// than a login, so audit entries show which key made the change.
+func WithAPIKey(ctx context.Context, keyID int64) context.Context {
+	return context.WithValue(ctx, apiKeyCtxKey{}, keyID)
+}
+
+// APIKeyFromContext returns the ID of the API key in use, or nil.
+func APIKeyFromContext(ctx context.Context) *int64 {
+	if keyID, ok := ctx.Value(apiKeyCtxKey{}).(int64); ok {
+		return &keyID
+	}
+	return nil
+}
+
+type clientCtxKey struct{}
+
+type clientInfo struct {
+	userAgent string
+	ip        string
+}
+
+// WithClient stores the user agent and IP address of the request's client,
+// so sessions record the device a user signed in on.
+func WithClient(ctx context.Context, userAgent, ip string) context.Context {
+	return context.WithValue(ctx, clientCtxKey{}, clientInfo{userAgent: userAgent, ip: ip})
+}
+
+// ClientFromContext returns the client's user agent and IP address, empty
+// when unknown.
+func ClientFromContext(ctx context.Context) (userAgent, ip string) {
+	info, _ := ctx.Value(clientCtxKey{}).(clientInfo)
+	return info.userAgent, info.ip
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+)
+
+// comparisonBuckets are the days-before-event checkpoints sales curves are
+// normalized to, so events with different on-sale dates line up.
+var comparisonBuckets = []int{60, 30, 14, 7, 3, 1, 0}
+
+type AnalyticsUsecase interface {
+	CompareEvents(ctx context.Context, eventID, organizerID int64, groupBy string, limit int) (*entity.EventComparison, error)
+}
+
+type analyticsUsecase struct {
+	eventRepo      repository.EventRepository
+	analyticsRepo  repository.AnalyticsRepository
+	contextTimeout time.Duration
+}
+
+func NewAnalyticsUsecase(eventRepo repository.EventRepository, analyticsRepo repository.AnalyticsRepository, timeout time.Duration) AnalyticsUsecase {
+	return &analyticsUsecase{
+		eventRepo:      eventRepo,
+		analyticsRepo:  analyticsRepo,
+		contextTimeout: timeout,
+	}
+}
+
+// CompareEvents returns the event's sales curve next to up to limit past
+// events of the same organizer grouped by "series" or "venue".
+func (uc *analyticsUsecase) CompareEvents(ctx context.Context, eventID, organizerID int64, groupBy string, limit int) (*entity.EventComparison, error) {
+	logger.FromContext(ctx).Debug("usecase: comparing event sales",
+		logger.Int64("event_id", eventID),
+		logger.String("group_by", groupBy),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, entity.ErrNotFound
+	}
+	if event.OrganizerID == nil || *event.OrganizerID != organizerID {
+		return nil, entity.ErrNotFound
+	}
+	if groupBy == "series" && event.Series == "" {
+		return nil, entity.ErrEventHasNoSeries
+	}
+
+	past, err := uc.analyticsRepo.GetComparableEvents(ctx, event, groupBy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int64{event.ID}
+	for _, p := range past {
+		ids = append(ids, p.ID)
+	}
+	sales, err := uc.analyticsRepo.GetDailySalesBeforeEvent(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// The current event only has points for checkpoints already reached
+	daysUntil := int(math.Ceil(time.Until(event.Date).Hours() / 24))
+
+	comparison := &entity.EventComparison{
+		GroupBy: groupBy,
+		Buckets: comparisonBuckets,
+		Current: buildSalesCurve(event, sales[event.ID], daysUntil),
+		Past:    make([]entity.EventSalesCurve, 0, len(past)),
+	}
+	for i := range past {
+		comparison.Past = append(comparison.Past, buildSalesCurve(&past[i], sales[past[i].ID], 0))
+	}
+
+	logger.FromContext(ctx).Debug("usecase: event comparison built",
+		logger.Int64("event_id", eventID),
+		logger.Int("past_events", len(past)),
+	)
+	return comparison, nil
+}
+
+// buildSalesCurve folds daily sales into the cumulative bucket curve, skipping
+// checkpoints closer to the event than minDaysBefore.
+func buildSalesCurve(event *entity.Event, daily map[int]int, minDaysBefore int) entity.EventSalesCurve {
+	curve := entity.EventSalesCurve{
+		EventID:  event.ID,
+		Name:     event.Name,
+		Location: event.Location,
+		Series:   event.Series,
+		Date:     event.Date,
+		Capacity: event.Capacity,
+		Points:   []entity.SalesCurvePoint{},
+	}
+
+	cumulative := 0
+	upper := math.MaxInt
+	for _, bucket := range comparisonBuckets {
+		sold := 0
+		for days, count := range daily {
+			if days >= bucket && days < upper {
+				sold += count
+			}
+		}
+		upper = bucket
+		cumulative += sold
+
+		if bucket < minDaysBefore {
+			break
+		}
+
+		point := entity.SalesCurvePoint{DaysBefore: bucket, Sold: sold, CumulativeSold: cumulative}
+		if event.Capacity > 0 {
+			point.PercentSold = math.Round(float64(cumulative)/float64(event.Capacity)*10000) / 100
+		}
+		curve.Points = append(curve.Points, point)
+	}
+
+	return curve
+}
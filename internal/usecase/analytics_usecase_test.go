@@ -0,0 +1,84 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnalyticsUsecase_CompareEvents(t *testing.T) {
+	organizerID := int64(5)
+	current := &entity.Event{
+		ID: 10, Name: "Jazz Night #3", Location: "Hall A", Series: "Jazz Night", Capacity: 100,
+		OrganizerID: &organizerID, Date: time.Now().Add(10 * 24 * time.Hour),
+	}
+	past := []entity.Event{
+		{ID: 7, Name: "Jazz Night #2", Location: "Hall A", Series: "Jazz Night", Capacity: 50,
+			OrganizerID: &organizerID, Date: time.Now().Add(-30 * 24 * time.Hour)},
+	}
+
+	t.Run("Curves Normalized To Buckets", func(t *testing.T) {
+		mockEventRepo := new(mocks.MockEventRepo)
+		mockAnalyticsRepo := new(mocks.MockAnalyticsRepo)
+
+		mockEventRepo.On("GetEventByID", mock.Anything, int64(10)).Return(current, nil).Once()
+		mockAnalyticsRepo.On("GetComparableEvents", mock.Anything, current, "series", 5).Return(past, nil).Once()
+		mockAnalyticsRepo.On("GetDailySalesBeforeEvent", mock.Anything, []int64{10, 7}).Return(map[int64]map[int]int{
+			10: {40: 10, 20: 5},
+			7:  {90: 5, 20: 10, 5: 10, 0: 25},
+		}, nil).Once()
+
+		uc := usecase.NewAnalyticsUsecase(mockEventRepo, mockAnalyticsRepo, 2*time.Second)
+		cmp, err := uc.CompareEvents(context.Background(), 10, organizerID, "series", 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{60, 30, 14, 7, 3, 1, 0}, cmp.Buckets)
+
+		// Current event is 10 days out: only the 60, 30 and 14 day checkpoints are reached
+		assert.Len(t, cmp.Current.Points, 3)
+		assert.Equal(t, 10, cmp.Current.Points[1].CumulativeSold)
+		assert.Equal(t, 15, cmp.Current.Points[2].CumulativeSold)
+		assert.Equal(t, 15.0, cmp.Current.Points[2].PercentSold)
+
+		assert.Len(t, cmp.Past, 1)
+		points := cmp.Past[0].Points
+		assert.Len(t, points, 7)
+		assert.Equal(t, 5, points[0].CumulativeSold)  // 60+ days
+		assert.Equal(t, 15, points[2].CumulativeSold) // by 14 days
+		assert.Equal(t, 25, points[5].CumulativeSold) // by 1 day
+		assert.Equal(t, 50, points[6].CumulativeSold) // event day
+		assert.Equal(t, 100.0, points[6].PercentSold)
+
+		mockEventRepo.AssertExpectations(t)
+		mockAnalyticsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Series Comparison Without Series", func(t *testing.T) {
+		mockEventRepo := new(mocks.MockEventRepo)
+		noSeries := *current
+		noSeries.Series = ""
+		mockEventRepo.On("GetEventByID", mock.Anything, int64(10)).Return(&noSeries, nil).Once()
+
+		uc := usecase.NewAnalyticsUsecase(mockEventRepo, new(mocks.MockAnalyticsRepo), 2*time.Second)
+		_, err := uc.CompareEvents(context.Background(), 10, organizerID, "series", 5)
+
+		assert.ErrorIs(t, err, entity.ErrEventHasNoSeries)
+	})
+
+	t.Run("Other Organizer's Event", func(t *testing.T) {
+		mockEventRepo := new(mocks.MockEventRepo)
+		mockEventRepo.On("GetEventByID", mock.Anything, int64(10)).Return(current, nil).Once()
+
+		uc := usecase.NewAnalyticsUsecase(mockEventRepo, new(mocks.MockAnalyticsRepo), 2*time.Second)
+		_, err := uc.CompareEvents(context.Background(), 10, 99, "venue", 5)
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+	})
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/clock"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// apiKeyPrefix marks API keys so they are recognisable in configs and
+// secret scanners; apiKeyShownPrefix is how much of a key listings show.
+const (
+	apiKeyPrefix      = "tk_"
+	apiKeyShownPrefix = len(apiKeyPrefix) + 8
+)
+
+type APIKeyUsecase interface {
+	// CreateKey issues a key to an organizer or admin, with scopes of their
+	// role. The key is set in key.Key and cannot be retrieved later.
+	CreateKey(ctx context.Context, adminID int64, key *entity.APIKey) error
+	// ListKeys returns the keys of a user, or all keys when userID is nil.
+	ListKeys(ctx context.Context, userID *int64) ([]entity.APIKey, error)
+	RevokeKey(ctx context.Context, keyID int64) error
+	// AuthenticateAPIKey returns the active key, or ErrInvalidAPIKey.
+	AuthenticateAPIKey(ctx context.Context, key string) (*entity.APIKey, error)
+}
+
+type apiKeyUsecase struct {
+	apiKeyRepo     repository.APIKeyRepository
+	userRepo       repository.UserRepository
+	auditor        AuditUsecase
+	clock          clock.Clock
+	contextTimeout time.Duration
+}
+
+func NewAPIKeyUsecase(
+	apiKeyRepo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
+	auditor AuditUsecase,
+	clk clock.Clock,
+	timeout time.Duration,
+) APIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepo:     apiKeyRepo,
+		userRepo:       userRepo,
+		auditor:        auditor,
+		clock:          clk,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *apiKeyUsecase) CreateKey(ctx context.Context, adminID int64, key *entity.APIKey) error {
+	ctx, span := tracing.Start(ctx, "APIKeyUsecase.CreateKey",
+		attribute.Int64("user_id", key.UserID),
+	)
+	defer span.End()
+
+	key.Name = strings.TrimSpace(key.Name)
+	if key.Name == "" || len(key.Name) > 100 {
+		return fmt.Errorf("%w: name must be 1 to 100 characters", entity.ErrInvalidAPIKeyRequest)
+	}
+	if len(key.Scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required", entity.ErrInvalidAPIKeyRequest)
+	}
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(uc.clock.Now()) {
+		return fmt.Errorf("%w: expires_at must be in the future", entity.ErrInvalidAPIKeyRequest)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	owner, err := uc.userRepo.GetUserByID(ctx, int(key.UserID))
+	if err != nil {
+		return entity.ErrNotFound
+	}
+	allowed := entity.APIKeyScopesByRole[owner.Role]
+	if allowed == nil {
+		return fmt.Errorf("%w: keys can only be issued to organizers and admins", entity.ErrInvalidAPIKeyRequest)
+	}
+	for _, scope := range key.Scopes {
+		if !slices.Contains(allowed, scope) {
+			return fmt.Errorf("%w: scope %q is not available to a %s", entity.ErrInvalidAPIKeyRequest, scope, owner.Role)
+		}
+	}
+
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to generate api key", logger.Err(err))
+		return err
+	}
+	secret := apiKeyPrefix + hex.EncodeToString(buf)
+
+	key.Prefix = secret[:apiKeyShownPrefix]
+	key.CreatedBy = adminID
+	if err := uc.apiKeyRepo.CreateKey(ctx, key, hashToken(secret)); err != nil {
+		return err
+	}
+	key.Key = secret
+
+	uc.auditor.Record(ctx, ActionAPIKeyCreate, "user", key.UserID, map[string]interface{}{
+		"key_id": key.ID,
+		"name":   key.Name,
+		"scopes": key.Scopes,
+	})
+
+	logger.FromContext(ctx).Info("usecase: api key created",
+		logger.Int64("user_id", key.UserID),
+		logger.Int64("key_id", key.ID),
+	)
+	return nil
+}
+
+func (uc *apiKeyUsecase) ListKeys(ctx context.Context, userID *int64) ([]entity.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.apiKeyRepo.ListKeys(ctx, userID)
+}
+
+func (uc *apiKeyUsecase) RevokeKey(ctx context.Context, keyID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.apiKeyRepo.RevokeKey(ctx, keyID); err != nil {
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionAPIKeyRevoke, "api_key", keyID, nil)
+
+	logger.FromContext(ctx).Info("usecase: api key revoked", logger.Int64("key_id", keyID))
+	return nil
+}
+
+func (uc *apiKeyUsecase) AuthenticateAPIKey(ctx context.Context, key string) (*entity.APIKey, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, entity.ErrInvalidAPIKey
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	apiKey, err := uc.apiKeyRepo.UseKey(ctx, hashToken(key))
+	if err != nil {
+		if err == entity.ErrNotFound {
+			logger.FromContext(ctx).Warn("usecase: unknown, expired or revoked api key")
+			return nil, entity.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	return apiKey, nil
+}
@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAPIKeyUsecase_CreateKey(t *testing.T) {
+	adminID := int64(1)
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Key Returned Once, Hash Stored", func(t *testing.T) {
+		mockKeyRepo := new(mocks.MockAPIKeyRepo)
+		mockUserRepo := new(mocks.MockUserRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+
+		var storedHash string
+		mockUserRepo.On("GetUserByID", mock.Anything, 7).Return(&entity.User{ID: 7, Role: "organizer"}, nil)
+		mockKeyRepo.On("CreateKey", mock.Anything, mock.AnythingOfType("*entity.APIKey"), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*entity.APIKey).ID = 3
+				storedHash = args.String(2)
+			}).Return(nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionAPIKeyCreate, "user", int64(7),
+			map[string]interface{}{"key_id": int64(3), "name": "Box office", "scopes": []string{entity.ScopeOrganizerRead}}).Once()
+
+		u := usecase.NewAPIKeyUsecase(mockKeyRepo, mockUserRepo, mockAudit, clock.NewFake(now), time.Second*2)
+		key := &entity.APIKey{UserID: 7, Name: " Box office ", Scopes: []string{entity.ScopeOrganizerRead}}
+		err := u.CreateKey(context.Background(), adminID, key)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(key.Key, "tk_"))
+		assert.True(t, strings.HasPrefix(key.Key, key.Prefix))
+		assert.Equal(t, "Box office", key.Name)
+		assert.Equal(t, adminID, key.CreatedBy)
+		sum := sha256.Sum256([]byte(key.Key))
+		assert.Equal(t, hex.EncodeToString(sum[:]), storedHash)
+		mockKeyRepo.AssertExpectations(t)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Scope Outside Owner's Role", func(t *testing.T) {
+		mockKeyRepo := new(mocks.MockAPIKeyRepo)
+		mockUserRepo := new(mocks.MockUserRepo)
+		mockUserRepo.On("GetUserByID", mock.Anything, 7).Return(&entity.User{ID: 7, Role: "organizer"}, nil)
+
+		u := usecase.NewAPIKeyUsecase(mockKeyRepo, mockUserRepo, new(mocks.MockAuditUsecase), clock.NewFake(now), time.Second*2)
+		key := &entity.APIKey{UserID: 7, Name: "Box office", Scopes: []string{entity.ScopeAdminRead}}
+		err := u.CreateKey(context.Background(), adminID, key)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidAPIKeyRequest)
+		assert.Empty(t, key.Key)
+		mockKeyRepo.AssertNotCalled(t, "CreateKey", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Owner Is A Customer", func(t *testing.T) {
+		mockKeyRepo := new(mocks.MockAPIKeyRepo)
+		mockUserRepo := new(mocks.MockUserRepo)
+		mockUserRepo.On("GetUserByID", mock.Anything, 8).Return(&entity.User{ID: 8, Role: "user"}, nil)
+
+		u := usecase.NewAPIKeyUsecase(mockKeyRepo, mockUserRepo, new(mocks.MockAuditUsecase), clock.NewFake(now), time.Second*2)
+		err := u.CreateKey(context.Background(), adminID, &entity.APIKey{UserID: 8, Name: "Scraper", Scopes: []string{entity.ScopeOrganizerRead}})
+
+		assert.ErrorIs(t, err, entity.ErrInvalidAPIKeyRequest)
+		mockKeyRepo.AssertNotCalled(t, "CreateKey", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Expiry In The Past", func(t *testing.T) {
+		mockKeyRepo := new(mocks.MockAPIKeyRepo)
+		mockUserRepo := new(mocks.MockUserRepo)
+		past := now.Add(-time.Hour)
+
+		u := usecase.NewAPIKeyUsecase(mockKeyRepo, mockUserRepo, new(mocks.MockAuditUsecase), clock.NewFake(now), time.Second*2)
+		err := u.CreateKey(context.Background(), adminID, &entity.APIKey{UserID: 7, Name: "Box office", Scopes: []string{entity.ScopeOrganizerRead}, ExpiresAt: &past})
+
+		assert.ErrorIs(t, err, entity.ErrInvalidAPIKeyRequest)
+		mockUserRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAPIKeyUsecase_RevokeKey(t *testing.T) {
+	t.Run("Success - Audited", func(t *testing.T) {
+		mockKeyRepo := new(mocks.MockAPIKeyRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+		mockKeyRepo.On("RevokeKey", mock.Anything, int64(3)).Return(nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionAPIKeyRevoke, "api_key", int64(3), map[string]interface{}(nil)).Once()
+
+		u := usecase.NewAPIKeyUsecase(mockKeyRepo, new(mocks.MockUserRepo), mockAudit, clock.Real{}, time.Second*2)
+		err := u.RevokeKey(context.Background(), 3)
+
+		assert.NoError(t, err)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Not Found, Not Audited", func(t *testing.T) {
+		mockKeyRepo := new(mocks.MockAPIKeyRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+		mockKeyRepo.On("RevokeKey", mock.Anything, int64(3)).Return(entity.ErrNotFound).Once()
+
+		u := usecase.NewAPIKeyUsecase(mockKeyRepo, new(mocks.MockUserRepo), mockAudit, clock.Real{}, time.Second*2)
+		err := u.RevokeKey(context.Background(), 3)
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAPIKeyUsecase_AuthenticateAPIKey(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockKeyRepo := new(mocks.MockAPIKeyRepo)
+		sum := sha256.Sum256([]byte("tk_abc"))
+		mockKeyRepo.On("UseKey", mock.Anything, hex.EncodeToString(sum[:])).
+			Return(&entity.APIKey{ID: 3, UserID: 7, Scopes: []string{entity.ScopeOrganizerRead}, OwnerRole: "organizer"}, nil).Once()
+
+		u := usecase.NewAPIKeyUsecase(mockKeyRepo, new(mocks.MockUserRepo), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+		key, err := u.AuthenticateAPIKey(context.Background(), "tk_abc")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), key.UserID)
+		assert.True(t, key.Allows("organizer", false))
+		assert.False(t, key.Allows("organizer", true))
+		assert.False(t, key.Allows("admin", false))
+	})
+
+	t.Run("Failed - Unknown, Expired Or Revoked", func(t *testing.T) {
+		mockKeyRepo := new(mocks.MockAPIKeyRepo)
+		mockKeyRepo.On("UseKey", mock.Anything, mock.AnythingOfType("string")).Return(nil, entity.ErrNotFound).Once()
+
+		u := usecase.NewAPIKeyUsecase(mockKeyRepo, new(mocks.MockUserRepo), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+		_, err := u.AuthenticateAPIKey(context.Background(), "tk_abc")
+
+		assert.ErrorIs(t, err, entity.ErrInvalidAPIKey)
+	})
+
+	t.Run("Failed - Not An API Key", func(t *testing.T) {
+		mockKeyRepo := new(mocks.MockAPIKeyRepo)
+
+		u := usecase.NewAPIKeyUsecase(mockKeyRepo, new(mocks.MockUserRepo), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+		_, err := u.AuthenticateAPIKey(context.Background(), "gk_abc")
+
+		assert.ErrorIs(t, err, entity.ErrInvalidAPIKey)
+		mockKeyRepo.AssertNotCalled(t, "UseKey", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/clock"
+	"ticres/pkg/i18n"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// attendanceReportLookback bounds how long after its report became due an
+// event is still reported, so a scheduler that was down for a while, or a
+// first deploy, doesn't email organizers about long past events.
+const attendanceReportLookback = 7 * 24 * time.Hour
+
+type AttendanceUsecase interface {
+	// GetAttendance returns the attendance of an event the organizer owns:
+	// its post-event report once generated, live counts before.
+	GetAttendance(ctx context.Context, eventID, organizerID int64) (*entity.AttendanceReport, error)
+	// GenerateReports writes the post-event reports of events that ended,
+	// emails them to their organizers and returns how many were sent.
+	GenerateReports(ctx context.Context) (int, error)
+}
+
+type attendanceUsecase struct {
+	attendanceRepo repository.AttendanceRepository
+	eventRepo      repository.EventRepository
+	userRepo       repository.UserRepository
+	notifier       NotificationService
+	reportURL      string
+	reportDelay    time.Duration
+	clock          clock.Clock
+	contextTimeout time.Duration
+}
+
+// NewAttendanceUsecase builds the attendance reports. An event's report is
+// generated reportDelay after it starts, as events have no end time, and
+// the email links to reportURL followed by /<event id>/attendance.
+func NewAttendanceUsecase(
+	attendanceRepo repository.AttendanceRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	notifier NotificationService,
+	reportURL string,
+	reportDelay time.Duration,
+	clk clock.Clock,
+	timeout time.Duration,
+) AttendanceUsecase {
+	return &attendanceUsecase{
+		attendanceRepo: attendanceRepo,
+		eventRepo:      eventRepo,
+		userRepo:       userRepo,
+		notifier:       notifier,
+		reportURL:      reportURL,
+		reportDelay:    reportDelay,
+		clock:          clk,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *attendanceUsecase) GetAttendance(ctx context.Context, eventID, organizerID int64) (*entity.AttendanceReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil || event.IsDeleted() {
+		return nil, entity.ErrNotFound
+	}
+	if event.OrganizerID == nil || *event.OrganizerID != organizerID {
+		return nil, entity.ErrNotFound
+	}
+
+	report, err := uc.attendanceRepo.GetAttendanceReport(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	report.Summarize(uc.clock.Now())
+	return report, nil
+}
+
+func (uc *attendanceUsecase) GenerateReports(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "AttendanceUsecase.GenerateReports")
+	defer span.End()
+
+	now := uc.clock.Now()
+	endedBefore := now.Add(-uc.reportDelay)
+	reports, err := uc.attendanceRepo.CreateAttendanceReports(ctx, endedBefore.Add(-attendanceReportLookback), endedBefore)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to generate attendance reports", logger.Err(err))
+		return 0, err
+	}
+
+	// The reports are recorded before they are sent, so a failed email
+	// can't send the same report on every run
+	var sent int
+	for i := range reports {
+		report := &reports[i]
+		report.Summarize(now)
+
+		organizer, err := uc.userRepo.GetUserByID(ctx, int(report.OrganizerID))
+		if err != nil {
+			logger.FromContext(ctx).Warn("usecase: organizer not found for attendance report", logger.Int64("event_id", report.EventID), logger.Err(err))
+			continue
+		}
+		uc.notifier.SendNotification(ctx, 0, organizer.Email, i18n.Msg(
+			"The attendance report for \"%s\" is ready: %s of %s tickets were checked in (%s%%) and %s were no-shows. See the full report at %s",
+			report.EventName, report.CheckedIn, report.TicketsSold, report.AttendanceRate, report.NoShows,
+			fmt.Sprintf("%s/%d/attendance", uc.reportURL, report.EventID),
+		))
+		sent++
+
+		logger.FromContext(ctx).Info("usecase: attendance report sent",
+			logger.Int64("event_id", report.EventID),
+			logger.Int("tickets_sold", report.TicketsSold),
+			logger.Int("checked_in", report.CheckedIn),
+		)
+	}
+
+	span.SetAttributes(attribute.Int("reports", len(reports)), attribute.Int("sent", sent))
+	return sent, nil
+}
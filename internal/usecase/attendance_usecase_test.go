@@ -0,0 +1,145 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/clock"
+	"ticres/pkg/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAttendanceUsecase_GetAttendance(t *testing.T) {
+	organizerID := int64(5)
+	otherID := int64(6)
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	past := clk.Now().Add(-24 * time.Hour)
+	upcoming := clk.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name         string
+		organizerID  int64
+		event        *entity.Event
+		report       *entity.AttendanceReport
+		wantErr      error
+		wantNoShows  int
+		wantRate     float64
+		wantRepoCall bool
+	}{
+		{
+			name:         "Success - Event Ended",
+			organizerID:  organizerID,
+			event:        &entity.Event{ID: 1, OrganizerID: &organizerID},
+			report:       &entity.AttendanceReport{EventID: 1, EventDate: past, TicketsSold: 480, CheckedIn: 432},
+			wantNoShows:  48,
+			wantRate:     90,
+			wantRepoCall: true,
+		},
+		{
+			name:         "Success - Upcoming Event Has No No-Shows",
+			organizerID:  organizerID,
+			event:        &entity.Event{ID: 1, OrganizerID: &organizerID},
+			report:       &entity.AttendanceReport{EventID: 1, EventDate: upcoming, TicketsSold: 3, CheckedIn: 1},
+			wantRate:     33.33,
+			wantRepoCall: true,
+		},
+		{
+			name:         "Success - Nothing Sold",
+			organizerID:  organizerID,
+			event:        &entity.Event{ID: 1, OrganizerID: &organizerID},
+			report:       &entity.AttendanceReport{EventID: 1, EventDate: past},
+			wantRepoCall: true,
+		},
+		{
+			name:        "Failed - Other Organizer",
+			organizerID: organizerID,
+			event:       &entity.Event{ID: 1, OrganizerID: &otherID},
+			wantErr:     entity.ErrNotFound,
+		},
+		{
+			name:        "Failed - Deleted Event",
+			organizerID: organizerID,
+			event:       &entity.Event{ID: 1, OrganizerID: &organizerID, DeletedAt: &past},
+			wantErr:     entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attendanceRepo := new(mocks.MockAttendanceRepo)
+			eventRepo := new(mocks.MockEventRepo)
+
+			eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(tt.event, nil).Once()
+			if tt.wantRepoCall {
+				attendanceRepo.On("GetAttendanceReport", mock.Anything, int64(1)).Return(tt.report, nil).Once()
+			}
+
+			uc := usecase.NewAttendanceUsecase(attendanceRepo, eventRepo, new(mocks.MockUserRepo), new(mocks.MockNotificationService), "http://app/organizer/events", 6*time.Hour, clk, 2*time.Second)
+			report, err := uc.GetAttendance(context.Background(), 1, tt.organizerID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, report)
+				attendanceRepo.AssertNotCalled(t, "GetAttendanceReport", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNoShows, report.NoShows)
+			assert.Equal(t, tt.wantRate, report.AttendanceRate)
+			attendanceRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_GenerateReports(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	ended := clk.Now().Add(-7 * time.Hour)
+
+	attendanceRepo := new(mocks.MockAttendanceRepo)
+	userRepo := new(mocks.MockUserRepo)
+	notif := new(mocks.MockNotificationService)
+
+	endedBefore := clk.Now().Add(-6 * time.Hour)
+	attendanceRepo.On("CreateAttendanceReports", mock.Anything, endedBefore.Add(-7*24*time.Hour), endedBefore).
+		Return([]entity.AttendanceReport{
+			{EventID: 1, EventName: "Show", EventDate: ended, OrganizerID: 5, TicketsSold: 200, CheckedIn: 150},
+			{EventID: 2, EventName: "Gone", EventDate: ended, OrganizerID: 9, TicketsSold: 10, CheckedIn: 10},
+		}, nil).Once()
+	userRepo.On("GetUserByID", mock.Anything, 5).Return(&entity.User{ID: 5, Email: "organizer@mail.com"}, nil).Once()
+	userRepo.On("GetUserByID", mock.Anything, 9).Return(nil, entity.ErrNotFound).Once()
+	notif.On("SendNotification", mock.Anything, int64(0), "organizer@mail.com", mock.MatchedBy(func(msg i18n.Message) bool {
+		text := msg.In("en")
+		return strings.Contains(text, "150 of 200 tickets were checked in (75%) and 50 were no-shows") &&
+			strings.Contains(text, "http://app/organizer/events/1/attendance")
+	})).Once()
+
+	uc := usecase.NewAttendanceUsecase(attendanceRepo, new(mocks.MockEventRepo), userRepo, notif, "http://app/organizer/events", 6*time.Hour, clk, 2*time.Second)
+	sent, err := uc.GenerateReports(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	attendanceRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	notif.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_GenerateReports_RepoError(t *testing.T) {
+	attendanceRepo := new(mocks.MockAttendanceRepo)
+	notif := new(mocks.MockNotificationService)
+	attendanceRepo.On("CreateAttendanceReports", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	uc := usecase.NewAttendanceUsecase(attendanceRepo, new(mocks.MockEventRepo), new(mocks.MockUserRepo), notif, "http://app/organizer/events", 6*time.Hour, clock.Real{}, 2*time.Second)
+	sent, err := uc.GenerateReports(context.Background())
+
+	assert.Error(t, err)
+	assert.Zero(t, sent)
+	notif.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+)
+
+const (
+	ActionEventCancel           = "event.cancel"
+	ActionEventRestore          = "event.restore"
+	ActionEventTicketLimit      = "event.ticket_limit"
+	ActionEventBookingRateLimit = "event.booking_rate_limit"
+	ActionEventSalesWaves       = "event.sales_waves"
+	ActionEventRefundPolicy     = "event.refund_policy"
+	ActionEventUpdate           = "event.update"
+	ActionUserRoleGrant         = "user.role_grant"
+	ActionUserPasswordChange    = "user.password_change"
+	ActionUserDelete            = "user.delete"
+	ActionUserDataExport        = "user.data_export"
+	ActionUserTwoFactorEnable   = "user.2fa_enable"
+	ActionUserRecoveryCodeUse   = "user.recovery_code_use"
+	ActionUserOAuthLink         = "user.oauth_link"
+	ActionUserSessionRevoke     = "user.session_revoke"
+	ActionRefundBulk            = "refund.bulk"
+	ActionRefundCreate          = "refund.create"
+	ActionBookingSeatChange     = "booking.seat_change"
+	ActionAlertTriggered        = "alert.triggered"
+
+	ActionBackfillStart = "backfill.start"
+
+	ActionBankAccountVerified = "bank_account.verified"
+
+	ActionExperimentStart = "experiment.start"
+	ActionExperimentStop  = "experiment.stop"
+
+	ActionJobRequeue = "job.requeue"
+
+	ActionPaymentLinkCreate = "payment_link.create"
+
+	ActionRefundRequestApprove = "refund_request.approve"
+	ActionRefundRequestReject  = "refund_request.reject"
+
+	ActionTicketsRegenerate = "tickets.regenerate"
+
+	ActionEventStaffGrant  = "event_staff.grant"
+	ActionEventStaffRevoke = "event_staff.revoke"
+
+	ActionQueueTokenIssue = "queue_token.issue"
+
+	ActionGateCreate = "gate.create"
+	ActionGateRevoke = "gate.revoke"
+
+	ActionAPIKeyCreate = "api_key.create"
+	ActionAPIKeyRevoke = "api_key.revoke"
+
+	ActionWebhookCreate = "webhook.create"
+	ActionWebhookUpdate = "webhook.update"
+	ActionWebhookDelete = "webhook.delete"
+)
+
+type AuditUsecase interface {
+	Record(ctx context.Context, action, entityType string, entityID int64, details map[string]interface{})
+	// RecordChange is Record for actions that modify an entity, keeping its
+	// state before and after the change. Either may be nil.
+	RecordChange(ctx context.Context, action, entityType string, entityID int64, before, after interface{})
+	ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, page, limit int) ([]entity.AuditLog, int, error)
+}
+
+// AlertNotifier delivers alerts to the ops channel.
+type AlertNotifier interface {
+	Notify(ctx context.Context, subject, message string) error
+}
+
+type auditUsecase struct {
+	auditRepo      repository.AuditRepository
+	notifier       AlertNotifier
+	rules          []entity.AlertRule
+	contextTimeout time.Duration
+}
+
+func NewAuditUsecase(repo repository.AuditRepository, notifier AlertNotifier, rules []entity.AlertRule, timeout time.Duration) AuditUsecase {
+	return &auditUsecase{
+		auditRepo:      repo,
+		notifier:       notifier,
+		rules:          rules,
+		contextTimeout: timeout,
+	}
+}
+
+// DefaultAlertRules are used when ALERT_RULES is not configured.
+func DefaultAlertRules() []entity.AlertRule {
+	return []entity.AlertRule{
+		{Name: "mass_event_cancellation", Action: ActionEventCancel, PerActor: true, WindowMinutes: 60, Threshold: 3},
+		{Name: "role_grant", Action: ActionUserRoleGrant, WindowMinutes: 1, Threshold: 1},
+		{Name: "large_bulk_refund", Action: ActionRefundBulk, WindowMinutes: 60 * 24, AmountThreshold: 100000000},
+	}
+}
+
+// LoadAlertRules parses a JSON array of rules, falling back to the defaults when raw is empty.
+func LoadAlertRules(raw string) ([]entity.AlertRule, error) {
+	if raw == "" {
+		return DefaultAlertRules(), nil
+	}
+
+	var rules []entity.AlertRule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil, fmt.Errorf("invalid alert rules: %w", err)
+	}
+	for _, rule := range rules {
+		if rule.Action == "" || rule.WindowMinutes <= 0 {
+			return nil, fmt.Errorf("invalid alert rule %q: action and window_minutes are required", rule.Name)
+		}
+		if rule.Threshold <= 0 && rule.AmountThreshold <= 0 {
+			return nil, fmt.Errorf("invalid alert rule %q: threshold or amount_threshold is required", rule.Name)
+		}
+	}
+	return rules, nil
+}
+
+// Record writes an audit entry and evaluates the alert rules against it.
+// Auditing never fails the calling operation; errors are only logged.
+func (uc *auditUsecase) Record(ctx context.Context, action, entityType string, entityID int64, details map[string]interface{}) {
+	uc.record(ctx, &entity.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Details:    details,
+	})
+}
+
+func (uc *auditUsecase) RecordChange(ctx context.Context, action, entityType string, entityID int64, before, after interface{}) {
+	entry := &entity.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	var err error
+	if entry.Before, err = snapshot(before); err == nil {
+		entry.After, err = snapshot(after)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to encode audit snapshot", logger.String("action", action), logger.Err(err))
+	}
+	uc.record(ctx, entry)
+}
+
+func (uc *auditUsecase) ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, page, limit int) ([]entity.AuditLog, int, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, 0, fmt.Errorf("%w: from must be before to", entity.ErrInvalidAuditFilter)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.auditRepo.ListAuditLogs(ctx, filter, page, limit)
+}
+
+// record stamps the entry with who made the request and how, then stores it.
+func (uc *auditUsecase) record(ctx context.Context, entry *entity.AuditLog) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	action := entry.Action
+	entry.ActorID = ActorFromContext(ctx)
+	entry.APIKeyID = APIKeyFromContext(ctx)
+	entry.RequestID = logger.RequestIDFromContext(ctx)
+	if err := uc.auditRepo.CreateAuditLog(ctx, entry); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to record audit log", logger.String("action", action), logger.Err(err))
+		return
+	}
+
+	if action == ActionAlertTriggered {
+		return
+	}
+	uc.evaluateRules(ctx, entry)
+}
+
+func (uc *auditUsecase) evaluateRules(ctx context.Context, entry *entity.AuditLog) {
+	for _, rule := range uc.rules {
+		if rule.Action != entry.Action || !detailsMatch(entry.Details, rule.Match) {
+			continue
+		}
+
+		var actorID *int64
+		if rule.PerActor {
+			actorID = entry.ActorID
+		}
+		since := entry.CreatedAt.Add(-time.Duration(rule.WindowMinutes) * time.Minute)
+
+		agg, err := uc.auditRepo.AggregateActions(ctx, rule.Action, rule.Match, actorID, since)
+		if err != nil {
+			logger.FromContext(ctx).Error("usecase: failed to evaluate alert rule", logger.String("rule", rule.Name), logger.Err(err))
+			continue
+		}
+
+		if ruleTriggered(rule, agg, detailAmount(entry.Details)) {
+			uc.raiseAlert(ctx, rule, entry, agg)
+		}
+	}
+}
+
+// ruleTriggered fires every time the count reaches a multiple of the threshold,
+// and when the amount sum crosses the amount threshold with this entry.
+func ruleTriggered(rule entity.AlertRule, agg *entity.AuditAggregate, entryAmount float64) bool {
+	if rule.Threshold > 0 && agg.Count > 0 && agg.Count%rule.Threshold == 0 {
+		return true
+	}
+	if rule.AmountThreshold > 0 && agg.Amount-entryAmount < rule.AmountThreshold && agg.Amount >= rule.AmountThreshold {
+		return true
+	}
+	return false
+}
+
+func (uc *auditUsecase) raiseAlert(ctx context.Context, rule entity.AlertRule, entry *entity.AuditLog, agg *entity.AuditAggregate) {
+	actor := "system"
+	if entry.ActorID != nil {
+		actor = fmt.Sprintf("user %d", *entry.ActorID)
+	}
+	subject := fmt.Sprintf("Admin activity alert: %s", rule.Name)
+	message := fmt.Sprintf("%s performed %s on %s %d (%d in last %d min, amount %.2f)",
+		actor, entry.Action, entry.EntityType, entry.EntityID, agg.Count, rule.WindowMinutes, agg.Amount)
+
+	logger.FromContext(ctx).Warn("usecase: alert rule triggered",
+		logger.String("rule", rule.Name),
+		logger.String("action", entry.Action),
+		logger.Int("count", agg.Count),
+		logger.Float64("amount", agg.Amount),
+	)
+
+	alertEntry := &entity.AuditLog{
+		ActorID:    entry.ActorID,
+		Action:     ActionAlertTriggered,
+		EntityType: "audit_log",
+		EntityID:   entry.ID,
+		Details: map[string]interface{}{
+			"rule":   rule.Name,
+			"action": entry.Action,
+			"count":  agg.Count,
+			"total":  agg.Amount,
+		},
+	}
+	if err := uc.auditRepo.CreateAuditLog(ctx, alertEntry); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to record alert", logger.String("rule", rule.Name), logger.Err(err))
+	}
+
+	// Deliver outside the request so a slow ops channel never delays the admin action.
+	go func() {
+		notifyCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := uc.notifier.Notify(notifyCtx, subject, message); err != nil {
+			logger.FromContext(ctx).Error("usecase: failed to deliver alert", logger.String("rule", rule.Name), logger.Err(err))
+		}
+	}()
+}
+
+// snapshot encodes an entity's state for the audit log; nil stays absent.
+func snapshot(state interface{}) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	return json.Marshal(state)
+}
+
+func detailsMatch(details map[string]interface{}, match map[string]string) bool {
+	for key, want := range match {
+		got, ok := details[key]
+		if !ok || fmt.Sprint(got) != want {
+			return false
+		}
+	}
+	return true
+}
+
+func detailAmount(details map[string]interface{}) float64 {
+	switch v := details["amount"].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	}
+	return 0
+}
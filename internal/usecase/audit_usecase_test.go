@@ -0,0 +1,175 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditUsecase_Record(t *testing.T) {
+	cancelRule := entity.AlertRule{Name: "mass_cancel", Action: usecase.ActionEventCancel, PerActor: true, WindowMinutes: 60, Threshold: 3}
+	refundRule := entity.AlertRule{Name: "big_refund", Action: usecase.ActionRefundBulk, WindowMinutes: 60, AmountThreshold: 1000}
+
+	isEntry := func(action string) interface{} {
+		return mock.MatchedBy(func(l *entity.AuditLog) bool { return l.Action == action })
+	}
+
+	tests := []struct {
+		name      string
+		action    string
+		details   map[string]interface{}
+		mock      func(mockRepo *mocks.MockAuditRepo)
+		wantAlert bool
+	}{
+		{
+			name:   "Below Threshold - No Alert",
+			action: usecase.ActionEventCancel,
+			mock: func(mockRepo *mocks.MockAuditRepo) {
+				mockRepo.On("CreateAuditLog", mock.Anything, isEntry(usecase.ActionEventCancel)).Return(nil).Once()
+				mockRepo.On("AggregateActions", mock.Anything, usecase.ActionEventCancel, mock.Anything, mock.Anything, mock.Anything).
+					Return(&entity.AuditAggregate{Count: 2}, nil).Once()
+			},
+		},
+		{
+			name:   "Mass Cancellation - Alert",
+			action: usecase.ActionEventCancel,
+			mock: func(mockRepo *mocks.MockAuditRepo) {
+				mockRepo.On("CreateAuditLog", mock.Anything, isEntry(usecase.ActionEventCancel)).Return(nil).Once()
+				mockRepo.On("AggregateActions", mock.Anything, usecase.ActionEventCancel, mock.Anything, mock.Anything, mock.Anything).
+					Return(&entity.AuditAggregate{Count: 3}, nil).Once()
+				mockRepo.On("CreateAuditLog", mock.Anything, isEntry(usecase.ActionAlertTriggered)).Return(nil).Once()
+			},
+			wantAlert: true,
+		},
+		{
+			name:    "Refund Amount Crosses Threshold - Alert",
+			action:  usecase.ActionRefundBulk,
+			details: map[string]interface{}{"amount": float64(600)},
+			mock: func(mockRepo *mocks.MockAuditRepo) {
+				mockRepo.On("CreateAuditLog", mock.Anything, isEntry(usecase.ActionRefundBulk)).Return(nil).Once()
+				mockRepo.On("AggregateActions", mock.Anything, usecase.ActionRefundBulk, mock.Anything, mock.Anything, mock.Anything).
+					Return(&entity.AuditAggregate{Count: 2, Amount: 1100}, nil).Once()
+				mockRepo.On("CreateAuditLog", mock.Anything, isEntry(usecase.ActionAlertTriggered)).Return(nil).Once()
+			},
+			wantAlert: true,
+		},
+		{
+			name:    "Refund Amount Already Above Threshold - No Repeat Alert",
+			action:  usecase.ActionRefundBulk,
+			details: map[string]interface{}{"amount": float64(100)},
+			mock: func(mockRepo *mocks.MockAuditRepo) {
+				mockRepo.On("CreateAuditLog", mock.Anything, isEntry(usecase.ActionRefundBulk)).Return(nil).Once()
+				mockRepo.On("AggregateActions", mock.Anything, usecase.ActionRefundBulk, mock.Anything, mock.Anything, mock.Anything).
+					Return(&entity.AuditAggregate{Count: 3, Amount: 1200}, nil).Once()
+			},
+		},
+		{
+			name:   "Audit Insert Fails - Rules Skipped",
+			action: usecase.ActionEventCancel,
+			mock: func(mockRepo *mocks.MockAuditRepo) {
+				mockRepo.On("CreateAuditLog", mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockAuditRepo)
+			mockNotifier := new(mocks.MockAlertNotifier)
+			notified := make(chan struct{}, 1)
+
+			tt.mock(mockRepo)
+			if tt.wantAlert {
+				mockNotifier.On("Notify", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+					Return(nil).Once().Run(func(args mock.Arguments) { notified <- struct{}{} })
+			}
+
+			u := usecase.NewAuditUsecase(mockRepo, mockNotifier, []entity.AlertRule{cancelRule, refundRule}, time.Second*2)
+			ctx := usecase.WithActor(context.Background(), 1)
+			u.Record(ctx, tt.action, "event", 10, tt.details)
+
+			if tt.wantAlert {
+				select {
+				case <-notified:
+				case <-time.After(time.Second):
+					t.Fatal("expected alert to be delivered")
+				}
+			}
+			mockRepo.AssertExpectations(t)
+			mockNotifier.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuditUsecase_RecordChange(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepo)
+	var stored *entity.AuditLog
+	mockRepo.On("CreateAuditLog", mock.Anything, mock.AnythingOfType("*entity.AuditLog")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.AuditLog) }).Return(nil).Once()
+
+	u := usecase.NewAuditUsecase(mockRepo, new(mocks.MockAlertNotifier), nil, time.Second*2)
+	ctx := usecase.WithAPIKey(usecase.WithActor(context.Background(), 1), 3)
+	ctx = logger.ContextWithRequestID(ctx, "req-42")
+	u.RecordChange(ctx, usecase.ActionUserRoleGrant, "user", 7, map[string]interface{}{"role": "user"}, map[string]interface{}{"role": "organizer"})
+
+	mockRepo.AssertExpectations(t)
+	assert.Equal(t, int64(1), *stored.ActorID)
+	assert.Equal(t, int64(3), *stored.APIKeyID)
+	assert.Equal(t, "req-42", stored.RequestID)
+	assert.JSONEq(t, `{"role":"user"}`, string(stored.Before))
+	assert.JSONEq(t, `{"role":"organizer"}`, string(stored.After))
+}
+
+func TestAuditUsecase_ListAuditLogs(t *testing.T) {
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.MockAuditRepo)
+		filter := entity.AuditLogFilter{EntityType: "booking", From: &from, To: &to}
+		mockRepo.On("ListAuditLogs", mock.Anything, filter, 2, 20).Return([]entity.AuditLog{{ID: 9}}, 21, nil).Once()
+
+		u := usecase.NewAuditUsecase(mockRepo, new(mocks.MockAlertNotifier), nil, time.Second*2)
+		logs, total, err := u.ListAuditLogs(context.Background(), filter, 2, 20)
+
+		assert.NoError(t, err)
+		assert.Len(t, logs, 1)
+		assert.Equal(t, 21, total)
+	})
+
+	t.Run("Failed - From After To", func(t *testing.T) {
+		mockRepo := new(mocks.MockAuditRepo)
+
+		u := usecase.NewAuditUsecase(mockRepo, new(mocks.MockAlertNotifier), nil, time.Second*2)
+		_, _, err := u.ListAuditLogs(context.Background(), entity.AuditLogFilter{From: &to, To: &from}, 1, 20)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidAuditFilter)
+		mockRepo.AssertNotCalled(t, "ListAuditLogs", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestLoadAlertRules(t *testing.T) {
+	rules, err := usecase.LoadAlertRules("")
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.DefaultAlertRules(), rules)
+
+	rules, err = usecase.LoadAlertRules(`[{"name":"grants","action":"user.role_grant","match":{"role":"admin"},"window_minutes":5,"threshold":1}]`)
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+	assert.Equal(t, "admin", rules[0].Match["role"])
+
+	_, err = usecase.LoadAlertRules(`[{"name":"broken","action":"event.cancel","window_minutes":5}]`)
+	assert.Error(t, err)
+
+	_, err = usecase.LoadAlertRules(`not json`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+)
+
+// BackfillUsecase lets admins run the registered data backfills and follow
+// their progress.
+type BackfillUsecase interface {
+	ListBackfills(ctx context.Context) ([]entity.Backfill, error)
+	GetBackfill(ctx context.Context, name string) (*entity.Backfill, error)
+	// StartBackfill marks the backfill RUNNING and queues the worker job
+	// that runs it, both or neither. Zero batchSize uses the default; it is
+	// capped at entity.MaxBackfillBatchSize.
+	StartBackfill(ctx context.Context, name string, batchSize int) (*entity.Backfill, error)
+}
+
+// BackfillRunner runs backfills in the background.
+type BackfillRunner interface {
+	// EnqueueBackfill queues the backfill's job as part of the unit of work
+	// in ctx, so it is queued only if it commits.
+	EnqueueBackfill(ctx context.Context, name string) error
+}
+
+type backfillUsecase struct {
+	backfillRepo   repository.BackfillRepository
+	txManager      repository.TxManager
+	runner         BackfillRunner
+	auditor        AuditUsecase
+	contextTimeout time.Duration
+}
+
+func NewBackfillUsecase(
+	backfillRepo repository.BackfillRepository,
+	txManager repository.TxManager,
+	runner BackfillRunner,
+	auditor AuditUsecase,
+	timeout time.Duration,
+) BackfillUsecase {
+	return &backfillUsecase{
+		backfillRepo:   backfillRepo,
+		txManager:      txManager,
+		runner:         runner,
+		auditor:        auditor,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *backfillUsecase) ListBackfills(ctx context.Context) ([]entity.Backfill, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	backfills, err := uc.backfillRepo.ListBackfills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range backfills {
+		backfills[i].Progress = backfills[i].Percent()
+	}
+	return backfills, nil
+}
+
+func (uc *backfillUsecase) GetBackfill(ctx context.Context, name string) (*entity.Backfill, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	backfill, err := uc.backfillRepo.GetBackfill(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	backfill.Progress = backfill.Percent()
+	return backfill, nil
+}
+
+func (uc *backfillUsecase) StartBackfill(ctx context.Context, name string, batchSize int) (*entity.Backfill, error) {
+	if batchSize < 1 {
+		batchSize = entity.DefaultBackfillBatchSize
+	}
+	if batchSize > entity.MaxBackfillBatchSize {
+		batchSize = entity.MaxBackfillBatchSize
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	var backfill *entity.Backfill
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if backfill, err = uc.backfillRepo.StartBackfill(ctx, name, batchSize); err != nil {
+			return err
+		}
+		return uc.runner.EnqueueBackfill(ctx, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("usecase: backfill started",
+		logger.String("backfill", name),
+		logger.Int64("last_id", backfill.LastID),
+		logger.Int64("max_id", backfill.MaxID),
+	)
+	uc.auditor.Record(ctx, ActionBackfillStart, "backfill", 0, map[string]interface{}{
+		"backfill":   name,
+		"batch_size": batchSize,
+		"from_id":    backfill.LastID,
+		"max_id":     backfill.MaxID,
+	})
+	backfill.Progress = backfill.Percent()
+	return backfill, nil
+}
@@ -0,0 +1,89 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBackfillUsecase_StartBackfill(t *testing.T) {
+	tests := []struct {
+		name          string
+		batchSize     int
+		wantBatchSize int
+		startErr      error
+		enqueueErr    error
+		wantErr       error
+	}{
+		{name: "Success - Default Batch Size", batchSize: 0, wantBatchSize: entity.DefaultBackfillBatchSize},
+		{name: "Success - Batch Size Capped", batchSize: 50000, wantBatchSize: entity.MaxBackfillBatchSize},
+		{name: "Already Running", batchSize: 500, wantBatchSize: 500, startErr: entity.ErrBackfillRunning, wantErr: entity.ErrBackfillRunning},
+		{name: "Unknown Backfill", batchSize: 500, wantBatchSize: 500, startErr: entity.ErrNotFound, wantErr: entity.ErrNotFound},
+		{name: "Job Not Stored", batchSize: 500, wantBatchSize: 500, enqueueErr: errors.New("db down"), wantErr: errors.New("db down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBackfillRepo)
+			mockRunner := new(mocks.MockBackfillRunner)
+			mockTx := new(mocks.MockTxManager)
+			mockAudit := new(mocks.MockAuditUsecase)
+
+			mockTx.On("WithinTransaction", mock.Anything).Return(nil).Once()
+			if tt.startErr != nil {
+				mockRepo.On("StartBackfill", mock.Anything, "seat_layout", tt.wantBatchSize).Return(nil, tt.startErr).Once()
+			} else {
+				mockRepo.On("StartBackfill", mock.Anything, "seat_layout", tt.wantBatchSize).
+					Return(&entity.Backfill{Name: "seat_layout", Status: entity.BackfillRunning, BatchSize: tt.wantBatchSize, LastID: 2500, MaxID: 10000}, nil).Once()
+				mockRunner.On("EnqueueBackfill", mock.Anything, "seat_layout").Return(tt.enqueueErr).Once()
+			}
+			if tt.wantErr == nil {
+				mockAudit.On("Record", mock.Anything, usecase.ActionBackfillStart, "backfill", int64(0), mock.Anything).Once()
+			}
+
+			uc := usecase.NewBackfillUsecase(mockRepo, mockTx, mockRunner, mockAudit, 2*time.Second)
+			backfill, err := uc.StartBackfill(context.Background(), "seat_layout", tt.batchSize)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, backfill)
+				mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 25.0, backfill.Progress)
+			}
+			mockRepo.AssertExpectations(t)
+			mockRunner.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBackfillUsecase_ListBackfills(t *testing.T) {
+	mockRepo := new(mocks.MockBackfillRepo)
+	mockRepo.On("ListBackfills", mock.Anything).Return([]entity.Backfill{
+		{Name: "seat_layout", Status: entity.BackfillPending},
+		{Name: "running", Status: entity.BackfillRunning, LastID: 300, MaxID: 400},
+		{Name: "empty_table", Status: entity.BackfillCompleted},
+		{Name: "failed", Status: entity.BackfillFailed, LastID: 100, MaxID: 800},
+	}, nil).Once()
+
+	uc := usecase.NewBackfillUsecase(mockRepo, newTxManager(), new(mocks.MockBackfillRunner), new(mocks.MockAuditUsecase), 2*time.Second)
+	backfills, err := uc.ListBackfills(context.Background())
+
+	assert.NoError(t, err)
+	progress := make([]float64, len(backfills))
+	for i, b := range backfills {
+		progress[i] = b.Progress
+	}
+	assert.Equal(t, []float64{0, 75, 100, 12.5}, progress)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+	"unicode"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/payout"
+)
+
+const (
+	VerificationMicroDeposit = "micro_deposit"
+	VerificationPennyDrop    = "penny_drop"
+
+	// maxVerifyAttempts is how many wrong micro-deposit guesses are allowed
+	// before the account is marked FAILED and must be registered again.
+	maxVerifyAttempts = 3
+)
+
+// AccountCipher encrypts bank account numbers before they are stored.
+type AccountCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+type BankAccountUsecase interface {
+	AddBankAccount(ctx context.Context, account *entity.BankAccount) error
+	ListBankAccounts(ctx context.Context, organizerID int64) ([]entity.BankAccount, error)
+	VerifyMicroDeposits(ctx context.Context, organizerID, accountID int64, amounts []float64) (*entity.BankAccount, error)
+	SetDefault(ctx context.Context, organizerID, accountID int64) error
+	// GetPayoutAccount returns the organizer's default verified account with the
+	// account number decrypted. Used by settlement when paying organizers out.
+	GetPayoutAccount(ctx context.Context, organizerID int64) (*entity.BankAccount, error)
+}
+
+type bankAccountUsecase struct {
+	bankAccountRepo repository.BankAccountRepository
+	cipher          AccountCipher
+	provider        payout.Provider
+	auditor         AuditUsecase
+	contextTimeout  time.Duration
+}
+
+func NewBankAccountUsecase(
+	bankAccountRepo repository.BankAccountRepository,
+	cipher AccountCipher,
+	provider payout.Provider,
+	auditor AuditUsecase,
+	timeout time.Duration,
+) BankAccountUsecase {
+	return &bankAccountUsecase{
+		bankAccountRepo: bankAccountRepo,
+		cipher:          cipher,
+		provider:        provider,
+		auditor:         auditor,
+		contextTimeout:  timeout,
+	}
+}
+
+// AddBankAccount registers a payout account and starts verification. Micro-deposit
+// accounts stay PENDING_VERIFICATION until the organizer confirms the amounts;
+// penny-drop accounts are verified immediately by matching the holder name.
+func (uc *bankAccountUsecase) AddBankAccount(ctx context.Context, account *entity.BankAccount) error {
+	logger.FromContext(ctx).Debug("usecase: adding bank account",
+		logger.Int64("organizer_id", account.OrganizerID),
+		logger.String("method", account.VerificationMethod),
+	)
+
+	if account.VerificationMethod != VerificationMicroDeposit && account.VerificationMethod != VerificationPennyDrop {
+		return entity.ErrInvalidVerificationMethod
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	enc, err := uc.cipher.Encrypt(account.AccountNumber)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to encrypt account number", logger.Err(err))
+		return err
+	}
+	account.AccountNumberEnc = enc
+	account.AccountLast4 = lastFour(account.AccountNumber)
+	account.Status = "PENDING_VERIFICATION"
+
+	if account.VerificationMethod == VerificationMicroDeposit {
+		amounts, err := microDepositAmounts()
+		if err != nil {
+			logger.FromContext(ctx).Error("usecase: failed to generate micro-deposit amounts", logger.Err(err))
+			return err
+		}
+		account.DepositAmounts = amounts
+	}
+
+	if err := uc.bankAccountRepo.CreateBankAccount(ctx, account); err != nil {
+		return err
+	}
+
+	dest := payout.Account{
+		BankName:      account.BankName,
+		AccountNumber: account.AccountNumber,
+		AccountHolder: account.AccountHolder,
+	}
+
+	if account.VerificationMethod == VerificationMicroDeposit {
+		if err := uc.provider.SendMicroDeposits(ctx, dest, account.DepositAmounts); err != nil {
+			logger.FromContext(ctx).Error("usecase: failed to send micro-deposits", logger.Int64("bank_account_id", account.ID), logger.Err(err))
+			uc.markFailed(ctx, account)
+			return err
+		}
+		logger.FromContext(ctx).Info("usecase: micro-deposits sent", logger.Int64("bank_account_id", account.ID))
+		return nil
+	}
+
+	holder, err := uc.provider.PennyDrop(ctx, dest)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: penny drop failed", logger.Int64("bank_account_id", account.ID), logger.Err(err))
+		uc.markFailed(ctx, account)
+		return err
+	}
+	if normalizeHolderName(holder) != normalizeHolderName(account.AccountHolder) {
+		logger.FromContext(ctx).Warn("usecase: penny drop holder name mismatch", logger.Int64("bank_account_id", account.ID))
+		uc.markFailed(ctx, account)
+		return entity.ErrVerificationFailed
+	}
+
+	return uc.markVerified(ctx, account)
+}
+
+func (uc *bankAccountUsecase) ListBankAccounts(ctx context.Context, organizerID int64) ([]entity.BankAccount, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.bankAccountRepo.GetBankAccountsByOrganizer(ctx, organizerID)
+}
+
+func (uc *bankAccountUsecase) VerifyMicroDeposits(ctx context.Context, organizerID, accountID int64, amounts []float64) (*entity.BankAccount, error) {
+	logger.FromContext(ctx).Debug("usecase: verifying micro-deposits",
+		logger.Int64("organizer_id", organizerID),
+		logger.Int64("bank_account_id", accountID),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	account, err := uc.bankAccountRepo.GetBankAccountByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	// Other organizers' accounts are reported as missing rather than forbidden
+	if account.OrganizerID != organizerID {
+		return nil, entity.ErrNotFound
+	}
+	if account.Status != "PENDING_VERIFICATION" || account.VerificationMethod != VerificationMicroDeposit {
+		return nil, entity.ErrBankAccountNotPending
+	}
+
+	if !depositsMatch(account.DepositAmounts, amounts) {
+		account.VerifyAttempts++
+		status := account.Status
+		if account.VerifyAttempts >= maxVerifyAttempts {
+			status = "FAILED"
+		}
+		if err := uc.bankAccountRepo.UpdateVerification(ctx, account.ID, status, account.VerifyAttempts); err != nil {
+			return nil, err
+		}
+		account.Status = status
+		logger.FromContext(ctx).Warn("usecase: micro-deposit amounts mismatch",
+			logger.Int64("bank_account_id", account.ID),
+			logger.Int("attempts", account.VerifyAttempts),
+		)
+		return account, entity.ErrVerificationFailed
+	}
+
+	if err := uc.markVerified(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (uc *bankAccountUsecase) SetDefault(ctx context.Context, organizerID, accountID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.bankAccountRepo.SetDefaultBankAccount(ctx, organizerID, accountID)
+}
+
+func (uc *bankAccountUsecase) GetPayoutAccount(ctx context.Context, organizerID int64) (*entity.BankAccount, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	account, err := uc.bankAccountRepo.GetDefaultBankAccount(ctx, organizerID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrBankAccountNotVerified
+		}
+		return nil, err
+	}
+
+	number, err := uc.cipher.Decrypt(account.AccountNumberEnc)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to decrypt account number", logger.Int64("bank_account_id", account.ID), logger.Err(err))
+		return nil, err
+	}
+	account.AccountNumber = number
+	return account, nil
+}
+
+// markVerified flags the account VERIFIED and makes it the default payout
+// destination when the organizer has none yet.
+func (uc *bankAccountUsecase) markVerified(ctx context.Context, account *entity.BankAccount) error {
+	if err := uc.bankAccountRepo.UpdateVerification(ctx, account.ID, "VERIFIED", account.VerifyAttempts); err != nil {
+		return err
+	}
+	account.Status = "VERIFIED"
+
+	if _, err := uc.bankAccountRepo.GetDefaultBankAccount(ctx, account.OrganizerID); errors.Is(err, entity.ErrNotFound) {
+		if err := uc.bankAccountRepo.SetDefaultBankAccount(ctx, account.OrganizerID, account.ID); err != nil {
+			logger.FromContext(ctx).Warn("usecase: failed to set first verified account as default",
+				logger.Int64("bank_account_id", account.ID),
+				logger.Err(err),
+			)
+		} else {
+			account.IsDefault = true
+		}
+	}
+
+	uc.auditor.Record(ctx, ActionBankAccountVerified, "bank_account", account.ID, map[string]interface{}{
+		"method": account.VerificationMethod,
+		"last4":  account.AccountLast4,
+	})
+
+	logger.FromContext(ctx).Info("usecase: bank account verified",
+		logger.Int64("bank_account_id", account.ID),
+		logger.Int64("organizer_id", account.OrganizerID),
+	)
+	return nil
+}
+
+func (uc *bankAccountUsecase) markFailed(ctx context.Context, account *entity.BankAccount) {
+	account.Status = "FAILED"
+	if err := uc.bankAccountRepo.UpdateVerification(ctx, account.ID, "FAILED", account.VerifyAttempts); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to mark bank account failed", logger.Int64("bank_account_id", account.ID), logger.Err(err))
+	}
+}
+
+// microDepositAmounts returns two distinct amounts between 0.01 and 0.99.
+func microDepositAmounts() ([]float64, error) {
+	var cents [2]int64
+	for i := range cents {
+		for {
+			n, err := rand.Int(rand.Reader, big.NewInt(99))
+			if err != nil {
+				return nil, err
+			}
+			cents[i] = n.Int64() + 1
+			if i == 0 || cents[i] != cents[0] {
+				break
+			}
+		}
+	}
+	return []float64{float64(cents[0]) / 100, float64(cents[1]) / 100}, nil
+}
+
+// depositsMatch compares amounts in cents, ignoring the order they were entered in.
+func depositsMatch(expected, got []float64) bool {
+	if len(expected) != 2 || len(got) != 2 {
+		return false
+	}
+	toCents := func(v float64) int64 { return int64(math.Round(v * 100)) }
+	e0, e1 := toCents(expected[0]), toCents(expected[1])
+	g0, g1 := toCents(got[0]), toCents(got[1])
+	return (e0 == g0 && e1 == g1) || (e0 == g1 && e1 == g0)
+}
+
+func normalizeHolderName(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToUpper(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func lastFour(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	return number[len(number)-4:]
+}
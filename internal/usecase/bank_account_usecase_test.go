@@ -0,0 +1,209 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/encryption"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCipher(t *testing.T) *encryption.Cipher {
+	c, err := encryption.NewCipher([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	return c
+}
+
+func TestBankAccountUsecase_AddBankAccount(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		mock       func(mockRepo *mocks.MockBankAccountRepo, mockProvider *mocks.MockPayoutProvider, mockAudit *mocks.MockAuditUsecase)
+		wantStatus string
+		wantErr    error
+	}{
+		{
+			name:   "Micro-deposit Sent",
+			method: usecase.VerificationMicroDeposit,
+			mock: func(mockRepo *mocks.MockBankAccountRepo, mockProvider *mocks.MockPayoutProvider, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("CreateBankAccount", mock.Anything, mock.MatchedBy(func(a *entity.BankAccount) bool {
+					return len(a.DepositAmounts) == 2 && a.AccountNumberEnc != "" && a.AccountNumberEnc != "1234567890"
+				})).Return(nil).Once()
+				mockProvider.On("SendMicroDeposits", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			wantStatus: "PENDING_VERIFICATION",
+		},
+		{
+			name:   "Penny Drop Verified",
+			method: usecase.VerificationPennyDrop,
+			mock: func(mockRepo *mocks.MockBankAccountRepo, mockProvider *mocks.MockPayoutProvider, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("CreateBankAccount", mock.Anything, mock.Anything).Return(nil).Once()
+				mockProvider.On("PennyDrop", mock.Anything, mock.Anything).Return("budi  santoso", nil).Once()
+				mockRepo.On("UpdateVerification", mock.Anything, mock.Anything, "VERIFIED", 0).Return(nil).Once()
+				mockRepo.On("GetDefaultBankAccount", mock.Anything, int64(5)).Return(nil, entity.ErrNotFound).Once()
+				mockRepo.On("SetDefaultBankAccount", mock.Anything, int64(5), mock.Anything).Return(nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionBankAccountVerified, "bank_account", mock.Anything, mock.Anything).Once()
+			},
+			wantStatus: "VERIFIED",
+		},
+		{
+			name:   "Penny Drop Name Mismatch",
+			method: usecase.VerificationPennyDrop,
+			mock: func(mockRepo *mocks.MockBankAccountRepo, mockProvider *mocks.MockPayoutProvider, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("CreateBankAccount", mock.Anything, mock.Anything).Return(nil).Once()
+				mockProvider.On("PennyDrop", mock.Anything, mock.Anything).Return("SOMEONE ELSE", nil).Once()
+				mockRepo.On("UpdateVerification", mock.Anything, mock.Anything, "FAILED", 0).Return(nil).Once()
+			},
+			wantStatus: "FAILED",
+			wantErr:    entity.ErrVerificationFailed,
+		},
+		{
+			name:   "Invalid Method",
+			method: "carrier_pigeon",
+			mock: func(mockRepo *mocks.MockBankAccountRepo, mockProvider *mocks.MockPayoutProvider, mockAudit *mocks.MockAuditUsecase) {
+			},
+			wantErr: entity.ErrInvalidVerificationMethod,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBankAccountRepo)
+			mockProvider := new(mocks.MockPayoutProvider)
+			mockAudit := new(mocks.MockAuditUsecase)
+			tt.mock(mockRepo, mockProvider, mockAudit)
+
+			uc := usecase.NewBankAccountUsecase(mockRepo, newTestCipher(t), mockProvider, mockAudit, 2*time.Second)
+			account := &entity.BankAccount{
+				OrganizerID:        5,
+				BankName:           "BCA",
+				AccountHolder:      "Budi Santoso",
+				AccountNumber:      "1234567890",
+				VerificationMethod: tt.method,
+			}
+			err := uc.AddBankAccount(context.Background(), account)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "7890", account.AccountLast4)
+			}
+			if tt.wantStatus != "" {
+				assert.Equal(t, tt.wantStatus, account.Status)
+			}
+			mockRepo.AssertExpectations(t)
+			mockProvider.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBankAccountUsecase_VerifyMicroDeposits(t *testing.T) {
+	pending := func(attempts int) *entity.BankAccount {
+		return &entity.BankAccount{
+			ID:                 9,
+			OrganizerID:        5,
+			VerificationMethod: usecase.VerificationMicroDeposit,
+			Status:             "PENDING_VERIFICATION",
+			DepositAmounts:     []float64{0.12, 0.47},
+			VerifyAttempts:     attempts,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		organizerID int64
+		amounts     []float64
+		mock        func(mockRepo *mocks.MockBankAccountRepo, mockAudit *mocks.MockAuditUsecase)
+		wantErr     error
+	}{
+		{
+			name:        "Success - Amounts In Any Order",
+			organizerID: 5,
+			amounts:     []float64{0.47, 0.12},
+			mock: func(mockRepo *mocks.MockBankAccountRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetBankAccountByID", mock.Anything, int64(9)).Return(pending(0), nil).Once()
+				mockRepo.On("UpdateVerification", mock.Anything, int64(9), "VERIFIED", 0).Return(nil).Once()
+				mockRepo.On("GetDefaultBankAccount", mock.Anything, int64(5)).Return(&entity.BankAccount{ID: 2}, nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionBankAccountVerified, "bank_account", int64(9), mock.Anything).Once()
+			},
+		},
+		{
+			name:        "Wrong Amounts",
+			organizerID: 5,
+			amounts:     []float64{0.10, 0.47},
+			mock: func(mockRepo *mocks.MockBankAccountRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetBankAccountByID", mock.Anything, int64(9)).Return(pending(0), nil).Once()
+				mockRepo.On("UpdateVerification", mock.Anything, int64(9), "PENDING_VERIFICATION", 1).Return(nil).Once()
+			},
+			wantErr: entity.ErrVerificationFailed,
+		},
+		{
+			name:        "Last Attempt Fails Account",
+			organizerID: 5,
+			amounts:     []float64{0.10, 0.47},
+			mock: func(mockRepo *mocks.MockBankAccountRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetBankAccountByID", mock.Anything, int64(9)).Return(pending(2), nil).Once()
+				mockRepo.On("UpdateVerification", mock.Anything, int64(9), "FAILED", 3).Return(nil).Once()
+			},
+			wantErr: entity.ErrVerificationFailed,
+		},
+		{
+			name:        "Other Organizer's Account",
+			organizerID: 6,
+			amounts:     []float64{0.12, 0.47},
+			mock: func(mockRepo *mocks.MockBankAccountRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetBankAccountByID", mock.Anything, int64(9)).Return(pending(0), nil).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBankAccountRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			tt.mock(mockRepo, mockAudit)
+
+			uc := usecase.NewBankAccountUsecase(mockRepo, newTestCipher(t), new(mocks.MockPayoutProvider), mockAudit, 2*time.Second)
+			_, err := uc.VerifyMicroDeposits(context.Background(), tt.organizerID, 9, tt.amounts)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBankAccountUsecase_GetPayoutAccount(t *testing.T) {
+	cipher := newTestCipher(t)
+	enc, err := cipher.Encrypt("1234567890")
+	require.NoError(t, err)
+
+	mockRepo := new(mocks.MockBankAccountRepo)
+	mockRepo.On("GetDefaultBankAccount", mock.Anything, int64(5)).
+		Return(&entity.BankAccount{ID: 9, OrganizerID: 5, AccountNumberEnc: enc, Status: "VERIFIED"}, nil).Once()
+	mockRepo.On("GetDefaultBankAccount", mock.Anything, int64(6)).Return(nil, entity.ErrNotFound).Once()
+
+	uc := usecase.NewBankAccountUsecase(mockRepo, cipher, new(mocks.MockPayoutProvider), new(mocks.MockAuditUsecase), 2*time.Second)
+
+	account, err := uc.GetPayoutAccount(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234567890", account.AccountNumber)
+
+	_, err = uc.GetPayoutAccount(context.Background(), 6)
+	assert.ErrorIs(t, err, entity.ErrBankAccountNotVerified)
+	mockRepo.AssertExpectations(t)
+}
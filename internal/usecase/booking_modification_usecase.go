@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type BookingModificationUsecase interface {
+	// ChangeSeats swaps fromSeatIDs[i] for toSeatIDs[i] on a paid booking. The
+	// new seats may not cost less in total; any difference is charged with
+	// paymentMethod, which is only required when there is something to pay.
+	ChangeSeats(ctx context.Context, userID, bookingID int64, fromSeatIDs, toSeatIDs []int64, paymentMethod string) (*entity.BookingModification, error)
+	ListModifications(ctx context.Context, userID, bookingID int64) ([]entity.BookingModification, error)
+}
+
+type bookingModificationUsecase struct {
+	modificationRepo repository.BookingModificationRepository
+	bookingRepo      repository.BookingRepository
+	ticketRepo       repository.TicketRepository
+	receiptSender    ReceiptSender
+	auditor          AuditUsecase
+	contextTimeout   time.Duration
+}
+
+func NewBookingModificationUsecase(
+	modificationRepo repository.BookingModificationRepository,
+	bookingRepo repository.BookingRepository,
+	ticketRepo repository.TicketRepository,
+	receiptSender ReceiptSender,
+	auditor AuditUsecase,
+	timeout time.Duration,
+) BookingModificationUsecase {
+	return &bookingModificationUsecase{
+		modificationRepo: modificationRepo,
+		bookingRepo:      bookingRepo,
+		ticketRepo:       ticketRepo,
+		receiptSender:    receiptSender,
+		auditor:          auditor,
+		contextTimeout:   timeout,
+	}
+}
+
+func (uc *bookingModificationUsecase) ChangeSeats(ctx context.Context, userID, bookingID int64, fromSeatIDs, toSeatIDs []int64, paymentMethod string) (*entity.BookingModification, error) {
+	ctx, span := tracing.Start(ctx, "BookingModificationUsecase.ChangeSeats",
+		attribute.Int64("booking_id", bookingID),
+		attribute.Int("seat_count", len(toSeatIDs)),
+	)
+	defer span.End()
+
+	logger.FromContext(ctx).Info("usecase: changing booking seats",
+		logger.Int64("booking_id", bookingID),
+		logger.Int64("user_id", userID),
+		logger.Int("seat_count", len(toSeatIDs)),
+	)
+
+	if err := validateSeatChange(fromSeatIDs, toSeatIDs); err != nil {
+		return nil, err
+	}
+
+	var methodCode string
+	if paymentMethod != "" {
+		code, ok := validPaymentMethods[paymentMethod]
+		if !ok {
+			return nil, entity.ErrInvalidPaymentMethod
+		}
+		methodCode = code
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	booking, err := uc.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != userID {
+		return nil, entity.ErrUnauthorized
+	}
+	if booking.Status != entity.BookingPaid {
+		return nil, entity.ErrBookingNotPaid
+	}
+
+	mod := &entity.BookingModification{
+		BookingID:     bookingID,
+		FromSeatIDs:   fromSeatIDs,
+		ToSeatIDs:     toSeatIDs,
+		PaymentMethod: paymentMethod,
+	}
+	// Mock gateway reference for the difference; dropped when nothing is charged
+	if methodCode != "" {
+		mod.ExternalID = seatChangeExternalID(methodCode, bookingID)
+	}
+
+	if err := uc.modificationRepo.ApplySeatChange(ctx, mod); err != nil {
+		logger.FromContext(ctx).Warn("usecase: seat change rejected", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return nil, err
+	}
+
+	uc.auditor.RecordChange(ctx, ActionBookingSeatChange, "booking", bookingID,
+		map[string]interface{}{"seat_ids": fromSeatIDs},
+		map[string]interface{}{"seat_ids": toSeatIDs, "modification_id": mod.ID, "amount_charged": mod.AmountCharged},
+	)
+
+	// The change is committed; tickets are only loaded for the response
+	tickets, err := uc.ticketRepo.GetTicketsByBookingID(ctx, bookingID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("usecase: failed to load reissued tickets", logger.Int64("booking_id", bookingID), logger.Err(err))
+	}
+	mod.Tickets = tickets
+
+	// Re-send the receipt so the user has the new QR codes
+	uc.receiptSender.SendPaymentReceipt(ctx, bookingID)
+
+	logger.FromContext(ctx).Info("usecase: booking seats changed",
+		logger.Int64("booking_id", bookingID),
+		logger.Int64("modification_id", mod.ID),
+		logger.Float64("amount_charged", mod.AmountCharged),
+	)
+	return mod, nil
+}
+
+func (uc *bookingModificationUsecase) ListModifications(ctx context.Context, userID, bookingID int64) ([]entity.BookingModification, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	booking, err := uc.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != userID {
+		return nil, entity.ErrUnauthorized
+	}
+
+	return uc.modificationRepo.GetModificationsByBookingID(ctx, bookingID)
+}
+
+// validateSeatChange requires a one-to-one mapping between distinct seats.
+func validateSeatChange(fromSeatIDs, toSeatIDs []int64) error {
+	if len(fromSeatIDs) == 0 || len(fromSeatIDs) != len(toSeatIDs) {
+		return entity.ErrInvalidSeatChange
+	}
+	seen := make(map[int64]bool, len(fromSeatIDs)*2)
+	for _, ids := range [][]int64{fromSeatIDs, toSeatIDs} {
+		for _, id := range ids {
+			if seen[id] {
+				return entity.ErrInvalidSeatChange
+			}
+			seen[id] = true
+		}
+	}
+	return nil
+}
+
+// seatChangeExternalID is the mock gateway reference for a seat change charge.
+func seatChangeExternalID(methodCode string, bookingID int64) string {
+	return fmt.Sprintf("MOD-%s-%d-%d", methodCode, bookingID, time.Now().UnixMilli())
+}
@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookingModificationUsecase_ChangeSeats(t *testing.T) {
+	paidBooking := &entity.Booking{ID: 1, UserID: 7, Status: "PAID"}
+
+	tests := []struct {
+		name          string
+		userID        int64
+		from          []int64
+		to            []int64
+		paymentMethod string
+		mock          func(modRepo *mocks.MockBookingModificationRepo, bookingRepo *mocks.MockBookingRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService)
+		wantErr       error
+	}{
+		{
+			name:          "Success - Upgrade Charged",
+			userID:        7,
+			from:          []int64{101},
+			to:            []int64{205},
+			paymentMethod: "e_wallet",
+			mock: func(modRepo *mocks.MockBookingModificationRepo, bookingRepo *mocks.MockBookingRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService) {
+				bookingRepo.On("GetBookingByID", mock.Anything, int64(1)).Return(paidBooking, nil).Once()
+				modRepo.On("ApplySeatChange", mock.Anything, mock.MatchedBy(func(m *entity.BookingModification) bool {
+					return m.BookingID == 1 && m.PaymentMethod == "e_wallet" && m.ExternalID != ""
+				})).Return(nil).Once()
+				ticketRepo.On("GetTicketsByBookingID", mock.Anything, int64(1)).
+					Return([]entity.Ticket{{ID: 1, SeatID: 205, Code: "TCK-new"}}, nil).Once()
+				notif.On("SendPaymentReceipt", mock.Anything, int64(1)).Once()
+			},
+		},
+		{
+			name:   "Success - Same Price Without Payment Method",
+			userID: 7,
+			from:   []int64{101},
+			to:     []int64{102},
+			mock: func(modRepo *mocks.MockBookingModificationRepo, bookingRepo *mocks.MockBookingRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService) {
+				bookingRepo.On("GetBookingByID", mock.Anything, int64(1)).Return(paidBooking, nil).Once()
+				modRepo.On("ApplySeatChange", mock.Anything, mock.MatchedBy(func(m *entity.BookingModification) bool {
+					return m.ExternalID == ""
+				})).Return(nil).Once()
+				ticketRepo.On("GetTicketsByBookingID", mock.Anything, int64(1)).Return([]entity.Ticket{}, nil).Once()
+				notif.On("SendPaymentReceipt", mock.Anything, int64(1)).Once()
+			},
+		},
+		{
+			name:   "Failed - Mismatched Seat Lists",
+			userID: 7,
+			from:   []int64{101, 102},
+			to:     []int64{205},
+			mock: func(*mocks.MockBookingModificationRepo, *mocks.MockBookingRepo, *mocks.MockTicketRepo, *mocks.MockNotificationService) {
+			},
+			wantErr: entity.ErrInvalidSeatChange,
+		},
+		{
+			name:   "Failed - Duplicate Seat",
+			userID: 7,
+			from:   []int64{101, 101},
+			to:     []int64{205, 206},
+			mock: func(*mocks.MockBookingModificationRepo, *mocks.MockBookingRepo, *mocks.MockTicketRepo, *mocks.MockNotificationService) {
+			},
+			wantErr: entity.ErrInvalidSeatChange,
+		},
+		{
+			name:          "Failed - Invalid Payment Method",
+			userID:        7,
+			from:          []int64{101},
+			to:            []int64{205},
+			paymentMethod: "cash",
+			mock: func(*mocks.MockBookingModificationRepo, *mocks.MockBookingRepo, *mocks.MockTicketRepo, *mocks.MockNotificationService) {
+			},
+			wantErr: entity.ErrInvalidPaymentMethod,
+		},
+		{
+			name:   "Failed - Not Booking Owner",
+			userID: 8,
+			from:   []int64{101},
+			to:     []int64{205},
+			mock: func(modRepo *mocks.MockBookingModificationRepo, bookingRepo *mocks.MockBookingRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService) {
+				bookingRepo.On("GetBookingByID", mock.Anything, int64(1)).Return(paidBooking, nil).Once()
+			},
+			wantErr: entity.ErrUnauthorized,
+		},
+		{
+			name:   "Failed - Booking Not Paid",
+			userID: 7,
+			from:   []int64{101},
+			to:     []int64{205},
+			mock: func(modRepo *mocks.MockBookingModificationRepo, bookingRepo *mocks.MockBookingRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService) {
+				bookingRepo.On("GetBookingByID", mock.Anything, int64(1)).
+					Return(&entity.Booking{ID: 1, UserID: 7, Status: "PENDING"}, nil).Once()
+			},
+			wantErr: entity.ErrBookingNotPaid,
+		},
+		{
+			name:          "Failed - Seat Taken",
+			userID:        7,
+			from:          []int64{101},
+			to:            []int64{205},
+			paymentMethod: "credit_card",
+			mock: func(modRepo *mocks.MockBookingModificationRepo, bookingRepo *mocks.MockBookingRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService) {
+				bookingRepo.On("GetBookingByID", mock.Anything, int64(1)).Return(paidBooking, nil).Once()
+				modRepo.On("ApplySeatChange", mock.Anything, mock.Anything).Return(entity.ErrSeatUnavailable).Once()
+			},
+			wantErr: entity.ErrSeatUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modRepo := new(mocks.MockBookingModificationRepo)
+			bookingRepo := new(mocks.MockBookingRepo)
+			ticketRepo := new(mocks.MockTicketRepo)
+			notif := new(mocks.MockNotificationService)
+			mockAudit := new(mocks.MockAuditUsecase)
+			tt.mock(modRepo, bookingRepo, ticketRepo, notif)
+			if tt.wantErr == nil {
+				mockAudit.On("RecordChange", mock.Anything, usecase.ActionBookingSeatChange, "booking", int64(1),
+					map[string]interface{}{"seat_ids": tt.from}, mock.Anything).Once()
+			}
+
+			uc := usecase.NewBookingModificationUsecase(modRepo, bookingRepo, ticketRepo, notif, mockAudit, 2*time.Second)
+			mod, err := uc.ChangeSeats(context.Background(), tt.userID, 1, tt.from, tt.to, tt.paymentMethod)
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				assert.Nil(t, mod)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, mod)
+			}
+			modRepo.AssertExpectations(t)
+			bookingRepo.AssertExpectations(t)
+			ticketRepo.AssertExpectations(t)
+			notif.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
@@ -2,43 +2,167 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"ticres/internal/entity"
 	"ticres/internal/repository"
+	"ticres/pkg/clock"
+	"ticres/pkg/i18n"
 	"ticres/pkg/logger"
+	"ticres/pkg/payment"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type BookingUsecase interface {
-	BookSeats(ctx context.Context, userID, eventID int64, seatIDs []int64, userEmail string) (*entity.BookingWithPayment, error)
+	// BookSeats reserves the given seats, or quantity tickets: general
+	// admission, or the best available adjacent seats matching pref of a
+	// seated event. Exactly one of seatIDs and quantity must be given. The
+	// confirmation is sent to the email on the user's account.
+	BookSeats(ctx context.Context, userID, eventID int64, seatIDs []int64, quantity int, pref entity.SeatPreference) (*entity.BookingWithPayment, error)
 	GetBookingsByUserID(ctx context.Context, userID int64) ([]entity.BookingWithDetails, error)
+	// GetBookingDetail returns one of the user's bookings with its seats,
+	// payment and refund status.
+	GetBookingDetail(ctx context.Context, userID, bookingID int64) (*entity.BookingDetail, error)
 	GetAllBookings(ctx context.Context, status, sortBy, sortOrder string, page, limit int) ([]entity.BookingWithDetails, int, error)
+	// GetAllBookingsByCursor returns the page of bookings after cursor in
+	// the given order. An empty cursor starts at the top; a malformed one,
+	// or one made for another order, is entity.ErrInvalidCursor.
+	GetAllBookingsByCursor(ctx context.Context, status, sortBy, sortOrder, cursor string, limit int) (*entity.BookingPage, error)
 	GetBookingsByEventID(ctx context.Context, eventID int64, status, sortBy, sortOrder string) ([]entity.BookingWithDetails, error)
+	// GetEventBookingSummary totals the event's bookings: tickets sold,
+	// occupancy, revenue, refunds and the split by status and tier.
+	GetEventBookingSummary(ctx context.Context, eventID int64) (*entity.EventBookingSummary, error)
+	// StreamEventBookings passes every booking of the event to fn for the
+	// attendee export. The stream is bounded by ctx only, since a large
+	// event can outlast the usecase timeout.
+	StreamEventBookings(ctx context.Context, eventID int64, fn func(entity.BookingExportRow) error) error
+	// ReleaseLapsedHolds expires bookings left unpaid past their deadline
+	// and frees their seats, returning how many bookings were released.
+	ReleaseLapsedHolds(ctx context.Context) (int, error)
+	// PreviewLapsedHolds reports the bookings ReleaseLapsedHolds would
+	// expire now, without changing anything.
+	PreviewLapsedHolds(ctx context.Context) (*entity.DryRunResult, error)
+	// GetCustomerSummary returns a customer's purchase history, lifetime
+	// value and risk flags for support and fraud review.
+	GetCustomerSummary(ctx context.Context, userID int64) (*entity.CustomerSummary, error)
+	// NotifyPaymentsRestored tells the customers of bookings held during a
+	// payment gateway outage that they can pay again, once the gateway has
+	// taken a call since they were held, and returns how many were told.
+	NotifyPaymentsRestored(ctx context.Context) (int, error)
 }
 
+// LapsedHoldGrace is how long past its payment deadline a hold is kept
+// before being released, so a payment started just before the deadline
+// can still finish.
+const LapsedHoldGrace = 2 * time.Minute
+
+// lapsedHoldBatch caps the bookings released per run.
+const lapsedHoldBatch = 500
+
+// paymentWindow is how long a new booking holds its tickets for payment.
+const paymentWindow = 15 * time.Minute
+
+// restoredPaymentWindow is the least time customers told that payments work
+// again get to pay.
+const restoredPaymentWindow = 15 * time.Minute
+
+// gatewayWaiterBatch caps the customers told per run.
+const gatewayWaiterBatch = 500
+
 type NotificationService interface {
-	SendNotification(bookingID int64, email, message string)
-	EnqueueCancellation(eventID int64)
+	// SendNotification emails message, translated into the language of the
+	// notification emails.
+	SendNotification(ctx context.Context, bookingID int64, email string, message i18n.Message)
+	SendBookingConfirmation(ctx context.Context, bookingID int64, email string)
+	// EnqueueCancellation queues the refunds of a cancelled event as part of
+	// the unit of work in ctx, so they are queued only if it commits.
+	EnqueueCancellation(ctx context.Context, eventID int64) error
+}
+
+// PricingAssigner picks the pricing experiment variant a user books under.
+type PricingAssigner interface {
+	AssignVariant(ctx context.Context, eventID, userID int64) (*entity.PricingVariant, error)
+}
+
+// BookingConflictPolicy controls the check for PAID tickets to other events
+// starting within Window of the event being booked. Events have no end time,
+// so Window stands in for their duration. A zero Window disables the check;
+// otherwise conflicts are returned as a warning, or rejected when Block is set.
+type BookingConflictPolicy struct {
+	Window time.Duration
+	Block  bool
+}
+
+// GatewayOutagePolicy keeps bookings going while the payment gateway is
+// down. Bookings made while Gateway's circuit is not closed are held for
+// Hold instead of the usual payment window, and their customers are told
+// once payments work again. A zero Hold disables it.
+type GatewayOutagePolicy struct {
+	Gateway GatewayMonitor
+	Hold    time.Duration
 }
 
 type bookingUsecase struct {
 	bookingRepo     repository.BookingRepository
 	transactionRepo repository.TransactionRepository
+	txManager       repository.TxManager
+	userRepo        repository.UserRepository
 	contextTimeout  time.Duration
 	notifWorker     NotificationService
+	webhooks        WebhookPublisher
+	events          DomainEventPublisher
+	pricing         PricingAssigner
+	conflicts       BookingConflictPolicy
+	ticketLimit     int
+	outage          GatewayOutagePolicy
+	clock           clock.Clock
 }
 
-func NewBookingUsecase(repo repository.BookingRepository, txnRepo repository.TransactionRepository, timeout time.Duration, notifWorker NotificationService) BookingUsecase {
+// NewBookingUsecase creates the booking usecase. ticketLimit caps the tickets
+// one user may hold per event unless the event sets its own cap; zero means
+// no cap.
+func NewBookingUsecase(repo repository.BookingRepository, txnRepo repository.TransactionRepository, txManager repository.TxManager, userRepo repository.UserRepository, timeout time.Duration, notifWorker NotificationService, webhooks WebhookPublisher, events DomainEventPublisher, pricing PricingAssigner, conflicts BookingConflictPolicy, ticketLimit int, outage GatewayOutagePolicy, clk clock.Clock) BookingUsecase {
 	return &bookingUsecase{
 		bookingRepo:     repo,
 		transactionRepo: txnRepo,
+		txManager:       txManager,
+		userRepo:        userRepo,
 		contextTimeout:  timeout,
 		notifWorker:     notifWorker,
+		webhooks:        webhooks,
+		events:          events,
+		pricing:         pricing,
+		conflicts:       conflicts,
+		ticketLimit:     ticketLimit,
+		outage:          outage,
+		clock:           clk,
 	}
 }
 
-func (uc *bookingUsecase) BookSeats(ctx context.Context, userID, eventID int64, seatIDs []int64, userEmail string) (*entity.BookingWithPayment, error) {
-	logger.Debug("usecase: booking seats",
+func (uc *bookingUsecase) BookSeats(ctx context.Context, userID, eventID int64, seatIDs []int64, quantity int, pref entity.SeatPreference) (*entity.BookingWithPayment, error) {
+	ctx, span := tracing.Start(ctx, "BookingUsecase.BookSeats",
+		attribute.Int64("event_id", eventID),
+		attribute.Int("seat_count", len(seatIDs)),
+		attribute.Int("quantity", quantity),
+	)
+	defer span.End()
+
+	if (len(seatIDs) == 0) == (quantity == 0) {
+		return nil, fmt.Errorf("%w: give either seat_ids or quantity", entity.ErrInvalidBookingRequest)
+	}
+	if quantity < 0 || quantity > entity.MaxGeneralAdmissionQuantity {
+		return nil, fmt.Errorf("%w: quantity must be between 1 and %d", entity.ErrInvalidBookingRequest, entity.MaxGeneralAdmissionQuantity)
+	}
+	if quantity == 0 && !pref.IsZero() {
+		return nil, fmt.Errorf("%w: tier_id and section only apply with a quantity", entity.ErrInvalidBookingRequest)
+	}
+
+	logger.FromContext(ctx).Debug("usecase: booking seats",
 		logger.Int64("user_id", userID),
 		logger.Int64("event_id", eventID),
 		logger.Int("seat_count", len(seatIDs)),
@@ -47,102 +171,465 @@ func (uc *bookingUsecase) BookSeats(ctx context.Context, userID, eventID int64,
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
-	bookingID, totalAmount, err := uc.bookingRepo.CreateBooking(ctx, userID, eventID, seatIDs)
+	// Looked up rather than taken from the token so the confirmation follows
+	// the account's current email
+	customer, err := uc.userRepo.GetUserByID(ctx, int(userID))
 	if err != nil {
-		logger.Error("usecase: failed to book seats",
+		logger.FromContext(ctx).Error("usecase: failed to look up booking customer",
 			logger.Int64("user_id", userID),
-			logger.Int64("event_id", eventID),
 			logger.Err(err),
 		)
 		return nil, err
 	}
 
-	// Create a PENDING transaction
-	txn := &entity.Transaction{
-		Amount:    totalAmount,
-		BookingID: bookingID,
-		Status:    "PENDING",
+	// Checked before seats are reserved so a blocked booking holds nothing
+	conflicts, err := uc.findConflicts(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
 	}
-	if err := uc.transactionRepo.CreateTransaction(ctx, txn); err != nil {
-		logger.Error("usecase: failed to create pending transaction",
-			logger.Int64("booking_id", bookingID),
+
+	// A failed assignment must not block the sale; book at list price instead
+	variant, err := uc.pricing.AssignVariant(ctx, eventID, userID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("usecase: pricing variant assignment failed, using list price",
+			logger.Int64("event_id", eventID),
 			logger.Err(err),
 		)
-		// Booking was created successfully, so we don't fail the whole operation
-		// The transaction can be created later during payment
+		variant = nil
+	}
+
+	requested := quantity
+	if requested == 0 {
+		requested = len(seatIDs)
+	}
+
+	// The booking and its PENDING transaction are written together, so a
+	// failed transaction insert leaves no booking holding the seats. The
+	// ticket limit is checked in the same transaction, under a lock, so two
+	// concurrent bookings can't both pass it.
+	expiresAt := uc.clock.Now().Add(paymentWindow)
+	var bookingID int64
+	var totalAmount float64
+	var txn *entity.Transaction
+	var assigned []entity.BookedSeat
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.checkTicketLimit(ctx, userID, eventID, requested); err != nil {
+			return err
+		}
+
+		var err error
+		if quantity > 0 {
+			bookingID, totalAmount, err = uc.bookingRepo.CreateGeneralBooking(ctx, userID, eventID, quantity, variant, expiresAt)
+			// A quantity for a seated event books its best available seats
+			if errors.Is(err, entity.ErrNotGeneralAdmission) {
+				bookingID, totalAmount, assigned, err = uc.bookingRepo.CreateBestAvailableBooking(ctx, userID, eventID, quantity, pref, variant, expiresAt)
+			}
+		} else {
+			bookingID, totalAmount, err = uc.bookingRepo.CreateBooking(ctx, userID, eventID, seatIDs, variant, expiresAt)
+		}
+		if err != nil {
+			logger.FromContext(ctx).Error("usecase: failed to book seats",
+				logger.Int64("user_id", userID),
+				logger.Int64("event_id", eventID),
+				logger.Err(err),
+			)
+			return err
+		}
+
+		txn = &entity.Transaction{
+			Amount:    totalAmount,
+			BookingID: bookingID,
+			Status:    entity.PaymentPending,
+		}
+		if err := uc.transactionRepo.CreateTransaction(ctx, txn); err != nil {
+			logger.FromContext(ctx).Error("usecase: failed to create pending transaction",
+				logger.Int64("booking_id", bookingID),
+				logger.Err(err),
+			)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	expiresAt := time.Now().Add(15 * time.Minute)
-	uc.notifWorker.SendNotification(bookingID, userEmail, "Booking berhasil! Silakan selesaikan pembayaran dalam 15 menit.")
+	// Checkout would fail now, so the seats are kept long enough for the
+	// customer to pay once told the gateway is back
+	paymentUnavailable := false
+	if uc.gatewayDown() {
+		deadline, err := uc.bookingRepo.HoldForGateway(ctx, bookingID, uc.clock.Now().Add(uc.outage.Hold))
+		if err != nil {
+			logger.FromContext(ctx).Warn("usecase: failed to hold booking for payment gateway",
+				logger.Int64("booking_id", bookingID),
+				logger.Err(err),
+			)
+		} else {
+			expiresAt = deadline
+			paymentUnavailable = true
+		}
+	}
+	uc.notifWorker.SendBookingConfirmation(ctx, bookingID, customer.Email)
+	uc.webhooks.PublishWebhookEvent(ctx, entity.WebhookBookingCreated, bookingID)
+	uc.events.PublishDomainEvent(ctx, entity.DomainEventBookingCreated, bookingID, map[string]interface{}{
+		"event_id":     eventID,
+		"user_id":      userID,
+		"status":       entity.BookingPending,
+		"total_amount": totalAmount,
+		"expires_at":   expiresAt,
+	})
 
-	logger.Info("usecase: seats booked successfully",
+	logger.FromContext(ctx).Info("usecase: seats booked successfully",
 		logger.Int64("booking_id", bookingID),
 		logger.Int64("user_id", userID),
 		logger.Int64("event_id", eventID),
 		logger.Float64("total_amount", totalAmount),
 	)
 
-	return &entity.BookingWithPayment{
-		BookingID:   bookingID,
-		EventID:     eventID,
-		Status:      "PENDING",
-		TotalAmount: totalAmount,
-		ExpiresAt:   &expiresAt,
-		Transaction: txn,
-	}, nil
+	result := &entity.BookingWithPayment{
+		BookingID:          bookingID,
+		EventID:            eventID,
+		Status:             entity.BookingPending,
+		TotalAmount:        totalAmount,
+		ExpiresAt:          &expiresAt,
+		CustomerEmail:      customer.Email,
+		Transaction:        txn,
+		PaymentUnavailable: paymentUnavailable,
+		Seats:              assigned,
+	}
+	if len(conflicts) > 0 {
+		result.Warning = &entity.BookingWarning{
+			Message:   "You already have a ticket to another event at a nearby time",
+			Conflicts: conflicts,
+		}
+	}
+	return result, nil
+}
+
+// checkTicketLimit rejects a booking that would take the user past the
+// event's per-user cap, or the default cap when the event has none. Tickets
+// in PAID and PENDING bookings count, so unpaid holds can't be used to
+// hoard seats. It must run in the booking's transaction, which keeps the
+// user's other bookings to the event waiting until it commits.
+func (uc *bookingUsecase) checkTicketLimit(ctx context.Context, userID, eventID int64, requested int) error {
+	allowance, err := uc.bookingRepo.GetTicketAllowance(ctx, userID, eventID)
+	if err != nil {
+		return err
+	}
+
+	limit := uc.ticketLimit
+	if allowance.Limit != nil {
+		limit = *allowance.Limit
+	}
+	if limit <= 0 || allowance.Held+requested <= limit {
+		return nil
+	}
+
+	logger.FromContext(ctx).Info("usecase: booking over the per-user ticket limit",
+		logger.Int64("user_id", userID),
+		logger.Int64("event_id", eventID),
+		logger.Int("held", allowance.Held),
+		logger.Int("requested", requested),
+		logger.Int("limit", limit),
+	)
+	return fmt.Errorf("%w: at most %d tickets per user for this event, %d already held", entity.ErrTicketLimitExceeded, limit, allowance.Held)
+}
+
+// findConflicts applies the conflict policy. Lookup failures only skip the
+// warning; they never fail a booking.
+func (uc *bookingUsecase) findConflicts(ctx context.Context, userID, eventID int64) ([]entity.BookingConflict, error) {
+	if uc.conflicts.Window <= 0 {
+		return nil, nil
+	}
+
+	conflicts, err := uc.bookingRepo.GetOverlappingPaidBookings(ctx, userID, eventID, uc.conflicts.Window)
+	if err != nil {
+		logger.FromContext(ctx).Warn("usecase: booking conflict check failed",
+			logger.Int64("user_id", userID),
+			logger.Int64("event_id", eventID),
+			logger.Err(err),
+		)
+		return nil, nil
+	}
+	if len(conflicts) > 0 && uc.conflicts.Block {
+		logger.FromContext(ctx).Info("usecase: booking blocked by overlapping event",
+			logger.Int64("user_id", userID),
+			logger.Int64("event_id", eventID),
+			logger.Int64("conflicting_event_id", conflicts[0].EventID),
+		)
+		return nil, entity.ErrBookingConflict
+	}
+	return conflicts, nil
 }
 
 func (uc *bookingUsecase) GetBookingsByUserID(ctx context.Context, userID int64) ([]entity.BookingWithDetails, error) {
-	logger.Debug("usecase: getting bookings by user ID", logger.Int64("user_id", userID))
+	logger.FromContext(ctx).Debug("usecase: getting bookings by user ID", logger.Int64("user_id", userID))
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
 	bookings, err := uc.bookingRepo.GetBookingsByUserID(ctx, userID)
 	if err != nil {
-		logger.Error("usecase: failed to get bookings by user ID", logger.Int64("user_id", userID), logger.Err(err))
+		logger.FromContext(ctx).Error("usecase: failed to get bookings by user ID", logger.Int64("user_id", userID), logger.Err(err))
 		return nil, err
 	}
 
-	logger.Debug("usecase: bookings fetched", logger.Int64("user_id", userID), logger.Int("count", len(bookings)))
+	logger.FromContext(ctx).Debug("usecase: bookings fetched", logger.Int64("user_id", userID), logger.Int("count", len(bookings)))
 	return bookings, nil
 }
 
+func (uc *bookingUsecase) GetBookingDetail(ctx context.Context, userID, bookingID int64) (*entity.BookingDetail, error) {
+	logger.FromContext(ctx).Debug("usecase: getting booking detail",
+		logger.Int64("user_id", userID),
+		logger.Int64("booking_id", bookingID),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	detail, err := uc.bookingRepo.GetBookingDetail(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.UserID != userID {
+		logger.FromContext(ctx).Warn("usecase: booking detail requested by another user",
+			logger.Int64("user_id", userID),
+			logger.Int64("booking_id", bookingID),
+		)
+		return nil, entity.ErrUnauthorized
+	}
+
+	return detail, nil
+}
+
 func (uc *bookingUsecase) GetAllBookings(ctx context.Context, status, sortBy, sortOrder string, page, limit int) ([]entity.BookingWithDetails, int, error) {
-	logger.Debug("usecase: getting all bookings",
+	logger.FromContext(ctx).Debug("usecase: getting all bookings",
 		logger.String("status", status),
 		logger.Int("page", page),
 		logger.Int("limit", limit),
 	)
 
+	filter, err := parseBookingStatusFilter(status)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
-	bookings, total, err := uc.bookingRepo.GetAllBookings(ctx, status, sortBy, sortOrder, page, limit)
+	bookings, total, err := uc.bookingRepo.GetAllBookings(ctx, filter, sortBy, sortOrder, page, limit)
 	if err != nil {
-		logger.Error("usecase: failed to get all bookings", logger.Err(err))
+		logger.FromContext(ctx).Error("usecase: failed to get all bookings", logger.Err(err))
 		return nil, 0, err
 	}
 
-	logger.Debug("usecase: all bookings fetched", logger.Int("total", total))
+	logger.FromContext(ctx).Debug("usecase: all bookings fetched", logger.Int("total", total))
 	return bookings, total, nil
 }
 
+func (uc *bookingUsecase) GetAllBookingsByCursor(ctx context.Context, status, sortBy, sortOrder, cursor string, limit int) (*entity.BookingPage, error) {
+	logger.FromContext(ctx).Debug("usecase: getting all bookings by cursor",
+		logger.String("status", status),
+		logger.Int("limit", limit),
+	)
+
+	filter, err := parseBookingStatusFilter(status)
+	if err != nil {
+		return nil, err
+	}
+	after, err := entity.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	bookings, next, err := uc.bookingRepo.GetAllBookingsAfter(ctx, filter, sortBy, sortOrder, after, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to page all bookings", logger.Err(err))
+		return nil, err
+	}
+
+	page := &entity.BookingPage{Bookings: bookings}
+	if next != nil {
+		page.NextCursor = next.Encode()
+		page.HasMore = true
+	}
+	return page, nil
+}
+
 func (uc *bookingUsecase) GetBookingsByEventID(ctx context.Context, eventID int64, status, sortBy, sortOrder string) ([]entity.BookingWithDetails, error) {
-	logger.Debug("usecase: getting bookings by event ID", logger.Int64("event_id", eventID))
+	logger.FromContext(ctx).Debug("usecase: getting bookings by event ID", logger.Int64("event_id", eventID))
+
+	filter, err := parseBookingStatusFilter(status)
+	if err != nil {
+		return nil, err
+	}
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
-	bookings, err := uc.bookingRepo.GetBookingsWithDetailsByEventID(ctx, eventID, status, sortBy, sortOrder)
+	bookings, err := uc.bookingRepo.GetBookingsWithDetailsByEventID(ctx, eventID, filter, sortBy, sortOrder)
 	if err != nil {
-		logger.Error("usecase: failed to get bookings by event ID", logger.Int64("event_id", eventID), logger.Err(err))
+		logger.FromContext(ctx).Error("usecase: failed to get bookings by event ID", logger.Int64("event_id", eventID), logger.Err(err))
 		return nil, err
 	}
 
-	logger.Debug("usecase: bookings fetched by event ID",
+	logger.FromContext(ctx).Debug("usecase: bookings fetched by event ID",
 		logger.Int64("event_id", eventID),
 		logger.Int("count", len(bookings)),
 	)
 	return bookings, nil
 }
+
+func (uc *bookingUsecase) GetEventBookingSummary(ctx context.Context, eventID int64) (*entity.EventBookingSummary, error) {
+	logger.FromContext(ctx).Debug("usecase: summarizing event bookings", logger.Int64("event_id", eventID))
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	summary, err := uc.bookingRepo.GetEventBookingSummary(ctx, eventID)
+	if err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Error("usecase: failed to summarize event bookings", logger.Int64("event_id", eventID), logger.Err(err))
+		}
+		return nil, err
+	}
+	return summary, nil
+}
+
+// parseBookingStatusFilter reads an optional booking status filter; empty
+// means every status.
+func parseBookingStatusFilter(status string) (entity.BookingStatus, error) {
+	if status == "" {
+		return "", nil
+	}
+	parsed, ok := entity.ParseBookingStatus(status)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", entity.ErrInvalidBookingStatus, status)
+	}
+	return parsed, nil
+}
+
+func (uc *bookingUsecase) StreamEventBookings(ctx context.Context, eventID int64, fn func(entity.BookingExportRow) error) error {
+	ctx, span := tracing.Start(ctx, "BookingUsecase.StreamEventBookings",
+		attribute.Int64("event_id", eventID),
+	)
+	defer span.End()
+
+	logger.FromContext(ctx).Debug("usecase: streaming event bookings", logger.Int64("event_id", eventID))
+
+	if err := uc.bookingRepo.StreamEventBookings(ctx, eventID, fn); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to stream event bookings", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (uc *bookingUsecase) ReleaseLapsedHolds(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "BookingUsecase.ReleaseLapsedHolds")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	ids, err := uc.bookingRepo.ExpireLapsedBookings(ctx, uc.clock.Now().Add(-LapsedHoldGrace), lapsedHoldBatch)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to expire lapsed holds", logger.Err(err))
+		return 0, err
+	}
+
+	// The bookings are expired before their seats are freed, so a failure
+	// here leaks seats rather than selling them twice; the inventory
+	// monitor reports such leaks.
+	released := 0
+	for _, id := range ids {
+		if err := uc.bookingRepo.ReleaseSeatsByBookingID(ctx, id); err != nil {
+			logger.FromContext(ctx).Error("usecase: failed to release lapsed hold", logger.Int64("booking_id", id), logger.Err(err))
+			continue
+		}
+		released++
+	}
+	span.SetAttributes(attribute.Int("released", released))
+	return released, nil
+}
+
+// gatewayDown reports whether new bookings get the outage hold.
+func (uc *bookingUsecase) gatewayDown() bool {
+	return uc.outage.Hold > 0 && uc.outage.Gateway != nil && uc.outage.Gateway.CircuitState() != payment.CircuitClosed
+}
+
+func (uc *bookingUsecase) NotifyPaymentsRestored(ctx context.Context) (int, error) {
+	// A closed circuit alone is also the state of an instance that has not
+	// called the gateway yet; only a call that went through shows it works
+	if uc.outage.Gateway == nil || uc.outage.Gateway.CircuitState() != payment.CircuitClosed {
+		return 0, nil
+	}
+	recoveredAt := uc.outage.Gateway.LastSuccess()
+	if recoveredAt.IsZero() {
+		return 0, nil
+	}
+
+	ctx, span := tracing.Start(ctx, "BookingUsecase.NotifyPaymentsRestored")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	bookings, err := uc.bookingRepo.ClaimGatewayWaiters(ctx, recoveredAt, uc.clock.Now().Add(restoredPaymentWindow), gatewayWaiterBatch)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to claim bookings waiting for payments", logger.Err(err))
+		return 0, err
+	}
+
+	notified := 0
+	for _, b := range bookings {
+		if b.UserEmail == "" {
+			continue
+		}
+		uc.notifWorker.SendNotification(ctx, b.BookingID, b.UserEmail,
+			i18n.Msg("Payments are working again. You can now pay for booking #%s.", b.BookingID))
+		notified++
+	}
+	span.SetAttributes(attribute.Int("notified", notified))
+	return notified, nil
+}
+
+func (uc *bookingUsecase) PreviewLapsedHolds(ctx context.Context) (*entity.DryRunResult, error) {
+	ctx, span := tracing.Start(ctx, "BookingUsecase.PreviewLapsedHolds")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	bookings, err := uc.bookingRepo.GetLapsedBookings(ctx, uc.clock.Now().Add(-LapsedHoldGrace))
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to preview lapsed holds", logger.Err(err))
+		return nil, err
+	}
+	for i := range bookings {
+		bookings[i].Action = entity.DryRunExpire
+	}
+	return entity.NewDryRunResult(bookings), nil
+}
+
+func (uc *bookingUsecase) GetCustomerSummary(ctx context.Context, userID int64) (*entity.CustomerSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	summary, err := uc.bookingRepo.GetCustomerSummary(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	summary.LifetimeValue = entity.AddAmounts(summary.TotalSpent, -summary.TotalRefunded)
+	summary.Flags = summary.RiskFlags()
+
+	if len(summary.Flags) > 0 {
+		logger.FromContext(ctx).Info("usecase: customer summary raised flags",
+			logger.Int64("user_id", userID),
+			logger.String("flags", strings.Join(summary.Flags, ",")),
+		)
+	}
+	return summary, nil
+}
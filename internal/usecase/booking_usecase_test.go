@@ -3,55 +3,129 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
 	"ticres/internal/entity"
 	"ticres/internal/usecase"
 	"ticres/internal/usecase/mocks"
+	"ticres/pkg/clock"
+	"ticres/pkg/i18n"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// newCustomerRepo returns a user repository that knows the booking customer.
+func newCustomerRepo() *mocks.MockUserRepo {
+	m := new(mocks.MockUserRepo)
+	m.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, Email: "user@test.com"}, nil).Maybe()
+	return m
+}
+
+// newTxManager returns a transaction manager that runs the work in place.
+func newTxManager() *mocks.MockTxManager {
+	m := new(mocks.MockTxManager)
+	m.On("WithinTransaction", mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+// newWebhookPublisher returns a publisher that accepts any lifecycle event.
+func newWebhookPublisher() *mocks.MockWebhookPublisher {
+	m := new(mocks.MockWebhookPublisher)
+	m.On("PublishWebhookEvent", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	return m
+}
+
+func newDomainEvents() *mocks.MockDomainEventPublisher {
+	m := new(mocks.MockDomainEventPublisher)
+	m.On("PublishDomainEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	return m
+}
+
 func TestBookingUsecase_BookSeats(t *testing.T) {
 	tests := []struct {
-		name      string
-		userID    int64
-		eventID   int64
-		seatIDs   []int64
-		userEmail string
-		mock      func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService)
-		wantErr   bool
+		name    string
+		userID  int64
+		eventID int64
+		seatIDs []int64
+		mock    func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner)
+		wantErr bool
 	}{
 		{
-			name:      "Success Booking",
-			userID:    1,
-			eventID:   10,
-			seatIDs:   []int64{101, 102},
-			userEmail: "user@test.com",
-			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService) {
-				mockRepo.On("CreateBooking", mock.Anything, int64(1), int64(10), []int64{101, 102}).
+			name:    "Success Booking",
+			userID:  1,
+			eventID: 10,
+			seatIDs: []int64{101, 102},
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+				mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, nil).Once()
+				mockRepo.On("CreateBooking", mock.Anything, int64(1), int64(10), []int64{101, 102}, (*entity.PricingVariant)(nil), mock.Anything).
 					Return(int64(999), float64(200000), nil).Once()
 				mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).
 					Return(nil).Once()
-				mockNotif.On("SendNotification", int64(999), "user@test.com", mock.AnythingOfType("string")).
+				mockNotif.On("SendBookingConfirmation", mock.Anything, int64(999), "user@test.com").
 					Once()
 			},
 			wantErr: false,
 		},
 		{
-			name:      "Failed Booking - Seats Taken",
-			userID:    1,
-			eventID:   10,
-			seatIDs:   []int64{101},
-			userEmail: "user@test.com",
-			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService) {
-				mockRepo.On("CreateBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			name:    "Failed Booking - Seats Taken",
+			userID:  1,
+			eventID: 10,
+			seatIDs: []int64{101},
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+				mockPricing.On("AssignVariant", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				mockRepo.On("CreateBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(int64(0), float64(0), errors.New("seat not available")).Once()
 			},
 			wantErr: true,
 		},
+		{
+			name:    "Failed Booking - Transaction Insert Rolls Back Booking",
+			userID:  1,
+			eventID: 10,
+			seatIDs: []int64{101, 102},
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+				mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, nil).Once()
+				mockRepo.On("CreateBooking", mock.Anything, int64(1), int64(10), []int64{101, 102}, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(999), float64(200000), nil).Once()
+				mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).
+					Return(errors.New("db down")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Success Booking - Pricing Variant Recorded",
+			userID:  1,
+			eventID: 10,
+			seatIDs: []int64{101, 102},
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+				variant := &entity.PricingVariant{ID: 3, Key: "discount", PriceMultiplier: 0.8}
+				mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(variant, nil).Once()
+				mockRepo.On("CreateBooking", mock.Anything, int64(1), int64(10), []int64{101, 102}, variant, mock.Anything).
+					Return(int64(999), float64(200000), nil).Once()
+				mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).
+					Return(nil).Once()
+				mockNotif.On("SendBookingConfirmation", mock.Anything, int64(999), "user@test.com").Once()
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Success Booking - Assignment Failure Uses List Price",
+			userID:  1,
+			eventID: 10,
+			seatIDs: []int64{101, 102},
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+				mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, errors.New("db down")).Once()
+				mockRepo.On("CreateBooking", mock.Anything, int64(1), int64(10), []int64{101, 102}, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(999), float64(200000), nil).Once()
+				mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).
+					Return(nil).Once()
+				mockNotif.On("SendBookingConfirmation", mock.Anything, int64(999), "user@test.com").Once()
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -59,11 +133,13 @@ func TestBookingUsecase_BookSeats(t *testing.T) {
 			mockRepo := new(mocks.MockBookingRepo)
 			mockTxnRepo := new(mocks.MockTransactionRepo)
 			mockNotif := new(mocks.MockNotificationService)
+			mockPricing := new(mocks.MockPricingAssigner)
+			mockRepo.On("GetTicketAllowance", mock.Anything, mock.Anything, mock.Anything).Return(&entity.TicketAllowance{}, nil).Maybe()
 
-			tt.mock(mockRepo, mockTxnRepo, mockNotif)
+			tt.mock(mockRepo, mockTxnRepo, mockNotif, mockPricing)
 
-			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, time.Second*2, mockNotif)
-			result, err := u.BookSeats(context.Background(), tt.userID, tt.eventID, tt.seatIDs, tt.userEmail)
+			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, newTxManager(), newCustomerRepo(), time.Second*2, mockNotif, newWebhookPublisher(), newDomainEvents(), mockPricing, usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+			result, err := u.BookSeats(context.Background(), tt.userID, tt.eventID, tt.seatIDs, 0, entity.SeatPreference{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -71,13 +147,509 @@ func TestBookingUsecase_BookSeats(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
-				assert.Equal(t, "PENDING", result.Status)
+				assert.Equal(t, entity.BookingPending, result.Status)
 				assert.Equal(t, float64(200000), result.TotalAmount)
+				assert.Equal(t, "user@test.com", result.CustomerEmail)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockTxnRepo.AssertExpectations(t)
+			mockNotif.AssertExpectations(t)
+			mockPricing.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookingUsecase_BookSeats_CustomerLookup(t *testing.T) {
+	t.Run("Success - Confirmation Goes To Account Email", func(t *testing.T) {
+		mockRepo := new(mocks.MockBookingRepo)
+		mockTxnRepo := new(mocks.MockTransactionRepo)
+		mockUserRepo := new(mocks.MockUserRepo)
+		mockNotif := new(mocks.MockNotificationService)
+		mockPricing := new(mocks.MockPricingAssigner)
+
+		mockUserRepo.On("GetUserByID", mock.Anything, 7).Return(&entity.User{ID: 7, Email: "jane@test.com"}, nil).Once()
+		mockRepo.On("GetTicketAllowance", mock.Anything, int64(7), int64(10)).Return(&entity.TicketAllowance{}, nil).Once()
+		mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(7)).Return(nil, nil).Once()
+		mockRepo.On("CreateBooking", mock.Anything, int64(7), int64(10), []int64{101}, (*entity.PricingVariant)(nil), mock.Anything).
+			Return(int64(999), float64(100000), nil).Once()
+		mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(nil).Once()
+		mockNotif.On("SendBookingConfirmation", mock.Anything, int64(999), "jane@test.com").Once()
+		mockWebhooks := new(mocks.MockWebhookPublisher)
+		mockWebhooks.On("PublishWebhookEvent", mock.Anything, entity.WebhookBookingCreated, int64(999)).Once()
+
+		u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, newTxManager(), mockUserRepo, time.Second*2, mockNotif, mockWebhooks, newDomainEvents(), mockPricing, usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+		result, err := u.BookSeats(context.Background(), 7, 10, []int64{101}, 0, entity.SeatPreference{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jane@test.com", result.CustomerEmail)
+		mockUserRepo.AssertExpectations(t)
+		mockNotif.AssertExpectations(t)
+		mockWebhooks.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Lookup Error Books Nothing", func(t *testing.T) {
+		mockRepo := new(mocks.MockBookingRepo)
+		mockUserRepo := new(mocks.MockUserRepo)
+		mockUserRepo.On("GetUserByID", mock.Anything, 7).Return(nil, errors.New("db down")).Once()
+
+		u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), mockUserRepo, time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+		result, err := u.BookSeats(context.Background(), 7, 10, []int64{101}, 0, entity.SeatPreference{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestBookingUsecase_BookSeats_Conflicts(t *testing.T) {
+	window := 4 * time.Hour
+	conflicts := []entity.BookingConflict{{BookingID: 50, EventID: 11, EventName: "Konser B", EventDate: time.Now()}}
+
+	tests := []struct {
+		name        string
+		block       bool
+		mock        func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner)
+		wantErr     error
+		wantWarning bool
+	}{
+		{
+			name: "Warns - Overlapping Paid Booking",
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+				mockRepo.On("GetOverlappingPaidBookings", mock.Anything, int64(1), int64(10), window).Return(conflicts, nil).Once()
+				mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, nil).Once()
+				mockRepo.On("CreateBooking", mock.Anything, int64(1), int64(10), []int64{101}, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(999), float64(100000), nil).Once()
+				mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(nil).Once()
+				mockNotif.On("SendBookingConfirmation", mock.Anything, int64(999), "user@test.com").Once()
+			},
+			wantWarning: true,
+		},
+		{
+			name: "No Warning - Check Fails",
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+				mockRepo.On("GetOverlappingPaidBookings", mock.Anything, int64(1), int64(10), window).Return(nil, errors.New("db down")).Once()
+				mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, nil).Once()
+				mockRepo.On("CreateBooking", mock.Anything, int64(1), int64(10), []int64{101}, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(999), float64(100000), nil).Once()
+				mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(nil).Once()
+				mockNotif.On("SendBookingConfirmation", mock.Anything, int64(999), "user@test.com").Once()
+			},
+		},
+		{
+			name:  "Blocked - Overlapping Paid Booking",
+			block: true,
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+				mockRepo.On("GetOverlappingPaidBookings", mock.Anything, int64(1), int64(10), window).Return(conflicts, nil).Once()
+			},
+			wantErr: entity.ErrBookingConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBookingRepo)
+			mockTxnRepo := new(mocks.MockTransactionRepo)
+			mockNotif := new(mocks.MockNotificationService)
+			mockPricing := new(mocks.MockPricingAssigner)
+			mockRepo.On("GetTicketAllowance", mock.Anything, mock.Anything, mock.Anything).Return(&entity.TicketAllowance{}, nil).Maybe()
+			tt.mock(mockRepo, mockTxnRepo, mockNotif, mockPricing)
+
+			policy := usecase.BookingConflictPolicy{Window: window, Block: tt.block}
+			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, newTxManager(), newCustomerRepo(), time.Second*2, mockNotif, newWebhookPublisher(), newDomainEvents(), mockPricing, policy, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+			result, err := u.BookSeats(context.Background(), 1, 10, []int64{101}, 0, entity.SeatPreference{})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				mockRepo.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				if tt.wantWarning {
+					assert.NotNil(t, result.Warning)
+					assert.Equal(t, conflicts, result.Warning.Conflicts)
+				} else {
+					assert.Nil(t, result.Warning)
+				}
+			}
+			mockRepo.AssertExpectations(t)
+			mockTxnRepo.AssertExpectations(t)
+			mockNotif.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookingUsecase_BookSeats_TicketLimit(t *testing.T) {
+	four := 4
+
+	tests := []struct {
+		name         string
+		defaultLimit int
+		allowance    *entity.TicketAllowance
+		seatIDs      []int64
+		quantity     int
+		wantErr      error
+	}{
+		{
+			name:         "Within Default Limit",
+			defaultLimit: 4,
+			allowance:    &entity.TicketAllowance{Held: 2},
+			seatIDs:      []int64{101, 102},
+		},
+		{
+			name:         "Over Default Limit",
+			defaultLimit: 4,
+			allowance:    &entity.TicketAllowance{Held: 3},
+			seatIDs:      []int64{101, 102},
+			wantErr:      entity.ErrTicketLimitExceeded,
+		},
+		{
+			name:         "Event Limit Overrides Default",
+			defaultLimit: 10,
+			allowance:    &entity.TicketAllowance{Limit: &four, Held: 2},
+			quantity:     3,
+			wantErr:      entity.ErrTicketLimitExceeded,
+		},
+		{
+			name:      "No Limit",
+			allowance: &entity.TicketAllowance{Held: 50},
+			seatIDs:   []int64{101, 102},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBookingRepo)
+			mockTxnRepo := new(mocks.MockTransactionRepo)
+			mockNotif := new(mocks.MockNotificationService)
+			mockPricing := new(mocks.MockPricingAssigner)
+
+			mockRepo.On("GetTicketAllowance", mock.Anything, int64(1), int64(10)).Return(tt.allowance, nil).Once()
+			mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, nil).Once()
+			if tt.wantErr == nil {
+				mockRepo.On("CreateBooking", mock.Anything, int64(1), int64(10), tt.seatIDs, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(999), float64(200000), nil).Once()
+				mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(nil).Once()
+				mockNotif.On("SendBookingConfirmation", mock.Anything, int64(999), "user@test.com").Once()
+			}
+
+			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, newTxManager(), newCustomerRepo(), time.Second*2, mockNotif, newWebhookPublisher(), newDomainEvents(), mockPricing, usecase.BookingConflictPolicy{}, tt.defaultLimit, usecase.GatewayOutagePolicy{}, clock.Real{})
+			result, err := u.BookSeats(context.Background(), 1, 10, tt.seatIDs, tt.quantity, entity.SeatPreference{})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				mockRepo.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				mockRepo.AssertNotCalled(t, "CreateGeneralBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
 			}
+			mockRepo.AssertExpectations(t)
+			mockTxnRepo.AssertExpectations(t)
+			mockNotif.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookingUsecase_BookSeats_TicketLimitInTransaction(t *testing.T) {
+	mockRepo := new(mocks.MockBookingRepo)
+	mockTx := new(mocks.MockTxManager)
+	mockPricing := new(mocks.MockPricingAssigner)
+
+	// The count is only read inside the booking transaction, so a failed
+	// begin never reaches it
+	txErr := errors.New("begin failed")
+	mockTx.On("WithinTransaction", mock.Anything).Return(txErr).Once()
+	mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, nil).Once()
+
+	u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), mockTx, newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), mockPricing, usecase.BookingConflictPolicy{}, 4, usecase.GatewayOutagePolicy{}, clock.Real{})
+	result, err := u.BookSeats(context.Background(), 1, 10, []int64{101}, 0, entity.SeatPreference{})
+
+	assert.ErrorIs(t, err, txErr)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "GetTicketAllowance", mock.Anything, mock.Anything, mock.Anything)
+	mockTx.AssertExpectations(t)
+}
+
+func TestBookingUsecase_BookSeats_GeneralAdmission(t *testing.T) {
+	tests := []struct {
+		name     string
+		seatIDs  []int64
+		quantity int
+		mock     func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner)
+		wantErr  error
+	}{
+		{
+			name:     "Success - Quantity Booked",
+			quantity: 3,
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+				mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, nil).Once()
+				mockRepo.On("CreateGeneralBooking", mock.Anything, int64(1), int64(10), 3, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(999), float64(300000), nil).Once()
+				mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(nil).Once()
+				mockNotif.On("SendBookingConfirmation", mock.Anything, int64(999), "user@test.com").Once()
+			},
+		},
+		{
+			name:     "Failed - Sold Out",
+			quantity: 2,
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+				mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, nil).Once()
+				mockRepo.On("CreateGeneralBooking", mock.Anything, int64(1), int64(10), 2, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(0), float64(0), entity.ErrSoldOut).Once()
+			},
+			wantErr: entity.ErrSoldOut,
+		},
+		{
+			name:     "Failed - Seats And Quantity",
+			seatIDs:  []int64{101},
+			quantity: 1,
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+			},
+			wantErr: entity.ErrInvalidBookingRequest,
+		},
+		{
+			name: "Failed - Nothing Requested",
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+			},
+			wantErr: entity.ErrInvalidBookingRequest,
+		},
+		{
+			name:     "Failed - Quantity Above Limit",
+			quantity: entity.MaxGeneralAdmissionQuantity + 1,
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService, mockPricing *mocks.MockPricingAssigner) {
+			},
+			wantErr: entity.ErrInvalidBookingRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBookingRepo)
+			mockTxnRepo := new(mocks.MockTransactionRepo)
+			mockNotif := new(mocks.MockNotificationService)
+			mockPricing := new(mocks.MockPricingAssigner)
+			mockRepo.On("GetTicketAllowance", mock.Anything, mock.Anything, mock.Anything).Return(&entity.TicketAllowance{}, nil).Maybe()
+			tt.mock(mockRepo, mockTxnRepo, mockNotif, mockPricing)
 
+			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, newTxManager(), newCustomerRepo(), time.Second*2, mockNotif, newWebhookPublisher(), newDomainEvents(), mockPricing, usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+			result, err := u.BookSeats(context.Background(), 1, 10, tt.seatIDs, tt.quantity, entity.SeatPreference{})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.BookingPending, result.Status)
+				assert.Equal(t, float64(300000), result.TotalAmount)
+			}
+			mockRepo.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 			mockRepo.AssertExpectations(t)
 			mockTxnRepo.AssertExpectations(t)
 			mockNotif.AssertExpectations(t)
+			mockPricing.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookingUsecase_BookSeats_BestAvailable(t *testing.T) {
+	tierID := int64(2)
+	vip := entity.SeatPreference{TierID: &tierID, Section: "VIP"}
+	seats := []entity.BookedSeat{
+		{SeatID: 41, SeatNumber: "VIP-A4", Category: "VIP", Price: 250000},
+		{SeatID: 42, SeatNumber: "VIP-A5", Category: "VIP", Price: 250000},
+	}
+
+	tests := []struct {
+		name     string
+		seatIDs  []int64
+		quantity int
+		pref     entity.SeatPreference
+		mock     func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService)
+		wantErr  error
+	}{
+		{
+			name:     "Success - Seated Event Gets Adjacent Seats",
+			quantity: 2,
+			pref:     vip,
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService) {
+				mockRepo.On("CreateGeneralBooking", mock.Anything, int64(1), int64(10), 2, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(0), float64(0), entity.ErrNotGeneralAdmission).Once()
+				mockRepo.On("CreateBestAvailableBooking", mock.Anything, int64(1), int64(10), 2, vip, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(999), float64(500000), seats, nil).Once()
+				mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(nil).Once()
+				mockNotif.On("SendBookingConfirmation", mock.Anything, int64(999), "user@test.com").Once()
+			},
+		},
+		{
+			name:     "Failed - No Run Of Adjacent Seats",
+			quantity: 4,
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService) {
+				mockRepo.On("CreateGeneralBooking", mock.Anything, int64(1), int64(10), 4, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(0), float64(0), entity.ErrNotGeneralAdmission).Once()
+				mockRepo.On("CreateBestAvailableBooking", mock.Anything, int64(1), int64(10), 4, entity.SeatPreference{}, (*entity.PricingVariant)(nil), mock.Anything).
+					Return(int64(0), float64(0), nil, entity.ErrNoAdjacentSeats).Once()
+			},
+			wantErr: entity.ErrNoAdjacentSeats,
+		},
+		{
+			name:    "Failed - Preference Without Quantity",
+			seatIDs: []int64{101},
+			pref:    vip,
+			mock: func(mockRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockNotif *mocks.MockNotificationService) {
+			},
+			wantErr: entity.ErrInvalidBookingRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBookingRepo)
+			mockTxnRepo := new(mocks.MockTransactionRepo)
+			mockNotif := new(mocks.MockNotificationService)
+			mockPricing := new(mocks.MockPricingAssigner)
+			mockRepo.On("GetTicketAllowance", mock.Anything, mock.Anything, mock.Anything).Return(&entity.TicketAllowance{}, nil).Maybe()
+			mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, nil).Maybe()
+			tt.mock(mockRepo, mockTxnRepo, mockNotif)
+
+			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, newTxManager(), newCustomerRepo(), time.Second*2, mockNotif, newWebhookPublisher(), newDomainEvents(), mockPricing, usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+			result, err := u.BookSeats(context.Background(), 1, 10, tt.seatIDs, tt.quantity, tt.pref)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, float64(500000), result.TotalAmount)
+				assert.Equal(t, seats, result.Seats)
+			}
+			mockRepo.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockRepo.AssertExpectations(t)
+			mockTxnRepo.AssertExpectations(t)
+			mockNotif.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookingUsecase_BookSeats_GatewayOutage(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	held := clk.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		circuit     string
+		hold        time.Duration
+		holdErr     error
+		wantHold    bool
+		wantExpires *time.Time
+	}{
+		{name: "Gateway Up", circuit: "closed", hold: time.Hour},
+		{name: "Circuit Open - Held", circuit: "open", hold: time.Hour, wantHold: true, wantExpires: &held},
+		{name: "Circuit Half Open - Held", circuit: "half_open", hold: time.Hour, wantHold: true, wantExpires: &held},
+		{name: "Hold Disabled", circuit: "open"},
+		{name: "Hold Failed - Usual Deadline", circuit: "open", hold: time.Hour, wantHold: true, holdErr: errors.New("db down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBookingRepo)
+			mockTxnRepo := new(mocks.MockTransactionRepo)
+			mockNotif := new(mocks.MockNotificationService)
+			mockPricing := new(mocks.MockPricingAssigner)
+			mockGateway := new(mocks.MockGatewayMonitor)
+
+			mockRepo.On("GetTicketAllowance", mock.Anything, int64(1), int64(10)).Return(&entity.TicketAllowance{}, nil).Once()
+			mockPricing.On("AssignVariant", mock.Anything, int64(10), int64(1)).Return(nil, nil).Once()
+			// The repository stores the deadline the usecase's clock gives
+			mockRepo.On("CreateBooking", mock.Anything, int64(1), int64(10), []int64{101}, (*entity.PricingVariant)(nil), clk.Now().Add(15*time.Minute)).
+				Return(int64(999), float64(100000), nil).Once()
+			mockTxnRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(nil).Once()
+			mockNotif.On("SendBookingConfirmation", mock.Anything, int64(999), "user@test.com").Once()
+			mockGateway.On("CircuitState").Return(tt.circuit).Maybe()
+			if tt.wantHold {
+				mockRepo.On("HoldForGateway", mock.Anything, int64(999), clk.Now().Add(tt.hold)).Return(held, tt.holdErr).Once()
+			}
+
+			outage := usecase.GatewayOutagePolicy{Gateway: mockGateway, Hold: tt.hold}
+			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, newTxManager(), newCustomerRepo(), time.Second*2, mockNotif, newWebhookPublisher(), newDomainEvents(), mockPricing, usecase.BookingConflictPolicy{}, 0, outage, clk)
+			result, err := u.BookSeats(context.Background(), 1, 10, []int64{101}, 0, entity.SeatPreference{})
+
+			// Checkout trouble never fails the booking itself
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExpires != nil, result.PaymentUnavailable)
+			if tt.wantExpires != nil {
+				assert.Equal(t, *tt.wantExpires, *result.ExpiresAt)
+			} else {
+				assert.Equal(t, clk.Now().Add(15*time.Minute), *result.ExpiresAt)
+			}
+			if !tt.wantHold {
+				mockRepo.AssertNotCalled(t, "HoldForGateway", mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockRepo.AssertExpectations(t)
+			mockNotif.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookingUsecase_GetBookingDetail(t *testing.T) {
+	detail := &entity.BookingDetail{
+		ID:      5,
+		UserID:  1,
+		EventID: 10,
+		Status:  "PAID",
+		Seats: []entity.BookedSeat{
+			{SeatID: 100, SeatNumber: "A1", Category: "VIP", Price: 150000},
+		},
+		Transaction: &entity.Transaction{ID: 7, BookingID: 5, Amount: 150000, Status: "COMPLETED"},
+	}
+
+	tests := []struct {
+		name    string
+		userID  int64
+		mock    func(mockRepo *mocks.MockBookingRepo)
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			userID: 1,
+			mock: func(mockRepo *mocks.MockBookingRepo) {
+				mockRepo.On("GetBookingDetail", mock.Anything, int64(5)).Return(detail, nil).Once()
+			},
+		},
+		{
+			name:   "Failed - Another User's Booking",
+			userID: 2,
+			mock: func(mockRepo *mocks.MockBookingRepo) {
+				mockRepo.On("GetBookingDetail", mock.Anything, int64(5)).Return(detail, nil).Once()
+			},
+			wantErr: entity.ErrUnauthorized,
+		},
+		{
+			name:   "Failed - Not Found",
+			userID: 1,
+			mock: func(mockRepo *mocks.MockBookingRepo) {
+				mockRepo.On("GetBookingDetail", mock.Anything, int64(5)).Return(nil, entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBookingRepo)
+			tt.mock(mockRepo)
+
+			u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+			got, err := u.GetBookingDetail(context.Background(), tt.userID, 5)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, detail, got)
+			}
+			mockRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -136,7 +708,7 @@ func TestBookingUsecase_GetBookingsByUserID(t *testing.T) {
 
 			tt.mock(mockRepo)
 
-			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, time.Second*2, mockNotif)
+			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, newTxManager(), newCustomerRepo(), time.Second*2, mockNotif, newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
 			bookings, err := u.GetBookingsByUserID(context.Background(), tt.userID)
 
 			if tt.wantErr {
@@ -178,13 +750,38 @@ func TestBookingUsecase_GetAllBookings(t *testing.T) {
 			page:      1,
 			limit:     20,
 			mock: func(mockRepo *mocks.MockBookingRepo) {
-				mockRepo.On("GetAllBookings", mock.Anything, "", "created_at", "desc", 1, 20).
+				mockRepo.On("GetAllBookings", mock.Anything, entity.BookingStatus(""), "created_at", "desc", 1, 20).
 					Return(mockBookings, 2, nil).Once()
 			},
 			wantErr:      false,
 			wantBookings: mockBookings,
 			wantTotal:    2,
 		},
+		{
+			name:      "Success - Lowercase Status Filter",
+			status:    "paid",
+			sortBy:    "created_at",
+			sortOrder: "desc",
+			page:      1,
+			limit:     20,
+			mock: func(mockRepo *mocks.MockBookingRepo) {
+				mockRepo.On("GetAllBookings", mock.Anything, entity.BookingPaid, "created_at", "desc", 1, 20).
+					Return(mockBookings[:1], 1, nil).Once()
+			},
+			wantErr:      false,
+			wantBookings: mockBookings[:1],
+			wantTotal:    1,
+		},
+		{
+			name:      "Failed - Unknown Status",
+			status:    "confirmed",
+			sortBy:    "created_at",
+			sortOrder: "desc",
+			page:      1,
+			limit:     20,
+			mock:      func(mockRepo *mocks.MockBookingRepo) {},
+			wantErr:   true,
+		},
 		{
 			name:      "Failed - DB Error",
 			status:    "",
@@ -193,7 +790,7 @@ func TestBookingUsecase_GetAllBookings(t *testing.T) {
 			page:      1,
 			limit:     20,
 			mock: func(mockRepo *mocks.MockBookingRepo) {
-				mockRepo.On("GetAllBookings", mock.Anything, "", "created_at", "desc", 1, 20).
+				mockRepo.On("GetAllBookings", mock.Anything, entity.BookingStatus(""), "created_at", "desc", 1, 20).
 					Return(nil, 0, errors.New("db error")).Once()
 			},
 			wantErr:      true,
@@ -210,7 +807,7 @@ func TestBookingUsecase_GetAllBookings(t *testing.T) {
 
 			tt.mock(mockRepo)
 
-			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, time.Second*2, mockNotif)
+			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, newTxManager(), newCustomerRepo(), time.Second*2, mockNotif, newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
 			bookings, total, err := u.GetAllBookings(context.Background(), tt.status, tt.sortBy, tt.sortOrder, tt.page, tt.limit)
 
 			if tt.wantErr {
@@ -227,6 +824,113 @@ func TestBookingUsecase_GetAllBookings(t *testing.T) {
 	}
 }
 
+func TestBookingUsecase_GetAllBookingsByCursor(t *testing.T) {
+	created := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	mockBookings := []entity.BookingWithDetails{
+		{ID: 9, UserID: 1, EventID: 10, Status: entity.BookingPaid, CreatedAt: created},
+	}
+	after := &entity.PageCursor{Sort: "created_at:desc", CreatedAt: created, ID: 12}
+
+	t.Run("Success - Next Page", func(t *testing.T) {
+		mockRepo := new(mocks.MockBookingRepo)
+		next := &entity.PageCursor{Sort: "created_at:desc", CreatedAt: created, ID: 9}
+		mockRepo.On("GetAllBookingsAfter", mock.Anything, entity.BookingPaid, "created_at", "desc", after, 1).
+			Return(mockBookings, next, nil).Once()
+
+		u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+		page, err := u.GetAllBookingsByCursor(context.Background(), "PAID", "created_at", "desc", after.Encode(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockBookings, page.Bookings)
+		assert.True(t, page.HasMore)
+		assert.Equal(t, next.Encode(), page.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Cursor For Another Sort", func(t *testing.T) {
+		mockRepo := new(mocks.MockBookingRepo)
+		mockRepo.On("GetAllBookingsAfter", mock.Anything, entity.BookingStatus(""), "status", "asc", after, 20).
+			Return(nil, nil, entity.ErrInvalidCursor).Once()
+
+		u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+		page, err := u.GetAllBookingsByCursor(context.Background(), "", "status", "asc", after.Encode(), 20)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidCursor)
+		assert.Nil(t, page)
+	})
+
+	t.Run("Failed - Malformed Cursor", func(t *testing.T) {
+		mockRepo := new(mocks.MockBookingRepo)
+
+		u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+		page, err := u.GetAllBookingsByCursor(context.Background(), "", "created_at", "desc", "%%%", 20)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidCursor)
+		assert.Nil(t, page)
+		mockRepo.AssertNotCalled(t, "GetAllBookingsAfter")
+	})
+}
+
+func TestBookingUsecase_GetEventBookingSummary(t *testing.T) {
+	tierID := int64(3)
+	summary := &entity.EventBookingSummary{
+		EventID: 10, Capacity: 200, TicketsSold: 150, OccupancyPercent: 75, Revenue: 15000000, Refunds: 100000,
+		ByStatus: []entity.BookingStatusTotal{{Status: entity.BookingPaid, Bookings: 60, Tickets: 150, Amount: 15000000}},
+		ByTier:   []entity.TierSalesTotal{{TierID: &tierID, TierName: "VIP", TicketsSold: 150, Revenue: 15000000}},
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(mockRepo *mocks.MockBookingRepo)
+		want    *entity.EventBookingSummary
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mockRepo *mocks.MockBookingRepo) {
+				mockRepo.On("GetEventBookingSummary", mock.Anything, int64(10)).Return(summary, nil).Once()
+			},
+			want: summary,
+		},
+		{
+			name: "Unknown event",
+			mock: func(mockRepo *mocks.MockBookingRepo) {
+				mockRepo.On("GetEventBookingSummary", mock.Anything, int64(10)).Return(nil, entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBookingRepo)
+			tt.mock(mockRepo)
+
+			u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+			got, err := u.GetEventBookingSummary(context.Background(), 10)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventBookingSummary_Summarize(t *testing.T) {
+	s := entity.EventBookingSummary{Capacity: 300, TicketsSold: 100}
+	s.Summarize()
+	assert.Equal(t, 33.33, s.OccupancyPercent)
+
+	s = entity.EventBookingSummary{TicketsSold: 5}
+	s.Summarize()
+	assert.Equal(t, 0.0, s.OccupancyPercent)
+}
+
 func TestBookingUsecase_GetBookingsByEventID(t *testing.T) {
 	mockBookings := []entity.BookingWithDetails{
 		{ID: 1, UserID: 1, UserName: "John", UserEmail: "john@test.com", EventID: 10, EventName: "Concert A", Status: "PAID"},
@@ -250,7 +954,7 @@ func TestBookingUsecase_GetBookingsByEventID(t *testing.T) {
 			sortBy:    "created_at",
 			sortOrder: "desc",
 			mock: func(mockRepo *mocks.MockBookingRepo) {
-				mockRepo.On("GetBookingsWithDetailsByEventID", mock.Anything, int64(10), "", "created_at", "desc").
+				mockRepo.On("GetBookingsWithDetailsByEventID", mock.Anything, int64(10), entity.BookingStatus(""), "created_at", "desc").
 					Return(mockBookings, nil).Once()
 			},
 			wantErr:      false,
@@ -263,7 +967,7 @@ func TestBookingUsecase_GetBookingsByEventID(t *testing.T) {
 			sortBy:    "created_at",
 			sortOrder: "desc",
 			mock: func(mockRepo *mocks.MockBookingRepo) {
-				mockRepo.On("GetBookingsWithDetailsByEventID", mock.Anything, int64(10), "", "created_at", "desc").
+				mockRepo.On("GetBookingsWithDetailsByEventID", mock.Anything, int64(10), entity.BookingStatus(""), "created_at", "desc").
 					Return(nil, errors.New("db error")).Once()
 			},
 			wantErr:      true,
@@ -279,7 +983,7 @@ func TestBookingUsecase_GetBookingsByEventID(t *testing.T) {
 
 			tt.mock(mockRepo)
 
-			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, time.Second*2, mockNotif)
+			u := usecase.NewBookingUsecase(mockRepo, mockTxnRepo, newTxManager(), newCustomerRepo(), time.Second*2, mockNotif, newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
 			bookings, err := u.GetBookingsByEventID(context.Background(), tt.eventID, tt.status, tt.sortBy, tt.sortOrder)
 
 			if tt.wantErr {
@@ -294,3 +998,290 @@ func TestBookingUsecase_GetBookingsByEventID(t *testing.T) {
 		})
 	}
 }
+
+func TestBookingUsecase_StreamEventBookings(t *testing.T) {
+	rows := []entity.BookingExportRow{
+		{BookingID: 1, UserName: "John", Seats: []string{"A1", "A2"}, PaymentStatus: "SUCCESS"},
+		{BookingID: 2, UserName: "Jane", Quantity: 3, PaymentStatus: "PENDING"},
+	}
+
+	t.Run("Success - Streams Every Booking", func(t *testing.T) {
+		mockRepo := new(mocks.MockBookingRepo)
+		mockRepo.On("StreamEventBookings", mock.Anything, int64(10), mock.Anything).Return(rows, nil).Once()
+
+		u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+		var got []int64
+		err := u.StreamEventBookings(context.Background(), 10, func(row entity.BookingExportRow) error {
+			got = append(got, row.BookingID)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, got)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Event Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockBookingRepo)
+		mockRepo.On("StreamEventBookings", mock.Anything, int64(9), mock.Anything).Return(nil, entity.ErrNotFound).Once()
+
+		u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+		err := u.StreamEventBookings(context.Background(), 9, func(entity.BookingExportRow) error { return nil })
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Writer Error Stops Stream", func(t *testing.T) {
+		mockRepo := new(mocks.MockBookingRepo)
+		mockRepo.On("StreamEventBookings", mock.Anything, int64(10), mock.Anything).Return(rows, nil).Once()
+
+		u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+		writeErr := errors.New("broken pipe")
+		calls := 0
+		err := u.StreamEventBookings(context.Background(), 10, func(entity.BookingExportRow) error {
+			calls++
+			return writeErr
+		})
+
+		assert.ErrorIs(t, err, writeErr)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestBookingUsecase_ReleaseLapsedHolds(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name         string
+		mock         func(mockRepo *mocks.MockBookingRepo)
+		wantReleased int
+		wantErr      bool
+	}{
+		{
+			name: "Success",
+			mock: func(mockRepo *mocks.MockBookingRepo) {
+				mockRepo.On("ExpireLapsedBookings", mock.Anything, clk.Now().Add(-usecase.LapsedHoldGrace), mock.Anything).Return([]int64{3, 4}, nil).Once()
+				mockRepo.On("ReleaseSeatsByBookingID", mock.Anything, int64(3)).Return(nil).Once()
+				mockRepo.On("ReleaseSeatsByBookingID", mock.Anything, int64(4)).Return(nil).Once()
+			},
+			wantReleased: 2,
+		},
+		{
+			name: "Success - Release Failure Does Not Stop The Run",
+			mock: func(mockRepo *mocks.MockBookingRepo) {
+				mockRepo.On("ExpireLapsedBookings", mock.Anything, mock.Anything, mock.Anything).Return([]int64{3, 4}, nil).Once()
+				mockRepo.On("ReleaseSeatsByBookingID", mock.Anything, int64(3)).Return(errors.New("db error")).Once()
+				mockRepo.On("ReleaseSeatsByBookingID", mock.Anything, int64(4)).Return(nil).Once()
+			},
+			wantReleased: 1,
+		},
+		{
+			name: "Success - Nothing Lapsed",
+			mock: func(mockRepo *mocks.MockBookingRepo) {
+				mockRepo.On("ExpireLapsedBookings", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "Failed - DB Error",
+			mock: func(mockRepo *mocks.MockBookingRepo) {
+				mockRepo.On("ExpireLapsedBookings", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBookingRepo)
+			tt.mock(mockRepo)
+
+			u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clk)
+			released, err := u.ReleaseLapsedHolds(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantReleased, released)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookingUsecase_PreviewLapsedHolds(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+		mockRepo := new(mocks.MockBookingRepo)
+		mockRepo.On("GetLapsedBookings", mock.Anything, clk.Now().Add(-usecase.LapsedHoldGrace)).Return([]entity.AffectedBooking{
+			{BookingID: 3, Status: "PENDING", Tickets: 2},
+			{BookingID: 4, Status: "PENDING", Tickets: 1},
+		}, nil).Once()
+
+		u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clk)
+		result, err := u.PreviewLapsedHolds(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Expired)
+		assert.Equal(t, 3, result.TicketsReleased)
+		assert.Zero(t, result.RefundAmount)
+		for _, b := range result.Bookings {
+			assert.Equal(t, entity.DryRunExpire, b.Action)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - DB Error", func(t *testing.T) {
+		mockRepo := new(mocks.MockBookingRepo)
+		mockRepo.On("GetLapsedBookings", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+
+		u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+		result, err := u.PreviewLapsedHolds(context.Background())
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestBookingUsecase_NotifyPaymentsRestored(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	recoveredAt := clk.Now().Add(-time.Minute)
+	deadline := clk.Now().Add(15 * time.Minute)
+
+	tests := []struct {
+		name         string
+		circuit      string
+		lastSuccess  time.Time
+		waiters      []entity.AffectedBooking
+		claimErr     error
+		wantClaim    bool
+		wantNotified []int64
+		wantErr      bool
+	}{
+		{
+			name:        "Gateway Back - Customers Told",
+			circuit:     "closed",
+			lastSuccess: recoveredAt,
+			waiters: []entity.AffectedBooking{
+				{BookingID: 5, UserEmail: "a@test.com", ExpiresAt: &deadline},
+				{BookingID: 6, UserEmail: "b@test.com", ExpiresAt: &deadline},
+				{BookingID: 7, ExpiresAt: &deadline},
+			},
+			wantClaim:    true,
+			wantNotified: []int64{5, 6},
+		},
+		{name: "Circuit Still Open", circuit: "open", lastSuccess: recoveredAt},
+		{name: "Circuit Half Open", circuit: "half_open", lastSuccess: recoveredAt},
+		{name: "No Call Went Through Yet", circuit: "closed"},
+		{name: "Claim Failed", circuit: "closed", lastSuccess: recoveredAt, claimErr: errors.New("db down"), wantClaim: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBookingRepo)
+			mockNotif := new(mocks.MockNotificationService)
+			mockGateway := new(mocks.MockGatewayMonitor)
+
+			mockGateway.On("CircuitState").Return(tt.circuit)
+			mockGateway.On("LastSuccess").Return(tt.lastSuccess).Maybe()
+			if tt.wantClaim {
+				mockRepo.On("ClaimGatewayWaiters", mock.Anything, tt.lastSuccess, deadline, 500).
+					Return(tt.waiters, tt.claimErr).Once()
+			}
+			for _, id := range tt.wantNotified {
+				mockNotif.On("SendNotification", mock.Anything, id, mock.Anything, mock.MatchedBy(func(m i18n.Message) bool {
+					return len(m.Args) == 1 && m.Args[0] == strconv.FormatInt(id, 10)
+				})).Once()
+			}
+
+			outage := usecase.GatewayOutagePolicy{Gateway: mockGateway, Hold: time.Hour}
+			u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, mockNotif, newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, outage, clk)
+			notified, err := u.NotifyPaymentsRestored(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, len(tt.wantNotified), notified)
+			if !tt.wantClaim {
+				mockRepo.AssertNotCalled(t, "ClaimGatewayWaiters", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockRepo.AssertExpectations(t)
+			mockNotif.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookingUsecase_GetCustomerSummary(t *testing.T) {
+	tests := []struct {
+		name      string
+		summary   *entity.CustomerSummary
+		repoErr   error
+		wantErr   error
+		wantLTV   float64
+		wantFlags []string
+	}{
+		{
+			name: "Success - Clean History",
+			summary: &entity.CustomerSummary{
+				UserID:          1,
+				Bookings:        entity.CustomerBookingCounts{Total: 4, Paid: 3, Refunded: 1},
+				TicketsAttended: 5,
+				NoShows:         1,
+				TotalSpent:      400000,
+				TotalRefunded:   100000,
+				Refunds:         1,
+			},
+			wantLTV:   300000,
+			wantFlags: []string{},
+		},
+		{
+			name: "Success - Risk Flags Raised",
+			summary: &entity.CustomerSummary{
+				UserID:          1,
+				Bookings:        entity.CustomerBookingCounts{Total: 13, Paid: 2, Expired: 7, Refunded: 4},
+				TicketsAttended: 1,
+				NoShows:         3,
+				TotalSpent:      600000.5,
+				TotalRefunded:   400000.25,
+				Refunds:         4,
+				FraudRefunds:    1,
+			},
+			wantLTV: 200000.25,
+			wantFlags: []string{
+				entity.CustomerFlagFraudRefund,
+				entity.CustomerFlagFrequentRefunds,
+				entity.CustomerFlagFrequentNoShows,
+				entity.CustomerFlagLapsedHolds,
+			},
+		},
+		{
+			name:    "Failed - Unknown User",
+			repoErr: entity.ErrNotFound,
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockBookingRepo)
+			mockRepo.On("GetCustomerSummary", mock.Anything, int64(1)).Return(tt.summary, tt.repoErr).Once()
+
+			u := usecase.NewBookingUsecase(mockRepo, new(mocks.MockTransactionRepo), newTxManager(), newCustomerRepo(), time.Second*2, new(mocks.MockNotificationService), newWebhookPublisher(), newDomainEvents(), new(mocks.MockPricingAssigner), usecase.BookingConflictPolicy{}, 0, usecase.GatewayOutagePolicy{}, clock.Real{})
+			summary, err := u.GetCustomerSummary(context.Background(), 1)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, summary)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantLTV, summary.LifetimeValue)
+				assert.Equal(t, tt.wantFlags, summary.Flags)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type CheckinUsecase interface {
+	CheckIn(ctx context.Context, eventID int64, code string, staffID int64) (*entity.Ticket, error)
+}
+
+type checkinUsecase struct {
+	ticketRepo     repository.TicketRepository
+	contextTimeout time.Duration
+}
+
+func NewCheckinUsecase(ticketRepo repository.TicketRepository, timeout time.Duration) CheckinUsecase {
+	return &checkinUsecase{
+		ticketRepo:     ticketRepo,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *checkinUsecase) CheckIn(ctx context.Context, eventID int64, code string, staffID int64) (*entity.Ticket, error) {
+	ctx, span := tracing.Start(ctx, "CheckinUsecase.CheckIn",
+		attribute.Int64("event_id", eventID),
+	)
+	defer span.End()
+
+	logger.FromContext(ctx).Debug("usecase: checking in ticket",
+		logger.Int64("event_id", eventID),
+		logger.Int64("staff_id", staffID),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	ticket, err := uc.ticketRepo.GetTicketByCode(ctx, code)
+	if err != nil {
+		logger.FromContext(ctx).Warn("usecase: ticket lookup failed", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+
+	if ticket.EventID != eventID {
+		logger.FromContext(ctx).Warn("usecase: ticket presented at wrong event",
+			logger.Int64("ticket_id", ticket.ID),
+			logger.Int64("ticket_event_id", ticket.EventID),
+			logger.Int64("event_id", eventID),
+		)
+		return nil, entity.ErrTicketWrongEvent
+	}
+
+	if ticket.BookingStatus != entity.BookingPaid {
+		logger.FromContext(ctx).Warn("usecase: ticket booking not paid",
+			logger.Int64("ticket_id", ticket.ID),
+			logger.String("booking_status", string(ticket.BookingStatus)),
+		)
+		return nil, entity.ErrTicketNotActive
+	}
+
+	if ticket.CheckedInAt != nil {
+		return ticket, entity.ErrTicketAlreadyUsed
+	}
+
+	checkedInAt, err := uc.ticketRepo.MarkCheckedIn(ctx, ticket.ID, staffID)
+	if err != nil {
+		return ticket, err
+	}
+
+	ticket.CheckedInAt = checkedInAt
+	ticket.CheckedInBy = &staffID
+
+	logger.FromContext(ctx).Info("usecase: ticket checked in",
+		logger.Int64("ticket_id", ticket.ID),
+		logger.Int64("event_id", eventID),
+		logger.Int64("staff_id", staffID),
+	)
+	return ticket, nil
+}
@@ -0,0 +1,119 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckinUsecase_CheckIn(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		eventID int64
+		code    string
+		mock    func(mockRepo *mocks.MockTicketRepo)
+		wantErr error
+	}{
+		{
+			name:    "Success Check-in",
+			eventID: 10,
+			code:    "TCK-abc",
+			mock: func(mockRepo *mocks.MockTicketRepo) {
+				mockRepo.On("GetTicketByCode", mock.Anything, "TCK-abc").
+					Return(&entity.Ticket{ID: 1, EventID: 10, Code: "TCK-abc", BookingStatus: "PAID"}, nil).Once()
+				mockRepo.On("MarkCheckedIn", mock.Anything, int64(1), int64(7)).
+					Return(&now, nil).Once()
+			},
+		},
+		{
+			name:    "Failed - Unknown Code",
+			eventID: 10,
+			code:    "TCK-missing",
+			mock: func(mockRepo *mocks.MockTicketRepo) {
+				mockRepo.On("GetTicketByCode", mock.Anything, "TCK-missing").
+					Return(nil, entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name:    "Failed - Revoked Code",
+			eventID: 10,
+			code:    "TCK-old",
+			mock: func(mockRepo *mocks.MockTicketRepo) {
+				mockRepo.On("GetTicketByCode", mock.Anything, "TCK-old").
+					Return(nil, entity.ErrTicketRevoked).Once()
+			},
+			wantErr: entity.ErrTicketRevoked,
+		},
+		{
+			name:    "Failed - Wrong Event",
+			eventID: 11,
+			code:    "TCK-abc",
+			mock: func(mockRepo *mocks.MockTicketRepo) {
+				mockRepo.On("GetTicketByCode", mock.Anything, "TCK-abc").
+					Return(&entity.Ticket{ID: 1, EventID: 10, BookingStatus: "PAID"}, nil).Once()
+			},
+			wantErr: entity.ErrTicketWrongEvent,
+		},
+		{
+			name:    "Failed - Booking Refunded",
+			eventID: 10,
+			code:    "TCK-abc",
+			mock: func(mockRepo *mocks.MockTicketRepo) {
+				mockRepo.On("GetTicketByCode", mock.Anything, "TCK-abc").
+					Return(&entity.Ticket{ID: 1, EventID: 10, BookingStatus: "REFUNDED"}, nil).Once()
+			},
+			wantErr: entity.ErrTicketNotActive,
+		},
+		{
+			name:    "Failed - Already Checked In",
+			eventID: 10,
+			code:    "TCK-abc",
+			mock: func(mockRepo *mocks.MockTicketRepo) {
+				mockRepo.On("GetTicketByCode", mock.Anything, "TCK-abc").
+					Return(&entity.Ticket{ID: 1, EventID: 10, BookingStatus: "PAID", CheckedInAt: &now}, nil).Once()
+			},
+			wantErr: entity.ErrTicketAlreadyUsed,
+		},
+		{
+			name:    "Failed - Concurrent Scan Wins Race",
+			eventID: 10,
+			code:    "TCK-abc",
+			mock: func(mockRepo *mocks.MockTicketRepo) {
+				mockRepo.On("GetTicketByCode", mock.Anything, "TCK-abc").
+					Return(&entity.Ticket{ID: 1, EventID: 10, BookingStatus: "PAID"}, nil).Once()
+				mockRepo.On("MarkCheckedIn", mock.Anything, int64(1), int64(7)).
+					Return(nil, entity.ErrTicketAlreadyUsed).Once()
+			},
+			wantErr: entity.ErrTicketAlreadyUsed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockTicketRepo)
+			tt.mock(mockRepo)
+
+			u := usecase.NewCheckinUsecase(mockRepo, time.Second*2)
+			ticket, err := u.CheckIn(context.Background(), tt.eventID, tt.code, 7)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, ticket.CheckedInAt)
+				assert.Equal(t, int64(7), *ticket.CheckedInBy)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
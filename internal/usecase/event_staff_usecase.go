@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type EventStaffUsecase interface {
+	// GrantAccess gives a staff account one scope on an event of the
+	// organizer.
+	GrantAccess(ctx context.Context, organizerID int64, grant *entity.EventStaff) error
+	RevokeAccess(ctx context.Context, organizerID, eventID, userID int64, scope string) error
+	ListEventStaff(ctx context.Context, organizerID, eventID int64) ([]entity.EventStaff, error)
+	// ListMyEvents returns the events a staff account was given access to.
+	ListMyEvents(ctx context.Context, staffID int64) ([]entity.EventStaff, error)
+	// AuthorizeStaff checks that a staff account holds scope on the event and
+	// returns the event's organizer, on whose behalf the staff acts. It fails
+	// with ErrEventAccessDenied otherwise.
+	AuthorizeStaff(ctx context.Context, eventID, staffID int64, scope string) (int64, error)
+}
+
+type eventStaffUsecase struct {
+	staffRepo      repository.EventStaffRepository
+	eventRepo      repository.EventRepository
+	auditor        AuditUsecase
+	contextTimeout time.Duration
+}
+
+func NewEventStaffUsecase(
+	staffRepo repository.EventStaffRepository,
+	eventRepo repository.EventRepository,
+	auditor AuditUsecase,
+	timeout time.Duration,
+) EventStaffUsecase {
+	return &eventStaffUsecase{
+		staffRepo:      staffRepo,
+		eventRepo:      eventRepo,
+		auditor:        auditor,
+		contextTimeout: timeout,
+	}
+}
+
+// ownEvent fails with ErrNotFound unless the organizer owns the event.
+func (uc *eventStaffUsecase) ownEvent(ctx context.Context, organizerID, eventID int64) error {
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return entity.ErrNotFound
+	}
+	// Organizers only manage their own events
+	if event.OrganizerID == nil || *event.OrganizerID != organizerID {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+func (uc *eventStaffUsecase) GrantAccess(ctx context.Context, organizerID int64, grant *entity.EventStaff) error {
+	ctx, span := tracing.Start(ctx, "EventStaffUsecase.GrantAccess",
+		attribute.Int64("event_id", grant.EventID),
+		attribute.Int64("user_id", grant.UserID),
+	)
+	defer span.End()
+
+	if !entity.IsStaffScope(grant.Scope) {
+		return entity.ErrInvalidStaffScope
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.ownEvent(ctx, organizerID, grant.EventID); err != nil {
+		return err
+	}
+
+	grant.GrantedBy = organizerID
+	if err := uc.staffRepo.GrantAccess(ctx, grant); err != nil {
+		logger.FromContext(ctx).Warn("usecase: failed to grant event staff access",
+			logger.Int64("event_id", grant.EventID),
+			logger.Int64("user_id", grant.UserID),
+			logger.Err(err),
+		)
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionEventStaffGrant, "event", grant.EventID, map[string]interface{}{
+		"user_id": grant.UserID,
+		"scope":   grant.Scope,
+	})
+
+	logger.FromContext(ctx).Info("usecase: event staff access granted",
+		logger.Int64("event_id", grant.EventID),
+		logger.Int64("user_id", grant.UserID),
+		logger.String("scope", grant.Scope),
+	)
+	return nil
+}
+
+func (uc *eventStaffUsecase) RevokeAccess(ctx context.Context, organizerID, eventID, userID int64, scope string) error {
+	ctx, span := tracing.Start(ctx, "EventStaffUsecase.RevokeAccess",
+		attribute.Int64("event_id", eventID),
+		attribute.Int64("user_id", userID),
+	)
+	defer span.End()
+
+	if !entity.IsStaffScope(scope) {
+		return entity.ErrInvalidStaffScope
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.ownEvent(ctx, organizerID, eventID); err != nil {
+		return err
+	}
+
+	if err := uc.staffRepo.RevokeAccess(ctx, eventID, userID, scope); err != nil {
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionEventStaffRevoke, "event", eventID, map[string]interface{}{
+		"user_id": userID,
+		"scope":   scope,
+	})
+
+	logger.FromContext(ctx).Info("usecase: event staff access revoked",
+		logger.Int64("event_id", eventID),
+		logger.Int64("user_id", userID),
+		logger.String("scope", scope),
+	)
+	return nil
+}
+
+func (uc *eventStaffUsecase) ListEventStaff(ctx context.Context, organizerID, eventID int64) ([]entity.EventStaff, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.ownEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+	return uc.staffRepo.ListEventStaff(ctx, eventID)
+}
+
+func (uc *eventStaffUsecase) ListMyEvents(ctx context.Context, staffID int64) ([]entity.EventStaff, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.staffRepo.ListStaffEvents(ctx, staffID)
+}
+
+func (uc *eventStaffUsecase) AuthorizeStaff(ctx context.Context, eventID, staffID int64, scope string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	ok, err := uc.staffRepo.HasAccess(ctx, eventID, staffID, scope)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		logger.FromContext(ctx).Warn("usecase: staff has no access to event",
+			logger.Int64("event_id", eventID),
+			logger.Int64("user_id", staffID),
+			logger.String("scope", scope),
+		)
+		return 0, entity.ErrEventAccessDenied
+	}
+
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return 0, err
+	}
+	if event.OrganizerID == nil {
+		return 0, entity.ErrEventAccessDenied
+	}
+	return *event.OrganizerID, nil
+}
@@ -0,0 +1,116 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEventStaffUsecase_GrantAccess(t *testing.T) {
+	organizerID := int64(3)
+	otherOrganizer := int64(4)
+	ownEvent := &entity.Event{ID: 10, OrganizerID: &organizerID}
+
+	tests := []struct {
+		name     string
+		scope    string
+		event    *entity.Event
+		repoErr  error
+		wantErr  error
+		wantRepo bool
+	}{
+		{name: "Success", scope: entity.StaffScopeCheckin, event: ownEvent, wantRepo: true},
+		{name: "Failed - Invalid Scope", scope: "refunds", event: ownEvent, wantErr: entity.ErrInvalidStaffScope},
+		{name: "Failed - Other Organizer's Event", scope: entity.StaffScopeReports, event: &entity.Event{ID: 10, OrganizerID: &otherOrganizer}, wantErr: entity.ErrNotFound},
+		{name: "Failed - Not A Staff Account", scope: entity.StaffScopeCheckin, event: ownEvent, repoErr: entity.ErrNotStaffAccount, wantErr: entity.ErrNotStaffAccount, wantRepo: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStaffRepo := new(mocks.MockEventStaffRepo)
+			mockEventRepo := new(mocks.MockEventRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+
+			mockEventRepo.On("GetEventByID", mock.Anything, int64(10)).Return(tt.event, nil).Maybe()
+			if tt.wantRepo {
+				mockStaffRepo.On("GrantAccess", mock.Anything, mock.MatchedBy(func(g *entity.EventStaff) bool {
+					return g.EventID == 10 && g.UserID == 7 && g.GrantedBy == organizerID
+				})).Return(tt.repoErr).Once()
+			}
+			if tt.wantErr == nil {
+				mockAudit.On("Record", mock.Anything, usecase.ActionEventStaffGrant, "event", int64(10),
+					map[string]interface{}{"user_id": int64(7), "scope": tt.scope}).Once()
+			}
+
+			u := usecase.NewEventStaffUsecase(mockStaffRepo, mockEventRepo, mockAudit, time.Second*2)
+			err := u.GrantAccess(context.Background(), organizerID, &entity.EventStaff{EventID: 10, UserID: 7, Scope: tt.scope})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tt.wantRepo {
+				mockStaffRepo.AssertNotCalled(t, "GrantAccess", mock.Anything, mock.Anything)
+			}
+			mockStaffRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventStaffUsecase_RevokeAccess(t *testing.T) {
+	organizerID := int64(3)
+	mockStaffRepo := new(mocks.MockEventStaffRepo)
+	mockEventRepo := new(mocks.MockEventRepo)
+	mockAudit := new(mocks.MockAuditUsecase)
+
+	mockEventRepo.On("GetEventByID", mock.Anything, int64(10)).Return(&entity.Event{ID: 10, OrganizerID: &organizerID}, nil)
+	mockStaffRepo.On("RevokeAccess", mock.Anything, int64(10), int64(7), entity.StaffScopeReports).Return(nil).Once()
+	mockAudit.On("Record", mock.Anything, usecase.ActionEventStaffRevoke, "event", int64(10),
+		map[string]interface{}{"user_id": int64(7), "scope": entity.StaffScopeReports}).Once()
+
+	u := usecase.NewEventStaffUsecase(mockStaffRepo, mockEventRepo, mockAudit, time.Second*2)
+	err := u.RevokeAccess(context.Background(), organizerID, 10, 7, entity.StaffScopeReports)
+
+	assert.NoError(t, err)
+	mockStaffRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestEventStaffUsecase_AuthorizeStaff(t *testing.T) {
+	organizerID := int64(3)
+
+	t.Run("Granted", func(t *testing.T) {
+		mockStaffRepo := new(mocks.MockEventStaffRepo)
+		mockEventRepo := new(mocks.MockEventRepo)
+		mockStaffRepo.On("HasAccess", mock.Anything, int64(10), int64(7), entity.StaffScopeCheckin).Return(true, nil).Once()
+		mockEventRepo.On("GetEventByID", mock.Anything, int64(10)).Return(&entity.Event{ID: 10, OrganizerID: &organizerID}, nil).Once()
+
+		u := usecase.NewEventStaffUsecase(mockStaffRepo, mockEventRepo, new(mocks.MockAuditUsecase), time.Second*2)
+		got, err := u.AuthorizeStaff(context.Background(), 10, 7, entity.StaffScopeCheckin)
+
+		assert.NoError(t, err)
+		assert.Equal(t, organizerID, got)
+	})
+
+	t.Run("Not Granted", func(t *testing.T) {
+		mockStaffRepo := new(mocks.MockEventStaffRepo)
+		mockEventRepo := new(mocks.MockEventRepo)
+		mockStaffRepo.On("HasAccess", mock.Anything, int64(10), int64(7), entity.StaffScopeReports).Return(false, nil).Once()
+
+		u := usecase.NewEventStaffUsecase(mockStaffRepo, mockEventRepo, new(mocks.MockAuditUsecase), time.Second*2)
+		_, err := u.AuthorizeStaff(context.Background(), 10, 7, entity.StaffScopeReports)
+
+		assert.ErrorIs(t, err, entity.ErrEventAccessDenied)
+		mockEventRepo.AssertNotCalled(t, "GetEventByID", mock.Anything, mock.Anything)
+	})
+}
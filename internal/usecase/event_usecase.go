@@ -2,148 +2,836 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
 	"time"
 
 	"ticres/internal/entity"
 	"ticres/internal/repository"
 	"ticres/pkg/logger"
+	"ticres/pkg/storage"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type EventUsecase interface {
+	// CreateEvent rejects an event starting sooner than the minimum lead
+	// time with an entity.ValidationError.
 	CreateEvent(ctx context.Context, event *entity.Event, ticketPrice float64) error
 	ListEvents(ctx context.Context) ([]entity.Event, error)
-	ListEventsWithSearch(ctx context.Context, search string, page, limit int) ([]entity.Event, int, error)
+	// ListEventsWithSearch returns one page of the events matching filter
+	// and the total number of matches.
+	ListEventsWithSearch(ctx context.Context, filter entity.EventFilter, page, limit int) ([]entity.Event, int, error)
+	// ListEventsByCursor returns the page of events matching filter after
+	// cursor, in the same order as ListEventsWithSearch. An empty cursor
+	// starts at the top; a malformed one is entity.ErrInvalidCursor.
+	ListEventsByCursor(ctx context.Context, filter entity.EventFilter, cursor string, limit int) (*entity.EventPage, error)
 	GetEventByID(ctx context.Context, eventID int64) (*entity.Event, error)
 	GetEventWithSeats(ctx context.Context, eventID int64) (*entity.EventWithSeats, error)
+	ListSeats(ctx context.Context, eventID, cursor int64, limit int, filter entity.SeatFilter) (*entity.SeatPage, error)
+	ListSections(ctx context.Context, eventID int64) ([]entity.SectionAvailability, error)
+	// GetEventCapacity reports sold, held and available tickets for the
+	// event, broken down by section for seated events.
+	GetEventCapacity(ctx context.Context, eventID int64) (*entity.EventCapacity, error)
+	// GetPublicAvailability returns the ticket count and price range shown
+	// to aggregators. The figures may be up to 30 seconds old.
+	GetPublicAvailability(ctx context.Context, eventID int64) (*entity.PublicAvailability, error)
+	// StreamSeats passes every seat of the event to fn. The stream is bounded
+	// by ctx only, since a full stadium map can outlast the usecase timeout.
+	StreamSeats(ctx context.Context, eventID int64, filter entity.SeatFilter, fn func(entity.Seat) error) error
+	// SubscribeSeatUpdates follows seats of the event being booked and
+	// released until ctx is done.
+	SubscribeSeatUpdates(ctx context.Context, eventID int64) (<-chan entity.SeatUpdate, error)
+	// EditEvent applies the same lead time as CreateEvent when the date
+	// changes.
 	EditEvent(ctx context.Context, event *entity.Event, prev int64) error
+	// CancelEvent deletes the event, hiding it from buyers, and refunds or
+	// cancels its bookings in the background. A deleted event is
+	// entity.ErrNotFound.
 	CancelEvent(ctx context.Context, eventID int64) error
+	// RestoreEvent lists a deleted event again with the status it had
+	// before. Its bookings stay refunded.
+	RestoreEvent(ctx context.Context, eventID int64) error
+	// PreviewCancellation reports the bookings CancelEvent would refund or
+	// cancel, without changing anything.
+	PreviewCancellation(ctx context.Context, eventID int64) (*entity.DryRunResult, error)
+	// SetTicketLimit caps the tickets one user may hold for the event; nil
+	// falls back to the configured default.
+	SetTicketLimit(ctx context.Context, eventID int64, limit *int) error
+	// SetBookingRateLimit sets how many booking requests per second the
+	// event takes; nil falls back to the default budget.
+	SetBookingRateLimit(ctx context.Context, eventID int64, limit *int) error
+	// GetSalesWaves returns the event's release schedule and how much of
+	// it is on sale now.
+	GetSalesWaves(ctx context.Context, eventID int64) (*entity.SalesWaveSchedule, error)
+	// SetSalesWaves replaces the waves the event's tickets go on sale in;
+	// an empty schedule puts them all on sale.
+	SetSalesWaves(ctx context.Context, eventID int64, waves []entity.SalesWave) (*entity.SalesWaveSchedule, error)
+	// SetRefundPolicy sets the refund policy of an event owned by the
+	// organizer; nil removes it, refunding in full again. Requests already
+	// filed keep the share they were filed for.
+	SetRefundPolicy(ctx context.Context, eventID, organizerID int64, policy *entity.RefundPolicy) error
+	// UpdateEventContent replaces the FAQ, door time, prohibited items and
+	// description blocks of an event owned by the organizer.
+	UpdateEventContent(ctx context.Context, eventID, organizerID int64, content *entity.EventContent) error
+	// UpdateSeatLayout places the event's seats on the seat map.
+	UpdateSeatLayout(ctx context.Context, eventID int64, layout *entity.SeatLayout) error
+	// UploadEventImage stores the event's poster, replacing any previous one,
+	// and returns its URL. With a non-nil organizerID the event must be the
+	// organizer's.
+	UploadEventImage(ctx context.Context, eventID int64, organizerID *int64, contentType string, size int64, content io.Reader) (string, error)
 }
 
+// MaxEventImageSize is the largest event poster that may be uploaded.
+const MaxEventImageSize = 5 << 20
+
+// EventImagePrefix is the storage prefix of event posters. Everything under
+// it is public.
+const EventImagePrefix = "posters"
+
 type eventUsecase struct {
 	eventRepo      repository.EventRepository
+	txManager      repository.TxManager
 	contextTimeout time.Duration
 	worker			NotificationService
+	events         DomainEventPublisher
+	auditor        AuditUsecase
+	store          storage.Storage
+	minLeadTime    time.Duration
 }
 
-func NewEventUsecase(repo repository.EventRepository, timeout time.Duration, worker NotificationService) EventUsecase {
-	return &eventUsecase{eventRepo: repo, contextTimeout: timeout, worker: worker}
+// NewEventUsecase builds the event usecase. New and rescheduled events must
+// start at least minLeadTime from now; zero only requires a future date.
+func NewEventUsecase(repo repository.EventRepository, txManager repository.TxManager, timeout time.Duration, worker NotificationService, events DomainEventPublisher, auditor AuditUsecase, store storage.Storage, minLeadTime time.Duration) EventUsecase {
+	return &eventUsecase{eventRepo: repo, txManager: txManager, contextTimeout: timeout, worker: worker, events: events, auditor: auditor, store: store, minLeadTime: minLeadTime}
+}
+
+// checkEventDate rejects a start date sooner than the minimum lead time.
+func (uc *eventUsecase) checkEventDate(date time.Time) error {
+	earliest := time.Now().Add(uc.minLeadTime)
+	if date.After(earliest) {
+		return nil
+	}
+	invalid := &entity.ValidationError{}
+	if uc.minLeadTime > 0 {
+		invalid.Add("date", "must be at least %s from now", uc.minLeadTime.String())
+	} else {
+		invalid.Add("date", "must be in the future")
+	}
+	return invalid
 }
 
 func (uc *eventUsecase) CreateEvent(ctx context.Context, event *entity.Event, ticketPrice float64) error {
-	logger.Debug("usecase: creating event", logger.String("name", event.Name))
+	ctx, span := tracing.Start(ctx, "EventUsecase.CreateEvent",
+		attribute.Int("capacity", event.Capacity),
+	)
+	defer span.End()
+
+	logger.FromContext(ctx).Debug("usecase: creating event", logger.String("name", event.Name))
+
+	if err := event.SeatNumbering.Validate(event.Capacity); err != nil {
+		return err
+	}
+	if event.IsGeneralAdmission() && event.SeatNumbering != nil {
+		return fmt.Errorf("%w: general admission events have no seats", entity.ErrInvalidSeatNumbering)
+	}
+	if err := uc.checkEventDate(event.Date); err != nil {
+		return err
+	}
+	if err := validateEventDetails(event); err != nil {
+		return err
+	}
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
 	err := uc.eventRepo.CreateEvent(ctx, event, ticketPrice)
 	if err != nil {
-		logger.Error("usecase: failed to create event", logger.Err(err))
+		logger.FromContext(ctx).Error("usecase: failed to create event", logger.Err(err))
 		return err
 	}
 
-	logger.Info("usecase: event created", logger.Int64("event_id", event.ID))
+	uc.events.PublishDomainEvent(ctx, entity.DomainEventEventCreated, event.ID, eventDomainData(event))
+	logger.FromContext(ctx).Info("usecase: event created", logger.Int64("event_id", event.ID))
 	return nil
 }
 
 func (uc *eventUsecase) ListEvents(ctx context.Context) ([]entity.Event, error) {
-	logger.Debug("usecase: listing all events")
+	logger.FromContext(ctx).Debug("usecase: listing all events")
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
 	events, err := uc.eventRepo.GetAllEvents(ctx)
 	if err != nil {
-		logger.Error("usecase: failed to list events", logger.Err(err))
+		logger.FromContext(ctx).Error("usecase: failed to list events", logger.Err(err))
 		return nil, err
 	}
 
-	logger.Debug("usecase: events listed", logger.Int("count", len(events)))
+	logger.FromContext(ctx).Debug("usecase: events listed", logger.Int("count", len(events)))
 	return events, nil
 }
 
-func (uc *eventUsecase) ListEventsWithSearch(ctx context.Context, search string, page, limit int) ([]entity.Event, int, error) {
-	logger.Debug("usecase: listing events with search",
-		logger.String("search", search),
+func (uc *eventUsecase) ListEventsWithSearch(ctx context.Context, filter entity.EventFilter, page, limit int) ([]entity.Event, int, error) {
+	logger.FromContext(ctx).Debug("usecase: listing events with search",
+		logger.String("search", filter.Search),
 		logger.Int("page", page),
 		logger.Int("limit", limit),
 	)
 
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
-	events, total, err := uc.eventRepo.GetEventsWithSearch(ctx, search, page, limit)
+	events, total, err := uc.eventRepo.GetEventsWithSearch(ctx, filter, page, limit)
 	if err != nil {
-		logger.Error("usecase: failed to search events", logger.Err(err))
+		logger.FromContext(ctx).Error("usecase: failed to search events", logger.Err(err))
 		return nil, 0, err
 	}
 
-	logger.Debug("usecase: events search completed", logger.Int("total", total))
+	logger.FromContext(ctx).Debug("usecase: events search completed", logger.Int("total", total))
 	return events, total, nil
 }
 
+func (uc *eventUsecase) ListEventsByCursor(ctx context.Context, filter entity.EventFilter, cursor string, limit int) (*entity.EventPage, error) {
+	logger.FromContext(ctx).Debug("usecase: listing events by cursor",
+		logger.String("search", filter.Search),
+		logger.Int("limit", limit),
+	)
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	after, err := entity.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	events, next, err := uc.eventRepo.GetEventsAfter(ctx, filter, after, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to page events", logger.Err(err))
+		return nil, err
+	}
+
+	page := &entity.EventPage{Events: events}
+	if next != nil {
+		page.NextCursor = next.Encode()
+		page.HasMore = true
+	}
+	return page, nil
+}
+
 func (uc *eventUsecase) GetEventByID(ctx context.Context, eventID int64) (*entity.Event, error) {
-	logger.Debug("usecase: getting event by ID", logger.Int64("event_id", eventID))
+	logger.FromContext(ctx).Debug("usecase: getting event by ID", logger.Int64("event_id", eventID))
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
 	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
 	if err != nil {
-		logger.Warn("usecase: event not found", logger.Int64("event_id", eventID), logger.Err(err))
+		logger.FromContext(ctx).Warn("usecase: event not found", logger.Int64("event_id", eventID), logger.Err(err))
 		return nil, err
 	}
 
 	return event, nil
 }
 
+// getListedEvent looks up an event buyers can see. A deleted event is
+// entity.ErrNotFound, like one that never existed.
+func (uc *eventUsecase) getListedEvent(ctx context.Context, eventID int64) (*entity.Event, error) {
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil || event.IsDeleted() {
+		return nil, entity.ErrNotFound
+	}
+	return event, nil
+}
+
 func (uc *eventUsecase) GetEventWithSeats(ctx context.Context, eventID int64) (*entity.EventWithSeats, error) {
-	logger.Debug("usecase: getting event with seats", logger.Int64("event_id", eventID))
+	logger.FromContext(ctx).Debug("usecase: getting event with seats", logger.Int64("event_id", eventID))
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
 	eventWithSeats, err := uc.eventRepo.GetEventWithSeats(ctx, eventID)
 	if err != nil {
-		logger.Warn("usecase: event with seats not found", logger.Int64("event_id", eventID), logger.Err(err))
+		logger.FromContext(ctx).Warn("usecase: event with seats not found", logger.Int64("event_id", eventID), logger.Err(err))
 		return nil, err
 	}
+	if eventWithSeats.Event.IsDeleted() {
+		return nil, entity.ErrNotFound
+	}
 
 	return eventWithSeats, nil
 }
 
+func (uc *eventUsecase) ListSeats(ctx context.Context, eventID, cursor int64, limit int, filter entity.SeatFilter) (*entity.SeatPage, error) {
+	logger.FromContext(ctx).Debug("usecase: listing seats",
+		logger.Int64("event_id", eventID),
+		logger.Int64("cursor", cursor),
+		logger.Int("limit", limit),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.getListedEvent(ctx, eventID); err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	// One extra row tells whether another page exists
+	seats, err := uc.eventRepo.GetSeatsPage(ctx, eventID, cursor, limit+1, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to list seats", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+
+	page := &entity.SeatPage{Seats: seats}
+	if len(seats) > limit {
+		page.Seats = seats[:limit]
+		page.HasMore = true
+		page.NextCursor = page.Seats[limit-1].ID
+	}
+
+	if hasSections(page.Seats) {
+		imageIDs, err := uc.eventRepo.GetSectionImageIDs(ctx, eventID)
+		if err != nil {
+			// Images are decoration; the seat page is still useful without them
+			logger.FromContext(ctx).Warn("usecase: failed to load section images", logger.Int64("event_id", eventID), logger.Err(err))
+		}
+		for _, seat := range page.Seats {
+			if id, ok := imageIDs[seat.Category]; ok {
+				if page.SectionImages == nil {
+					page.SectionImages = make(map[string]string)
+				}
+				page.SectionImages[seat.Category] = SectionImageURL(id)
+			}
+		}
+	}
+	return page, nil
+}
+
+func (uc *eventUsecase) StreamSeats(ctx context.Context, eventID int64, filter entity.SeatFilter, fn func(entity.Seat) error) error {
+	ctx, span := tracing.Start(ctx, "EventUsecase.StreamSeats",
+		attribute.Int64("event_id", eventID),
+	)
+	defer span.End()
+
+	logger.FromContext(ctx).Debug("usecase: streaming seats", logger.Int64("event_id", eventID))
+
+	lookupCtx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	_, err := uc.getListedEvent(lookupCtx, eventID)
+	cancel()
+	if err != nil {
+		return entity.ErrNotFound
+	}
+
+	return uc.eventRepo.StreamSeats(ctx, eventID, filter, fn)
+}
+
+func (uc *eventUsecase) SubscribeSeatUpdates(ctx context.Context, eventID int64) (<-chan entity.SeatUpdate, error) {
+	logger.FromContext(ctx).Debug("usecase: subscribing to seat updates", logger.Int64("event_id", eventID))
+
+	lookupCtx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.getListedEvent(lookupCtx, eventID); err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	// The subscription lives as long as the client stays connected
+	return uc.eventRepo.SubscribeSeatUpdates(ctx, eventID)
+}
+
+func (uc *eventUsecase) ListSections(ctx context.Context, eventID int64) ([]entity.SectionAvailability, error) {
+	logger.FromContext(ctx).Debug("usecase: listing sections", logger.Int64("event_id", eventID))
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.getListedEvent(ctx, eventID); err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	sections, err := uc.eventRepo.GetSectionAvailability(ctx, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to list sections", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	for i := range sections {
+		if sections[i].ImageID != 0 {
+			sections[i].ImageURL = SectionImageURL(sections[i].ImageID)
+		}
+	}
+	return sections, nil
+}
+
+func (uc *eventUsecase) GetPublicAvailability(ctx context.Context, eventID int64) (*entity.PublicAvailability, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	availability, err := uc.eventRepo.GetPublicAvailability(ctx, eventID)
+	if err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Error("usecase: failed to get public availability", logger.Int64("event_id", eventID), logger.Err(err))
+		}
+		return nil, err
+	}
+	return availability, nil
+}
+
+func (uc *eventUsecase) GetEventCapacity(ctx context.Context, eventID int64) (*entity.EventCapacity, error) {
+	ctx, span := tracing.Start(ctx, "EventUsecase.GetEventCapacity",
+		attribute.Int64("event_id", eventID),
+	)
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	capacity, err := uc.eventRepo.GetEventCapacity(ctx, eventID)
+	if err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Error("usecase: failed to get event capacity", logger.Int64("event_id", eventID), logger.Err(err))
+		}
+		return nil, err
+	}
+	if capacity.AdmissionMode == entity.AdmissionGeneral {
+		return capacity, nil
+	}
+
+	capacity.Sections, err = uc.eventRepo.GetSectionAvailability(ctx, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to get section capacity", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	return capacity, nil
+}
+
 func (uc *eventUsecase) EditEvent(ctx context.Context, event *entity.Event, prev int64) error {
-	logger.Debug("usecase: editing event",
+	ctx, span := tracing.Start(ctx, "EventUsecase.EditEvent",
+		attribute.Int64("event_id", event.ID),
+	)
+	defer span.End()
+
+	logger.FromContext(ctx).Debug("usecase: editing event",
 		logger.Int64("event_id", event.ID),
 		logger.Int64("prev_capacity", prev),
 	)
 
+	// Only seats added by a capacity increase are numbered, but the whole
+	// range must still fit the scheme and stay unique
+	if int64(event.Capacity) > prev {
+		if err := event.SeatNumbering.Validate(event.Capacity); err != nil {
+			return err
+		}
+	}
+	if err := validateEventDetails(event); err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
-	err := uc.eventRepo.UpdateEvent(ctx, event, prev)
+	current, err := uc.eventRepo.GetEventByID(ctx, event.ID)
 	if err != nil {
-		logger.Error("usecase: failed to edit event", logger.Int64("event_id", event.ID), logger.Err(err))
 		return err
 	}
+	// Events close to or past their date can still be edited as long as the
+	// date itself is left alone
+	if dateErr := uc.checkEventDate(event.Date); dateErr != nil && !current.Date.Equal(event.Date) {
+		return dateErr
+	}
 
-	logger.Info("usecase: event edited", logger.Int64("event_id", event.ID))
+	err = uc.eventRepo.UpdateEvent(ctx, event, prev)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to edit event", logger.Int64("event_id", event.ID), logger.Err(err))
+		return err
+	}
+
+	uc.auditor.RecordChange(ctx, ActionEventUpdate, "event", event.ID, eventAuditState(current), eventAuditState(event))
+	uc.events.PublishDomainEvent(ctx, entity.DomainEventEventUpdated, event.ID, eventDomainData(event))
+	logger.FromContext(ctx).Info("usecase: event edited", logger.Int64("event_id", event.ID))
+	return nil
+}
+
+// eventAuditState is the part of an event admins edit, as kept in the
+// audit log.
+func eventAuditState(e *entity.Event) map[string]interface{} {
+	return map[string]interface{}{
+		"name":           e.Name,
+		"location":       e.Location,
+		"date":           e.Date,
+		"capacity":       e.Capacity,
+		"category":       e.Category,
+		"description":    e.Description,
+		"terms":          e.Terms,
+		"organizer_name": e.OrganizerName,
+		"content":        e.Content,
+		"metadata":       e.Metadata,
+	}
+}
+
+// eventDomainData is what consumers of the event's domain events are told
+// about it.
+func eventDomainData(e *entity.Event) map[string]interface{} {
+	return map[string]interface{}{
+		"name":         e.Name,
+		"location":     e.Location,
+		"date":         e.Date,
+		"capacity":     e.Capacity,
+		"category":     e.Category,
+		"organizer_id": e.OrganizerID,
+	}
+}
+
+// validateEventDetails checks the metadata and, since the date may have
+// moved, that the doors still open before the event starts.
+func validateEventDetails(event *entity.Event) error {
+	if err := event.ValidateMetadata(); err != nil {
+		return err
+	}
+	if event.Content != nil {
+		return event.Content.Validate(event.Date)
+	}
 	return nil
 }
 
 func (uc *eventUsecase) CancelEvent(ctx context.Context, eventID int64) error {
-	logger.Info("usecase: cancelling event", logger.Int64("event_id", eventID))
+	ctx, span := tracing.Start(ctx, "EventUsecase.CancelEvent",
+		attribute.Int64("event_id", eventID),
+	)
+	defer span.End()
+
+	logger.FromContext(ctx).Info("usecase: cancelling event", logger.Int64("event_id", eventID))
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
-	err := uc.eventRepo.UpdateEventStatus(ctx, eventID, "CANCELLED")
+	// The refund job commits with the cancellation, so a crash in between
+	// can't leave a cancelled event nobody is refunded for
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.eventRepo.DeleteEvent(ctx, eventID); err != nil {
+			return err
+		}
+		return uc.worker.EnqueueCancellation(ctx, eventID)
+	})
 	if err != nil {
-		logger.Error("usecase: failed to cancel event", logger.Int64("event_id", eventID), logger.Err(err))
+		if !errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Error("usecase: failed to cancel event", logger.Int64("event_id", eventID), logger.Err(err))
+		}
 		return err
 	}
 
-	uc.worker.EnqueueCancellation(eventID)
-	logger.Info("usecase: event cancelled, refund process enqueued", logger.Int64("event_id", eventID))
+	uc.auditor.Record(ctx, ActionEventCancel, "event", eventID, map[string]interface{}{"status": entity.EventStatusCancelled})
+	uc.events.PublishDomainEvent(ctx, entity.DomainEventEventCancelled, eventID, nil)
+	logger.FromContext(ctx).Info("usecase: event cancelled, refund process enqueued", logger.Int64("event_id", eventID))
 
 	return nil
-}
\ No newline at end of file
+}
+
+func (uc *eventUsecase) RestoreEvent(ctx context.Context, eventID int64) error {
+	logger.FromContext(ctx).Info("usecase: restoring event", logger.Int64("event_id", eventID))
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.eventRepo.RestoreEvent(ctx, eventID); err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Error("usecase: failed to restore event", logger.Int64("event_id", eventID), logger.Err(err))
+		}
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionEventRestore, "event", eventID, nil)
+	uc.events.PublishDomainEvent(ctx, entity.DomainEventEventRestored, eventID, nil)
+	return nil
+}
+
+func (uc *eventUsecase) PreviewCancellation(ctx context.Context, eventID int64) (*entity.DryRunResult, error) {
+	ctx, span := tracing.Start(ctx, "EventUsecase.PreviewCancellation",
+		attribute.Int64("event_id", eventID),
+	)
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.eventRepo.GetEventByID(ctx, eventID); err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	bookings, err := uc.eventRepo.GetCancellableBookings(ctx, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to preview event cancellation", logger.Int64("event_id", eventID), logger.Err(err))
+		return nil, err
+	}
+	// Mirrors the refund job: PAID bookings are refunded, PENDING ones cancelled
+	for i := range bookings {
+		if bookings[i].Status == entity.BookingPaid {
+			bookings[i].Action = entity.DryRunRefund
+		} else {
+			bookings[i].Action = entity.DryRunCancel
+		}
+	}
+
+	result := entity.NewDryRunResult(bookings)
+	logger.FromContext(ctx).Info("usecase: event cancellation previewed",
+		logger.Int64("event_id", eventID),
+		logger.Int("refunded", result.Refunded),
+		logger.Int("cancelled", result.Cancelled),
+	)
+	return result, nil
+}
+
+func (uc *eventUsecase) SetTicketLimit(ctx context.Context, eventID int64, limit *int) error {
+	logger.FromContext(ctx).Debug("usecase: setting event ticket limit", logger.Int64("event_id", eventID))
+
+	if limit != nil && *limit < 1 {
+		return fmt.Errorf("%w: must be at least 1", entity.ErrInvalidTicketLimit)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.eventRepo.SetTicketLimit(ctx, eventID, limit); err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Error("usecase: failed to set event ticket limit", logger.Int64("event_id", eventID), logger.Err(err))
+		}
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionEventTicketLimit, "event", eventID, map[string]interface{}{"max_tickets_per_user": limit})
+	return nil
+}
+
+func (uc *eventUsecase) SetBookingRateLimit(ctx context.Context, eventID int64, limit *int) error {
+	logger.FromContext(ctx).Debug("usecase: setting event booking rate limit", logger.Int64("event_id", eventID))
+
+	if limit != nil && *limit < 1 {
+		return fmt.Errorf("%w: must be at least 1", entity.ErrInvalidBookingRateLimit)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.eventRepo.SetBookingRateLimit(ctx, eventID, limit); err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Error("usecase: failed to set event booking rate limit", logger.Int64("event_id", eventID), logger.Err(err))
+		}
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionEventBookingRateLimit, "event", eventID, map[string]interface{}{"booking_rate_limit": limit})
+	return nil
+}
+
+func (uc *eventUsecase) GetSalesWaves(ctx context.Context, eventID int64) (*entity.SalesWaveSchedule, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	schedule, err := uc.eventRepo.GetSalesWaves(ctx, eventID)
+	if err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Error("usecase: failed to get sales waves", logger.Int64("event_id", eventID), logger.Err(err))
+		}
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (uc *eventUsecase) SetSalesWaves(ctx context.Context, eventID int64, waves []entity.SalesWave) (*entity.SalesWaveSchedule, error) {
+	ctx, span := tracing.Start(ctx, "EventUsecase.SetSalesWaves",
+		attribute.Int64("event_id", eventID),
+		attribute.Int("waves", len(waves)),
+	)
+	defer span.End()
+
+	if err := entity.ValidateSalesWaves(waves); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.eventRepo.ReplaceSalesWaves(ctx, eventID, waves); err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Error("usecase: failed to set sales waves", logger.Int64("event_id", eventID), logger.Err(err))
+		}
+		return nil, err
+	}
+
+	total := 0
+	for _, w := range waves {
+		total += w.Quantity
+	}
+	uc.auditor.Record(ctx, ActionEventSalesWaves, "event", eventID, map[string]interface{}{
+		"waves":    len(waves),
+		"quantity": total,
+	})
+
+	return uc.eventRepo.GetSalesWaves(ctx, eventID)
+}
+
+func (uc *eventUsecase) UpdateEventContent(ctx context.Context, eventID, organizerID int64, content *entity.EventContent) error {
+	logger.FromContext(ctx).Debug("usecase: updating event content",
+		logger.Int64("event_id", eventID),
+		logger.Int64("organizer_id", organizerID),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return entity.ErrNotFound
+	}
+	// Organizers only manage their own events
+	if event.OrganizerID == nil || *event.OrganizerID != organizerID {
+		return entity.ErrNotFound
+	}
+
+	if err := content.Validate(event.Date); err != nil {
+		return err
+	}
+
+	return uc.eventRepo.UpdateEventContent(ctx, eventID, content)
+}
+
+func (uc *eventUsecase) SetRefundPolicy(ctx context.Context, eventID, organizerID int64, policy *entity.RefundPolicy) error {
+	logger.FromContext(ctx).Debug("usecase: setting event refund policy",
+		logger.Int64("event_id", eventID),
+		logger.Int64("organizer_id", organizerID),
+	)
+
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return entity.ErrNotFound
+	}
+	// Organizers only manage their own events
+	if event.OrganizerID == nil || *event.OrganizerID != organizerID {
+		return entity.ErrNotFound
+	}
+
+	if err := uc.eventRepo.SetRefundPolicy(ctx, eventID, policy); err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Error("usecase: failed to set event refund policy", logger.Int64("event_id", eventID), logger.Err(err))
+		}
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionEventRefundPolicy, "event", eventID, map[string]interface{}{"refund_policy": policy})
+	return nil
+}
+
+func (uc *eventUsecase) UpdateSeatLayout(ctx context.Context, eventID int64, layout *entity.SeatLayout) error {
+	ctx, span := tracing.Start(ctx, "EventUsecase.UpdateSeatLayout",
+		attribute.Int64("event_id", eventID),
+	)
+	defer span.End()
+
+	if err := layout.Validate(); err != nil {
+		logger.FromContext(ctx).Warn("usecase: invalid seat layout", logger.Int64("event_id", eventID), logger.Err(err))
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.eventRepo.GetEventByID(ctx, eventID); err != nil {
+		return entity.ErrNotFound
+	}
+
+	if err := uc.eventRepo.UpdateSeatLayout(ctx, eventID, layout); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("usecase: seat layout updated",
+		logger.Int64("event_id", eventID),
+		logger.Int("seats", len(layout.Seats)),
+	)
+	return nil
+}
+
+func hasSections(seats []entity.Seat) bool {
+	for _, seat := range seats {
+		if seat.Category != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (uc *eventUsecase) UploadEventImage(ctx context.Context, eventID int64, organizerID *int64, contentType string, size int64, content io.Reader) (string, error) {
+	ctx, span := tracing.Start(ctx, "EventUsecase.UploadEventImage", attribute.Int64("event_id", eventID))
+	defer span.End()
+
+	ext, ok := imageExtensions[contentType]
+	if !ok || size <= 0 || size > MaxEventImageSize {
+		logger.FromContext(ctx).Warn("usecase: invalid event image",
+			logger.String("content_type", contentType),
+			logger.Int64("size", size),
+		)
+		return "", entity.ErrInvalidEventImage
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return "", entity.ErrNotFound
+	}
+	// Organizers only manage their own events
+	if organizerID != nil && (event.OrganizerID == nil || *event.OrganizerID != *organizerID) {
+		return "", entity.ErrNotFound
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to generate event image key", logger.Err(err))
+		return "", err
+	}
+	key := fmt.Sprintf("%s/%d/%s%s", EventImagePrefix, eventID, hex.EncodeToString(buf), ext)
+
+	if err := uc.store.Put(ctx, key, content, contentType); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to store event image", logger.Int64("event_id", eventID), logger.Err(err))
+		return "", err
+	}
+
+	url := uc.store.URL(key)
+	oldKey, err := uc.eventRepo.SetEventImage(ctx, eventID, key, url)
+	if err != nil {
+		// Don't leave an orphaned file behind when the update fails
+		if delErr := uc.store.Delete(context.Background(), key); delErr != nil {
+			logger.FromContext(ctx).Warn("usecase: failed to clean up event image", logger.String("key", key), logger.Err(delErr))
+		}
+		return "", err
+	}
+	if oldKey != "" {
+		if err := uc.store.Delete(ctx, oldKey); err != nil {
+			logger.FromContext(ctx).Warn("usecase: failed to delete replaced event image", logger.String("key", oldKey), logger.Err(err))
+		}
+	}
+
+	logger.FromContext(ctx).Info("usecase: event image uploaded", logger.Int64("event_id", eventID), logger.String("key", key))
+	return url, nil
+}
@@ -3,6 +3,8 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +17,8 @@ import (
 )
 
 func TestEventUsecase_CreateEvent(t *testing.T) {
+	future := time.Now().Add(30 * 24 * time.Hour)
+
 	tests := []struct {
 		name        string
 		input       *entity.Event
@@ -24,16 +28,64 @@ func TestEventUsecase_CreateEvent(t *testing.T) {
 	}{
 		{
 			name:        "Success Create Event",
-			input:       &entity.Event{Name: "Konser Coldplay", Capacity: 1000},
+			input:       &entity.Event{Date: future, Name: "Konser Coldplay", Capacity: 1000},
 			ticketPrice: 150000,
 			mock: func(mockRepo *mocks.MockEventRepo) {
 				mockRepo.On("CreateEvent", mock.Anything, mock.AnythingOfType("*entity.Event"), float64(150000)).Return(nil).Once()
 			},
 			wantErr: false,
 		},
+		{
+			name: "Success Create Event - Row Numbering",
+			input: &entity.Event{Date: future, Name: "Teater A", Capacity: 60, SeatNumbering: &entity.SeatNumbering{
+				Scheme: entity.SeatSchemeRows, SeatsPerRow: 20,
+			}},
+			ticketPrice: 75000,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("CreateEvent", mock.Anything, mock.AnythingOfType("*entity.Event"), float64(75000)).Return(nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed Create Event - Capacity Exceeds Sections",
+			input: &entity.Event{Date: future, Name: "Konser C", Capacity: 100, SeatNumbering: &entity.SeatNumbering{
+				Scheme:   entity.SeatSchemeSections,
+				Sections: []entity.SeatSection{{Name: "VIP", Rows: 2, SeatsPerRow: 10}, {Name: "REG", Rows: 5, SeatsPerRow: 10}},
+			}},
+			ticketPrice: 50000,
+			mock:        func(mockRepo *mocks.MockEventRepo) {},
+			wantErr:     true,
+		},
+		{
+			name: "Failed Create Event - Format Produces Duplicates",
+			input: &entity.Event{Date: future, Name: "Konser D", Capacity: 40, SeatNumbering: &entity.SeatNumbering{
+				Scheme: entity.SeatSchemeRows, SeatsPerRow: 20, Format: "{row}",
+			}},
+			ticketPrice: 50000,
+			mock:        func(mockRepo *mocks.MockEventRepo) {},
+			wantErr:     true,
+		},
+		{
+			name:        "Success Create Event - General Admission",
+			input:       &entity.Event{Date: future, Name: "Festival Musik", Capacity: 5000, AdmissionMode: entity.AdmissionGeneral},
+			ticketPrice: 250000,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("CreateEvent", mock.Anything, mock.AnythingOfType("*entity.Event"), float64(250000)).Return(nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed Create Event - General Admission With Seat Numbering",
+			input: &entity.Event{Date: future, Name: "Festival Musik", Capacity: 100, AdmissionMode: entity.AdmissionGeneral, SeatNumbering: &entity.SeatNumbering{
+				Scheme: entity.SeatSchemeSequential,
+			}},
+			ticketPrice: 250000,
+			mock:        func(mockRepo *mocks.MockEventRepo) {},
+			wantErr:     true,
+		},
 		{
 			name:        "Failed Create Event - DB Error",
-			input:       &entity.Event{Name: "Konser B", Capacity: 100},
+			input:       &entity.Event{Date: future, Name: "Konser B", Capacity: 100},
 			ticketPrice: 50000,
 			mock: func(mockRepo *mocks.MockEventRepo) {
 				mockRepo.On("CreateEvent", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
@@ -49,7 +101,7 @@ func TestEventUsecase_CreateEvent(t *testing.T) {
 
 			tt.mock(mockRepo)
 
-			u := usecase.NewEventUsecase(mockRepo, time.Second*2, mockNotif)
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, mockNotif, newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
 			err := u.CreateEvent(context.Background(), tt.input, tt.ticketPrice)
 
 			if tt.wantErr {
@@ -107,7 +159,7 @@ func TestEventUsecase_ListEvents(t *testing.T) {
 
 			tt.mock(mockRepo)
 
-			u := usecase.NewEventUsecase(mockRepo, time.Second*2, mockNotif)
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, mockNotif, newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
 			events, err := u.ListEvents(context.Background())
 
 			if tt.wantErr {
@@ -127,10 +179,11 @@ func TestEventUsecase_ListEventsWithSearch(t *testing.T) {
 		{ID: 1, Name: "Konser Coldplay", Location: "Jakarta", Capacity: 1000},
 		{ID: 2, Name: "Konser Westlife", Location: "Bandung", Capacity: 500},
 	}
+	minPrice, maxPrice := 100000.0, 500000.0
 
 	tests := []struct {
 		name       string
-		search     string
+		filter     entity.EventFilter
 		page       int
 		limit      int
 		mock       func(mockRepo *mocks.MockEventRepo)
@@ -140,11 +193,11 @@ func TestEventUsecase_ListEventsWithSearch(t *testing.T) {
 	}{
 		{
 			name:   "Success - Search with Results",
-			search: "Konser",
+			filter: entity.EventFilter{Search: "Konser"},
 			page:   1,
 			limit:  10,
 			mock: func(mockRepo *mocks.MockEventRepo) {
-				mockRepo.On("GetEventsWithSearch", mock.Anything, "Konser", 1, 10).
+				mockRepo.On("GetEventsWithSearch", mock.Anything, entity.EventFilter{Search: "Konser"}, 1, 10).
 					Return(mockEvents, 2, nil).Once()
 			},
 			wantErr:    false,
@@ -153,11 +206,11 @@ func TestEventUsecase_ListEventsWithSearch(t *testing.T) {
 		},
 		{
 			name:   "Success - Search Empty Result",
-			search: "NonExistent",
+			filter: entity.EventFilter{Search: "NonExistent"},
 			page:   1,
 			limit:  10,
 			mock: func(mockRepo *mocks.MockEventRepo) {
-				mockRepo.On("GetEventsWithSearch", mock.Anything, "NonExistent", 1, 10).
+				mockRepo.On("GetEventsWithSearch", mock.Anything, entity.EventFilter{Search: "NonExistent"}, 1, 10).
 					Return([]entity.Event{}, 0, nil).Once()
 			},
 			wantErr:    false,
@@ -166,24 +219,74 @@ func TestEventUsecase_ListEventsWithSearch(t *testing.T) {
 		},
 		{
 			name:   "Success - Pagination Page 2",
-			search: "",
+			filter: entity.EventFilter{Search: ""},
 			page:   2,
 			limit:  1,
 			mock: func(mockRepo *mocks.MockEventRepo) {
-				mockRepo.On("GetEventsWithSearch", mock.Anything, "", 2, 1).
+				mockRepo.On("GetEventsWithSearch", mock.Anything, entity.EventFilter{Search: ""}, 2, 1).
 					Return(mockEvents[1:], 2, nil).Once()
 			},
 			wantErr:    false,
 			wantEvents: mockEvents[1:],
 			wantTotal:  2,
 		},
+		{
+			name:   "Success - Combined Filters",
+			filter: entity.EventFilter{Location: "Jakarta", Category: "concert", Status: entity.EventStatusAvailable, MinPrice: &minPrice, MaxPrice: &maxPrice},
+			page:   1,
+			limit:  10,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventsWithSearch", mock.Anything, entity.EventFilter{Location: "Jakarta", Category: "concert", Status: entity.EventStatusAvailable, MinPrice: &minPrice, MaxPrice: &maxPrice}, 1, 10).
+					Return(mockEvents[:1], 1, nil).Once()
+			},
+			wantErr:    false,
+			wantEvents: mockEvents[:1],
+			wantTotal:  1,
+		},
+		{
+			name:   "Failed - Price Range Reversed",
+			filter: entity.EventFilter{MinPrice: &maxPrice, MaxPrice: &minPrice},
+			page:   1,
+			limit:  10,
+			mock:   func(mockRepo *mocks.MockEventRepo) {},
+			wantErr: true,
+		},
+		{
+			name:   "Failed - Unknown Status",
+			filter: entity.EventFilter{Status: "sold_out"},
+			page:   1,
+			limit:  10,
+			mock:   func(mockRepo *mocks.MockEventRepo) {},
+			wantErr: true,
+		},
+		{
+			name:   "Success - Full-Text Query",
+			filter: entity.EventFilter{Query: "jazz jakarta"},
+			page:   1,
+			limit:  10,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventsWithSearch", mock.Anything, entity.EventFilter{Query: "jazz jakarta"}, 1, 10).
+					Return(mockEvents[:1], 1, nil).Once()
+			},
+			wantErr:    false,
+			wantEvents: mockEvents[:1],
+			wantTotal:  1,
+		},
+		{
+			name:   "Failed - Query Too Long",
+			filter: entity.EventFilter{Query: strings.Repeat("a", 201)},
+			page:   1,
+			limit:  10,
+			mock:   func(mockRepo *mocks.MockEventRepo) {},
+			wantErr: true,
+		},
 		{
 			name:   "Failed - DB Error",
-			search: "Konser",
+			filter: entity.EventFilter{Search: "Konser"},
 			page:   1,
 			limit:  10,
 			mock: func(mockRepo *mocks.MockEventRepo) {
-				mockRepo.On("GetEventsWithSearch", mock.Anything, "Konser", 1, 10).
+				mockRepo.On("GetEventsWithSearch", mock.Anything, entity.EventFilter{Search: "Konser"}, 1, 10).
 					Return(nil, 0, errors.New("db error")).Once()
 			},
 			wantErr:    true,
@@ -199,8 +302,8 @@ func TestEventUsecase_ListEventsWithSearch(t *testing.T) {
 
 			tt.mock(mockRepo)
 
-			u := usecase.NewEventUsecase(mockRepo, time.Second*2, mockNotif)
-			events, total, err := u.ListEventsWithSearch(context.Background(), tt.search, tt.page, tt.limit)
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, mockNotif, newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+			events, total, err := u.ListEventsWithSearch(context.Background(), tt.filter, tt.page, tt.limit)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -215,6 +318,84 @@ func TestEventUsecase_ListEventsWithSearch(t *testing.T) {
 	}
 }
 
+func TestEventUsecase_ListEventsByCursor(t *testing.T) {
+	created := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	mockEvents := []entity.Event{
+		{ID: 3, Name: "Konser C", CreatedAt: created},
+		{ID: 2, Name: "Konser B", CreatedAt: created},
+	}
+	after := &entity.PageCursor{Sort: "created_at", CreatedAt: created, ID: 4}
+
+	tests := []struct {
+		name       string
+		filter     entity.EventFilter
+		cursor     string
+		mock       func(mockRepo *mocks.MockEventRepo)
+		wantErr    error
+		wantEvents []entity.Event
+		wantNext   *entity.PageCursor
+	}{
+		{
+			name: "Success - First Page With More",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventsAfter", mock.Anything, entity.EventFilter{}, (*entity.PageCursor)(nil), 2).
+					Return(mockEvents, &entity.PageCursor{Sort: "created_at", CreatedAt: created, ID: 2}, nil).Once()
+			},
+			wantEvents: mockEvents,
+			wantNext:   &entity.PageCursor{Sort: "created_at", CreatedAt: created, ID: 2},
+		},
+		{
+			name:   "Success - Last Page",
+			filter: entity.EventFilter{Search: "Konser"},
+			cursor: after.Encode(),
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventsAfter", mock.Anything, entity.EventFilter{Search: "Konser"}, after, 2).
+					Return(mockEvents, nil, nil).Once()
+			},
+			wantEvents: mockEvents,
+		},
+		{
+			name:    "Failed - Malformed Cursor",
+			cursor:  "not-a-cursor",
+			mock:    func(mockRepo *mocks.MockEventRepo) {},
+			wantErr: entity.ErrInvalidCursor,
+		},
+		{
+			name:    "Failed - Invalid Filter",
+			filter:  entity.EventFilter{Status: "deleted"},
+			mock:    func(mockRepo *mocks.MockEventRepo) {},
+			wantErr: entity.ErrInvalidEventFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			tt.mock(mockRepo)
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+			page, err := u.ListEventsByCursor(context.Background(), tt.filter, tt.cursor, 2)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, page)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantEvents, page.Events)
+				assert.Equal(t, tt.wantNext != nil, page.HasMore)
+				if tt.wantNext != nil {
+					next, err := entity.DecodeCursor(page.NextCursor)
+					assert.NoError(t, err)
+					assert.Equal(t, tt.wantNext, next)
+				} else {
+					assert.Empty(t, page.NextCursor)
+				}
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestEventUsecase_GetEventByID(t *testing.T) {
 	mockEvent := &entity.Event{ID: 1, Name: "Konser Coldplay", Location: "Jakarta", Capacity: 1000}
 
@@ -264,7 +445,7 @@ func TestEventUsecase_GetEventByID(t *testing.T) {
 
 			tt.mock(mockRepo)
 
-			u := usecase.NewEventUsecase(mockRepo, time.Second*2, mockNotif)
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, mockNotif, newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
 			event, err := u.GetEventByID(context.Background(), tt.eventID)
 
 			if tt.wantErr {
@@ -326,6 +507,17 @@ func TestEventUsecase_GetEventWithSeats(t *testing.T) {
 			wantErr:   true,
 			wantEvent: nil,
 		},
+		{
+			name:    "Failed - Deleted Event",
+			eventID: 2,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				deletedAt := time.Now()
+				mockRepo.On("GetEventWithSeats", mock.Anything, int64(2)).
+					Return(&entity.EventWithSeats{Event: entity.Event{ID: 2, DeletedAt: &deletedAt}}, nil).Once()
+			},
+			wantErr:   true,
+			wantEvent: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -335,7 +527,7 @@ func TestEventUsecase_GetEventWithSeats(t *testing.T) {
 
 			tt.mock(mockRepo)
 
-			u := usecase.NewEventUsecase(mockRepo, time.Second*2, mockNotif)
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, mockNotif, newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
 			eventWithSeats, err := u.GetEventWithSeats(context.Background(), tt.eventID)
 
 			if tt.wantErr {
@@ -351,92 +543,352 @@ func TestEventUsecase_GetEventWithSeats(t *testing.T) {
 	}
 }
 
-func TestEventUsecase_EditEvent(t *testing.T) {
+func TestEventUsecase_ListSeats(t *testing.T) {
+	event := &entity.Event{ID: 1, Name: "Konser Stadion", Capacity: 80000}
+	seats := []entity.Seat{
+		{ID: 11, EventID: 1, SeatNumber: "A1"},
+		{ID: 12, EventID: 1, SeatNumber: "A2"},
+		{ID: 13, EventID: 1, SeatNumber: "A3"},
+	}
+
 	tests := []struct {
-		name        string
-		input       *entity.Event
-		prevCapacity int64
-		mock        func(mockRepo *mocks.MockEventRepo)
-		wantErr     bool
+		name              string
+		cursor            int64
+		limit             int
+		mock              func(mockRepo *mocks.MockEventRepo)
+		wantErr           error
+		wantSeats         int
+		wantHasMore       bool
+		wantNextCursor    int64
+		wantSectionImages map[string]string
 	}{
 		{
-			name:        "Success Edit Event",
-			input:       &entity.Event{ID: 1, Name: "Konser Updated", Capacity: 2000},
-			prevCapacity: 1000,
+			name:   "Success - More Pages",
+			cursor: 10,
+			limit:  2,
 			mock: func(mockRepo *mocks.MockEventRepo) {
-				mockRepo.On("UpdateEvent", mock.Anything, mock.AnythingOfType("*entity.Event")).Return(nil).Once()
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(event, nil).Once()
+				mockRepo.On("GetSeatsPage", mock.Anything, int64(1), int64(10), 3, entity.SeatFilter{}).Return(seats, nil).Once()
 			},
-			wantErr: false,
+			wantSeats:      2,
+			wantHasMore:    true,
+			wantNextCursor: 12,
 		},
 		{
-			name:        "Failed Edit Event - Not Found",
-			input:       &entity.Event{ID: 999, Name: "Konser Unknown", Capacity: 100},
-			prevCapacity: 100,
+			name:   "Success - Last Page",
+			cursor: 10,
+			limit:  5,
 			mock: func(mockRepo *mocks.MockEventRepo) {
-				mockRepo.On("UpdateEvent", mock.Anything, mock.Anything).Return(entity.ErrNotFound).Once()
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(event, nil).Once()
+				mockRepo.On("GetSeatsPage", mock.Anything, int64(1), int64(10), 6, entity.SeatFilter{}).Return(seats, nil).Once()
 			},
-			wantErr: true,
+			wantSeats: 3,
 		},
 		{
-			name:        "Failed Edit Event - DB Error",
-			input:       &entity.Event{ID: 1, Name: "Konser Error", Capacity: 500},
-			prevCapacity: 1000,
+			name:  "Success - Section Images",
+			limit: 5,
 			mock: func(mockRepo *mocks.MockEventRepo) {
-				mockRepo.On("UpdateEvent", mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
+				sectioned := []entity.Seat{
+					{ID: 11, EventID: 1, SeatNumber: "A1", Category: "VIP"},
+					{ID: 12, EventID: 1, SeatNumber: "B1", Category: "REGULAR"},
+				}
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(event, nil).Once()
+				mockRepo.On("GetSeatsPage", mock.Anything, int64(1), int64(0), 6, entity.SeatFilter{}).Return(sectioned, nil).Once()
+				mockRepo.On("GetSectionImageIDs", mock.Anything, int64(1)).Return(map[string]int64{"VIP": 9}, nil).Once()
 			},
-			wantErr: true,
+			wantSeats:         2,
+			wantSectionImages: map[string]string{"VIP": "/api/v1/section-images/9"},
+		},
+		{
+			name:  "Failed - Event Not Found",
+			limit: 5,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(nil, errors.New("no rows")).Once()
+			},
+			wantErr: entity.ErrNotFound,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(mocks.MockEventRepo)
-			mockNotif := new(mocks.MockNotificationService)
+			tt.mock(mockRepo)
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+			page, err := u.ListSeats(context.Background(), 1, tt.cursor, tt.limit, entity.SeatFilter{})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, page)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, page.Seats, tt.wantSeats)
+				assert.Equal(t, tt.wantHasMore, page.HasMore)
+				assert.Equal(t, tt.wantNextCursor, page.NextCursor)
+				assert.Equal(t, tt.wantSectionImages, page.SectionImages)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_StreamSeats(t *testing.T) {
+	seats := []entity.Seat{{ID: 1, SeatNumber: "A1"}, {ID: 2, SeatNumber: "A2"}}
+
+	t.Run("Success - Streams Every Seat", func(t *testing.T) {
+		mockRepo := new(mocks.MockEventRepo)
+		mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+		mockRepo.On("StreamSeats", mock.Anything, int64(1), entity.SeatFilter{AvailableOnly: true, Section: "VIP"}, mock.Anything).Return(seats, nil).Once()
 
+		u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+		var got []string
+		err := u.StreamSeats(context.Background(), 1, entity.SeatFilter{AvailableOnly: true, Section: "VIP"}, func(s entity.Seat) error {
+			got = append(got, s.SeatNumber)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"A1", "A2"}, got)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Event Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockEventRepo)
+		mockRepo.On("GetEventByID", mock.Anything, int64(9)).Return(nil, errors.New("no rows")).Once()
+
+		u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+		err := u.StreamSeats(context.Background(), 9, entity.SeatFilter{}, func(entity.Seat) error { return nil })
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestEventUsecase_SubscribeSeatUpdates(t *testing.T) {
+	t.Run("Success - Delivers Updates", func(t *testing.T) {
+		updates := make(chan entity.SeatUpdate, 1)
+		updates <- entity.SeatUpdate{EventID: 1, SeatIDs: []int64{7, 8}, IsBooked: true}
+
+		mockRepo := new(mocks.MockEventRepo)
+		mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+		mockRepo.On("SubscribeSeatUpdates", mock.Anything, int64(1)).Return((<-chan entity.SeatUpdate)(updates), nil).Once()
+
+		u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+		got, err := u.SubscribeSeatUpdates(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.SeatUpdate{EventID: 1, SeatIDs: []int64{7, 8}, IsBooked: true}, <-got)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Event Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockEventRepo)
+		mockRepo.On("GetEventByID", mock.Anything, int64(9)).Return(nil, errors.New("no rows")).Once()
+
+		u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+		got, err := u.SubscribeSeatUpdates(context.Background(), 9)
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.Nil(t, got)
+		mockRepo.AssertNotCalled(t, "SubscribeSeatUpdates", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEventUsecase_ListSections(t *testing.T) {
+	sections := []entity.SectionAvailability{
+		{Section: "VIP", Total: 20, Available: 4, MinPrice: 500000, MaxPrice: 500000},
+		{Section: "REG", Total: 100, Available: 63, MinPrice: 150000, MaxPrice: 200000},
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(mockRepo *mocks.MockEventRepo)
+		want    []entity.SectionAvailability
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+				mockRepo.On("GetSectionAvailability", mock.Anything, int64(1)).Return(sections, nil).Once()
+			},
+			want: sections,
+		},
+		{
+			name: "Failed - Event Not Found",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(nil, errors.New("no rows")).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name: "Failed - DB Error",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+				mockRepo.On("GetSectionAvailability", mock.Anything, int64(1)).Return(nil, errors.New("db error")).Once()
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
 			tt.mock(mockRepo)
 
-			u := usecase.NewEventUsecase(mockRepo, time.Second*2, mockNotif)
-			err := u.EditEvent(context.Background(), tt.input, tt.prevCapacity)
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+			got, err := u.ListSections(context.Background(), 1)
 
-			if tt.wantErr {
-				assert.Error(t, err)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
 			}
 			mockRepo.AssertExpectations(t)
 		})
 	}
 }
 
-func TestEventUsecase_CancelEvent(t *testing.T) {
+func TestEventUsecase_GetEventCapacity(t *testing.T) {
+	sections := []entity.SectionAvailability{
+		{Section: "VIP", Total: 20, Available: 4, Held: 6, Sold: 10},
+	}
+
 	tests := []struct {
 		name    string
-		eventID int64
-		mock    func(mockRepo *mocks.MockEventRepo, mockNotif *mocks.MockNotificationService)
-		wantErr bool
+		mock    func(mockRepo *mocks.MockEventRepo)
+		want    *entity.EventCapacity
+		wantErr error
 	}{
 		{
-			name:    "Success Cancel Event",
-			eventID: 1,
-			mock: func(mockRepo *mocks.MockEventRepo, mockNotif *mocks.MockNotificationService) {
-				mockRepo.On("UpdateEventStatus", mock.Anything, int64(1), "CANCELLED").Return(nil).Once()
-				mockNotif.On("EnqueueCancellation", int64(1)).Once()
+			name: "Success - Seated With Sections",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventCapacity", mock.Anything, int64(1)).
+					Return(&entity.EventCapacity{EventID: 1, AdmissionMode: entity.AdmissionSeated, Capacity: 20, Sold: 10, Held: 5, LapsedHolds: 1, Available: 4}, nil).Once()
+				mockRepo.On("GetSectionAvailability", mock.Anything, int64(1)).Return(sections, nil).Once()
+			},
+			want: &entity.EventCapacity{EventID: 1, AdmissionMode: entity.AdmissionSeated, Capacity: 20, Sold: 10, Held: 5, LapsedHolds: 1, Available: 4, Sections: sections},
+		},
+		{
+			name: "Success - General Admission",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventCapacity", mock.Anything, int64(1)).
+					Return(&entity.EventCapacity{EventID: 1, AdmissionMode: entity.AdmissionGeneral, Capacity: 500, Sold: 300, Held: 20, Available: 180}, nil).Once()
+			},
+			want: &entity.EventCapacity{EventID: 1, AdmissionMode: entity.AdmissionGeneral, Capacity: 500, Sold: 300, Held: 20, Available: 180},
+		},
+		{
+			name: "Failed - Event Not Found",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventCapacity", mock.Anything, int64(1)).Return(nil, entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name: "Failed - Sections DB Error",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventCapacity", mock.Anything, int64(1)).
+					Return(&entity.EventCapacity{EventID: 1, AdmissionMode: entity.AdmissionSeated}, nil).Once()
+				mockRepo.On("GetSectionAvailability", mock.Anything, int64(1)).Return(nil, errors.New("db error")).Once()
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			tt.mock(mockRepo)
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+			got, err := u.GetEventCapacity(context.Background(), 1)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_EditEvent(t *testing.T) {
+	future := time.Now().Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		input       *entity.Event
+		prevCapacity int64
+		mock        func(mockRepo *mocks.MockEventRepo)
+		wantErr     bool
+	}{
+		{
+			name:        "Success Edit Event",
+			input:       &entity.Event{ID: 1, Name: "Konser Updated", Capacity: 2000, Date: future},
+			prevCapacity: 1000,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, Name: "Konser", Capacity: 1000, Date: future}, nil).Once()
+				mockRepo.On("UpdateEvent", mock.Anything, mock.AnythingOfType("*entity.Event")).Return(nil).Once()
 			},
 			wantErr: false,
 		},
 		{
-			name:    "Failed Cancel Event - Not Found",
-			eventID: 999,
-			mock: func(mockRepo *mocks.MockEventRepo, mockNotif *mocks.MockNotificationService) {
-				mockRepo.On("UpdateEventStatus", mock.Anything, int64(999), "CANCELLED").Return(entity.ErrNotFound).Once()
+			name: "Failed Edit Event - Growth Exceeds Sections",
+			input: &entity.Event{ID: 2, Name: "Teater B", Capacity: 30, Date: future, SeatNumbering: &entity.SeatNumbering{
+				Scheme:   entity.SeatSchemeSections,
+				Sections: []entity.SeatSection{{Name: "VIP", Rows: 2, SeatsPerRow: 10}},
+			}},
+			prevCapacity: 20,
+			mock:         func(mockRepo *mocks.MockEventRepo) {},
+			wantErr:      true,
+		},
+		{
+			name: "Failed Edit Event - Too Many Metadata Keys",
+			input: func() *entity.Event {
+				metadata := map[string]interface{}{}
+				for i := 0; i < 51; i++ {
+					metadata[fmt.Sprintf("key%d", i)] = i
+				}
+				return &entity.Event{ID: 1, Name: "Konser A", Capacity: 1000, Date: future, Metadata: metadata}
+			}(),
+			prevCapacity: 1000,
+			mock:         func(mockRepo *mocks.MockEventRepo) {},
+			wantErr:      true,
+		},
+		{
+			name: "Failed Edit Event - Date Moved Before Doors Open",
+			input: func() *entity.Event {
+				doorsOpen := time.Date(2026, 12, 31, 18, 0, 0, 0, time.UTC)
+				return &entity.Event{ID: 1, Name: "Konser A", Capacity: 1000,
+					Date:    time.Date(2026, 12, 31, 17, 0, 0, 0, time.UTC),
+					Content: &entity.EventContent{DoorsOpenAt: &doorsOpen},
+				}
+			}(),
+			prevCapacity: 1000,
+			mock:         func(mockRepo *mocks.MockEventRepo) {},
+			wantErr:      true,
+		},
+		{
+			name:        "Failed Edit Event - Not Found",
+			input:       &entity.Event{ID: 999, Name: "Konser Unknown", Capacity: 100, Date: future},
+			prevCapacity: 100,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(999)).Return(nil, entity.ErrNotFound).Once()
 			},
 			wantErr: true,
 		},
 		{
-			name:    "Failed Cancel Event - DB Error",
-			eventID: 1,
-			mock: func(mockRepo *mocks.MockEventRepo, mockNotif *mocks.MockNotificationService) {
-				mockRepo.On("UpdateEventStatus", mock.Anything, int64(1), "CANCELLED").Return(errors.New("db error")).Once()
+			name:        "Failed Edit Event - DB Error",
+			input:       &entity.Event{ID: 1, Name: "Konser Error", Capacity: 500, Date: future},
+			prevCapacity: 1000,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, Capacity: 1000, Date: future}, nil).Once()
+				mockRepo.On("UpdateEvent", mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
 			},
 			wantErr: true,
 		},
@@ -446,11 +898,15 @@ func TestEventUsecase_CancelEvent(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(mocks.MockEventRepo)
 			mockNotif := new(mocks.MockNotificationService)
+			mockAudit := new(mocks.MockAuditUsecase)
 
-			tt.mock(mockRepo, mockNotif)
+			tt.mock(mockRepo)
+			if !tt.wantErr {
+				mockAudit.On("RecordChange", mock.Anything, usecase.ActionEventUpdate, "event", tt.input.ID, mock.Anything, mock.Anything).Once()
+			}
 
-			u := usecase.NewEventUsecase(mockRepo, time.Second*2, mockNotif)
-			err := u.CancelEvent(context.Background(), tt.eventID)
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, mockNotif, newDomainEvents(), mockAudit, new(mocks.MockStorage), 0)
+			err := u.EditEvent(context.Background(), tt.input, tt.prevCapacity)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -458,7 +914,882 @@ func TestEventUsecase_CancelEvent(t *testing.T) {
 				assert.NoError(t, err)
 			}
 			mockRepo.AssertExpectations(t)
-			mockNotif.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
 		})
 	}
 }
+
+func TestEventUsecase_EditEvent_CapacityBelowBooked(t *testing.T) {
+	mockRepo := new(mocks.MockEventRepo)
+	mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, Capacity: 1000}, nil).Once()
+	mockRepo.On("UpdateEvent", mock.Anything, mock.Anything).
+		Return(&entity.CapacityBelowBookedError{Requested: 100, Minimum: 250}).Once()
+
+	u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+	err := u.EditEvent(context.Background(), &entity.Event{ID: 1, Capacity: 100, Date: time.Now().Add(24 * time.Hour)}, 1000)
+
+	var belowBooked *entity.CapacityBelowBookedError
+	assert.ErrorAs(t, err, &belowBooked)
+	assert.Equal(t, 250, belowBooked.Minimum)
+	assert.ErrorIs(t, err, entity.ErrCapacityBelowSold)
+}
+
+func TestEventUsecase_CreateEvent_LeadTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    time.Time
+		wantErr bool
+	}{
+		{name: "Success - After Lead Time", date: time.Now().Add(72 * time.Hour)},
+		{name: "Failed - Inside Lead Time", date: time.Now().Add(12 * time.Hour), wantErr: true},
+		{name: "Failed - In The Past", date: time.Now().Add(-time.Hour), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			if !tt.wantErr {
+				mockRepo.On("CreateEvent", mock.Anything, mock.Anything, float64(50000)).Return(nil).Once()
+			}
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 24*time.Hour)
+			err := u.CreateEvent(context.Background(), &entity.Event{Name: "Konser A", Capacity: 100, Date: tt.date}, 50000)
+
+			if tt.wantErr {
+				var invalid *entity.ValidationError
+				assert.ErrorAs(t, err, &invalid)
+				assert.ErrorIs(t, err, entity.ErrValidation)
+				assert.Equal(t, "date", invalid.Fields[0].Field)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_EditEvent_PastDate(t *testing.T) {
+	past := time.Date(2024, 5, 1, 19, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Date Unchanged", func(t *testing.T) {
+		mockRepo := new(mocks.MockEventRepo)
+		mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, Date: past}, nil).Once()
+		mockRepo.On("UpdateEvent", mock.Anything, mock.Anything).Return(nil).Once()
+		mockAudit := new(mocks.MockAuditUsecase)
+		mockAudit.On("RecordChange", mock.Anything, usecase.ActionEventUpdate, "event", int64(1),
+			mock.MatchedBy(func(before map[string]interface{}) bool { return before["name"] == "" }),
+			mock.MatchedBy(func(after map[string]interface{}) bool { return after["name"] == "Konser A" })).Once()
+
+		u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), mockAudit, new(mocks.MockStorage), 0)
+		err := u.EditEvent(context.Background(), &entity.Event{ID: 1, Name: "Konser A", Capacity: 100, Date: past}, 100)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Moved Into The Past", func(t *testing.T) {
+		mockRepo := new(mocks.MockEventRepo)
+		mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, Date: time.Now().Add(48 * time.Hour)}, nil).Once()
+
+		u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+		err := u.EditEvent(context.Background(), &entity.Event{ID: 1, Name: "Konser A", Capacity: 100, Date: past}, 100)
+
+		assert.ErrorIs(t, err, entity.ErrValidation)
+		mockRepo.AssertNotCalled(t, "UpdateEvent", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSeatNumbering_SeatLabel(t *testing.T) {
+	sections := &entity.SeatNumbering{
+		Scheme:   entity.SeatSchemeSections,
+		Sections: []entity.SeatSection{{Name: "VIP", Rows: 2, SeatsPerRow: 5}, {Name: "REG", Rows: 30, SeatsPerRow: 2}},
+	}
+
+	tests := []struct {
+		name        string
+		numbering   *entity.SeatNumbering
+		seat        int
+		wantLabel   string
+		wantSection string
+	}{
+		{name: "Legacy", numbering: nil, seat: 7, wantLabel: "42-7"},
+		{name: "Sequential", numbering: &entity.SeatNumbering{Scheme: entity.SeatSchemeSequential}, seat: 7, wantLabel: "7"},
+		{name: "Rows First Seat", numbering: &entity.SeatNumbering{Scheme: entity.SeatSchemeRows, SeatsPerRow: 10}, seat: 1, wantLabel: "A1"},
+		{name: "Rows Wraps To Next Row", numbering: &entity.SeatNumbering{Scheme: entity.SeatSchemeRows, SeatsPerRow: 10}, seat: 11, wantLabel: "B1"},
+		{name: "Rows Past Z", numbering: &entity.SeatNumbering{Scheme: entity.SeatSchemeRows, SeatsPerRow: 1}, seat: 27, wantLabel: "AA1"},
+		{name: "Custom Format", numbering: &entity.SeatNumbering{Scheme: entity.SeatSchemeRows, SeatsPerRow: 10, Format: "E{event}-{row}-{seat}"}, seat: 12, wantLabel: "E42-B-2"},
+		{name: "Sections First Section", numbering: sections, seat: 6, wantLabel: "VIP-B1", wantSection: "VIP"},
+		{name: "Sections Rows Restart", numbering: sections, seat: 11, wantLabel: "REG-A1", wantSection: "REG"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, section, err := tt.numbering.SeatLabel(42, tt.seat)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLabel, label)
+			assert.Equal(t, tt.wantSection, section)
+		})
+	}
+}
+
+func TestEvent_SetPriceRange(t *testing.T) {
+	price := func(p float64) *float64 { return &p }
+
+	tests := []struct {
+		name    string
+		event   entity.Event
+		seats   []entity.Seat
+		wantMin *float64
+		wantMax *float64
+	}{
+		{name: "Seated", event: entity.Event{AdmissionMode: entity.AdmissionSeated}, seats: []entity.Seat{{Price: 300000}, {Price: 150000}, {Price: 750000}}, wantMin: price(150000), wantMax: price(750000)},
+		{name: "Seated Without Seats", event: entity.Event{AdmissionMode: entity.AdmissionSeated}},
+		{name: "General Admission", event: entity.Event{AdmissionMode: entity.AdmissionGeneral, GeneralPrice: 200000}, wantMin: price(200000), wantMax: price(200000)},
+		{name: "General Admission Unpriced", event: entity.Event{AdmissionMode: entity.AdmissionGeneral}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.event.SetPriceRange(tt.seats)
+
+			assert.Equal(t, tt.wantMin, tt.event.MinPrice)
+			assert.Equal(t, tt.wantMax, tt.event.MaxPrice)
+		})
+	}
+}
+
+func TestSeatNumbering_SeatRowColumn(t *testing.T) {
+	sections := &entity.SeatNumbering{
+		Scheme:   entity.SeatSchemeSections,
+		Sections: []entity.SeatSection{{Name: "VIP", Rows: 2, SeatsPerRow: 5}, {Name: "REG", Rows: 30, SeatsPerRow: 2}},
+	}
+
+	tests := []struct {
+		name       string
+		numbering  *entity.SeatNumbering
+		seat       int
+		wantRow    string
+		wantColumn int
+	}{
+		{name: "Legacy", numbering: nil, seat: 7},
+		{name: "Sequential", numbering: &entity.SeatNumbering{Scheme: entity.SeatSchemeSequential}, seat: 7},
+		{name: "Rows", numbering: &entity.SeatNumbering{Scheme: entity.SeatSchemeRows, SeatsPerRow: 10}, seat: 12, wantRow: "B", wantColumn: 2},
+		{name: "Sections Rows Restart", numbering: sections, seat: 12, wantRow: "A", wantColumn: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row, column := tt.numbering.SeatRowColumn(tt.seat)
+
+			assert.Equal(t, tt.wantRow, row)
+			assert.Equal(t, tt.wantColumn, column)
+		})
+	}
+}
+
+func TestEventUsecase_CancelEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		eventID int64
+		mock    func(mockRepo *mocks.MockEventRepo, mockNotif *mocks.MockNotificationService)
+		wantErr bool
+	}{
+		{
+			name:    "Success Cancel Event",
+			eventID: 1,
+			mock: func(mockRepo *mocks.MockEventRepo, mockNotif *mocks.MockNotificationService) {
+				mockRepo.On("DeleteEvent", mock.Anything, int64(1)).Return(nil).Once()
+				mockNotif.On("EnqueueCancellation", mock.Anything, int64(1)).Return(nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Failed Cancel Event - Refund Job Not Stored",
+			eventID: 1,
+			mock: func(mockRepo *mocks.MockEventRepo, mockNotif *mocks.MockNotificationService) {
+				mockRepo.On("DeleteEvent", mock.Anything, int64(1)).Return(nil).Once()
+				mockNotif.On("EnqueueCancellation", mock.Anything, int64(1)).Return(errors.New("db error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Failed Cancel Event - Not Found or Already Deleted",
+			eventID: 999,
+			mock: func(mockRepo *mocks.MockEventRepo, mockNotif *mocks.MockNotificationService) {
+				mockRepo.On("DeleteEvent", mock.Anything, int64(999)).Return(entity.ErrNotFound).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Failed Cancel Event - DB Error",
+			eventID: 1,
+			mock: func(mockRepo *mocks.MockEventRepo, mockNotif *mocks.MockNotificationService) {
+				mockRepo.On("DeleteEvent", mock.Anything, int64(1)).Return(errors.New("db error")).Once()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			mockNotif := new(mocks.MockNotificationService)
+			mockAudit := new(mocks.MockAuditUsecase)
+			mockEvents := new(mocks.MockDomainEventPublisher)
+
+			tt.mock(mockRepo, mockNotif)
+			if !tt.wantErr {
+				mockAudit.On("Record", mock.Anything, usecase.ActionEventCancel, "event", tt.eventID, mock.Anything).Once()
+				mockEvents.On("PublishDomainEvent", mock.Anything, entity.DomainEventEventCancelled, tt.eventID, map[string]interface{}(nil)).Once()
+			}
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, mockNotif, mockEvents, mockAudit, new(mocks.MockStorage), 0)
+			err := u.CancelEvent(context.Background(), tt.eventID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				mockEvents.AssertNotCalled(t, "PublishDomainEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockNotif.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+			mockEvents.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_RestoreEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		eventID int64
+		mock    func(mockRepo *mocks.MockEventRepo)
+		wantErr error
+	}{
+		{
+			name:    "Success",
+			eventID: 1,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("RestoreEvent", mock.Anything, int64(1)).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Failed - Not Deleted",
+			eventID: 2,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("RestoreEvent", mock.Anything, int64(2)).Return(entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+
+			tt.mock(mockRepo)
+			if tt.wantErr == nil {
+				mockAudit.On("Record", mock.Anything, usecase.ActionEventRestore, "event", tt.eventID, mock.Anything).Once()
+			}
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), mockAudit, new(mocks.MockStorage), 0)
+			err := u.RestoreEvent(context.Background(), tt.eventID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_PreviewCancellation(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(mockRepo *mocks.MockEventRepo)
+		want    *entity.DryRunResult
+		wantErr error
+	}{
+		{
+			name: "Success - Refunds Paid And Cancels Pending",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+				mockRepo.On("GetCancellableBookings", mock.Anything, int64(1)).Return([]entity.AffectedBooking{
+					{BookingID: 3, Status: "PAID", Tickets: 2, PaidAmount: 200000},
+					{BookingID: 4, Status: "PENDING", Tickets: 1},
+					{BookingID: 5, Status: "PAID", Tickets: 1, PaidAmount: 100000},
+				}, nil).Once()
+			},
+			want: &entity.DryRunResult{
+				DryRun: true,
+				Bookings: []entity.AffectedBooking{
+					{BookingID: 3, Status: "PAID", Tickets: 2, PaidAmount: 200000, Action: entity.DryRunRefund},
+					{BookingID: 4, Status: "PENDING", Tickets: 1, Action: entity.DryRunCancel},
+					{BookingID: 5, Status: "PAID", Tickets: 1, PaidAmount: 100000, Action: entity.DryRunRefund},
+				},
+				Refunded:        2,
+				RefundAmount:    300000,
+				Cancelled:       1,
+				TicketsReleased: 4,
+			},
+		},
+		{
+			name: "Success - No Bookings",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+				mockRepo.On("GetCancellableBookings", mock.Anything, int64(1)).Return(nil, nil).Once()
+			},
+			want: &entity.DryRunResult{DryRun: true, Bookings: []entity.AffectedBooking{}},
+		},
+		{
+			name: "Failed - Event Not Found",
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(nil, errors.New("no rows")).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			tt.mock(mockRepo)
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+			result, err := u.PreviewCancellation(context.Background(), 1)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, result)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_SetTicketLimit(t *testing.T) {
+	four, zero := 4, 0
+
+	tests := []struct {
+		name    string
+		limit   *int
+		mock    func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase)
+		wantErr error
+	}{
+		{
+			name:  "Success Set Limit",
+			limit: &four,
+			mock: func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("SetTicketLimit", mock.Anything, int64(1), &four).Return(nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionEventTicketLimit, "event", int64(1), mock.Anything).Once()
+			},
+		},
+		{
+			name: "Success Clear Limit",
+			mock: func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("SetTicketLimit", mock.Anything, int64(1), (*int)(nil)).Return(nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionEventTicketLimit, "event", int64(1), mock.Anything).Once()
+			},
+		},
+		{
+			name:    "Failed - Zero Limit",
+			limit:   &zero,
+			mock:    func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {},
+			wantErr: entity.ErrInvalidTicketLimit,
+		},
+		{
+			name:  "Failed - Event Not Found",
+			limit: &four,
+			mock: func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("SetTicketLimit", mock.Anything, int64(1), &four).Return(entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			tt.mock(mockRepo, mockAudit)
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), mockAudit, new(mocks.MockStorage), 0)
+			err := u.SetTicketLimit(context.Background(), 1, tt.limit)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_SetSalesWaves(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2026, 3, 1, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		waves   []entity.SalesWave
+		mock    func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase)
+		wantErr error
+	}{
+		{
+			name:  "Success - Sorted By Start",
+			waves: []entity.SalesWave{{StartsAt: at(12), Quantity: 1000}, {StartsAt: at(10), Quantity: 1000}},
+			mock: func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("ReplaceSalesWaves", mock.Anything, int64(1), mock.MatchedBy(func(waves []entity.SalesWave) bool {
+					return len(waves) == 2 && waves[0].StartsAt.Equal(at(10)) && waves[1].StartsAt.Equal(at(12))
+				})).Return(nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionEventSalesWaves, "event", int64(1), map[string]interface{}{
+					"waves": 2, "quantity": 2000,
+				}).Once()
+				mockRepo.On("GetSalesWaves", mock.Anything, int64(1)).Return(&entity.SalesWaveSchedule{EventID: 1}, nil).Once()
+			},
+		},
+		{
+			name: "Success - Clear Waves",
+			mock: func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("ReplaceSalesWaves", mock.Anything, int64(1), []entity.SalesWave(nil)).Return(nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionEventSalesWaves, "event", int64(1), mock.Anything).Once()
+				mockRepo.On("GetSalesWaves", mock.Anything, int64(1)).Return(&entity.SalesWaveSchedule{EventID: 1}, nil).Once()
+			},
+		},
+		{
+			name:    "Failed - Zero Quantity",
+			waves:   []entity.SalesWave{{StartsAt: at(10), Quantity: 0}},
+			mock:    func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {},
+			wantErr: entity.ErrInvalidSalesWave,
+		},
+		{
+			name:    "Failed - Duplicate Start",
+			waves:   []entity.SalesWave{{StartsAt: at(10), Quantity: 500}, {StartsAt: at(10), Quantity: 500}},
+			mock:    func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {},
+			wantErr: entity.ErrInvalidSalesWave,
+		},
+		{
+			name:  "Failed - Event Not Found",
+			waves: []entity.SalesWave{{StartsAt: at(10), Quantity: 1000}},
+			mock: func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("ReplaceSalesWaves", mock.Anything, int64(1), mock.Anything).Return(entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			tt.mock(mockRepo, mockAudit)
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), mockAudit, new(mocks.MockStorage), 0)
+			schedule, err := u.SetSalesWaves(context.Background(), 1, tt.waves)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, schedule)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), schedule.EventID)
+			}
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_UpdateEventContent(t *testing.T) {
+	organizerID := int64(7)
+	eventDate := time.Now().Add(48 * time.Hour)
+	doorsOpen := eventDate.Add(-time.Hour)
+	afterStart := eventDate.Add(time.Hour)
+	ownedEvent := &entity.Event{ID: 1, Date: eventDate, OrganizerID: &organizerID}
+
+	validContent := &entity.EventContent{
+		DoorsOpenAt:     &doorsOpen,
+		FAQ:             []entity.FAQEntry{{Question: "Parkir?", Answer: "Tersedia di basement."}},
+		ProhibitedItems: []string{"Senjata tajam"},
+		Blocks: []entity.ContentBlock{
+			{Type: entity.ContentBlockHeading, Text: "Line-up"},
+			{Type: entity.ContentBlockList, Items: []string{"Band A", "Band B"}},
+			{Type: entity.ContentBlockImage, ImageURL: "https://cdn.example.com/stage.png"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		organizerID int64
+		content     *entity.EventContent
+		mock        func(mockRepo *mocks.MockEventRepo)
+		wantErr     error
+	}{
+		{
+			name:        "Success",
+			organizerID: organizerID,
+			content:     validContent,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(ownedEvent, nil).Once()
+				mockRepo.On("UpdateEventContent", mock.Anything, int64(1), validContent).Return(nil).Once()
+			},
+		},
+		{
+			name:        "Failed - Not Event Organizer",
+			organizerID: 8,
+			content:     validContent,
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(ownedEvent, nil).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name:        "Failed - Doors Open After Start",
+			organizerID: organizerID,
+			content:     &entity.EventContent{DoorsOpenAt: &afterStart},
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(ownedEvent, nil).Once()
+			},
+			wantErr: entity.ErrInvalidEventContent,
+		},
+		{
+			name:        "Failed - Unknown Block Type",
+			organizerID: organizerID,
+			content:     &entity.EventContent{Blocks: []entity.ContentBlock{{Type: "video", Text: "x"}}},
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(ownedEvent, nil).Once()
+			},
+			wantErr: entity.ErrInvalidEventContent,
+		},
+		{
+			name:        "Failed - Image Without URL",
+			organizerID: organizerID,
+			content:     &entity.EventContent{Blocks: []entity.ContentBlock{{Type: entity.ContentBlockImage, ImageURL: "javascript:alert(1)"}}},
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(ownedEvent, nil).Once()
+			},
+			wantErr: entity.ErrInvalidEventContent,
+		},
+		{
+			name:        "Failed - Empty FAQ Answer",
+			organizerID: organizerID,
+			content:     &entity.EventContent{FAQ: []entity.FAQEntry{{Question: "Parkir?"}}},
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(ownedEvent, nil).Once()
+			},
+			wantErr: entity.ErrInvalidEventContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			tt.mock(mockRepo)
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+			err := u.UpdateEventContent(context.Background(), 1, tt.organizerID, tt.content)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_SetRefundPolicy(t *testing.T) {
+	organizerID := int64(7)
+	ownedEvent := &entity.Event{ID: 1, Date: time.Now().Add(30 * 24 * time.Hour), OrganizerID: &organizerID}
+	policy := &entity.RefundPolicy{FullRefundDays: 14, PartialRefundDays: 3, PartialRefundPercent: 50}
+
+	tests := []struct {
+		name        string
+		organizerID int64
+		policy      *entity.RefundPolicy
+		mock        func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase)
+		wantErr     error
+	}{
+		{
+			name:        "Success Set Policy",
+			organizerID: organizerID,
+			policy:      policy,
+			mock: func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(ownedEvent, nil).Once()
+				mockRepo.On("SetRefundPolicy", mock.Anything, int64(1), policy).Return(nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionEventRefundPolicy, "event", int64(1), mock.Anything).Once()
+			},
+		},
+		{
+			name:        "Success Remove Policy",
+			organizerID: organizerID,
+			mock: func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(ownedEvent, nil).Once()
+				mockRepo.On("SetRefundPolicy", mock.Anything, int64(1), (*entity.RefundPolicy)(nil)).Return(nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionEventRefundPolicy, "event", int64(1), mock.Anything).Once()
+			},
+		},
+		{
+			name:        "Failed - Not Event Organizer",
+			organizerID: 8,
+			policy:      policy,
+			mock: func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(ownedEvent, nil).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name:        "Failed - Partial Tier Before Full Tier",
+			organizerID: organizerID,
+			policy:      &entity.RefundPolicy{FullRefundDays: 3, PartialRefundDays: 14, PartialRefundPercent: 50},
+			mock:        func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {},
+			wantErr:     entity.ErrInvalidRefundPolicy,
+		},
+		{
+			name:        "Failed - Partial Percent Of 100",
+			organizerID: organizerID,
+			policy:      &entity.RefundPolicy{FullRefundDays: 14, PartialRefundDays: 3, PartialRefundPercent: 100},
+			mock:        func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {},
+			wantErr:     entity.ErrInvalidRefundPolicy,
+		},
+		{
+			name:        "Failed - Partial Days Without Percent",
+			organizerID: organizerID,
+			policy:      &entity.RefundPolicy{FullRefundDays: 14, PartialRefundDays: 3},
+			mock:        func(mockRepo *mocks.MockEventRepo, mockAudit *mocks.MockAuditUsecase) {},
+			wantErr:     entity.ErrInvalidRefundPolicy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			tt.mock(mockRepo, mockAudit)
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), mockAudit, new(mocks.MockStorage), 0)
+			err := u.SetRefundPolicy(context.Background(), 1, tt.organizerID, tt.policy)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_UpdateSeatLayout(t *testing.T) {
+	x, y := 10.0, 20.0
+
+	tests := []struct {
+		name    string
+		layout  *entity.SeatLayout
+		mock    func(mockRepo *mocks.MockEventRepo)
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			layout: &entity.SeatLayout{Seats: []entity.SeatPlacement{{SeatNumber: "A1", Section: "VIP", Row: "A", Column: 1, X: &x, Y: &y}}},
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+				mockRepo.On("UpdateSeatLayout", mock.Anything, int64(1), mock.AnythingOfType("*entity.SeatLayout")).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Failed - Empty Layout",
+			layout:  &entity.SeatLayout{},
+			mock:    func(mockRepo *mocks.MockEventRepo) {},
+			wantErr: entity.ErrInvalidSeatLayout,
+		},
+		{
+			name:    "Failed - Duplicate Seat",
+			layout:  &entity.SeatLayout{Seats: []entity.SeatPlacement{{SeatNumber: "A1"}, {SeatNumber: "A1"}}},
+			mock:    func(mockRepo *mocks.MockEventRepo) {},
+			wantErr: entity.ErrInvalidSeatLayout,
+		},
+		{
+			name:    "Failed - Only X Given",
+			layout:  &entity.SeatLayout{Seats: []entity.SeatPlacement{{SeatNumber: "A1", X: &x}}},
+			mock:    func(mockRepo *mocks.MockEventRepo) {},
+			wantErr: entity.ErrInvalidSeatLayout,
+		},
+		{
+			name:   "Failed - Event Not Found",
+			layout: &entity.SeatLayout{Seats: []entity.SeatPlacement{{SeatNumber: "A1"}}},
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(nil, errors.New("no rows")).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name:   "Failed - Unknown Seat",
+			layout: &entity.SeatLayout{Seats: []entity.SeatPlacement{{SeatNumber: "Z99"}}},
+			mock: func(mockRepo *mocks.MockEventRepo) {
+				mockRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+				mockRepo.On("UpdateSeatLayout", mock.Anything, int64(1), mock.AnythingOfType("*entity.SeatLayout")).Return(entity.ErrInvalidSeatLayout).Once()
+			},
+			wantErr: entity.ErrInvalidSeatLayout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			tt.mock(mockRepo)
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+			err := u.UpdateSeatLayout(context.Background(), 1, tt.layout)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_UploadEventImage(t *testing.T) {
+	organizerID := int64(7)
+	otherID := int64(8)
+	posterKey := mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "posters/1/") && strings.HasSuffix(key, ".png")
+	})
+
+	tests := []struct {
+		name        string
+		organizerID *int64
+		contentType string
+		size        int64
+		mock        func(eventRepo *mocks.MockEventRepo, store *mocks.MockStorage)
+		wantURL     string
+		wantErr     error
+	}{
+		{
+			name:        "Success - Replaces Previous Poster",
+			organizerID: &organizerID,
+			contentType: "image/png",
+			size:        1024,
+			mock: func(eventRepo *mocks.MockEventRepo, store *mocks.MockStorage) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, OrganizerID: &organizerID}, nil).Once()
+				store.On("Put", mock.Anything, posterKey, mock.Anything, "image/png").Return(nil).Once()
+				store.On("URL", posterKey).Return("/uploads/posters/1/new.png").Once()
+				eventRepo.On("SetEventImage", mock.Anything, int64(1), posterKey, "/uploads/posters/1/new.png").Return("posters/1/old.png", nil).Once()
+				store.On("Delete", mock.Anything, "posters/1/old.png").Return(nil).Once()
+			},
+			wantURL: "/uploads/posters/1/new.png",
+		},
+		{
+			name:        "Success - Admin Sets Any Event's Poster",
+			contentType: "image/png",
+			size:        1024,
+			mock: func(eventRepo *mocks.MockEventRepo, store *mocks.MockStorage) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+				store.On("Put", mock.Anything, posterKey, mock.Anything, "image/png").Return(nil).Once()
+				store.On("URL", posterKey).Return("/uploads/posters/1/new.png").Once()
+				eventRepo.On("SetEventImage", mock.Anything, int64(1), posterKey, "/uploads/posters/1/new.png").Return("", nil).Once()
+			},
+			wantURL: "/uploads/posters/1/new.png",
+		},
+		{
+			name:        "Failed - Unsupported Type",
+			organizerID: &organizerID,
+			contentType: "image/gif",
+			size:        1024,
+			mock:        func(*mocks.MockEventRepo, *mocks.MockStorage) {},
+			wantErr:     entity.ErrInvalidEventImage,
+		},
+		{
+			name:        "Failed - Too Large",
+			organizerID: &organizerID,
+			contentType: "image/jpeg",
+			size:        usecase.MaxEventImageSize + 1,
+			mock:        func(*mocks.MockEventRepo, *mocks.MockStorage) {},
+			wantErr:     entity.ErrInvalidEventImage,
+		},
+		{
+			name:        "Failed - Not Event Owner",
+			organizerID: &organizerID,
+			contentType: "image/png",
+			size:        1024,
+			mock: func(eventRepo *mocks.MockEventRepo, store *mocks.MockStorage) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, OrganizerID: &otherID}, nil).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name:        "Failed - DB Error Removes Stored File",
+			organizerID: &organizerID,
+			contentType: "image/png",
+			size:        1024,
+			mock: func(eventRepo *mocks.MockEventRepo, store *mocks.MockStorage) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, OrganizerID: &organizerID}, nil).Once()
+				store.On("Put", mock.Anything, posterKey, mock.Anything, "image/png").Return(nil).Once()
+				store.On("URL", posterKey).Return("/uploads/posters/1/new.png").Once()
+				eventRepo.On("SetEventImage", mock.Anything, int64(1), posterKey, mock.Anything).Return("", errors.New("db down")).Once()
+				store.On("Delete", mock.Anything, posterKey).Return(nil).Once()
+			},
+			wantErr: errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockEventRepo)
+			mockStore := new(mocks.MockStorage)
+			tt.mock(mockRepo, mockStore)
+
+			u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), mockStore, 0)
+			url, err := u.UploadEventImage(context.Background(), 1, tt.organizerID, tt.contentType, tt.size, strings.NewReader("poster"))
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantURL, url)
+			}
+			mockRepo.AssertExpectations(t)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventUsecase_GetPublicAvailability(t *testing.T) {
+	minPrice, maxPrice := 150000.0, 750000.0
+	availability := &entity.PublicAvailability{EventID: 1, Status: "available", Capacity: 100, Available: 40, MinPrice: &minPrice, MaxPrice: &maxPrice}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.MockEventRepo)
+		mockRepo.On("GetPublicAvailability", mock.Anything, int64(1)).Return(availability, nil).Once()
+
+		u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+		got, err := u.GetPublicAvailability(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, availability, got)
+	})
+
+	t.Run("Failed - Event Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockEventRepo)
+		mockRepo.On("GetPublicAvailability", mock.Anything, int64(99)).Return(nil, entity.ErrNotFound).Once()
+
+		u := usecase.NewEventUsecase(mockRepo, newTxManager(), time.Second*2, new(mocks.MockNotificationService), newDomainEvents(), new(mocks.MockAuditUsecase), new(mocks.MockStorage), 0)
+		got, err := u.GetPublicAvailability(context.Background(), 99)
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.Nil(t, got)
+	})
+}
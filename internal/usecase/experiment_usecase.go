@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+)
+
+const (
+	FeeDisplayInclusive = "inclusive"
+	FeeDisplayItemized  = "itemized"
+
+	// Variant prices may move at most this far from the list price.
+	minPriceMultiplier = 0.5
+	maxPriceMultiplier = 2.0
+)
+
+type ExperimentUsecase interface {
+	CreateExperiment(ctx context.Context, exp *entity.PricingExperiment) error
+	GetExperiment(ctx context.Context, experimentID int64) (*entity.PricingExperiment, error)
+	ListExperiments(ctx context.Context, eventID int64) ([]entity.PricingExperiment, error)
+	StopExperiment(ctx context.Context, experimentID int64) error
+	GetResults(ctx context.Context, experimentID int64) (*entity.ExperimentResults, error)
+	// AssignVariant returns the variant the user is bucketed into for the
+	// event's active experiment, or nil when no experiment is running.
+	AssignVariant(ctx context.Context, eventID, userID int64) (*entity.PricingVariant, error)
+}
+
+type experimentUsecase struct {
+	experimentRepo repository.ExperimentRepository
+	eventRepo      repository.EventRepository
+	auditor        AuditUsecase
+	contextTimeout time.Duration
+}
+
+func NewExperimentUsecase(
+	experimentRepo repository.ExperimentRepository,
+	eventRepo repository.EventRepository,
+	auditor AuditUsecase,
+	timeout time.Duration,
+) ExperimentUsecase {
+	return &experimentUsecase{
+		experimentRepo: experimentRepo,
+		eventRepo:      eventRepo,
+		auditor:        auditor,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *experimentUsecase) CreateExperiment(ctx context.Context, exp *entity.PricingExperiment) error {
+	logger.FromContext(ctx).Debug("usecase: creating pricing experiment",
+		logger.Int64("event_id", exp.EventID),
+		logger.Int("variants", len(exp.Variants)),
+	)
+
+	if err := validateVariants(exp.Variants); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.eventRepo.GetEventByID(ctx, exp.EventID); err != nil {
+		return entity.ErrNotFound
+	}
+
+	if err := uc.experimentRepo.CreateExperiment(ctx, exp); err != nil {
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionExperimentStart, "pricing_experiment", exp.ID, map[string]interface{}{
+		"event_id": exp.EventID,
+		"variants": len(exp.Variants),
+	})
+	return nil
+}
+
+func (uc *experimentUsecase) GetExperiment(ctx context.Context, experimentID int64) (*entity.PricingExperiment, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.experimentRepo.GetExperimentByID(ctx, experimentID)
+}
+
+func (uc *experimentUsecase) ListExperiments(ctx context.Context, eventID int64) ([]entity.PricingExperiment, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.experimentRepo.GetExperimentsByEvent(ctx, eventID)
+}
+
+func (uc *experimentUsecase) StopExperiment(ctx context.Context, experimentID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.experimentRepo.StopExperiment(ctx, experimentID); err != nil {
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionExperimentStop, "pricing_experiment", experimentID, nil)
+	return nil
+}
+
+// GetResults derives conversion and revenue per exposed user for each variant.
+func (uc *experimentUsecase) GetResults(ctx context.Context, experimentID int64) (*entity.ExperimentResults, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	exp, err := uc.experimentRepo.GetExperimentByID(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := uc.experimentRepo.GetVariantResults(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range variants {
+		v := &variants[i]
+		if v.Exposures > 0 {
+			v.ConversionRate = math.Round(float64(v.PaidBookings)/float64(v.Exposures)*10000) / 10000
+			v.RevenuePerExposure = math.Round(v.Revenue/float64(v.Exposures)*100) / 100
+		}
+	}
+
+	return &entity.ExperimentResults{Experiment: *exp, Variants: variants}, nil
+}
+
+func (uc *experimentUsecase) AssignVariant(ctx context.Context, eventID, userID int64) (*entity.PricingVariant, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	exp, err := uc.experimentRepo.GetActiveExperimentByEvent(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	variant := bucketVariant(exp.ID, userID, exp.Variants)
+	if variant == nil {
+		return nil, nil
+	}
+
+	// Exposure tracking is best effort; the assignment itself is deterministic
+	if err := uc.experimentRepo.RecordExposure(ctx, exp.ID, variant.ID, userID); err != nil {
+		logger.FromContext(ctx).Warn("usecase: failed to record pricing exposure",
+			logger.Int64("experiment_id", exp.ID),
+			logger.Int64("user_id", userID),
+			logger.Err(err),
+		)
+	}
+
+	return variant, nil
+}
+
+// bucketVariant deterministically maps a user to a variant by hashing the
+// experiment and user IDs, so a user sees the same variant on every visit
+// without an assignment being stored first.
+func bucketVariant(experimentID, userID int64, variants []entity.PricingVariant) *entity.PricingVariant {
+	var total uint32
+	for _, v := range variants {
+		total += uint32(v.Weight)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(strconv.FormatInt(experimentID, 10) + ":" + strconv.FormatInt(userID, 10)))
+	bucket := h.Sum32() % total
+
+	for i := range variants {
+		if bucket < uint32(variants[i].Weight) {
+			return &variants[i]
+		}
+		bucket -= uint32(variants[i].Weight)
+	}
+	return nil
+}
+
+func validateVariants(variants []entity.PricingVariant) error {
+	if len(variants) < 2 {
+		return entity.ErrInvalidExperiment
+	}
+	seen := make(map[string]bool, len(variants))
+	for i := range variants {
+		v := &variants[i]
+		if v.FeeDisplay == "" {
+			v.FeeDisplay = FeeDisplayInclusive
+		}
+		if v.Key == "" || seen[v.Key] || v.Weight <= 0 ||
+			v.PriceMultiplier < minPriceMultiplier || v.PriceMultiplier > maxPriceMultiplier ||
+			(v.FeeDisplay != FeeDisplayInclusive && v.FeeDisplay != FeeDisplayItemized) {
+			return entity.ErrInvalidExperiment
+		}
+		seen[v.Key] = true
+	}
+	return nil
+}
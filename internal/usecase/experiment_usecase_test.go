@@ -0,0 +1,122 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testVariants() []entity.PricingVariant {
+	return []entity.PricingVariant{
+		{ID: 1, Key: "control", Weight: 50, PriceMultiplier: 1},
+		{ID: 2, Key: "discount", Weight: 50, PriceMultiplier: 0.9, FeeDisplay: usecase.FeeDisplayItemized},
+	}
+}
+
+func TestExperimentUsecase_CreateExperiment(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockExpRepo := new(mocks.MockExperimentRepo)
+		mockEventRepo := new(mocks.MockEventRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+
+		exp := &entity.PricingExperiment{EventID: 10, Name: "Fee display", Variants: testVariants()}
+		mockEventRepo.On("GetEventByID", mock.Anything, int64(10)).Return(&entity.Event{ID: 10}, nil).Once()
+		mockExpRepo.On("CreateExperiment", mock.Anything, exp).Return(nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionExperimentStart, "pricing_experiment", mock.Anything, mock.Anything).Once()
+
+		uc := usecase.NewExperimentUsecase(mockExpRepo, mockEventRepo, mockAudit, 2*time.Second)
+		err := uc.CreateExperiment(context.Background(), exp)
+
+		assert.NoError(t, err)
+		assert.Equal(t, usecase.FeeDisplayInclusive, exp.Variants[0].FeeDisplay)
+		mockExpRepo.AssertExpectations(t)
+		mockAudit.AssertExpectations(t)
+	})
+
+	invalid := map[string][]entity.PricingVariant{
+		"Single Variant": testVariants()[:1],
+		"Duplicate Key": {
+			{Key: "a", Weight: 1, PriceMultiplier: 1},
+			{Key: "a", Weight: 1, PriceMultiplier: 1},
+		},
+		"Multiplier Out Of Range": {
+			{Key: "a", Weight: 1, PriceMultiplier: 1},
+			{Key: "b", Weight: 1, PriceMultiplier: 3},
+		},
+		"Zero Weight": {
+			{Key: "a", Weight: 1, PriceMultiplier: 1},
+			{Key: "b", Weight: 0, PriceMultiplier: 1},
+		},
+	}
+	for name, variants := range invalid {
+		t.Run(name, func(t *testing.T) {
+			uc := usecase.NewExperimentUsecase(new(mocks.MockExperimentRepo), new(mocks.MockEventRepo), new(mocks.MockAuditUsecase), 2*time.Second)
+			err := uc.CreateExperiment(context.Background(), &entity.PricingExperiment{EventID: 10, Variants: variants})
+			assert.ErrorIs(t, err, entity.ErrInvalidExperiment)
+		})
+	}
+}
+
+func TestExperimentUsecase_AssignVariant(t *testing.T) {
+	t.Run("Stable Bucketing", func(t *testing.T) {
+		mockExpRepo := new(mocks.MockExperimentRepo)
+		exp := &entity.PricingExperiment{ID: 4, EventID: 10, Status: "ACTIVE", Variants: testVariants()}
+		mockExpRepo.On("GetActiveExperimentByEvent", mock.Anything, int64(10)).Return(exp, nil)
+		mockExpRepo.On("RecordExposure", mock.Anything, int64(4), mock.Anything, mock.Anything).Return(nil)
+
+		uc := usecase.NewExperimentUsecase(mockExpRepo, new(mocks.MockEventRepo), new(mocks.MockAuditUsecase), 2*time.Second)
+
+		counts := map[string]int{}
+		for userID := int64(1); userID <= 1000; userID++ {
+			first, err := uc.AssignVariant(context.Background(), 10, userID)
+			require.NoError(t, err)
+			require.NotNil(t, first)
+
+			again, err := uc.AssignVariant(context.Background(), 10, userID)
+			require.NoError(t, err)
+			assert.Equal(t, first.Key, again.Key, "user %d switched variants", userID)
+			counts[first.Key]++
+		}
+
+		// A 50/50 split over 1000 users should land well within 40-60%
+		assert.InDelta(t, 500, counts["control"], 100)
+		assert.InDelta(t, 500, counts["discount"], 100)
+	})
+
+	t.Run("No Active Experiment", func(t *testing.T) {
+		mockExpRepo := new(mocks.MockExperimentRepo)
+		mockExpRepo.On("GetActiveExperimentByEvent", mock.Anything, int64(10)).Return(nil, entity.ErrNotFound).Once()
+
+		uc := usecase.NewExperimentUsecase(mockExpRepo, new(mocks.MockEventRepo), new(mocks.MockAuditUsecase), 2*time.Second)
+		variant, err := uc.AssignVariant(context.Background(), 10, 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, variant)
+	})
+}
+
+func TestExperimentUsecase_GetResults(t *testing.T) {
+	mockExpRepo := new(mocks.MockExperimentRepo)
+	exp := &entity.PricingExperiment{ID: 4, EventID: 10, Variants: testVariants()}
+	mockExpRepo.On("GetExperimentByID", mock.Anything, int64(4)).Return(exp, nil).Once()
+	mockExpRepo.On("GetVariantResults", mock.Anything, int64(4)).Return([]entity.VariantResult{
+		{VariantID: 1, Key: "control", Exposures: 200, Bookings: 30, PaidBookings: 20, Revenue: 2000000},
+		{VariantID: 2, Key: "discount", Exposures: 0},
+	}, nil).Once()
+
+	uc := usecase.NewExperimentUsecase(mockExpRepo, new(mocks.MockEventRepo), new(mocks.MockAuditUsecase), 2*time.Second)
+	results, err := uc.GetResults(context.Background(), 4)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0.1, results.Variants[0].ConversionRate)
+	assert.Equal(t, 10000.0, results.Variants[0].RevenuePerExposure)
+	assert.Zero(t, results.Variants[1].ConversionRate)
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/i18n"
+	"ticres/pkg/logger"
+)
+
+const (
+	// velocityWindow is the trailing period sales velocity is measured over.
+	velocityWindow = 7 * 24 * time.Hour
+
+	// An event lags its target when it has sold less than laggingRatio of the
+	// seats a straight-line pace to sell-out would have sold by now. Events
+	// less than minElapsedRatio through their sales period are not judged yet.
+	laggingRatio    = 0.8
+	minElapsedRatio = 0.2
+
+	// boostCooldown keeps organizers from receiving more than one nudge a day per event.
+	boostCooldown = 24 * time.Hour
+)
+
+type ForecastUsecase interface {
+	GetForecast(ctx context.Context, eventID, organizerID int64) (*entity.SalesForecast, error)
+	// NotifyLaggingEvents sends a marketing-boost notification to organizers
+	// whose upcoming events are selling slower than target.
+	NotifyLaggingEvents(ctx context.Context) (int, error)
+}
+
+type forecastUsecase struct {
+	eventRepo      repository.EventRepository
+	analyticsRepo  repository.AnalyticsRepository
+	userRepo       repository.UserRepository
+	notifier       NotificationService
+	contextTimeout time.Duration
+}
+
+func NewForecastUsecase(
+	eventRepo repository.EventRepository,
+	analyticsRepo repository.AnalyticsRepository,
+	userRepo repository.UserRepository,
+	notifier NotificationService,
+	timeout time.Duration,
+) ForecastUsecase {
+	return &forecastUsecase{
+		eventRepo:      eventRepo,
+		analyticsRepo:  analyticsRepo,
+		userRepo:       userRepo,
+		notifier:       notifier,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *forecastUsecase) GetForecast(ctx context.Context, eventID, organizerID int64) (*entity.SalesForecast, error) {
+	logger.FromContext(ctx).Debug("usecase: computing sales forecast",
+		logger.Int64("event_id", eventID),
+		logger.Int64("organizer_id", organizerID),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, entity.ErrNotFound
+	}
+	// Organizers only see their own events
+	if event.OrganizerID == nil || *event.OrganizerID != organizerID {
+		return nil, entity.ErrNotFound
+	}
+
+	return uc.forecast(ctx, event)
+}
+
+func (uc *forecastUsecase) NotifyLaggingEvents(ctx context.Context) (int, error) {
+	events, err := uc.analyticsRepo.GetBoostCandidates(ctx, boostCooldown)
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+	for i := range events {
+		event := &events[i]
+		fc, err := uc.forecast(ctx, event)
+		if err != nil {
+			logger.FromContext(ctx).Warn("usecase: forecast failed, skipping boost check", logger.Int64("event_id", event.ID), logger.Err(err))
+			continue
+		}
+		if !fc.LaggingTarget {
+			continue
+		}
+
+		organizer, err := uc.userRepo.GetUserByID(ctx, int(*event.OrganizerID))
+		if err != nil {
+			logger.FromContext(ctx).Warn("usecase: organizer not found for boost", logger.Int64("event_id", event.ID), logger.Err(err))
+			continue
+		}
+
+		uc.notifier.SendNotification(ctx, 0, organizer.Email, i18n.Msg(
+			"Ticket sales for \"%s\" are below target: %s of the %s target seats sold. Consider extra promotion to boost sales.",
+			event.Name, fc.Sold, fc.TargetSold,
+		))
+		if err := uc.analyticsRepo.MarkMarketingBoostSent(ctx, event.ID); err != nil {
+			continue
+		}
+		sent++
+
+		logger.FromContext(ctx).Info("usecase: marketing boost sent",
+			logger.Int64("event_id", event.ID),
+			logger.Int("sold", fc.Sold),
+			logger.Int("target", fc.TargetSold),
+		)
+	}
+
+	return sent, nil
+}
+
+// forecast projects the sell-out time from the trailing sales velocity and
+// compares sales so far against a straight-line pace from the event's creation
+// (when tickets go on sale) to the event date.
+func (uc *forecastUsecase) forecast(ctx context.Context, event *entity.Event) (*entity.SalesForecast, error) {
+	now := time.Now()
+
+	windowStart := now.Add(-velocityWindow)
+	if event.CreatedAt.After(windowStart) {
+		windowStart = event.CreatedAt
+	}
+
+	stats, err := uc.analyticsRepo.GetSalesStats(ctx, event.ID, windowStart)
+	if err != nil {
+		return nil, err
+	}
+
+	fc := &entity.SalesForecast{
+		EventID:     event.ID,
+		Capacity:    event.Capacity,
+		Sold:        stats.TotalSold,
+		Remaining:   event.Capacity - stats.TotalSold,
+		GeneratedAt: now,
+	}
+	if fc.Remaining <= 0 {
+		fc.Remaining = 0
+		fc.SoldOut = true
+		fc.SellsOutBeforeEvent = true
+		fc.TargetSold = event.Capacity
+		return fc, nil
+	}
+
+	windowHours := math.Max(now.Sub(windowStart).Hours(), 1)
+	fc.VelocityPerHour = math.Round(float64(stats.SoldInWindow)/windowHours*100) / 100
+
+	if stats.SoldInWindow > 0 {
+		hoursLeft := float64(fc.Remaining) * windowHours / float64(stats.SoldInWindow)
+		eta := now.Add(time.Duration(hoursLeft * float64(time.Hour)))
+		fc.SellOutETA = &eta
+		fc.SellsOutBeforeEvent = eta.Before(event.Date)
+	}
+
+	salesPeriod := event.Date.Sub(event.CreatedAt)
+	if salesPeriod > 0 {
+		elapsed := math.Min(float64(now.Sub(event.CreatedAt))/float64(salesPeriod), 1)
+		fc.TargetSold = int(math.Round(elapsed * float64(event.Capacity)))
+		fc.LaggingTarget = elapsed >= minElapsedRatio && float64(fc.Sold) < laggingRatio*float64(fc.TargetSold)
+	}
+
+	return fc, nil
+}
@@ -0,0 +1,138 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestForecastUsecase_GetForecast(t *testing.T) {
+	organizerID := int64(5)
+	otherID := int64(6)
+
+	// On sale for 10 days, event in 10 days: target is half the capacity
+	newEvent := func(owner *int64) *entity.Event {
+		return &entity.Event{
+			ID:          1,
+			Capacity:    100,
+			OrganizerID: owner,
+			CreatedAt:   time.Now().Add(-10 * 24 * time.Hour),
+			Date:        time.Now().Add(10 * 24 * time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		event   *entity.Event
+		stats   *entity.SalesStats
+		wantErr error
+		check   func(t *testing.T, fc *entity.SalesForecast)
+	}{
+		{
+			name:  "On Pace To Sell Out",
+			event: newEvent(&organizerID),
+			stats: &entity.SalesStats{TotalSold: 60, SoldInWindow: 42},
+			check: func(t *testing.T, fc *entity.SalesForecast) {
+				assert.Equal(t, 40, fc.Remaining)
+				assert.Equal(t, 0.25, fc.VelocityPerHour)
+				assert.NotNil(t, fc.SellOutETA)
+				assert.True(t, fc.SellsOutBeforeEvent)
+				assert.Equal(t, 50, fc.TargetSold)
+				assert.False(t, fc.LaggingTarget)
+			},
+		},
+		{
+			name:  "Lagging Target",
+			event: newEvent(&organizerID),
+			stats: &entity.SalesStats{TotalSold: 10, SoldInWindow: 7},
+			check: func(t *testing.T, fc *entity.SalesForecast) {
+				assert.False(t, fc.SellsOutBeforeEvent)
+				assert.True(t, fc.LaggingTarget)
+			},
+		},
+		{
+			name:  "No Sales Yet",
+			event: newEvent(&organizerID),
+			stats: &entity.SalesStats{},
+			check: func(t *testing.T, fc *entity.SalesForecast) {
+				assert.Nil(t, fc.SellOutETA)
+				assert.Equal(t, float64(0), fc.VelocityPerHour)
+			},
+		},
+		{
+			name:  "Sold Out",
+			event: newEvent(&organizerID),
+			stats: &entity.SalesStats{TotalSold: 100, SoldInWindow: 20},
+			check: func(t *testing.T, fc *entity.SalesForecast) {
+				assert.True(t, fc.SoldOut)
+				assert.Equal(t, 0, fc.Remaining)
+			},
+		},
+		{
+			name:    "Other Organizer's Event",
+			event:   newEvent(&otherID),
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEventRepo := new(mocks.MockEventRepo)
+			mockAnalyticsRepo := new(mocks.MockAnalyticsRepo)
+
+			mockEventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(tt.event, nil).Once()
+			if tt.stats != nil {
+				mockAnalyticsRepo.On("GetSalesStats", mock.Anything, int64(1), mock.AnythingOfType("time.Time")).Return(tt.stats, nil).Once()
+			}
+
+			uc := usecase.NewForecastUsecase(mockEventRepo, mockAnalyticsRepo, new(mocks.MockUserRepo), new(mocks.MockNotificationService), 2*time.Second)
+			fc, err := uc.GetForecast(context.Background(), 1, organizerID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				tt.check(t, fc)
+			}
+			mockEventRepo.AssertExpectations(t)
+			mockAnalyticsRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestForecastUsecase_NotifyLaggingEvents(t *testing.T) {
+	organizerID := int64(5)
+	events := []entity.Event{
+		{ID: 1, Name: "Slow Show", Capacity: 100, OrganizerID: &organizerID,
+			CreatedAt: time.Now().Add(-10 * 24 * time.Hour), Date: time.Now().Add(10 * 24 * time.Hour)},
+		{ID: 2, Name: "Hot Show", Capacity: 100, OrganizerID: &organizerID,
+			CreatedAt: time.Now().Add(-10 * 24 * time.Hour), Date: time.Now().Add(10 * 24 * time.Hour)},
+	}
+
+	mockAnalyticsRepo := new(mocks.MockAnalyticsRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	mockNotif := new(mocks.MockNotificationService)
+
+	mockAnalyticsRepo.On("GetBoostCandidates", mock.Anything, 24*time.Hour).Return(events, nil).Once()
+	mockAnalyticsRepo.On("GetSalesStats", mock.Anything, int64(1), mock.Anything).Return(&entity.SalesStats{TotalSold: 5, SoldInWindow: 5}, nil).Once()
+	mockAnalyticsRepo.On("GetSalesStats", mock.Anything, int64(2), mock.Anything).Return(&entity.SalesStats{TotalSold: 70, SoldInWindow: 40}, nil).Once()
+	mockUserRepo.On("GetUserByID", mock.Anything, 5).Return(&entity.User{ID: 5, Email: "organizer@mail.com"}, nil).Once()
+	mockNotif.On("SendNotification", mock.Anything, int64(0), "organizer@mail.com", mock.AnythingOfType("i18n.Message")).Once()
+	mockAnalyticsRepo.On("MarkMarketingBoostSent", mock.Anything, int64(1)).Return(nil).Once()
+
+	uc := usecase.NewForecastUsecase(new(mocks.MockEventRepo), mockAnalyticsRepo, mockUserRepo, mockNotif, 2*time.Second)
+	sent, err := uc.NotifyLaggingEvents(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	mockAnalyticsRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockNotif.AssertExpectations(t)
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+)
+
+// FunnelUsecase keeps one event's on-sale from taking all of the booking
+// capacity of the platform. Every event gets a budget of booking requests
+// per second; requests over it are turned away with a time to retry, while
+// users admitted by the waiting room carry a queue token that skips it.
+type FunnelUsecase interface {
+	// Admit counts a hold or booking request for the event and returns an
+	// *entity.EventBusyError once the event's budget for the current second
+	// is spent. A valid queue token for the user and event skips the budget.
+	Admit(ctx context.Context, userID, eventID int64, queueToken string) error
+	// IssueQueueTokens admits each user to the event's booking funnel for
+	// ttl. Zero ttl uses entity.DefaultQueueTokenTTL.
+	IssueQueueTokens(ctx context.Context, eventID int64, userIDs []int64, ttl time.Duration) ([]entity.QueueToken, error)
+}
+
+const (
+	// funnelBudgetTTL is how long an event's budget is kept in memory, so
+	// requests turned away never reach the database. A changed limit takes
+	// effect on every instance within it.
+	funnelBudgetTTL = 10 * time.Second
+	// funnelRetryJitter spreads out the Retry-After of rejected requests
+	// so they don't all come back at the start of the next second.
+	funnelRetryJitter = 2 * time.Second
+)
+
+type funnelBudget struct {
+	perSecond int
+	expiresAt time.Time
+}
+
+type funnelUsecase struct {
+	eventRepo      repository.EventRepository
+	limiter        repository.FunnelLimiter
+	auditor        AuditUsecase
+	defaultBudget  int
+	queueSecret    []byte
+	contextTimeout time.Duration
+
+	mu      sync.Mutex
+	budgets map[int64]funnelBudget
+}
+
+// NewFunnelUsecase builds the booking funnel. defaultBudget is the
+// requests per second of events without their own limit; zero leaves them
+// unlimited. queueSecret signs queue tokens.
+func NewFunnelUsecase(
+	eventRepo repository.EventRepository,
+	limiter repository.FunnelLimiter,
+	auditor AuditUsecase,
+	defaultBudget int,
+	queueSecret string,
+	timeout time.Duration,
+) FunnelUsecase {
+	return &funnelUsecase{
+		eventRepo:      eventRepo,
+		limiter:        limiter,
+		auditor:        auditor,
+		defaultBudget:  defaultBudget,
+		queueSecret:    []byte(queueSecret),
+		contextTimeout: timeout,
+		budgets:        make(map[int64]funnelBudget),
+	}
+}
+
+func (uc *funnelUsecase) Admit(ctx context.Context, userID, eventID int64, queueToken string) error {
+	if queueToken != "" {
+		if err := uc.verifyQueueToken(queueToken, userID, eventID); err == nil {
+			return nil
+		}
+		logger.FromContext(ctx).Debug("usecase: ignoring invalid queue token",
+			logger.Int64("user_id", userID),
+			logger.Int64("event_id", eventID),
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	perSecond, err := uc.budget(ctx, eventID)
+	if err != nil {
+		// The funnel must not stop sales on its own failure; the booking
+		// itself reports a missing event
+		logger.FromContext(ctx).Warn("usecase: failed to look up booking budget, admitting request",
+			logger.Int64("event_id", eventID),
+			logger.Err(err),
+		)
+		return nil
+	}
+	if perSecond <= 0 {
+		return nil
+	}
+
+	wait, err := uc.limiter.Take(ctx, eventID, perSecond)
+	if err != nil {
+		logger.FromContext(ctx).Warn("usecase: booking budget unavailable, admitting request",
+			logger.Int64("event_id", eventID),
+			logger.Err(err),
+		)
+		return nil
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	logger.FromContext(ctx).Info("usecase: booking request over the event budget",
+		logger.Int64("user_id", userID),
+		logger.Int64("event_id", eventID),
+		logger.Int("per_second", perSecond),
+	)
+	return &entity.EventBusyError{
+		EventID:    eventID,
+		RetryAfter: wait + time.Duration(rand.Int63n(int64(funnelRetryJitter))),
+	}
+}
+
+// budget returns the event's requests per second, from memory while it is
+// fresh.
+func (uc *funnelUsecase) budget(ctx context.Context, eventID int64) (int, error) {
+	uc.mu.Lock()
+	cached, ok := uc.budgets[eventID]
+	uc.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.perSecond, nil
+	}
+
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return 0, err
+	}
+	perSecond := uc.defaultBudget
+	if event.BookingRateLimit != nil {
+		perSecond = *event.BookingRateLimit
+	}
+
+	uc.mu.Lock()
+	uc.budgets[eventID] = funnelBudget{perSecond: perSecond, expiresAt: time.Now().Add(funnelBudgetTTL)}
+	uc.mu.Unlock()
+	return perSecond, nil
+}
+
+func (uc *funnelUsecase) IssueQueueTokens(ctx context.Context, eventID int64, userIDs []int64, ttl time.Duration) ([]entity.QueueToken, error) {
+	if len(userIDs) == 0 || len(userIDs) > entity.MaxQueueTokensPerRequest {
+		return nil, fmt.Errorf("%w: give between 1 and %d users", entity.ErrInvalidQueueToken, entity.MaxQueueTokensPerRequest)
+	}
+	if ttl == 0 {
+		ttl = entity.DefaultQueueTokenTTL
+	}
+	if ttl < 0 || ttl > entity.MaxQueueTokenTTL {
+		return nil, fmt.Errorf("%w: ttl must be at most %s", entity.ErrInvalidQueueToken, entity.MaxQueueTokenTTL)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil || event.IsDeleted() {
+		return nil, entity.ErrNotFound
+	}
+
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	tokens := make([]entity.QueueToken, len(userIDs))
+	for i, userID := range userIDs {
+		tokens[i] = entity.QueueToken{
+			UserID:    userID,
+			Token:     uc.signQueueToken(eventID, userID, expiresAt),
+			ExpiresAt: expiresAt,
+		}
+	}
+
+	logger.FromContext(ctx).Info("usecase: queue tokens issued",
+		logger.Int64("event_id", eventID),
+		logger.Int("count", len(tokens)),
+	)
+	uc.auditor.Record(ctx, ActionQueueTokenIssue, "event", eventID, map[string]interface{}{
+		"count":      len(tokens),
+		"expires_at": expiresAt,
+	})
+	return tokens, nil
+}
+
+// signQueueToken returns "<eventID>.<userID>.<unix expiry>.<signature>". The
+// signed message is prefixed so no other token signed with the same secret
+// can pass as a queue token.
+func (uc *funnelUsecase) signQueueToken(eventID, userID int64, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d.%d.%d", eventID, userID, expiresAt.Unix())
+	return payload + "." + base64.RawURLEncoding.EncodeToString(uc.queueTokenMAC(payload))
+}
+
+func (uc *funnelUsecase) queueTokenMAC(payload string) []byte {
+	mac := hmac.New(sha256.New, uc.queueSecret)
+	mac.Write([]byte("queue-token:" + payload))
+	return mac.Sum(nil)
+}
+
+// verifyQueueToken checks that token is signed, unexpired and issued to
+// userID for eventID.
+func (uc *funnelUsecase) verifyQueueToken(token string, userID, eventID int64) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 {
+		return entity.ErrInvalidQueueToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil || !hmac.Equal(sig, uc.queueTokenMAC(strings.Join(parts[:3], "."))) {
+		return entity.ErrInvalidQueueToken
+	}
+
+	if parts[0] != strconv.FormatInt(eventID, 10) || parts[1] != strconv.FormatInt(userID, 10) {
+		return entity.ErrInvalidQueueToken
+	}
+	expiresAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || time.Now().Unix() >= expiresAt {
+		return entity.ErrInvalidQueueToken
+	}
+	return nil
+}
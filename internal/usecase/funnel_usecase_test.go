@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFunnelUsecase_Admit(t *testing.T) {
+	eventLimit := 5
+
+	tests := []struct {
+		name          string
+		defaultBudget int
+		event         *entity.Event
+		eventErr      error
+		wantBudget    int
+		wait          time.Duration
+		takeErr       error
+		wantBusy      bool
+	}{
+		{name: "Under Budget", defaultBudget: 100, event: &entity.Event{ID: 7}, wantBudget: 100},
+		{name: "Over Budget", defaultBudget: 100, event: &entity.Event{ID: 7}, wantBudget: 100, wait: 300 * time.Millisecond, wantBusy: true},
+		{name: "Event Budget Overrides Default", defaultBudget: 100, event: &entity.Event{ID: 7, BookingRateLimit: &eventLimit}, wantBudget: 5, wait: time.Second, wantBusy: true},
+		{name: "No Budget", defaultBudget: 0, event: &entity.Event{ID: 7}},
+		{name: "Limiter Down - Admitted", defaultBudget: 100, event: &entity.Event{ID: 7}, wantBudget: 100, takeErr: errors.New("redis down")},
+		{name: "Event Lookup Failed - Admitted", defaultBudget: 100, eventErr: errors.New("db down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEventRepo := new(mocks.MockEventRepo)
+			mockLimiter := new(mocks.MockFunnelLimiter)
+
+			if tt.eventErr != nil {
+				mockEventRepo.On("GetEventByID", mock.Anything, int64(7)).Return(nil, tt.eventErr).Once()
+			} else {
+				mockEventRepo.On("GetEventByID", mock.Anything, int64(7)).Return(tt.event, nil).Once()
+			}
+			if tt.wantBudget > 0 {
+				mockLimiter.On("Take", mock.Anything, int64(7), tt.wantBudget).Return(tt.wait, tt.takeErr).Once()
+			}
+
+			uc := usecase.NewFunnelUsecase(mockEventRepo, mockLimiter, new(mocks.MockAuditUsecase), tt.defaultBudget, "secret", 2*time.Second)
+			err := uc.Admit(context.Background(), 42, 7, "")
+
+			if tt.wantBusy {
+				assert.ErrorIs(t, err, entity.ErrEventBusy)
+				var busy *entity.EventBusyError
+				if assert.ErrorAs(t, err, &busy) {
+					assert.Equal(t, int64(7), busy.EventID)
+					assert.GreaterOrEqual(t, busy.RetryAfter, tt.wait)
+					assert.Less(t, busy.RetryAfter, tt.wait+2*time.Second)
+					assert.GreaterOrEqual(t, busy.RetryAfterSeconds(), 1)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.wantBudget == 0 {
+				mockLimiter.AssertNotCalled(t, "Take", mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockEventRepo.AssertExpectations(t)
+			mockLimiter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFunnelUsecase_Admit_CachesBudget(t *testing.T) {
+	mockEventRepo := new(mocks.MockEventRepo)
+	mockLimiter := new(mocks.MockFunnelLimiter)
+
+	mockEventRepo.On("GetEventByID", mock.Anything, int64(7)).Return(&entity.Event{ID: 7}, nil).Once()
+	mockLimiter.On("Take", mock.Anything, int64(7), 100).Return(time.Duration(0), nil).Times(3)
+
+	uc := usecase.NewFunnelUsecase(mockEventRepo, mockLimiter, new(mocks.MockAuditUsecase), 100, "secret", 2*time.Second)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, uc.Admit(context.Background(), 42, 7, ""))
+	}
+
+	mockEventRepo.AssertExpectations(t)
+	mockLimiter.AssertExpectations(t)
+}
+
+func TestFunnelUsecase_QueueToken(t *testing.T) {
+	mockEventRepo := new(mocks.MockEventRepo)
+	mockAudit := new(mocks.MockAuditUsecase)
+
+	mockEventRepo.On("GetEventByID", mock.Anything, int64(7)).Return(&entity.Event{ID: 7}, nil)
+	mockAudit.On("Record", mock.Anything, usecase.ActionQueueTokenIssue, "event", int64(7), mock.Anything).Once()
+
+	uc := usecase.NewFunnelUsecase(mockEventRepo, new(mocks.MockFunnelLimiter), mockAudit, 100, "secret", 2*time.Second)
+	tokens, err := uc.IssueQueueTokens(context.Background(), 7, []int64{42, 43}, 0)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 2)
+	assert.Equal(t, int64(42), tokens[0].UserID)
+	assert.WithinDuration(t, time.Now().Add(entity.DefaultQueueTokenTTL), tokens[0].ExpiresAt, 2*time.Second)
+	mockAudit.AssertExpectations(t)
+
+	tests := []struct {
+		name     string
+		userID   int64
+		eventID  int64
+		token    string
+		wantSkip bool
+	}{
+		{name: "Valid Token Skips Budget", userID: 42, eventID: 7, token: tokens[0].Token, wantSkip: true},
+		{name: "Other User's Token", userID: 43, eventID: 7, token: tokens[0].Token},
+		{name: "Other Event", userID: 42, eventID: 8, token: tokens[0].Token},
+		{name: "Tampered Token", userID: 42, eventID: 7, token: tokens[0].Token + "x"},
+		{name: "Signed With Another Secret", userID: 42, eventID: 7, token: "7.42.4102444800.c2lnbmF0dXJl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLimiter := new(mocks.MockFunnelLimiter)
+			eventRepo := new(mocks.MockEventRepo)
+			eventRepo.On("GetEventByID", mock.Anything, tt.eventID).Return(&entity.Event{ID: tt.eventID}, nil)
+			mockLimiter.On("Take", mock.Anything, tt.eventID, 100).Return(time.Second, nil)
+
+			admit := usecase.NewFunnelUsecase(eventRepo, mockLimiter, new(mocks.MockAuditUsecase), 100, "secret", 2*time.Second)
+			err := admit.Admit(context.Background(), tt.userID, tt.eventID, tt.token)
+
+			if tt.wantSkip {
+				assert.NoError(t, err)
+				mockLimiter.AssertNotCalled(t, "Take", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.ErrorIs(t, err, entity.ErrEventBusy)
+			}
+		})
+	}
+}
+
+func TestFunnelUsecase_IssueQueueTokens_Invalid(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name    string
+		userIDs []int64
+		ttl     time.Duration
+		event   *entity.Event
+		wantErr error
+	}{
+		{name: "No Users", userIDs: nil, wantErr: entity.ErrInvalidQueueToken},
+		{name: "Too Many Users", userIDs: make([]int64, entity.MaxQueueTokensPerRequest+1), wantErr: entity.ErrInvalidQueueToken},
+		{name: "TTL Too Long", userIDs: []int64{42}, ttl: 2 * time.Hour, wantErr: entity.ErrInvalidQueueToken},
+		{name: "Deleted Event", userIDs: []int64{42}, event: &entity.Event{ID: 7, DeletedAt: &deletedAt}, wantErr: entity.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEventRepo := new(mocks.MockEventRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			if tt.event != nil {
+				mockEventRepo.On("GetEventByID", mock.Anything, int64(7)).Return(tt.event, nil).Once()
+			}
+
+			uc := usecase.NewFunnelUsecase(mockEventRepo, new(mocks.MockFunnelLimiter), mockAudit, 100, "secret", 2*time.Second)
+			tokens, err := uc.IssueQueueTokens(context.Background(), 7, tt.userIDs, tt.ttl)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, tokens)
+			mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
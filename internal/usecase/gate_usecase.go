@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// gateKeyPrefix marks gate API keys so they are recognisable in configs.
+const gateKeyPrefix = "gk_"
+
+type GateUsecase interface {
+	// CreateGate registers a turnstile on an event of the organizer. The
+	// gate's API key is set in gate.Key and cannot be retrieved later.
+	CreateGate(ctx context.Context, organizerID int64, gate *entity.Gate) error
+	ListGates(ctx context.Context, organizerID, eventID int64) ([]entity.Gate, error)
+	RevokeGate(ctx context.Context, organizerID, eventID, gateID int64) error
+	// AuthenticateGate returns the active gate the key belongs to, or
+	// ErrInvalidGateKey.
+	AuthenticateGate(ctx context.Context, key string) (*entity.Gate, error)
+	// ScanBatch checks in a batch of scanned codes at the gate's event and
+	// returns a verdict per code, in the order given.
+	ScanBatch(ctx context.Context, gate *entity.Gate, codes []string) ([]entity.ScanVerdict, error)
+}
+
+type gateUsecase struct {
+	gateRepo       repository.GateRepository
+	ticketRepo     repository.TicketRepository
+	eventRepo      repository.EventRepository
+	auditor        AuditUsecase
+	contextTimeout time.Duration
+}
+
+func NewGateUsecase(
+	gateRepo repository.GateRepository,
+	ticketRepo repository.TicketRepository,
+	eventRepo repository.EventRepository,
+	auditor AuditUsecase,
+	timeout time.Duration,
+) GateUsecase {
+	return &gateUsecase{
+		gateRepo:       gateRepo,
+		ticketRepo:     ticketRepo,
+		eventRepo:      eventRepo,
+		auditor:        auditor,
+		contextTimeout: timeout,
+	}
+}
+
+// ownEvent fails with ErrNotFound unless the organizer owns the event.
+func (uc *gateUsecase) ownEvent(ctx context.Context, organizerID, eventID int64) error {
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return entity.ErrNotFound
+	}
+	if event.OrganizerID == nil || *event.OrganizerID != organizerID {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+func (uc *gateUsecase) CreateGate(ctx context.Context, organizerID int64, gate *entity.Gate) error {
+	ctx, span := tracing.Start(ctx, "GateUsecase.CreateGate",
+		attribute.Int64("event_id", gate.EventID),
+	)
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.ownEvent(ctx, organizerID, gate.EventID); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to generate gate key", logger.Err(err))
+		return err
+	}
+	key := gateKeyPrefix + hex.EncodeToString(buf)
+
+	gate.CreatedBy = organizerID
+	if err := uc.gateRepo.CreateGate(ctx, gate, hashToken(key)); err != nil {
+		return err
+	}
+	gate.Key = key
+
+	uc.auditor.Record(ctx, ActionGateCreate, "event", gate.EventID, map[string]interface{}{
+		"gate_id": gate.ID,
+		"name":    gate.Name,
+	})
+
+	logger.FromContext(ctx).Info("usecase: gate created",
+		logger.Int64("event_id", gate.EventID),
+		logger.Int64("gate_id", gate.ID),
+	)
+	return nil
+}
+
+func (uc *gateUsecase) ListGates(ctx context.Context, organizerID, eventID int64) ([]entity.Gate, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.ownEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+	return uc.gateRepo.ListGates(ctx, eventID)
+}
+
+func (uc *gateUsecase) RevokeGate(ctx context.Context, organizerID, eventID, gateID int64) error {
+	ctx, span := tracing.Start(ctx, "GateUsecase.RevokeGate",
+		attribute.Int64("event_id", eventID),
+		attribute.Int64("gate_id", gateID),
+	)
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.ownEvent(ctx, organizerID, eventID); err != nil {
+		return err
+	}
+	if err := uc.gateRepo.RevokeGate(ctx, eventID, gateID); err != nil {
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionGateRevoke, "event", eventID, map[string]interface{}{
+		"gate_id": gateID,
+	})
+
+	logger.FromContext(ctx).Info("usecase: gate revoked",
+		logger.Int64("event_id", eventID),
+		logger.Int64("gate_id", gateID),
+	)
+	return nil
+}
+
+func (uc *gateUsecase) AuthenticateGate(ctx context.Context, key string) (*entity.Gate, error) {
+	if !strings.HasPrefix(key, gateKeyPrefix) {
+		return nil, entity.ErrInvalidGateKey
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	gate, err := uc.gateRepo.UseGate(ctx, hashToken(key))
+	if err != nil {
+		if err == entity.ErrNotFound {
+			logger.FromContext(ctx).Warn("usecase: unknown or revoked gate key")
+			return nil, entity.ErrInvalidGateKey
+		}
+		return nil, err
+	}
+	return gate, nil
+}
+
+func (uc *gateUsecase) ScanBatch(ctx context.Context, gate *entity.Gate, codes []string) ([]entity.ScanVerdict, error) {
+	ctx, span := tracing.Start(ctx, "GateUsecase.ScanBatch",
+		attribute.Int64("event_id", gate.EventID),
+		attribute.Int64("gate_id", gate.ID),
+		attribute.Int("count", len(codes)),
+	)
+	defer span.End()
+
+	if len(codes) == 0 || len(codes) > entity.MaxScanBatch {
+		return nil, entity.ErrInvalidScanBatch
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	scanned, err := uc.ticketRepo.CheckInBatch(ctx, gate.EventID, gate.ID, codes)
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]entity.ScannedCode, len(scanned))
+	for _, sc := range scanned {
+		byCode[sc.Code] = sc
+	}
+
+	verdicts := make([]entity.ScanVerdict, len(codes))
+	admitted := 0
+	for i, code := range codes {
+		v := entity.ScanVerdict{Code: code}
+		sc, ok := byCode[code]
+		t := sc.Ticket
+		switch {
+		case !ok || t == nil:
+			v.Verdict = entity.ScanUnknown
+			if sc.Revoked {
+				v.Verdict = entity.ScanRevoked
+			}
+		case t.EventID != gate.EventID:
+			v.Verdict = entity.ScanWrongEvent
+		case t.BookingStatus != entity.BookingPaid:
+			v.Verdict = entity.ScanNotPaid
+		default:
+			v.TicketID, v.SeatNumber, v.CheckedInAt = t.ID, t.SeatNumber, t.CheckedInAt
+			v.Verdict = entity.ScanAlreadyUsed
+			// A code scanned twice in one batch is only admitted once
+			if sc.Admitted {
+				v.Verdict = entity.ScanAdmitted
+				admitted++
+				sc.Admitted = false
+				byCode[code] = sc
+			}
+		}
+		verdicts[i] = v
+	}
+
+	logger.FromContext(ctx).Info("usecase: scan batch checked in",
+		logger.Int64("event_id", gate.EventID),
+		logger.Int64("gate_id", gate.ID),
+		logger.Int("scanned", len(codes)),
+		logger.Int("admitted", admitted),
+	)
+	return verdicts, nil
+}
@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGateUsecase_CreateGate(t *testing.T) {
+	organizerID := int64(3)
+	otherOrganizer := int64(4)
+
+	t.Run("Success - Key Returned Once, Hash Stored", func(t *testing.T) {
+		mockGateRepo := new(mocks.MockGateRepo)
+		mockEventRepo := new(mocks.MockEventRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+
+		var storedHash string
+		mockEventRepo.On("GetEventByID", mock.Anything, int64(10)).Return(&entity.Event{ID: 10, OrganizerID: &organizerID}, nil)
+		mockGateRepo.On("CreateGate", mock.Anything, mock.AnythingOfType("*entity.Gate"), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*entity.Gate).ID = 5
+				storedHash = args.String(2)
+			}).Return(nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionGateCreate, "event", int64(10),
+			map[string]interface{}{"gate_id": int64(5), "name": "North 3"}).Once()
+
+		u := usecase.NewGateUsecase(mockGateRepo, new(mocks.MockTicketRepo), mockEventRepo, mockAudit, time.Second*2)
+		gate := &entity.Gate{EventID: 10, Name: "North 3"}
+		err := u.CreateGate(context.Background(), organizerID, gate)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(gate.Key, "gk_"))
+		assert.Equal(t, organizerID, gate.CreatedBy)
+		sum := sha256.Sum256([]byte(gate.Key))
+		assert.Equal(t, hex.EncodeToString(sum[:]), storedHash)
+		mockGateRepo.AssertExpectations(t)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Other Organizer's Event", func(t *testing.T) {
+		mockGateRepo := new(mocks.MockGateRepo)
+		mockEventRepo := new(mocks.MockEventRepo)
+
+		mockEventRepo.On("GetEventByID", mock.Anything, int64(10)).Return(&entity.Event{ID: 10, OrganizerID: &otherOrganizer}, nil)
+
+		u := usecase.NewGateUsecase(mockGateRepo, new(mocks.MockTicketRepo), mockEventRepo, new(mocks.MockAuditUsecase), time.Second*2)
+		gate := &entity.Gate{EventID: 10, Name: "North 3"}
+		err := u.CreateGate(context.Background(), organizerID, gate)
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.Empty(t, gate.Key)
+		mockGateRepo.AssertNotCalled(t, "CreateGate", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGateUsecase_AuthenticateGate(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockGateRepo := new(mocks.MockGateRepo)
+		sum := sha256.Sum256([]byte("gk_abc"))
+		mockGateRepo.On("UseGate", mock.Anything, hex.EncodeToString(sum[:])).Return(&entity.Gate{ID: 5, EventID: 10}, nil).Once()
+
+		u := usecase.NewGateUsecase(mockGateRepo, new(mocks.MockTicketRepo), new(mocks.MockEventRepo), new(mocks.MockAuditUsecase), time.Second*2)
+		gate, err := u.AuthenticateGate(context.Background(), "gk_abc")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), gate.ID)
+		mockGateRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Unknown Or Revoked Key", func(t *testing.T) {
+		mockGateRepo := new(mocks.MockGateRepo)
+		mockGateRepo.On("UseGate", mock.Anything, mock.Anything).Return(nil, entity.ErrNotFound).Once()
+
+		u := usecase.NewGateUsecase(mockGateRepo, new(mocks.MockTicketRepo), new(mocks.MockEventRepo), new(mocks.MockAuditUsecase), time.Second*2)
+		_, err := u.AuthenticateGate(context.Background(), "gk_revoked")
+
+		assert.ErrorIs(t, err, entity.ErrInvalidGateKey)
+	})
+
+	t.Run("Failed - Not A Gate Key", func(t *testing.T) {
+		mockGateRepo := new(mocks.MockGateRepo)
+
+		u := usecase.NewGateUsecase(mockGateRepo, new(mocks.MockTicketRepo), new(mocks.MockEventRepo), new(mocks.MockAuditUsecase), time.Second*2)
+		_, err := u.AuthenticateGate(context.Background(), "Bearer eyJhbGciOi")
+
+		assert.ErrorIs(t, err, entity.ErrInvalidGateKey)
+		mockGateRepo.AssertNotCalled(t, "UseGate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGateUsecase_ScanBatch(t *testing.T) {
+	gate := &entity.Gate{ID: 5, EventID: 10}
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	t.Run("Verdict Per Code In Order", func(t *testing.T) {
+		mockTicketRepo := new(mocks.MockTicketRepo)
+		codes := []string{"TCK-ok", "TCK-used", "TCK-other", "TCK-pending", "TCK-old", "TCK-typo", "TCK-ok"}
+		mockTicketRepo.On("CheckInBatch", mock.Anything, int64(10), int64(5), codes).Return([]entity.ScannedCode{
+			{Code: "TCK-ok", Admitted: true, Ticket: &entity.Ticket{ID: 1, EventID: 10, SeatNumber: "A-1", BookingStatus: "PAID", CheckedInAt: &now}},
+			{Code: "TCK-used", Ticket: &entity.Ticket{ID: 2, EventID: 10, BookingStatus: "PAID", CheckedInAt: &earlier}},
+			{Code: "TCK-other", Ticket: &entity.Ticket{ID: 3, EventID: 11, BookingStatus: "PAID"}},
+			{Code: "TCK-pending", Ticket: &entity.Ticket{ID: 4, EventID: 10, BookingStatus: "PENDING"}},
+			{Code: "TCK-old", Revoked: true},
+			{Code: "TCK-typo"},
+		}, nil).Once()
+
+		u := usecase.NewGateUsecase(new(mocks.MockGateRepo), mockTicketRepo, new(mocks.MockEventRepo), new(mocks.MockAuditUsecase), time.Second*2)
+		verdicts, err := u.ScanBatch(context.Background(), gate, codes)
+
+		assert.NoError(t, err)
+		var got []string
+		for _, v := range verdicts {
+			got = append(got, v.Verdict)
+		}
+		assert.Equal(t, []string{
+			entity.ScanAdmitted, entity.ScanAlreadyUsed, entity.ScanWrongEvent, entity.ScanNotPaid,
+			entity.ScanRevoked, entity.ScanUnknown, entity.ScanAlreadyUsed,
+		}, got)
+		assert.Equal(t, "A-1", verdicts[0].SeatNumber)
+		assert.Equal(t, &earlier, verdicts[1].CheckedInAt)
+		assert.Zero(t, verdicts[2].TicketID)
+		mockTicketRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Empty Batch", func(t *testing.T) {
+		mockTicketRepo := new(mocks.MockTicketRepo)
+
+		u := usecase.NewGateUsecase(new(mocks.MockGateRepo), mockTicketRepo, new(mocks.MockEventRepo), new(mocks.MockAuditUsecase), time.Second*2)
+		_, err := u.ScanBatch(context.Background(), gate, nil)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidScanBatch)
+		mockTicketRepo.AssertNotCalled(t, "CheckInBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Batch Too Large", func(t *testing.T) {
+		mockTicketRepo := new(mocks.MockTicketRepo)
+
+		u := usecase.NewGateUsecase(new(mocks.MockGateRepo), mockTicketRepo, new(mocks.MockEventRepo), new(mocks.MockAuditUsecase), time.Second*2)
+		_, err := u.ScanBatch(context.Background(), gate, make([]string, entity.MaxScanBatch+1))
+
+		assert.ErrorIs(t, err, entity.ErrInvalidScanBatch)
+		mockTicketRepo.AssertNotCalled(t, "CheckInBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// inventoryLookback keeps events that started recently in the check, since
+// bookings can still be paid or released around the start time.
+const inventoryLookback = 24 * time.Hour
+
+type InventoryMonitor interface {
+	// CheckInventory verifies the seat accounting invariants of current
+	// events, pages ops about new violations and returns all violations found.
+	CheckInventory(ctx context.Context) ([]entity.InventoryViolation, error)
+	// LastRun returns the outcome of the last check, or nil before the first.
+	LastRun() *entity.ReconciliationRun
+}
+
+type inventoryMonitor struct {
+	inventoryRepo  repository.InventoryRepository
+	notifier       AlertNotifier
+	contextTimeout time.Duration
+
+	mu sync.Mutex
+	// seen holds the violations of the previous run and reported the ones
+	// ops were already paged about, keyed by event and check.
+	seen     map[string]bool
+	reported map[string]bool
+	lastRun  *entity.ReconciliationRun
+}
+
+func NewInventoryMonitor(inventoryRepo repository.InventoryRepository, notifier AlertNotifier, timeout time.Duration) InventoryMonitor {
+	return &inventoryMonitor{
+		inventoryRepo:  inventoryRepo,
+		notifier:       notifier,
+		contextTimeout: timeout,
+		seen:           map[string]bool{},
+		reported:       map[string]bool{},
+	}
+}
+
+func (uc *inventoryMonitor) CheckInventory(ctx context.Context) ([]entity.InventoryViolation, error) {
+	ctx, span := tracing.Start(ctx, "InventoryMonitor.CheckInventory")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	snapshots, err := uc.inventoryRepo.GetInventorySnapshots(ctx, startedAt.Add(-inventoryLookback))
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to load inventory snapshots", logger.Err(err))
+		uc.recordRun(startedAt, 0, err)
+		return nil, err
+	}
+
+	var violations []entity.InventoryViolation
+	for _, s := range snapshots {
+		violations = append(violations, s.Violations()...)
+	}
+	span.SetAttributes(attribute.Int("events", len(snapshots)), attribute.Int("violations", len(violations)))
+
+	uc.recordRun(startedAt, len(violations), nil)
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	current := make(map[string]bool, len(violations))
+	pages := map[int64][]entity.InventoryViolation{}
+	var order []int64
+	for _, v := range violations {
+		key := fmt.Sprintf("%d:%s", v.EventID, v.Check)
+		current[key] = true
+		if uc.reported[key] {
+			continue
+		}
+
+		logger.FromContext(ctx).Warn("usecase: inventory invariant violated",
+			logger.Int64("event_id", v.EventID),
+			logger.String("check", v.Check),
+			logger.String("detail", v.Detail),
+		)
+
+		// A leak can show up for a moment between a booking expiring and
+		// its seats being released, so it must persist for a second run.
+		if !v.Oversell && !uc.seen[key] {
+			continue
+		}
+		if _, ok := pages[v.EventID]; !ok {
+			order = append(order, v.EventID)
+		}
+		pages[v.EventID] = append(pages[v.EventID], v)
+	}
+
+	for key := range uc.reported {
+		if !current[key] {
+			logger.FromContext(ctx).Info("usecase: inventory invariant restored", logger.String("check", key))
+			delete(uc.reported, key)
+		}
+	}
+	uc.seen = current
+
+	// Violations that fail to page are retried on the next run
+	for _, eventID := range order {
+		if err := uc.page(ctx, pages[eventID]); err != nil {
+			logger.FromContext(ctx).Error("usecase: failed to page inventory violation", logger.Int64("event_id", eventID), logger.Err(err))
+			continue
+		}
+		for _, v := range pages[eventID] {
+			uc.reported[fmt.Sprintf("%d:%s", v.EventID, v.Check)] = true
+		}
+	}
+	return violations, nil
+}
+
+func (uc *inventoryMonitor) recordRun(startedAt time.Time, violations int, err error) {
+	run := &entity.ReconciliationRun{
+		Name:       "inventory",
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+		Violations: violations,
+	}
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	uc.mu.Lock()
+	uc.lastRun = run
+	uc.mu.Unlock()
+}
+
+func (uc *inventoryMonitor) LastRun() *entity.ReconciliationRun {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.lastRun == nil {
+		return nil
+	}
+	run := *uc.lastRun
+	return &run
+}
+
+func (uc *inventoryMonitor) page(ctx context.Context, violations []entity.InventoryViolation) error {
+	first := violations[0]
+	subject := fmt.Sprintf("Seat inventory violation: event %d (%s)", first.EventID, first.EventName)
+	for _, v := range violations {
+		if v.Oversell {
+			subject = fmt.Sprintf("OVERSELL: event %d (%s)", first.EventID, first.EventName)
+			break
+		}
+	}
+
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		lines = append(lines, fmt.Sprintf("%s: %s", v.Check, v.Detail))
+	}
+
+	return uc.notifier.Notify(ctx, subject, strings.Join(lines, "\n"))
+}
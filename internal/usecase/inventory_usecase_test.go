@@ -0,0 +1,157 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInventorySnapshot_Violations(t *testing.T) {
+	tests := []struct {
+		name       string
+		snapshot   entity.InventorySnapshot
+		wantChecks []string
+	}{
+		{
+			name:     "Consistent Seated Event",
+			snapshot: entity.InventorySnapshot{Capacity: 100, BookedSeats: 40, HeldItems: 40, HeldSeats: 40},
+		},
+		{
+			name:       "Seat Held By Two Bookings",
+			snapshot:   entity.InventorySnapshot{Capacity: 100, BookedSeats: 40, HeldItems: 41, HeldSeats: 40},
+			wantChecks: []string{"seat_held_twice"},
+		},
+		{
+			name:       "Held Seat Not Flagged Booked",
+			snapshot:   entity.InventorySnapshot{Capacity: 100, BookedSeats: 39, HeldItems: 40, HeldSeats: 40, UnflaggedSeats: 1},
+			wantChecks: []string{"held_seat_not_booked"},
+		},
+		{
+			name:       "Booked Over Capacity",
+			snapshot:   entity.InventorySnapshot{Capacity: 10, BookedSeats: 12, HeldItems: 12, HeldSeats: 12},
+			wantChecks: []string{"held_over_capacity", "booked_over_capacity"},
+		},
+		{
+			name:       "Leaked Seat",
+			snapshot:   entity.InventorySnapshot{Capacity: 100, BookedSeats: 41, HeldItems: 40, HeldSeats: 40},
+			wantChecks: []string{"booked_seat_not_held"},
+		},
+		{
+			name:     "Consistent General Admission",
+			snapshot: entity.InventorySnapshot{AdmissionMode: entity.AdmissionGeneral, Capacity: 500, GeneralSold: 120, GeneralRemaining: 380},
+		},
+		{
+			name:       "General Admission Counter Oversold",
+			snapshot:   entity.InventorySnapshot{AdmissionMode: entity.AdmissionGeneral, Capacity: 500, GeneralSold: 120, GeneralRemaining: 390},
+			wantChecks: []string{"ga_counter_over_capacity"},
+		},
+		{
+			name:       "General Admission Counter Leak",
+			snapshot:   entity.InventorySnapshot{AdmissionMode: entity.AdmissionGeneral, Capacity: 500, GeneralSold: 120, GeneralRemaining: 370},
+			wantChecks: []string{"ga_counter_leak"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checks []string
+			for _, v := range tt.snapshot.Violations() {
+				checks = append(checks, v.Check)
+			}
+			assert.Equal(t, tt.wantChecks, checks)
+		})
+	}
+}
+
+func TestInventoryMonitor_CheckInventory(t *testing.T) {
+	oversold := entity.InventorySnapshot{EventID: 1, EventName: "Konser A", Capacity: 100, BookedSeats: 40, HeldItems: 41, HeldSeats: 40}
+	leaking := entity.InventorySnapshot{EventID: 2, EventName: "Konser B", Capacity: 100, BookedSeats: 41, HeldItems: 40, HeldSeats: 40}
+	healthy := entity.InventorySnapshot{EventID: 1, EventName: "Konser A", Capacity: 100, BookedSeats: 40, HeldItems: 40, HeldSeats: 40}
+
+	t.Run("Oversell Pages Once", func(t *testing.T) {
+		mockRepo := new(mocks.MockInventoryRepo)
+		mockNotifier := new(mocks.MockAlertNotifier)
+		mockRepo.On("GetInventorySnapshots", mock.Anything, mock.AnythingOfType("time.Time")).
+			Return([]entity.InventorySnapshot{oversold}, nil).Twice()
+		mockNotifier.On("Notify", mock.Anything, "OVERSELL: event 1 (Konser A)", mock.Anything).Return(nil).Once()
+
+		u := usecase.NewInventoryMonitor(mockRepo, mockNotifier, time.Second*2)
+		for i := 0; i < 2; i++ {
+			violations, err := u.CheckInventory(context.Background())
+			assert.NoError(t, err)
+			assert.Len(t, violations, 1)
+		}
+
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("Leak Pages When It Persists", func(t *testing.T) {
+		mockRepo := new(mocks.MockInventoryRepo)
+		mockNotifier := new(mocks.MockAlertNotifier)
+		mockRepo.On("GetInventorySnapshots", mock.Anything, mock.Anything).
+			Return([]entity.InventorySnapshot{leaking}, nil).Once()
+
+		u := usecase.NewInventoryMonitor(mockRepo, mockNotifier, time.Second*2)
+		_, err := u.CheckInventory(context.Background())
+		assert.NoError(t, err)
+		mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything)
+
+		mockRepo.On("GetInventorySnapshots", mock.Anything, mock.Anything).
+			Return([]entity.InventorySnapshot{leaking}, nil).Once()
+		mockNotifier.On("Notify", mock.Anything, "Seat inventory violation: event 2 (Konser B)", mock.Anything).Return(nil).Once()
+		_, err = u.CheckInventory(context.Background())
+		assert.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("Failed Page Retried And Restored Violation Pages Again", func(t *testing.T) {
+		mockRepo := new(mocks.MockInventoryRepo)
+		mockNotifier := new(mocks.MockAlertNotifier)
+		u := usecase.NewInventoryMonitor(mockRepo, mockNotifier, time.Second*2)
+
+		mockRepo.On("GetInventorySnapshots", mock.Anything, mock.Anything).Return([]entity.InventorySnapshot{oversold}, nil).Once()
+		mockNotifier.On("Notify", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("webhook down")).Once()
+		_, err := u.CheckInventory(context.Background())
+		assert.NoError(t, err)
+
+		mockRepo.On("GetInventorySnapshots", mock.Anything, mock.Anything).Return([]entity.InventorySnapshot{oversold}, nil).Once()
+		mockNotifier.On("Notify", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		_, err = u.CheckInventory(context.Background())
+		assert.NoError(t, err)
+
+		mockRepo.On("GetInventorySnapshots", mock.Anything, mock.Anything).Return([]entity.InventorySnapshot{healthy}, nil).Once()
+		violations, err := u.CheckInventory(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, violations)
+
+		mockRepo.On("GetInventorySnapshots", mock.Anything, mock.Anything).Return([]entity.InventorySnapshot{oversold}, nil).Once()
+		mockNotifier.On("Notify", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		_, err = u.CheckInventory(context.Background())
+		assert.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertNumberOfCalls(t, "Notify", 3)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockRepo := new(mocks.MockInventoryRepo)
+		mockNotifier := new(mocks.MockAlertNotifier)
+		mockRepo.On("GetInventorySnapshots", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+		u := usecase.NewInventoryMonitor(mockRepo, mockNotifier, time.Second*2)
+		_, err := u.CheckInventory(context.Background())
+		assert.Error(t, err)
+		mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxInvoiceGaps caps the missing numbers reported at once; a series with
+// more gaps than that needs a closer look anyway.
+const maxInvoiceGaps = 1000
+
+// firstInvoiceYear is the first year invoices were numbered.
+const firstInvoiceYear = 2026
+
+type InvoiceUsecase interface {
+	// ListInvoices returns the year's invoices of one issuer, or of every
+	// issuer when issuerID is nil.
+	ListInvoices(ctx context.Context, issuerID *int64, year int) ([]entity.Invoice, error)
+	// FindGaps reports numbers missing from the year's invoice series.
+	FindGaps(ctx context.Context, year int) ([]entity.InvoiceGap, error)
+}
+
+type invoiceUsecase struct {
+	invoiceRepo    repository.InvoiceRepository
+	contextTimeout time.Duration
+}
+
+func NewInvoiceUsecase(invoiceRepo repository.InvoiceRepository, timeout time.Duration) InvoiceUsecase {
+	return &invoiceUsecase{
+		invoiceRepo:    invoiceRepo,
+		contextTimeout: timeout,
+	}
+}
+
+func validateInvoiceYear(year int) error {
+	if year < firstInvoiceYear || year > time.Now().Year()+1 {
+		return entity.ErrInvalidInvoiceYear
+	}
+	return nil
+}
+
+func (uc *invoiceUsecase) ListInvoices(ctx context.Context, issuerID *int64, year int) ([]entity.Invoice, error) {
+	ctx, span := tracing.Start(ctx, "InvoiceUsecase.ListInvoices", attribute.Int("year", year))
+	defer span.End()
+
+	if err := validateInvoiceYear(year); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.invoiceRepo.ListInvoices(ctx, issuerID, year)
+}
+
+func (uc *invoiceUsecase) FindGaps(ctx context.Context, year int) ([]entity.InvoiceGap, error) {
+	ctx, span := tracing.Start(ctx, "InvoiceUsecase.FindGaps", attribute.Int("year", year))
+	defer span.End()
+
+	if err := validateInvoiceYear(year); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	gaps, err := uc.invoiceRepo.FindInvoiceGaps(ctx, year, maxInvoiceGaps)
+	if err != nil {
+		return nil, err
+	}
+	if len(gaps) > 0 {
+		logger.FromContext(ctx).Warn("usecase: invoice numbering gaps found", logger.Int("year", year), logger.Int("count", len(gaps)))
+	}
+	return gaps, nil
+}
@@ -0,0 +1,64 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInvoiceUsecase_ListInvoices(t *testing.T) {
+	year := time.Now().Year()
+	organizer := int64(12)
+
+	t.Run("Success - One Issuer", func(t *testing.T) {
+		mockRepo := new(mocks.MockInvoiceRepo)
+		invoices := []entity.Invoice{
+			{ID: 1, Number: entity.FormatInvoiceNumber(organizer, year, 1), IssuerID: organizer, Year: year, Sequence: 1},
+			{ID: 4, Number: entity.FormatInvoiceNumber(organizer, year, 2), IssuerID: organizer, Year: year, Sequence: 2},
+		}
+		mockRepo.On("ListInvoices", mock.Anything, &organizer, year).Return(invoices, nil).Once()
+
+		u := usecase.NewInvoiceUsecase(mockRepo, time.Second*2)
+		got, err := u.ListInvoices(context.Background(), &organizer, year)
+
+		assert.NoError(t, err)
+		assert.Equal(t, invoices, got)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Year Out Of Range", func(t *testing.T) {
+		mockRepo := new(mocks.MockInvoiceRepo)
+
+		u := usecase.NewInvoiceUsecase(mockRepo, time.Second*2)
+		_, err := u.ListInvoices(context.Background(), nil, 1999)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidInvoiceYear)
+		mockRepo.AssertNotCalled(t, "ListInvoices", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestInvoiceUsecase_FindGaps(t *testing.T) {
+	year := time.Now().Year()
+	mockRepo := new(mocks.MockInvoiceRepo)
+	gaps := []entity.InvoiceGap{{IssuerID: entity.PlatformIssuerID, Year: year, Sequence: 7}}
+	mockRepo.On("FindInvoiceGaps", mock.Anything, year, 1000).Return(gaps, nil).Once()
+
+	u := usecase.NewInvoiceUsecase(mockRepo, time.Second*2)
+	got, err := u.FindGaps(context.Background(), year)
+
+	assert.NoError(t, err)
+	assert.Equal(t, gaps, got)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFormatInvoiceNumber(t *testing.T) {
+	assert.Equal(t, "INV/2026/000042", entity.FormatInvoiceNumber(entity.PlatformIssuerID, 2026, 42))
+	assert.Equal(t, "INV/ORG12/2026/000042", entity.FormatInvoiceNumber(12, 2026, 42))
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+)
+
+// JobUsecase exposes the background job dead-letter list to admins.
+type JobUsecase interface {
+	ListDeadJobs(ctx context.Context, page, limit int) ([]entity.Job, int, error)
+	RequeueDeadJob(ctx context.Context, jobID int64) error
+	// QueueStats counts the stored jobs by state and the outbox backlog,
+	// with the outbox limits and overflow counters.
+	QueueStats(ctx context.Context) (*entity.JobQueueStats, error)
+}
+
+// JobQueueMonitor reports the limits the outbox is held to and how many
+// jobs were queued over them.
+type JobQueueMonitor interface {
+	QueueStats() entity.JobQueueStats
+}
+
+type jobUsecase struct {
+	jobRepo        repository.JobRepository
+	queue          JobQueueMonitor
+	auditor        AuditUsecase
+	contextTimeout time.Duration
+}
+
+func NewJobUsecase(jobRepo repository.JobRepository, queue JobQueueMonitor, auditor AuditUsecase, timeout time.Duration) JobUsecase {
+	return &jobUsecase{
+		jobRepo:        jobRepo,
+		queue:          queue,
+		auditor:        auditor,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *jobUsecase) ListDeadJobs(ctx context.Context, page, limit int) ([]entity.Job, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.jobRepo.ListDeadJobs(ctx, page, limit)
+}
+
+func (uc *jobUsecase) RequeueDeadJob(ctx context.Context, jobID int64) error {
+	logger.FromContext(ctx).Debug("usecase: requeuing dead job", logger.Int64("job_id", jobID))
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.jobRepo.RequeueDeadJob(ctx, jobID); err != nil {
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionJobRequeue, "job", jobID, nil)
+	return nil
+}
+
+func (uc *jobUsecase) QueueStats(ctx context.Context) (*entity.JobQueueStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	backlog, err := uc.jobRepo.GetJobBacklog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats := uc.queue.QueueStats()
+	stats.JobBacklog = *backlog
+	return &stats, nil
+}
@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestJobUsecase_RequeueDeadJob(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.MockJobRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+
+		mockRepo.On("RequeueDeadJob", mock.Anything, int64(7)).Return(nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionJobRequeue, "job", int64(7), mock.Anything).Once()
+
+		uc := usecase.NewJobUsecase(mockRepo, new(mocks.MockJobQueueMonitor), mockAudit, 2*time.Second)
+		err := uc.RequeueDeadJob(context.Background(), 7)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Not Dead", func(t *testing.T) {
+		mockRepo := new(mocks.MockJobRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+
+		mockRepo.On("RequeueDeadJob", mock.Anything, int64(7)).Return(entity.ErrNotFound).Once()
+
+		uc := usecase.NewJobUsecase(mockRepo, new(mocks.MockJobQueueMonitor), mockAudit, 2*time.Second)
+		err := uc.RequeueDeadJob(context.Background(), 7)
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestJobUsecase_QueueStats(t *testing.T) {
+	mockRepo := new(mocks.MockJobRepo)
+	mockQueue := new(mocks.MockJobQueueMonitor)
+	backlog := &entity.JobBacklog{Due: 12, Scheduled: 3, Running: 4, Dead: 1, Outbox: 2}
+	mockRepo.On("GetJobBacklog", mock.Anything).Return(backlog, nil).Once()
+	mockQueue.On("QueueStats").Return(entity.JobQueueStats{OutboxLimit: 1000, Overflow: "drop", Backlogged: true, Overflowed: 3, Dropped: 3}).Once()
+
+	uc := usecase.NewJobUsecase(mockRepo, mockQueue, new(mocks.MockAuditUsecase), 2*time.Second)
+	got, err := uc.QueueStats(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.JobQueueStats{JobBacklog: *backlog, OutboxLimit: 1000, Overflow: "drop", Backlogged: true, Overflowed: 3, Dropped: 3}, got)
+	mockRepo.AssertExpectations(t)
+	mockQueue.AssertExpectations(t)
+}
+
+func TestJobUsecase_QueueStats_RepoError(t *testing.T) {
+	mockRepo := new(mocks.MockJobRepo)
+	mockRepo.On("GetJobBacklog", mock.Anything).Return(nil, errors.New("timeout")).Once()
+
+	uc := usecase.NewJobUsecase(mockRepo, new(mocks.MockJobQueueMonitor), new(mocks.MockAuditUsecase), 2*time.Second)
+	got, err := uc.QueueStats(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAlertNotifier struct {
+	mock.Mock
+}
+
+func (m *MockAlertNotifier) Notify(ctx context.Context, subject, message string) error {
+	args := m.Called(ctx, subject, message)
+	return args.Error(0)
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAnalyticsRepo struct {
+	mock.Mock
+}
+
+func (m *MockAnalyticsRepo) GetSalesStats(ctx context.Context, eventID int64, windowStart time.Time) (*entity.SalesStats, error) {
+	args := m.Called(ctx, eventID, windowStart)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SalesStats), args.Error(1)
+}
+
+func (m *MockAnalyticsRepo) GetBoostCandidates(ctx context.Context, cooldown time.Duration) ([]entity.Event, error) {
+	args := m.Called(ctx, cooldown)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Event), args.Error(1)
+}
+
+func (m *MockAnalyticsRepo) MarkMarketingBoostSent(ctx context.Context, eventID int64) error {
+	args := m.Called(ctx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsRepo) GetComparableEvents(ctx context.Context, event *entity.Event, groupBy string, limit int) ([]entity.Event, error) {
+	args := m.Called(ctx, event, groupBy, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Event), args.Error(1)
+}
+
+func (m *MockAnalyticsRepo) GetDailySalesBeforeEvent(ctx context.Context, eventIDs []int64) (map[int64]map[int]int, error) {
+	args := m.Called(ctx, eventIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]map[int]int), args.Error(1)
+}
@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyRepo struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepo) CreateKey(ctx context.Context, key *entity.APIKey, keyHash string) error {
+	args := m.Called(ctx, key, keyHash)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepo) ListKeys(ctx context.Context, userID *int64) ([]entity.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) RevokeKey(ctx context.Context, keyID int64) error {
+	args := m.Called(ctx, keyID)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepo) UseKey(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
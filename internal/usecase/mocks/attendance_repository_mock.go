@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAttendanceRepo struct {
+	mock.Mock
+}
+
+func (m *MockAttendanceRepo) GetAttendanceReport(ctx context.Context, eventID int64) (*entity.AttendanceReport, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AttendanceReport), args.Error(1)
+}
+
+func (m *MockAttendanceRepo) CreateAttendanceReports(ctx context.Context, endedAfter, endedBefore time.Time) ([]entity.AttendanceReport, error) {
+	args := m.Called(ctx, endedAfter, endedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.AttendanceReport), args.Error(1)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditRepo struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepo) CreateAuditLog(ctx context.Context, log *entity.AuditLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepo) AggregateActions(ctx context.Context, action string, match map[string]string, actorID *int64, since time.Time) (*entity.AuditAggregate, error) {
+	args := m.Called(ctx, action, match, actorID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AuditAggregate), args.Error(1)
+}
+
+func (m *MockAuditRepo) ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, page, limit int) ([]entity.AuditLog, int, error) {
+	args := m.Called(ctx, filter, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]entity.AuditLog), args.Int(1), args.Error(2)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAuditUsecase) Record(ctx context.Context, action, entityType string, entityID int64, details map[string]interface{}) {
+	m.Called(ctx, action, entityType, entityID, details)
+}
+
+func (m *MockAuditUsecase) RecordChange(ctx context.Context, action, entityType string, entityID int64, before, after interface{}) {
+	m.Called(ctx, action, entityType, entityID, before, after)
+}
+
+func (m *MockAuditUsecase) ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, page, limit int) ([]entity.AuditLog, int, error) {
+	args := m.Called(ctx, filter, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]entity.AuditLog), args.Int(1), args.Error(2)
+}
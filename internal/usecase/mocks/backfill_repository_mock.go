@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBackfillRepo struct {
+	mock.Mock
+}
+
+func (m *MockBackfillRepo) ListBackfills(ctx context.Context) ([]entity.Backfill, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Backfill), args.Error(1)
+}
+
+func (m *MockBackfillRepo) GetBackfill(ctx context.Context, name string) (*entity.Backfill, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Backfill), args.Error(1)
+}
+
+func (m *MockBackfillRepo) StartBackfill(ctx context.Context, name string, batchSize int) (*entity.Backfill, error) {
+	args := m.Called(ctx, name, batchSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Backfill), args.Error(1)
+}
+
+func (m *MockBackfillRepo) RunBackfillBatch(ctx context.Context, name string) (*entity.Backfill, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Backfill), args.Error(1)
+}
+
+func (m *MockBackfillRepo) FailBackfill(ctx context.Context, name, lastError string) error {
+	args := m.Called(ctx, name, lastError)
+	return args.Error(0)
+}
+
+type MockBackfillRunner struct {
+	mock.Mock
+}
+
+func (m *MockBackfillRunner) EnqueueBackfill(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBankAccountRepo struct {
+	mock.Mock
+}
+
+func (m *MockBankAccountRepo) CreateBankAccount(ctx context.Context, account *entity.BankAccount) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+func (m *MockBankAccountRepo) GetBankAccountByID(ctx context.Context, accountID int64) (*entity.BankAccount, error) {
+	args := m.Called(ctx, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BankAccount), args.Error(1)
+}
+
+func (m *MockBankAccountRepo) GetBankAccountsByOrganizer(ctx context.Context, organizerID int64) ([]entity.BankAccount, error) {
+	args := m.Called(ctx, organizerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.BankAccount), args.Error(1)
+}
+
+func (m *MockBankAccountRepo) GetDefaultBankAccount(ctx context.Context, organizerID int64) (*entity.BankAccount, error) {
+	args := m.Called(ctx, organizerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BankAccount), args.Error(1)
+}
+
+func (m *MockBankAccountRepo) UpdateVerification(ctx context.Context, accountID int64, status string, attempts int) error {
+	args := m.Called(ctx, accountID, status, attempts)
+	return args.Error(0)
+}
+
+func (m *MockBankAccountRepo) SetDefaultBankAccount(ctx context.Context, organizerID, accountID int64) error {
+	args := m.Called(ctx, organizerID, accountID)
+	return args.Error(0)
+}
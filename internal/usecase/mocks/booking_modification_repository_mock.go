@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBookingModificationRepo struct {
+	mock.Mock
+}
+
+func (m *MockBookingModificationRepo) ApplySeatChange(ctx context.Context, mod *entity.BookingModification) error {
+	args := m.Called(ctx, mod)
+	return args.Error(0)
+}
+
+func (m *MockBookingModificationRepo) GetModificationsByBookingID(ctx context.Context, bookingID int64) ([]entity.BookingModification, error) {
+	args := m.Called(ctx, bookingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.BookingModification), args.Error(1)
+}
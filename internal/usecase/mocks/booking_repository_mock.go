@@ -2,6 +2,8 @@ package mocks
 
 import (
 	"context"
+	"time"
+
 	"ticres/internal/entity"
 
 	"github.com/stretchr/testify/mock"
@@ -11,8 +13,8 @@ type MockBookingRepo struct {
 	mock.Mock
 }
 
-func (m *MockBookingRepo) CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64) (int64, float64, error) {
-	args := m.Called(ctx, userID, eventID, seatIDs)
+func (m *MockBookingRepo) CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64, variant *entity.PricingVariant, expiresAt time.Time) (int64, float64, error) {
+	args := m.Called(ctx, userID, eventID, seatIDs, variant, expiresAt)
 	return args.Get(0).(int64), args.Get(1).(float64), args.Error(2)
 }
 
@@ -40,7 +42,15 @@ func (m *MockBookingRepo) GetBookingsByUserID(ctx context.Context, userID int64)
 	return args.Get(0).([]entity.BookingWithDetails), args.Error(1)
 }
 
-func (m *MockBookingRepo) GetAllBookings(ctx context.Context, status, sortBy, sortOrder string, page, limit int) ([]entity.BookingWithDetails, int, error) {
+func (m *MockBookingRepo) GetBookingDetail(ctx context.Context, bookingID int64) (*entity.BookingDetail, error) {
+	args := m.Called(ctx, bookingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BookingDetail), args.Error(1)
+}
+
+func (m *MockBookingRepo) GetAllBookings(ctx context.Context, status entity.BookingStatus, sortBy, sortOrder string, page, limit int) ([]entity.BookingWithDetails, int, error) {
 	args := m.Called(ctx, status, sortBy, sortOrder, page, limit)
 	if args.Get(0) == nil {
 		return nil, args.Int(1), args.Error(2)
@@ -48,7 +58,20 @@ func (m *MockBookingRepo) GetAllBookings(ctx context.Context, status, sortBy, so
 	return args.Get(0).([]entity.BookingWithDetails), args.Int(1), args.Error(2)
 }
 
-func (m *MockBookingRepo) GetBookingsWithDetailsByEventID(ctx context.Context, eventID int64, status, sortBy, sortOrder string) ([]entity.BookingWithDetails, error) {
+func (m *MockBookingRepo) GetAllBookingsAfter(ctx context.Context, status entity.BookingStatus, sortBy, sortOrder string, after *entity.PageCursor, limit int) ([]entity.BookingWithDetails, *entity.PageCursor, error) {
+	args := m.Called(ctx, status, sortBy, sortOrder, after, limit)
+	var bookings []entity.BookingWithDetails
+	if args.Get(0) != nil {
+		bookings = args.Get(0).([]entity.BookingWithDetails)
+	}
+	var next *entity.PageCursor
+	if args.Get(1) != nil {
+		next = args.Get(1).(*entity.PageCursor)
+	}
+	return bookings, next, args.Error(2)
+}
+
+func (m *MockBookingRepo) GetBookingsWithDetailsByEventID(ctx context.Context, eventID int64, status entity.BookingStatus, sortBy, sortOrder string) ([]entity.BookingWithDetails, error) {
 	args := m.Called(ctx, eventID, status, sortBy, sortOrder)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -56,12 +79,112 @@ func (m *MockBookingRepo) GetBookingsWithDetailsByEventID(ctx context.Context, e
 	return args.Get(0).([]entity.BookingWithDetails), args.Error(1)
 }
 
-func (m *MockBookingRepo) UpdateBookingStatus(ctx context.Context, bookingID int64, status string) error {
+func (m *MockBookingRepo) GetEventBookingSummary(ctx context.Context, eventID int64) (*entity.EventBookingSummary, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EventBookingSummary), args.Error(1)
+}
+
+func (m *MockBookingRepo) StreamEventBookings(ctx context.Context, eventID int64, fn func(entity.BookingExportRow) error) error {
+	args := m.Called(ctx, eventID, fn)
+	if rows, ok := args.Get(0).([]entity.BookingExportRow); ok {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockBookingRepo) UpdateBookingStatus(ctx context.Context, bookingID int64, status entity.BookingStatus) error {
 	args := m.Called(ctx, bookingID, status)
 	return args.Error(0)
 }
 
+func (m *MockBookingRepo) ExtendBookingExpiry(ctx context.Context, bookingID int64, expiresAt time.Time) (time.Time, error) {
+	args := m.Called(ctx, bookingID, expiresAt)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockBookingRepo) HoldForGateway(ctx context.Context, bookingID int64, expiresAt time.Time) (time.Time, error) {
+	args := m.Called(ctx, bookingID, expiresAt)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockBookingRepo) ClaimGatewayWaiters(ctx context.Context, recoveredAt, expiresAt time.Time, limit int) ([]entity.AffectedBooking, error) {
+	args := m.Called(ctx, recoveredAt, expiresAt, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.AffectedBooking), args.Error(1)
+}
+
+func (m *MockBookingRepo) ExpireLapsedBookings(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
 func (m *MockBookingRepo) ReleaseSeatsByBookingID(ctx context.Context, bookingID int64) error {
 	args := m.Called(ctx, bookingID)
 	return args.Error(0)
 }
+
+func (m *MockBookingRepo) GetOverlappingPaidBookings(ctx context.Context, userID, eventID int64, window time.Duration) ([]entity.BookingConflict, error) {
+	args := m.Called(ctx, userID, eventID, window)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.BookingConflict), args.Error(1)
+}
+
+func (m *MockBookingRepo) CreateGeneralBooking(ctx context.Context, userID, eventID int64, quantity int, variant *entity.PricingVariant, expiresAt time.Time) (int64, float64, error) {
+	args := m.Called(ctx, userID, eventID, quantity, variant, expiresAt)
+	return args.Get(0).(int64), args.Get(1).(float64), args.Error(2)
+}
+
+func (m *MockBookingRepo) CreateBestAvailableBooking(ctx context.Context, userID, eventID int64, quantity int, pref entity.SeatPreference, variant *entity.PricingVariant, expiresAt time.Time) (int64, float64, []entity.BookedSeat, error) {
+	args := m.Called(ctx, userID, eventID, quantity, pref, variant, expiresAt)
+	var seats []entity.BookedSeat
+	if args.Get(2) != nil {
+		seats = args.Get(2).([]entity.BookedSeat)
+	}
+	return args.Get(0).(int64), args.Get(1).(float64), seats, args.Error(3)
+}
+
+func (m *MockBookingRepo) GetTicketAllowance(ctx context.Context, userID, eventID int64) (*entity.TicketAllowance, error) {
+	args := m.Called(ctx, userID, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TicketAllowance), args.Error(1)
+}
+
+func (m *MockBookingRepo) GetAffectedBooking(ctx context.Context, bookingID int64) (*entity.AffectedBooking, error) {
+	args := m.Called(ctx, bookingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AffectedBooking), args.Error(1)
+}
+
+func (m *MockBookingRepo) GetLapsedBookings(ctx context.Context, before time.Time) ([]entity.AffectedBooking, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.AffectedBooking), args.Error(1)
+}
+
+func (m *MockBookingRepo) GetCustomerSummary(ctx context.Context, userID int64) (*entity.CustomerSummary, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CustomerSummary), args.Error(1)
+}
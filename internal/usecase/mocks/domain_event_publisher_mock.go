@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDomainEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockDomainEventPublisher) PublishDomainEvent(ctx context.Context, eventType string, aggregateID int64, data map[string]interface{}) {
+	m.Called(ctx, eventType, aggregateID, data)
+}
@@ -25,14 +25,27 @@ func (m *MockEventRepo) GetAllEvents(ctx context.Context) ([]entity.Event, error
 	return args.Get(0).([]entity.Event), args.Error(1)
 }
 
-func (m *MockEventRepo) GetEventsWithSearch(ctx context.Context, search string, page, limit int) ([]entity.Event, int, error) {
-	args := m.Called(ctx, search, page, limit)
+func (m *MockEventRepo) GetEventsWithSearch(ctx context.Context, filter entity.EventFilter, page, limit int) ([]entity.Event, int, error) {
+	args := m.Called(ctx, filter, page, limit)
 	if args.Get(0) == nil {
 		return nil, args.Int(1), args.Error(2)
 	}
 	return args.Get(0).([]entity.Event), args.Int(1), args.Error(2)
 }
 
+func (m *MockEventRepo) GetEventsAfter(ctx context.Context, filter entity.EventFilter, after *entity.PageCursor, limit int) ([]entity.Event, *entity.PageCursor, error) {
+	args := m.Called(ctx, filter, after, limit)
+	var events []entity.Event
+	if args.Get(0) != nil {
+		events = args.Get(0).([]entity.Event)
+	}
+	var next *entity.PageCursor
+	if args.Get(1) != nil {
+		next = args.Get(1).(*entity.PageCursor)
+	}
+	return events, next, args.Error(2)
+}
+
 func (m *MockEventRepo) GetEventByID(ctx context.Context, eventID int64) (*entity.Event, error) {
 	args := m.Called(ctx, eventID)
 	if args.Get(0) == nil {
@@ -57,12 +70,133 @@ func (m *MockEventRepo) GetSeatsByEventID(ctx context.Context, eventID int64) ([
 	return args.Get(0).([]entity.Seat), args.Error(1)
 }
 
+func (m *MockEventRepo) GetSeatsPage(ctx context.Context, eventID, afterID int64, limit int, filter entity.SeatFilter) ([]entity.Seat, error) {
+	args := m.Called(ctx, eventID, afterID, limit, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Seat), args.Error(1)
+}
+
+func (m *MockEventRepo) StreamSeats(ctx context.Context, eventID int64, filter entity.SeatFilter, fn func(entity.Seat) error) error {
+	args := m.Called(ctx, eventID, filter, fn)
+	if seats, ok := args.Get(0).([]entity.Seat); ok {
+		for _, seat := range seats {
+			if err := fn(seat); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockEventRepo) GetCancellableBookings(ctx context.Context, eventID int64) ([]entity.AffectedBooking, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.AffectedBooking), args.Error(1)
+}
+
+func (m *MockEventRepo) IsEventCancelled(ctx context.Context, eventID int64) (bool, error) {
+	args := m.Called(ctx, eventID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockEventRepo) GetEventCapacity(ctx context.Context, eventID int64) (*entity.EventCapacity, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EventCapacity), args.Error(1)
+}
+
+func (m *MockEventRepo) GetSectionAvailability(ctx context.Context, eventID int64) ([]entity.SectionAvailability, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.SectionAvailability), args.Error(1)
+}
+
 func (m *MockEventRepo) UpdateEvent(ctx context.Context, event *entity.Event, preCapacity int64) error {
 	args := m.Called(ctx, event)
 	return args.Error(0)
 }
 
-func (m *MockEventRepo) UpdateEventStatus(ctx context.Context, eventID int64, status string) error {
-	args := m.Called(ctx, eventID, status)
+func (m *MockEventRepo) DeleteEvent(ctx context.Context, eventID int64) error {
+	args := m.Called(ctx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockEventRepo) RestoreEvent(ctx context.Context, eventID int64) error {
+	args := m.Called(ctx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockEventRepo) UpdateEventContent(ctx context.Context, eventID int64, content *entity.EventContent) error {
+	args := m.Called(ctx, eventID, content)
+	return args.Error(0)
+}
+
+func (m *MockEventRepo) SetEventImage(ctx context.Context, eventID int64, key, url string) (string, error) {
+	args := m.Called(ctx, eventID, key, url)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockEventRepo) GetSectionImageIDs(ctx context.Context, eventID int64) (map[string]int64, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+func (m *MockEventRepo) UpdateSeatLayout(ctx context.Context, eventID int64, layout *entity.SeatLayout) error {
+	args := m.Called(ctx, eventID, layout)
+	return args.Error(0)
+}
+
+func (m *MockEventRepo) SubscribeSeatUpdates(ctx context.Context, eventID int64) (<-chan entity.SeatUpdate, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(<-chan entity.SeatUpdate), args.Error(1)
+}
+
+func (m *MockEventRepo) GetPublicAvailability(ctx context.Context, eventID int64) (*entity.PublicAvailability, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PublicAvailability), args.Error(1)
+}
+
+func (m *MockEventRepo) GetSalesWaves(ctx context.Context, eventID int64) (*entity.SalesWaveSchedule, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SalesWaveSchedule), args.Error(1)
+}
+
+func (m *MockEventRepo) ReplaceSalesWaves(ctx context.Context, eventID int64, waves []entity.SalesWave) error {
+	args := m.Called(ctx, eventID, waves)
+	return args.Error(0)
+}
+
+func (m *MockEventRepo) SetTicketLimit(ctx context.Context, eventID int64, limit *int) error {
+	args := m.Called(ctx, eventID, limit)
+	return args.Error(0)
+}
+
+func (m *MockEventRepo) SetBookingRateLimit(ctx context.Context, eventID int64, limit *int) error {
+	args := m.Called(ctx, eventID, limit)
+	return args.Error(0)
+}
+
+func (m *MockEventRepo) SetRefundPolicy(ctx context.Context, eventID int64, policy *entity.RefundPolicy) error {
+	args := m.Called(ctx, eventID, policy)
 	return args.Error(0)
 }
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEventStaffRepo struct {
+	mock.Mock
+}
+
+func (m *MockEventStaffRepo) GrantAccess(ctx context.Context, grant *entity.EventStaff) error {
+	args := m.Called(ctx, grant)
+	return args.Error(0)
+}
+
+func (m *MockEventStaffRepo) RevokeAccess(ctx context.Context, eventID, userID int64, scope string) error {
+	args := m.Called(ctx, eventID, userID, scope)
+	return args.Error(0)
+}
+
+func (m *MockEventStaffRepo) ListEventStaff(ctx context.Context, eventID int64) ([]entity.EventStaff, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.EventStaff), args.Error(1)
+}
+
+func (m *MockEventStaffRepo) ListStaffEvents(ctx context.Context, userID int64) ([]entity.EventStaff, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.EventStaff), args.Error(1)
+}
+
+func (m *MockEventStaffRepo) HasAccess(ctx context.Context, eventID, userID int64, scope string) (bool, error) {
+	args := m.Called(ctx, eventID, userID, scope)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockExperimentRepo struct {
+	mock.Mock
+}
+
+func (m *MockExperimentRepo) CreateExperiment(ctx context.Context, exp *entity.PricingExperiment) error {
+	args := m.Called(ctx, exp)
+	return args.Error(0)
+}
+
+func (m *MockExperimentRepo) GetExperimentByID(ctx context.Context, experimentID int64) (*entity.PricingExperiment, error) {
+	args := m.Called(ctx, experimentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PricingExperiment), args.Error(1)
+}
+
+func (m *MockExperimentRepo) GetActiveExperimentByEvent(ctx context.Context, eventID int64) (*entity.PricingExperiment, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PricingExperiment), args.Error(1)
+}
+
+func (m *MockExperimentRepo) GetExperimentsByEvent(ctx context.Context, eventID int64) ([]entity.PricingExperiment, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.PricingExperiment), args.Error(1)
+}
+
+func (m *MockExperimentRepo) StopExperiment(ctx context.Context, experimentID int64) error {
+	args := m.Called(ctx, experimentID)
+	return args.Error(0)
+}
+
+func (m *MockExperimentRepo) RecordExposure(ctx context.Context, experimentID, variantID, userID int64) error {
+	args := m.Called(ctx, experimentID, variantID, userID)
+	return args.Error(0)
+}
+
+func (m *MockExperimentRepo) GetVariantResults(ctx context.Context, experimentID int64) ([]entity.VariantResult, error) {
+	args := m.Called(ctx, experimentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.VariantResult), args.Error(1)
+}
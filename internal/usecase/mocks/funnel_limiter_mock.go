@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFunnelLimiter struct {
+	mock.Mock
+}
+
+func (m *MockFunnelLimiter) Take(ctx context.Context, eventID int64, perSecond int) (time.Duration, error) {
+	args := m.Called(ctx, eventID, perSecond)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGateRepo struct {
+	mock.Mock
+}
+
+func (m *MockGateRepo) CreateGate(ctx context.Context, gate *entity.Gate, keyHash string) error {
+	args := m.Called(ctx, gate, keyHash)
+	return args.Error(0)
+}
+
+func (m *MockGateRepo) ListGates(ctx context.Context, eventID int64) ([]entity.Gate, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Gate), args.Error(1)
+}
+
+func (m *MockGateRepo) RevokeGate(ctx context.Context, eventID, gateID int64) error {
+	args := m.Called(ctx, eventID, gateID)
+	return args.Error(0)
+}
+
+func (m *MockGateRepo) UseGate(ctx context.Context, keyHash string) (*entity.Gate, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Gate), args.Error(1)
+}
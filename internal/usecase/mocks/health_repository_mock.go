@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHealthRepo struct {
+	mock.Mock
+}
+
+func (m *MockHealthRepo) PingDatabase(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockHealthRepo) PingCache(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockHealthRepo) CacheCircuitState() string {
+	args := m.Called()
+	return args.String(0)
+}
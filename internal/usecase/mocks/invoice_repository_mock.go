@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInvoiceRepo struct {
+	mock.Mock
+}
+
+func (m *MockInvoiceRepo) ListInvoices(ctx context.Context, issuerID *int64, year int) ([]entity.Invoice, error) {
+	args := m.Called(ctx, issuerID, year)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepo) FindInvoiceGaps(ctx context.Context, year, limit int) ([]entity.InvoiceGap, error) {
+	args := m.Called(ctx, year, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.InvoiceGap), args.Error(1)
+}
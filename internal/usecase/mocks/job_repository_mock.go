@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobRepo struct {
+	mock.Mock
+}
+
+func (m *MockJobRepo) Enqueue(ctx context.Context, job *entity.Job) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) ClaimJobs(ctx context.Context, lane string, limit int, lease time.Duration) ([]entity.Job, error) {
+	args := m.Called(ctx, lane, limit, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Job), args.Error(1)
+}
+
+func (m *MockJobRepo) CompleteJob(ctx context.Context, jobID int64) error {
+	args := m.Called(ctx, jobID)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) RetryJob(ctx context.Context, jobID int64, runAt time.Time, lastError string) error {
+	args := m.Called(ctx, jobID, runAt, lastError)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) KillJob(ctx context.Context, jobID int64, lastError string) error {
+	args := m.Called(ctx, jobID, lastError)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) ListDeadJobs(ctx context.Context, page, limit int) ([]entity.Job, int, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]entity.Job), args.Int(1), args.Error(2)
+}
+
+func (m *MockJobRepo) RequeueDeadJob(ctx context.Context, jobID int64) error {
+	args := m.Called(ctx, jobID)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) GetJobBacklog(ctx context.Context) (*entity.JobBacklog, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.JobBacklog), args.Error(1)
+}
+
+type MockJobQueueMonitor struct {
+	mock.Mock
+}
+
+func (m *MockJobQueueMonitor) QueueStats() entity.JobQueueStats {
+	args := m.Called()
+	return args.Get(0).(entity.JobQueueStats)
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationRepo struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepo) CreateNotification(ctx context.Context, n *entity.Notification) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepo) ListNotifications(ctx context.Context, userID int64, unreadOnly bool, page, limit int) (*entity.NotificationPage, error) {
+	args := m.Called(ctx, userID, unreadOnly, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.NotificationPage), args.Error(1)
+}
+
+func (m *MockNotificationRepo) MarkRead(ctx context.Context, userID, notificationID int64) error {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Error(0)
+}
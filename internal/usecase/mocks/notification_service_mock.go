@@ -1,14 +1,40 @@
 package mocks
-import "github.com/stretchr/testify/mock"
+import (
+	"context"
+	"ticres/pkg/i18n"
+
+	"github.com/stretchr/testify/mock"
+)
 
 type MockNotificationService struct {
 	mock.Mock
 }
-func (m *MockNotificationService) SendNotification(bookingID int64, email, message string) {
+func (m *MockNotificationService) SendNotification(ctx context.Context, bookingID int64, email string, message i18n.Message) {
 	// Karena void function, kita cuma perlu rekam panggilan
-	m.Called(bookingID, email, message)
+	m.Called(ctx, bookingID, email, message)
+}
+
+func (m *MockNotificationService) SendBookingConfirmation(ctx context.Context, bookingID int64, email string) {
+	m.Called(ctx, bookingID, email)
+}
+
+func (m *MockNotificationService) EnqueueCancellation(ctx context.Context, eventID int64) error {
+	args := m.Called(ctx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) SendPaymentReceipt(ctx context.Context, bookingID int64) {
+	m.Called(ctx, bookingID)
+}
+
+func (m *MockNotificationService) SendUpgradeOffer(ctx context.Context, offerID int64, email, acceptLink string) {
+	m.Called(ctx, offerID, email, acceptLink)
 }
 
-func (m *MockNotificationService) EnqueueCancellation(eventID int64){
-	m.Called(eventID)
-}
\ No newline at end of file
+func (m *MockNotificationService) EnqueueRefundRequest(ctx context.Context, refundID int64) {
+	m.Called(ctx, refundID)
+}
+
+func (m *MockNotificationService) SendTicketsReissued(ctx context.Context, bookingID int64) {
+	m.Called(ctx, bookingID)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/pkg/oauth"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOAuthProvider struct {
+	mock.Mock
+}
+
+func (m *MockOAuthProvider) AuthCodeURL(state string) string {
+	args := m.Called(state)
+	return args.String(0)
+}
+
+func (m *MockOAuthProvider) Exchange(ctx context.Context, code string) (*oauth.Profile, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*oauth.Profile), args.Error(1)
+}
@@ -0,0 +1,78 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrganizerRepo struct {
+	mock.Mock
+}
+
+func (m *MockOrganizerRepo) CreateApplication(ctx context.Context, app *entity.OrganizerApplication) error {
+	args := m.Called(ctx, app)
+	return args.Error(0)
+}
+
+func (m *MockOrganizerRepo) GetApplicationByID(ctx context.Context, applicationID int64) (*entity.OrganizerApplication, error) {
+	args := m.Called(ctx, applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.OrganizerApplication), args.Error(1)
+}
+
+func (m *MockOrganizerRepo) GetLatestApplicationByUserID(ctx context.Context, userID int64) (*entity.OrganizerApplication, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.OrganizerApplication), args.Error(1)
+}
+
+func (m *MockOrganizerRepo) ListApplications(ctx context.Context, status string, page, limit int) ([]entity.OrganizerApplication, int, error) {
+	args := m.Called(ctx, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]entity.OrganizerApplication), args.Int(1), args.Error(2)
+}
+
+func (m *MockOrganizerRepo) AddDocument(ctx context.Context, doc *entity.OrganizerDocument) error {
+	args := m.Called(ctx, doc)
+	return args.Error(0)
+}
+
+func (m *MockOrganizerRepo) GetDocumentsByApplicationID(ctx context.Context, applicationID int64) ([]entity.OrganizerDocument, error) {
+	args := m.Called(ctx, applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.OrganizerDocument), args.Error(1)
+}
+
+func (m *MockOrganizerRepo) ApproveApplication(ctx context.Context, applicationID, reviewerID int64, note string) error {
+	args := m.Called(ctx, applicationID, reviewerID, note)
+	return args.Error(0)
+}
+
+func (m *MockOrganizerRepo) RejectApplication(ctx context.Context, applicationID, reviewerID int64, note string) error {
+	args := m.Called(ctx, applicationID, reviewerID, note)
+	return args.Error(0)
+}
+
+func (m *MockOrganizerRepo) ListPlaintextAccountNumbers(ctx context.Context, limit int) ([]entity.OrganizerApplication, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.OrganizerApplication), args.Error(1)
+}
+
+func (m *MockOrganizerRepo) SetEncryptedAccountNumber(ctx context.Context, applicationID int64, enc, last4 string) error {
+	args := m.Called(ctx, applicationID, enc, last4)
+	return args.Error(0)
+}
@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPasswordResetSender struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetSender) SendPasswordReset(ctx context.Context, email, resetLink string) {
+	m.Called(ctx, email, resetLink)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/pkg/payment"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPaymentGateway struct {
+	mock.Mock
+}
+
+func (m *MockPaymentGateway) Charge(ctx context.Context, req payment.ChargeRequest) (string, error) {
+	args := m.Called(ctx, req)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPaymentGateway) Refund(ctx context.Context, req payment.RefundRequest) (string, error) {
+	args := m.Called(ctx, req)
+	return args.String(0), args.Error(1)
+}
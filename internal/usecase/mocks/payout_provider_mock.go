@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/pkg/payout"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPayoutProvider struct {
+	mock.Mock
+}
+
+func (m *MockPayoutProvider) SendMicroDeposits(ctx context.Context, account payout.Account, amounts []float64) error {
+	args := m.Called(ctx, account, amounts)
+	return args.Error(0)
+}
+
+func (m *MockPayoutProvider) PennyDrop(ctx context.Context, account payout.Account) (string, error) {
+	args := m.Called(ctx, account)
+	return args.String(0), args.Error(1)
+}
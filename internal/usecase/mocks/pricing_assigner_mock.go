@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPricingAssigner struct {
+	mock.Mock
+}
+
+func (m *MockPricingAssigner) AssignVariant(ctx context.Context, eventID, userID int64) (*entity.PricingVariant, error) {
+	args := m.Called(ctx, eventID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PricingVariant), args.Error(1)
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"ticres/internal/entity"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -23,3 +24,76 @@ func (m *MockRefundRepo) GetRefundByBookingID(ctx context.Context, bookingID int
 	}
 	return args.Get(0).(*entity.Refund), args.Error(1)
 }
+
+func (m *MockRefundRepo) RefundBooking(ctx context.Context, refund *entity.Refund) error {
+	args := m.Called(ctx, refund)
+	return args.Error(0)
+}
+
+func (m *MockRefundRepo) ListRefundReasons(ctx context.Context) ([]entity.RefundReason, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.RefundReason), args.Error(1)
+}
+
+func (m *MockRefundRepo) GetRefundReason(ctx context.Context, code string) (*entity.RefundReason, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RefundReason), args.Error(1)
+}
+
+func (m *MockRefundRepo) CreateRefundReason(ctx context.Context, reason *entity.RefundReason) error {
+	args := m.Called(ctx, reason)
+	return args.Error(0)
+}
+
+func (m *MockRefundRepo) UpdateRefundReason(ctx context.Context, reason *entity.RefundReason) error {
+	args := m.Called(ctx, reason)
+	return args.Error(0)
+}
+
+func (m *MockRefundRepo) GetRefundSummary(ctx context.Context, from, to time.Time) ([]entity.RefundReasonSummary, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.RefundReasonSummary), args.Error(1)
+}
+
+func (m *MockRefundRepo) CreateRefundRequest(ctx context.Context, refund *entity.Refund) error {
+	args := m.Called(ctx, refund)
+	return args.Error(0)
+}
+
+func (m *MockRefundRepo) GetRefundRequest(ctx context.Context, refundID int64) (*entity.RefundRequestDetails, error) {
+	args := m.Called(ctx, refundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RefundRequestDetails), args.Error(1)
+}
+
+func (m *MockRefundRepo) ListRefundRequests(ctx context.Context, status string) ([]entity.RefundRequestDetails, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.RefundRequestDetails), args.Error(1)
+}
+
+func (m *MockRefundRepo) ReviewRefundRequest(ctx context.Context, refundID int64, from, to string, reviewerID *int64, note string) error {
+	args := m.Called(ctx, refundID, from, to, reviewerID, note)
+	return args.Error(0)
+}
+
+func (m *MockRefundRepo) CompleteRefundRequest(ctx context.Context, refundID int64) (*entity.Refund, error) {
+	args := m.Called(ctx, refundID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Refund), args.Error(1)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReportCache struct {
+	mock.Mock
+}
+
+func (m *MockReportCache) GetRefundReport(ctx context.Context, from, to time.Time) (*entity.RefundReport, bool) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1)
+	}
+	return args.Get(0).(*entity.RefundReport), args.Bool(1)
+}
+
+func (m *MockReportCache) SetRefundReport(ctx context.Context, report *entity.RefundReport, ttl time.Duration) {
+	m.Called(ctx, report, ttl)
+}
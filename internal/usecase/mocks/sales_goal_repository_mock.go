@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSalesGoalRepo struct {
+	mock.Mock
+}
+
+func (m *MockSalesGoalRepo) UpsertSalesGoal(ctx context.Context, goal *entity.SalesGoal) error {
+	args := m.Called(ctx, goal)
+	return args.Error(0)
+}
+
+func (m *MockSalesGoalRepo) GetSalesGoalProgress(ctx context.Context, eventID int64) (*entity.SalesGoalProgress, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SalesGoalProgress), args.Error(1)
+}
+
+func (m *MockSalesGoalRepo) DeleteSalesGoal(ctx context.Context, eventID int64) error {
+	args := m.Called(ctx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockSalesGoalRepo) GetActiveSalesGoals(ctx context.Context) ([]entity.SalesGoalProgress, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.SalesGoalProgress), args.Error(1)
+}
+
+func (m *MockSalesGoalRepo) MarkSalesGoalAlerts(ctx context.Context, eventID int64, alerts entity.SalesGoalAlerts) error {
+	args := m.Called(ctx, eventID, alerts)
+	return args.Error(0)
+}
@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSectionImageRepo struct {
+	mock.Mock
+}
+
+func (m *MockSectionImageRepo) ReplaceSectionImage(ctx context.Context, img *entity.SectionImage) (string, error) {
+	args := m.Called(ctx, img)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSectionImageRepo) GetSectionImages(ctx context.Context, eventID int64) ([]entity.SectionImage, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.SectionImage), args.Error(1)
+}
+
+func (m *MockSectionImageRepo) GetSectionImageByID(ctx context.Context, imageID int64) (*entity.SectionImage, error) {
+	args := m.Called(ctx, imageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SectionImage), args.Error(1)
+}
+
+func (m *MockSectionImageRepo) DeleteSectionImage(ctx context.Context, eventID int64, section string) (string, error) {
+	args := m.Called(ctx, eventID, section)
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGatewayMonitor struct {
+	mock.Mock
+}
+
+func (m *MockGatewayMonitor) CircuitState() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockGatewayMonitor) LastSuccess() time.Time {
+	args := m.Called()
+	return args.Get(0).(time.Time)
+}
+
+type MockReconciliationMonitor struct {
+	mock.Mock
+}
+
+func (m *MockReconciliationMonitor) LastRun() *entity.ReconciliationRun {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*entity.ReconciliationRun)
+}
@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTicketRepo struct {
+	mock.Mock
+}
+
+func (m *MockTicketRepo) IssueTickets(ctx context.Context, bookingID int64) ([]entity.Ticket, error) {
+	args := m.Called(ctx, bookingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Ticket), args.Error(1)
+}
+
+func (m *MockTicketRepo) GetTicketsByBookingID(ctx context.Context, bookingID int64) ([]entity.Ticket, error) {
+	args := m.Called(ctx, bookingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Ticket), args.Error(1)
+}
+
+func (m *MockTicketRepo) GetTicketByCode(ctx context.Context, code string) (*entity.Ticket, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Ticket), args.Error(1)
+}
+
+func (m *MockTicketRepo) MarkCheckedIn(ctx context.Context, ticketID, staffID int64) (*time.Time, error) {
+	args := m.Called(ctx, ticketID, staffID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockTicketRepo) RegenerateTickets(ctx context.Context, bookingID int64, revokedBy *int64) ([]entity.Ticket, error) {
+	args := m.Called(ctx, bookingID, revokedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Ticket), args.Error(1)
+}
+
+func (m *MockTicketRepo) CheckInBatch(ctx context.Context, eventID, gateID int64, codes []string) ([]entity.ScannedCode, error) {
+	args := m.Called(ctx, eventID, gateID, codes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ScannedCode), args.Error(1)
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTicketTierRepo struct {
+	mock.Mock
+}
+
+func (m *MockTicketTierRepo) CreateTier(ctx context.Context, tier *entity.TicketTier) error {
+	args := m.Called(ctx, tier)
+	return args.Error(0)
+}
+
+func (m *MockTicketTierRepo) GetTiersByEventID(ctx context.Context, eventID int64) ([]entity.TicketTier, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.TicketTier), args.Error(1)
+}
+
+func (m *MockTicketTierRepo) GetTierByID(ctx context.Context, tierID int64) (*entity.TicketTier, error) {
+	args := m.Called(ctx, tierID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TicketTier), args.Error(1)
+}
+
+func (m *MockTicketTierRepo) UpdateTier(ctx context.Context, tier *entity.TicketTier) error {
+	args := m.Called(ctx, tier)
+	return args.Error(0)
+}
+
+func (m *MockTicketTierRepo) DeleteTier(ctx context.Context, tierID int64) error {
+	args := m.Called(ctx, tierID)
+	return args.Error(0)
+}
+
+func (m *MockTicketTierRepo) AssignSeats(ctx context.Context, tierID, eventID int64, assignment *entity.TierSeatAssignment) (int64, error) {
+	args := m.Called(ctx, tierID, eventID, assignment)
+	return args.Get(0).(int64), args.Error(1)
+}
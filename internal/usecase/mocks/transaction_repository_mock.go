@@ -32,7 +32,7 @@ func (m *MockTransactionRepo) GetTransactionByExternalID(ctx context.Context, ex
 	return args.Get(0).(*entity.Transaction), args.Error(1)
 }
 
-func (m *MockTransactionRepo) UpdateTransactionStatus(ctx context.Context, paymentID int64, status, externalID string) error {
+func (m *MockTransactionRepo) UpdateTransactionStatus(ctx context.Context, paymentID int64, status entity.PaymentStatus, externalID string) error {
 	args := m.Called(ctx, paymentID, status, externalID)
 	return args.Error(0)
 }
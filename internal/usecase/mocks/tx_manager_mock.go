@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTxManager runs fn directly with the caller's context unless told to
+// fail before it starts.
+type MockTxManager struct {
+	mock.Mock
+}
+
+func (m *MockTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
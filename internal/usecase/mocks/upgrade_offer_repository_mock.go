@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUpgradeOfferRepo struct {
+	mock.Mock
+}
+
+func (m *MockUpgradeOfferRepo) ExpireOffers(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUpgradeOfferRepo) GetUpgradeCandidates(ctx context.Context, within time.Duration, limit int) ([]entity.UpgradeCandidate, error) {
+	args := m.Called(ctx, within, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.UpgradeCandidate), args.Error(1)
+}
+
+func (m *MockUpgradeOfferRepo) CreateOffer(ctx context.Context, offer *entity.UpgradeOffer, tokenHash string) error {
+	args := m.Called(ctx, offer, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockUpgradeOfferRepo) GetOfferByID(ctx context.Context, offerID int64) (*entity.UpgradeOffer, error) {
+	args := m.Called(ctx, offerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UpgradeOffer), args.Error(1)
+}
+
+func (m *MockUpgradeOfferRepo) GetOfferByTokenHash(ctx context.Context, tokenHash string) (*entity.UpgradeOffer, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UpgradeOffer), args.Error(1)
+}
+
+func (m *MockUpgradeOfferRepo) AcceptOffer(ctx context.Context, tokenHash string, mod *entity.BookingModification) (*entity.UpgradeOffer, error) {
+	args := m.Called(ctx, tokenHash, mod)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UpgradeOffer), args.Error(1)
+}
@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserDataExporter struct {
+	mock.Mock
+}
+
+func (m *MockUserDataExporter) EnqueueUserExport(ctx context.Context, exportID int64) error {
+	args := m.Called(ctx, exportID)
+	return args.Error(0)
+}
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserExportRepo struct {
+	mock.Mock
+}
+
+func (m *MockUserExportRepo) CreateExport(ctx context.Context, userID int64) (*entity.UserDataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UserDataExport), args.Error(1)
+}
+
+func (m *MockUserExportRepo) GetExport(ctx context.Context, exportID int64) (*entity.UserDataExport, error) {
+	args := m.Called(ctx, exportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UserDataExport), args.Error(1)
+}
+
+func (m *MockUserExportRepo) GetLatestExport(ctx context.Context, userID int64) (*entity.UserDataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UserDataExport), args.Error(1)
+}
+
+func (m *MockUserExportRepo) CollectUserData(ctx context.Context, userID int64) (*entity.UserDataArchive, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UserDataArchive), args.Error(1)
+}
+
+func (m *MockUserExportRepo) CompleteExport(ctx context.Context, exportID int64, archive []byte) error {
+	args := m.Called(ctx, exportID, archive)
+	return args.Error(0)
+}
+
+func (m *MockUserExportRepo) FailExport(ctx context.Context, exportID int64, reason string) error {
+	args := m.Called(ctx, exportID, reason)
+	return args.Error(0)
+}
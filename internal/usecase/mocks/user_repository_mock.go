@@ -2,6 +2,8 @@ package mocks
 
 import (
 	"context"
+	"time"
+
 	"ticres/internal/entity"
 	"github.com/stretchr/testify/mock"
 )
@@ -35,4 +37,111 @@ func (m *MockUserRepo) GetUserByID(ctx context.Context, id int) (*entity.User, e
 	}
 
 	return args.Get(0).(*entity.User), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (m *MockUserRepo) UpdateUserRole(ctx context.Context, id int64, role string) error {
+	args := m.Called(ctx, id, role)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) CreatePasswordResetToken(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error {
+	args := m.Called(ctx, userID, tokenHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int64, error) {
+	args := m.Called(ctx, tokenHash, passwordHash)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepo) UpdateProfile(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UpdatePassword(ctx context.Context, userID, keepSessionID int64, passwordHash string) error {
+	args := m.Called(ctx, userID, keepSessionID, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) AnonymizeUser(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetTwoFactor(ctx context.Context, userID int64) (*entity.TwoFactor, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TwoFactor), args.Error(1)
+}
+
+func (m *MockUserRepo) SetTwoFactorSecret(ctx context.Context, userID int64, secret string) error {
+	args := m.Called(ctx, userID, secret)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) EnableTwoFactor(ctx context.Context, userID int64, step int64, codeHashes []string) error {
+	args := m.Called(ctx, userID, step, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UseTwoFactorStep(ctx context.Context, userID int64, step int64) error {
+	args := m.Called(ctx, userID, step)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) error {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) RecordTwoFactorFailure(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetUserByOAuth(ctx context.Context, provider, subject string) (*entity.User, error) {
+	args := m.Called(ctx, provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepo) LinkOAuth(ctx context.Context, userID int64, provider, subject string) error {
+	args := m.Called(ctx, userID, provider, subject)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) CreateSession(ctx context.Context, session *entity.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetActiveSession(ctx context.Context, userID, sessionID int64) (*entity.Session, error) {
+	args := m.Called(ctx, userID, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Session), args.Error(1)
+}
+
+func (m *MockUserRepo) TouchSession(ctx context.Context, sessionID int64) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) ListSessions(ctx context.Context, userID int64) ([]entity.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Session), args.Error(1)
+}
+
+func (m *MockUserRepo) RevokeSession(ctx context.Context, userID, sessionID int64) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWarehouseRepo struct {
+	mock.Mock
+}
+
+func (m *MockWarehouseRepo) GetWatermark(ctx context.Context, destination, dataset string) (*entity.ExportWatermark, error) {
+	args := m.Called(ctx, destination, dataset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ExportWatermark), args.Error(1)
+}
+
+func (m *MockWarehouseRepo) SaveWatermark(ctx context.Context, w *entity.ExportWatermark, rows int) error {
+	args := m.Called(ctx, w, rows)
+	return args.Error(0)
+}
+
+func (m *MockWarehouseRepo) FetchChanges(ctx context.Context, dataset string, after *entity.ExportWatermark, until time.Time, limit int) ([]entity.ExportRecord, error) {
+	args := m.Called(ctx, dataset, after, until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ExportRecord), args.Error(1)
+}
+
+type MockWarehouseSink struct {
+	mock.Mock
+}
+
+func (m *MockWarehouseSink) Write(ctx context.Context, dataset string, rows []json.RawMessage) error {
+	args := m.Called(ctx, dataset, rows)
+	return args.Error(0)
+}
@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookPublisher struct {
+	mock.Mock
+}
+
+func (m *MockWebhookPublisher) PublishWebhookEvent(ctx context.Context, eventType string, bookingID int64) {
+	m.Called(ctx, eventType, bookingID)
+}
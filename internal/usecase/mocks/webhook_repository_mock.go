@@ -0,0 +1,78 @@
+package mocks
+
+import (
+	"context"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookRepo struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepo) CreateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) ListWebhooks(ctx context.Context, organizerID *int64) ([]entity.Webhook, error) {
+	args := m.Called(ctx, organizerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepo) GetWebhook(ctx context.Context, webhookID int64) (*entity.Webhook, error) {
+	args := m.Called(ctx, webhookID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepo) UpdateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	args := m.Called(ctx, webhookID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) ListSubscribers(ctx context.Context, organizerID *int64, eventType string) ([]entity.Webhook, error) {
+	args := m.Called(ctx, organizerID, eventType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepo) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (bool, error) {
+	args := m.Called(ctx, delivery)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookRepo) GetDelivery(ctx context.Context, deliveryID int64) (*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepo) RecordAttempt(ctx context.Context, deliveryID int64, status string, responseStatus *int, lastError string) error {
+	args := m.Called(ctx, deliveryID, status, responseStatus, lastError)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) ListDeliveries(ctx context.Context, webhookID int64, page, limit int) ([]entity.WebhookDelivery, int, error) {
+	args := m.Called(ctx, webhookID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]entity.WebhookDelivery), args.Int(1), args.Error(2)
+}
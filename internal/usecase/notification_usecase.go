@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+)
+
+// NotificationUsecase reads the in-app inbox the notification worker fills.
+type NotificationUsecase interface {
+	ListNotifications(ctx context.Context, userID int64, unreadOnly bool, page, limit int) (*entity.NotificationPage, error)
+	MarkRead(ctx context.Context, userID, notificationID int64) error
+}
+
+type notificationUsecase struct {
+	notificationRepo repository.NotificationRepository
+	contextTimeout   time.Duration
+}
+
+func NewNotificationUsecase(notificationRepo repository.NotificationRepository, timeout time.Duration) NotificationUsecase {
+	return &notificationUsecase{
+		notificationRepo: notificationRepo,
+		contextTimeout:   timeout,
+	}
+}
+
+func (uc *notificationUsecase) ListNotifications(ctx context.Context, userID int64, unreadOnly bool, page, limit int) (*entity.NotificationPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.notificationRepo.ListNotifications(ctx, userID, unreadOnly, page, limit)
+}
+
+func (uc *notificationUsecase) MarkRead(ctx context.Context, userID, notificationID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.notificationRepo.MarkRead(ctx, userID, notificationID); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Debug("usecase: notification read",
+		logger.Int64("user_id", userID),
+		logger.Int64("notification_id", notificationID),
+	)
+	return nil
+}
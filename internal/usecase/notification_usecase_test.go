@@ -0,0 +1,70 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotificationUsecase_ListNotifications(t *testing.T) {
+	t.Run("Success - Page With Unread Count", func(t *testing.T) {
+		mockRepo := new(mocks.MockNotificationRepo)
+		bookingID := int64(42)
+		expected := &entity.NotificationPage{
+			Notifications: []entity.Notification{
+				{ID: 2, UserID: 7, Kind: "payment_receipt", Message: "Payment received for booking #%s", MessageArgs: []string{"42"}, BookingID: &bookingID},
+			},
+			Total:  5,
+			Unread: 1,
+		}
+		mockRepo.On("ListNotifications", mock.Anything, int64(7), true, 1, 20).Return(expected, nil).Once()
+
+		u := usecase.NewNotificationUsecase(mockRepo, time.Second*2)
+		result, err := u.ListNotifications(context.Background(), 7, true, 1, 20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Repository Error", func(t *testing.T) {
+		mockRepo := new(mocks.MockNotificationRepo)
+		mockRepo.On("ListNotifications", mock.Anything, int64(7), false, 1, 20).Return(nil, assert.AnError).Once()
+
+		u := usecase.NewNotificationUsecase(mockRepo, time.Second*2)
+		result, err := u.ListNotifications(context.Background(), 7, false, 1, 20)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, result)
+	})
+}
+
+func TestNotificationUsecase_MarkRead(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.MockNotificationRepo)
+		mockRepo.On("MarkRead", mock.Anything, int64(7), int64(2)).Return(nil).Once()
+
+		u := usecase.NewNotificationUsecase(mockRepo, time.Second*2)
+		err := u.MarkRead(context.Background(), 7, 2)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Another User's Notification", func(t *testing.T) {
+		mockRepo := new(mocks.MockNotificationRepo)
+		mockRepo.On("MarkRead", mock.Anything, int64(8), int64(2)).Return(entity.ErrNotFound).Once()
+
+		u := usecase.NewNotificationUsecase(mockRepo, time.Second*2)
+		err := u.MarkRead(context.Background(), 8, 2)
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+	})
+}
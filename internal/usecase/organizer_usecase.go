@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/storage"
+)
+
+// MaxDocumentSize is the largest supporting document an applicant may upload.
+const MaxDocumentSize = 10 << 20
+
+// accountEncryptBatch is how many stored account numbers are encrypted at once.
+const accountEncryptBatch = 100
+
+var allowedDocumentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+var validDocTypes = map[string]bool{
+	"id_card":          true,
+	"business_license": true,
+	"tax_certificate":  true,
+	"bank_statement":   true,
+	"other":            true,
+}
+
+type OrganizerUsecase interface {
+	SubmitApplication(ctx context.Context, app *entity.OrganizerApplication) error
+	GetMyApplication(ctx context.Context, userID int64) (*entity.OrganizerApplication, error)
+	UploadDocument(ctx context.Context, userID int64, doc *entity.OrganizerDocument, content io.Reader) error
+	ListApplications(ctx context.Context, status string, page, limit int) ([]entity.OrganizerApplication, int, error)
+	GetApplication(ctx context.Context, applicationID int64) (*entity.OrganizerApplication, error)
+	OpenDocument(ctx context.Context, applicationID, documentID int64) (*entity.OrganizerDocument, io.ReadCloser, error)
+	ReviewApplication(ctx context.Context, applicationID, reviewerID int64, approve bool, note string) error
+	// EncryptStoredAccountNumbers encrypts the bank account numbers stored
+	// before they were encrypted and returns how many it encrypted.
+	EncryptStoredAccountNumbers(ctx context.Context) (int, error)
+}
+
+type organizerUsecase struct {
+	organizerRepo  repository.OrganizerRepository
+	store          storage.Storage
+	cipher         AccountCipher
+	auditor        AuditUsecase
+	contextTimeout time.Duration
+}
+
+func NewOrganizerUsecase(organizerRepo repository.OrganizerRepository, store storage.Storage, cipher AccountCipher, auditor AuditUsecase, timeout time.Duration) OrganizerUsecase {
+	return &organizerUsecase{
+		organizerRepo:  organizerRepo,
+		store:          store,
+		cipher:         cipher,
+		auditor:        auditor,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *organizerUsecase) SubmitApplication(ctx context.Context, app *entity.OrganizerApplication) error {
+	logger.FromContext(ctx).Debug("usecase: submitting organizer application", logger.Int64("user_id", app.UserID))
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	existing, err := uc.organizerRepo.GetLatestApplicationByUserID(ctx, app.UserID)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return err
+	}
+	if existing != nil && (existing.Status == "PENDING" || existing.Status == "APPROVED") {
+		logger.FromContext(ctx).Warn("usecase: organizer application already active",
+			logger.Int64("user_id", app.UserID),
+			logger.String("status", existing.Status),
+		)
+		return entity.ErrApplicationExists
+	}
+
+	enc, err := uc.cipher.Encrypt(app.BankAccountNumber)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to encrypt account number", logger.Err(err))
+		return err
+	}
+	app.BankAccountNumberEnc = enc
+	app.BankAccountLast4 = lastFour(app.BankAccountNumber)
+
+	if err := uc.organizerRepo.CreateApplication(ctx, app); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("usecase: organizer application submitted",
+		logger.Int64("application_id", app.ID),
+		logger.Int64("user_id", app.UserID),
+	)
+	return nil
+}
+
+func (uc *organizerUsecase) GetMyApplication(ctx context.Context, userID int64) (*entity.OrganizerApplication, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	app, err := uc.organizerRepo.GetLatestApplicationByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.attachDocuments(ctx, app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// UploadDocument stores a supporting document against the caller's pending
+// application. Documents can no longer be changed once the application is reviewed.
+func (uc *organizerUsecase) UploadDocument(ctx context.Context, userID int64, doc *entity.OrganizerDocument, content io.Reader) error {
+	logger.FromContext(ctx).Debug("usecase: uploading organizer document",
+		logger.Int64("user_id", userID),
+		logger.String("doc_type", doc.DocType),
+	)
+
+	if !validDocTypes[doc.DocType] || !allowedDocumentTypes[doc.ContentType] ||
+		doc.SizeBytes <= 0 || doc.SizeBytes > MaxDocumentSize {
+		logger.FromContext(ctx).Warn("usecase: invalid organizer document",
+			logger.String("doc_type", doc.DocType),
+			logger.String("content_type", doc.ContentType),
+			logger.Int64("size", doc.SizeBytes),
+		)
+		return entity.ErrInvalidDocument
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	app, err := uc.organizerRepo.GetLatestApplicationByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if app.Status != "PENDING" {
+		return entity.ErrApplicationNotPending
+	}
+
+	key, err := documentKey(app.ID, doc.FileName)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to generate document key", logger.Err(err))
+		return err
+	}
+
+	if err := uc.store.Put(ctx, key, content, doc.ContentType); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to store organizer document", logger.Int64("application_id", app.ID), logger.Err(err))
+		return err
+	}
+
+	doc.ApplicationID = app.ID
+	doc.StorageKey = key
+	if err := uc.organizerRepo.AddDocument(ctx, doc); err != nil {
+		// Don't leave an orphaned file behind when the metadata insert fails
+		if delErr := uc.store.Delete(context.Background(), key); delErr != nil {
+			logger.FromContext(ctx).Warn("usecase: failed to clean up organizer document", logger.String("key", key), logger.Err(delErr))
+		}
+		return err
+	}
+
+	logger.FromContext(ctx).Info("usecase: organizer document uploaded",
+		logger.Int64("application_id", app.ID),
+		logger.Int64("document_id", doc.ID),
+	)
+	return nil
+}
+
+func (uc *organizerUsecase) ListApplications(ctx context.Context, status string, page, limit int) ([]entity.OrganizerApplication, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.organizerRepo.ListApplications(ctx, status, page, limit)
+}
+
+func (uc *organizerUsecase) GetApplication(ctx context.Context, applicationID int64) (*entity.OrganizerApplication, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	app, err := uc.organizerRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.attachDocuments(ctx, app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// OpenDocument returns the document metadata and a reader over its content.
+// The caller must close the reader.
+func (uc *organizerUsecase) OpenDocument(ctx context.Context, applicationID, documentID int64) (*entity.OrganizerDocument, io.ReadCloser, error) {
+	docs, err := uc.organizerRepo.GetDocumentsByApplicationID(ctx, applicationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range docs {
+		if docs[i].ID != documentID {
+			continue
+		}
+		rc, err := uc.store.Get(ctx, docs[i].StorageKey)
+		if err != nil {
+			logger.FromContext(ctx).Error("usecase: failed to open organizer document",
+				logger.Int64("document_id", documentID),
+				logger.Err(err),
+			)
+			return nil, nil, err
+		}
+		return &docs[i], rc, nil
+	}
+
+	return nil, nil, entity.ErrNotFound
+}
+
+// ReviewApplication approves or rejects a pending application. Approval grants
+// the applicant the organizer role; the change takes effect on their next login.
+func (uc *organizerUsecase) ReviewApplication(ctx context.Context, applicationID, reviewerID int64, approve bool, note string) error {
+	logger.FromContext(ctx).Debug("usecase: reviewing organizer application",
+		logger.Int64("application_id", applicationID),
+		logger.Int64("reviewer_id", reviewerID),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	app, err := uc.organizerRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return err
+	}
+	if app.Status != "PENDING" {
+		return entity.ErrApplicationNotPending
+	}
+
+	if !approve {
+		if err := uc.organizerRepo.RejectApplication(ctx, applicationID, reviewerID, note); err != nil {
+			return err
+		}
+		logger.FromContext(ctx).Info("usecase: organizer application rejected", logger.Int64("application_id", applicationID))
+		return nil
+	}
+
+	if err := uc.organizerRepo.ApproveApplication(ctx, applicationID, reviewerID, note); err != nil {
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionUserRoleGrant, "user", app.UserID, map[string]interface{}{
+		"role":           "organizer",
+		"application_id": applicationID,
+	})
+
+	logger.FromContext(ctx).Info("usecase: organizer application approved",
+		logger.Int64("application_id", applicationID),
+		logger.Int64("user_id", app.UserID),
+	)
+	return nil
+}
+
+// EncryptStoredAccountNumbers runs once at startup. The encryption key is
+// only known to the API, so a migration can't encrypt the numbers itself.
+func (uc *organizerUsecase) EncryptStoredAccountNumbers(ctx context.Context) (int, error) {
+	encrypted := 0
+	for {
+		apps, err := uc.organizerRepo.ListPlaintextAccountNumbers(ctx, accountEncryptBatch)
+		if err != nil {
+			return encrypted, err
+		}
+		for _, app := range apps {
+			enc, err := uc.cipher.Encrypt(app.BankAccountNumber)
+			if err != nil {
+				logger.FromContext(ctx).Error("usecase: failed to encrypt account number", logger.Int64("application_id", app.ID), logger.Err(err))
+				return encrypted, err
+			}
+			if err := uc.organizerRepo.SetEncryptedAccountNumber(ctx, app.ID, enc, lastFour(app.BankAccountNumber)); err != nil {
+				return encrypted, err
+			}
+			encrypted++
+		}
+		if len(apps) < accountEncryptBatch {
+			return encrypted, nil
+		}
+	}
+}
+
+// attachDocuments loads document metadata only. Identity documents are never
+// exposed through a public URL; admins download them through the API.
+func (uc *organizerUsecase) attachDocuments(ctx context.Context, app *entity.OrganizerApplication) error {
+	docs, err := uc.organizerRepo.GetDocumentsByApplicationID(ctx, app.ID)
+	if err != nil {
+		return err
+	}
+	app.Documents = docs
+	return nil
+}
+
+// documentKey builds a unique storage key so re-uploads never overwrite each other.
+func documentKey(applicationID int64, fileName string) (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	name := strings.ReplaceAll(filepath.Base(fileName), " ", "_")
+	return fmt.Sprintf("organizer-applications/%d/%s-%s", applicationID, hex.EncodeToString(buf), name), nil
+}
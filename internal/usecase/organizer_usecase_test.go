@@ -0,0 +1,270 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOrganizerUsecase_SubmitApplication(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(mockRepo *mocks.MockOrganizerRepo)
+		wantErr error
+	}{
+		{
+			name: "Success - First Application",
+			mock: func(mockRepo *mocks.MockOrganizerRepo) {
+				mockRepo.On("GetLatestApplicationByUserID", mock.Anything, int64(5)).Return(nil, entity.ErrNotFound).Once()
+				mockRepo.On("CreateApplication", mock.Anything, mock.AnythingOfType("*entity.OrganizerApplication")).Return(nil).Once()
+			},
+		},
+		{
+			name: "Success - Reapply After Rejection",
+			mock: func(mockRepo *mocks.MockOrganizerRepo) {
+				mockRepo.On("GetLatestApplicationByUserID", mock.Anything, int64(5)).
+					Return(&entity.OrganizerApplication{ID: 1, UserID: 5, Status: "REJECTED"}, nil).Once()
+				mockRepo.On("CreateApplication", mock.Anything, mock.AnythingOfType("*entity.OrganizerApplication")).Return(nil).Once()
+			},
+		},
+		{
+			name: "Failed - Pending Application Exists",
+			mock: func(mockRepo *mocks.MockOrganizerRepo) {
+				mockRepo.On("GetLatestApplicationByUserID", mock.Anything, int64(5)).
+					Return(&entity.OrganizerApplication{ID: 1, UserID: 5, Status: "PENDING"}, nil).Once()
+			},
+			wantErr: entity.ErrApplicationExists,
+		},
+		{
+			name: "Failed - Already Approved",
+			mock: func(mockRepo *mocks.MockOrganizerRepo) {
+				mockRepo.On("GetLatestApplicationByUserID", mock.Anything, int64(5)).
+					Return(&entity.OrganizerApplication{ID: 1, UserID: 5, Status: "APPROVED"}, nil).Once()
+			},
+			wantErr: entity.ErrApplicationExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockOrganizerRepo)
+			tt.mock(mockRepo)
+
+			uc := usecase.NewOrganizerUsecase(mockRepo, new(mocks.MockStorage), newTestCipher(t), new(mocks.MockAuditUsecase), 2*time.Second)
+			err := uc.SubmitApplication(context.Background(), &entity.OrganizerApplication{UserID: 5, BusinessName: "Acme Events"})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrganizerUsecase_SubmitApplication_EncryptsAccountNumber(t *testing.T) {
+	cipher := newTestCipher(t)
+	mockRepo := new(mocks.MockOrganizerRepo)
+	mockRepo.On("GetLatestApplicationByUserID", mock.Anything, int64(5)).Return(nil, entity.ErrNotFound).Once()
+	mockRepo.On("CreateApplication", mock.Anything, mock.MatchedBy(func(app *entity.OrganizerApplication) bool {
+		plain, err := cipher.Decrypt(app.BankAccountNumberEnc)
+		return err == nil && plain == "1234567890" && app.BankAccountLast4 == "7890"
+	})).Return(nil).Once()
+
+	uc := usecase.NewOrganizerUsecase(mockRepo, new(mocks.MockStorage), cipher, new(mocks.MockAuditUsecase), 2*time.Second)
+	err := uc.SubmitApplication(context.Background(), &entity.OrganizerApplication{UserID: 5, BusinessName: "Acme Events", BankAccountNumber: "1234567890"})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrganizerUsecase_EncryptStoredAccountNumbers(t *testing.T) {
+	cipher := newTestCipher(t)
+	decryptsTo := func(want string) interface{} {
+		return mock.MatchedBy(func(enc string) bool {
+			plain, err := cipher.Decrypt(enc)
+			return err == nil && plain == want
+		})
+	}
+
+	t.Run("Encrypts Until None Are Left", func(t *testing.T) {
+		mockRepo := new(mocks.MockOrganizerRepo)
+		mockRepo.On("ListPlaintextAccountNumbers", mock.Anything, 100).
+			Return([]entity.OrganizerApplication{{ID: 1, BankAccountNumber: "1234567890"}, {ID: 2, BankAccountNumber: "555"}}, nil).Once()
+		mockRepo.On("SetEncryptedAccountNumber", mock.Anything, int64(1), decryptsTo("1234567890"), "7890").Return(nil).Once()
+		mockRepo.On("SetEncryptedAccountNumber", mock.Anything, int64(2), decryptsTo("555"), "555").Return(nil).Once()
+
+		uc := usecase.NewOrganizerUsecase(mockRepo, new(mocks.MockStorage), cipher, new(mocks.MockAuditUsecase), 2*time.Second)
+		n, err := uc.EncryptStoredAccountNumbers(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, n)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Stops At The First Failure", func(t *testing.T) {
+		mockRepo := new(mocks.MockOrganizerRepo)
+		mockRepo.On("ListPlaintextAccountNumbers", mock.Anything, 100).
+			Return([]entity.OrganizerApplication{{ID: 1, BankAccountNumber: "1234567890"}, {ID: 2, BankAccountNumber: "555"}}, nil).Once()
+		mockRepo.On("SetEncryptedAccountNumber", mock.Anything, int64(1), mock.Anything, "7890").Return(errors.New("db down")).Once()
+
+		uc := usecase.NewOrganizerUsecase(mockRepo, new(mocks.MockStorage), cipher, new(mocks.MockAuditUsecase), 2*time.Second)
+		n, err := uc.EncryptStoredAccountNumbers(context.Background())
+
+		assert.Error(t, err)
+		assert.Zero(t, n)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrganizerUsecase_UploadDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     entity.OrganizerDocument
+		mock    func(mockRepo *mocks.MockOrganizerRepo, mockStore *mocks.MockStorage)
+		wantErr error
+	}{
+		{
+			name: "Success Upload",
+			doc:  entity.OrganizerDocument{DocType: "id_card", FileName: "ktp.png", ContentType: "image/png", SizeBytes: 1024},
+			mock: func(mockRepo *mocks.MockOrganizerRepo, mockStore *mocks.MockStorage) {
+				mockRepo.On("GetLatestApplicationByUserID", mock.Anything, int64(5)).
+					Return(&entity.OrganizerApplication{ID: 3, UserID: 5, Status: "PENDING"}, nil).Once()
+				mockStore.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "organizer-applications/3/") && strings.HasSuffix(key, "-ktp.png")
+				}), mock.Anything, "image/png").Return(nil).Once()
+				mockRepo.On("AddDocument", mock.Anything, mock.AnythingOfType("*entity.OrganizerDocument")).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Failed - Unsupported File Type",
+			doc:     entity.OrganizerDocument{DocType: "id_card", FileName: "ktp.exe", ContentType: "application/octet-stream", SizeBytes: 1024},
+			mock:    func(mockRepo *mocks.MockOrganizerRepo, mockStore *mocks.MockStorage) {},
+			wantErr: entity.ErrInvalidDocument,
+		},
+		{
+			name:    "Failed - Too Large",
+			doc:     entity.OrganizerDocument{DocType: "id_card", FileName: "ktp.pdf", ContentType: "application/pdf", SizeBytes: usecase.MaxDocumentSize + 1},
+			mock:    func(mockRepo *mocks.MockOrganizerRepo, mockStore *mocks.MockStorage) {},
+			wantErr: entity.ErrInvalidDocument,
+		},
+		{
+			name: "Failed - Application Already Reviewed",
+			doc:  entity.OrganizerDocument{DocType: "id_card", FileName: "ktp.pdf", ContentType: "application/pdf", SizeBytes: 1024},
+			mock: func(mockRepo *mocks.MockOrganizerRepo, mockStore *mocks.MockStorage) {
+				mockRepo.On("GetLatestApplicationByUserID", mock.Anything, int64(5)).
+					Return(&entity.OrganizerApplication{ID: 3, UserID: 5, Status: "APPROVED"}, nil).Once()
+			},
+			wantErr: entity.ErrApplicationNotPending,
+		},
+		{
+			name: "Failed - Metadata Insert Removes Stored File",
+			doc:  entity.OrganizerDocument{DocType: "id_card", FileName: "ktp.pdf", ContentType: "application/pdf", SizeBytes: 1024},
+			mock: func(mockRepo *mocks.MockOrganizerRepo, mockStore *mocks.MockStorage) {
+				mockRepo.On("GetLatestApplicationByUserID", mock.Anything, int64(5)).
+					Return(&entity.OrganizerApplication{ID: 3, UserID: 5, Status: "PENDING"}, nil).Once()
+				mockStore.On("Put", mock.Anything, mock.Anything, mock.Anything, "application/pdf").Return(nil).Once()
+				mockRepo.On("AddDocument", mock.Anything, mock.Anything).Return(errors.New("db error")).Once()
+				mockStore.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockOrganizerRepo)
+			mockStore := new(mocks.MockStorage)
+			tt.mock(mockRepo, mockStore)
+
+			uc := usecase.NewOrganizerUsecase(mockRepo, mockStore, newTestCipher(t), new(mocks.MockAuditUsecase), 2*time.Second)
+			doc := tt.doc
+			err := uc.UploadDocument(context.Background(), 5, &doc, strings.NewReader("content"))
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(3), doc.ApplicationID)
+			}
+			mockRepo.AssertExpectations(t)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrganizerUsecase_ReviewApplication(t *testing.T) {
+	tests := []struct {
+		name    string
+		approve bool
+		mock    func(mockRepo *mocks.MockOrganizerRepo, mockAudit *mocks.MockAuditUsecase)
+		wantErr error
+	}{
+		{
+			name:    "Success Approve",
+			approve: true,
+			mock: func(mockRepo *mocks.MockOrganizerRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetApplicationByID", mock.Anything, int64(3)).
+					Return(&entity.OrganizerApplication{ID: 3, UserID: 5, Status: "PENDING"}, nil).Once()
+				mockRepo.On("ApproveApplication", mock.Anything, int64(3), int64(1), "looks good").Return(nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionUserRoleGrant, "user", int64(5), mock.Anything).Once()
+			},
+		},
+		{
+			name:    "Success Reject",
+			approve: false,
+			mock: func(mockRepo *mocks.MockOrganizerRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetApplicationByID", mock.Anything, int64(3)).
+					Return(&entity.OrganizerApplication{ID: 3, UserID: 5, Status: "PENDING"}, nil).Once()
+				mockRepo.On("RejectApplication", mock.Anything, int64(3), int64(1), "looks good").Return(nil).Once()
+			},
+		},
+		{
+			name:    "Failed - Already Reviewed",
+			approve: true,
+			mock: func(mockRepo *mocks.MockOrganizerRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetApplicationByID", mock.Anything, int64(3)).
+					Return(&entity.OrganizerApplication{ID: 3, UserID: 5, Status: "REJECTED"}, nil).Once()
+			},
+			wantErr: entity.ErrApplicationNotPending,
+		},
+		{
+			name:    "Failed - Not Found",
+			approve: true,
+			mock: func(mockRepo *mocks.MockOrganizerRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRepo.On("GetApplicationByID", mock.Anything, int64(3)).Return(nil, entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockOrganizerRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			tt.mock(mockRepo, mockAudit)
+
+			uc := usecase.NewOrganizerUsecase(mockRepo, new(mocks.MockStorage), newTestCipher(t), mockAudit, 2*time.Second)
+			err := uc.ReviewApplication(context.Background(), 3, 1, tt.approve, "looks good")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
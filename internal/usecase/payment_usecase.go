@@ -2,34 +2,94 @@ package usecase
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"ticres/internal/entity"
 	"ticres/internal/repository"
+	"ticres/pkg/clock"
 	"ticres/pkg/logger"
+	"ticres/pkg/payment"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type PaymentUsecase interface {
 	ProcessPayment(ctx context.Context, bookingID, userID int64, paymentMethod string) (*entity.Transaction, error)
 	GetPaymentStatus(ctx context.Context, bookingID, userID int64) (*entity.BookingWithPayment, error)
+	// CreatePaymentLink signs a link support can send to the booking's
+	// customer. With extendBy the payment deadline is first moved to at
+	// least extendBy from now; otherwise the link expires with the booking.
+	CreatePaymentLink(ctx context.Context, bookingID int64, extendBy time.Duration) (*entity.PaymentLink, error)
+	// GetPaymentLinkBooking returns the booking behind a payment link token.
+	GetPaymentLinkBooking(ctx context.Context, token string) (*entity.BookingWithPayment, error)
+	// PayWithLink pays the booking behind a payment link token.
+	PayWithLink(ctx context.Context, token, paymentMethod string) (*entity.Transaction, error)
+}
+
+// MaxPaymentLinkExtension caps how far support can push a payment deadline.
+const MaxPaymentLinkExtension = 24 * time.Hour
+
+// gatewayHoldTimeout bounds holding a booking after a failed checkout, which
+// may run after the gateway used up the request's time.
+const gatewayHoldTimeout = 2 * time.Second
+
+// ReceiptSender emails a payment receipt with the issued tickets.
+type ReceiptSender interface {
+	SendPaymentReceipt(ctx context.Context, bookingID int64)
 }
 
 type paymentUsecase struct {
 	bookingRepo     repository.BookingRepository
 	transactionRepo repository.TransactionRepository
+	ticketRepo      repository.TicketRepository
+	gateway         payment.Gateway
+	receiptSender   ReceiptSender
+	webhooks        WebhookPublisher
+	events          DomainEventPublisher
+	auditor         AuditUsecase
+	linkSecret      []byte
+	linkURL         string
+	outageHold      time.Duration
+	clock           clock.Clock
 	contextTimeout  time.Duration
 }
 
 func NewPaymentUsecase(
 	bookingRepo repository.BookingRepository,
 	transactionRepo repository.TransactionRepository,
+	ticketRepo repository.TicketRepository,
+	gateway payment.Gateway,
+	receiptSender ReceiptSender,
+	webhooks WebhookPublisher,
+	events DomainEventPublisher,
+	auditor AuditUsecase,
+	linkSecret string,
+	linkURL string,
+	outageHold time.Duration,
+	clk clock.Clock,
 	timeout time.Duration,
 ) PaymentUsecase {
 	return &paymentUsecase{
 		bookingRepo:     bookingRepo,
 		transactionRepo: transactionRepo,
+		ticketRepo:      ticketRepo,
+		gateway:         gateway,
+		receiptSender:   receiptSender,
+		webhooks:        webhooks,
+		events:          events,
+		auditor:         auditor,
+		linkSecret:      []byte(linkSecret),
+		linkURL:         linkURL,
+		outageHold:      outageHold,
+		clock:           clk,
 		contextTimeout:  timeout,
 	}
 }
@@ -41,7 +101,13 @@ var validPaymentMethods = map[string]string{
 }
 
 func (uc *paymentUsecase) ProcessPayment(ctx context.Context, bookingID, userID int64, paymentMethod string) (*entity.Transaction, error) {
-	logger.Info("usecase: processing payment",
+	ctx, span := tracing.Start(ctx, "PaymentUsecase.ProcessPayment",
+		attribute.Int64("booking_id", bookingID),
+		attribute.String("payment_method", paymentMethod),
+	)
+	defer span.End()
+
+	logger.FromContext(ctx).Info("usecase: processing payment",
 		logger.Int64("booking_id", bookingID),
 		logger.Int64("user_id", userID),
 		logger.String("payment_method", paymentMethod),
@@ -65,18 +131,25 @@ func (uc *paymentUsecase) ProcessPayment(ctx context.Context, bookingID, userID
 		return nil, entity.ErrUnauthorized
 	}
 
+	return uc.completePayment(ctx, booking, paymentMethod, methodCode)
+}
+
+// completePayment charges a booking the caller is allowed to pay.
+func (uc *paymentUsecase) completePayment(ctx context.Context, booking *entity.Booking, paymentMethod, methodCode string) (*entity.Transaction, error) {
+	bookingID := booking.ID
+
 	// Check booking status
-	if booking.Status != "PENDING" {
-		if booking.Status == "PAID" {
+	if booking.Status != entity.BookingPending {
+		if booking.Status == entity.BookingPaid {
 			return nil, entity.ErrPaymentAlreadyMade
 		}
 		return nil, entity.ErrBookingNotPending
 	}
 
 	// Check expiry
-	if booking.ExpiresAt != nil && time.Now().After(*booking.ExpiresAt) {
+	if booking.ExpiresAt != nil && uc.clock.Now().After(*booking.ExpiresAt) {
 		// Mark booking as expired and release seats
-		uc.bookingRepo.UpdateBookingStatus(ctx, bookingID, "EXPIRED")
+		uc.bookingRepo.UpdateBookingStatus(ctx, bookingID, entity.BookingExpired)
 		uc.bookingRepo.ReleaseSeatsByBookingID(ctx, bookingID)
 		return nil, entity.ErrBookingExpired
 	}
@@ -86,7 +159,7 @@ func (uc *paymentUsecase) ProcessPayment(ctx context.Context, bookingID, userID
 	if err != nil {
 		return nil, err
 	}
-	if txn != nil && txn.Status == "COMPLETED" {
+	if txn != nil && txn.Status == entity.PaymentCompleted {
 		return nil, entity.ErrPaymentAlreadyMade
 	}
 
@@ -96,36 +169,61 @@ func (uc *paymentUsecase) ProcessPayment(ctx context.Context, bookingID, userID
 			Amount:        booking.TotalAmount,
 			PaymentMethod: paymentMethod,
 			BookingID:     bookingID,
-			Status:        "PENDING",
+			Status:        entity.PaymentPending,
 		}
 		if err := uc.transactionRepo.CreateTransaction(ctx, txn); err != nil {
 			return nil, err
 		}
 	}
 
-	// Simulate payment gateway processing
-	time.Sleep(500 * time.Millisecond)
-
-	// Generate external ID (mock gateway reference)
-	externalID := fmt.Sprintf("PAY-%s-%d-%d", methodCode, bookingID, time.Now().UnixMilli())
+	// Keyed by the transaction, so a retried or repeated attempt is never
+	// charged twice
+	externalID, err := uc.gateway.Charge(ctx, payment.ChargeRequest{
+		IdempotencyKey: fmt.Sprintf("payment-%d", txn.ID),
+		Reference:      fmt.Sprintf("%s-%d", methodCode, bookingID),
+		Method:         paymentMethod,
+		Amount:         txn.Amount,
+	})
+	if err != nil {
+		err = gatewayError(ctx, err, bookingID)
+		if errors.Is(err, entity.ErrGatewayUnavailable) {
+			uc.holdForGateway(ctx, bookingID)
+		}
+		return nil, err
+	}
 
 	// Update transaction to COMPLETED
-	if err := uc.transactionRepo.UpdateTransactionStatus(ctx, txn.ID, "COMPLETED", externalID); err != nil {
-		logger.Error("usecase: failed to update transaction status", logger.Err(err))
+	if err := uc.transactionRepo.UpdateTransactionStatus(ctx, txn.ID, entity.PaymentCompleted, externalID); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to update transaction status", logger.Err(err))
 		return nil, err
 	}
 
 	// Update booking to PAID
-	if err := uc.bookingRepo.UpdateBookingStatus(ctx, bookingID, "PAID"); err != nil {
-		logger.Error("usecase: failed to update booking status", logger.Err(err))
+	if err := uc.bookingRepo.UpdateBookingStatus(ctx, bookingID, entity.BookingPaid); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to update booking status", logger.Err(err))
 		return nil, err
 	}
 
-	txn.Status = "COMPLETED"
+	// Issue one QR ticket per seat. The payment already succeeded, so a failure
+	// here is logged and the tickets can be issued again later.
+	if _, err := uc.ticketRepo.IssueTickets(ctx, bookingID); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to issue tickets", logger.Int64("booking_id", bookingID), logger.Err(err))
+	}
+
+	txn.Status = entity.PaymentCompleted
 	txn.ExternalID = externalID
 	txn.PaymentMethod = paymentMethod
 
-	logger.Info("usecase: payment processed successfully",
+	uc.receiptSender.SendPaymentReceipt(ctx, bookingID)
+	uc.webhooks.PublishWebhookEvent(ctx, entity.WebhookPaymentCompleted, bookingID)
+	uc.events.PublishDomainEvent(ctx, entity.DomainEventPaymentCompleted, bookingID, map[string]interface{}{
+		"transaction_id": txn.ID,
+		"amount":         txn.Amount,
+		"payment_method": paymentMethod,
+		"external_id":    externalID,
+	})
+
+	logger.FromContext(ctx).Info("usecase: payment processed successfully",
 		logger.Int64("booking_id", bookingID),
 		logger.String("external_id", externalID),
 		logger.String("payment_method", paymentMethod),
@@ -134,8 +232,40 @@ func (uc *paymentUsecase) ProcessPayment(ctx context.Context, bookingID, userID
 	return txn, nil
 }
 
+// holdForGateway keeps a booking whose checkout failed on the gateway for the
+// outage hold, and has its customer told once payments work again.
+func (uc *paymentUsecase) holdForGateway(ctx context.Context, bookingID int64) {
+	if uc.outageHold <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), gatewayHoldTimeout)
+	defer cancel()
+
+	if _, err := uc.bookingRepo.HoldForGateway(ctx, bookingID, uc.clock.Now().Add(uc.outageHold)); err != nil {
+		logger.FromContext(ctx).Warn("usecase: failed to hold booking for payment gateway",
+			logger.Int64("booking_id", bookingID),
+			logger.Err(err),
+		)
+	}
+}
+
+// gatewayError turns a failed gateway call into the error shown to the
+// client. The transaction stays PENDING, so the booking can be paid again.
+func gatewayError(ctx context.Context, err error, bookingID int64) error {
+	switch {
+	case errors.Is(err, payment.ErrDeclined):
+		logger.FromContext(ctx).Warn("usecase: payment declined", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return entity.ErrPaymentDeclined
+	case errors.Is(err, payment.ErrUnavailable):
+		logger.FromContext(ctx).Error("usecase: payment gateway unavailable", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return entity.ErrGatewayUnavailable
+	default:
+		return err
+	}
+}
+
 func (uc *paymentUsecase) GetPaymentStatus(ctx context.Context, bookingID, userID int64) (*entity.BookingWithPayment, error) {
-	logger.Debug("usecase: getting payment status", logger.Int64("booking_id", bookingID))
+	logger.FromContext(ctx).Debug("usecase: getting payment status", logger.Int64("booking_id", bookingID))
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
@@ -162,9 +292,172 @@ func (uc *paymentUsecase) GetPaymentStatus(ctx context.Context, bookingID, userI
 		Transaction: txn,
 	}
 
+	if booking.Status == entity.BookingPaid {
+		tickets, err := uc.ticketRepo.GetTicketsByBookingID(ctx, bookingID)
+		if err != nil {
+			return nil, err
+		}
+		result.Tickets = tickets
+	}
+
 	return result, nil
 }
 
+func (uc *paymentUsecase) CreatePaymentLink(ctx context.Context, bookingID int64, extendBy time.Duration) (*entity.PaymentLink, error) {
+	ctx, span := tracing.Start(ctx, "PaymentUsecase.CreatePaymentLink",
+		attribute.Int64("booking_id", bookingID),
+	)
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if extendBy < 0 || extendBy > MaxPaymentLinkExtension {
+		return nil, fmt.Errorf("%w: extension must be at most %s", entity.ErrInvalidPaymentLink, MaxPaymentLinkExtension)
+	}
+
+	booking, err := uc.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	switch booking.Status {
+	case entity.BookingPending:
+	case entity.BookingPaid:
+		return nil, entity.ErrPaymentAlreadyMade
+	case entity.BookingExpired:
+		// The seats are already released, so the booking can't be revived
+		return nil, entity.ErrBookingExpired
+	default:
+		return nil, entity.ErrBookingNotPending
+	}
+
+	var deadline time.Time
+	if booking.ExpiresAt != nil {
+		deadline = *booking.ExpiresAt
+	}
+	if extendBy > 0 {
+		deadline, err = uc.bookingRepo.ExtendBookingExpiry(ctx, bookingID, uc.clock.Now().Add(extendBy))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !deadline.After(uc.clock.Now()) {
+		return nil, entity.ErrBookingExpired
+	}
+
+	link := &entity.PaymentLink{
+		BookingID: bookingID,
+		URL:       uc.linkURL + "?token=" + uc.signPaymentLink(bookingID, deadline),
+		ExpiresAt: deadline,
+	}
+
+	uc.auditor.Record(ctx, ActionPaymentLinkCreate, "booking", bookingID, map[string]interface{}{
+		"expires_at": deadline,
+		"extended":   extendBy > 0,
+	})
+
+	logger.FromContext(ctx).Info("usecase: payment link created",
+		logger.Int64("booking_id", bookingID),
+		logger.Any("expires_at", deadline),
+	)
+	return link, nil
+}
+
+func (uc *paymentUsecase) GetPaymentLinkBooking(ctx context.Context, token string) (*entity.BookingWithPayment, error) {
+	bookingID, err := uc.verifyPaymentLink(token)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	booking, err := uc.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.BookingWithPayment{
+		BookingID:   booking.ID,
+		EventID:     booking.EventID,
+		Status:      booking.Status,
+		TotalAmount: booking.TotalAmount,
+		ExpiresAt:   booking.ExpiresAt,
+	}, nil
+}
+
+func (uc *paymentUsecase) PayWithLink(ctx context.Context, token, paymentMethod string) (*entity.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "PaymentUsecase.PayWithLink",
+		attribute.String("payment_method", paymentMethod),
+	)
+	defer span.End()
+
+	bookingID, err := uc.verifyPaymentLink(token)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int64("booking_id", bookingID))
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	methodCode, ok := validPaymentMethods[paymentMethod]
+	if !ok {
+		return nil, entity.ErrInvalidPaymentMethod
+	}
+
+	booking, err := uc.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("usecase: processing payment link",
+		logger.Int64("booking_id", bookingID),
+		logger.String("payment_method", paymentMethod),
+	)
+	return uc.completePayment(ctx, booking, paymentMethod, methodCode)
+}
+
+// signPaymentLink returns "<bookingID>.<unix expiry>.<signature>". The
+// signed message is prefixed so no other token signed with the same secret
+// can pass as a payment link.
+func (uc *paymentUsecase) signPaymentLink(bookingID int64, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d.%d", bookingID, expiresAt.Unix())
+	return payload + "." + base64.RawURLEncoding.EncodeToString(uc.paymentLinkMAC(payload))
+}
+
+func (uc *paymentUsecase) paymentLinkMAC(payload string) []byte {
+	mac := hmac.New(sha256.New, uc.linkSecret)
+	mac.Write([]byte("payment-link:" + payload))
+	return mac.Sum(nil)
+}
+
+// verifyPaymentLink checks the signature and expiry of a payment link
+// token and returns its booking ID.
+func (uc *paymentUsecase) verifyPaymentLink(token string) (int64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, entity.ErrInvalidPaymentLink
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, uc.paymentLinkMAC(parts[0]+"."+parts[1])) {
+		return 0, entity.ErrInvalidPaymentLink
+	}
+
+	bookingID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, entity.ErrInvalidPaymentLink
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, entity.ErrInvalidPaymentLink
+	}
+	if uc.clock.Now().Unix() >= expiresAt {
+		return 0, entity.ErrPaymentLinkExpired
+	}
+	return bookingID, nil
+}
+
 // FormatPaymentMethod returns display name for a payment method code
 func FormatPaymentMethod(method string) string {
 	names := map[string]string{
@@ -0,0 +1,224 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/clock"
+	"ticres/pkg/payment"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const paymentLinkURL = "http://localhost:3000/pay"
+
+func newPaymentLinkUsecase(mockBookingRepo *mocks.MockBookingRepo, mockTxnRepo *mocks.MockTransactionRepo, mockTicketRepo *mocks.MockTicketRepo, mockGateway *mocks.MockPaymentGateway, mockNotif *mocks.MockNotificationService, mockAudit *mocks.MockAuditUsecase, clk clock.Clock) usecase.PaymentUsecase {
+	return usecase.NewPaymentUsecase(mockBookingRepo, mockTxnRepo, mockTicketRepo, mockGateway, mockNotif, newWebhookPublisher(), newDomainEvents(), mockAudit, "link-secret", paymentLinkURL, time.Hour, clk, time.Second*2)
+}
+
+func linkToken(t *testing.T, link *entity.PaymentLink) string {
+	t.Helper()
+	assert.True(t, strings.HasPrefix(link.URL, paymentLinkURL+"?token="))
+	return strings.TrimPrefix(link.URL, paymentLinkURL+"?token=")
+}
+
+func TestPaymentUsecase_CreatePaymentLink(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	inTenMinutes := clk.Now().Add(10 * time.Minute)
+	anHourAgo := clk.Now().Add(-time.Hour)
+	extended := clk.Now().Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		extendBy     time.Duration
+		mock         func(mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase)
+		wantErr      error
+		wantDeadline time.Time
+	}{
+		{
+			name: "Success - Booking Deadline",
+			mock: func(mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).
+					Return(&entity.Booking{ID: 5, Status: "PENDING", ExpiresAt: &inTenMinutes}, nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionPaymentLinkCreate, "booking", int64(5), mock.Anything).Once()
+			},
+			wantDeadline: inTenMinutes,
+		},
+		{
+			name:     "Success - Extended Deadline",
+			extendBy: time.Hour,
+			mock: func(mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).
+					Return(&entity.Booking{ID: 5, Status: "PENDING", ExpiresAt: &anHourAgo}, nil).Once()
+				mockBookingRepo.On("ExtendBookingExpiry", mock.Anything, int64(5), extended).
+					Return(extended, nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionPaymentLinkCreate, "booking", int64(5), mock.Anything).Once()
+			},
+			wantDeadline: extended,
+		},
+		{
+			name: "Failed - Deadline Passed Without Extension",
+			mock: func(mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).
+					Return(&entity.Booking{ID: 5, Status: "PENDING", ExpiresAt: &anHourAgo}, nil).Once()
+			},
+			wantErr: entity.ErrBookingExpired,
+		},
+		{
+			name:     "Failed - Seats Already Released",
+			extendBy: time.Hour,
+			mock: func(mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).
+					Return(&entity.Booking{ID: 5, Status: "EXPIRED", ExpiresAt: &anHourAgo}, nil).Once()
+			},
+			wantErr: entity.ErrBookingExpired,
+		},
+		{
+			name: "Failed - Already Paid",
+			mock: func(mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).
+					Return(&entity.Booking{ID: 5, Status: "PAID", ExpiresAt: &inTenMinutes}, nil).Once()
+			},
+			wantErr: entity.ErrPaymentAlreadyMade,
+		},
+		{
+			name:     "Failed - Extension Too Long",
+			extendBy: usecase.MaxPaymentLinkExtension + time.Minute,
+			mock:     func(mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {},
+			wantErr:  entity.ErrInvalidPaymentLink,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBookingRepo := new(mocks.MockBookingRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			tt.mock(mockBookingRepo, mockAudit)
+
+			u := newPaymentLinkUsecase(mockBookingRepo, new(mocks.MockTransactionRepo), new(mocks.MockTicketRepo), new(mocks.MockPaymentGateway), new(mocks.MockNotificationService), mockAudit, clk)
+			link, err := u.CreatePaymentLink(context.Background(), 5, tt.extendBy)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, link)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(5), link.BookingID)
+				assert.True(t, tt.wantDeadline.Equal(link.ExpiresAt))
+				assert.NotEmpty(t, linkToken(t, link))
+			}
+			mockBookingRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPaymentUsecase_PaymentLinkToken(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	deadline := clk.Now().Add(10 * time.Minute)
+
+	mockBookingRepo := new(mocks.MockBookingRepo)
+	mockTxnRepo := new(mocks.MockTransactionRepo)
+	mockTicketRepo := new(mocks.MockTicketRepo)
+	mockGateway := new(mocks.MockPaymentGateway)
+	mockNotif := new(mocks.MockNotificationService)
+	mockAudit := new(mocks.MockAuditUsecase)
+
+	booking := &entity.Booking{ID: 5, UserID: 9, EventID: 10, Status: "PENDING", TotalAmount: 150000, ExpiresAt: &deadline}
+	mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(booking, nil)
+	mockAudit.On("Record", mock.Anything, usecase.ActionPaymentLinkCreate, "booking", int64(5), mock.Anything).Once()
+
+	u := newPaymentLinkUsecase(mockBookingRepo, mockTxnRepo, mockTicketRepo, mockGateway, mockNotif, mockAudit, clk)
+	link, err := u.CreatePaymentLink(context.Background(), 5, 0)
+	assert.NoError(t, err)
+	token := linkToken(t, link)
+
+	t.Run("Lookup", func(t *testing.T) {
+		result, err := u.GetPaymentLinkBooking(context.Background(), token)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), result.BookingID)
+		assert.Equal(t, float64(150000), result.TotalAmount)
+	})
+
+	t.Run("Tampered Booking ID", func(t *testing.T) {
+		tampered := "6" + strings.TrimPrefix(token, "5")
+		_, err := u.GetPaymentLinkBooking(context.Background(), tampered)
+		assert.ErrorIs(t, err, entity.ErrInvalidPaymentLink)
+	})
+
+	t.Run("Signed With Another Secret", func(t *testing.T) {
+		other := usecase.NewPaymentUsecase(mockBookingRepo, mockTxnRepo, mockTicketRepo, mockGateway, mockNotif, newWebhookPublisher(), newDomainEvents(), mockAudit, "other-secret", paymentLinkURL, time.Hour, clk, time.Second*2)
+		_, err := other.PayWithLink(context.Background(), token, "e_wallet")
+		assert.ErrorIs(t, err, entity.ErrInvalidPaymentLink)
+	})
+
+	charge := payment.ChargeRequest{IdempotencyKey: "payment-77", Reference: "EW-5", Method: "e_wallet", Amount: 150000}
+
+	for _, tc := range []struct {
+		name       string
+		gatewayErr error
+		wantErr    error
+		// wantHold is set when the booking is kept for the outage hold
+		wantHold bool
+	}{
+		{name: "Gateway Unavailable", gatewayErr: fmt.Errorf("%w: circuit open", payment.ErrUnavailable), wantErr: entity.ErrGatewayUnavailable, wantHold: true},
+		{name: "Payment Declined", gatewayErr: payment.ErrDeclined, wantErr: entity.ErrPaymentDeclined},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTxnRepo.On("GetTransactionByBookingID", mock.Anything, int64(5)).
+				Return(&entity.Transaction{ID: 77, BookingID: 5, Amount: 150000, Status: "PENDING"}, nil).Once()
+			mockGateway.On("Charge", mock.Anything, charge).Return("", tc.gatewayErr).Once()
+			if tc.wantHold {
+				mockBookingRepo.On("HoldForGateway", mock.Anything, int64(5), clk.Now().Add(time.Hour)).
+					Return(clk.Now().Add(time.Hour), nil).Once()
+			}
+
+			_, err := u.PayWithLink(context.Background(), token, "e_wallet")
+			assert.ErrorIs(t, err, tc.wantErr)
+			// A hold after a declined payment fails on the used-up expectation
+			if tc.wantHold {
+				mockBookingRepo.AssertCalled(t, "HoldForGateway", mock.Anything, int64(5), mock.Anything)
+			}
+			// The transaction stays PENDING so the customer can try again
+			mockTxnRepo.AssertNotCalled(t, "UpdateTransactionStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockBookingRepo.AssertNotCalled(t, "UpdateBookingStatus", mock.Anything, int64(5), entity.BookingPaid)
+		})
+	}
+
+	t.Run("Pay Without Login", func(t *testing.T) {
+		mockTxnRepo.On("GetTransactionByBookingID", mock.Anything, int64(5)).
+			Return(&entity.Transaction{ID: 77, BookingID: 5, Amount: 150000, Status: "PENDING"}, nil).Once()
+		mockGateway.On("Charge", mock.Anything, charge).Return("PAY-EW-5-1", nil).Once()
+		mockTxnRepo.On("UpdateTransactionStatus", mock.Anything, int64(77), entity.PaymentCompleted, "PAY-EW-5-1").Return(nil).Once()
+		mockBookingRepo.On("UpdateBookingStatus", mock.Anything, int64(5), entity.BookingPaid).Return(nil).Once()
+		mockTicketRepo.On("IssueTickets", mock.Anything, int64(5)).Return([]entity.Ticket{}, nil).Once()
+		mockNotif.On("SendPaymentReceipt", mock.Anything, int64(5)).Once()
+
+		txn, err := u.PayWithLink(context.Background(), token, "e_wallet")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.PaymentCompleted, txn.Status)
+		assert.Equal(t, "e_wallet", txn.PaymentMethod)
+		assert.Equal(t, "PAY-EW-5-1", txn.ExternalID)
+		mockGateway.AssertExpectations(t)
+		mockTxnRepo.AssertExpectations(t)
+		mockTicketRepo.AssertExpectations(t)
+		mockNotif.AssertExpectations(t)
+	})
+
+	t.Run("Link Expired", func(t *testing.T) {
+		clk.Advance(10*time.Minute - time.Second)
+		_, err := u.GetPaymentLinkBooking(context.Background(), token)
+		assert.NoError(t, err)
+
+		clk.Advance(time.Second)
+		_, err = u.GetPaymentLinkBooking(context.Background(), token)
+		assert.ErrorIs(t, err, entity.ErrPaymentLinkExpired)
+	})
+}
@@ -0,0 +1,187 @@
+package usecase_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"ticres/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pricingRuns is how many random carts each property is checked against.
+// The seed is fixed so a failure can be replayed; it is logged on failure.
+const (
+	pricingRuns = 2000
+	pricingSeed = 1799
+)
+
+// randomPrice is a DECIMAL(10,2) price, occasionally zero for free tickets.
+func randomPrice(r *rand.Rand) float64 {
+	if r.Intn(20) == 0 {
+		return 0
+	}
+	return float64(r.Int63n(50_000_000)) / 100
+}
+
+// randomMultiplier is a DECIMAL(6,4) variant multiplier within the 0.5-2.0
+// experiments allow: a discount, no change, or a surcharge.
+func randomMultiplier(r *rand.Rand) float64 {
+	switch r.Intn(4) {
+	case 0:
+		return 0 // no variant
+	case 1:
+		return 1
+	}
+	return float64(5000+r.Intn(15001)) / 10000
+}
+
+// randomCart picks 1-10 seats, each at one of up to 4 tier prices or its
+// own seat price.
+func randomCart(r *rand.Rand) []float64 {
+	tiers := make([]float64, r.Intn(5))
+	for i := range tiers {
+		tiers[i] = randomPrice(r)
+	}
+	prices := make([]float64, 1+r.Intn(10))
+	for i := range prices {
+		if len(tiers) > 0 && r.Intn(3) > 0 {
+			prices[i] = tiers[r.Intn(len(tiers))]
+		} else {
+			prices[i] = randomPrice(r)
+		}
+	}
+	return prices
+}
+
+func cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func isWholeCents(amount float64) bool {
+	return float64(cents(amount))/100 == amount
+}
+
+func TestPricing_QuoteTicketsProperties(t *testing.T) {
+	r := rand.New(rand.NewSource(pricingSeed))
+
+	for i := 0; i < pricingRuns; i++ {
+		prices := randomCart(r)
+		multiplier := randomMultiplier(r)
+		quote := entity.QuoteTickets(prices, multiplier)
+
+		var listCents int64
+		for _, p := range prices {
+			listCents += cents(p)
+		}
+		effective := multiplier
+		if effective == 0 {
+			effective = 1
+		}
+
+		ok := assert.Equal(t, listCents, cents(quote.Subtotal), "subtotal is the sum of the list prices") &&
+			assert.Equal(t, cents(quote.Total), cents(quote.Subtotal)+cents(quote.Adjustment), "total = subtotal + adjustment") &&
+			assert.True(t, isWholeCents(quote.Subtotal) && isWholeCents(quote.Adjustment) && isWholeCents(quote.Total), "amounts are whole cents") &&
+			assert.GreaterOrEqual(t, quote.Total, 0.0, "total is not negative") &&
+			assert.LessOrEqual(t, math.Abs(float64(cents(quote.Total))-float64(listCents)*effective), 0.5+1e-6, "total is rounded to the nearest cent")
+
+		switch {
+		case effective < 1:
+			ok = ok && assert.LessOrEqual(t, quote.Total, quote.Subtotal, "a discount never raises the price")
+		case effective > 1:
+			ok = ok && assert.GreaterOrEqual(t, quote.Total, quote.Subtotal, "a surcharge never lowers the price")
+		default:
+			ok = ok && assert.Equal(t, quote.Subtotal, quote.Total, "no variant keeps the list price")
+		}
+
+		// The order seats are added in must not change the total
+		shuffled := append([]float64(nil), prices...)
+		r.Shuffle(len(shuffled), func(a, b int) { shuffled[a], shuffled[b] = shuffled[b], shuffled[a] })
+		ok = ok && assert.Equal(t, quote, entity.QuoteTickets(shuffled, multiplier), "total does not depend on seat order")
+
+		if !ok {
+			t.Fatalf("run %d (seed %d): prices %v, multiplier %v, quote %+v", i, pricingSeed, prices, multiplier, quote)
+		}
+	}
+}
+
+func TestPricing_GeneralAdmissionMatchesSeatedPricing(t *testing.T) {
+	r := rand.New(rand.NewSource(pricingSeed))
+
+	for i := 0; i < pricingRuns; i++ {
+		price := randomPrice(r)
+		quantity := 1 + r.Intn(entity.MaxGeneralAdmissionQuantity)
+		multiplier := randomMultiplier(r)
+
+		prices := make([]float64, quantity)
+		for j := range prices {
+			prices[j] = price
+		}
+		require.Equal(t, entity.QuoteTickets(prices, multiplier), entity.QuoteGeneralAdmission(price, quantity, multiplier),
+			"run %d (seed %d): price %v x %d, multiplier %v", i, pricingSeed, price, quantity, multiplier)
+	}
+}
+
+// A booking is paid once at booking time and again for every seat upgrade,
+// and a refund returns the completed payments. Replaying random upgrade
+// chains checks the booking's amount stays exactly what was paid, so a
+// refund of it never returns more.
+func TestPricing_SeatChangesAndRefundProperties(t *testing.T) {
+	r := rand.New(rand.NewSource(pricingSeed))
+
+	for i := 0; i < pricingRuns; i++ {
+		multiplier := randomMultiplier(r)
+		seats := randomCart(r)
+		quote := entity.QuoteTickets(seats, multiplier)
+
+		payments := []float64{quote.Total}
+		amount := quote.Total
+		for changes := r.Intn(4); changes > 0; changes-- {
+			from := r.Intn(len(seats))
+			to := randomPrice(r)
+			charge := entity.SeatChangeCharge(seats[from], to, multiplier)
+
+			require.True(t, isWholeCents(charge), "run %d: charge %v is whole cents", i, charge)
+			if to < seats[from] {
+				require.LessOrEqual(t, charge, 0.0, "run %d: moving to a cheaper seat is never charged", i)
+				continue // rejected as a downgrade
+			}
+			require.GreaterOrEqual(t, charge, 0.0, "run %d: an upgrade is never a credit", i)
+
+			seats[from] = to
+			amount = entity.AddAmounts(amount, charge)
+			if charge > 0 {
+				payments = append(payments, charge)
+			}
+		}
+
+		paid := entity.AddAmounts(payments...)
+		require.True(t, isWholeCents(amount), "run %d: amount %v is whole cents", i, amount)
+		require.Equal(t, paid, amount, "run %d (seed %d): booking amount equals what was paid", i, pricingSeed)
+		require.GreaterOrEqual(t, amount, 0.0, "run %d: amount is not negative", i)
+	}
+}
+
+// Known cases where naive float math is a cent off.
+func TestPricing_RoundingEdgeCases(t *testing.T) {
+	tests := []struct {
+		name       string
+		prices     []float64
+		multiplier float64
+		wantTotal  float64
+	}{
+		{name: "Float Sum Drift", prices: []float64{0.1, 0.2}, multiplier: 0, wantTotal: 0.3},
+		{name: "Half Cent Rounds Up", prices: []float64{1.01}, multiplier: 0.5, wantTotal: 0.51},
+		{name: "Half Cent Below Float Representation", prices: []float64{1.005 * 2}, multiplier: 0.5, wantTotal: 1.01},
+		{name: "Many Small Prices", prices: []float64{0.07, 0.07, 0.07, 0.07, 0.07, 0.07, 0.07, 0.07, 0.07, 0.07}, multiplier: 1, wantTotal: 0.7},
+		{name: "Four Decimal Multiplier", prices: []float64{99999.99}, multiplier: 0.8333, wantTotal: 83329.99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantTotal, entity.QuoteTickets(tt.prices, tt.multiplier).Total)
+		})
+	}
+}
@@ -0,0 +1,455 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/clock"
+	"ticres/pkg/i18n"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// MaxRefundReportRange caps the period a refund report can cover.
+	MaxRefundReportRange = 366 * 24 * time.Hour
+	// DefaultRefundReportDays is the period covered when a report has no
+	// from date. Its report is the one recomputed in the background.
+	DefaultRefundReportDays = 30
+)
+
+type RefundUsecase interface {
+	ListReasons(ctx context.Context) ([]entity.RefundReason, error)
+	CreateReason(ctx context.Context, reason *entity.RefundReason) error
+	// UpdateReason changes a reason's label or deactivates it. Refunds
+	// already filed under it keep the reason.
+	UpdateReason(ctx context.Context, reason *entity.RefundReason) error
+	// RefundBooking refunds a PAID booking under an active reason and frees
+	// its seats. Refunds for a customer request follow the event's refund
+	// policy and fail with ErrRefundWindowClosed once it returns nothing;
+	// other reasons refund in full.
+	RefundBooking(ctx context.Context, bookingID int64, reason, note string) (*entity.Refund, error)
+	// PreviewRefund reports what RefundBooking would refund, without
+	// changing anything.
+	PreviewRefund(ctx context.Context, bookingID int64, reason, note string) (*entity.DryRunResult, error)
+	// GetRefundReport totals the refunds issued in [from, to) by reason.
+	// Reports are cached; refresh recomputes it even when a cached copy exists.
+	GetRefundReport(ctx context.Context, from, to time.Time, refresh bool) (*entity.RefundReport, error)
+	// RefreshReports recomputes the default refund report so dashboards
+	// opening it hit a warm cache.
+	RefreshReports(ctx context.Context) error
+
+	// RequestRefund files the user's request to refund their PAID booking,
+	// for the share the event's refund policy returns today. It fails with
+	// ErrRefundWindowClosed once the policy returns nothing.
+	RequestRefund(ctx context.Context, userID, bookingID int64, reason, note string) (*entity.Refund, error)
+	ListRefundRequests(ctx context.Context, status string) ([]entity.RefundRequestDetails, error)
+	// ApproveRefundRequest approves a request and queues it for the worker,
+	// which returns the money.
+	ApproveRefundRequest(ctx context.Context, refundID int64, note string) (*entity.RefundRequestDetails, error)
+	// RejectRefundRequest rejects a request with a note for the customer.
+	RejectRefundRequest(ctx context.Context, refundID int64, note string) (*entity.RefundRequestDetails, error)
+}
+
+// RefundRequestProcessor returns the money of approved refund requests in
+// the background and tells customers about rejected ones.
+type RefundRequestProcessor interface {
+	EnqueueRefundRequest(ctx context.Context, refundID int64)
+	SendNotification(ctx context.Context, bookingID int64, email string, message i18n.Message)
+}
+
+type refundUsecase struct {
+	refundRepo     repository.RefundRepository
+	bookingRepo    repository.BookingRepository
+	eventRepo      repository.EventRepository
+	reports        repository.ReportCache
+	reportTTL      time.Duration
+	processor      RefundRequestProcessor
+	auditor        AuditUsecase
+	clock          clock.Clock
+	contextTimeout time.Duration
+}
+
+func NewRefundUsecase(refundRepo repository.RefundRepository, bookingRepo repository.BookingRepository, eventRepo repository.EventRepository, reports repository.ReportCache, reportTTL time.Duration, processor RefundRequestProcessor, auditor AuditUsecase, clk clock.Clock, timeout time.Duration) RefundUsecase {
+	return &refundUsecase{
+		refundRepo:     refundRepo,
+		bookingRepo:    bookingRepo,
+		eventRepo:      eventRepo,
+		reports:        reports,
+		reportTTL:      reportTTL,
+		processor:      processor,
+		auditor:        auditor,
+		clock:          clk,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *refundUsecase) ListReasons(ctx context.Context) ([]entity.RefundReason, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.refundRepo.ListRefundReasons(ctx)
+}
+
+func (uc *refundUsecase) CreateReason(ctx context.Context, reason *entity.RefundReason) error {
+	if err := reason.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	reason.Active = true
+	if err := uc.refundRepo.CreateRefundReason(ctx, reason); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("usecase: refund reason created", logger.String("code", reason.Code))
+	return nil
+}
+
+func (uc *refundUsecase) UpdateReason(ctx context.Context, reason *entity.RefundReason) error {
+	if err := reason.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.refundRepo.UpdateRefundReason(ctx, reason); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("usecase: refund reason updated",
+		logger.String("code", reason.Code),
+		logger.Any("active", reason.Active),
+	)
+	return nil
+}
+
+func (uc *refundUsecase) RefundBooking(ctx context.Context, bookingID int64, reason, note string) (*entity.Refund, error) {
+	ctx, span := tracing.Start(ctx, "RefundUsecase.RefundBooking",
+		attribute.Int64("booking_id", bookingID),
+		attribute.String("reason", reason),
+	)
+	defer span.End()
+
+	note, err := entity.ValidateRefundNote(note)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.checkReason(ctx, reason); err != nil {
+		return nil, err
+	}
+	percent, err := uc.reasonPercent(ctx, bookingID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := &entity.Refund{BookingID: bookingID, Reason: reason, Note: note, Percent: percent}
+	if err := uc.refundRepo.RefundBooking(ctx, refund); err != nil {
+		return nil, err
+	}
+
+	// The refund is already recorded; a failed release leaves seats the
+	// inventory monitor reports as leaked rather than failing the refund.
+	if err := uc.bookingRepo.ReleaseSeatsByBookingID(ctx, bookingID); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to release refunded seats", logger.Int64("booking_id", bookingID), logger.Err(err))
+	}
+
+	uc.auditor.Record(ctx, ActionRefundCreate, "booking", bookingID, map[string]interface{}{
+		"refund_id": refund.ID,
+		"amount":    refund.Amount,
+		"percent":   refund.Percent,
+		"reason":    refund.Reason,
+	})
+
+	logger.FromContext(ctx).Info("usecase: booking refunded",
+		logger.Int64("booking_id", bookingID),
+		logger.Float64("amount", refund.Amount),
+		logger.String("reason", reason),
+	)
+	return refund, nil
+}
+
+func (uc *refundUsecase) PreviewRefund(ctx context.Context, bookingID int64, reason, note string) (*entity.DryRunResult, error) {
+	ctx, span := tracing.Start(ctx, "RefundUsecase.PreviewRefund",
+		attribute.Int64("booking_id", bookingID),
+		attribute.String("reason", reason),
+	)
+	defer span.End()
+
+	if _, err := entity.ValidateRefundNote(note); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.checkReason(ctx, reason); err != nil {
+		return nil, err
+	}
+
+	booking, err := uc.bookingRepo.GetAffectedBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status != entity.BookingPaid {
+		return nil, entity.ErrBookingNotPaid
+	}
+	percent, err := uc.reasonPercent(ctx, bookingID, reason)
+	if err != nil {
+		return nil, err
+	}
+	if percent < 100 {
+		amount := entity.RefundShare(booking.PaidAmount, percent)
+		booking.RefundAmount = &amount
+	}
+	booking.Action = entity.DryRunRefund
+
+	return entity.NewDryRunResult([]entity.AffectedBooking{*booking}), nil
+}
+
+func (uc *refundUsecase) GetRefundReport(ctx context.Context, from, to time.Time, refresh bool) (*entity.RefundReport, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: to must be after from", entity.ErrInvalidReportRange)
+	}
+	if to.Sub(from) > MaxRefundReportRange {
+		return nil, fmt.Errorf("%w: range is limited to %d days", entity.ErrInvalidReportRange, int(MaxRefundReportRange.Hours()/24))
+	}
+
+	if !refresh {
+		if report, ok := uc.reports.GetRefundReport(ctx, from, to); ok {
+			return report, nil
+		}
+	}
+	return uc.buildRefundReport(ctx, from, to)
+}
+
+func (uc *refundUsecase) RefreshReports(ctx context.Context) error {
+	to := uc.clock.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	_, err := uc.buildRefundReport(ctx, to.AddDate(0, 0, -DefaultRefundReportDays), to)
+	return err
+}
+
+// buildRefundReport runs the refund aggregate and caches the result.
+func (uc *refundUsecase) buildRefundReport(ctx context.Context, from, to time.Time) (*entity.RefundReport, error) {
+	ctx, span := tracing.Start(ctx, "RefundUsecase.GetRefundReport")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	summary, err := uc.refundRepo.GetRefundSummary(ctx, from, to)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to build refund report", logger.Err(err))
+		return nil, err
+	}
+
+	report := &entity.RefundReport{From: from, To: to, ByReason: summary, GeneratedAt: uc.clock.Now().UTC()}
+	if report.ByReason == nil {
+		report.ByReason = []entity.RefundReasonSummary{}
+	}
+	for _, s := range summary {
+		report.TotalCount += s.Count
+		report.TotalAmount += s.Amount
+	}
+
+	uc.reports.SetRefundReport(ctx, report, uc.reportTTL)
+	return report, nil
+}
+
+// reasonPercent returns the share of what was paid a refund for reason
+// returns. Only customer requests are bound by the event's refund policy;
+// support refunds a cancellation, duplicate charge or fraud in full.
+func (uc *refundUsecase) reasonPercent(ctx context.Context, bookingID int64, reason string) (int, error) {
+	if reason != entity.RefundReasonCustomerRequest {
+		return 100, nil
+	}
+	booking, err := uc.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return 0, entity.ErrNotFound
+	}
+	return uc.policyPercent(ctx, booking.EventID)
+}
+
+// policyPercent returns the share of what was paid the event's refund
+// policy returns today, or ErrRefundWindowClosed when it returns nothing.
+func (uc *refundUsecase) policyPercent(ctx context.Context, eventID int64) (int, error) {
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to fetch event refund policy", logger.Int64("event_id", eventID), logger.Err(err))
+		return 0, err
+	}
+	percent := event.RefundPolicy.RefundPercent(event.Date, uc.clock.Now())
+	if percent == 0 {
+		return 0, entity.ErrRefundWindowClosed
+	}
+	return percent, nil
+}
+
+// checkReason accepts only active reasons of the taxonomy.
+func (uc *refundUsecase) checkReason(ctx context.Context, reason string) error {
+	r, err := uc.refundRepo.GetRefundReason(ctx, reason)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return fmt.Errorf("%w: unknown reason %q", entity.ErrInvalidRefundReason, reason)
+		}
+		return err
+	}
+	if !r.Active {
+		return fmt.Errorf("%w: reason %q is no longer in use", entity.ErrInvalidRefundReason, reason)
+	}
+	return nil
+}
+
+func (uc *refundUsecase) RequestRefund(ctx context.Context, userID, bookingID int64, reason, note string) (*entity.Refund, error) {
+	ctx, span := tracing.Start(ctx, "RefundUsecase.RequestRefund",
+		attribute.Int64("booking_id", bookingID),
+		attribute.String("reason", reason),
+	)
+	defer span.End()
+
+	// Cancellation refunds are issued by the system, not requested
+	if reason == entity.RefundReasonEventCancelled {
+		return nil, fmt.Errorf("%w: reason %q cannot be requested", entity.ErrInvalidRefundReason, reason)
+	}
+	note, err := entity.ValidateRefundNote(note)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.checkReason(ctx, reason); err != nil {
+		return nil, err
+	}
+
+	booking, err := uc.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, entity.ErrNotFound
+	}
+	if booking.UserID != userID {
+		return nil, entity.ErrUnauthorized
+	}
+	if booking.Status != entity.BookingPaid {
+		return nil, entity.ErrBookingNotPaid
+	}
+	percent, err := uc.policyPercent(ctx, booking.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := &entity.Refund{BookingID: bookingID, Reason: reason, Note: note, Percent: percent}
+	if err := uc.refundRepo.CreateRefundRequest(ctx, refund); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("usecase: refund requested",
+		logger.Int64("refund_id", refund.ID),
+		logger.Int64("booking_id", bookingID),
+		logger.Int64("user_id", userID),
+		logger.Int("percent", percent),
+	)
+	return refund, nil
+}
+
+func (uc *refundUsecase) ListRefundRequests(ctx context.Context, status string) ([]entity.RefundRequestDetails, error) {
+	if status != "" && !entity.IsRefundStatus(status) {
+		return nil, fmt.Errorf("%w: %q", entity.ErrInvalidRefundStatus, status)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.refundRepo.ListRefundRequests(ctx, status)
+}
+
+func (uc *refundUsecase) ApproveRefundRequest(ctx context.Context, refundID int64, note string) (*entity.RefundRequestDetails, error) {
+	ctx, span := tracing.Start(ctx, "RefundUsecase.ApproveRefundRequest", attribute.Int64("refund_id", refundID))
+	defer span.End()
+
+	request, err := uc.review(ctx, refundID, entity.RefundApproved, note)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.processor.EnqueueRefundRequest(ctx, refundID)
+	return request, nil
+}
+
+func (uc *refundUsecase) RejectRefundRequest(ctx context.Context, refundID int64, note string) (*entity.RefundRequestDetails, error) {
+	ctx, span := tracing.Start(ctx, "RefundUsecase.RejectRefundRequest", attribute.Int64("refund_id", refundID))
+	defer span.End()
+
+	if strings.TrimSpace(note) == "" {
+		return nil, fmt.Errorf("%w: a note for the customer is required when rejecting", entity.ErrInvalidRefundReason)
+	}
+
+	request, err := uc.review(ctx, refundID, entity.RefundRejected, note)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.processor.SendNotification(ctx, request.BookingID, request.UserEmail,
+		i18n.Msg("Your refund request for booking #%s was rejected: %s", request.BookingID, request.ReviewNote))
+	return request, nil
+}
+
+// review moves a request to status to on behalf of the acting admin.
+func (uc *refundUsecase) review(ctx context.Context, refundID int64, to, note string) (*entity.RefundRequestDetails, error) {
+	note, err := entity.ValidateRefundNote(note)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	request, err := uc.refundRepo.GetRefundRequest(ctx, refundID)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.CanRefundTransition(request.Status, to) {
+		return nil, fmt.Errorf("%w: request is %s", entity.ErrRefundNotReviewable, request.Status)
+	}
+
+	reviewer := ActorFromContext(ctx)
+	if err := uc.refundRepo.ReviewRefundRequest(ctx, refundID, request.Status, to, reviewer, note); err != nil {
+		return nil, err
+	}
+
+	now := uc.clock.Now()
+	request.Status = to
+	request.ReviewedBy = reviewer
+	request.ReviewedAt = &now
+	request.ReviewNote = note
+
+	action := ActionRefundRequestApprove
+	if to == entity.RefundRejected {
+		action = ActionRefundRequestReject
+	}
+	uc.auditor.Record(ctx, action, "booking", request.BookingID, map[string]interface{}{
+		"refund_id": refundID,
+		"amount":    request.Amount,
+		"reason":    request.Reason,
+	})
+
+	logger.FromContext(ctx).Info("usecase: refund request reviewed",
+		logger.Int64("refund_id", refundID),
+		logger.String("status", to),
+	)
+	return request, nil
+}
@@ -0,0 +1,690 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/clock"
+	"ticres/pkg/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// openEventRepo serves event 3, a month away and without a refund policy.
+func openEventRepo() *mocks.MockEventRepo {
+	mockEventRepo := new(mocks.MockEventRepo)
+	mockEventRepo.On("GetEventByID", mock.Anything, int64(3)).Return(&entity.Event{ID: 3, Date: time.Now().AddDate(0, 1, 0)}, nil).Maybe()
+	return mockEventRepo
+}
+
+func TestRefundUsecase_RefundBooking(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  string
+		note    string
+		mock    func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase)
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			reason: entity.RefundReasonDuplicateCharge,
+			note:   "  Charged twice by the gateway  ",
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRefundRepo.On("GetRefundReason", mock.Anything, entity.RefundReasonDuplicateCharge).
+					Return(&entity.RefundReason{Code: entity.RefundReasonDuplicateCharge, Active: true}, nil).Once()
+				mockRefundRepo.On("RefundBooking", mock.Anything, mock.MatchedBy(func(r *entity.Refund) bool {
+					return r.BookingID == 5 && r.Reason == entity.RefundReasonDuplicateCharge && r.Note == "Charged twice by the gateway"
+				})).Run(func(args mock.Arguments) {
+					r := args.Get(1).(*entity.Refund)
+					r.ID = 11
+					r.Amount = 150000
+					r.Status = "COMPLETED"
+				}).Return(nil).Once()
+				mockBookingRepo.On("ReleaseSeatsByBookingID", mock.Anything, int64(5)).Return(nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionRefundCreate, "booking", int64(5), mock.Anything).Once()
+			},
+		},
+		{
+			name:   "Success - Seat Release Failure Keeps Refund",
+			reason: entity.RefundReasonCustomerRequest,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRefundRepo.On("GetRefundReason", mock.Anything, entity.RefundReasonCustomerRequest).
+					Return(&entity.RefundReason{Code: entity.RefundReasonCustomerRequest, Active: true}, nil).Once()
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(&entity.Booking{ID: 5, EventID: 3, Status: "PAID"}, nil).Once()
+				mockRefundRepo.On("RefundBooking", mock.Anything, mock.Anything).Return(nil).Once()
+				mockBookingRepo.On("ReleaseSeatsByBookingID", mock.Anything, int64(5)).Return(errors.New("db error")).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionRefundCreate, "booking", int64(5), mock.Anything).Once()
+			},
+		},
+		{
+			name:   "Failed - Unknown Reason",
+			reason: "changed_mind",
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRefundRepo.On("GetRefundReason", mock.Anything, "changed_mind").Return(nil, entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrInvalidRefundReason,
+		},
+		{
+			name:   "Failed - Reason Deactivated",
+			reason: entity.RefundReasonFraud,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRefundRepo.On("GetRefundReason", mock.Anything, entity.RefundReasonFraud).
+					Return(&entity.RefundReason{Code: entity.RefundReasonFraud, Active: false}, nil).Once()
+			},
+			wantErr: entity.ErrInvalidRefundReason,
+		},
+		{
+			name:   "Failed - Note Too Long",
+			reason: entity.RefundReasonCustomerRequest,
+			note:   strings.Repeat("x", 256),
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+			},
+			wantErr: entity.ErrInvalidRefundReason,
+		},
+		{
+			name:   "Failed - Booking Not Paid",
+			reason: entity.RefundReasonCustomerRequest,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo, mockAudit *mocks.MockAuditUsecase) {
+				mockRefundRepo.On("GetRefundReason", mock.Anything, entity.RefundReasonCustomerRequest).
+					Return(&entity.RefundReason{Code: entity.RefundReasonCustomerRequest, Active: true}, nil).Once()
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(&entity.Booking{ID: 5, EventID: 3, Status: "PAID"}, nil).Once()
+				mockRefundRepo.On("RefundBooking", mock.Anything, mock.Anything).Return(entity.ErrBookingNotPaid).Once()
+			},
+			wantErr: entity.ErrBookingNotPaid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRefundRepo := new(mocks.MockRefundRepo)
+			mockBookingRepo := new(mocks.MockBookingRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			tt.mock(mockRefundRepo, mockBookingRepo, mockAudit)
+
+			u := usecase.NewRefundUsecase(mockRefundRepo, mockBookingRepo, openEventRepo(), new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), mockAudit, clock.Real{}, time.Second*2)
+			refund, err := u.RefundBooking(context.Background(), 5, tt.reason, tt.note)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, refund)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(5), refund.BookingID)
+				assert.Equal(t, tt.reason, refund.Reason)
+			}
+			mockRefundRepo.AssertExpectations(t)
+			mockBookingRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRefundUsecase_PreviewRefund(t *testing.T) {
+	activeReason := func(mockRefundRepo *mocks.MockRefundRepo) {
+		mockRefundRepo.On("GetRefundReason", mock.Anything, entity.RefundReasonCustomerRequest).
+			Return(&entity.RefundReason{Code: entity.RefundReasonCustomerRequest, Active: true}, nil).Once()
+	}
+
+	tests := []struct {
+		name    string
+		reason  string
+		mock    func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo)
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			reason: entity.RefundReasonCustomerRequest,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo) {
+				activeReason(mockRefundRepo)
+				mockBookingRepo.On("GetAffectedBooking", mock.Anything, int64(5)).
+					Return(&entity.AffectedBooking{BookingID: 5, Status: "PAID", Tickets: 2, PaidAmount: 150000}, nil).Once()
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(&entity.Booking{ID: 5, EventID: 3, Status: "PAID"}, nil).Once()
+			},
+		},
+		{
+			name:   "Failed - Unknown Reason",
+			reason: "changed_mind",
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo) {
+				mockRefundRepo.On("GetRefundReason", mock.Anything, "changed_mind").Return(nil, entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrInvalidRefundReason,
+		},
+		{
+			name:   "Failed - Booking Not Found",
+			reason: entity.RefundReasonCustomerRequest,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo) {
+				activeReason(mockRefundRepo)
+				mockBookingRepo.On("GetAffectedBooking", mock.Anything, int64(5)).Return(nil, entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name:   "Failed - Booking Not Paid",
+			reason: entity.RefundReasonCustomerRequest,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo) {
+				activeReason(mockRefundRepo)
+				mockBookingRepo.On("GetAffectedBooking", mock.Anything, int64(5)).
+					Return(&entity.AffectedBooking{BookingID: 5, Status: "PENDING", Tickets: 2}, nil).Once()
+			},
+			wantErr: entity.ErrBookingNotPaid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRefundRepo := new(mocks.MockRefundRepo)
+			mockBookingRepo := new(mocks.MockBookingRepo)
+			tt.mock(mockRefundRepo, mockBookingRepo)
+
+			u := usecase.NewRefundUsecase(mockRefundRepo, mockBookingRepo, openEventRepo(), new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+			result, err := u.PreviewRefund(context.Background(), 5, tt.reason, "")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, result.DryRun)
+				assert.Equal(t, 1, result.Refunded)
+				assert.Equal(t, 150000.0, result.RefundAmount)
+				assert.Equal(t, 2, result.TicketsReleased)
+				assert.Equal(t, entity.DryRunRefund, result.Bookings[0].Action)
+			}
+			mockRefundRepo.AssertExpectations(t)
+			mockBookingRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRefundUsecase_CreateReason(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  entity.RefundReason
+		mock    func(mockRefundRepo *mocks.MockRefundRepo)
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			reason: entity.RefundReason{Code: "chargeback", Label: " Chargeback "},
+			mock: func(mockRefundRepo *mocks.MockRefundRepo) {
+				mockRefundRepo.On("CreateRefundReason", mock.Anything, mock.MatchedBy(func(r *entity.RefundReason) bool {
+					return r.Code == "chargeback" && r.Label == "Chargeback" && r.Active
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Failed - Invalid Code",
+			reason:  entity.RefundReason{Code: "Charge Back", Label: "Chargeback"},
+			mock:    func(mockRefundRepo *mocks.MockRefundRepo) {},
+			wantErr: entity.ErrInvalidRefundReason,
+		},
+		{
+			name:    "Failed - Missing Label",
+			reason:  entity.RefundReason{Code: "chargeback", Label: "  "},
+			mock:    func(mockRefundRepo *mocks.MockRefundRepo) {},
+			wantErr: entity.ErrInvalidRefundReason,
+		},
+		{
+			name:   "Failed - Code Exists",
+			reason: entity.RefundReason{Code: entity.RefundReasonFraud, Label: "Fraud"},
+			mock: func(mockRefundRepo *mocks.MockRefundRepo) {
+				mockRefundRepo.On("CreateRefundReason", mock.Anything, mock.Anything).Return(entity.ErrRefundReasonExists).Once()
+			},
+			wantErr: entity.ErrRefundReasonExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRefundRepo := new(mocks.MockRefundRepo)
+			tt.mock(mockRefundRepo)
+
+			u := usecase.NewRefundUsecase(mockRefundRepo, new(mocks.MockBookingRepo), new(mocks.MockEventRepo), new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+			reason := tt.reason
+			err := u.CreateReason(context.Background(), &reason)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRefundRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRefundUsecase_GetRefundReport(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	cached := &entity.RefundReport{From: from, To: to, TotalCount: 7, TotalAmount: 700000, ByReason: []entity.RefundReasonSummary{
+		{Reason: entity.RefundReasonCustomerRequest, Count: 7, Amount: 700000},
+	}}
+
+	tests := []struct {
+		name        string
+		from, to    time.Time
+		refresh     bool
+		mock        func(mockRefundRepo *mocks.MockRefundRepo, mockCache *mocks.MockReportCache)
+		wantCount   int
+		wantAmount  float64
+		wantReasons int
+		wantErr     error
+	}{
+		{
+			name: "Success",
+			from: from,
+			to:   to,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockCache *mocks.MockReportCache) {
+				mockCache.On("GetRefundReport", mock.Anything, from, to).Return(nil, false).Once()
+				mockRefundRepo.On("GetRefundSummary", mock.Anything, from, to).Return([]entity.RefundReasonSummary{
+					{Reason: entity.RefundReasonEventCancelled, Count: 40, Amount: 6000000},
+					{Reason: entity.RefundReasonCustomerRequest, Count: 3, Amount: 450000},
+					{Reason: entity.RefundReasonFraud},
+				}, nil).Once()
+				mockCache.On("SetRefundReport", mock.Anything, mock.MatchedBy(func(r *entity.RefundReport) bool {
+					return r.TotalCount == 43 && !r.GeneratedAt.IsZero()
+				}), time.Minute).Once()
+			},
+			wantCount:   43,
+			wantAmount:  6450000,
+			wantReasons: 3,
+		},
+		{
+			name: "Success - No Reasons",
+			from: from,
+			to:   to,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockCache *mocks.MockReportCache) {
+				mockCache.On("GetRefundReport", mock.Anything, from, to).Return(nil, false).Once()
+				mockRefundRepo.On("GetRefundSummary", mock.Anything, from, to).Return(nil, nil).Once()
+				mockCache.On("SetRefundReport", mock.Anything, mock.Anything, time.Minute).Once()
+			},
+		},
+		{
+			name: "Success - Served From Cache",
+			from: from,
+			to:   to,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockCache *mocks.MockReportCache) {
+				mockCache.On("GetRefundReport", mock.Anything, from, to).Return(cached, true).Once()
+			},
+			wantCount:   7,
+			wantAmount:  700000,
+			wantReasons: 1,
+		},
+		{
+			name:    "Success - Refresh Skips Cache",
+			from:    from,
+			to:      to,
+			refresh: true,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockCache *mocks.MockReportCache) {
+				mockRefundRepo.On("GetRefundSummary", mock.Anything, from, to).Return([]entity.RefundReasonSummary{
+					{Reason: entity.RefundReasonCustomerRequest, Count: 8, Amount: 800000},
+				}, nil).Once()
+				mockCache.On("SetRefundReport", mock.Anything, mock.Anything, time.Minute).Once()
+			},
+			wantCount:   8,
+			wantAmount:  800000,
+			wantReasons: 1,
+		},
+		{
+			name: "Failed - Query Error Is Not Cached",
+			from: from,
+			to:   to,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockCache *mocks.MockReportCache) {
+				mockCache.On("GetRefundReport", mock.Anything, from, to).Return(nil, false).Once()
+				mockRefundRepo.On("GetRefundSummary", mock.Anything, from, to).Return(nil, errors.New("db error")).Once()
+			},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name:    "Failed - Range Reversed",
+			from:    to,
+			to:      from,
+			mock:    func(mockRefundRepo *mocks.MockRefundRepo, mockCache *mocks.MockReportCache) {},
+			wantErr: entity.ErrInvalidReportRange,
+		},
+		{
+			name:    "Failed - Range Too Long",
+			from:    from,
+			to:      from.Add(usecase.MaxRefundReportRange + time.Hour),
+			mock:    func(mockRefundRepo *mocks.MockRefundRepo, mockCache *mocks.MockReportCache) {},
+			wantErr: entity.ErrInvalidReportRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRefundRepo := new(mocks.MockRefundRepo)
+			mockCache := new(mocks.MockReportCache)
+			tt.mock(mockRefundRepo, mockCache)
+
+			u := usecase.NewRefundUsecase(mockRefundRepo, new(mocks.MockBookingRepo), new(mocks.MockEventRepo), mockCache, time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+			report, err := u.GetRefundReport(context.Background(), tt.from, tt.to, tt.refresh)
+
+			if tt.wantErr != nil {
+				if errors.Is(tt.wantErr, entity.ErrInvalidReportRange) {
+					assert.ErrorIs(t, err, tt.wantErr)
+				} else {
+					assert.EqualError(t, err, tt.wantErr.Error())
+				}
+				assert.Nil(t, report)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCount, report.TotalCount)
+				assert.Equal(t, tt.wantAmount, report.TotalAmount)
+				assert.Len(t, report.ByReason, tt.wantReasons)
+			}
+			mockRefundRepo.AssertExpectations(t)
+			mockCache.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRefundUsecase_RefreshReports(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepo)
+	mockCache := new(mocks.MockReportCache)
+
+	mockRefundRepo.On("GetRefundSummary", mock.Anything, mock.Anything, mock.Anything).Return([]entity.RefundReasonSummary{}, nil).Once()
+	mockCache.On("SetRefundReport", mock.Anything, mock.MatchedBy(func(r *entity.RefundReport) bool {
+		return r.To.Sub(r.From) == usecase.DefaultRefundReportDays*24*time.Hour && r.To.After(time.Now())
+	}), 5*time.Minute).Once()
+
+	u := usecase.NewRefundUsecase(mockRefundRepo, new(mocks.MockBookingRepo), new(mocks.MockEventRepo), mockCache, 5*time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+	assert.NoError(t, u.RefreshReports(context.Background()))
+
+	mockRefundRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestRefundUsecase_RequestRefund(t *testing.T) {
+	paid := &entity.Booking{ID: 5, UserID: 9, EventID: 3, Status: "PAID"}
+	activeReason := func(mockRefundRepo *mocks.MockRefundRepo) {
+		mockRefundRepo.On("GetRefundReason", mock.Anything, entity.RefundReasonCustomerRequest).
+			Return(&entity.RefundReason{Code: entity.RefundReasonCustomerRequest, Active: true}, nil).Once()
+	}
+
+	tests := []struct {
+		name    string
+		reason  string
+		mock    func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo)
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			reason: entity.RefundReasonCustomerRequest,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo) {
+				activeReason(mockRefundRepo)
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(paid, nil).Once()
+				mockRefundRepo.On("CreateRefundRequest", mock.Anything, mock.MatchedBy(func(r *entity.Refund) bool {
+					return r.BookingID == 5 && r.Reason == entity.RefundReasonCustomerRequest && r.Note == "Sakit"
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*entity.Refund).Status = entity.RefundRequested
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Failed - Event Cancelled Reason",
+			reason:  entity.RefundReasonEventCancelled,
+			mock:    func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo) {},
+			wantErr: entity.ErrInvalidRefundReason,
+		},
+		{
+			name:   "Failed - Someone Else's Booking",
+			reason: entity.RefundReasonCustomerRequest,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo) {
+				activeReason(mockRefundRepo)
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(&entity.Booking{ID: 5, UserID: 2, Status: "PAID"}, nil).Once()
+			},
+			wantErr: entity.ErrUnauthorized,
+		},
+		{
+			name:   "Failed - Booking Not Paid",
+			reason: entity.RefundReasonCustomerRequest,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo) {
+				activeReason(mockRefundRepo)
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(&entity.Booking{ID: 5, UserID: 9, Status: "PENDING"}, nil).Once()
+			},
+			wantErr: entity.ErrBookingNotPaid,
+		},
+		{
+			name:   "Failed - Open Request Exists",
+			reason: entity.RefundReasonCustomerRequest,
+			mock: func(mockRefundRepo *mocks.MockRefundRepo, mockBookingRepo *mocks.MockBookingRepo) {
+				activeReason(mockRefundRepo)
+				mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(paid, nil).Once()
+				mockRefundRepo.On("CreateRefundRequest", mock.Anything, mock.Anything).Return(entity.ErrRefundRequestExists).Once()
+			},
+			wantErr: entity.ErrRefundRequestExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRefundRepo := new(mocks.MockRefundRepo)
+			mockBookingRepo := new(mocks.MockBookingRepo)
+			tt.mock(mockRefundRepo, mockBookingRepo)
+
+			u := usecase.NewRefundUsecase(mockRefundRepo, mockBookingRepo, openEventRepo(), new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+			refund, err := u.RequestRefund(context.Background(), 9, 5, tt.reason, " Sakit ")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, refund)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.RefundRequested, refund.Status)
+			}
+			mockRefundRepo.AssertExpectations(t)
+			mockBookingRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRefundUsecase_RefundPolicy(t *testing.T) {
+	policy := &entity.RefundPolicy{FullRefundDays: 14, PartialRefundDays: 7, PartialRefundPercent: 50}
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name        string
+		policy      *entity.RefundPolicy
+		eventIn     time.Duration
+		wantPercent int
+		wantErr     error
+	}{
+		{name: "No Policy", eventIn: 24 * time.Hour, wantPercent: 100},
+		{name: "Full Refund Tier", policy: policy, eventIn: 30 * 24 * time.Hour, wantPercent: 100},
+		{name: "Full Refund Tier Boundary", policy: policy, eventIn: 14 * 24 * time.Hour, wantPercent: 100},
+		{name: "Partial Refund Tier", policy: policy, eventIn: 10 * 24 * time.Hour, wantPercent: 50},
+		{name: "Partial Refund Tier Just Past Full", policy: policy, eventIn: 14*24*time.Hour - time.Second, wantPercent: 50},
+		{name: "Partial Refund Tier Boundary", policy: policy, eventIn: 7 * 24 * time.Hour, wantPercent: 50},
+		{name: "Window Closed", policy: policy, eventIn: 3 * 24 * time.Hour, wantErr: entity.ErrRefundWindowClosed},
+		{name: "Window Closed Just Past Partial", policy: policy, eventIn: 7*24*time.Hour - time.Second, wantErr: entity.ErrRefundWindowClosed},
+		{name: "Event Started", policy: &entity.RefundPolicy{}, eventIn: -time.Hour, wantErr: entity.ErrRefundWindowClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRefundRepo := new(mocks.MockRefundRepo)
+			mockBookingRepo := new(mocks.MockBookingRepo)
+			mockEventRepo := new(mocks.MockEventRepo)
+
+			mockRefundRepo.On("GetRefundReason", mock.Anything, entity.RefundReasonCustomerRequest).
+				Return(&entity.RefundReason{Code: entity.RefundReasonCustomerRequest, Active: true}, nil).Once()
+			mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).
+				Return(&entity.Booking{ID: 5, UserID: 9, EventID: 3, Status: "PAID"}, nil).Once()
+			mockEventRepo.On("GetEventByID", mock.Anything, int64(3)).
+				Return(&entity.Event{ID: 3, Date: clk.Now().Add(tt.eventIn), RefundPolicy: tt.policy}, nil).Once()
+			if tt.wantErr == nil {
+				mockRefundRepo.On("CreateRefundRequest", mock.Anything, mock.MatchedBy(func(r *entity.Refund) bool {
+					return r.Percent == tt.wantPercent
+				})).Return(nil).Once()
+			}
+
+			u := usecase.NewRefundUsecase(mockRefundRepo, mockBookingRepo, mockEventRepo, new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clk, time.Second*2)
+			refund, err := u.RequestRefund(context.Background(), 9, 5, entity.RefundReasonCustomerRequest, "")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, refund)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPercent, refund.Percent)
+			}
+			mockRefundRepo.AssertExpectations(t)
+			mockBookingRepo.AssertExpectations(t)
+			mockEventRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRefundUsecase_RefundBooking_RefundPolicy(t *testing.T) {
+	closed := &entity.Event{
+		ID:           3,
+		Date:         time.Now().Add(48 * time.Hour),
+		RefundPolicy: &entity.RefundPolicy{FullRefundDays: 14, PartialRefundDays: 7, PartialRefundPercent: 50},
+	}
+
+	t.Run("Customer Request Follows Policy", func(t *testing.T) {
+		mockRefundRepo := new(mocks.MockRefundRepo)
+		mockBookingRepo := new(mocks.MockBookingRepo)
+		mockEventRepo := new(mocks.MockEventRepo)
+		mockRefundRepo.On("GetRefundReason", mock.Anything, entity.RefundReasonCustomerRequest).
+			Return(&entity.RefundReason{Code: entity.RefundReasonCustomerRequest, Active: true}, nil).Once()
+		mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(&entity.Booking{ID: 5, EventID: 3, Status: "PAID"}, nil).Once()
+		mockEventRepo.On("GetEventByID", mock.Anything, int64(3)).Return(closed, nil).Once()
+
+		u := usecase.NewRefundUsecase(mockRefundRepo, mockBookingRepo, mockEventRepo, new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+		refund, err := u.RefundBooking(context.Background(), 5, entity.RefundReasonCustomerRequest, "")
+
+		assert.ErrorIs(t, err, entity.ErrRefundWindowClosed)
+		assert.Nil(t, refund)
+		mockRefundRepo.AssertNotCalled(t, "RefundBooking", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Other Reasons Refund In Full", func(t *testing.T) {
+		mockRefundRepo := new(mocks.MockRefundRepo)
+		mockBookingRepo := new(mocks.MockBookingRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+		mockRefundRepo.On("GetRefundReason", mock.Anything, entity.RefundReasonDuplicateCharge).
+			Return(&entity.RefundReason{Code: entity.RefundReasonDuplicateCharge, Active: true}, nil).Once()
+		mockRefundRepo.On("RefundBooking", mock.Anything, mock.MatchedBy(func(r *entity.Refund) bool {
+			return r.Percent == 100
+		})).Return(nil).Once()
+		mockBookingRepo.On("ReleaseSeatsByBookingID", mock.Anything, int64(5)).Return(nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionRefundCreate, "booking", int64(5), mock.Anything).Once()
+
+		u := usecase.NewRefundUsecase(mockRefundRepo, mockBookingRepo, new(mocks.MockEventRepo), new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), mockAudit, clock.Real{}, time.Second*2)
+		_, err := u.RefundBooking(context.Background(), 5, entity.RefundReasonDuplicateCharge, "")
+
+		assert.NoError(t, err)
+		mockRefundRepo.AssertExpectations(t)
+		mockBookingRepo.AssertNotCalled(t, "GetBookingByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Preview Shows Partial Amount", func(t *testing.T) {
+		partial := *closed
+		partial.Date = time.Now().Add(10 * 24 * time.Hour)
+		mockRefundRepo := new(mocks.MockRefundRepo)
+		mockBookingRepo := new(mocks.MockBookingRepo)
+		mockEventRepo := new(mocks.MockEventRepo)
+		mockRefundRepo.On("GetRefundReason", mock.Anything, entity.RefundReasonCustomerRequest).
+			Return(&entity.RefundReason{Code: entity.RefundReasonCustomerRequest, Active: true}, nil).Once()
+		mockBookingRepo.On("GetAffectedBooking", mock.Anything, int64(5)).
+			Return(&entity.AffectedBooking{BookingID: 5, Status: "PAID", Tickets: 2, PaidAmount: 150000.5}, nil).Once()
+		mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(&entity.Booking{ID: 5, EventID: 3, Status: "PAID"}, nil).Once()
+		mockEventRepo.On("GetEventByID", mock.Anything, int64(3)).Return(&partial, nil).Once()
+
+		u := usecase.NewRefundUsecase(mockRefundRepo, mockBookingRepo, mockEventRepo, new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+		result, err := u.PreviewRefund(context.Background(), 5, entity.RefundReasonCustomerRequest, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 75000.25, result.RefundAmount)
+		if assert.NotNil(t, result.Bookings[0].RefundAmount) {
+			assert.Equal(t, 75000.25, *result.Bookings[0].RefundAmount)
+		}
+	})
+}
+
+func TestRefundUsecase_ReviewRefundRequest(t *testing.T) {
+	request := func(status string) *entity.RefundRequestDetails {
+		return &entity.RefundRequestDetails{
+			Refund:    entity.Refund{ID: 3, BookingID: 5, Amount: 150000, Reason: entity.RefundReasonCustomerRequest, Status: status},
+			UserEmail: "budi@test.com",
+		}
+	}
+	ctx := usecase.WithActor(context.Background(), 1)
+	admin := int64(1)
+
+	t.Run("Approve Queues Processing", func(t *testing.T) {
+		mockRefundRepo := new(mocks.MockRefundRepo)
+		mockProcessor := new(mocks.MockNotificationService)
+		mockAudit := new(mocks.MockAuditUsecase)
+		mockRefundRepo.On("GetRefundRequest", mock.Anything, int64(3)).Return(request(entity.RefundRequested), nil).Once()
+		mockRefundRepo.On("ReviewRefundRequest", mock.Anything, int64(3), entity.RefundRequested, entity.RefundApproved, &admin, "").Return(nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionRefundRequestApprove, "booking", int64(5), mock.Anything).Once()
+		mockProcessor.On("EnqueueRefundRequest", mock.Anything, int64(3)).Once()
+
+		u := usecase.NewRefundUsecase(mockRefundRepo, new(mocks.MockBookingRepo), new(mocks.MockEventRepo), new(mocks.MockReportCache), time.Minute, mockProcessor, mockAudit, clock.Real{}, time.Second*2)
+		got, err := u.ApproveRefundRequest(ctx, 3, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.RefundApproved, got.Status)
+		assert.Equal(t, &admin, got.ReviewedBy)
+		mockRefundRepo.AssertExpectations(t)
+		mockProcessor.AssertExpectations(t)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Reject Notifies Customer", func(t *testing.T) {
+		mockRefundRepo := new(mocks.MockRefundRepo)
+		mockProcessor := new(mocks.MockNotificationService)
+		mockAudit := new(mocks.MockAuditUsecase)
+		mockRefundRepo.On("GetRefundRequest", mock.Anything, int64(3)).Return(request(entity.RefundApproved), nil).Once()
+		mockRefundRepo.On("ReviewRefundRequest", mock.Anything, int64(3), entity.RefundApproved, entity.RefundRejected, &admin, "Lewat batas waktu").Return(nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionRefundRequestReject, "booking", int64(5), mock.Anything).Once()
+		mockProcessor.On("SendNotification", mock.Anything, int64(5), "budi@test.com", mock.MatchedBy(func(msg i18n.Message) bool {
+			return strings.Contains(msg.In("id"), "Lewat batas waktu")
+		})).Once()
+
+		u := usecase.NewRefundUsecase(mockRefundRepo, new(mocks.MockBookingRepo), new(mocks.MockEventRepo), new(mocks.MockReportCache), time.Minute, mockProcessor, mockAudit, clock.Real{}, time.Second*2)
+		got, err := u.RejectRefundRequest(ctx, 3, "Lewat batas waktu")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.RefundRejected, got.Status)
+		mockRefundRepo.AssertExpectations(t)
+		mockProcessor.AssertExpectations(t)
+	})
+
+	t.Run("Reject Requires Note", func(t *testing.T) {
+		u := usecase.NewRefundUsecase(new(mocks.MockRefundRepo), new(mocks.MockBookingRepo), new(mocks.MockEventRepo), new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+		_, err := u.RejectRefundRequest(ctx, 3, "  ")
+		assert.ErrorIs(t, err, entity.ErrInvalidRefundReason)
+	})
+
+	t.Run("Completed Request Cannot Be Reviewed", func(t *testing.T) {
+		mockRefundRepo := new(mocks.MockRefundRepo)
+		mockRefundRepo.On("GetRefundRequest", mock.Anything, int64(3)).Return(request(entity.RefundCompleted), nil).Once()
+
+		u := usecase.NewRefundUsecase(mockRefundRepo, new(mocks.MockBookingRepo), new(mocks.MockEventRepo), new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+		_, err := u.ApproveRefundRequest(ctx, 3, "")
+
+		assert.ErrorIs(t, err, entity.ErrRefundNotReviewable)
+		mockRefundRepo.AssertExpectations(t)
+	})
+
+	t.Run("Approved Request Cannot Be Approved Again", func(t *testing.T) {
+		mockRefundRepo := new(mocks.MockRefundRepo)
+		mockRefundRepo.On("GetRefundRequest", mock.Anything, int64(3)).Return(request(entity.RefundApproved), nil).Once()
+
+		u := usecase.NewRefundUsecase(mockRefundRepo, new(mocks.MockBookingRepo), new(mocks.MockEventRepo), new(mocks.MockReportCache), time.Minute, new(mocks.MockNotificationService), new(mocks.MockAuditUsecase), clock.Real{}, time.Second*2)
+		_, err := u.ApproveRefundRequest(ctx, 3, "")
+
+		assert.ErrorIs(t, err, entity.ErrRefundNotReviewable)
+	})
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/clock"
+	"ticres/pkg/i18n"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type SalesGoalUsecase interface {
+	// SetSalesGoal sets the sales goal of an event the organizer owns and
+	// re-arms its alerts. The target may not exceed the event's capacity.
+	SetSalesGoal(ctx context.Context, organizerID int64, goal *entity.SalesGoal) (*entity.SalesGoalProgress, error)
+	GetSalesGoal(ctx context.Context, organizerID, eventID int64) (*entity.SalesGoalProgress, error)
+	DeleteSalesGoal(ctx context.Context, organizerID, eventID int64) error
+	// CheckSalesGoals emails organizers the goal alerts that became due
+	// and returns how many events had alerts sent.
+	CheckSalesGoals(ctx context.Context) (int, error)
+}
+
+type salesGoalUsecase struct {
+	goalRepo       repository.SalesGoalRepository
+	eventRepo      repository.EventRepository
+	userRepo       repository.UserRepository
+	notifier       NotificationService
+	clock          clock.Clock
+	contextTimeout time.Duration
+}
+
+func NewSalesGoalUsecase(
+	goalRepo repository.SalesGoalRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	notifier NotificationService,
+	clk clock.Clock,
+	timeout time.Duration,
+) SalesGoalUsecase {
+	return &salesGoalUsecase{
+		goalRepo:       goalRepo,
+		eventRepo:      eventRepo,
+		userRepo:       userRepo,
+		notifier:       notifier,
+		clock:          clk,
+		contextTimeout: timeout,
+	}
+}
+
+// ownEvent returns the event, or ErrNotFound unless the organizer owns it
+// and it is not deleted.
+func (uc *salesGoalUsecase) ownEvent(ctx context.Context, organizerID, eventID int64) (*entity.Event, error) {
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil || event.IsDeleted() {
+		return nil, entity.ErrNotFound
+	}
+	if event.OrganizerID == nil || *event.OrganizerID != organizerID {
+		return nil, entity.ErrNotFound
+	}
+	return event, nil
+}
+
+func (uc *salesGoalUsecase) SetSalesGoal(ctx context.Context, organizerID int64, goal *entity.SalesGoal) (*entity.SalesGoalProgress, error) {
+	if err := goal.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	event, err := uc.ownEvent(ctx, organizerID, goal.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if goal.TargetTickets > event.Capacity {
+		return nil, fmt.Errorf("%w: target_tickets is above the capacity of %d", entity.ErrInvalidSalesGoal, event.Capacity)
+	}
+
+	if err := uc.goalRepo.UpsertSalesGoal(ctx, goal); err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("usecase: sales goal set",
+		logger.Int64("event_id", goal.EventID),
+		logger.Int("target_tickets", goal.TargetTickets),
+	)
+	return uc.goalRepo.GetSalesGoalProgress(ctx, goal.EventID)
+}
+
+func (uc *salesGoalUsecase) GetSalesGoal(ctx context.Context, organizerID, eventID int64) (*entity.SalesGoalProgress, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.ownEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+	return uc.goalRepo.GetSalesGoalProgress(ctx, eventID)
+}
+
+func (uc *salesGoalUsecase) DeleteSalesGoal(ctx context.Context, organizerID, eventID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.ownEvent(ctx, organizerID, eventID); err != nil {
+		return err
+	}
+	if err := uc.goalRepo.DeleteSalesGoal(ctx, eventID); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("usecase: sales goal deleted", logger.Int64("event_id", eventID))
+	return nil
+}
+
+func (uc *salesGoalUsecase) CheckSalesGoals(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "SalesGoalUsecase.CheckSalesGoals")
+	defer span.End()
+
+	goals, err := uc.goalRepo.GetActiveSalesGoals(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to load sales goals", logger.Err(err))
+		return 0, err
+	}
+
+	now := uc.clock.Now()
+	var sent int
+	for i := range goals {
+		goal := &goals[i]
+		alerts := goal.DueAlerts(now)
+		if !alerts.Any() {
+			continue
+		}
+
+		organizer, err := uc.userRepo.GetUserByID(ctx, int(goal.OrganizerID))
+		if err != nil {
+			logger.FromContext(ctx).Warn("usecase: organizer not found for sales goal", logger.Int64("event_id", goal.EventID), logger.Err(err))
+			continue
+		}
+
+		// The alerts are recorded first, so a failed update can't send
+		// the same email on every run
+		if err := uc.goalRepo.MarkSalesGoalAlerts(ctx, goal.EventID, alerts); err != nil {
+			continue
+		}
+		for _, msg := range salesGoalMessages(goal, alerts) {
+			uc.notifier.SendNotification(ctx, 0, organizer.Email, msg)
+		}
+		sent++
+
+		logger.FromContext(ctx).Info("usecase: sales goal alerts sent",
+			logger.Int64("event_id", goal.EventID),
+			logger.Int("sold", goal.Sold),
+			logger.Int("target", goal.TargetTickets),
+		)
+	}
+
+	span.SetAttributes(attribute.Int("goals", len(goals)), attribute.Int("alerted", sent))
+	return sent, nil
+}
+
+// salesGoalMessages words the alerts. Passing several thresholds at once
+// is reported as the highest of them.
+func salesGoalMessages(goal *entity.SalesGoalProgress, alerts entity.SalesGoalAlerts) []i18n.Message {
+	var msgs []i18n.Message
+	if n := len(alerts.Reached); n > 0 {
+		msgs = append(msgs, i18n.Msg(
+			"Ticket sales for \"%s\" passed %s%% of your goal: %s of %s tickets sold.",
+			goal.EventName, alerts.Reached[n-1], goal.Sold, goal.TargetTickets,
+		))
+	}
+	if alerts.SoldOut {
+		msgs = append(msgs, i18n.Msg("\"%s\" is sold out: all %s tickets are sold.", goal.EventName, goal.Capacity))
+	}
+	if alerts.Stalled {
+		msgs = append(msgs, i18n.Msg(
+			"No tickets for \"%s\" have sold in the last %s hours. %s of your goal of %s tickets are sold so far.",
+			goal.EventName, goal.StallAfterHours, goal.Sold, goal.TargetTickets,
+		))
+	}
+	return msgs
+}
@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSalesGoalUsecase_SetSalesGoal(t *testing.T) {
+	organizerID := int64(5)
+	otherID := int64(6)
+	event := &entity.Event{ID: 1, Capacity: 1000, OrganizerID: &organizerID}
+
+	tests := []struct {
+		name           string
+		organizerID    int64
+		goal           entity.SalesGoal
+		mock           func(goalRepo *mocks.MockSalesGoalRepo)
+		wantErr        error
+		wantThresholds []int
+	}{
+		{
+			name:        "Success - Default Thresholds",
+			organizerID: organizerID,
+			goal:        entity.SalesGoal{EventID: 1, TargetTickets: 800, NotifySoldOut: true},
+			mock: func(goalRepo *mocks.MockSalesGoalRepo) {
+				goalRepo.On("UpsertSalesGoal", mock.Anything, mock.AnythingOfType("*entity.SalesGoal")).Return(nil).Once()
+				goalRepo.On("GetSalesGoalProgress", mock.Anything, int64(1)).Return(&entity.SalesGoalProgress{}, nil).Once()
+			},
+			wantThresholds: []int{50, 100},
+		},
+		{
+			name:           "Success - Thresholds Sorted",
+			organizerID:    organizerID,
+			goal:           entity.SalesGoal{EventID: 1, TargetTickets: 800, Thresholds: []int{90, 25}},
+			wantThresholds: []int{25, 90},
+			mock: func(goalRepo *mocks.MockSalesGoalRepo) {
+				goalRepo.On("UpsertSalesGoal", mock.Anything, mock.AnythingOfType("*entity.SalesGoal")).Return(nil).Once()
+				goalRepo.On("GetSalesGoalProgress", mock.Anything, int64(1)).Return(&entity.SalesGoalProgress{}, nil).Once()
+			},
+		},
+		{
+			name:        "Failed - Target Above Capacity",
+			organizerID: organizerID,
+			goal:        entity.SalesGoal{EventID: 1, TargetTickets: 1001},
+			mock:        func(goalRepo *mocks.MockSalesGoalRepo) {},
+			wantErr:     entity.ErrInvalidSalesGoal,
+		},
+		{
+			name:        "Failed - Threshold Out Of Range",
+			organizerID: organizerID,
+			goal:        entity.SalesGoal{EventID: 1, TargetTickets: 800, Thresholds: []int{50, 150}},
+			mock:        func(goalRepo *mocks.MockSalesGoalRepo) {},
+			wantErr:     entity.ErrInvalidSalesGoal,
+		},
+		{
+			name:        "Failed - Not The Organizer's Event",
+			organizerID: otherID,
+			goal:        entity.SalesGoal{EventID: 1, TargetTickets: 800},
+			mock:        func(goalRepo *mocks.MockSalesGoalRepo) {},
+			wantErr:     entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goalRepo := new(mocks.MockSalesGoalRepo)
+			eventRepo := new(mocks.MockEventRepo)
+			eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(event, nil).Maybe()
+			tt.mock(goalRepo)
+
+			uc := usecase.NewSalesGoalUsecase(goalRepo, eventRepo, new(mocks.MockUserRepo), new(mocks.MockNotificationService), clock.Real{}, 2*time.Second)
+			goal := tt.goal
+			_, err := uc.SetSalesGoal(context.Background(), tt.organizerID, &goal)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantThresholds, goal.Thresholds)
+			}
+			goalRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSalesGoalUsecase_CheckSalesGoals(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	now := clk.Now()
+	setAt := now.Add(-72 * time.Hour)
+	lastSale := now.Add(-50 * time.Hour)
+	goal := func(eventID int64, sold int) entity.SalesGoalProgress {
+		return entity.SalesGoalProgress{
+			SalesGoal: entity.SalesGoal{
+				EventID:       eventID,
+				TargetTickets: 100,
+				Thresholds:    []int{25, 50, 100},
+				NotifySoldOut: true,
+				UpdatedAt:     setAt,
+			},
+			EventName:   "Show",
+			OrganizerID: 5,
+			Capacity:    200,
+			Sold:        sold,
+			LastSaleAt:  &now,
+		}
+	}
+
+	// Passes 25% and 50% since the last check
+	halfway := goal(1, 60)
+	// 25% was already reported
+	quiet := goal(2, 30)
+	quiet.ReachedThresholds = []int{25}
+	// Sold out and every threshold passed
+	soldOut := goal(3, 200)
+	// Nothing sold for 50 hours with a 48 hour stall window
+	stalled := goal(4, 10)
+	stalled.Thresholds = []int{50}
+	stalled.StallAfterHours = 48
+	stalled.LastSaleAt = &lastSale
+	// The same stall, already reported
+	stallReported := stalled
+	stallReported.EventID = 5
+	reportedAt := now.Add(-time.Hour)
+	stallReported.StallAlertedAt = &reportedAt
+
+	goalRepo := new(mocks.MockSalesGoalRepo)
+	userRepo := new(mocks.MockUserRepo)
+	notif := new(mocks.MockNotificationService)
+
+	goalRepo.On("GetActiveSalesGoals", mock.Anything).
+		Return([]entity.SalesGoalProgress{halfway, quiet, soldOut, stalled, stallReported}, nil).Once()
+	userRepo.On("GetUserByID", mock.Anything, 5).Return(&entity.User{ID: 5, Email: "organizer@mail.com"}, nil).Times(3)
+	goalRepo.On("MarkSalesGoalAlerts", mock.Anything, int64(1), entity.SalesGoalAlerts{Reached: []int{25, 50}}).Return(nil).Once()
+	goalRepo.On("MarkSalesGoalAlerts", mock.Anything, int64(3), entity.SalesGoalAlerts{Reached: []int{25, 50, 100}, SoldOut: true}).Return(nil).Once()
+	goalRepo.On("MarkSalesGoalAlerts", mock.Anything, int64(4), entity.SalesGoalAlerts{Stalled: true}).Return(nil).Once()
+	// One email per threshold report, sell-out and stall
+	notif.On("SendNotification", mock.Anything, int64(0), "organizer@mail.com", mock.AnythingOfType("i18n.Message")).Times(4)
+
+	uc := usecase.NewSalesGoalUsecase(goalRepo, new(mocks.MockEventRepo), userRepo, notif, clk, 2*time.Second)
+	alerted, err := uc.CheckSalesGoals(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, alerted)
+	goalRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	notif.AssertExpectations(t)
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/storage"
+)
+
+// MaxSectionImageSize is the largest view-from-seat image an organizer may upload.
+const MaxSectionImageSize = 5 << 20
+
+// imageExtensions maps the accepted image types to their file extension.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// SectionImageURL is the public download path of a section image.
+func SectionImageURL(imageID int64) string {
+	return fmt.Sprintf("/api/v1/section-images/%d", imageID)
+}
+
+type SectionImageUsecase interface {
+	// UploadSectionImage sets the view-from-seat image of a section of the
+	// organizer's event, replacing any previous one.
+	UploadSectionImage(ctx context.Context, organizerID int64, img *entity.SectionImage, content io.Reader) error
+	ListSectionImages(ctx context.Context, eventID int64) ([]entity.SectionImage, error)
+	DeleteSectionImage(ctx context.Context, eventID, organizerID int64, section string) error
+	// OpenSectionImage returns the image metadata and a reader over its
+	// content. The caller must close the reader.
+	OpenSectionImage(ctx context.Context, imageID int64) (*entity.SectionImage, io.ReadCloser, error)
+}
+
+type sectionImageUsecase struct {
+	imageRepo      repository.SectionImageRepository
+	eventRepo      repository.EventRepository
+	store          storage.Storage
+	contextTimeout time.Duration
+}
+
+func NewSectionImageUsecase(imageRepo repository.SectionImageRepository, eventRepo repository.EventRepository, store storage.Storage, timeout time.Duration) SectionImageUsecase {
+	return &sectionImageUsecase{
+		imageRepo:      imageRepo,
+		eventRepo:      eventRepo,
+		store:          store,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *sectionImageUsecase) UploadSectionImage(ctx context.Context, organizerID int64, img *entity.SectionImage, content io.Reader) error {
+	logger.FromContext(ctx).Debug("usecase: uploading section image",
+		logger.Int64("event_id", img.EventID),
+		logger.String("section", img.Section),
+	)
+
+	ext, ok := imageExtensions[img.ContentType]
+	if !ok || img.SizeBytes <= 0 || img.SizeBytes > MaxSectionImageSize {
+		logger.FromContext(ctx).Warn("usecase: invalid section image",
+			logger.String("content_type", img.ContentType),
+			logger.Int64("size", img.SizeBytes),
+		)
+		return entity.ErrInvalidSectionImage
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.checkSection(ctx, img.EventID, organizerID, img.Section); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to generate section image key", logger.Err(err))
+		return err
+	}
+	img.StorageKey = fmt.Sprintf("events/%d/sections/%s%s", img.EventID, hex.EncodeToString(buf), ext)
+
+	if err := uc.store.Put(ctx, img.StorageKey, content, img.ContentType); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to store section image", logger.Int64("event_id", img.EventID), logger.Err(err))
+		return err
+	}
+
+	oldKey, err := uc.imageRepo.ReplaceSectionImage(ctx, img)
+	if err != nil {
+		// Don't leave an orphaned file behind when the metadata insert fails
+		if delErr := uc.store.Delete(context.Background(), img.StorageKey); delErr != nil {
+			logger.FromContext(ctx).Warn("usecase: failed to clean up section image", logger.String("key", img.StorageKey), logger.Err(delErr))
+		}
+		return err
+	}
+	if oldKey != "" {
+		if err := uc.store.Delete(ctx, oldKey); err != nil {
+			logger.FromContext(ctx).Warn("usecase: failed to delete replaced section image", logger.String("key", oldKey), logger.Err(err))
+		}
+	}
+
+	img.URL = SectionImageURL(img.ID)
+	logger.FromContext(ctx).Info("usecase: section image uploaded",
+		logger.Int64("event_id", img.EventID),
+		logger.Int64("image_id", img.ID),
+	)
+	return nil
+}
+
+func (uc *sectionImageUsecase) ListSectionImages(ctx context.Context, eventID int64) ([]entity.SectionImage, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.eventRepo.GetEventByID(ctx, eventID); err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	images, err := uc.imageRepo.GetSectionImages(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range images {
+		images[i].URL = SectionImageURL(images[i].ID)
+	}
+	return images, nil
+}
+
+func (uc *sectionImageUsecase) DeleteSectionImage(ctx context.Context, eventID, organizerID int64, section string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.checkOwner(ctx, eventID, organizerID); err != nil {
+		return err
+	}
+
+	key, err := uc.imageRepo.DeleteSectionImage(ctx, eventID, section)
+	if err != nil {
+		return err
+	}
+	if err := uc.store.Delete(ctx, key); err != nil {
+		logger.FromContext(ctx).Warn("usecase: failed to delete section image file", logger.String("key", key), logger.Err(err))
+	}
+	return nil
+}
+
+func (uc *sectionImageUsecase) OpenSectionImage(ctx context.Context, imageID int64) (*entity.SectionImage, io.ReadCloser, error) {
+	img, err := uc.imageRepo.GetSectionImageByID(ctx, imageID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, err := uc.store.Get(ctx, img.StorageKey)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to open section image", logger.Int64("image_id", imageID), logger.Err(err))
+		return nil, nil, err
+	}
+	return img, rc, nil
+}
+
+// checkOwner hides events of other organizers behind ErrNotFound.
+func (uc *sectionImageUsecase) checkOwner(ctx context.Context, eventID, organizerID int64) error {
+	event, err := uc.eventRepo.GetEventByID(ctx, eventID)
+	if err != nil {
+		return entity.ErrNotFound
+	}
+	if event.OrganizerID == nil || *event.OrganizerID != organizerID {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// checkSection also requires the section to exist among the event's seats.
+func (uc *sectionImageUsecase) checkSection(ctx context.Context, eventID, organizerID int64, section string) error {
+	if strings.TrimSpace(section) == "" {
+		return entity.ErrInvalidSectionImage
+	}
+	if err := uc.checkOwner(ctx, eventID, organizerID); err != nil {
+		return err
+	}
+
+	sections, err := uc.eventRepo.GetSectionAvailability(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	for _, s := range sections {
+		if s.Section == section {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: event has no section %q", entity.ErrInvalidSectionImage, section)
+}
@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSectionImageUsecase_UploadSectionImage(t *testing.T) {
+	organizerID := int64(7)
+	otherID := int64(8)
+	sections := []entity.SectionAvailability{{Section: "VIP"}, {Section: "REGULAR"}}
+
+	tests := []struct {
+		name    string
+		img     entity.SectionImage
+		mock    func(imageRepo *mocks.MockSectionImageRepo, eventRepo *mocks.MockEventRepo, store *mocks.MockStorage)
+		wantErr error
+	}{
+		{
+			name: "Success - Replaces Previous Image",
+			img:  entity.SectionImage{EventID: 1, Section: "VIP", ContentType: "image/png", SizeBytes: 1024},
+			mock: func(imageRepo *mocks.MockSectionImageRepo, eventRepo *mocks.MockEventRepo, store *mocks.MockStorage) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, OrganizerID: &organizerID}, nil).Once()
+				eventRepo.On("GetSectionAvailability", mock.Anything, int64(1)).Return(sections, nil).Once()
+				store.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "events/1/sections/") && strings.HasSuffix(key, ".png")
+				}), mock.Anything, "image/png").Return(nil).Once()
+				imageRepo.On("ReplaceSectionImage", mock.Anything, mock.AnythingOfType("*entity.SectionImage")).
+					Run(func(args mock.Arguments) { args.Get(1).(*entity.SectionImage).ID = 42 }).
+					Return("events/1/sections/old.png", nil).Once()
+				store.On("Delete", mock.Anything, "events/1/sections/old.png").Return(nil).Once()
+			},
+		},
+		{
+			name:    "Failed - Unsupported Type",
+			img:     entity.SectionImage{EventID: 1, Section: "VIP", ContentType: "application/pdf", SizeBytes: 1024},
+			mock:    func(*mocks.MockSectionImageRepo, *mocks.MockEventRepo, *mocks.MockStorage) {},
+			wantErr: entity.ErrInvalidSectionImage,
+		},
+		{
+			name:    "Failed - Too Large",
+			img:     entity.SectionImage{EventID: 1, Section: "VIP", ContentType: "image/jpeg", SizeBytes: usecase.MaxSectionImageSize + 1},
+			mock:    func(*mocks.MockSectionImageRepo, *mocks.MockEventRepo, *mocks.MockStorage) {},
+			wantErr: entity.ErrInvalidSectionImage,
+		},
+		{
+			name: "Failed - Not Event Owner",
+			img:  entity.SectionImage{EventID: 1, Section: "VIP", ContentType: "image/png", SizeBytes: 1024},
+			mock: func(imageRepo *mocks.MockSectionImageRepo, eventRepo *mocks.MockEventRepo, store *mocks.MockStorage) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, OrganizerID: &otherID}, nil).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name: "Failed - Unknown Section",
+			img:  entity.SectionImage{EventID: 1, Section: "BALCONY", ContentType: "image/png", SizeBytes: 1024},
+			mock: func(imageRepo *mocks.MockSectionImageRepo, eventRepo *mocks.MockEventRepo, store *mocks.MockStorage) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, OrganizerID: &organizerID}, nil).Once()
+				eventRepo.On("GetSectionAvailability", mock.Anything, int64(1)).Return(sections, nil).Once()
+			},
+			wantErr: entity.ErrInvalidSectionImage,
+		},
+		{
+			name: "Failed - DB Error Removes Stored File",
+			img:  entity.SectionImage{EventID: 1, Section: "VIP", ContentType: "image/webp", SizeBytes: 1024},
+			mock: func(imageRepo *mocks.MockSectionImageRepo, eventRepo *mocks.MockEventRepo, store *mocks.MockStorage) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, OrganizerID: &organizerID}, nil).Once()
+				eventRepo.On("GetSectionAvailability", mock.Anything, int64(1)).Return(sections, nil).Once()
+				store.On("Put", mock.Anything, mock.AnythingOfType("string"), mock.Anything, "image/webp").Return(nil).Once()
+				imageRepo.On("ReplaceSectionImage", mock.Anything, mock.AnythingOfType("*entity.SectionImage")).Return("", errors.New("db down")).Once()
+				store.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+			},
+			wantErr: errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imageRepo := new(mocks.MockSectionImageRepo)
+			eventRepo := new(mocks.MockEventRepo)
+			store := new(mocks.MockStorage)
+			tt.mock(imageRepo, eventRepo, store)
+
+			uc := usecase.NewSectionImageUsecase(imageRepo, eventRepo, store, 2*time.Second)
+			img := tt.img
+			err := uc.UploadSectionImage(context.Background(), organizerID, &img, strings.NewReader("image"))
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.wantErr, entity.ErrInvalidSectionImage) || errors.Is(tt.wantErr, entity.ErrNotFound) {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "/api/v1/section-images/42", img.URL)
+			}
+			imageRepo.AssertExpectations(t)
+			eventRepo.AssertExpectations(t)
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSectionImageUsecase_DeleteSectionImage(t *testing.T) {
+	organizerID := int64(7)
+
+	t.Run("Success", func(t *testing.T) {
+		imageRepo := new(mocks.MockSectionImageRepo)
+		eventRepo := new(mocks.MockEventRepo)
+		store := new(mocks.MockStorage)
+		eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, OrganizerID: &organizerID}, nil).Once()
+		imageRepo.On("DeleteSectionImage", mock.Anything, int64(1), "VIP").Return("events/1/sections/a.png", nil).Once()
+		store.On("Delete", mock.Anything, "events/1/sections/a.png").Return(nil).Once()
+
+		uc := usecase.NewSectionImageUsecase(imageRepo, eventRepo, store, 2*time.Second)
+		err := uc.DeleteSectionImage(context.Background(), 1, organizerID, "VIP")
+
+		assert.NoError(t, err)
+		imageRepo.AssertExpectations(t)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("Failed - No Image", func(t *testing.T) {
+		imageRepo := new(mocks.MockSectionImageRepo)
+		eventRepo := new(mocks.MockEventRepo)
+		store := new(mocks.MockStorage)
+		eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1, OrganizerID: &organizerID}, nil).Once()
+		imageRepo.On("DeleteSectionImage", mock.Anything, int64(1), "VIP").Return("", entity.ErrNotFound).Once()
+
+		uc := usecase.NewSectionImageUsecase(imageRepo, eventRepo, store, 2*time.Second)
+		err := uc.DeleteSectionImage(context.Background(), 1, organizerID, "VIP")
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		store.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/database"
+	"ticres/pkg/logger"
+	"ticres/pkg/payment"
+)
+
+// StatusUsecase reports the health of the platform's components for an
+// external status page. Unlike a liveness probe it checks every dependency,
+// so its result is cached briefly to keep polling cheap.
+type StatusUsecase interface {
+	GetStatus(ctx context.Context) *entity.SystemStatus
+}
+
+// GatewayMonitor reports the payment gateway's circuit breaker state and
+// when a call to it last went through.
+type GatewayMonitor interface {
+	CircuitState() string
+	LastSuccess() time.Time
+}
+
+// ReconciliationMonitor reports the last run of a periodic reconciliation.
+type ReconciliationMonitor interface {
+	LastRun() *entity.ReconciliationRun
+}
+
+const (
+	// statusCacheTTL is how long a computed status is served again.
+	statusCacheTTL = 5 * time.Second
+	// statusSlowPing marks a store degraded when it answers slower.
+	statusSlowPing = 500 * time.Millisecond
+	// statusJobLag marks the worker degraded once a due job has waited
+	// this long.
+	statusJobLag = 5 * time.Minute
+	// statusMissedRuns is how many reconciliation intervals may pass
+	// without a run before it is reported stale.
+	statusMissedRuns = 3
+)
+
+type statusUsecase struct {
+	health            repository.HealthRepository
+	jobRepo           repository.JobRepository
+	queue             JobQueueMonitor
+	gateway           GatewayMonitor
+	reconciliation    ReconciliationMonitor
+	reconcileInterval time.Duration
+	checkTimeout      time.Duration
+	startedAt         time.Time
+
+	mu     sync.Mutex
+	cached *entity.SystemStatus
+}
+
+// NewStatusUsecase builds the status report. reconcileInterval is how often
+// the reconciliation is expected to run. checkTimeout bounds each component
+// check; a store slower than this is reported down.
+func NewStatusUsecase(
+	health repository.HealthRepository,
+	jobRepo repository.JobRepository,
+	queue JobQueueMonitor,
+	gateway GatewayMonitor,
+	reconciliation ReconciliationMonitor,
+	reconcileInterval time.Duration,
+	checkTimeout time.Duration,
+) StatusUsecase {
+	return &statusUsecase{
+		health:            health,
+		jobRepo:           jobRepo,
+		queue:             queue,
+		gateway:           gateway,
+		reconciliation:    reconciliation,
+		reconcileInterval: reconcileInterval,
+		checkTimeout:      checkTimeout,
+		startedAt:         time.Now(),
+	}
+}
+
+func (uc *statusUsecase) GetStatus(ctx context.Context) *entity.SystemStatus {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.cached != nil && time.Since(uc.cached.CheckedAt) < statusCacheTTL {
+		return uc.cached
+	}
+
+	checks := []func(context.Context) entity.ComponentStatus{
+		uc.checkDatabase,
+		uc.checkCache,
+		uc.checkGateway,
+		uc.checkWorker,
+		uc.checkReconciliation,
+	}
+	// A poller hanging up must not leave a false outage in the cache
+	checkCtx := context.WithoutCancel(ctx)
+	components := make([]entity.ComponentStatus, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check func(context.Context) entity.ComponentStatus) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(checkCtx, uc.checkTimeout)
+			defer cancel()
+			components[i] = check(ctx)
+		}(i, check)
+	}
+	wg.Wait()
+
+	status := &entity.SystemStatus{Components: components, CheckedAt: time.Now()}
+	status.Summarize()
+	if status.Status != entity.ComponentOperational {
+		logger.FromContext(ctx).Warn("usecase: platform not fully operational", logger.String("status", status.Status))
+	}
+
+	uc.cached = status
+	return status
+}
+
+func (uc *statusUsecase) checkDatabase(ctx context.Context) entity.ComponentStatus {
+	return pingComponent(ctx, "database", true, uc.health.PingDatabase)
+}
+
+func (uc *statusUsecase) checkCache(ctx context.Context) entity.ComponentStatus {
+	c := pingComponent(ctx, "cache", false, uc.health.PingCache)
+	state := uc.health.CacheCircuitState()
+	c.Details = map[string]interface{}{"circuit": state}
+	if state == database.CircuitOpen {
+		c.Message = "Not responding; requests are served without the cache"
+	}
+	return c
+}
+
+// pingComponent reports a store down when ping fails and degraded when it
+// is slow, with the round trip time.
+func pingComponent(ctx context.Context, name string, critical bool, ping func(context.Context) error) entity.ComponentStatus {
+	start := time.Now()
+	err := ping(ctx)
+	latency := time.Since(start).Milliseconds()
+
+	c := entity.ComponentStatus{Name: name, Status: entity.ComponentOperational, LatencyMS: &latency, Critical: critical}
+	switch {
+	case err != nil:
+		logger.FromContext(ctx).Warn("usecase: status check failed", logger.String("component", name), logger.Err(err))
+		c.Status = entity.ComponentDown
+		c.Message = "Not responding"
+	case time.Duration(latency)*time.Millisecond > statusSlowPing:
+		c.Status = entity.ComponentDegraded
+		c.Message = "Responding slowly"
+	}
+	return c
+}
+
+func (uc *statusUsecase) checkGateway(ctx context.Context) entity.ComponentStatus {
+	state := uc.gateway.CircuitState()
+	c := entity.ComponentStatus{
+		Name:    "payment_gateway",
+		Status:  entity.ComponentOperational,
+		Details: map[string]interface{}{"circuit": state},
+	}
+	switch state {
+	case payment.CircuitOpen:
+		c.Status = entity.ComponentDown
+		c.Message = "Payments are failing; the gateway is not being called"
+	case payment.CircuitHalfOpen:
+		c.Status = entity.ComponentDegraded
+		c.Message = "Recovering from failures"
+	}
+	return c
+}
+
+func (uc *statusUsecase) checkWorker(ctx context.Context) entity.ComponentStatus {
+	queue := uc.queue.QueueStats()
+	c := entity.ComponentStatus{
+		Name:    "worker_queue",
+		Status:  entity.ComponentOperational,
+		Details: map[string]interface{}{},
+	}
+
+	backlog, err := uc.jobRepo.GetJobBacklog(ctx)
+	if err != nil {
+		c.Status = entity.ComponentDown
+		c.Message = "Job queue unavailable"
+		return c
+	}
+	c.Details["due"] = backlog.Due
+	c.Details["scheduled"] = backlog.Scheduled
+	c.Details["running"] = backlog.Running
+	c.Details["dead"] = backlog.Dead
+	c.Details["outbox"] = backlog.Outbox
+	c.Details["outbox_limit"] = queue.OutboxLimit
+	c.Details["dropped"] = queue.Dropped
+
+	if backlog.OldestDueAt != nil {
+		lag := time.Since(*backlog.OldestDueAt)
+		c.Details["lag_seconds"] = int64(lag.Seconds())
+		if lag > statusJobLag {
+			c.Status = entity.ComponentDegraded
+			c.Message = fmt.Sprintf("Jobs are waiting %d minutes or more", int(lag.Minutes()))
+		}
+	}
+	if queue.Backlogged {
+		c.Status = entity.ComponentDegraded
+		c.Message = "Job outbox is backed up"
+	}
+	return c
+}
+
+func (uc *statusUsecase) checkReconciliation(ctx context.Context) entity.ComponentStatus {
+	c := entity.ComponentStatus{Name: "reconciliation", Status: entity.ComponentOperational}
+	stale := time.Duration(statusMissedRuns) * uc.reconcileInterval
+
+	run := uc.reconciliation.LastRun()
+	if run == nil {
+		if time.Since(uc.startedAt) > stale {
+			c.Status = entity.ComponentDegraded
+			c.Message = "Has not run"
+		}
+		return c
+	}
+
+	c.Details = map[string]interface{}{
+		"name":        run.Name,
+		"last_run_at": run.FinishedAt,
+		"violations":  run.Violations,
+	}
+	switch {
+	case run.Error != "":
+		c.Status = entity.ComponentDegraded
+		c.Message = "Last run failed"
+	case time.Since(run.FinishedAt) > stale:
+		c.Status = entity.ComponentDegraded
+		c.Message = "Last run is overdue"
+	case run.Violations > 0:
+		c.Status = entity.ComponentDegraded
+		c.Message = "Inventory mismatches found"
+	}
+	return c
+}
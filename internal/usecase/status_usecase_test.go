@@ -0,0 +1,129 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/database"
+	"ticres/pkg/payment"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func componentByName(status *entity.SystemStatus, name string) entity.ComponentStatus {
+	for _, c := range status.Components {
+		if c.Name == name {
+			return c
+		}
+	}
+	return entity.ComponentStatus{}
+}
+
+func TestStatusUsecase_GetStatus(t *testing.T) {
+	longAgo := time.Now().Add(-20 * time.Minute)
+	recentRun := &entity.ReconciliationRun{Name: "inventory", FinishedAt: time.Now()}
+
+	tests := []struct {
+		name          string
+		dbErr         error
+		cacheErr      error
+		cacheCircuit  string
+		circuit       string
+		backlog       *entity.JobBacklog
+		backlogErr    error
+		backlogged    bool
+		lastRun       *entity.ReconciliationRun
+		wantStatus    string
+		wantComponent string
+		wantCompState string
+	}{
+		{name: "All Operational", circuit: payment.CircuitClosed, backlog: &entity.JobBacklog{Due: 3}, lastRun: recentRun,
+			wantStatus: entity.ComponentOperational, wantComponent: "database", wantCompState: entity.ComponentOperational},
+		{name: "Database Down", dbErr: errors.New("connection refused"), circuit: payment.CircuitClosed, backlog: &entity.JobBacklog{}, lastRun: recentRun,
+			wantStatus: entity.ComponentDown, wantComponent: "database", wantCompState: entity.ComponentDown},
+		{name: "Cache Down", cacheErr: errors.New("connection refused"), circuit: payment.CircuitClosed, backlog: &entity.JobBacklog{}, lastRun: recentRun,
+			wantStatus: entity.ComponentDegraded, wantComponent: "cache", wantCompState: entity.ComponentDown},
+		{name: "Cache Circuit Open", cacheErr: database.ErrCacheUnavailable, cacheCircuit: database.CircuitOpen, circuit: payment.CircuitClosed, backlog: &entity.JobBacklog{}, lastRun: recentRun,
+			wantStatus: entity.ComponentDegraded, wantComponent: "cache", wantCompState: entity.ComponentDown},
+		{name: "Gateway Circuit Open", circuit: payment.CircuitOpen, backlog: &entity.JobBacklog{}, lastRun: recentRun,
+			wantStatus: entity.ComponentDegraded, wantComponent: "payment_gateway", wantCompState: entity.ComponentDown},
+		{name: "Gateway Recovering", circuit: payment.CircuitHalfOpen, backlog: &entity.JobBacklog{}, lastRun: recentRun,
+			wantStatus: entity.ComponentDegraded, wantComponent: "payment_gateway", wantCompState: entity.ComponentDegraded},
+		{name: "Jobs Lagging", circuit: payment.CircuitClosed, backlog: &entity.JobBacklog{Due: 500, OldestDueAt: &longAgo}, lastRun: recentRun,
+			wantStatus: entity.ComponentDegraded, wantComponent: "worker_queue", wantCompState: entity.ComponentDegraded},
+		{name: "Job Outbox Backed Up", circuit: payment.CircuitClosed, backlog: &entity.JobBacklog{Outbox: 1200}, backlogged: true, lastRun: recentRun,
+			wantStatus: entity.ComponentDegraded, wantComponent: "worker_queue", wantCompState: entity.ComponentDegraded},
+		{name: "Job Queue Unavailable", circuit: payment.CircuitClosed, backlogErr: errors.New("timeout"), lastRun: recentRun,
+			wantStatus: entity.ComponentDegraded, wantComponent: "worker_queue", wantCompState: entity.ComponentDown},
+		{name: "Reconciliation Found Violations", circuit: payment.CircuitClosed, backlog: &entity.JobBacklog{},
+			lastRun:    &entity.ReconciliationRun{Name: "inventory", FinishedAt: time.Now(), Violations: 2},
+			wantStatus: entity.ComponentDegraded, wantComponent: "reconciliation", wantCompState: entity.ComponentDegraded},
+		{name: "Reconciliation Overdue", circuit: payment.CircuitClosed, backlog: &entity.JobBacklog{},
+			lastRun:    &entity.ReconciliationRun{Name: "inventory", FinishedAt: longAgo},
+			wantStatus: entity.ComponentDegraded, wantComponent: "reconciliation", wantCompState: entity.ComponentDegraded},
+		{name: "Reconciliation Not Run Yet", circuit: payment.CircuitClosed, backlog: &entity.JobBacklog{},
+			wantStatus: entity.ComponentOperational, wantComponent: "reconciliation", wantCompState: entity.ComponentOperational},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHealth := new(mocks.MockHealthRepo)
+			mockJobRepo := new(mocks.MockJobRepo)
+			mockQueue := new(mocks.MockJobQueueMonitor)
+			mockGateway := new(mocks.MockGatewayMonitor)
+			mockReconciliation := new(mocks.MockReconciliationMonitor)
+
+			mockHealth.On("PingDatabase", mock.Anything).Return(tt.dbErr)
+			mockHealth.On("PingCache", mock.Anything).Return(tt.cacheErr)
+			if tt.cacheCircuit == "" {
+				tt.cacheCircuit = database.CircuitClosed
+			}
+			mockHealth.On("CacheCircuitState").Return(tt.cacheCircuit)
+			mockGateway.On("CircuitState").Return(tt.circuit)
+			mockQueue.On("QueueStats").Return(entity.JobQueueStats{OutboxLimit: 1000, Backlogged: tt.backlogged})
+			if tt.backlogErr != nil {
+				mockJobRepo.On("GetJobBacklog", mock.Anything).Return(nil, tt.backlogErr)
+			} else {
+				mockJobRepo.On("GetJobBacklog", mock.Anything).Return(tt.backlog, nil)
+			}
+			mockReconciliation.On("LastRun").Return(tt.lastRun)
+
+			uc := usecase.NewStatusUsecase(mockHealth, mockJobRepo, mockQueue, mockGateway, mockReconciliation, time.Minute, 2*time.Second)
+			status := uc.GetStatus(context.Background())
+
+			assert.Equal(t, tt.wantStatus, status.Status)
+			assert.Len(t, status.Components, 5)
+			assert.Equal(t, tt.wantCompState, componentByName(status, tt.wantComponent).Status)
+		})
+	}
+}
+
+func TestStatusUsecase_GetStatus_Cached(t *testing.T) {
+	mockHealth := new(mocks.MockHealthRepo)
+	mockJobRepo := new(mocks.MockJobRepo)
+	mockQueue := new(mocks.MockJobQueueMonitor)
+	mockGateway := new(mocks.MockGatewayMonitor)
+	mockReconciliation := new(mocks.MockReconciliationMonitor)
+
+	mockHealth.On("PingDatabase", mock.Anything).Return(nil).Once()
+	mockHealth.On("PingCache", mock.Anything).Return(nil).Once()
+	mockHealth.On("CacheCircuitState").Return(database.CircuitClosed).Once()
+	mockGateway.On("CircuitState").Return(payment.CircuitClosed).Once()
+	mockQueue.On("QueueStats").Return(entity.JobQueueStats{OutboxLimit: 1000}).Once()
+	mockJobRepo.On("GetJobBacklog", mock.Anything).Return(&entity.JobBacklog{}, nil).Once()
+	mockReconciliation.On("LastRun").Return(nil).Once()
+
+	uc := usecase.NewStatusUsecase(mockHealth, mockJobRepo, mockQueue, mockGateway, mockReconciliation, time.Minute, 2*time.Second)
+	first := uc.GetStatus(context.Background())
+	second := uc.GetStatus(context.Background())
+
+	assert.Same(t, first, second)
+	mockHealth.AssertExpectations(t)
+	mockJobRepo.AssertExpectations(t)
+}
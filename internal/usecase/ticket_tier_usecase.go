@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+)
+
+type TicketTierUsecase interface {
+	CreateTier(ctx context.Context, tier *entity.TicketTier) error
+	ListTiers(ctx context.Context, eventID int64) ([]entity.TicketTier, error)
+	// UpdateTier replaces name, price and quota of a tier of the event.
+	UpdateTier(ctx context.Context, tier *entity.TicketTier) error
+	DeleteTier(ctx context.Context, eventID, tierID int64) error
+	// AssignSeats moves unsold seats into the tier and returns how many moved.
+	AssignSeats(ctx context.Context, eventID, tierID int64, assignment *entity.TierSeatAssignment) (int64, error)
+}
+
+type ticketTierUsecase struct {
+	tierRepo       repository.TicketTierRepository
+	eventRepo      repository.EventRepository
+	contextTimeout time.Duration
+}
+
+func NewTicketTierUsecase(tierRepo repository.TicketTierRepository, eventRepo repository.EventRepository, timeout time.Duration) TicketTierUsecase {
+	return &ticketTierUsecase{
+		tierRepo:       tierRepo,
+		eventRepo:      eventRepo,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *ticketTierUsecase) CreateTier(ctx context.Context, tier *entity.TicketTier) error {
+	if err := tier.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.eventRepo.GetEventByID(ctx, tier.EventID); err != nil {
+		return entity.ErrNotFound
+	}
+
+	if err := uc.tierRepo.CreateTier(ctx, tier); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("usecase: ticket tier created",
+		logger.Int64("tier_id", tier.ID),
+		logger.Int64("event_id", tier.EventID),
+	)
+	return nil
+}
+
+func (uc *ticketTierUsecase) ListTiers(ctx context.Context, eventID int64) ([]entity.TicketTier, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.eventRepo.GetEventByID(ctx, eventID); err != nil {
+		return nil, entity.ErrNotFound
+	}
+	return uc.tierRepo.GetTiersByEventID(ctx, eventID)
+}
+
+func (uc *ticketTierUsecase) UpdateTier(ctx context.Context, tier *entity.TicketTier) error {
+	if err := tier.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.checkTier(ctx, tier.EventID, tier.ID); err != nil {
+		return err
+	}
+	if err := uc.tierRepo.UpdateTier(ctx, tier); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("usecase: ticket tier updated", logger.Int64("tier_id", tier.ID))
+	return nil
+}
+
+func (uc *ticketTierUsecase) DeleteTier(ctx context.Context, eventID, tierID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.checkTier(ctx, eventID, tierID); err != nil {
+		return err
+	}
+	return uc.tierRepo.DeleteTier(ctx, tierID)
+}
+
+func (uc *ticketTierUsecase) AssignSeats(ctx context.Context, eventID, tierID int64, assignment *entity.TierSeatAssignment) (int64, error) {
+	if len(assignment.SeatIDs) == 0 && assignment.Section == "" {
+		return 0, fmt.Errorf("%w: seat_ids or section is required", entity.ErrInvalidTicketTier)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	moved, err := uc.tierRepo.AssignSeats(ctx, tierID, eventID, assignment)
+	if err != nil {
+		return 0, err
+	}
+
+	logger.FromContext(ctx).Info("usecase: seats assigned to ticket tier",
+		logger.Int64("tier_id", tierID),
+		logger.Int64("seats", moved),
+	)
+	return moved, nil
+}
+
+// checkTier reports tiers of other events as not found.
+func (uc *ticketTierUsecase) checkTier(ctx context.Context, eventID, tierID int64) error {
+	tier, err := uc.tierRepo.GetTierByID(ctx, tierID)
+	if err != nil {
+		return err
+	}
+	if tier.EventID != eventID {
+		return entity.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTicketTierUsecase_CreateTier(t *testing.T) {
+	tests := []struct {
+		name    string
+		tier    entity.TicketTier
+		mock    func(tierRepo *mocks.MockTicketTierRepo, eventRepo *mocks.MockEventRepo)
+		wantErr error
+	}{
+		{
+			name: "Success",
+			tier: entity.TicketTier{EventID: 1, Name: " VIP ", Price: 750000, Quota: 100},
+			mock: func(tierRepo *mocks.MockTicketTierRepo, eventRepo *mocks.MockEventRepo) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+				tierRepo.On("CreateTier", mock.Anything, mock.MatchedBy(func(tier *entity.TicketTier) bool {
+					return tier.Name == "VIP"
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Failed - Negative Price",
+			tier:    entity.TicketTier{EventID: 1, Name: "VIP", Price: -1, Quota: 100},
+			mock:    func(*mocks.MockTicketTierRepo, *mocks.MockEventRepo) {},
+			wantErr: entity.ErrInvalidTicketTier,
+		},
+		{
+			name:    "Failed - Zero Quota",
+			tier:    entity.TicketTier{EventID: 1, Name: "VIP", Price: 100},
+			mock:    func(*mocks.MockTicketTierRepo, *mocks.MockEventRepo) {},
+			wantErr: entity.ErrInvalidTicketTier,
+		},
+		{
+			name: "Failed - Event Not Found",
+			tier: entity.TicketTier{EventID: 1, Name: "VIP", Price: 100, Quota: 10},
+			mock: func(tierRepo *mocks.MockTicketTierRepo, eventRepo *mocks.MockEventRepo) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(nil, errors.New("no rows")).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name: "Failed - Duplicate Name",
+			tier: entity.TicketTier{EventID: 1, Name: "VIP", Price: 100, Quota: 10},
+			mock: func(tierRepo *mocks.MockTicketTierRepo, eventRepo *mocks.MockEventRepo) {
+				eventRepo.On("GetEventByID", mock.Anything, int64(1)).Return(&entity.Event{ID: 1}, nil).Once()
+				tierRepo.On("CreateTier", mock.Anything, mock.AnythingOfType("*entity.TicketTier")).Return(entity.ErrTierNameTaken).Once()
+			},
+			wantErr: entity.ErrTierNameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tierRepo := new(mocks.MockTicketTierRepo)
+			eventRepo := new(mocks.MockEventRepo)
+			tt.mock(tierRepo, eventRepo)
+
+			uc := usecase.NewTicketTierUsecase(tierRepo, eventRepo, 2*time.Second)
+			tier := tt.tier
+			err := uc.CreateTier(context.Background(), &tier)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			tierRepo.AssertExpectations(t)
+			eventRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTicketTierUsecase_UpdateTier(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(tierRepo *mocks.MockTicketTierRepo)
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(tierRepo *mocks.MockTicketTierRepo) {
+				tierRepo.On("GetTierByID", mock.Anything, int64(3)).Return(&entity.TicketTier{ID: 3, EventID: 1}, nil).Once()
+				tierRepo.On("UpdateTier", mock.Anything, mock.AnythingOfType("*entity.TicketTier")).Return(nil).Once()
+			},
+		},
+		{
+			name: "Failed - Tier Of Another Event",
+			mock: func(tierRepo *mocks.MockTicketTierRepo) {
+				tierRepo.On("GetTierByID", mock.Anything, int64(3)).Return(&entity.TicketTier{ID: 3, EventID: 2}, nil).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name: "Failed - Quota Below Assigned Seats",
+			mock: func(tierRepo *mocks.MockTicketTierRepo) {
+				tierRepo.On("GetTierByID", mock.Anything, int64(3)).Return(&entity.TicketTier{ID: 3, EventID: 1}, nil).Once()
+				tierRepo.On("UpdateTier", mock.Anything, mock.AnythingOfType("*entity.TicketTier")).Return(entity.ErrTierQuotaExceeded).Once()
+			},
+			wantErr: entity.ErrTierQuotaExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tierRepo := new(mocks.MockTicketTierRepo)
+			tt.mock(tierRepo)
+
+			uc := usecase.NewTicketTierUsecase(tierRepo, new(mocks.MockEventRepo), 2*time.Second)
+			err := uc.UpdateTier(context.Background(), &entity.TicketTier{ID: 3, EventID: 1, Name: "VIP", Price: 500000, Quota: 50})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			tierRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTicketTierUsecase_AssignSeats(t *testing.T) {
+	tests := []struct {
+		name       string
+		assignment *entity.TierSeatAssignment
+		mock       func(tierRepo *mocks.MockTicketTierRepo)
+		wantMoved  int64
+		wantErr    error
+	}{
+		{
+			name:       "Success - By Section",
+			assignment: &entity.TierSeatAssignment{Section: "VIP"},
+			mock: func(tierRepo *mocks.MockTicketTierRepo) {
+				tierRepo.On("AssignSeats", mock.Anything, int64(3), int64(1), &entity.TierSeatAssignment{Section: "VIP"}).Return(int64(40), nil).Once()
+			},
+			wantMoved: 40,
+		},
+		{
+			name:       "Failed - Nothing Selected",
+			assignment: &entity.TierSeatAssignment{},
+			mock:       func(*mocks.MockTicketTierRepo) {},
+			wantErr:    entity.ErrInvalidTicketTier,
+		},
+		{
+			name:       "Failed - Quota Exceeded",
+			assignment: &entity.TierSeatAssignment{SeatIDs: []int64{1, 2, 3}},
+			mock: func(tierRepo *mocks.MockTicketTierRepo) {
+				tierRepo.On("AssignSeats", mock.Anything, int64(3), int64(1), mock.Anything).Return(int64(0), entity.ErrTierQuotaExceeded).Once()
+			},
+			wantErr: entity.ErrTierQuotaExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tierRepo := new(mocks.MockTicketTierRepo)
+			tt.mock(tierRepo)
+
+			uc := usecase.NewTicketTierUsecase(tierRepo, new(mocks.MockEventRepo), 2*time.Second)
+			moved, err := uc.AssignSeats(context.Background(), 1, 3, tt.assignment)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantMoved, moved)
+			}
+			tierRepo.AssertExpectations(t)
+		})
+	}
+}
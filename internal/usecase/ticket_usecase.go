@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type TicketUsecase interface {
+	// ResendTickets emails the current tickets of a PAID booking to its
+	// holder again.
+	ResendTickets(ctx context.Context, bookingID int64) error
+	// RegenerateTickets replaces the codes of a PAID booking's tickets, e.g.
+	// after they leaked, and emails the new ones to the holder. The old codes
+	// are rejected at check-in from then on.
+	RegenerateTickets(ctx context.Context, bookingID int64) ([]entity.Ticket, error)
+}
+
+// TicketSender emails tickets to a booking's holder.
+type TicketSender interface {
+	ReceiptSender
+	SendTicketsReissued(ctx context.Context, bookingID int64)
+}
+
+type ticketUsecase struct {
+	ticketRepo     repository.TicketRepository
+	bookingRepo    repository.BookingRepository
+	sender         TicketSender
+	auditor        AuditUsecase
+	contextTimeout time.Duration
+}
+
+func NewTicketUsecase(
+	ticketRepo repository.TicketRepository,
+	bookingRepo repository.BookingRepository,
+	sender TicketSender,
+	auditor AuditUsecase,
+	timeout time.Duration,
+) TicketUsecase {
+	return &ticketUsecase{
+		ticketRepo:     ticketRepo,
+		bookingRepo:    bookingRepo,
+		sender:         sender,
+		auditor:        auditor,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *ticketUsecase) ResendTickets(ctx context.Context, bookingID int64) error {
+	ctx, span := tracing.Start(ctx, "TicketUsecase.ResendTickets", attribute.Int64("booking_id", bookingID))
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	booking, err := uc.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+	if booking.Status != entity.BookingPaid {
+		return entity.ErrBookingNotPaid
+	}
+
+	uc.sender.SendPaymentReceipt(ctx, bookingID)
+
+	logger.FromContext(ctx).Info("usecase: tickets resent", logger.Int64("booking_id", bookingID))
+	return nil
+}
+
+func (uc *ticketUsecase) RegenerateTickets(ctx context.Context, bookingID int64) ([]entity.Ticket, error) {
+	ctx, span := tracing.Start(ctx, "TicketUsecase.RegenerateTickets", attribute.Int64("booking_id", bookingID))
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	tickets, err := uc.ticketRepo.RegenerateTickets(ctx, bookingID, ActorFromContext(ctx))
+	if err != nil {
+		logger.FromContext(ctx).Warn("usecase: ticket regeneration failed", logger.Int64("booking_id", bookingID), logger.Err(err))
+		return nil, err
+	}
+
+	uc.auditor.Record(ctx, ActionTicketsRegenerate, "booking", bookingID, map[string]interface{}{
+		"ticket_count": len(tickets),
+	})
+	uc.sender.SendTicketsReissued(ctx, bookingID)
+
+	logger.FromContext(ctx).Info("usecase: tickets regenerated",
+		logger.Int64("booking_id", bookingID),
+		logger.Int("ticket_count", len(tickets)),
+	)
+	return tickets, nil
+}
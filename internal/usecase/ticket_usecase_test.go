@@ -0,0 +1,86 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTicketUsecase_RegenerateTickets(t *testing.T) {
+	admin := int64(1)
+	ctx := usecase.WithActor(context.Background(), admin)
+
+	t.Run("Success", func(t *testing.T) {
+		mockTicketRepo := new(mocks.MockTicketRepo)
+		mockSender := new(mocks.MockNotificationService)
+		mockAudit := new(mocks.MockAuditUsecase)
+		tickets := []entity.Ticket{{ID: 1, BookingID: 5, Code: "TCK-new1"}, {ID: 2, BookingID: 5, Code: "TCK-new2"}}
+
+		mockTicketRepo.On("RegenerateTickets", mock.Anything, int64(5), &admin).Return(tickets, nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionTicketsRegenerate, "booking", int64(5), map[string]interface{}{"ticket_count": 2}).Once()
+		mockSender.On("SendTicketsReissued", mock.Anything, int64(5)).Once()
+
+		u := usecase.NewTicketUsecase(mockTicketRepo, new(mocks.MockBookingRepo), mockSender, mockAudit, time.Second*2)
+		got, err := u.RegenerateTickets(ctx, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tickets, got)
+		mockTicketRepo.AssertExpectations(t)
+		mockSender.AssertExpectations(t)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Booking Not Paid", func(t *testing.T) {
+		mockTicketRepo := new(mocks.MockTicketRepo)
+		mockSender := new(mocks.MockNotificationService)
+		mockTicketRepo.On("RegenerateTickets", mock.Anything, int64(5), &admin).Return(nil, entity.ErrBookingNotPaid).Once()
+
+		u := usecase.NewTicketUsecase(mockTicketRepo, new(mocks.MockBookingRepo), mockSender, new(mocks.MockAuditUsecase), time.Second*2)
+		got, err := u.RegenerateTickets(ctx, 5)
+
+		assert.ErrorIs(t, err, entity.ErrBookingNotPaid)
+		assert.Nil(t, got)
+		mockSender.AssertNotCalled(t, "SendTicketsReissued", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTicketUsecase_ResendTickets(t *testing.T) {
+	tests := []struct {
+		name    string
+		booking *entity.Booking
+		repoErr error
+		wantErr error
+	}{
+		{name: "Success", booking: &entity.Booking{ID: 5, Status: "PAID"}},
+		{name: "Failed - Booking Not Paid", booking: &entity.Booking{ID: 5, Status: "PENDING"}, wantErr: entity.ErrBookingNotPaid},
+		{name: "Failed - Booking Not Found", repoErr: entity.ErrNotFound, wantErr: entity.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBookingRepo := new(mocks.MockBookingRepo)
+			mockSender := new(mocks.MockNotificationService)
+			mockBookingRepo.On("GetBookingByID", mock.Anything, int64(5)).Return(tt.booking, tt.repoErr).Once()
+			if tt.wantErr == nil {
+				mockSender.On("SendPaymentReceipt", mock.Anything, int64(5)).Once()
+			}
+
+			u := usecase.NewTicketUsecase(new(mocks.MockTicketRepo), mockBookingRepo, mockSender, new(mocks.MockAuditUsecase), time.Second*2)
+			err := u.ResendTickets(context.Background(), 5)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockSender.AssertExpectations(t)
+		})
+	}
+}
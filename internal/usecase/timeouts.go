@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// usecaseTimeouts names the usecases whose timeout can be set, with the
+// built-in timeout of those that differ from the default. Reports and admin
+// jobs scan many rows; status checks must answer while a poller waits.
+var usecaseTimeouts = map[string]time.Duration{
+	"analytics":            30 * time.Second,
+	"api_key":              0,
+	"attendance":           30 * time.Second,
+	"audit":                0,
+	"backfill":             time.Minute,
+	"bank_account":         0,
+	"booking":              0,
+	"booking_modification": 0,
+	"checkin":              0,
+	"event":                0,
+	"event_staff":          0,
+	"experiment":           0,
+	"forecast":             30 * time.Second,
+	"funnel":               0,
+	"gate":                 0,
+	"inventory":            30 * time.Second,
+	"invoice":              0,
+	"job":                  0,
+	"notification":         0,
+	"organizer":            0,
+	"payment":              0,
+	"refund":               30 * time.Second,
+	"sales_goal":           0,
+	"section_image":        0,
+	"status":               2 * time.Second,
+	"ticket":               0,
+	"ticket_tier":          0,
+	"upgrade_offer":        0,
+	"user":                 0,
+	"webhook":              0,
+}
+
+// Timeouts is how long each usecase may take per call.
+type Timeouts struct {
+	def       time.Duration
+	overrides map[string]time.Duration
+}
+
+// LoadTimeouts parses overrides of the form "refund=1m,status=1s" on top of
+// the built-in timeouts. Usecases without one get def.
+func LoadTimeouts(def time.Duration, raw string) (Timeouts, error) {
+	t := Timeouts{def: def, overrides: map[string]time.Duration{}}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			return Timeouts{}, fmt.Errorf("invalid usecase timeout %q: want name=duration", part)
+		}
+		if _, known := usecaseTimeouts[name]; !known {
+			return Timeouts{}, fmt.Errorf("invalid usecase timeout %q: unknown usecase, want one of %s", part, strings.Join(timeoutNames(), ", "))
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return Timeouts{}, fmt.Errorf("invalid usecase timeout %q: want a positive duration", part)
+		}
+		t.overrides[name] = d
+	}
+	return t, nil
+}
+
+// For returns the timeout of the named usecase.
+func (t Timeouts) For(name string) time.Duration {
+	if d, ok := t.overrides[name]; ok {
+		return d
+	}
+	if d := usecaseTimeouts[name]; d > 0 {
+		return d
+	}
+	return t.def
+}
+
+func timeoutNames() []string {
+	names := make([]string, 0, len(usecaseTimeouts))
+	for name := range usecaseTimeouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
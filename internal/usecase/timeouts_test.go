@@ -0,0 +1,33 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"ticres/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTimeouts(t *testing.T) {
+	timeouts, err := usecase.LoadTimeouts(5*time.Second, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, timeouts.For("booking"))
+	assert.Equal(t, 30*time.Second, timeouts.For("refund"))
+	assert.Equal(t, 2*time.Second, timeouts.For("status"))
+
+	timeouts, err = usecase.LoadTimeouts(3*time.Second, "refund=2m, booking=8s")
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, timeouts.For("refund"))
+	assert.Equal(t, 8*time.Second, timeouts.For("booking"))
+	assert.Equal(t, 3*time.Second, timeouts.For("event"))
+
+	_, err = usecase.LoadTimeouts(5*time.Second, "reports=1m")
+	assert.Error(t, err)
+
+	_, err = usecase.LoadTimeouts(5*time.Second, "refund=soon")
+	assert.Error(t, err)
+
+	_, err = usecase.LoadTimeouts(5*time.Second, "refund")
+	assert.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/clock"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// Unsold premium seats are offered as upgrades once the event is less
+	// than upgradeOfferWindow away. Each offer stays open for upgradeOfferTTL
+	// or until the event starts, whichever comes first.
+	upgradeOfferWindow = 72 * time.Hour
+	upgradeOfferTTL    = 24 * time.Hour
+
+	// upgradeOfferBatchSize caps the offers sent per run.
+	upgradeOfferBatchSize = 200
+)
+
+// UpgradeOfferSender emails upgrade offers and the reissued tickets after one
+// is accepted.
+type UpgradeOfferSender interface {
+	ReceiptSender
+	SendUpgradeOffer(ctx context.Context, offerID int64, email, acceptLink string)
+}
+
+type UpgradeOfferUsecase interface {
+	// SendUpgradeOffers offers unsold premium seats of upcoming events to
+	// existing ticket holders and returns how many offers were sent.
+	SendUpgradeOffers(ctx context.Context) (int, error)
+	GetOffer(ctx context.Context, token string) (*entity.UpgradeOffer, error)
+	// AcceptOffer swaps the seat and charges the difference. Without a
+	// payment method the one used for the original booking is charged.
+	AcceptOffer(ctx context.Context, token, paymentMethod string) (*entity.BookingModification, error)
+}
+
+type upgradeOfferUsecase struct {
+	offerRepo       repository.UpgradeOfferRepository
+	transactionRepo repository.TransactionRepository
+	ticketRepo      repository.TicketRepository
+	sender          UpgradeOfferSender
+	acceptURL       string
+	clock           clock.Clock
+	contextTimeout  time.Duration
+}
+
+func NewUpgradeOfferUsecase(
+	offerRepo repository.UpgradeOfferRepository,
+	transactionRepo repository.TransactionRepository,
+	ticketRepo repository.TicketRepository,
+	sender UpgradeOfferSender,
+	acceptURL string,
+	clk clock.Clock,
+	timeout time.Duration,
+) UpgradeOfferUsecase {
+	return &upgradeOfferUsecase{
+		offerRepo:       offerRepo,
+		transactionRepo: transactionRepo,
+		ticketRepo:      ticketRepo,
+		sender:          sender,
+		acceptURL:       acceptURL,
+		clock:           clk,
+		contextTimeout:  timeout,
+	}
+}
+
+func (uc *upgradeOfferUsecase) SendUpgradeOffers(ctx context.Context) (int, error) {
+	// Free up premium seats whose previous offer lapsed
+	if _, err := uc.offerRepo.ExpireOffers(ctx); err != nil {
+		return 0, err
+	}
+
+	candidates, err := uc.offerRepo.GetUpgradeCandidates(ctx, upgradeOfferWindow, upgradeOfferBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+	for _, c := range candidates {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return sent, err
+		}
+		token := hex.EncodeToString(buf)
+
+		expiresAt := uc.clock.Now().Add(upgradeOfferTTL)
+		if c.EventDate.Before(expiresAt) {
+			expiresAt = c.EventDate
+		}
+
+		offer := &entity.UpgradeOffer{
+			BookingID:       c.BookingID,
+			FromSeatID:      c.FromSeatID,
+			ToSeatID:        c.ToSeatID,
+			PriceDifference: c.PriceDifference,
+			ExpiresAt:       expiresAt,
+		}
+		if err := uc.offerRepo.CreateOffer(ctx, offer, hashToken(token)); err != nil {
+			// Most likely a concurrent run took the seat or ticket first
+			logger.FromContext(ctx).Warn("usecase: skipping upgrade offer", logger.Int64("booking_id", c.BookingID), logger.Err(err))
+			continue
+		}
+
+		uc.sender.SendUpgradeOffer(ctx, offer.ID, c.UserEmail, uc.acceptURL+"?token="+token)
+		sent++
+
+		logger.FromContext(ctx).Info("usecase: upgrade offer sent",
+			logger.Int64("offer_id", offer.ID),
+			logger.Int64("booking_id", c.BookingID),
+			logger.Int64("to_seat_id", c.ToSeatID),
+			logger.Float64("price_difference", c.PriceDifference),
+		)
+	}
+
+	return sent, nil
+}
+
+func (uc *upgradeOfferUsecase) GetOffer(ctx context.Context, token string) (*entity.UpgradeOffer, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	offer, err := uc.offerRepo.GetOfferByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	// Lapsed offers are only marked on the next scheduler run
+	if offer.Status == entity.UpgradeOfferPending && !offer.ExpiresAt.After(uc.clock.Now()) {
+		offer.Status = entity.UpgradeOfferExpired
+	}
+	return offer, nil
+}
+
+func (uc *upgradeOfferUsecase) AcceptOffer(ctx context.Context, token, paymentMethod string) (*entity.BookingModification, error) {
+	ctx, span := tracing.Start(ctx, "UpgradeOfferUsecase.AcceptOffer")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	tokenHash := hashToken(token)
+	offer, err := uc.offerRepo.GetOfferByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int64("booking_id", offer.BookingID), attribute.Int64("offer_id", offer.ID))
+
+	// One click: charge the method the tickets were originally paid with
+	if paymentMethod == "" {
+		txn, err := uc.transactionRepo.GetTransactionByBookingID(ctx, offer.BookingID)
+		if err != nil {
+			logger.FromContext(ctx).Warn("usecase: no original payment for upgrade offer", logger.Int64("booking_id", offer.BookingID), logger.Err(err))
+			return nil, entity.ErrInvalidPaymentMethod
+		}
+		paymentMethod = txn.PaymentMethod
+	}
+	methodCode, ok := validPaymentMethods[paymentMethod]
+	if !ok {
+		return nil, entity.ErrInvalidPaymentMethod
+	}
+
+	mod := &entity.BookingModification{
+		PaymentMethod: paymentMethod,
+		ExternalID:    seatChangeExternalID(methodCode, offer.BookingID),
+	}
+	if _, err := uc.offerRepo.AcceptOffer(ctx, tokenHash, mod); err != nil {
+		logger.FromContext(ctx).Warn("usecase: upgrade offer not accepted", logger.Int64("offer_id", offer.ID), logger.Err(err))
+		return nil, err
+	}
+
+	tickets, err := uc.ticketRepo.GetTicketsByBookingID(ctx, offer.BookingID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("usecase: failed to load reissued tickets", logger.Int64("booking_id", offer.BookingID), logger.Err(err))
+	}
+	mod.Tickets = tickets
+
+	uc.sender.SendPaymentReceipt(ctx, offer.BookingID)
+
+	logger.FromContext(ctx).Info("usecase: upgrade offer accepted",
+		logger.Int64("offer_id", offer.ID),
+		logger.Int64("booking_id", offer.BookingID),
+		logger.Float64("amount_charged", mod.AmountCharged),
+	)
+	return mod, nil
+}
@@ -0,0 +1,171 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpgradeOfferUsecase_SendUpgradeOffers(t *testing.T) {
+	offerRepo := new(mocks.MockUpgradeOfferRepo)
+	notif := new(mocks.MockNotificationService)
+
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	soon := clk.Now().Add(3 * time.Hour)
+	candidates := []entity.UpgradeCandidate{
+		{BookingID: 1, UserEmail: "a@example.com", EventID: 10, EventDate: soon, FromSeatID: 101, ToSeatID: 201, PriceDifference: 50000},
+		{BookingID: 2, UserEmail: "b@example.com", EventID: 10, EventDate: soon, FromSeatID: 102, ToSeatID: 202, PriceDifference: 50000},
+	}
+
+	offerRepo.On("ExpireOffers", mock.Anything).Return(int64(0), nil).Once()
+	offerRepo.On("GetUpgradeCandidates", mock.Anything, mock.Anything, mock.Anything).Return(candidates, nil).Once()
+	offerRepo.On("CreateOffer", mock.Anything, mock.MatchedBy(func(o *entity.UpgradeOffer) bool {
+		return o.BookingID == 1
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		offer := args.Get(1).(*entity.UpgradeOffer)
+		// Offers close when the event starts
+		assert.True(t, offer.ExpiresAt.Equal(soon))
+		assert.Len(t, args.String(2), 64)
+		offer.ID = 7
+	}).Return(nil).Once()
+	offerRepo.On("CreateOffer", mock.Anything, mock.MatchedBy(func(o *entity.UpgradeOffer) bool {
+		return o.BookingID == 2
+	}), mock.Anything).Return(errors.New("duplicate key")).Once()
+	notif.On("SendUpgradeOffer", mock.Anything, int64(7), "a@example.com", mock.MatchedBy(func(link string) bool {
+		return strings.HasPrefix(link, "http://localhost:3000/upgrade-offers?token=")
+	})).Once()
+
+	uc := usecase.NewUpgradeOfferUsecase(offerRepo, new(mocks.MockTransactionRepo), new(mocks.MockTicketRepo), notif, "http://localhost:3000/upgrade-offers", clk, 2*time.Second)
+	sent, err := uc.SendUpgradeOffers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	offerRepo.AssertExpectations(t)
+	notif.AssertExpectations(t)
+}
+
+func TestUpgradeOfferUsecase_GetOffer_Lapsed(t *testing.T) {
+	sum := sha256.Sum256([]byte("tok"))
+	tokenHash := hex.EncodeToString(sum[:])
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	deadline := clk.Now().Add(time.Hour)
+
+	offerRepo := new(mocks.MockUpgradeOfferRepo)
+	offerRepo.On("GetOfferByTokenHash", mock.Anything, tokenHash).
+		Return(&entity.UpgradeOffer{ID: 7, Status: entity.UpgradeOfferPending, ExpiresAt: deadline}, nil).Once()
+	offerRepo.On("GetOfferByTokenHash", mock.Anything, tokenHash).
+		Return(&entity.UpgradeOffer{ID: 7, Status: entity.UpgradeOfferPending, ExpiresAt: deadline}, nil).Once()
+
+	uc := usecase.NewUpgradeOfferUsecase(offerRepo, new(mocks.MockTransactionRepo), new(mocks.MockTicketRepo), new(mocks.MockNotificationService), "http://localhost:3000/upgrade-offers", clk, 2*time.Second)
+
+	offer, err := uc.GetOffer(context.Background(), "tok")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.UpgradeOfferPending, offer.Status)
+
+	// Shown as expired from its deadline on, before the scheduler marks it
+	clk.Advance(time.Hour)
+	offer, err = uc.GetOffer(context.Background(), "tok")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.UpgradeOfferExpired, offer.Status)
+	offerRepo.AssertExpectations(t)
+}
+
+func TestUpgradeOfferUsecase_AcceptOffer(t *testing.T) {
+	sum := sha256.Sum256([]byte("tok"))
+	tokenHash := hex.EncodeToString(sum[:])
+	offer := &entity.UpgradeOffer{ID: 7, BookingID: 1, FromSeatID: 101, ToSeatID: 201, Status: entity.UpgradeOfferPending}
+
+	tests := []struct {
+		name          string
+		paymentMethod string
+		mock          func(offerRepo *mocks.MockUpgradeOfferRepo, txnRepo *mocks.MockTransactionRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService)
+		wantErr       error
+	}{
+		{
+			name: "Success - Charges Original Payment Method",
+			mock: func(offerRepo *mocks.MockUpgradeOfferRepo, txnRepo *mocks.MockTransactionRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService) {
+				offerRepo.On("GetOfferByTokenHash", mock.Anything, tokenHash).Return(offer, nil).Once()
+				txnRepo.On("GetTransactionByBookingID", mock.Anything, int64(1)).
+					Return(&entity.Transaction{BookingID: 1, PaymentMethod: "bank_transfer"}, nil).Once()
+				offerRepo.On("AcceptOffer", mock.Anything, tokenHash, mock.MatchedBy(func(m *entity.BookingModification) bool {
+					return m.PaymentMethod == "bank_transfer" && strings.HasPrefix(m.ExternalID, "MOD-BT-1-")
+				})).Return(offer, nil).Once()
+				ticketRepo.On("GetTicketsByBookingID", mock.Anything, int64(1)).Return([]entity.Ticket{{SeatID: 201}}, nil).Once()
+				notif.On("SendPaymentReceipt", mock.Anything, int64(1)).Once()
+			},
+		},
+		{
+			name:          "Success - Explicit Payment Method",
+			paymentMethod: "e_wallet",
+			mock: func(offerRepo *mocks.MockUpgradeOfferRepo, txnRepo *mocks.MockTransactionRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService) {
+				offerRepo.On("GetOfferByTokenHash", mock.Anything, tokenHash).Return(offer, nil).Once()
+				offerRepo.On("AcceptOffer", mock.Anything, tokenHash, mock.MatchedBy(func(m *entity.BookingModification) bool {
+					return m.PaymentMethod == "e_wallet"
+				})).Return(offer, nil).Once()
+				ticketRepo.On("GetTicketsByBookingID", mock.Anything, int64(1)).Return([]entity.Ticket{}, nil).Once()
+				notif.On("SendPaymentReceipt", mock.Anything, int64(1)).Once()
+			},
+		},
+		{
+			name: "Failed - Unknown Token",
+			mock: func(offerRepo *mocks.MockUpgradeOfferRepo, txnRepo *mocks.MockTransactionRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService) {
+				offerRepo.On("GetOfferByTokenHash", mock.Anything, tokenHash).Return(nil, entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name:          "Failed - Invalid Payment Method",
+			paymentMethod: "cash",
+			mock: func(offerRepo *mocks.MockUpgradeOfferRepo, txnRepo *mocks.MockTransactionRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService) {
+				offerRepo.On("GetOfferByTokenHash", mock.Anything, tokenHash).Return(offer, nil).Once()
+			},
+			wantErr: entity.ErrInvalidPaymentMethod,
+		},
+		{
+			name:          "Failed - Offer Closed",
+			paymentMethod: "credit_card",
+			mock: func(offerRepo *mocks.MockUpgradeOfferRepo, txnRepo *mocks.MockTransactionRepo, ticketRepo *mocks.MockTicketRepo, notif *mocks.MockNotificationService) {
+				offerRepo.On("GetOfferByTokenHash", mock.Anything, tokenHash).Return(offer, nil).Once()
+				offerRepo.On("AcceptOffer", mock.Anything, tokenHash, mock.Anything).Return(nil, entity.ErrUpgradeOfferClosed).Once()
+			},
+			wantErr: entity.ErrUpgradeOfferClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offerRepo := new(mocks.MockUpgradeOfferRepo)
+			txnRepo := new(mocks.MockTransactionRepo)
+			ticketRepo := new(mocks.MockTicketRepo)
+			notif := new(mocks.MockNotificationService)
+			tt.mock(offerRepo, txnRepo, ticketRepo, notif)
+
+			uc := usecase.NewUpgradeOfferUsecase(offerRepo, txnRepo, ticketRepo, notif, "http://localhost:3000/upgrade-offers", clock.Real{}, 2*time.Second)
+			mod, err := uc.AcceptOffer(context.Background(), "tok", tt.paymentMethod)
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				assert.Nil(t, mod)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, mod)
+			}
+			offerRepo.AssertExpectations(t)
+			txnRepo.AssertExpectations(t)
+			ticketRepo.AssertExpectations(t)
+			notif.AssertExpectations(t)
+		})
+	}
+}
@@ -2,12 +2,26 @@ package usecase
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"ticres/internal/entity"
 	"ticres/internal/repository"
+	"ticres/pkg/clock"
 	"ticres/pkg/logger"
+	"ticres/pkg/oauth"
+	"ticres/pkg/totp"
+	"ticres/pkg/tracing"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
@@ -15,8 +29,123 @@ import (
 
 type UserUsecase interface {
 	Register(ctx context.Context, user *entity.User) error
-	Login(ctx context.Context, email string, password string) (string, error)
+	// Login checks the user's password and returns a token, or a challenge
+	// to complete with CompleteLogin when the user has 2FA enabled.
+	Login(ctx context.Context, email string, password string) (*entity.LoginResult, error)
+	// CompleteLogin returns a token once code, from the user's
+	// authenticator app or one of their recovery codes, checks out for the
+	// login challenge.
+	CompleteLogin(ctx context.Context, challengeToken, code string) (string, error)
 	GetProfile(ctx context.Context, userID int) (*entity.User, error)
+	UpdateRole(ctx context.Context, userID int64, role string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	// UpdateProfile sets the user's name and username; an empty username
+	// removes it.
+	UpdateProfile(ctx context.Context, userID int64, name, username string) (*entity.User, error)
+	// ChangePassword sets a new password once the current one checks out,
+	// and signs the user out of every session but sessionID, the one making
+	// the change.
+	ChangePassword(ctx context.Context, userID, sessionID int64, currentPassword, newPassword string) error
+	// DeleteAccount wipes the personal data of the user's account once their
+	// password checks out. Bookings, payments and invoices are kept for the
+	// books, under an anonymous name.
+	DeleteAccount(ctx context.Context, userID int64, password string) error
+	// RequestDataExport returns the user's latest data export, with its
+	// archive once READY, and queues a new one when there is none or the
+	// last is stale or failed.
+	RequestDataExport(ctx context.Context, userID int64) (*entity.UserDataExport, error)
+	// EnableTwoFactor starts setting 2FA up with a new secret for the user's
+	// authenticator app. 2FA is not checked on login until VerifyTwoFactor.
+	EnableTwoFactor(ctx context.Context, userID int64) (*entity.TwoFactorSetup, error)
+	// VerifyTwoFactor turns 2FA on once code, from the authenticator app,
+	// checks out against the new secret, and returns the user's recovery
+	// codes. They are only ever shown this once.
+	VerifyTwoFactor(ctx context.Context, userID int64, code string) ([]string, error)
+	// OAuthURL returns where to send the user to sign in with provider.
+	OAuthURL(ctx context.Context, provider string) (string, error)
+	// OAuthLogin signs the user in with the code the provider sent them
+	// back with. A new user gets an account; an existing one, found by
+	// their verified email, is linked to the provider. Users with 2FA get a
+	// challenge like Login.
+	OAuthLogin(ctx context.Context, provider, code, state string) (*entity.LoginResult, error)
+	// ListSessions returns the devices the user is signed in on, most
+	// recently used first.
+	ListSessions(ctx context.Context, userID int64) ([]entity.Session, error)
+	// RevokeSession signs the user out on one of their devices. Returns
+	// ErrNotFound if the user has no such active session.
+	RevokeSession(ctx context.Context, userID, sessionID int64) error
+	// AuthenticateSession checks that the session a token was issued for is
+	// still active and records that it was used. Returns ErrSessionRevoked
+	// if it was revoked or has expired.
+	AuthenticateSession(ctx context.Context, userID, sessionID int64) error
+}
+
+// PasswordResetSender emails the reset link to the user.
+type PasswordResetSender interface {
+	SendPasswordReset(ctx context.Context, email, resetLink string)
+}
+
+// UserDataExporter builds personal data exports in the background.
+type UserDataExporter interface {
+	// EnqueueUserExport queues the export's job as part of the unit of work
+	// in ctx, so it is queued only if the export is stored.
+	EnqueueUserExport(ctx context.Context, exportID int64) error
+}
+
+// TokenSigner signs the access tokens users authenticate their requests
+// with.
+type TokenSigner interface {
+	Sign(claims jwt.MapClaims) (string, error)
+}
+
+// TwoFactorCipher encrypts TOTP secrets before they are stored.
+type TwoFactorCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+// passwordResetTTL is how long an emailed reset link stays valid.
+const passwordResetTTL = 30 * time.Minute
+
+// sessionTouchInterval is how stale a session's last seen time may get
+// before a request updates it, so busy clients don't write on every call.
+const sessionTouchInterval = time.Minute
+
+// maxUserAgentLength caps the user agent stored with a session.
+const maxUserAgentLength = 512
+
+// userExportMaxAge is how long a READY data export is handed out before a
+// request builds a fresh one with the user's latest bookings.
+const userExportMaxAge = 24 * time.Hour
+
+// undeletableRoles hold records other users depend on, such as events and
+// payouts; the role must be revoked before the account can be deleted.
+var undeletableRoles = map[string]bool{
+	"organizer": true,
+	"admin":     true,
+}
+
+const (
+	// twoFactorIssuer names the account in authenticator apps.
+	twoFactorIssuer = "TicRes"
+	// loginChallengeTTL is how long a user has to enter their 2FA code
+	// after their password checked out.
+	loginChallengeTTL = 5 * time.Minute
+	// maxTwoFactorFailures is how many wrong codes lock 2FA logins for a
+	// while, so six digit codes can't be guessed.
+	maxTwoFactorFailures = 5
+	// recoveryCodeCount is how many recovery codes enabling 2FA issues.
+	recoveryCodeCount = 10
+	// oauthStateTTL is how long a user has to sign in at the provider.
+	oauthStateTTL = 10 * time.Minute
+)
+
+var validRoles = map[string]bool{
+	"user":      true,
+	"staff":     true,
+	"organizer": true,
+	"admin":     true,
 }
 
 // 2. Struct Implementasi
@@ -24,28 +153,51 @@ type userUsecase struct {
 	userRepo       repository.UserRepository
 	contextTimeout time.Duration
 	jwtSecret		string
+	tokens         TokenSigner
 	jwtExp			int	
+	auditor        AuditUsecase
+	resetSender    PasswordResetSender
+	resetURL       string
+	exportRepo     repository.UserExportRepository
+	txManager      repository.TxManager
+	exporter       UserDataExporter
+	totpCipher     TwoFactorCipher
+	clock          clock.Clock
+	oauthProviders map[string]oauth.Provider
 }
 
 // Constructor
-func NewUserUsecase(u repository.UserRepository, timeout time.Duration, jwtSecret string, jwtExp int) UserUsecase {
+func NewUserUsecase(u repository.UserRepository, timeout time.Duration, jwtSecret string, tokens TokenSigner, jwtExp int, auditor AuditUsecase, resetSender PasswordResetSender, resetURL string, exportRepo repository.UserExportRepository, txManager repository.TxManager, exporter UserDataExporter, totpCipher TwoFactorCipher, clk clock.Clock, oauthProviders map[string]oauth.Provider) UserUsecase {
 	return &userUsecase{
 		userRepo:       u,
 		contextTimeout: timeout,
 		jwtSecret: jwtSecret,
+		tokens:         tokens,
 		jwtExp: jwtExp,
+		auditor: auditor,
+		resetSender: resetSender,
+		resetURL: resetURL,
+		exportRepo:     exportRepo,
+		txManager:      txManager,
+		exporter:       exporter,
+		totpCipher:     totpCipher,
+		clock:          clk,
+		oauthProviders: oauthProviders,
 	}
 }
 
 func (uc *userUsecase) Register(ctx context.Context, user *entity.User) error {
-	logger.Debug("registering new user", logger.String("email", user.Email))
+	ctx, span := tracing.Start(ctx, "UserUsecase.Register")
+	defer span.End()
+
+	logger.FromContext(ctx).Debug("registering new user", logger.String("email", user.Email))
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
-		logger.Error("failed to hash password", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to hash password", logger.Err(err))
 		return err
 	}
 
@@ -53,73 +205,686 @@ func (uc *userUsecase) Register(ctx context.Context, user *entity.User) error {
 
 	err = uc.userRepo.CreateUser(ctx, user)
 	if err != nil {
-		logger.Error("failed to create user",
+		logger.FromContext(ctx).Error("failed to create user",
 			logger.String("email", user.Email),
 			logger.Err(err),
 		)
 		return err
 	}
 
-	logger.Info("user registered successfully",
+	logger.FromContext(ctx).Info("user registered successfully",
 		logger.Int64("user_id", user.ID),
 		logger.String("email", user.Email),
 	)
 	return nil
 }
 
-func (uc *userUsecase) Login(ctx context.Context, email, password string) (string, error) {
-	logger.Debug("user login attempt", logger.String("email", email))
+func (uc *userUsecase) Login(ctx context.Context, email, password string) (*entity.LoginResult, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.Login")
+	defer span.End()
+
+	logger.FromContext(ctx).Debug("user login attempt", logger.String("email", email))
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
 	user, err := uc.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
-		logger.Warn("login failed: user not found", logger.String("email", email))
-		return "", entity.ErrInternalServer
+		logger.FromContext(ctx).Warn("login failed: user not found", logger.String("email", email))
+		return nil, entity.ErrInternalServer
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		logger.Warn("login failed: invalid password", logger.String("email", email))
-		return "", errors.New("invalid email or password")
+		logger.FromContext(ctx).Warn("login failed: invalid password", logger.String("email", email))
+		return nil, errors.New("invalid email or password")
+	}
+
+	return uc.loginResult(ctx, user)
+}
+
+// loginResult signs in a user whose first factor checked out: with a token,
+// or a challenge when they have 2FA enabled.
+func (uc *userUsecase) loginResult(ctx context.Context, user *entity.User) (*entity.LoginResult, error) {
+	if user.TwoFactorEnabled {
+		expiresAt := uc.clock.Now().Add(loginChallengeTTL)
+		logger.FromContext(ctx).Info("login awaiting two-factor code", logger.Int64("user_id", user.ID))
+		return &entity.LoginResult{
+			TwoFactorRequired: true,
+			ChallengeToken:    uc.signLoginChallenge(user.ID, expiresAt),
+			ChallengeExpires:  &expiresAt,
+		}, nil
+	}
+
+	signedToken, err := uc.issueToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.LoginResult{Token: signedToken}, nil
+}
+
+func (uc *userUsecase) CompleteLogin(ctx context.Context, challengeToken, code string) (string, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.CompleteLogin")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	userID, err := uc.verifyLoginChallenge(challengeToken)
+	if err != nil {
+		return "", err
+	}
+
+	tf, err := uc.userRepo.GetTwoFactor(ctx, userID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return "", entity.ErrInvalidLoginChallenge
+	}
+	if err != nil {
+		return "", err
+	}
+	if !tf.Enabled {
+		return "", entity.ErrInvalidLoginChallenge
+	}
+	if tf.RecentFailures >= maxTwoFactorFailures {
+		logger.FromContext(ctx).Warn("two-factor login locked: too many wrong codes", logger.Int64("user_id", userID))
+		return "", entity.ErrTooManyTwoFactorAttempts
+	}
+
+	if err := uc.checkSecondFactor(ctx, userID, tf, code); err != nil {
+		if errors.Is(err, entity.ErrInvalidTwoFactorCode) {
+			logger.FromContext(ctx).Warn("login failed: invalid two-factor code", logger.Int64("user_id", userID))
+			uc.userRepo.RecordTwoFactorFailure(ctx, userID)
+		}
+		return "", err
+	}
+
+	user, err := uc.userRepo.GetUserByID(ctx, int(userID))
+	if err != nil {
+		return "", entity.ErrInvalidLoginChallenge
+	}
+	return uc.issueToken(ctx, user)
+}
+
+// checkSecondFactor accepts a code from the user's authenticator app, each
+// at most once, or one of their unused recovery codes.
+func (uc *userUsecase) checkSecondFactor(ctx context.Context, userID int64, tf *entity.TwoFactor, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) != totp.Digits {
+		if err := uc.userRepo.UseRecoveryCode(ctx, userID, hashToken(normalizeRecoveryCode(code))); err != nil {
+			return err
+		}
+		uc.auditor.Record(ctx, ActionUserRecoveryCodeUse, "user", userID, nil)
+		return nil
+	}
+
+	secret, err := uc.totpCipher.Decrypt(tf.Secret)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to decrypt two-factor secret", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+	step, ok := totp.Validate(secret, code, uc.clock.Now())
+	if !ok {
+		return entity.ErrInvalidTwoFactorCode
+	}
+	return uc.userRepo.UseTwoFactorStep(ctx, userID, step)
+}
+
+// issueToken opens a session for the client signing in and signs the JWT
+// the user authenticates their requests with, bound to that session.
+func (uc *userUsecase) issueToken(ctx context.Context, user *entity.User) (string, error) {
+	expiresAt := uc.clock.Now().Add(time.Duration(uc.jwtExp) * time.Hour)
+	userAgent, ip := ClientFromContext(ctx)
+	if utf8.RuneCountInString(userAgent) > maxUserAgentLength {
+		userAgent = string([]rune(userAgent)[:maxUserAgentLength])
+	}
+	session := &entity.Session{UserID: user.ID, UserAgent: userAgent, IPAddress: ip, ExpiresAt: expiresAt}
+	if err := uc.userRepo.CreateSession(ctx, session); err != nil {
+		return "", err
 	}
 
 	claims := jwt.MapClaims{
 		"user_id": user.ID,
 		"email":   user.Email,
 		"role":    user.Role,
-		"exp":     time.Now().Add(time.Duration(uc.jwtExp) * time.Hour).Unix(),
+		"sid":     session.ID,
+		"exp":     expiresAt.Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	signedToken, err := token.SignedString([]byte(uc.jwtSecret))
+	signedToken, err := uc.tokens.Sign(claims)
 	if err != nil {
-		logger.Error("failed to sign JWT token", logger.Err(err))
+		logger.FromContext(ctx).Error("failed to sign JWT token", logger.Err(err))
 		return "", err
 	}
 
-	logger.Info("user logged in successfully",
+	logger.FromContext(ctx).Info("user logged in successfully",
 		logger.Int64("user_id", user.ID),
-		logger.String("email", email),
+		logger.String("email", user.Email),
 		logger.String("role", user.Role),
 	)
 	return signedToken, nil
 }
 
 func (uc *userUsecase) GetProfile(ctx context.Context, userID int) (*entity.User, error) {
-	logger.Debug("fetching user profile", logger.Int("user_id", userID))
+	logger.FromContext(ctx).Debug("fetching user profile", logger.Int("user_id", userID))
 
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
 	user, err := uc.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
-		logger.Warn("failed to get user profile", logger.Int("user_id", userID), logger.Err(err))
+		logger.FromContext(ctx).Warn("failed to get user profile", logger.Int("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Debug("user profile fetched", logger.Int("user_id", userID))
+	return user, nil
+}
+
+func (uc *userUsecase) UpdateRole(ctx context.Context, userID int64, role string) error {
+	logger.FromContext(ctx).Info("updating user role", logger.Int64("user_id", userID), logger.String("role", role))
+
+	if !validRoles[role] {
+		return entity.ErrInvalidRole
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	user, err := uc.userRepo.GetUserByID(ctx, int(userID))
+	if err != nil {
+		return entity.ErrNotFound
+	}
+
+	if err := uc.userRepo.UpdateUserRole(ctx, userID, role); err != nil {
+		logger.FromContext(ctx).Error("failed to update user role", logger.Int64("user_id", userID), logger.Err(err))
+		return err
+	}
+
+	uc.auditor.RecordChange(ctx, ActionUserRoleGrant, "user", userID,
+		map[string]interface{}{"role": user.Role},
+		map[string]interface{}{"role": role},
+	)
+
+	logger.FromContext(ctx).Info("user role updated", logger.Int64("user_id", userID), logger.String("role", role))
+	return nil
+}
+
+// ForgotPassword emails a single-use reset link. It succeeds whether or not the
+// email is registered so the endpoint can't be used to discover accounts.
+func (uc *userUsecase) ForgotPassword(ctx context.Context, email string) error {
+	logger.FromContext(ctx).Debug("password reset requested", logger.String("email", email))
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	user, err := uc.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		logger.FromContext(ctx).Warn("password reset for unknown email", logger.String("email", email))
+		return nil
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		logger.FromContext(ctx).Error("failed to generate reset token", logger.Err(err))
+		return err
+	}
+	token := hex.EncodeToString(buf)
+
+	// Only the hash is stored, so a leaked table can't be used to reset passwords
+	if err := uc.userRepo.CreatePasswordResetToken(ctx, user.ID, hashToken(token), uc.clock.Now().Add(passwordResetTTL)); err != nil {
+		logger.FromContext(ctx).Error("failed to store reset token", logger.Int64("user_id", user.ID), logger.Err(err))
+		return err
+	}
+
+	uc.resetSender.SendPasswordReset(ctx, user.Email, uc.resetURL+"?token="+token)
+
+	logger.FromContext(ctx).Info("password reset email queued", logger.Int64("user_id", user.ID))
+	return nil
+}
+
+func (uc *userUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	logger.FromContext(ctx).Debug("resetting password")
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to hash password", logger.Err(err))
+		return err
+	}
+
+	userID, err := uc.userRepo.ResetPassword(ctx, hashToken(token), string(hashedPassword))
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("password reset successfully", logger.Int64("user_id", userID))
+	return nil
+}
+
+func (uc *userUsecase) UpdateProfile(ctx context.Context, userID int64, name, username string) (*entity.User, error) {
+	logger.FromContext(ctx).Debug("updating user profile", logger.Int64("user_id", userID))
+
+	user := &entity.User{ID: userID, Name: name, UserName: username}
+	if err := user.ValidateProfile(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.userRepo.UpdateProfile(ctx, user); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("user profile updated", logger.Int64("user_id", userID))
+	return user, nil
+}
+
+func (uc *userUsecase) ChangePassword(ctx context.Context, userID, sessionID int64, currentPassword, newPassword string) error {
+	ctx, span := tracing.Start(ctx, "UserUsecase.ChangePassword")
+	defer span.End()
+
+	logger.FromContext(ctx).Debug("changing password", logger.Int64("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	user, err := uc.userRepo.GetUserByID(ctx, int(userID))
+	if err != nil {
+		return entity.ErrNotFound
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		logger.FromContext(ctx).Warn("password change failed: wrong current password", logger.Int64("user_id", userID))
+		return entity.ErrWrongPassword
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to hash password", logger.Err(err))
+		return err
+	}
+	if err := uc.userRepo.UpdatePassword(ctx, userID, sessionID, string(hashedPassword)); err != nil {
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionUserPasswordChange, "user", userID, nil)
+
+	logger.FromContext(ctx).Info("password changed", logger.Int64("user_id", userID))
+	return nil
+}
+
+func (uc *userUsecase) DeleteAccount(ctx context.Context, userID int64, password string) error {
+	ctx, span := tracing.Start(ctx, "UserUsecase.DeleteAccount")
+	defer span.End()
+
+	logger.FromContext(ctx).Debug("deleting account", logger.Int64("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	user, err := uc.userRepo.GetUserByID(ctx, int(userID))
+	if err != nil {
+		return entity.ErrNotFound
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		logger.FromContext(ctx).Warn("account deletion failed: wrong password", logger.Int64("user_id", userID))
+		return entity.ErrWrongPassword
+	}
+	if undeletableRoles[user.Role] {
+		return entity.ErrAccountNotDeletable
+	}
+
+	if err := uc.userRepo.AnonymizeUser(ctx, userID); err != nil {
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionUserDelete, "user", userID, nil)
+
+	logger.FromContext(ctx).Info("account deleted", logger.Int64("user_id", userID))
+	return nil
+}
+
+func (uc *userUsecase) ListSessions(ctx context.Context, userID int64) ([]entity.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.userRepo.ListSessions(ctx, userID)
+}
+
+func (uc *userUsecase) RevokeSession(ctx context.Context, userID, sessionID int64) error {
+	ctx, span := tracing.Start(ctx, "UserUsecase.RevokeSession")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.userRepo.RevokeSession(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	uc.auditor.Record(ctx, ActionUserSessionRevoke, "user", userID, map[string]interface{}{"session_id": sessionID})
+	return nil
+}
+
+func (uc *userUsecase) AuthenticateSession(ctx context.Context, userID, sessionID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	session, err := uc.userRepo.GetActiveSession(ctx, userID, sessionID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			logger.FromContext(ctx).Warn("usecase: token of a revoked or expired session", logger.Int64("session_id", sessionID))
+			return entity.ErrSessionRevoked
+		}
+		return err
+	}
+
+	if uc.clock.Now().Sub(session.LastSeenAt) >= sessionTouchInterval {
+		// Only the last seen time is lost if this fails
+		if err := uc.userRepo.TouchSession(ctx, sessionID); err != nil {
+			logger.FromContext(ctx).Warn("usecase: failed to touch session", logger.Int64("session_id", sessionID), logger.Err(err))
+		}
+	}
+	return nil
+}
+
+func (uc *userUsecase) RequestDataExport(ctx context.Context, userID int64) (*entity.UserDataExport, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	latest, err := uc.exportRepo.GetLatestExport(ctx, userID)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return nil, err
+	}
+	if latest != nil {
+		switch {
+		case latest.Status == entity.UserExportPending:
+			return latest, nil
+		case latest.Status == entity.UserExportReady && latest.CompletedAt != nil && uc.clock.Now().Sub(*latest.CompletedAt) < userExportMaxAge:
+			return latest, nil
+		}
+	}
+
+	var export *entity.UserDataExport
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if export, err = uc.exportRepo.CreateExport(ctx, userID); err != nil {
+			return err
+		}
+		return uc.exporter.EnqueueUserExport(ctx, export.ID)
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to queue data export", logger.Int64("user_id", userID), logger.Err(err))
 		return nil, err
 	}
 
-	logger.Debug("user profile fetched", logger.Int("user_id", userID))
+	uc.auditor.Record(ctx, ActionUserDataExport, "user", userID, map[string]interface{}{"export_id": export.ID})
+	return export, nil
+}
+
+func (uc *userUsecase) EnableTwoFactor(ctx context.Context, userID int64) (*entity.TwoFactorSetup, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	user, err := uc.userRepo.GetUserByID(ctx, int(userID))
+	if err != nil {
+		return nil, entity.ErrNotFound
+	}
+	if user.TwoFactorEnabled {
+		return nil, entity.ErrTwoFactorEnabled
+	}
+
+	// Asking again before verifying replaces the secret, in case the first
+	// was never scanned
+	secret, err := totp.NewSecret()
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to generate two-factor secret", logger.Err(err))
+		return nil, err
+	}
+	encrypted, err := uc.totpCipher.Encrypt(secret)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to encrypt two-factor secret", logger.Err(err))
+		return nil, err
+	}
+	if err := uc.userRepo.SetTwoFactorSecret(ctx, userID, encrypted); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("two-factor setup started", logger.Int64("user_id", userID))
+	return &entity.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(twoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+func (uc *userUsecase) VerifyTwoFactor(ctx context.Context, userID int64, code string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.VerifyTwoFactor")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	tf, err := uc.userRepo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf.Enabled {
+		return nil, entity.ErrTwoFactorEnabled
+	}
+	if tf.Secret == "" {
+		return nil, entity.ErrTwoFactorNotSetUp
+	}
+
+	secret, err := uc.totpCipher.Decrypt(tf.Secret)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to decrypt two-factor secret", logger.Int64("user_id", userID), logger.Err(err))
+		return nil, err
+	}
+	step, ok := totp.Validate(secret, code, uc.clock.Now())
+	if !ok {
+		return nil, entity.ErrInvalidTwoFactorCode
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = newRecoveryCode(); err != nil {
+			logger.FromContext(ctx).Error("failed to generate recovery code", logger.Err(err))
+			return nil, err
+		}
+		hashes[i] = hashToken(normalizeRecoveryCode(codes[i]))
+	}
+	if err := uc.userRepo.EnableTwoFactor(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	uc.auditor.Record(ctx, ActionUserTwoFactorEnable, "user", userID, nil)
+
+	logger.FromContext(ctx).Info("two-factor enabled", logger.Int64("user_id", userID))
+	return codes, nil
+}
+
+func (uc *userUsecase) OAuthURL(ctx context.Context, provider string) (string, error) {
+	p, ok := uc.oauthProviders[provider]
+	if !ok {
+		return "", entity.ErrOAuthProviderUnavailable
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		logger.FromContext(ctx).Error("failed to generate oauth state", logger.Err(err))
+		return "", err
+	}
+	state := uc.signOAuthState(provider, uc.clock.Now().Add(oauthStateTTL), hex.EncodeToString(nonce))
+	return p.AuthCodeURL(state), nil
+}
+
+func (uc *userUsecase) OAuthLogin(ctx context.Context, provider, code, state string) (*entity.LoginResult, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.OAuthLogin")
+	defer span.End()
+
+	p, ok := uc.oauthProviders[provider]
+	if !ok {
+		return nil, entity.ErrOAuthProviderUnavailable
+	}
+	if err := uc.verifyOAuthState(state, provider); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	profile, err := p.Exchange(ctx, code)
+	if err != nil {
+		logger.FromContext(ctx).Warn("oauth login failed: code exchange", logger.String("provider", provider), logger.Err(err))
+		if errors.Is(err, oauth.ErrInvalidCode) {
+			return nil, entity.ErrInvalidOAuthCode
+		}
+		return nil, err
+	}
+	// An unverified address could belong to anyone, and would let them
+	// take over the account registered with it
+	if !profile.EmailVerified || profile.Email == "" {
+		return nil, entity.ErrOAuthEmailUnverified
+	}
+
+	user, err := uc.userRepo.GetUserByOAuth(ctx, provider, profile.Subject)
+	if errors.Is(err, entity.ErrNotFound) {
+		user, err = uc.provisionOAuthUser(ctx, provider, profile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Debug("oauth login", logger.Int64("user_id", user.ID), logger.String("provider", provider))
+	return uc.loginResult(ctx, user)
+}
+
+// provisionOAuthUser links the account registered with the profile's email
+// to the provider, or creates one when there is none.
+func (uc *userUsecase) provisionOAuthUser(ctx context.Context, provider string, profile *oauth.Profile) (*entity.User, error) {
+	if user, err := uc.userRepo.GetUserByEmail(ctx, profile.Email); err == nil {
+		if err := uc.userRepo.LinkOAuth(ctx, user.ID, provider, profile.Subject); err != nil {
+			return nil, err
+		}
+		uc.auditor.Record(ctx, ActionUserOAuthLink, "user", user.ID, map[string]interface{}{"provider": provider})
+		user.OAuthProvider = provider
+		return user, nil
+	}
+
+	name := strings.TrimSpace(profile.Name)
+	if name == "" {
+		name = strings.Split(profile.Email, "@")[0]
+	}
+	// No password: the account signs in with the provider until the user
+	// sets one through a reset link
+	user := &entity.User{
+		Name:          name,
+		Email:         profile.Email,
+		Role:          "user",
+		OAuthProvider: provider,
+		OAuthSubject:  profile.Subject,
+	}
+	if err := uc.userRepo.CreateUser(ctx, user); err != nil {
+		logger.FromContext(ctx).Error("failed to create oauth user", logger.String("provider", provider), logger.Err(err))
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("user registered with oauth", logger.Int64("user_id", user.ID), logger.String("provider", provider))
 	return user, nil
-}
\ No newline at end of file
+}
+
+// signOAuthState returns "<provider>.<unix expiry>.<nonce>.<signature>", the
+// state the provider hands back with the code. It proves the sign-in was
+// started here, recently, for that provider.
+func (uc *userUsecase) signOAuthState(provider string, expiresAt time.Time, nonce string) string {
+	payload := fmt.Sprintf("%s.%d.%s", provider, expiresAt.Unix(), nonce)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(uc.oauthStateMAC(payload))
+}
+
+func (uc *userUsecase) oauthStateMAC(payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(uc.jwtSecret))
+	mac.Write([]byte("oauth-state:" + payload))
+	return mac.Sum(nil)
+}
+
+// verifyOAuthState checks the signature and expiry of an OAuth state and
+// that it was issued for provider.
+func (uc *userUsecase) verifyOAuthState(state, provider string) error {
+	parts := strings.Split(state, ".")
+	if len(parts) != 4 {
+		return entity.ErrInvalidOAuthState
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil || !hmac.Equal(sig, uc.oauthStateMAC(strings.Join(parts[:3], "."))) {
+		return entity.ErrInvalidOAuthState
+	}
+	if parts[0] != provider {
+		return entity.ErrInvalidOAuthState
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || uc.clock.Now().Unix() >= expiresAt {
+		return entity.ErrInvalidOAuthState
+	}
+	return nil
+}
+
+// signLoginChallenge returns "<userID>.<unix expiry>.<signature>". The
+// signed message is prefixed so a challenge can't pass as any other token
+// signed with the JWT secret, and it is no JWT, so it can't pass as one.
+func (uc *userUsecase) signLoginChallenge(userID int64, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d.%d", userID, expiresAt.Unix())
+	return payload + "." + base64.RawURLEncoding.EncodeToString(uc.loginChallengeMAC(payload))
+}
+
+func (uc *userUsecase) loginChallengeMAC(payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(uc.jwtSecret))
+	mac.Write([]byte("login-challenge:" + payload))
+	return mac.Sum(nil)
+}
+
+// verifyLoginChallenge checks the signature and expiry of a login
+// challenge and returns its user ID.
+func (uc *userUsecase) verifyLoginChallenge(token string) (int64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, entity.ErrInvalidLoginChallenge
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, uc.loginChallengeMAC(parts[0]+"."+parts[1])) {
+		return 0, entity.ErrInvalidLoginChallenge
+	}
+
+	userID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, entity.ErrInvalidLoginChallenge
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || uc.clock.Now().Unix() >= expiresAt {
+		return 0, entity.ErrInvalidLoginChallenge
+	}
+	return userID, nil
+}
+
+// newRecoveryCode returns a random code like "k3m9x-2hq7p".
+func newRecoveryCode() (string, error) {
+	buf := make([]byte, 7)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(buf))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode lets users type recovery codes in any case and
+// with or without the dash.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// hashToken returns the hex SHA-256 of an emailed token; only hashes are stored.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
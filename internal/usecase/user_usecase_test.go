@@ -2,26 +2,42 @@ package usecase_test
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 	
 	"ticres/internal/entity"
 	"ticres/internal/usecase"
 	"ticres/internal/usecase/mocks"
+	"ticres/pkg/clock"
+	"ticres/pkg/jwtkeys"
+	"ticres/pkg/oauth"
+	"ticres/pkg/totp"
 
 	"golang.org/x/crypto/bcrypt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
+func newTestTokens(t *testing.T) *jwtkeys.KeySet {
+	keys, err := jwtkeys.NewHMAC([]byte("secret"))
+	require.NoError(t, err)
+	return keys
+}
+
 func TestUserUsecase_Register(t *testing.T) {
 	// 1. Setup Mock
 	mockRepo := new(mocks.MockUserRepo)
 	
 	// 2. Setup Usecase dengan Mock Repo
 	// jwtSecret & expiry asal saja karena Register tidak pakai JWT
-	u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", 1)
+	u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, new(mocks.MockAuditUsecase), new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
 
 	// 3. Definisi Tabel Test Case
 	tests := []struct {
@@ -118,6 +134,7 @@ func TestUserUsercase_Login(t *testing.T) {
 		mockBehavior func(m *mocks.MockUserRepo) 
 		wantErr     bool
 		expectedErr error
+		wantChallenge bool
 	}{
 		// berhasil
 		{
@@ -126,9 +143,20 @@ func TestUserUsercase_Login(t *testing.T) {
 			password: "password123",
 			mockBehavior: func(m *mocks.MockUserRepo) {
 				m.On("GetUserByEmail", mock.Anything, "test@example.com").Return(mockUser, nil).Once()
+				m.On("CreateSession", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil).Once()
 			},
 			wantErr: false,
 		},
+		{
+			name: "Success Login - Two-Factor Required",
+			email: "2fa@example.com",
+			password: "password123",
+			mockBehavior: func(m *mocks.MockUserRepo) {
+				m.On("GetUserByEmail", mock.Anything, "2fa@example.com").
+					Return(&entity.User{ID: 2, Email: "2fa@example.com", Password: string(hashedPassword), TwoFactorEnabled: true}, nil).Once()
+			},
+			wantChallenge: true,
+		},
 
 		// email tidak ditemukan 
 		{
@@ -163,21 +191,879 @@ func TestUserUsercase_Login(t *testing.T) {
 
 			tt.mockBehavior(mockRepo)
 
-			u :=usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", 1)
+			u :=usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, new(mocks.MockAuditUsecase), new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
 
 			// Execute
-			token, err := u.Login(context.Background(), tt.email, tt.password)
+			result, err := u.Login(context.Background(), tt.email, tt.password)
 
 			// Assertions
 			if tt.wantErr {
 				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else if tt.wantChallenge {
+				assert.NoError(t, err)
+				assert.True(t, result.TwoFactorRequired)
+				assert.NotEmpty(t, result.ChallengeToken)
+				assert.Empty(t, result.Token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Token)
+				assert.False(t, result.TwoFactorRequired)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+func TestUserUsecase_ForgotPassword(t *testing.T) {
+	t.Run("Registered Email Gets Reset Link", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepo)
+		mockSender := new(mocks.MockPasswordResetSender)
+		clk := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+
+		mockRepo.On("GetUserByEmail", mock.Anything, "test@example.com").
+			Return(&entity.User{ID: 1, Email: "test@example.com"}, nil).Once()
+		mockRepo.On("CreatePasswordResetToken", mock.Anything, int64(1), mock.AnythingOfType("string"), clk.Now().Add(30*time.Minute)).
+			Return(nil).Once()
+		mockSender.On("SendPasswordReset", mock.Anything, "test@example.com", mock.MatchedBy(func(link string) bool {
+			return strings.HasPrefix(link, "http://localhost:3000/reset-password?token=")
+		})).Once()
+
+		u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, new(mocks.MockAuditUsecase), mockSender, "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clk, nil)
+		err := u.ForgotPassword(context.Background(), "test@example.com")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockSender.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Email Still Succeeds", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepo)
+		mockSender := new(mocks.MockPasswordResetSender)
+
+		mockRepo.On("GetUserByEmail", mock.Anything, "unknown@example.com").
+			Return(nil, errors.New("no rows in result set")).Once()
+
+		u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, new(mocks.MockAuditUsecase), mockSender, "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+		err := u.ForgotPassword(context.Background(), "unknown@example.com")
+
+		assert.NoError(t, err)
+		mockSender.AssertNotCalled(t, "SendPasswordReset", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(m *mocks.MockUserRepo)
+		wantErr      error
+	}{
+		{
+			name: "Success Reset",
+			mockBehavior: func(m *mocks.MockUserRepo) {
+				m.On("ResetPassword", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+					Return(int64(1), nil).Once()
+			},
+		},
+		{
+			name: "Failed - Used Or Expired Token",
+			mockBehavior: func(m *mocks.MockUserRepo) {
+				m.On("ResetPassword", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+					Return(int64(0), entity.ErrInvalidResetToken).Once()
+			},
+			wantErr: entity.ErrInvalidResetToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockUserRepo)
+			tt.mockBehavior(mockRepo)
+
+			u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, new(mocks.MockAuditUsecase), new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+			err := u.ResetPassword(context.Background(), "token", "newpassword")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_UpdateProfile(t *testing.T) {
+	tests := []struct {
+		name         string
+		userName     string
+		username     string
+		mockBehavior func(m *mocks.MockUserRepo)
+		wantErr      error
+		wantUsername string
+	}{
+		{
+			name:     "Success - Trimmed",
+			userName: "  Budi Santoso ",
+			username: "budi.s",
+			mockBehavior: func(m *mocks.MockUserRepo) {
+				m.On("UpdateProfile", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.ID == 1 && u.Name == "Budi Santoso" && u.UserName == "budi.s"
+				})).Return(nil).Once()
+			},
+			wantUsername: "budi.s",
+		},
+		{
+			name:     "Success - Username Removed",
+			userName: "Budi",
+			mockBehavior: func(m *mocks.MockUserRepo) {
+				m.On("UpdateProfile", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil).Once()
+			},
+		},
+		{
+			name:         "Failed - Empty Name",
+			userName:     "   ",
+			mockBehavior: func(m *mocks.MockUserRepo) {},
+			wantErr:      entity.ErrInvalidProfile,
+		},
+		{
+			name:         "Failed - Invalid Username",
+			userName:     "Budi",
+			username:     "budi santoso",
+			mockBehavior: func(m *mocks.MockUserRepo) {},
+			wantErr:      entity.ErrInvalidProfile,
+		},
+		{
+			name:     "Failed - Username Taken",
+			userName: "Budi",
+			username: "admin",
+			mockBehavior: func(m *mocks.MockUserRepo) {
+				m.On("UpdateProfile", mock.Anything, mock.AnythingOfType("*entity.User")).Return(entity.ErrUsernameTaken).Once()
+			},
+			wantErr: entity.ErrUsernameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockUserRepo)
+			tt.mockBehavior(mockRepo)
+
+			u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, new(mocks.MockAuditUsecase), new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+			user, err := u.UpdateProfile(context.Background(), 1, tt.userName, tt.username)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, user)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantUsername, user.UserName)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_ChangePassword(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	user := &entity.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword)}
+
+	tests := []struct {
+		name         string
+		current      string
+		mockBehavior func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase)
+		wantErr      error
+	}{
+		{
+			name:    "Success",
+			current: "password123",
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {
+				m.On("GetUserByID", mock.Anything, 1).Return(user, nil).Once()
+				m.On("UpdatePassword", mock.Anything, int64(1), int64(7), mock.MatchedBy(func(hash string) bool {
+					return bcrypt.CompareHashAndPassword([]byte(hash), []byte("newpassword")) == nil
+				})).Return(nil).Once()
+				a.On("Record", mock.Anything, usecase.ActionUserPasswordChange, "user", int64(1), mock.Anything).Once()
+			},
+		},
+		{
+			name:    "Failed - Wrong Current Password",
+			current: "wrongpassword",
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {
+				m.On("GetUserByID", mock.Anything, 1).Return(user, nil).Once()
+			},
+			wantErr: entity.ErrWrongPassword,
+		},
+		{
+			name:    "Failed - User Not Found",
+			current: "password123",
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {
+				m.On("GetUserByID", mock.Anything, 1).Return(nil, errors.New("no rows in result set")).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockUserRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			tt.mockBehavior(mockRepo, mockAudit)
+
+			u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, mockAudit, new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+			err := u.ChangePassword(context.Background(), 1, 7, tt.current, "newpassword")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_DeleteAccount(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+
+	tests := []struct {
+		name         string
+		password     string
+		role         string
+		mockBehavior func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase)
+		wantErr      error
+	}{
+		{
+			name:     "Success",
+			password: "password123",
+			role:     "user",
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {
+				m.On("AnonymizeUser", mock.Anything, int64(1)).Return(nil).Once()
+				a.On("Record", mock.Anything, usecase.ActionUserDelete, "user", int64(1), mock.Anything).Once()
+			},
+		},
+		{
+			name:         "Failed - Wrong Password",
+			password:     "wrongpassword",
+			role:         "user",
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {},
+			wantErr:      entity.ErrWrongPassword,
+		},
+		{
+			name:         "Failed - Organizer",
+			password:     "password123",
+			role:         "organizer",
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {},
+			wantErr:      entity.ErrAccountNotDeletable,
+		},
+		{
+			name:     "Failed - Already Deleted",
+			password: "password123",
+			role:     "user",
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {
+				m.On("AnonymizeUser", mock.Anything, int64(1)).Return(entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockUserRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			mockRepo.On("GetUserByID", mock.Anything, 1).
+				Return(&entity.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword), Role: tt.role}, nil).Once()
+			tt.mockBehavior(mockRepo, mockAudit)
+
+			u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, mockAudit, new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+			err := u.DeleteAccount(context.Background(), 1, tt.password)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_RequestDataExport(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	justNow := clk.Now().Add(-time.Minute)
+	twoDaysAgo := clk.Now().Add(-48 * time.Hour)
+	queued := &entity.UserDataExport{ID: 8, UserID: 1, Status: entity.UserExportPending}
+
+	tests := []struct {
+		name       string
+		latest     *entity.UserDataExport
+		latestErr  error
+		enqueueErr error
+		wantQueued bool
+		wantID     int64
+		wantErr    bool
+	}{
+		{name: "First Request Is Queued", latestErr: entity.ErrNotFound, wantQueued: true, wantID: 8},
+		{name: "Still Building", latest: &entity.UserDataExport{ID: 7, Status: entity.UserExportPending}, wantID: 7},
+		{name: "Ready Archive Is Returned", latest: &entity.UserDataExport{ID: 7, Status: entity.UserExportReady, CompletedAt: &justNow, Archive: []byte(`{}`)}, wantID: 7},
+		{name: "Stale Archive Is Rebuilt", latest: &entity.UserDataExport{ID: 7, Status: entity.UserExportReady, CompletedAt: &twoDaysAgo}, wantQueued: true, wantID: 8},
+		{name: "Failed Export Is Retried", latest: &entity.UserDataExport{ID: 7, Status: entity.UserExportFailed, CompletedAt: &justNow}, wantQueued: true, wantID: 8},
+		{name: "Enqueue Failed", latestErr: entity.ErrNotFound, enqueueErr: errors.New("db down"), wantQueued: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exportRepo := new(mocks.MockUserExportRepo)
+			txManager := new(mocks.MockTxManager)
+			exporter := new(mocks.MockUserDataExporter)
+			mockAudit := new(mocks.MockAuditUsecase)
+
+			exportRepo.On("GetLatestExport", mock.Anything, int64(1)).Return(tt.latest, tt.latestErr).Once()
+			if tt.wantQueued {
+				txManager.On("WithinTransaction", mock.Anything).Return(nil).Once()
+				exportRepo.On("CreateExport", mock.Anything, int64(1)).Return(queued, nil).Once()
+				exporter.On("EnqueueUserExport", mock.Anything, int64(8)).Return(tt.enqueueErr).Once()
+				if tt.enqueueErr == nil {
+					mockAudit.On("Record", mock.Anything, usecase.ActionUserDataExport, "user", int64(1), mock.Anything).Once()
+				}
+			}
+
+			u := usecase.NewUserUsecase(new(mocks.MockUserRepo), time.Second*2, "secret", newTestTokens(t), 1, mockAudit, new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", exportRepo, txManager, exporter, newTestCipher(t), clk, nil)
+			export, err := u.RequestDataExport(context.Background(), 1)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, export)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantID, export.ID)
+			}
+			if !tt.wantQueued {
+				exportRepo.AssertNotCalled(t, "CreateExport", mock.Anything, mock.Anything)
+			}
+			exportRepo.AssertExpectations(t)
+			txManager.AssertExpectations(t)
+			exporter.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_EnableTwoFactor(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepo)
+		cipher := newTestCipher(t)
+		mockRepo.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, Email: "test@example.com"}, nil).Once()
+
+		var stored string
+		mockRepo.On("SetTwoFactorSecret", mock.Anything, int64(1), mock.Anything).
+			Run(func(args mock.Arguments) { stored = args.String(2) }).Return(nil).Once()
+
+		u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, new(mocks.MockAuditUsecase), new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), cipher, clock.Real{}, nil)
+		setup, err := u.EnableTwoFactor(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(setup.ProvisioningURI, "otpauth://totp/TicRes:test@example.com?"))
+		assert.Contains(t, setup.ProvisioningURI, "secret="+setup.Secret)
+		assert.NotEqual(t, setup.Secret, stored, "secret must be stored encrypted")
+		decrypted, err := cipher.Decrypt(stored)
+		assert.NoError(t, err)
+		assert.Equal(t, setup.Secret, decrypted)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Already Enabled", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepo)
+		mockRepo.On("GetUserByID", mock.Anything, 1).Return(&entity.User{ID: 1, TwoFactorEnabled: true}, nil).Once()
+
+		u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, new(mocks.MockAuditUsecase), new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+		setup, err := u.EnableTwoFactor(context.Background(), 1)
+
+		assert.ErrorIs(t, err, entity.ErrTwoFactorEnabled)
+		assert.Nil(t, setup)
+		mockRepo.AssertNotCalled(t, "SetTwoFactorSecret", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_VerifyTwoFactor(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	cipher := newTestCipher(t)
+	secret, _ := totp.NewSecret()
+	encrypted, _ := cipher.Encrypt(secret)
+	step := totp.Step(clk.Now())
+	code, _ := totp.Code(secret, step)
+
+	tests := []struct {
+		name     string
+		state    *entity.TwoFactor
+		code     string
+		wantErr  error
+		wantCall bool
+	}{
+		{
+			name:     "Success",
+			state:    &entity.TwoFactor{Secret: encrypted},
+			code:     code,
+			wantCall: true,
+		},
+		{
+			name:    "Failed - Wrong Code",
+			state:   &entity.TwoFactor{Secret: encrypted},
+			code:    "000000",
+			wantErr: entity.ErrInvalidTwoFactorCode,
+		},
+		{
+			name:    "Failed - Not Set Up",
+			state:   &entity.TwoFactor{},
+			code:    code,
+			wantErr: entity.ErrTwoFactorNotSetUp,
+		},
+		{
+			name:    "Failed - Already Enabled",
+			state:   &entity.TwoFactor{Secret: encrypted, Enabled: true},
+			code:    code,
+			wantErr: entity.ErrTwoFactorEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockUserRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			mockRepo.On("GetTwoFactor", mock.Anything, int64(1)).Return(tt.state, nil).Once()
+			if tt.wantCall {
+				mockRepo.On("EnableTwoFactor", mock.Anything, int64(1), step, mock.MatchedBy(func(hashes []string) bool {
+					return len(hashes) == 10
+				})).Return(nil).Once()
+				mockAudit.On("Record", mock.Anything, usecase.ActionUserTwoFactorEnable, "user", int64(1), mock.Anything).Once()
+			}
+
+			u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, mockAudit, new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), cipher, clk, nil)
+			codes, err := u.VerifyTwoFactor(context.Background(), 1, tt.code)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, codes)
+				mockRepo.AssertNotCalled(t, "EnableTwoFactor", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, codes, 10)
+				// The stored hashes are of the codes handed out
+				hashes := mockRepo.Calls[1].Arguments.Get(3).([]string)
+				assert.Equal(t, hashRecoveryCode(codes[0]), hashes[0])
+			}
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_Login_RS256KeyRotation(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &entity.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword), Role: "user"}
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	oldKeys, err := jwtkeys.NewRSA(oldKey)
+	require.NoError(t, err)
+	mockRepo := new(mocks.MockUserRepo)
+	mockRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil).Once()
+	mockRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil).Once()
+	u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", oldKeys, 1, new(mocks.MockAuditUsecase), new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+
+	result, err := u.Login(context.Background(), "test@example.com", "password123")
+	require.NoError(t, err)
+	claims, err := oldKeys.Parse(result.Token)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), claims["user_id"])
+
+	// After rotation the old key only verifies, and is still published
+	rotated, err := jwtkeys.NewRSA(newKey, &oldKey.PublicKey)
+	require.NoError(t, err)
+	_, err = rotated.Parse(result.Token)
+	assert.NoError(t, err)
+	assert.Len(t, rotated.JWKS().Keys, 2)
+	assert.Equal(t, oldKeys.JWKS().Keys[0].KeyID, rotated.JWKS().Keys[1].KeyID)
+
+	// Once the old key is dropped its tokens are rejected
+	newOnly, err := jwtkeys.NewRSA(newKey)
+	require.NoError(t, err)
+	_, err = newOnly.Parse(result.Token)
+	assert.ErrorIs(t, err, jwtkeys.ErrUnknownKey)
+
+	// An HS256 token is never accepted by an RS256 key set
+	hmacToken, err := newTestTokens(t).Sign(map[string]interface{}{"user_id": 1})
+	require.NoError(t, err)
+	_, err = rotated.Parse(hmacToken)
+	assert.Error(t, err)
+	assert.Empty(t, newTestTokens(t).JWKS().Keys)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUsecase_CompleteLogin(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	cipher := newTestCipher(t)
+	secret, _ := totp.NewSecret()
+	encrypted, _ := cipher.Encrypt(secret)
+	user := &entity.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword), TwoFactorEnabled: true}
+
+	tests := []struct {
+		name         string
+		challenge    func(token string) string
+		advance      time.Duration
+		code         func(now time.Time) string
+		state        *entity.TwoFactor
+		mockBehavior func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase, now time.Time)
+		wantErr      error
+	}{
+		{
+			name:  "Success - Authenticator Code",
+			code:  func(now time.Time) string { code, _ := totp.Code(secret, totp.Step(now)); return code },
+			state: &entity.TwoFactor{Secret: encrypted, Enabled: true},
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase, now time.Time) {
+				m.On("UseTwoFactorStep", mock.Anything, int64(1), totp.Step(now)).Return(nil).Once()
+				m.On("GetUserByID", mock.Anything, 1).Return(user, nil).Once()
+				m.On("CreateSession", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil).Once()
+			},
+		},
+		{
+			name:  "Success - Recovery Code",
+			code:  func(time.Time) string { return "ABCDE-fghij" },
+			state: &entity.TwoFactor{Secret: encrypted, Enabled: true},
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase, now time.Time) {
+				m.On("UseRecoveryCode", mock.Anything, int64(1), hashRecoveryCode("abcdefghij")).Return(nil).Once()
+				a.On("Record", mock.Anything, usecase.ActionUserRecoveryCodeUse, "user", int64(1), mock.Anything).Once()
+				m.On("GetUserByID", mock.Anything, 1).Return(user, nil).Once()
+				m.On("CreateSession", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil).Once()
+			},
+		},
+		{
+			name:  "Failed - Code Already Used",
+			code:  func(now time.Time) string { code, _ := totp.Code(secret, totp.Step(now)); return code },
+			state: &entity.TwoFactor{Secret: encrypted, Enabled: true},
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase, now time.Time) {
+				m.On("UseTwoFactorStep", mock.Anything, int64(1), totp.Step(now)).Return(entity.ErrInvalidTwoFactorCode).Once()
+				m.On("RecordTwoFactorFailure", mock.Anything, int64(1)).Return(nil).Once()
+			},
+			wantErr: entity.ErrInvalidTwoFactorCode,
+		},
+		{
+			name:  "Failed - Wrong Code",
+			code:  func(now time.Time) string { code, _ := totp.Code(secret, totp.Step(now)+5); return code },
+			state: &entity.TwoFactor{Secret: encrypted, Enabled: true},
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase, now time.Time) {
+				m.On("RecordTwoFactorFailure", mock.Anything, int64(1)).Return(nil).Once()
+			},
+			wantErr: entity.ErrInvalidTwoFactorCode,
+		},
+		{
+			name:         "Failed - Too Many Wrong Codes",
+			code:         func(now time.Time) string { code, _ := totp.Code(secret, totp.Step(now)); return code },
+			state:        &entity.TwoFactor{Secret: encrypted, Enabled: true, RecentFailures: 5},
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase, now time.Time) {},
+			wantErr:      entity.ErrTooManyTwoFactorAttempts,
+		},
+		{
+			name:         "Failed - Challenge Expired",
+			advance:      5 * time.Minute,
+			code:         func(now time.Time) string { code, _ := totp.Code(secret, totp.Step(now)); return code },
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase, now time.Time) {},
+			wantErr:      entity.ErrInvalidLoginChallenge,
+		},
+		{
+			name:         "Failed - Challenge Tampered",
+			challenge:    func(token string) string { return "2" + token[1:] },
+			code:         func(now time.Time) string { code, _ := totp.Code(secret, totp.Step(now)); return code },
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase, now time.Time) {},
+			wantErr:      entity.ErrInvalidLoginChallenge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+			mockRepo := new(mocks.MockUserRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			mockRepo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil).Once()
+
+			u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, mockAudit, new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), cipher, clk, nil)
+			result, err := u.Login(context.Background(), "test@example.com", "password123")
+			assert.NoError(t, err)
+
+			clk.Advance(tt.advance)
+			if tt.state != nil {
+				mockRepo.On("GetTwoFactor", mock.Anything, int64(1)).Return(tt.state, nil).Once()
+			}
+			tt.mockBehavior(mockRepo, mockAudit, clk.Now())
+
+			challenge := result.ChallengeToken
+			if tt.challenge != nil {
+				challenge = tt.challenge(challenge)
+			}
+			token, err := u.CompleteLogin(context.Background(), challenge, tt.code(clk.Now()))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
 				assert.Empty(t, token)
+				mockRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
 			} else {
 				assert.NoError(t, err)
 				assert.NotEmpty(t, token)
 			}
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_OAuthLogin(t *testing.T) {
+	profile := &oauth.Profile{Subject: "g-123", Email: "jane@example.com", EmailVerified: true, Name: "Jane"}
+
+	tests := []struct {
+		name          string
+		profile       *oauth.Profile
+		exchangeErr   error
+		state         func(state string) string
+		advance       time.Duration
+		mockBehavior  func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase)
+		wantErr       error
+		wantChallenge bool
+	}{
+		{
+			name:    "Success - Linked Account",
+			profile: profile,
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {
+				m.On("GetUserByOAuth", mock.Anything, "google", "g-123").Return(&entity.User{ID: 1, Email: "jane@example.com", Role: "user"}, nil).Once()
+				m.On("CreateSession", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Success - Links Account With Same Email",
+			profile: profile,
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {
+				m.On("GetUserByOAuth", mock.Anything, "google", "g-123").Return(nil, entity.ErrNotFound).Once()
+				m.On("GetUserByEmail", mock.Anything, "jane@example.com").Return(&entity.User{ID: 2, Email: "jane@example.com", Role: "user"}, nil).Once()
+				m.On("LinkOAuth", mock.Anything, int64(2), "google", "g-123").Return(nil).Once()
+				a.On("Record", mock.Anything, usecase.ActionUserOAuthLink, "user", int64(2), mock.Anything).Once()
+				m.On("CreateSession", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Success - Creates Account",
+			profile: profile,
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {
+				m.On("GetUserByOAuth", mock.Anything, "google", "g-123").Return(nil, entity.ErrNotFound).Once()
+				m.On("GetUserByEmail", mock.Anything, "jane@example.com").Return(nil, errors.New("no rows")).Once()
+				m.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Name == "Jane" && u.Password == "" && u.OAuthProvider == "google" && u.OAuthSubject == "g-123"
+				})).Return(nil).Once()
+				m.On("CreateSession", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Success - Two-Factor Required",
+			profile: profile,
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {
+				m.On("GetUserByOAuth", mock.Anything, "google", "g-123").Return(&entity.User{ID: 1, TwoFactorEnabled: true}, nil).Once()
+			},
+			wantChallenge: true,
+		},
+		{
+			name:         "Failed - Email Not Verified",
+			profile:      &oauth.Profile{Subject: "g-123", Email: "jane@example.com"},
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {},
+			wantErr:      entity.ErrOAuthEmailUnverified,
+		},
+		{
+			name:    "Failed - Linked To Another Google Account",
+			profile: profile,
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {
+				m.On("GetUserByOAuth", mock.Anything, "google", "g-123").Return(nil, entity.ErrNotFound).Once()
+				m.On("GetUserByEmail", mock.Anything, "jane@example.com").Return(&entity.User{ID: 2}, nil).Once()
+				m.On("LinkOAuth", mock.Anything, int64(2), "google", "g-123").Return(entity.ErrOAuthAccountConflict).Once()
+			},
+			wantErr: entity.ErrOAuthAccountConflict,
+		},
+		{
+			name:         "Failed - Code Rejected",
+			exchangeErr:  oauth.ErrInvalidCode,
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {},
+			wantErr:      entity.ErrInvalidOAuthCode,
+		},
+		{
+			name:         "Failed - State Expired",
+			advance:      10 * time.Minute,
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {},
+			wantErr:      entity.ErrInvalidOAuthState,
+		},
+		{
+			name:         "Failed - State Forged",
+			state:        func(state string) string { return strings.Replace(state, ".", "9.", 1) },
+			mockBehavior: func(m *mocks.MockUserRepo, a *mocks.MockAuditUsecase) {},
+			wantErr:      entity.ErrInvalidOAuthState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFake(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+			mockRepo := new(mocks.MockUserRepo)
+			mockAudit := new(mocks.MockAuditUsecase)
+			provider := new(mocks.MockOAuthProvider)
+			provider.On("AuthCodeURL", mock.Anything).Return("https://accounts.example/auth").Once()
+			if tt.profile != nil || tt.exchangeErr != nil {
+				provider.On("Exchange", mock.Anything, "code-1").Return(tt.profile, tt.exchangeErr).Once()
+			}
+			tt.mockBehavior(mockRepo, mockAudit)
+
+			u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, mockAudit, new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clk, map[string]oauth.Provider{"google": provider})
+			url, err := u.OAuthURL(context.Background(), "google")
+			assert.NoError(t, err)
+			assert.Equal(t, "https://accounts.example/auth", url)
 
+			state := provider.Calls[0].Arguments.String(0)
+			if tt.state != nil {
+				state = tt.state(state)
+			}
+			clk.Advance(tt.advance)
+			result, err := u.OAuthLogin(context.Background(), "google", "code-1", state)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			case tt.wantChallenge:
+				assert.NoError(t, err)
+				assert.True(t, result.TwoFactorRequired)
+				assert.Empty(t, result.Token)
+			default:
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Token)
+			}
 			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+			provider.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestUserUsecase_OAuthURL_UnknownProvider(t *testing.T) {
+	u := usecase.NewUserUsecase(new(mocks.MockUserRepo), time.Second*2, "secret", newTestTokens(t), 1, new(mocks.MockAuditUsecase), new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+
+	_, err := u.OAuthURL(context.Background(), "google")
+	assert.ErrorIs(t, err, entity.ErrOAuthProviderUnavailable)
+	_, err = u.OAuthLogin(context.Background(), "google", "code-1", "state")
+	assert.ErrorIs(t, err, entity.ErrOAuthProviderUnavailable)
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.ReplaceAll(code, "-", ""))))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestUserUsecase_LoginOpensSession(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	mockRepo := new(mocks.MockUserRepo)
+	mockRepo.On("GetUserByEmail", mock.Anything, "test@example.com").
+		Return(&entity.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword)}, nil).Once()
+	mockRepo.On("CreateSession", mock.Anything, mock.MatchedBy(func(s *entity.Session) bool {
+		return s.UserID == 1 && s.UserAgent == "Firefox" && s.IPAddress == "203.0.113.7"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*entity.Session).ID = 42
+	}).Return(nil).Once()
+
+	tokens := newTestTokens(t)
+	u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", tokens, 1, new(mocks.MockAuditUsecase), new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+	ctx := usecase.WithClient(context.Background(), "Firefox", "203.0.113.7")
+	result, err := u.Login(ctx, "test@example.com", "password123")
+	require.NoError(t, err)
+
+	claims, err := tokens.Parse(result.Token)
+	require.NoError(t, err)
+	assert.Equal(t, float64(42), claims["sid"])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUsecase_AuthenticateSession(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m *mocks.MockUserRepo)
+		wantErr      error
+	}{
+		{
+			name: "Active - Seen Recently",
+			mockBehavior: func(m *mocks.MockUserRepo) {
+				m.On("GetActiveSession", mock.Anything, int64(1), int64(7)).Return(&entity.Session{ID: 7, LastSeenAt: now.Add(-10 * time.Second)}, nil).Once()
+			},
+		},
+		{
+			name: "Active - Last Seen Updated",
+			mockBehavior: func(m *mocks.MockUserRepo) {
+				m.On("GetActiveSession", mock.Anything, int64(1), int64(7)).Return(&entity.Session{ID: 7, LastSeenAt: now.Add(-time.Hour)}, nil).Once()
+				m.On("TouchSession", mock.Anything, int64(7)).Return(nil).Once()
+			},
+		},
+		{
+			name: "Active - Touch Failure Ignored",
+			mockBehavior: func(m *mocks.MockUserRepo) {
+				m.On("GetActiveSession", mock.Anything, int64(1), int64(7)).Return(&entity.Session{ID: 7, LastSeenAt: now.Add(-time.Hour)}, nil).Once()
+				m.On("TouchSession", mock.Anything, int64(7)).Return(errors.New("db down")).Once()
+			},
+		},
+		{
+			name: "Revoked Or Expired",
+			mockBehavior: func(m *mocks.MockUserRepo) {
+				m.On("GetActiveSession", mock.Anything, int64(1), int64(7)).Return(nil, entity.ErrNotFound).Once()
+			},
+			wantErr: entity.ErrSessionRevoked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockUserRepo)
+			tt.mockBehavior(mockRepo)
+
+			u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, new(mocks.MockAuditUsecase), new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.NewFake(now), nil)
+			err := u.AuthenticateSession(context.Background(), 1, 7)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_RevokeSession(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+		mockRepo.On("RevokeSession", mock.Anything, int64(1), int64(7)).Return(nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionUserSessionRevoke, "user", int64(1), map[string]interface{}{"session_id": int64(7)}).Once()
+
+		u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, mockAudit, new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+		assert.NoError(t, u.RevokeSession(context.Background(), 1, 7))
+		mockRepo.AssertExpectations(t)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+		mockRepo.On("RevokeSession", mock.Anything, int64(1), int64(8)).Return(entity.ErrNotFound).Once()
+
+		u := usecase.NewUserUsecase(mockRepo, time.Second*2, "secret", newTestTokens(t), 1, mockAudit, new(mocks.MockPasswordResetSender), "http://localhost:3000/reset-password", new(mocks.MockUserExportRepo), new(mocks.MockTxManager), new(mocks.MockUserDataExporter), newTestCipher(t), clock.Real{}, nil)
+		assert.ErrorIs(t, u.RevokeSession(context.Background(), 1, 8), entity.ErrNotFound)
+		mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+	"ticres/pkg/warehouse"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WarehouseTarget is a destination and the datasets shipped to it.
+type WarehouseTarget struct {
+	Name     string
+	Datasets []string
+	Sink     warehouse.Sink
+}
+
+// NewWarehouseTargets builds a sink for every configured destination. A
+// destination without datasets gets all of them.
+func NewWarehouseTargets(dests []warehouse.Destination) ([]WarehouseTarget, error) {
+	targets := make([]WarehouseTarget, 0, len(dests))
+	for _, d := range dests {
+		datasets := d.Datasets
+		if len(datasets) == 0 {
+			datasets = entity.WarehouseDatasets
+		}
+		for _, ds := range datasets {
+			if !slices.Contains(entity.WarehouseDatasets, ds) {
+				return nil, fmt.Errorf("warehouse destination %q: unknown dataset %q", d.Name, ds)
+			}
+		}
+
+		sink, err := warehouse.NewSink(d)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, WarehouseTarget{Name: d.Name, Datasets: datasets, Sink: sink})
+	}
+	return targets, nil
+}
+
+// WarehouseExportConfig sets the rows per batch and how long a change must
+// be committed before it is exported. Rows are stamped when their
+// transaction writes them but only become visible on commit, so a lag longer
+// than any write transaction keeps a slow commit from landing behind the
+// watermark.
+type WarehouseExportConfig struct {
+	BatchSize int
+	Lag       time.Duration
+}
+
+type WarehouseExportUsecase interface {
+	// Export ships the rows changed since the last run of every dataset to
+	// every target. A failing target does not hold up the others.
+	Export(ctx context.Context) error
+}
+
+type warehouseExportUsecase struct {
+	warehouseRepo repository.WarehouseRepository
+	targets       []WarehouseTarget
+	cfg           WarehouseExportConfig
+}
+
+func NewWarehouseExportUsecase(warehouseRepo repository.WarehouseRepository, targets []WarehouseTarget, cfg WarehouseExportConfig) WarehouseExportUsecase {
+	return &warehouseExportUsecase{
+		warehouseRepo: warehouseRepo,
+		targets:       targets,
+		cfg:           cfg,
+	}
+}
+
+func (uc *warehouseExportUsecase) Export(ctx context.Context) error {
+	until := time.Now().UTC().Add(-uc.cfg.Lag)
+
+	var errs []error
+	for _, target := range uc.targets {
+		for _, dataset := range target.Datasets {
+			if err := uc.exportDataset(ctx, target, dataset, until); err != nil {
+				logger.FromContext(ctx).Error("usecase: warehouse export failed",
+					logger.String("destination", target.Name),
+					logger.String("dataset", dataset),
+					logger.Err(err),
+				)
+				errs = append(errs, fmt.Errorf("%s/%s: %w", target.Name, dataset, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// exportDataset ships batches until the dataset has caught up with until.
+// The watermark moves after every batch the sink accepted, so a failure
+// only resends the batch that failed.
+func (uc *warehouseExportUsecase) exportDataset(ctx context.Context, target WarehouseTarget, dataset string, until time.Time) error {
+	ctx, span := tracing.Start(ctx, "WarehouseExportUsecase.exportDataset",
+		attribute.String("warehouse.destination", target.Name),
+		attribute.String("warehouse.dataset", dataset),
+	)
+	defer span.End()
+
+	w, err := uc.warehouseRepo.GetWatermark(ctx, target.Name, dataset)
+	if err != nil {
+		return err
+	}
+
+	exported := 0
+	for {
+		records, err := uc.warehouseRepo.FetchChanges(ctx, dataset, w, until, uc.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			break
+		}
+
+		rows := make([]json.RawMessage, len(records))
+		for i, rec := range records {
+			rows[i] = rec.Data
+		}
+		if err := target.Sink.Write(ctx, dataset, rows); err != nil {
+			return err
+		}
+
+		last := records[len(records)-1]
+		w.At, w.ID = last.UpdatedAt, last.ID
+		if err := uc.warehouseRepo.SaveWatermark(ctx, w, len(records)); err != nil {
+			return err
+		}
+		exported += len(records)
+
+		if len(records) < uc.cfg.BatchSize {
+			break
+		}
+	}
+
+	if exported > 0 {
+		logger.FromContext(ctx).Info("usecase: warehouse export shipped rows",
+			logger.String("destination", target.Name),
+			logger.String("dataset", dataset),
+			logger.Int("rows", exported),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/warehouse"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func exportRecords(from int64, n int) []entity.ExportRecord {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	records := make([]entity.ExportRecord, n)
+	for i := range records {
+		id := from + int64(i)
+		records[i] = entity.ExportRecord{ID: id, UpdatedAt: base.Add(time.Duration(id) * time.Second), Data: json.RawMessage(`{}`)}
+	}
+	return records
+}
+
+func TestWarehouseExportUsecase_Export(t *testing.T) {
+	t.Run("Success - Ships Batches And Moves Watermark", func(t *testing.T) {
+		mockRepo := new(mocks.MockWarehouseRepo)
+		mockSink := new(mocks.MockWarehouseSink)
+		w := &entity.ExportWatermark{Destination: "lake", Dataset: entity.DatasetBookings}
+
+		mockRepo.On("GetWatermark", mock.Anything, "lake", entity.DatasetBookings).Return(w, nil).Once()
+		mockRepo.On("FetchChanges", mock.Anything, entity.DatasetBookings, w, mock.Anything, 2).Return(exportRecords(1, 2), nil).Once()
+		mockRepo.On("FetchChanges", mock.Anything, entity.DatasetBookings, w, mock.Anything, 2).Return(exportRecords(3, 1), nil).Once()
+		mockSink.On("Write", mock.Anything, entity.DatasetBookings, mock.Anything).Return(nil).Twice()
+		mockRepo.On("SaveWatermark", mock.Anything, w, 2).Return(nil).Once()
+		mockRepo.On("SaveWatermark", mock.Anything, w, 1).Return(nil).Once()
+
+		u := usecase.NewWarehouseExportUsecase(mockRepo, []usecase.WarehouseTarget{
+			{Name: "lake", Datasets: []string{entity.DatasetBookings}, Sink: mockSink},
+		}, usecase.WarehouseExportConfig{BatchSize: 2, Lag: time.Minute})
+
+		assert.NoError(t, u.Export(context.Background()))
+		assert.Equal(t, int64(3), w.ID)
+		mockRepo.AssertExpectations(t)
+		mockSink.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Sink Error Keeps Watermark And Other Targets Run", func(t *testing.T) {
+		mockRepo := new(mocks.MockWarehouseRepo)
+		failing := new(mocks.MockWarehouseSink)
+		healthy := new(mocks.MockWarehouseSink)
+		failingMark := &entity.ExportWatermark{Destination: "ch", Dataset: entity.DatasetRefunds, ID: 7}
+		healthyMark := &entity.ExportWatermark{Destination: "bq", Dataset: entity.DatasetRefunds}
+
+		mockRepo.On("GetWatermark", mock.Anything, "ch", entity.DatasetRefunds).Return(failingMark, nil).Once()
+		mockRepo.On("GetWatermark", mock.Anything, "bq", entity.DatasetRefunds).Return(healthyMark, nil).Once()
+		mockRepo.On("FetchChanges", mock.Anything, entity.DatasetRefunds, failingMark, mock.Anything, 10).Return(exportRecords(8, 1), nil).Once()
+		mockRepo.On("FetchChanges", mock.Anything, entity.DatasetRefunds, healthyMark, mock.Anything, 10).Return(exportRecords(1, 1), nil).Once()
+		failing.On("Write", mock.Anything, entity.DatasetRefunds, mock.Anything).Return(errors.New("connection refused")).Once()
+		healthy.On("Write", mock.Anything, entity.DatasetRefunds, mock.Anything).Return(nil).Once()
+		mockRepo.On("SaveWatermark", mock.Anything, healthyMark, 1).Return(nil).Once()
+
+		u := usecase.NewWarehouseExportUsecase(mockRepo, []usecase.WarehouseTarget{
+			{Name: "ch", Datasets: []string{entity.DatasetRefunds}, Sink: failing},
+			{Name: "bq", Datasets: []string{entity.DatasetRefunds}, Sink: healthy},
+		}, usecase.WarehouseExportConfig{BatchSize: 10, Lag: time.Minute})
+
+		err := u.Export(context.Background())
+		assert.ErrorContains(t, err, "connection refused")
+		assert.Equal(t, int64(7), failingMark.ID)
+		assert.Equal(t, int64(1), healthyMark.ID)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "SaveWatermark", mock.Anything, failingMark, mock.Anything)
+	})
+}
+
+func TestNewWarehouseTargets(t *testing.T) {
+	targets, err := usecase.NewWarehouseTargets([]warehouse.Destination{
+		{Name: "ch", Type: "clickhouse", URL: "http://clickhouse:8123", Database: "ticres"},
+		{Name: "lake", Type: "s3", Bucket: "bi", Region: "ap-southeast-1", Datasets: []string{entity.DatasetAuditLogs}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, targets, 2)
+	assert.Equal(t, entity.WarehouseDatasets, targets[0].Datasets)
+	assert.Equal(t, []string{entity.DatasetAuditLogs}, targets[1].Datasets)
+
+	_, err = usecase.NewWarehouseTargets([]warehouse.Destination{
+		{Name: "lake", Type: "s3", Bucket: "bi", Datasets: []string{"users"}},
+	})
+	assert.ErrorContains(t, err, "unknown dataset")
+
+	_, err = usecase.NewWarehouseTargets([]warehouse.Destination{{Name: "dw", Type: "snowflake"}})
+	assert.ErrorContains(t, err, "unknown type")
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/repository"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// webhookSecretPrefix marks webhook signing secrets, like apiKeyPrefix.
+const webhookSecretPrefix = "whsec_"
+
+// WebhookPublisher queues a booking lifecycle event for the webhooks
+// subscribed to it. Delivery happens in the background.
+type WebhookPublisher interface {
+	PublishWebhookEvent(ctx context.Context, eventType string, bookingID int64)
+}
+
+// DomainEventPublisher emits domain events to the message broker for
+// consumers outside the API. Publishing happens in the background and does
+// nothing when no broker is configured.
+type DomainEventPublisher interface {
+	PublishDomainEvent(ctx context.Context, eventType string, aggregateID int64, data map[string]interface{})
+}
+
+// WebhookCipher encrypts webhook signing secrets before they are stored.
+type WebhookCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+type WebhookUsecase interface {
+	// CreateWebhook subscribes a URL to lifecycle events. The signing secret
+	// is set in webhook.Secret and cannot be retrieved later.
+	CreateWebhook(ctx context.Context, adminID int64, webhook *entity.Webhook) error
+	// ListWebhooks returns the webhooks of an organizer, or all webhooks
+	// when organizerID is nil.
+	ListWebhooks(ctx context.Context, organizerID *int64) ([]entity.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookID int64, update entity.WebhookUpdate) (*entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID int64) error
+	// ListDeliveries returns a page of the webhook's delivery log with the
+	// total count.
+	ListDeliveries(ctx context.Context, webhookID int64, page, limit int) ([]entity.WebhookDelivery, int, error)
+}
+
+type webhookUsecase struct {
+	webhookRepo    repository.WebhookRepository
+	userRepo       repository.UserRepository
+	cipher         WebhookCipher
+	auditor        AuditUsecase
+	contextTimeout time.Duration
+}
+
+func NewWebhookUsecase(
+	webhookRepo repository.WebhookRepository,
+	userRepo repository.UserRepository,
+	cipher WebhookCipher,
+	auditor AuditUsecase,
+	timeout time.Duration,
+) WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepo:    webhookRepo,
+		userRepo:       userRepo,
+		cipher:         cipher,
+		auditor:        auditor,
+		contextTimeout: timeout,
+	}
+}
+
+// validateWebhook checks the URL and event types a webhook is saved with.
+func validateWebhook(rawURL string, eventTypes []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", entity.ErrInvalidWebhook)
+	}
+	if len(eventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", entity.ErrInvalidWebhook)
+	}
+	for _, t := range eventTypes {
+		if !slices.Contains(entity.WebhookEventTypes, t) {
+			return fmt.Errorf("%w: unknown event type %q", entity.ErrInvalidWebhook, t)
+		}
+	}
+	return nil
+}
+
+func (uc *webhookUsecase) CreateWebhook(ctx context.Context, adminID int64, webhook *entity.Webhook) error {
+	ctx, span := tracing.Start(ctx, "WebhookUsecase.CreateWebhook")
+	defer span.End()
+
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	webhook.EventTypes = slices.Compact(slices.Sorted(slices.Values(webhook.EventTypes)))
+	if err := validateWebhook(webhook.URL, webhook.EventTypes); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if webhook.OrganizerID != nil {
+		span.SetAttributes(attribute.Int64("organizer_id", *webhook.OrganizerID))
+		organizer, err := uc.userRepo.GetUserByID(ctx, int(*webhook.OrganizerID))
+		if err != nil {
+			return entity.ErrNotFound
+		}
+		if organizer.Role != "organizer" {
+			return fmt.Errorf("%w: webhooks can only be added for organizers", entity.ErrInvalidWebhook)
+		}
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to generate webhook secret", logger.Err(err))
+		return err
+	}
+	secret := webhookSecretPrefix + hex.EncodeToString(buf)
+	encrypted, err := uc.cipher.Encrypt(secret)
+	if err != nil {
+		logger.FromContext(ctx).Error("usecase: failed to encrypt webhook secret", logger.Err(err))
+		return err
+	}
+
+	webhook.SecretEncrypted = encrypted
+	webhook.Active = true
+	webhook.CreatedBy = adminID
+	if err := uc.webhookRepo.CreateWebhook(ctx, webhook); err != nil {
+		return err
+	}
+	webhook.Secret = secret
+
+	uc.auditor.Record(ctx, ActionWebhookCreate, "webhook", webhook.ID, map[string]interface{}{
+		"organizer_id": webhook.OrganizerID,
+		"url":          webhook.URL,
+		"event_types":  webhook.EventTypes,
+	})
+
+	logger.FromContext(ctx).Info("usecase: webhook created",
+		logger.Int64("webhook_id", webhook.ID),
+		logger.String("url", webhook.URL),
+	)
+	return nil
+}
+
+func (uc *webhookUsecase) ListWebhooks(ctx context.Context, organizerID *int64) ([]entity.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	return uc.webhookRepo.ListWebhooks(ctx, organizerID)
+}
+
+func (uc *webhookUsecase) UpdateWebhook(ctx context.Context, webhookID int64, update entity.WebhookUpdate) (*entity.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	webhook, err := uc.webhookRepo.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	before := webhookAuditState(webhook)
+
+	if update.URL != nil {
+		webhook.URL = strings.TrimSpace(*update.URL)
+	}
+	if update.EventTypes != nil {
+		webhook.EventTypes = slices.Compact(slices.Sorted(slices.Values(update.EventTypes)))
+	}
+	if update.Active != nil {
+		webhook.Active = *update.Active
+	}
+	if err := validateWebhook(webhook.URL, webhook.EventTypes); err != nil {
+		return nil, err
+	}
+
+	if err := uc.webhookRepo.UpdateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	uc.auditor.RecordChange(ctx, ActionWebhookUpdate, "webhook", webhookID, before, webhookAuditState(webhook))
+
+	logger.FromContext(ctx).Info("usecase: webhook updated", logger.Int64("webhook_id", webhookID))
+	return webhook, nil
+}
+
+// webhookAuditState is what the audit log keeps of a webhook before and
+// after a change. The secret never changes and is left out.
+func webhookAuditState(w *entity.Webhook) map[string]interface{} {
+	return map[string]interface{}{
+		"url":         w.URL,
+		"event_types": w.EventTypes,
+		"active":      w.Active,
+	}
+}
+
+func (uc *webhookUsecase) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.webhookRepo.DeleteWebhook(ctx, webhookID); err != nil {
+		return err
+	}
+
+	uc.auditor.Record(ctx, ActionWebhookDelete, "webhook", webhookID, nil)
+
+	logger.FromContext(ctx).Info("usecase: webhook deleted", logger.Int64("webhook_id", webhookID))
+	return nil
+}
+
+func (uc *webhookUsecase) ListDeliveries(ctx context.Context, webhookID int64, page, limit int) ([]entity.WebhookDelivery, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.webhookRepo.GetWebhook(ctx, webhookID); err != nil {
+		return nil, 0, err
+	}
+	return uc.webhookRepo.ListDeliveries(ctx, webhookID, page, limit)
+}
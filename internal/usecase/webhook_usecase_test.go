@@ -0,0 +1,167 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase"
+	"ticres/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookUsecase_CreateWebhook(t *testing.T) {
+	adminID := int64(1)
+	organizerID := int64(7)
+
+	t.Run("Success - Secret Returned Once, Stored Encrypted", func(t *testing.T) {
+		mockRepo := new(mocks.MockWebhookRepo)
+		mockUserRepo := new(mocks.MockUserRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+		cipher := newTestCipher(t)
+
+		var stored string
+		mockUserRepo.On("GetUserByID", mock.Anything, 7).Return(&entity.User{ID: 7, Role: "organizer"}, nil).Once()
+		mockRepo.On("CreateWebhook", mock.Anything, mock.AnythingOfType("*entity.Webhook")).
+			Run(func(args mock.Arguments) {
+				w := args.Get(1).(*entity.Webhook)
+				w.ID = 3
+				stored = w.SecretEncrypted
+			}).Return(nil).Once()
+		mockAudit.On("Record", mock.Anything, usecase.ActionWebhookCreate, "webhook", int64(3), mock.Anything).Once()
+
+		u := usecase.NewWebhookUsecase(mockRepo, mockUserRepo, cipher, mockAudit, time.Second*2)
+		webhook := &entity.Webhook{
+			OrganizerID: &organizerID,
+			URL:         " https://crm.example.com/hooks ",
+			EventTypes:  []string{entity.WebhookPaymentCompleted, entity.WebhookBookingCreated, entity.WebhookPaymentCompleted},
+		}
+		err := u.CreateWebhook(context.Background(), adminID, webhook)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(webhook.Secret, "whsec_"))
+		assert.Equal(t, "https://crm.example.com/hooks", webhook.URL)
+		assert.Equal(t, []string{entity.WebhookBookingCreated, entity.WebhookPaymentCompleted}, webhook.EventTypes)
+		assert.True(t, webhook.Active)
+		assert.Equal(t, adminID, webhook.CreatedBy)
+		assert.NotContains(t, stored, webhook.Secret)
+		decrypted, err := cipher.Decrypt(stored)
+		assert.NoError(t, err)
+		assert.Equal(t, webhook.Secret, decrypted)
+		mockRepo.AssertExpectations(t)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Invalid URL Or Event Type", func(t *testing.T) {
+		for _, webhook := range []*entity.Webhook{
+			{URL: "ftp://crm.example.com", EventTypes: []string{entity.WebhookBookingCreated}},
+			{URL: "/hooks", EventTypes: []string{entity.WebhookBookingCreated}},
+			{URL: "https://crm.example.com", EventTypes: nil},
+			{URL: "https://crm.example.com", EventTypes: []string{"booking.deleted"}},
+		} {
+			mockRepo := new(mocks.MockWebhookRepo)
+			u := usecase.NewWebhookUsecase(mockRepo, new(mocks.MockUserRepo), newTestCipher(t), new(mocks.MockAuditUsecase), time.Second*2)
+			err := u.CreateWebhook(context.Background(), adminID, webhook)
+
+			assert.ErrorIs(t, err, entity.ErrInvalidWebhook)
+			mockRepo.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("Failed - Owner Is Not An Organizer", func(t *testing.T) {
+		mockRepo := new(mocks.MockWebhookRepo)
+		mockUserRepo := new(mocks.MockUserRepo)
+		mockUserRepo.On("GetUserByID", mock.Anything, 7).Return(&entity.User{ID: 7, Role: "user"}, nil).Once()
+
+		u := usecase.NewWebhookUsecase(mockRepo, mockUserRepo, newTestCipher(t), new(mocks.MockAuditUsecase), time.Second*2)
+		err := u.CreateWebhook(context.Background(), adminID, &entity.Webhook{
+			OrganizerID: &organizerID,
+			URL:         "https://crm.example.com/hooks",
+			EventTypes:  []string{entity.WebhookBookingRefunded},
+		})
+
+		assert.ErrorIs(t, err, entity.ErrInvalidWebhook)
+		mockRepo.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookUsecase_UpdateWebhook(t *testing.T) {
+	current := func() *entity.Webhook {
+		return &entity.Webhook{ID: 3, URL: "https://crm.example.com/hooks", EventTypes: []string{entity.WebhookBookingCreated}, Active: true}
+	}
+
+	t.Run("Success - Disable Keeps Other Fields", func(t *testing.T) {
+		mockRepo := new(mocks.MockWebhookRepo)
+		mockAudit := new(mocks.MockAuditUsecase)
+		active := false
+
+		mockRepo.On("GetWebhook", mock.Anything, int64(3)).Return(current(), nil).Once()
+		mockRepo.On("UpdateWebhook", mock.Anything, mock.MatchedBy(func(w *entity.Webhook) bool {
+			return !w.Active && w.URL == "https://crm.example.com/hooks" && len(w.EventTypes) == 1
+		})).Return(nil).Once()
+		mockAudit.On("RecordChange", mock.Anything, usecase.ActionWebhookUpdate, "webhook", int64(3),
+			map[string]interface{}{"url": "https://crm.example.com/hooks", "event_types": []string{entity.WebhookBookingCreated}, "active": true},
+			map[string]interface{}{"url": "https://crm.example.com/hooks", "event_types": []string{entity.WebhookBookingCreated}, "active": false},
+		).Once()
+
+		u := usecase.NewWebhookUsecase(mockRepo, new(mocks.MockUserRepo), newTestCipher(t), mockAudit, time.Second*2)
+		webhook, err := u.UpdateWebhook(context.Background(), 3, entity.WebhookUpdate{Active: &active})
+
+		assert.NoError(t, err)
+		assert.False(t, webhook.Active)
+		mockRepo.AssertExpectations(t)
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Empty Event Types", func(t *testing.T) {
+		mockRepo := new(mocks.MockWebhookRepo)
+		mockRepo.On("GetWebhook", mock.Anything, int64(3)).Return(current(), nil).Once()
+
+		u := usecase.NewWebhookUsecase(mockRepo, new(mocks.MockUserRepo), newTestCipher(t), new(mocks.MockAuditUsecase), time.Second*2)
+		_, err := u.UpdateWebhook(context.Background(), 3, entity.WebhookUpdate{EventTypes: []string{}})
+
+		assert.ErrorIs(t, err, entity.ErrInvalidWebhook)
+		mockRepo.AssertNotCalled(t, "UpdateWebhook", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockWebhookRepo)
+		mockRepo.On("GetWebhook", mock.Anything, int64(9)).Return(nil, entity.ErrNotFound).Once()
+
+		u := usecase.NewWebhookUsecase(mockRepo, new(mocks.MockUserRepo), newTestCipher(t), new(mocks.MockAuditUsecase), time.Second*2)
+		_, err := u.UpdateWebhook(context.Background(), 9, entity.WebhookUpdate{})
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+	})
+}
+
+func TestWebhookUsecase_ListDeliveries(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.MockWebhookRepo)
+		deliveries := []entity.WebhookDelivery{{ID: 11, WebhookID: 3, EventType: entity.WebhookPaymentCompleted, Status: entity.WebhookDeliverySucceeded}}
+		mockRepo.On("GetWebhook", mock.Anything, int64(3)).Return(&entity.Webhook{ID: 3}, nil).Once()
+		mockRepo.On("ListDeliveries", mock.Anything, int64(3), 1, 50).Return(deliveries, 1, nil).Once()
+
+		u := usecase.NewWebhookUsecase(mockRepo, new(mocks.MockUserRepo), newTestCipher(t), new(mocks.MockAuditUsecase), time.Second*2)
+		result, total, err := u.ListDeliveries(context.Background(), 3, 1, 50)
+
+		assert.NoError(t, err)
+		assert.Equal(t, deliveries, result)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("Failed - Unknown Webhook", func(t *testing.T) {
+		mockRepo := new(mocks.MockWebhookRepo)
+		mockRepo.On("GetWebhook", mock.Anything, int64(9)).Return(nil, entity.ErrNotFound).Once()
+
+		u := usecase.NewWebhookUsecase(mockRepo, new(mocks.MockUserRepo), newTestCipher(t), new(mocks.MockAuditUsecase), time.Second*2)
+		_, _, err := u.ListDeliveries(context.Background(), 9, 1, 50)
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "ListDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+// AttendanceReporter sends organizers the post-event reports of events that
+// ended.
+type AttendanceReporter interface {
+	GenerateReports(ctx context.Context) (int, error)
+}
+
+// AttendanceReportScheduler periodically reports the attendance of events
+// that ended.
+type AttendanceReportScheduler struct {
+	reporter AttendanceReporter
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewAttendanceReportScheduler(reporter AttendanceReporter, interval time.Duration) *AttendanceReportScheduler {
+	return &AttendanceReportScheduler{
+		reporter: reporter,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (s *AttendanceReportScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		logger.Info("worker: attendance report scheduler started", logger.String("interval", s.interval.String()))
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				logger.Info("worker: attendance report scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (s *AttendanceReportScheduler) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sent, err := s.reporter.GenerateReports(ctx)
+	if err != nil {
+		logger.Error("worker: attendance reports failed", logger.Err(err))
+		return
+	}
+	logger.Debug("worker: attendance reports completed", logger.Int("sent", sent))
+}
+
+func (s *AttendanceReportScheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
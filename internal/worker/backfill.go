@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+)
+
+const (
+	// backfillBudget is how long one job runs batches before handing the
+	// rest to a new job, well inside jobLease so a job still working is
+	// never claimed again.
+	backfillBudget = 5 * time.Minute
+	// backfillPause spaces out batches so booking traffic keeps most of the
+	// database, and so one bulk job doesn't hog a connection.
+	backfillPause = 50 * time.Millisecond
+	// backfillBatchTimeout bounds a single batch.
+	backfillBatchTimeout = 30 * time.Second
+)
+
+// EnqueueBackfill writes the job running a backfill to the outbox, inside
+// the transaction ctx carries, so the job is stored exactly when the
+// backfill is marked RUNNING.
+func (w *NotificationWorker) EnqueueBackfill(ctx context.Context, name string) error {
+	logger.FromContext(ctx).Info("worker: enqueuing backfill", logger.String("backfill", name))
+	job, err := newJob(NotificationPayload{
+		Type:     JobBackfill,
+		Backfill: name,
+	})
+	if err != nil {
+		return err
+	}
+	return w.outboxRepo.AddJob(ctx, &job)
+}
+
+// runBackfill applies batches of the backfill until it completes, the
+// budget runs out or the worker stops; then a new job picks up from the
+// checkpoint. A failed batch fails the job, whose retry resumes from the
+// checkpoint too, and the backfill is marked FAILED once the job is out of
+// attempts.
+func (w *NotificationWorker) runBackfill(ctx context.Context, job entity.Job, name string) error {
+	deadline := time.Now().Add(backfillBudget)
+
+	for {
+		batchCtx, cancel := context.WithTimeout(ctx, backfillBatchTimeout)
+		b, err := w.backfillRepo.RunBackfillBatch(batchCtx, name)
+		cancel()
+		if err != nil {
+			if job.Attempts >= job.MaxAttempts {
+				w.backfillRepo.FailBackfill(ctx, name, err.Error())
+			}
+			return err
+		}
+		if b.Status != entity.BackfillRunning {
+			logger.Info("worker: backfill finished",
+				logger.String("backfill", name),
+				logger.String("status", b.Status),
+				logger.Int64("rows_updated", b.RowsUpdated),
+				logger.Int("batches", b.Batches),
+			)
+			return nil
+		}
+
+		stopping := false
+		select {
+		case <-w.stop:
+			stopping = true
+		case <-time.After(backfillPause):
+		}
+		if stopping || time.Now().After(deadline) {
+			logger.Info("worker: handing backfill over to a new job",
+				logger.String("backfill", name),
+				logger.Int64("last_id", b.LastID),
+				logger.Int64("max_id", b.MaxID),
+			)
+			// Stored straight in the job queue; if that fails this job is
+			// retried instead.
+			next, err := newJob(NotificationPayload{Type: JobBackfill, Backfill: name})
+			if err != nil {
+				return err
+			}
+			return w.jobRepo.Enqueue(ctx, &next)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+	"ticres/pkg/tracing"
+
+	"github.com/google/uuid"
+)
+
+// brokerTimeout bounds a single publish to the message broker.
+const brokerTimeout = 10 * time.Second
+
+// PublishDomainEvent queues a domain event for the message broker. The
+// event gets its ID and time now, so a retried job publishes the same
+// event. Nothing is queued when no broker is configured.
+func (w *NotificationWorker) PublishDomainEvent(ctx context.Context, eventType string, aggregateID int64, data map[string]interface{}) {
+	if w.broker == nil {
+		return
+	}
+	logger.FromContext(ctx).Debug("worker: enqueuing domain event",
+		logger.String("event_type", eventType),
+		logger.Int64("aggregate_id", aggregateID),
+	)
+	w.enqueue(ctx, NotificationPayload{
+		Type: JobDomainEvent,
+		DomainEvent: &entity.DomainEvent{
+			ID:          uuid.NewString(),
+			Type:        eventType,
+			AggregateID: aggregateID,
+			OccurredAt:  w.clock.Now(),
+			Data:        data,
+		},
+	})
+}
+
+// publishDomainEvent sends the event to its aggregate's topic, keyed by the
+// aggregate ID. A failed publish fails the job so it is retried.
+func (w *NotificationWorker) publishDomainEvent(ctx context.Context, event *entity.DomainEvent) error {
+	if event == nil {
+		return nil
+	}
+	if w.broker == nil {
+		logger.Warn("worker: message broker disabled, dropping domain event", logger.String("event_id", event.ID))
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode domain event: %w", err)
+	}
+
+	ctx, span := tracing.Start(ctx, "broker.Publish")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, brokerTimeout)
+	defer cancel()
+
+	if err := w.broker.Publish(ctx, event.Aggregate(), strconv.FormatInt(event.AggregateID, 10), payload); err != nil {
+		return fmt.Errorf("publish domain event: %w", err)
+	}
+
+	logger.Debug("worker: domain event published",
+		logger.String("event_id", event.ID),
+		logger.String("event_type", event.Type),
+	)
+	return nil
+}
@@ -0,0 +1,209 @@
+package worker
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/email"
+	"ticres/pkg/i18n"
+)
+
+// Every locale has its own directory with a layout.html, and for each email
+// an HTML body and a text file defining its "subject" and plain "text".
+// Templates translate shared labels, such as payment methods, from the API
+// message catalogs with the "t" function.
+//
+//go:embed templates
+var templateFS embed.FS
+
+// DefaultEmailLocale is the locale every email has a template in. Emails
+// missing from another locale fall back to it.
+const DefaultEmailLocale = "id"
+
+const (
+	tmplBookingConfirmation = "booking_confirmation"
+	tmplBookingCancelled    = "booking_cancelled"
+	tmplPaymentReceipt      = "payment_receipt"
+	tmplRefundNotice        = "refund_notice"
+	tmplNotification        = "notification"
+	tmplPasswordReset       = "password_reset"
+	tmplUpgradeOffer        = "upgrade_offer"
+	tmplTicketsReissued     = "tickets_reissued"
+)
+
+// emailTemplate is one email in one locale.
+type emailTemplate struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// emailTemplates holds the parsed templates by locale and email name, each
+// HTML body wrapped in its locale's layout.
+var emailTemplates = parseEmailTemplates(
+	tmplBookingConfirmation,
+	tmplBookingCancelled,
+	tmplPaymentReceipt,
+	tmplRefundNotice,
+	tmplNotification,
+	tmplPasswordReset,
+	tmplUpgradeOffer,
+	tmplTicketsReissued,
+)
+
+func parseEmailTemplates(names ...string) map[string]map[string]emailTemplate {
+	dirs, err := templateFS.ReadDir("templates")
+	if err != nil {
+		panic(err)
+	}
+	locales := make(map[string]map[string]emailTemplate, len(dirs))
+	for _, dir := range dirs {
+		locale := dir.Name()
+		root := "templates/" + locale + "/"
+		translate := func(message string) string { return i18n.T(locale, message) }
+		templates := make(map[string]emailTemplate, len(names))
+		for _, name := range names {
+			if _, err := fs.Stat(templateFS, root+name+".html"); err != nil && locale != DefaultEmailLocale {
+				continue
+			}
+			templates[name] = emailTemplate{
+				html: htmltemplate.Must(htmltemplate.New(name).Funcs(htmltemplate.FuncMap{"t": translate}).ParseFS(templateFS, root+"layout.html", root+name+".html")),
+				text: texttemplate.Must(texttemplate.New(name).Funcs(texttemplate.FuncMap{"t": translate}).ParseFS(templateFS, root+name+".txt")),
+			}
+		}
+		locales[locale] = templates
+	}
+	return locales
+}
+
+// HasEmailLocale reports whether emails can be written in the locale.
+func HasEmailLocale(locale string) bool {
+	_, ok := emailTemplates[locale]
+	return ok
+}
+
+// Timestamps in emails are shown in Western Indonesian Time.
+var emailLocation = time.FixedZone("WIB", 7*60*60)
+
+type bookingConfirmationData struct {
+	Name      string
+	BookingID int64
+	EventName string
+	EventDate string
+	Location  string
+	Amount    string
+	ExpiresAt string
+}
+
+type paymentReceiptData struct {
+	Name          string
+	BookingID     int64
+	Reference     string
+	InvoiceNumber string
+	PaymentMethod string
+	PaidAt        string
+	EventName     string
+	EventDate     string
+	Location      string
+	Amount        string
+	Tickets       []entity.Ticket
+}
+
+type ticketsReissuedData struct {
+	Name      string
+	BookingID int64
+	EventName string
+	EventDate string
+	Location  string
+	Tickets   []entity.Ticket
+}
+
+type refundNoticeData struct {
+	Name      string
+	BookingID int64
+	EventName string
+	Amount    string
+	Reason    string
+	// EventCancelled replaces the reason with the event's cancellation
+	EventCancelled bool
+}
+
+type bookingCancelledData struct {
+	Name      string
+	BookingID int64
+	EventName string
+	EventDate string
+}
+
+type notificationData struct {
+	Message string
+}
+
+type passwordResetData struct {
+	Link string
+}
+
+type upgradeOfferData struct {
+	Name      string
+	BookingID int64
+	EventName string
+	EventDate string
+	FromSeat  string
+	ToSeat    string
+	Amount    string
+	ExpiresAt string
+	Link      string
+}
+
+// renderEmail writes the named email in the locale, or in the default
+// locale when the locale doesn't have it.
+func renderEmail(locale, to, name string, data interface{}) (email.Message, error) {
+	tmpl, ok := emailTemplates[locale][name]
+	if !ok {
+		tmpl, ok = emailTemplates[DefaultEmailLocale][name]
+		if !ok {
+			return email.Message{}, fmt.Errorf("unknown email template %q", name)
+		}
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return email.Message{}, err
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "text", data); err != nil {
+		return email.Message{}, err
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return email.Message{}, err
+	}
+	return email.Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    html.String(),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
+
+// formatRupiah renders an amount as Indonesian currency, e.g. Rp 150.000.
+func formatRupiah(amount float64) string {
+	digits := strconv.FormatInt(int64(amount+0.5), 10)
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	return "Rp " + b.String()
+}
+
+func formatEmailTime(t time.Time) string {
+	return t.In(emailLocation).Format("02 Jan 2006 15:04 WIB")
+}
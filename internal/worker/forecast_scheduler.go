@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+// LaggingEventNotifier checks upcoming events and nudges organizers whose sales lag target.
+type LaggingEventNotifier interface {
+	NotifyLaggingEvents(ctx context.Context) (int, error)
+}
+
+// ForecastScheduler periodically runs the marketing-boost check.
+type ForecastScheduler struct {
+	notifier LaggingEventNotifier
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewForecastScheduler(notifier LaggingEventNotifier, interval time.Duration) *ForecastScheduler {
+	return &ForecastScheduler{
+		notifier: notifier,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (s *ForecastScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		logger.Info("worker: forecast scheduler started", logger.String("interval", s.interval.String()))
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				logger.Info("worker: forecast scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (s *ForecastScheduler) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sent, err := s.notifier.NotifyLaggingEvents(ctx)
+	if err != nil {
+		logger.Error("worker: marketing boost check failed", logger.Err(err))
+		return
+	}
+	logger.Debug("worker: marketing boost check completed", logger.Int("sent", sent))
+}
+
+func (s *ForecastScheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+// PaymentsRestoredNotifier tells customers held during a payment gateway
+// outage that they can pay again.
+type PaymentsRestoredNotifier interface {
+	NotifyPaymentsRestored(ctx context.Context) (int, error)
+}
+
+// GatewayRecoveryScheduler periodically checks whether the payment gateway
+// is back and, once it is, emails the customers whose bookings were held
+// while it was down.
+type GatewayRecoveryScheduler struct {
+	notifier PaymentsRestoredNotifier
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewGatewayRecoveryScheduler(notifier PaymentsRestoredNotifier, interval time.Duration) *GatewayRecoveryScheduler {
+	return &GatewayRecoveryScheduler{
+		notifier: notifier,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (s *GatewayRecoveryScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		logger.Info("worker: gateway recovery scheduler started", logger.String("interval", s.interval.String()))
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				logger.Info("worker: gateway recovery scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (s *GatewayRecoveryScheduler) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	notified, err := s.notifier.NotifyPaymentsRestored(ctx)
+	if err != nil {
+		logger.Error("worker: gateway recovery run failed", logger.Err(err))
+		return
+	}
+	if notified > 0 {
+		logger.Info("worker: customers told payments work again", logger.Int("count", notified))
+	}
+}
+
+func (s *GatewayRecoveryScheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
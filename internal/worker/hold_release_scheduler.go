@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+// HoldReleaser frees the seats of bookings left unpaid past their deadline.
+type HoldReleaser interface {
+	ReleaseLapsedHolds(ctx context.Context) (int, error)
+}
+
+// HoldReleaseScheduler periodically releases lapsed holds so their seats
+// show as available again without waiting for a payment attempt.
+type HoldReleaseScheduler struct {
+	holds    HoldReleaser
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewHoldReleaseScheduler(holds HoldReleaser, interval time.Duration) *HoldReleaseScheduler {
+	return &HoldReleaseScheduler{
+		holds:    holds,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (s *HoldReleaseScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		logger.Info("worker: hold release scheduler started", logger.String("interval", s.interval.String()))
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				logger.Info("worker: hold release scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (s *HoldReleaseScheduler) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	released, err := s.holds.ReleaseLapsedHolds(ctx)
+	if err != nil {
+		logger.Error("worker: hold release run failed", logger.Err(err))
+		return
+	}
+	if released > 0 {
+		logger.Info("worker: lapsed holds released", logger.Int("count", released))
+	}
+}
+
+func (s *HoldReleaseScheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ticres/internal/entity"
+	"ticres/pkg/i18n"
+	"ticres/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Inbox kinds, one per job type that notifies a single user.
+const (
+	InboxNotification        = "notification"
+	InboxBookingConfirmation = "booking_confirmation"
+	InboxPaymentReceipt      = "payment_receipt"
+	InboxTicketsReissued     = "tickets_reissued"
+	InboxUpgradeOffer        = "upgrade_offer"
+)
+
+// addToInbox stores the in-app counterpart of the job's email. It runs before
+// the email is sent and is keyed by the job, so a job retried after a failed
+// send adds its notification once. Jobs without one, such as password resets
+// or bulk refunds, are left alone.
+func (w *NotificationWorker) addToInbox(ctx context.Context, job entity.Job, p NotificationPayload) error {
+	var (
+		userID    int64
+		bookingID = p.BookingID
+		kind      string
+		message   i18n.Message
+	)
+
+	switch JobType(job.Type) {
+	case JobNotification:
+		// Notifications address an email, which may not belong to an account
+		user, err := w.userRepo.GetUserByEmail(ctx, p.UserEmail)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+		userID, kind = user.ID, InboxNotification
+		message = i18n.Message{Text: p.Message, Args: p.MessageArgs}
+	case JobBookingConfirmation, JobPaymentReceipt, JobTicketsReissued:
+		booking, err := w.bookingRepo.GetBookingByID(ctx, p.BookingID)
+		if err != nil {
+			return fmt.Errorf("get booking: %w", err)
+		}
+		userID = booking.UserID
+		switch JobType(job.Type) {
+		case JobBookingConfirmation:
+			kind, message = InboxBookingConfirmation, i18n.Msg("Booking #%s confirmed", booking.ID)
+		case JobPaymentReceipt:
+			kind, message = InboxPaymentReceipt, i18n.Msg("Payment received for booking #%s", booking.ID)
+		default:
+			kind, message = InboxTicketsReissued, i18n.Msg("Your tickets for booking #%s were reissued", booking.ID)
+		}
+	case JobUpgradeOffer:
+		offer, err := w.offerRepo.GetOfferByID(ctx, p.OfferID)
+		if err != nil {
+			return fmt.Errorf("get upgrade offer: %w", err)
+		}
+		// Closed offers are not emailed either
+		if offer.Status != entity.UpgradeOfferPending {
+			return nil
+		}
+		booking, err := w.bookingRepo.GetBookingByID(ctx, offer.BookingID)
+		if err != nil {
+			return fmt.Errorf("get booking: %w", err)
+		}
+		userID, bookingID = booking.UserID, offer.BookingID
+		kind, message = InboxUpgradeOffer, i18n.Msg("You have a seat upgrade offer for booking #%s", offer.BookingID)
+	default:
+		return nil
+	}
+
+	jobID := job.ID
+	n := &entity.Notification{
+		UserID:      userID,
+		Kind:        kind,
+		Message:     message.Text,
+		MessageArgs: message.Args,
+		JobID:       &jobID,
+	}
+	if bookingID != 0 {
+		n.BookingID = &bookingID
+	}
+	if err := w.inboxRepo.CreateNotification(ctx, n); err != nil {
+		return fmt.Errorf("add to inbox: %w", err)
+	}
+	logger.Debug("worker: notification added to inbox",
+		logger.Int64("user_id", userID),
+		logger.String("kind", kind),
+		logger.Int64("job_id", job.ID),
+	)
+	return nil
+}
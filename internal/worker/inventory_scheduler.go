@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+)
+
+// InventoryChecker verifies the seat accounting invariants and pages ops on
+// violations.
+type InventoryChecker interface {
+	CheckInventory(ctx context.Context) ([]entity.InventoryViolation, error)
+}
+
+// InventoryScheduler runs the oversell invariant check continuously.
+type InventoryScheduler struct {
+	checker  InventoryChecker
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewInventoryScheduler(checker InventoryChecker, interval time.Duration) *InventoryScheduler {
+	return &InventoryScheduler{
+		checker:  checker,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (s *InventoryScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		logger.Info("worker: inventory scheduler started", logger.String("interval", s.interval.String()))
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				logger.Info("worker: inventory scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (s *InventoryScheduler) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	defer cancel()
+
+	violations, err := s.checker.CheckInventory(ctx)
+	if err != nil {
+		logger.Error("worker: inventory check failed", logger.Err(err))
+		return
+	}
+	logger.Debug("worker: inventory check completed", logger.Int("violations", len(violations)))
+}
+
+func (s *InventoryScheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
@@ -2,100 +2,744 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"ticres/internal/entity"
 	"ticres/internal/repository"
+	"ticres/internal/usecase"
+	"ticres/pkg/alert"
+	"ticres/pkg/broker"
+	"ticres/pkg/clock"
+	"ticres/pkg/email"
+	"ticres/pkg/i18n"
 	"ticres/pkg/logger"
+	"ticres/pkg/payment"
+	"ticres/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
-type JobType int
+// emailSendTimeout bounds a single delivery attempt to the mail provider.
+const emailSendTimeout = 15 * time.Second
+
+const (
+	pollInterval = time.Second
+	// jobLease is how long a claimed job may run before another worker may
+	// assume it crashed and claim it again. Delivery is at-least-once.
+	jobLease       = 15 * time.Minute
+	maxJobAttempts = 5
+	retryBaseDelay = 10 * time.Second
+	retryMaxDelay  = time.Hour
+	// outboxBatch caps the outbox entries moved into the job queue at once.
+	outboxBatch = 100
+	// backlogAlertInterval spaces out alerts while the outbox stays backed up.
+	backlogAlertInterval = 5 * time.Minute
+)
 
+// What enqueueing does while the outbox is backed up.
 const (
-	JobNotification JobType = iota
-	JobRefund
+	// OverflowPersist stores the job anyway.
+	OverflowPersist = "persist"
+	// OverflowDrop discards the job so callers never wait on a struggling
+	// database.
+	OverflowDrop = "drop"
 )
 
+// QueueConfig bounds how jobs are queued. Storing a job may take up to
+// EnqueueTimeout. Once the outbox holds OutboxLimit entries, or its oldest
+// entry is OutboxMaxAge old, it counts as backed up: ops are alerted
+// through Notifier and Overflow applies to new jobs. A zero limit is not
+// checked.
+type QueueConfig struct {
+	EnqueueTimeout time.Duration
+	OutboxLimit    int
+	OutboxMaxAge   time.Duration
+	Overflow       string
+	Notifier       alert.Notifier
+}
+
+// laneWorkers is how many jobs of each lane run at once. Every lane has its
+// own workers, so a broadcast can't delay a receipt, and the higher lanes get
+// the larger share of the mail provider.
+var laneWorkers = []struct {
+	lane    string
+	workers int
+}{
+	{entity.JobLaneTransactional, 4},
+	{entity.JobLaneReminder, 2},
+	{entity.JobLaneBulk, 1},
+}
+
+type JobType string
+
+const (
+	JobNotification        JobType = "notification"
+	JobRefund              JobType = "refund"
+	JobPasswordReset       JobType = "password_reset"
+	JobBookingConfirmation JobType = "booking_confirmation"
+	JobPaymentReceipt      JobType = "payment_receipt"
+	JobUpgradeOffer        JobType = "upgrade_offer"
+	JobRefundRequest       JobType = "refund_request"
+	JobTicketsReissued     JobType = "tickets_reissued"
+	JobBackfill            JobType = "backfill"
+	JobUserExport          JobType = "user_export"
+	JobWebhookEvent        JobType = "webhook_event"
+	JobWebhookDelivery     JobType = "webhook_delivery"
+	JobDomainEvent         JobType = "domain_event"
+)
+
+// jobLanes assigns each job type its lane. Types not listed are transactional,
+// including the refunds of a cancelled event: they move customers' money and
+// must not wait behind backfills and exports.
+var jobLanes = map[JobType]string{
+	JobUpgradeOffer: entity.JobLaneReminder,
+	JobBackfill:     entity.JobLaneBulk,
+	JobUserExport:   entity.JobLaneBulk,
+	// Receivers may be slow, so they never hold up transactional email
+	JobWebhookEvent:    entity.JobLaneReminder,
+	JobWebhookDelivery: entity.JobLaneReminder,
+	JobDomainEvent:     entity.JobLaneReminder,
+}
+
+func laneOf(t JobType) string {
+	if lane, ok := jobLanes[t]; ok {
+		return lane
+	}
+	return entity.JobLaneTransactional
+}
+
+// NotificationPayload is stored as the job's JSON payload.
 type NotificationPayload struct {
-	Type      JobType
-	BookingID int64
-	UserEmail string
-	Message   string
-	EventID   int64
+	Type      JobType `json:"-"`
+	BookingID int64   `json:"booking_id,omitempty"`
+	UserEmail string  `json:"user_email,omitempty"`
+	Message   string  `json:"message,omitempty"`
+	EventID   int64   `json:"event_id,omitempty"`
+	OfferID   int64   `json:"offer_id,omitempty"`
+	RefundID  int64   `json:"refund_id,omitempty"`
+	// MessageArgs fill the %s placeholders in Message once it is translated
+	MessageArgs []string `json:"message_args,omitempty"`
+	Backfill    string   `json:"backfill,omitempty"`
+	ExportID    int64    `json:"export_id,omitempty"`
+	// WebhookEvent is the lifecycle event a webhook event job fans out
+	WebhookEvent string `json:"webhook_event,omitempty"`
+	DeliveryID   int64  `json:"delivery_id,omitempty"`
+	// DomainEvent is published to the message broker as is
+	DomainEvent *entity.DomainEvent `json:"domain_event,omitempty"`
+}
+
+// AuditRecorder records system actions performed by the worker.
+type AuditRecorder interface {
+	Record(ctx context.Context, action, entityType string, entityID int64, details map[string]interface{})
 }
 
+// NotificationWorker processes notification and refund jobs from the
+// persistent job queue, each priority lane with its own workers. Failed jobs
+// are retried with exponential backoff and moved to the dead-letter list once
+// they run out of attempts.
 type NotificationWorker struct {
-	JobQueue        chan NotificationPayload
+	jobRepo         repository.JobRepository
+	outboxRepo      repository.OutboxRepository
+	stop            chan struct{}
 	wg              sync.WaitGroup
+	queue           QueueConfig
+	backlogged      atomic.Bool
+	overflowed      atomic.Int64
+	dropped         atomic.Int64
+	lastAlert       time.Time
 	userRepo        repository.UserRepository
 	bookingRepo     repository.BookingRepository
 	transactionRepo repository.TransactionRepository
 	refundRepo      repository.RefundRepository
+	eventRepo       repository.EventRepository
+	ticketRepo      repository.TicketRepository
+	offerRepo       repository.UpgradeOfferRepository
+	backfillRepo    repository.BackfillRepository
+	exportRepo      repository.UserExportRepository
+	inboxRepo       repository.NotificationRepository
+	webhookRepo     repository.WebhookRepository
+	webhookCipher   usecase.WebhookCipher
+	webhookClient   *http.Client
+	broker          broker.Publisher
+	gateway         payment.Gateway
+	auditor         AuditRecorder
+	mailer          email.Sender
+	// locale is the language emails are written in
+	locale string
+	clock  clock.Clock
 }
 
 func NewNotificationWorker(
+	jobRepo repository.JobRepository,
+	outboxRepo repository.OutboxRepository,
 	uRepo repository.UserRepository,
 	bRepo repository.BookingRepository,
 	txnRepo repository.TransactionRepository,
 	refundRepo repository.RefundRepository,
+	eventRepo repository.EventRepository,
+	ticketRepo repository.TicketRepository,
+	offerRepo repository.UpgradeOfferRepository,
+	backfillRepo repository.BackfillRepository,
+	exportRepo repository.UserExportRepository,
+	inboxRepo repository.NotificationRepository,
+	webhookRepo repository.WebhookRepository,
+	webhookCipher usecase.WebhookCipher,
+	eventBroker broker.Publisher,
+	gateway payment.Gateway,
+	auditor AuditRecorder,
+	mailer email.Sender,
+	locale string,
+	clk clock.Clock,
+	queue QueueConfig,
 ) *NotificationWorker {
+	if !HasEmailLocale(locale) {
+		logger.Warn("worker: no email templates for locale, using default",
+			logger.String("locale", locale),
+			logger.String("default", DefaultEmailLocale),
+		)
+		locale = DefaultEmailLocale
+	}
+	if queue.EnqueueTimeout <= 0 {
+		queue.EnqueueTimeout = time.Second
+	}
+	if queue.Notifier == nil {
+		queue.Notifier = alert.LogNotifier{}
+	}
 	return &NotificationWorker{
-		JobQueue:        make(chan NotificationPayload, 100),
+		jobRepo:         jobRepo,
+		outboxRepo:      outboxRepo,
+		stop:            make(chan struct{}),
+		queue:           queue,
 		userRepo:        uRepo,
 		bookingRepo:     bRepo,
 		transactionRepo: txnRepo,
 		refundRepo:      refundRepo,
+		eventRepo:       eventRepo,
+		ticketRepo:      ticketRepo,
+		offerRepo:       offerRepo,
+		backfillRepo:    backfillRepo,
+		exportRepo:      exportRepo,
+		inboxRepo:       inboxRepo,
+		webhookRepo:     webhookRepo,
+		webhookCipher:   webhookCipher,
+		webhookClient:   &http.Client{},
+		broker:          eventBroker,
+		gateway:         gateway,
+		auditor:         auditor,
+		mailer:          mailer,
+		locale:          locale,
+		clock:           clk,
 	}
 }
 
 func (w *NotificationWorker) Start() {
 	w.wg.Add(1)
-	go func() {
-		defer w.wg.Done()
-		logger.Info("worker: notification worker started")
+	go w.dispatchOutbox()
 
-		for job := range w.JobQueue {
-			w.processJob(job)
+	for _, l := range laneWorkers {
+		for i := 0; i < l.workers; i++ {
+			w.wg.Add(1)
+			go w.runLane(l.lane)
 		}
+	}
+	logger.Info("worker: notification worker started")
+}
+
+// runLane polls one lane until the worker stops.
+func (w *NotificationWorker) runLane(lane string) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
 
-		logger.Info("worker: notification worker stopped")
-	}()
+	for {
+		select {
+		case <-ticker.C:
+			w.poll(lane)
+		case <-w.stop:
+			return
+		}
+	}
 }
 
-func (w *NotificationWorker) processJob(job NotificationPayload) {
-	if job.Type == JobNotification {
-		w.sendEmailLog(job.UserEmail, job.BookingID, job.Message)
-	} else if job.Type == JobRefund {
-		w.processEventRefund(job.EventID)
+// poll drains the lane's due jobs until it is empty or the worker stops. Jobs
+// are claimed one at a time so the lane's other workers can share the load.
+func (w *NotificationWorker) poll(lane string) {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		jobs, err := w.jobRepo.ClaimJobs(ctx, lane, 1, jobLease)
+		cancel()
+		if err != nil || len(jobs) == 0 {
+			return
+		}
+
+		for _, job := range jobs {
+			w.runJob(job)
+		}
+
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
 	}
 }
 
-func (w *NotificationWorker) sendEmailLog(email string, bookingID int64, message string) {
-	logger.Debug("worker: sending email",
-		logger.String("email", email),
-		logger.Int64("booking_id", bookingID),
-		logger.String("message", message),
+// runJob processes one job in its own root span. Jobs are enqueued without
+// a request context, so their traces start here.
+func (w *NotificationWorker) runJob(job entity.Job) {
+	ctx, span := tracing.Start(context.Background(), "job "+job.Type,
+		attribute.Int64("job.id", job.ID),
+		attribute.String("job.type", job.Type),
+		attribute.String("job.lane", job.Lane),
+		attribute.Int("job.attempt", job.Attempts),
 	)
-	time.Sleep(1 * time.Second) // Simulate email delay
+	defer span.End()
+
+	err := w.processJob(ctx, job)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	if err == nil {
+		if err := w.jobRepo.CompleteJob(ctx, job.ID); err != nil {
+			logger.Error("worker: failed to mark job complete", logger.Int64("job_id", job.ID), logger.Err(err))
+		}
+		return
+	}
+
+	if job.Attempts >= job.MaxAttempts {
+		logger.Error("worker: job failed permanently, moved to dead letter",
+			logger.Int64("job_id", job.ID),
+			logger.String("job_type", job.Type),
+			logger.Int("attempts", job.Attempts),
+			logger.Err(err),
+		)
+		w.jobRepo.KillJob(ctx, job.ID, err.Error())
+		return
+	}
+
+	delay := retryDelay(job.Attempts)
+	logger.Warn("worker: job failed, retrying",
+		logger.Int64("job_id", job.ID),
+		logger.String("job_type", job.Type),
+		logger.Int("attempts", job.Attempts),
+		logger.String("retry_in", delay.String()),
+		logger.Err(err),
+	)
+	w.jobRepo.RetryJob(ctx, job.ID, w.clock.Now().Add(delay), err.Error())
+}
+
+// retryDelay doubles the wait after every failed attempt, up to retryMaxDelay.
+func retryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	delay := retryBaseDelay << (attempts - 1)
+	if delay <= 0 || delay > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return delay
+}
+
+func (w *NotificationWorker) processJob(ctx context.Context, job entity.Job) error {
+	var p NotificationPayload
+	if err := json.Unmarshal(job.Payload, &p); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	if err := w.addToInbox(ctx, job, p); err != nil {
+		return err
+	}
+
+	switch JobType(job.Type) {
+	case JobNotification:
+		return w.sendNotificationEmail(ctx, p.UserEmail, p.BookingID, i18n.Message{Text: p.Message, Args: p.MessageArgs})
+	case JobRefund:
+		return w.processEventRefund(ctx, p.EventID)
+	case JobPasswordReset:
+		return w.sendPasswordResetEmail(ctx, p.UserEmail, p.Message)
+	case JobBookingConfirmation:
+		return w.sendBookingConfirmation(ctx, p.UserEmail, p.BookingID)
+	case JobPaymentReceipt:
+		return w.sendPaymentReceipt(ctx, p.BookingID)
+	case JobUpgradeOffer:
+		return w.sendUpgradeOffer(ctx, p.UserEmail, p.OfferID, p.Message)
+	case JobRefundRequest:
+		return w.processRefundRequest(ctx, p.RefundID)
+	case JobTicketsReissued:
+		return w.sendTicketsReissued(ctx, p.BookingID)
+	case JobBackfill:
+		return w.runBackfill(ctx, job, p.Backfill)
+	case JobUserExport:
+		return w.buildUserExport(ctx, job, p.ExportID)
+	case JobWebhookEvent:
+		return w.fanOutWebhookEvent(ctx, job, p.WebhookEvent, p.BookingID)
+	case JobWebhookDelivery:
+		return w.deliverWebhook(ctx, job, p.DeliveryID)
+	case JobDomainEvent:
+		return w.publishDomainEvent(ctx, p.DomainEvent)
+	}
+	return fmt.Errorf("unknown job type %q", job.Type)
+}
+
+// newJob encodes the payload as a job in its type's lane.
+func newJob(p NotificationPayload) (entity.Job, error) {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return entity.Job{}, fmt.Errorf("encode job payload: %w", err)
+	}
+	return entity.Job{Type: string(p.Type), Lane: laneOf(p.Type), Payload: payload, MaxAttempts: maxJobAttempts}, nil
+}
+
+// enqueue writes a job to the outbox before returning. Inside a unit of
+// work the job commits or rolls back with the caller's transaction; outside
+// one it is stored right away. A caller that goes away while the job is
+// being stored doesn't cancel it, and no caller waits longer than the
+// enqueue timeout. While the outbox is backed up the overflow mode applies.
+// Callers fire and forget, so a failure to store the job can only be logged.
+func (w *NotificationWorker) enqueue(ctx context.Context, p NotificationPayload) {
+	job, err := newJob(p)
+	if err != nil {
+		logger.FromContext(ctx).Error("worker: failed to encode job payload", logger.String("job_type", string(p.Type)), logger.Err(err))
+		return
+	}
+
+	if w.backlogged.Load() {
+		w.overflowed.Add(1)
+		if w.queue.Overflow == OverflowDrop {
+			w.dropped.Add(1)
+			logger.FromContext(ctx).Error("worker: outbox backed up, job dropped",
+				logger.String("job_type", job.Type),
+				logger.Int64("booking_id", p.BookingID),
+				logger.Int64("event_id", p.EventID),
+			)
+			return
+		}
+		logger.FromContext(ctx).Warn("worker: outbox backed up, storing job anyway", logger.String("job_type", job.Type))
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), w.queue.EnqueueTimeout)
+	defer cancel()
+	if err := w.outboxRepo.AddJob(ctx, &job); err != nil {
+		logger.FromContext(ctx).Error("worker: failed to enqueue job",
+			logger.String("job_type", job.Type),
+			logger.Int64("booking_id", p.BookingID),
+			logger.Int64("event_id", p.EventID),
+			logger.Err(err),
+		)
+	}
+}
+
+// dispatchOutbox moves jobs written to the outbox into the job queue until
+// the worker stops, draining it a batch at a time, then checks what is left
+// against the outbox limits.
+func (w *NotificationWorker) dispatchOutbox() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				moved, err := w.outboxRepo.Dispatch(ctx, outboxBatch)
+				cancel()
+				if err != nil || moved < outboxBatch {
+					break
+				}
+			}
+			w.checkOutbox()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// checkOutbox marks the outbox backed up while it holds more entries than
+// the limit or its oldest entry is older than the maximum age, and alerts
+// ops about it. A failed count leaves the last state in place.
+func (w *NotificationWorker) checkOutbox() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, oldest, err := w.outboxRepo.Backlog(ctx)
+	if err != nil {
+		return
+	}
+	var age time.Duration
+	if oldest != nil {
+		age = w.clock.Now().Sub(*oldest)
+	}
+	backedUp := (w.queue.OutboxLimit > 0 && count >= w.queue.OutboxLimit) ||
+		(w.queue.OutboxMaxAge > 0 && age >= w.queue.OutboxMaxAge)
+
+	if w.backlogged.Swap(backedUp) != backedUp {
+		if backedUp {
+			logger.Error("worker: outbox backed up", logger.Int("outbox", count), logger.Int64("oldest_age_seconds", int64(age.Seconds())))
+		} else {
+			logger.Info("worker: outbox caught up", logger.Int("outbox", count))
+		}
+	}
+	if backedUp {
+		w.alertBacklog(ctx, count, age)
+	}
+}
+
+// alertBacklog tells ops the outbox is backed up, at most once per
+// backlogAlertInterval.
+func (w *NotificationWorker) alertBacklog(ctx context.Context, count int, age time.Duration) {
+	now := w.clock.Now()
+	if !w.lastAlert.IsZero() && now.Sub(w.lastAlert) < backlogAlertInterval {
+		return
+	}
+	w.lastAlert = now
+
+	msg := fmt.Sprintf("The job outbox holds %d entries (limit %d) and the oldest is %s old (limit %s); overflow mode %q. %d jobs overflowed and %d were dropped since start.",
+		count, w.queue.OutboxLimit, age.Round(time.Second), w.queue.OutboxMaxAge, w.queue.Overflow, w.overflowed.Load(), w.dropped.Load())
+	if err := w.queue.Notifier.Notify(ctx, "Job outbox backed up", msg); err != nil {
+		logger.Error("worker: failed to send job outbox alert", logger.Err(err))
+	}
+}
+
+// QueueStats reports the outbox limits, whether the outbox is backed up and
+// the overflow counters. The backlog itself is counted by the job queue.
+func (w *NotificationWorker) QueueStats() entity.JobQueueStats {
+	return entity.JobQueueStats{
+		OutboxLimit:         w.queue.OutboxLimit,
+		OutboxMaxAgeSeconds: int64(w.queue.OutboxMaxAge.Seconds()),
+		Overflow:            w.queue.Overflow,
+		Backlogged:          w.backlogged.Load(),
+		Overflowed:          w.overflowed.Load(),
+		Dropped:             w.dropped.Load(),
+	}
+}
+
+// deliver hands a rendered email to the configured provider. The booking or
+// payment that triggered the email is unaffected by a failure.
+func (w *NotificationWorker) deliver(ctx context.Context, msg email.Message, bookingID int64, render error) error {
+	if render != nil {
+		return fmt.Errorf("render email: %w", render)
+	}
+
+	ctx, span := tracing.Start(ctx, "email.Send")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, emailSendTimeout)
+	defer cancel()
+
+	if err := w.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
 	logger.Info("worker: email sent",
-		logger.String("email", email),
+		logger.String("email", msg.To),
+		logger.String("subject", msg.Subject),
 		logger.Int64("booking_id", bookingID),
 	)
+	return nil
+}
+
+func (w *NotificationWorker) sendNotificationEmail(ctx context.Context, to string, bookingID int64, message i18n.Message) error {
+	text := message.In(w.locale)
+	logger.Debug("worker: sending notification email",
+		logger.String("email", to),
+		logger.Int64("booking_id", bookingID),
+		logger.String("message", text),
+	)
+	msg, err := renderEmail(w.locale, to, tmplNotification, notificationData{Message: text})
+	return w.deliver(ctx, msg, bookingID, err)
+}
+
+// sendPasswordResetEmail delivers the reset link. The link is a credential,
+// so it is never written to the logs.
+func (w *NotificationWorker) sendPasswordResetEmail(ctx context.Context, to, resetLink string) error {
+	logger.Debug("worker: sending password reset email", logger.String("email", to))
+	msg, err := renderEmail(w.locale, to, tmplPasswordReset, passwordResetData{Link: resetLink})
+	return w.deliver(ctx, msg, 0, err)
+}
+
+func (w *NotificationWorker) sendBookingConfirmation(ctx context.Context, to string, bookingID int64) error {
+	logger.Debug("worker: sending booking confirmation", logger.String("email", to), logger.Int64("booking_id", bookingID))
+
+	booking, err := w.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return fmt.Errorf("get booking: %w", err)
+	}
+	user, err := w.userRepo.GetUserByID(ctx, int(booking.UserID))
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	event, err := w.eventRepo.GetEventByID(ctx, booking.EventID)
+	if err != nil {
+		return fmt.Errorf("get event: %w", err)
+	}
+
+	data := bookingConfirmationData{
+		Name:      user.Name,
+		BookingID: booking.ID,
+		EventName: event.Name,
+		EventDate: formatEmailTime(event.Date),
+		Location:  event.Location,
+		Amount:    formatRupiah(booking.TotalAmount),
+	}
+	if booking.ExpiresAt != nil {
+		data.ExpiresAt = formatEmailTime(*booking.ExpiresAt)
+	}
+
+	msg, err := renderEmail(w.locale, to, tmplBookingConfirmation, data)
+	return w.deliver(ctx, msg, bookingID, err)
+}
+
+// sendUpgradeOffer emails the offer with its accept link. Like a reset link,
+// the accept link is a credential and is never logged.
+func (w *NotificationWorker) sendUpgradeOffer(ctx context.Context, to string, offerID int64, acceptLink string) error {
+	logger.Debug("worker: sending upgrade offer", logger.String("email", to), logger.Int64("offer_id", offerID))
+
+	offer, err := w.offerRepo.GetOfferByID(ctx, offerID)
+	if err != nil {
+		return fmt.Errorf("get upgrade offer: %w", err)
+	}
+	// Nothing to offer any more; retrying would not change that
+	if offer.Status != entity.UpgradeOfferPending {
+		logger.Info("worker: upgrade offer closed before sending", logger.Int64("offer_id", offerID))
+		return nil
+	}
+	booking, err := w.bookingRepo.GetBookingByID(ctx, offer.BookingID)
+	if err != nil {
+		return fmt.Errorf("get booking: %w", err)
+	}
+	user, err := w.userRepo.GetUserByID(ctx, int(booking.UserID))
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	msg, err := renderEmail(w.locale, to, tmplUpgradeOffer, upgradeOfferData{
+		Name:      user.Name,
+		BookingID: offer.BookingID,
+		EventName: offer.EventName,
+		EventDate: formatEmailTime(offer.EventDate),
+		FromSeat:  offer.FromSeatNumber,
+		ToSeat:    offer.ToSeatNumber,
+		Amount:    formatRupiah(offer.PriceDifference),
+		ExpiresAt: formatEmailTime(offer.ExpiresAt),
+		Link:      acceptLink,
+	})
+	return w.deliver(ctx, msg, offer.BookingID, err)
 }
 
-func (w *NotificationWorker) processEventRefund(eventID int64) {
+func (w *NotificationWorker) sendPaymentReceipt(ctx context.Context, bookingID int64) error {
+	logger.Debug("worker: sending payment receipt", logger.Int64("booking_id", bookingID))
+
+	booking, err := w.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return fmt.Errorf("get booking: %w", err)
+	}
+	user, err := w.userRepo.GetUserByID(ctx, int(booking.UserID))
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	event, err := w.eventRepo.GetEventByID(ctx, booking.EventID)
+	if err != nil {
+		return fmt.Errorf("get event: %w", err)
+	}
+	txn, err := w.transactionRepo.GetTransactionByBookingID(ctx, bookingID)
+	if err != nil {
+		return fmt.Errorf("get transaction: %w", err)
+	}
+	if txn == nil {
+		return fmt.Errorf("no transaction for booking %d", bookingID)
+	}
+	// The receipt is still useful without tickets; they can be viewed in the app
+	tickets, err := w.ticketRepo.GetTicketsByBookingID(ctx, bookingID)
+	if err != nil {
+		logger.Warn("worker: failed to load tickets for receipt", logger.Int64("booking_id", bookingID), logger.Err(err))
+	}
+
+	msg, err := renderEmail(w.locale, user.Email, tmplPaymentReceipt, paymentReceiptData{
+		Name:          user.Name,
+		BookingID:     booking.ID,
+		Reference:     txn.ExternalID,
+		InvoiceNumber: txn.InvoiceNumber,
+		PaymentMethod: usecase.FormatPaymentMethod(txn.PaymentMethod),
+		PaidAt:        formatEmailTime(txn.TransactionDate),
+		EventName:     event.Name,
+		EventDate:     formatEmailTime(event.Date),
+		Location:      event.Location,
+		Amount:        formatRupiah(txn.Amount),
+		Tickets:       tickets,
+	})
+	return w.deliver(ctx, msg, bookingID, err)
+}
+
+func (w *NotificationWorker) sendTicketsReissued(ctx context.Context, bookingID int64) error {
+	logger.Debug("worker: sending reissued tickets", logger.Int64("booking_id", bookingID))
+
+	booking, err := w.bookingRepo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return fmt.Errorf("get booking: %w", err)
+	}
+	user, err := w.userRepo.GetUserByID(ctx, int(booking.UserID))
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	event, err := w.eventRepo.GetEventByID(ctx, booking.EventID)
+	if err != nil {
+		return fmt.Errorf("get event: %w", err)
+	}
+	// Unlike the receipt, this email is pointless without the new codes
+	tickets, err := w.ticketRepo.GetTicketsByBookingID(ctx, bookingID)
+	if err != nil {
+		return fmt.Errorf("get tickets: %w", err)
+	}
+
+	msg, err := renderEmail(w.locale, user.Email, tmplTicketsReissued, ticketsReissuedData{
+		Name:      user.Name,
+		BookingID: booking.ID,
+		EventName: event.Name,
+		EventDate: formatEmailTime(event.Date),
+		Location:  event.Location,
+		Tickets:   tickets,
+	})
+	return w.deliver(ctx, msg, bookingID, err)
+}
+
+// processEventRefund refunds or cancels every booking of a cancelled event.
+// Bookings already handled are skipped, so a retried job only picks up the
+// bookings that failed the first time.
+func (w *NotificationWorker) processEventRefund(ctx context.Context, eventID int64) error {
 	logger.Info("worker: starting refund process", logger.Int64("event_id", eventID))
 
-	ctx := context.Background()
+	// An event restored after it was cancelled keeps its bookings, including
+	// the ones made since the restore
+	cancelled, err := w.eventRepo.IsEventCancelled(ctx, eventID)
+	if errors.Is(err, entity.ErrNotFound) {
+		logger.Warn("worker: event not found, skipping refunds", logger.Int64("event_id", eventID))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("check event cancellation: %w", err)
+	}
+	if !cancelled {
+		logger.Info("worker: event restored, skipping refunds", logger.Int64("event_id", eventID))
+		return nil
+	}
+
+	eventName, eventDate := fmt.Sprintf("Event #%d", eventID), ""
+	if event, err := w.eventRepo.GetEventByID(ctx, eventID); err == nil {
+		eventName, eventDate = event.Name, formatEmailTime(event.Date)
+	}
 
 	bookings, err := w.bookingRepo.GetBookingsByEventID(ctx, eventID)
 	if err != nil {
-		logger.Error("worker: failed to get bookings for refund",
-			logger.Int64("event_id", eventID),
-			logger.Err(err),
-		)
-		return
+		return fmt.Errorf("get bookings: %w", err)
 	}
 
 	logger.Debug("worker: processing refunds",
@@ -103,6 +747,9 @@ func (w *NotificationWorker) processEventRefund(eventID int64) {
 		logger.Int("booking_count", len(bookings)),
 	)
 
+	var refundedCount, failedCount int
+	var refundedAmount float64
+
 	for _, b := range bookings {
 		user, err := w.userRepo.GetUserByID(ctx, int(b.UserID))
 		if err != nil {
@@ -113,12 +760,11 @@ func (w *NotificationWorker) processEventRefund(eventID int64) {
 			continue
 		}
 
-		if b.Status == "PAID" {
+		if b.Status == entity.BookingPaid {
 			logger.Debug("worker: processing refund",
 				logger.Int64("booking_id", b.ID),
 				logger.String("email", user.Email),
 			)
-			time.Sleep(500 * time.Millisecond) // Simulate bank delay
 
 			// Get the transaction and update its status to REFUNDED
 			txn, err := w.transactionRepo.GetTransactionByBookingID(ctx, b.ID)
@@ -130,7 +776,23 @@ func (w *NotificationWorker) processEventRefund(eventID int64) {
 			}
 
 			if txn != nil {
-				if err := w.transactionRepo.UpdateTransactionStatus(ctx, txn.ID, "REFUNDED", ""); err != nil {
+				// Keyed by the booking, so a retried job never refunds it twice.
+				// The booking stays PAID until the gateway accepts the refund.
+				_, err := w.gateway.Refund(ctx, payment.RefundRequest{
+					IdempotencyKey: fmt.Sprintf("refund-booking-%d", b.ID),
+					ChargeID:       txn.ExternalID,
+					Amount:         txn.Amount,
+				})
+				if err != nil {
+					logger.Error("worker: gateway refund failed",
+						logger.Int64("booking_id", b.ID),
+						logger.Err(err),
+					)
+					failedCount++
+					continue
+				}
+
+				if err := w.transactionRepo.UpdateTransactionStatus(ctx, txn.ID, entity.PaymentRefunded, ""); err != nil {
 					logger.Error("worker: failed to update transaction to REFUNDED",
 						logger.Int64("payment_id", txn.ID),
 						logger.Err(err),
@@ -141,25 +803,36 @@ func (w *NotificationWorker) processEventRefund(eventID int64) {
 				refund := &entity.Refund{
 					BookingID: b.ID,
 					Amount:    txn.Amount,
-					Reason:    "Event cancelled by administrator",
-					Status:    "COMPLETED",
+					Reason:    entity.RefundReasonEventCancelled,
+					Note:      "Event cancelled by administrator",
+					Status:    entity.RefundCompleted,
 				}
 				if err := w.refundRepo.CreateRefund(ctx, refund); err != nil {
 					logger.Error("worker: failed to create refund record",
 						logger.Int64("booking_id", b.ID),
 						logger.Err(err),
 					)
+				} else {
+					refundedCount++
+					refundedAmount += refund.Amount
 				}
 			}
 
 			// Update booking status to REFUNDED
-			if err := w.bookingRepo.UpdateBookingStatus(ctx, b.ID, "REFUNDED"); err != nil {
+			if err := w.bookingRepo.UpdateBookingStatus(ctx, b.ID, entity.BookingRefunded); err != nil {
 				logger.Error("worker: failed to update booking status to REFUNDED",
 					logger.Int64("booking_id", b.ID),
 					logger.Err(err),
 				)
+				failedCount++
 				continue
 			}
+			w.PublishWebhookEvent(ctx, entity.WebhookBookingRefunded, b.ID)
+			w.PublishDomainEvent(ctx, entity.DomainEventBookingRefunded, b.ID, map[string]interface{}{
+				"event_id": eventID,
+				"amount":   b.TotalAmount,
+				"reason":   entity.RefundReasonEventCancelled,
+			})
 
 			// Release seats back
 			if err := w.bookingRepo.ReleaseSeatsByBookingID(ctx, b.ID); err != nil {
@@ -169,24 +842,38 @@ func (w *NotificationWorker) processEventRefund(eventID int64) {
 				)
 			}
 
-			w.sendEmailLog(user.Email, b.ID, "Event dibatalkan. Uang Anda telah kami refund sepenuhnya.")
+			refundAmount := b.TotalAmount
+			if txn != nil {
+				refundAmount = txn.Amount
+			}
+			msg, err := renderEmail(w.locale, user.Email, tmplRefundNotice, refundNoticeData{
+				Name:           user.Name,
+				BookingID:      b.ID,
+				EventName:      eventName,
+				Amount:         formatRupiah(refundAmount),
+				EventCancelled: true,
+			})
+			if err := w.deliver(ctx, msg, b.ID, err); err != nil {
+				logger.Warn("worker: failed to send refund notice", logger.Int64("booking_id", b.ID), logger.Err(err))
+			}
 			logger.Info("worker: booking refunded",
 				logger.Int64("booking_id", b.ID),
 				logger.String("email", user.Email),
 			)
 
-		} else if b.Status == "PENDING" {
+		} else if b.Status == entity.BookingPending {
 			// Cancel pending transaction if exists
 			txn, _ := w.transactionRepo.GetTransactionByBookingID(ctx, b.ID)
 			if txn != nil {
-				w.transactionRepo.UpdateTransactionStatus(ctx, txn.ID, "CANCELLED", "")
+				w.transactionRepo.UpdateTransactionStatus(ctx, txn.ID, entity.PaymentCancelled, "")
 			}
 
-			if err := w.bookingRepo.UpdateBookingStatus(ctx, b.ID, "CANCELLED"); err != nil {
+			if err := w.bookingRepo.UpdateBookingStatus(ctx, b.ID, entity.BookingCancelled); err != nil {
 				logger.Error("worker: failed to update booking status to CANCELLED",
 					logger.Int64("booking_id", b.ID),
 					logger.Err(err),
 				)
+				failedCount++
 				continue
 			}
 
@@ -198,7 +885,15 @@ func (w *NotificationWorker) processEventRefund(eventID int64) {
 				)
 			}
 
-			w.sendEmailLog(user.Email, b.ID, "Booking dibatalkan karena event ditiadakan.")
+			msg, err := renderEmail(w.locale, user.Email, tmplBookingCancelled, bookingCancelledData{
+				Name:      user.Name,
+				BookingID: b.ID,
+				EventName: eventName,
+				EventDate: eventDate,
+			})
+			if err := w.deliver(ctx, msg, b.ID, err); err != nil {
+				logger.Warn("worker: failed to send cancellation notice", logger.Int64("booking_id", b.ID), logger.Err(err))
+			}
 			logger.Info("worker: booking cancelled",
 				logger.Int64("booking_id", b.ID),
 				logger.String("email", user.Email),
@@ -206,33 +901,182 @@ func (w *NotificationWorker) processEventRefund(eventID int64) {
 		}
 	}
 
+	if refundedCount > 0 {
+		w.auditor.Record(ctx, usecase.ActionRefundBulk, "event", eventID, map[string]interface{}{
+			"bookings": refundedCount,
+			"amount":   refundedAmount,
+		})
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("%d bookings could not be refunded or cancelled", failedCount)
+	}
+
 	logger.Info("worker: refund process completed", logger.Int64("event_id", eventID))
+	return nil
+}
+
+// processRefundRequest returns the money of an approved refund request.
+// A request that is no longer APPROVED was already handled, so a retried
+// job finishes without refunding twice.
+func (w *NotificationWorker) processRefundRequest(ctx context.Context, refundID int64) error {
+	logger.Info("worker: processing refund request", logger.Int64("refund_id", refundID))
+
+	refund, err := w.refundRepo.CompleteRefundRequest(ctx, refundID)
+	switch {
+	case errors.Is(err, entity.ErrRefundNotReviewable), errors.Is(err, entity.ErrNotFound):
+		logger.Info("worker: refund request already handled", logger.Int64("refund_id", refundID))
+		return nil
+	case errors.Is(err, entity.ErrBookingNotPaid):
+		logger.Warn("worker: refund request rejected, booking is no longer paid", logger.Int64("refund_id", refundID))
+		return nil
+	case err != nil:
+		return fmt.Errorf("complete refund request: %w", err)
+	}
+
+	if err := w.bookingRepo.ReleaseSeatsByBookingID(ctx, refund.BookingID); err != nil {
+		logger.Error("worker: failed to release seats",
+			logger.Int64("booking_id", refund.BookingID),
+			logger.Err(err),
+		)
+	}
+
+	w.PublishWebhookEvent(ctx, entity.WebhookBookingRefunded, refund.BookingID)
+	w.PublishDomainEvent(ctx, entity.DomainEventBookingRefunded, refund.BookingID, map[string]interface{}{
+		"refund_id": refund.ID,
+		"amount":    refund.Amount,
+		"reason":    refund.Reason,
+	})
+
+	w.auditor.Record(ctx, usecase.ActionRefundCreate, "booking", refund.BookingID, map[string]interface{}{
+		"refund_id": refund.ID,
+		"amount":    refund.Amount,
+		"reason":    refund.Reason,
+	})
+
+	// The money is already returned, so a failed notice is only logged
+	request, err := w.refundRepo.GetRefundRequest(ctx, refundID)
+	if err != nil {
+		logger.Warn("worker: failed to load refund request for notice", logger.Int64("refund_id", refundID), logger.Err(err))
+		return nil
+	}
+	reason := refund.Reason
+	if r, err := w.refundRepo.GetRefundReason(ctx, refund.Reason); err == nil {
+		reason = r.Label
+	}
+	msg, err := renderEmail(w.locale, request.UserEmail, tmplRefundNotice, refundNoticeData{
+		Name:      request.UserName,
+		BookingID: refund.BookingID,
+		EventName: request.EventName,
+		Amount:    formatRupiah(refund.Amount),
+		Reason:    reason,
+	})
+	if err := w.deliver(ctx, msg, refund.BookingID, err); err != nil {
+		logger.Warn("worker: failed to send refund notice", logger.Int64("booking_id", refund.BookingID), logger.Err(err))
+	}
+
+	logger.Info("worker: refund request completed",
+		logger.Int64("refund_id", refundID),
+		logger.Int64("booking_id", refund.BookingID),
+	)
+	return nil
 }
 
-func (w *NotificationWorker) SendNotification(bookingID int64, email, message string) {
-	logger.Debug("worker: enqueuing notification",
+func (w *NotificationWorker) SendNotification(ctx context.Context, bookingID int64, email string, message i18n.Message) {
+	logger.FromContext(ctx).Debug("worker: enqueuing notification",
 		logger.Int64("booking_id", bookingID),
 		logger.String("email", email),
 	)
-	w.JobQueue <- NotificationPayload{
-		Type:      JobNotification,
-		BookingID: bookingID,
-		UserEmail: email,
-		Message:   message,
-	}
+	w.enqueue(ctx, NotificationPayload{
+		Type:        JobNotification,
+		BookingID:   bookingID,
+		UserEmail:   email,
+		Message:     message.Text,
+		MessageArgs: message.Args,
+	})
 }
 
-func (w *NotificationWorker) EnqueueCancellation(eventID int64) {
-	logger.Info("worker: enqueuing cancellation refund", logger.Int64("event_id", eventID))
-	w.JobQueue <- NotificationPayload{
+// EnqueueCancellation writes the refund job of a cancelled event to the
+// outbox, inside the transaction ctx carries, so the job is stored exactly
+// when the cancellation commits.
+func (w *NotificationWorker) EnqueueCancellation(ctx context.Context, eventID int64) error {
+	logger.FromContext(ctx).Info("worker: enqueuing cancellation refund", logger.Int64("event_id", eventID))
+	job, err := newJob(NotificationPayload{
 		Type:    JobRefund,
 		EventID: eventID,
+	})
+	if err != nil {
+		return err
 	}
+	return w.outboxRepo.AddJob(ctx, &job)
+}
+
+// EnqueueRefundRequest queues an approved refund request for processing.
+func (w *NotificationWorker) EnqueueRefundRequest(ctx context.Context, refundID int64) {
+	logger.FromContext(ctx).Info("worker: enqueuing refund request", logger.Int64("refund_id", refundID))
+	w.enqueue(ctx, NotificationPayload{
+		Type:     JobRefundRequest,
+		RefundID: refundID,
+	})
+}
+
+func (w *NotificationWorker) SendBookingConfirmation(ctx context.Context, bookingID int64, email string) {
+	logger.FromContext(ctx).Debug("worker: enqueuing booking confirmation",
+		logger.Int64("booking_id", bookingID),
+		logger.String("email", email),
+	)
+	w.enqueue(ctx, NotificationPayload{
+		Type:      JobBookingConfirmation,
+		BookingID: bookingID,
+		UserEmail: email,
+	})
+}
+
+// SendPaymentReceipt emails the receipt and tickets to the booking's owner.
+func (w *NotificationWorker) SendPaymentReceipt(ctx context.Context, bookingID int64) {
+	logger.FromContext(ctx).Debug("worker: enqueuing payment receipt", logger.Int64("booking_id", bookingID))
+	w.enqueue(ctx, NotificationPayload{
+		Type:      JobPaymentReceipt,
+		BookingID: bookingID,
+	})
+}
+
+// SendTicketsReissued emails the booking's new ticket codes to its owner.
+func (w *NotificationWorker) SendTicketsReissued(ctx context.Context, bookingID int64) {
+	logger.FromContext(ctx).Debug("worker: enqueuing reissued tickets", logger.Int64("booking_id", bookingID))
+	w.enqueue(ctx, NotificationPayload{
+		Type:      JobTicketsReissued,
+		BookingID: bookingID,
+	})
+}
+
+// SendPasswordReset queues the reset email. The link sits in the job payload
+// until delivered; completed jobs are deleted.
+func (w *NotificationWorker) SendPasswordReset(ctx context.Context, email, resetLink string) {
+	logger.FromContext(ctx).Debug("worker: enqueuing password reset email", logger.String("email", email))
+	w.enqueue(ctx, NotificationPayload{
+		Type:      JobPasswordReset,
+		UserEmail: email,
+		Message:   resetLink,
+	})
+}
+
+// SendUpgradeOffer queues the upgrade offer email with its one-click accept link.
+func (w *NotificationWorker) SendUpgradeOffer(ctx context.Context, offerID int64, email, acceptLink string) {
+	logger.FromContext(ctx).Debug("worker: enqueuing upgrade offer", logger.Int64("offer_id", offerID), logger.String("email", email))
+	w.enqueue(ctx, NotificationPayload{
+		Type:      JobUpgradeOffer,
+		UserEmail: email,
+		Message:   acceptLink,
+		OfferID:   offerID,
+	})
 }
 
+// Stop finishes the jobs in progress and stops polling. Jobs still queued stay
+// in the database and are picked up on the next start.
 func (w *NotificationWorker) Stop() {
-	logger.Info("worker: stopping, processing remaining jobs...")
-	close(w.JobQueue)
+	logger.Info("worker: stopping, finishing in-flight jobs...")
+	close(w.stop)
 	w.wg.Wait()
-	logger.Info("worker: all jobs finished, safe to exit")
+	logger.Info("worker: stopped, safe to exit")
 }
@@ -0,0 +1,166 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/internal/usecase/mocks"
+	"ticres/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProcessEventRefund_RestoredEvent(t *testing.T) {
+	tests := []struct {
+		name        string
+		cancelled   bool
+		checkErr    error
+		wantRefunds bool
+		wantErr     bool
+	}{
+		// The event was restored after the cancellation queued this job
+		{name: "Restored Before The Job Ran", cancelled: false},
+		{name: "Still Cancelled", cancelled: true, wantRefunds: true},
+		{name: "Event Gone", checkErr: entity.ErrNotFound},
+		{name: "Check Failed - Retried", checkErr: errors.New("db down"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := new(mocks.MockEventRepo)
+			bookingRepo := new(mocks.MockBookingRepo)
+
+			eventRepo.On("IsEventCancelled", mock.Anything, int64(10)).Return(tt.cancelled, tt.checkErr).Once()
+			if tt.wantRefunds {
+				eventRepo.On("GetEventByID", mock.Anything, int64(10)).Return(&entity.Event{ID: 10, Name: "Jazz Night"}, nil).Once()
+				bookingRepo.On("GetBookingsByEventID", mock.Anything, int64(10)).Return([]entity.Booking{}, nil).Once()
+			}
+
+			w := &NotificationWorker{eventRepo: eventRepo, bookingRepo: bookingRepo}
+			err := w.processEventRefund(context.Background(), 10)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tt.wantRefunds {
+				bookingRepo.AssertNotCalled(t, "GetBookingsByEventID", mock.Anything, mock.Anything)
+			}
+			eventRepo.AssertExpectations(t)
+			bookingRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCheckOutbox(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	recent, stale := now.Add(-10*time.Second), now.Add(-2*time.Minute)
+
+	tests := []struct {
+		name       string
+		count      int
+		oldest     *time.Time
+		countErr   error
+		wasBacked  bool
+		wantBacked bool
+	}{
+		{name: "Empty", count: 0},
+		{name: "Under Both Limits", count: 10, oldest: &recent},
+		{name: "At The Limit", count: 100, oldest: &recent, wantBacked: true},
+		{name: "Oldest Entry Too Old", count: 1, oldest: &stale, wantBacked: true},
+		{name: "Caught Up", count: 1, oldest: &recent, wasBacked: true},
+		// A failed count leaves the last state in place
+		{name: "Count Failed", countErr: errors.New("db down"), wasBacked: true, wantBacked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outboxRepo := new(mocks.MockOutboxRepo)
+			notifier := new(mocks.MockAlertNotifier)
+			outboxRepo.On("Backlog", mock.Anything).Return(tt.count, tt.oldest, tt.countErr).Once()
+			if tt.wantBacked && tt.countErr == nil {
+				notifier.On("Notify", mock.Anything, "Job outbox backed up", mock.Anything).Return(nil).Once()
+			}
+
+			w := &NotificationWorker{
+				outboxRepo: outboxRepo,
+				clock:      clock.NewFake(now),
+				queue:      QueueConfig{OutboxLimit: 100, OutboxMaxAge: time.Minute, Overflow: OverflowPersist, Notifier: notifier},
+			}
+			w.backlogged.Store(tt.wasBacked)
+			w.checkOutbox()
+
+			assert.Equal(t, tt.wantBacked, w.QueueStats().Backlogged)
+			outboxRepo.AssertExpectations(t)
+			notifier.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCheckOutbox_AlertsAtMostEveryInterval(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	outboxRepo := new(mocks.MockOutboxRepo)
+	notifier := new(mocks.MockAlertNotifier)
+	outboxRepo.On("Backlog", mock.Anything).Return(500, nil, nil)
+	notifier.On("Notify", mock.Anything, "Job outbox backed up", mock.Anything).Return(nil).Twice()
+
+	w := &NotificationWorker{
+		outboxRepo: outboxRepo,
+		clock:      clk,
+		queue:      QueueConfig{OutboxLimit: 100, Notifier: notifier},
+	}
+	w.checkOutbox()
+	clk.Advance(time.Minute)
+	w.checkOutbox()
+	clk.Advance(backlogAlertInterval)
+	w.checkOutbox()
+
+	notifier.AssertExpectations(t)
+}
+
+func TestEnqueue_Overflow(t *testing.T) {
+	tests := []struct {
+		name        string
+		overflow    string
+		backlogged  bool
+		wantStored  bool
+		wantDropped int64
+	}{
+		{name: "Not Backed Up", overflow: OverflowDrop, wantStored: true},
+		{name: "Backed Up - Persist", overflow: OverflowPersist, backlogged: true, wantStored: true},
+		{name: "Backed Up - Drop", overflow: OverflowDrop, backlogged: true, wantDropped: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outboxRepo := new(mocks.MockOutboxRepo)
+			if tt.wantStored {
+				outboxRepo.On("AddJob", mock.Anything, mock.MatchedBy(func(job *entity.Job) bool {
+					return job.Type == string(JobPaymentReceipt)
+				})).Return(nil).Once()
+			}
+
+			w := &NotificationWorker{
+				outboxRepo: outboxRepo,
+				queue:      QueueConfig{EnqueueTimeout: time.Second, Overflow: tt.overflow},
+			}
+			w.backlogged.Store(tt.backlogged)
+			w.enqueue(context.Background(), NotificationPayload{Type: JobPaymentReceipt, BookingID: 1})
+
+			stats := w.QueueStats()
+			assert.Equal(t, tt.wantDropped, stats.Dropped)
+			if tt.backlogged {
+				assert.Equal(t, int64(1), stats.Overflowed)
+			}
+			outboxRepo.AssertExpectations(t)
+			if !tt.wantStored {
+				outboxRepo.AssertNotCalled(t, "AddJob", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
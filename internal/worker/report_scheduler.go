@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+// ReportRefresher recomputes cached admin reports.
+type ReportRefresher interface {
+	RefreshReports(ctx context.Context) error
+}
+
+// ReportScheduler periodically recomputes the default admin reports so
+// dashboards are served from a warm cache instead of querying Postgres on
+// every page load.
+type ReportScheduler struct {
+	reports  ReportRefresher
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewReportScheduler(reports ReportRefresher, interval time.Duration) *ReportScheduler {
+	return &ReportScheduler{
+		reports:  reports,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (s *ReportScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		logger.Info("worker: report scheduler started", logger.String("interval", s.interval.String()))
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		// Warm the cache right away instead of waiting a full interval
+		s.run()
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				logger.Info("worker: report scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (s *ReportScheduler) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := s.reports.RefreshReports(ctx); err != nil {
+		logger.Error("worker: report refresh failed", logger.Err(err))
+	}
+}
+
+func (s *ReportScheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
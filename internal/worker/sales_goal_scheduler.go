@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+// SalesGoalChecker emails organizers the sales goal alerts that became due.
+type SalesGoalChecker interface {
+	CheckSalesGoals(ctx context.Context) (int, error)
+}
+
+// SalesGoalScheduler periodically checks events' sales against their goals.
+type SalesGoalScheduler struct {
+	checker  SalesGoalChecker
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewSalesGoalScheduler(checker SalesGoalChecker, interval time.Duration) *SalesGoalScheduler {
+	return &SalesGoalScheduler{
+		checker:  checker,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (s *SalesGoalScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		logger.Info("worker: sales goal scheduler started", logger.String("interval", s.interval.String()))
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				logger.Info("worker: sales goal scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (s *SalesGoalScheduler) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	alerted, err := s.checker.CheckSalesGoals(ctx)
+	if err != nil {
+		logger.Error("worker: sales goal check failed", logger.Err(err))
+		return
+	}
+	logger.Debug("worker: sales goal check completed", logger.Int("alerted", alerted))
+}
+
+func (s *SalesGoalScheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+// UpgradeOfferRunner offers unsold premium seats to existing ticket holders.
+type UpgradeOfferRunner interface {
+	SendUpgradeOffers(ctx context.Context) (int, error)
+}
+
+// UpgradeOfferScheduler periodically sends upgrade offers for upcoming events.
+type UpgradeOfferScheduler struct {
+	offers   UpgradeOfferRunner
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewUpgradeOfferScheduler(offers UpgradeOfferRunner, interval time.Duration) *UpgradeOfferScheduler {
+	return &UpgradeOfferScheduler{
+		offers:   offers,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (s *UpgradeOfferScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		logger.Info("worker: upgrade offer scheduler started", logger.String("interval", s.interval.String()))
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				logger.Info("worker: upgrade offer scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (s *UpgradeOfferScheduler) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sent, err := s.offers.SendUpgradeOffers(ctx)
+	if err != nil {
+		logger.Error("worker: upgrade offer run failed", logger.Err(err))
+		return
+	}
+	logger.Debug("worker: upgrade offer run completed", logger.Int("sent", sent))
+}
+
+func (s *UpgradeOfferScheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
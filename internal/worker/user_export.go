@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"ticres/internal/entity"
+	"ticres/pkg/i18n"
+	"ticres/pkg/logger"
+)
+
+// EnqueueUserExport writes the job building a personal data export to the
+// outbox, inside the transaction ctx carries, so the job is stored exactly
+// when the export is.
+func (w *NotificationWorker) EnqueueUserExport(ctx context.Context, exportID int64) error {
+	logger.FromContext(ctx).Info("worker: enqueuing user data export", logger.Int64("export_id", exportID))
+	job, err := newJob(NotificationPayload{
+		Type:     JobUserExport,
+		ExportID: exportID,
+	})
+	if err != nil {
+		return err
+	}
+	return w.outboxRepo.AddJob(ctx, &job)
+}
+
+// buildUserExport stores the archive of a PENDING export and emails the
+// user that it can be downloaded. An export replaced or removed with its
+// account in the meantime is skipped; one whose job runs out of attempts is
+// marked FAILED so the next request starts over.
+func (w *NotificationWorker) buildUserExport(ctx context.Context, job entity.Job, exportID int64) error {
+	export, err := w.exportRepo.GetExport(ctx, exportID)
+	if errors.Is(err, entity.ErrNotFound) {
+		logger.Info("worker: user data export gone before building", logger.Int64("export_id", exportID))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get export: %w", err)
+	}
+	if export.Status != entity.UserExportPending {
+		return nil
+	}
+
+	archive, err := w.exportRepo.CollectUserData(ctx, export.UserID)
+	var data []byte
+	if err == nil {
+		data, err = json.Marshal(archive)
+	}
+	if err == nil {
+		err = w.exportRepo.CompleteExport(ctx, exportID, data)
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil
+		}
+	}
+	if err != nil {
+		if job.Attempts >= job.MaxAttempts {
+			w.exportRepo.FailExport(ctx, exportID, err.Error())
+		}
+		return fmt.Errorf("build user export: %w", err)
+	}
+
+	logger.Info("worker: user data export built",
+		logger.Int64("export_id", exportID),
+		logger.Int64("user_id", export.UserID),
+		logger.Int("bookings", len(archive.Bookings)),
+		logger.Int("bytes", len(data)),
+	)
+	w.SendNotification(ctx, 0, archive.Profile.Email, i18n.Msg("Your personal data export is ready. You can download it from your account."))
+	return nil
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+// WarehouseExporter ships changed rows to the data warehouse.
+type WarehouseExporter interface {
+	Export(ctx context.Context) error
+}
+
+// WarehouseExportScheduler runs the warehouse export every interval. A run
+// may take up to the interval, so a large backlog is shipped over several
+// runs instead of overlapping them.
+type WarehouseExportScheduler struct {
+	exporter WarehouseExporter
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewWarehouseExportScheduler(exporter WarehouseExporter, interval time.Duration) *WarehouseExportScheduler {
+	return &WarehouseExportScheduler{
+		exporter: exporter,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (s *WarehouseExportScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		logger.Info("worker: warehouse export scheduler started", logger.String("interval", s.interval.String()))
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				logger.Info("worker: warehouse export scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (s *WarehouseExportScheduler) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	defer cancel()
+
+	// Failures are logged per destination and retried on the next run
+	s.exporter.Export(ctx)
+}
+
+func (s *WarehouseExportScheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
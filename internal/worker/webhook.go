@@ -0,0 +1,231 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ticres/internal/entity"
+	"ticres/pkg/logger"
+	"ticres/pkg/signing"
+	"ticres/pkg/tracing"
+)
+
+// Headers sent with every webhook delivery. The signature header holds the
+// value made by signing.Signer, which receivers check with signing.Verifier.
+const (
+	WebhookSignatureHeader = "X-TicRes-Signature"
+	WebhookEventHeader     = "X-TicRes-Event"
+	WebhookDeliveryHeader  = "X-TicRes-Delivery"
+)
+
+// webhookTimeout bounds a single delivery attempt to a webhook.
+const webhookTimeout = 10 * time.Second
+
+// webhookErrorBody caps how much of a failed response is kept in the
+// delivery log.
+const webhookErrorBody = 512
+
+// PublishWebhookEvent queues a lifecycle event of the booking. The worker
+// sends it to every active webhook subscribed to it, each delivery in its
+// own job so a failing receiver is retried on its own.
+func (w *NotificationWorker) PublishWebhookEvent(ctx context.Context, eventType string, bookingID int64) {
+	logger.FromContext(ctx).Debug("worker: enqueuing webhook event",
+		logger.String("event_type", eventType),
+		logger.Int64("booking_id", bookingID),
+	)
+	w.enqueue(ctx, NotificationPayload{
+		Type:         JobWebhookEvent,
+		BookingID:    bookingID,
+		WebhookEvent: eventType,
+	})
+}
+
+// fanOutWebhookEvent stores a delivery of the event for each subscribed
+// webhook and queues it. A retried job finds the deliveries it already
+// stored, so each webhook gets the event once; only deliveries never
+// attempted are queued again.
+func (w *NotificationWorker) fanOutWebhookEvent(ctx context.Context, job entity.Job, eventType string, bookingID int64) error {
+	booking, err := w.bookingRepo.GetBookingByID(ctx, bookingID)
+	if errors.Is(err, entity.ErrNotFound) {
+		logger.Warn("worker: booking gone before webhook event", logger.Int64("booking_id", bookingID))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get booking: %w", err)
+	}
+	event, err := w.eventRepo.GetEventByID(ctx, booking.EventID)
+	if err != nil {
+		return fmt.Errorf("get event: %w", err)
+	}
+
+	webhooks, err := w.webhookRepo.ListSubscribers(ctx, event.OrganizerID, eventType)
+	if err != nil {
+		return fmt.Errorf("list webhooks: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	data := entity.WebhookBookingData{
+		BookingID:   booking.ID,
+		EventID:     booking.EventID,
+		EventName:   event.Name,
+		Status:      booking.Status,
+		TotalAmount: booking.TotalAmount,
+		BookedAt:    booking.CreatedAt,
+	}
+	// Anonymized accounts keep their bookings, so a missing customer is
+	// left out rather than failing the event
+	if user, err := w.userRepo.GetUserByID(ctx, int(booking.UserID)); err == nil {
+		data.CustomerName, data.CustomerEmail = user.Name, user.Email
+	}
+	if eventType == entity.WebhookPaymentCompleted {
+		txn, err := w.transactionRepo.GetTransactionByBookingID(ctx, bookingID)
+		if err != nil {
+			return fmt.Errorf("get transaction: %w", err)
+		}
+		if txn != nil {
+			data.PaymentMethod, data.PaymentRef, data.InvoiceNumber = txn.PaymentMethod, txn.ExternalID, txn.InvoiceNumber
+		}
+	}
+
+	body := entity.WebhookEvent{
+		ID:        eventType + ":" + strconv.FormatInt(bookingID, 10),
+		Type:      eventType,
+		CreatedAt: job.CreatedAt,
+		Data:      data,
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode webhook event: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		delivery := &entity.WebhookDelivery{
+			WebhookID: webhook.ID,
+			EventKey:  body.ID,
+			EventType: eventType,
+			Payload:   payload,
+		}
+		created, err := w.webhookRepo.CreateDelivery(ctx, delivery)
+		if err != nil {
+			return fmt.Errorf("create webhook delivery: %w", err)
+		}
+		if !created && (delivery.Status != entity.WebhookDeliveryPending || delivery.Attempts > 0) {
+			continue
+		}
+		deliveryJob, err := newJob(NotificationPayload{Type: JobWebhookDelivery, DeliveryID: delivery.ID})
+		if err != nil {
+			return err
+		}
+		if err := w.jobRepo.Enqueue(ctx, &deliveryJob); err != nil {
+			return fmt.Errorf("enqueue webhook delivery: %w", err)
+		}
+	}
+
+	logger.Info("worker: webhook event fanned out",
+		logger.String("event_type", eventType),
+		logger.Int64("booking_id", bookingID),
+		logger.Int("webhooks", len(webhooks)),
+	)
+	return nil
+}
+
+// deliverWebhook POSTs a delivery's payload, signed with the webhook's
+// secret, and logs the outcome on the delivery. Any response other than 2xx
+// fails the job, so it is retried with the usual backoff until the
+// delivery is marked FAILED.
+func (w *NotificationWorker) deliverWebhook(ctx context.Context, job entity.Job, deliveryID int64) error {
+	delivery, err := w.webhookRepo.GetDelivery(ctx, deliveryID)
+	if errors.Is(err, entity.ErrNotFound) {
+		logger.Info("worker: webhook delivery gone before sending", logger.Int64("delivery_id", deliveryID))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get webhook delivery: %w", err)
+	}
+	if delivery.Status != entity.WebhookDeliveryPending {
+		return nil
+	}
+	webhook, err := w.webhookRepo.GetWebhook(ctx, delivery.WebhookID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get webhook: %w", err)
+	}
+	if !webhook.Active {
+		return w.webhookRepo.RecordAttempt(ctx, deliveryID, entity.WebhookDeliveryFailed, nil, "webhook is disabled")
+	}
+
+	responseStatus, sendErr := w.postWebhook(ctx, webhook, delivery)
+	if sendErr == nil {
+		if err := w.webhookRepo.RecordAttempt(ctx, deliveryID, entity.WebhookDeliverySucceeded, responseStatus, ""); err != nil {
+			logger.Error("worker: failed to record webhook delivery", logger.Int64("delivery_id", deliveryID), logger.Err(err))
+		}
+		logger.Info("worker: webhook delivered",
+			logger.Int64("delivery_id", deliveryID),
+			logger.Int64("webhook_id", webhook.ID),
+			logger.String("event_type", delivery.EventType),
+		)
+		return nil
+	}
+
+	status := entity.WebhookDeliveryPending
+	if job.Attempts >= job.MaxAttempts {
+		status = entity.WebhookDeliveryFailed
+	}
+	if err := w.webhookRepo.RecordAttempt(ctx, deliveryID, status, responseStatus, sendErr.Error()); err != nil {
+		logger.Error("worker: failed to record webhook delivery", logger.Int64("delivery_id", deliveryID), logger.Err(err))
+	}
+	return fmt.Errorf("deliver webhook: %w", sendErr)
+}
+
+// postWebhook sends one attempt of a delivery. It returns the response
+// status when there was a response.
+func (w *NotificationWorker) postWebhook(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (*int, error) {
+	secret, err := w.webhookCipher.Decrypt(webhook.SecretEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt secret: %w", err)
+	}
+	signer, err := signing.NewSigner([]byte(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := tracing.Start(ctx, "webhook.Deliver")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "TicRes-Webhooks/1.0")
+	req.Header.Set(WebhookSignatureHeader, signer.SignAt(w.clock.Now(), delivery.Payload))
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+
+	resp, err := w.webhookClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	if status < 200 || status >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBody))
+		return &status, fmt.Errorf("webhook returned status %d: %s", status, bytes.TrimSpace(snippet))
+	}
+	return &status, nil
+}
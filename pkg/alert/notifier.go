@@ -0,0 +1,72 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+// Notifier delivers an alert to an ops channel.
+type Notifier interface {
+	Notify(ctx context.Context, subject, message string) error
+}
+
+// WebhookNotifier posts alerts to a Slack-compatible incoming webhook.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, subject, message string) error {
+	body, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", subject, message),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// LogNotifier writes alerts to the application log. Used when no webhook is configured.
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, subject, message string) error {
+	logger.Warn("alert: "+subject, logger.String("message", message))
+	return nil
+}
+
+// NewNotifier returns a webhook notifier when a URL is configured, otherwise a log notifier.
+func NewNotifier(webhookURL string) Notifier {
+	if webhookURL == "" {
+		return LogNotifier{}
+	}
+	return NewWebhookNotifier(webhookURL)
+}
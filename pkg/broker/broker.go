@@ -0,0 +1,70 @@
+// Package broker publishes domain events to a message broker for consumers
+// outside the API: NATS, or Kafka through the Confluent REST Proxy.
+package broker
+
+import (
+	"context"
+	"fmt"
+)
+
+// Publisher sends messages to a broker. Key groups messages that must stay
+// together, such as the events of one booking; brokers that partition
+// topics use it to pick the partition.
+type Publisher interface {
+	Publish(ctx context.Context, topic, key string, payload []byte) error
+	Close() error
+}
+
+// Config selects the broker: "none" (the default) disables publishing,
+// "nats" connects to the NATS server at URL (nats://host:4222, or tls:// for
+// TLS) and "kafka" posts to the Kafka REST Proxy at URL. Username and
+// Password are sent when set. Topics are named TopicPrefix.<topic>.
+type Config struct {
+	Driver      string
+	URL         string
+	Username    string
+	Password    string
+	TopicPrefix string
+}
+
+// New builds the publisher cfg selects. It returns nil when publishing is
+// disabled.
+func New(cfg Config) (Publisher, error) {
+	var pub Publisher
+	switch cfg.Driver {
+	case "", "none":
+		return nil, nil
+	case "nats":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("broker: nats requires a URL")
+		}
+		nats, err := NewNATSPublisher(cfg.URL, cfg.Username, cfg.Password)
+		if err != nil {
+			return nil, err
+		}
+		pub = nats
+	case "kafka":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("broker: kafka requires the REST Proxy URL")
+		}
+		pub = NewKafkaRESTPublisher(cfg.URL, cfg.Username, cfg.Password)
+	default:
+		return nil, fmt.Errorf("broker: unknown driver %q", cfg.Driver)
+	}
+
+	if cfg.TopicPrefix == "" {
+		return pub, nil
+	}
+	return &prefixed{Publisher: pub, prefix: cfg.TopicPrefix + "."}, nil
+}
+
+// prefixed puts every topic under a common prefix, so deployments sharing
+// a broker keep their topics apart.
+type prefixed struct {
+	Publisher
+	prefix string
+}
+
+func (p *prefixed) Publish(ctx context.Context, topic, key string, payload []byte) error {
+	return p.Publisher.Publish(ctx, p.prefix+topic, key, payload)
+}
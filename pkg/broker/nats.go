@@ -0,0 +1,239 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const natsDialTimeout = 5 * time.Second
+
+// NATSPublisher publishes over the NATS client protocol, which needs no
+// client library for publishing. Each message is followed by a PING, and
+// Publish returns once the server's PONG shows the message was accepted.
+// The connection is opened on first use and again after any failure.
+type NATSPublisher struct {
+	addr     string
+	useTLS   bool
+	username string
+	password string
+	token    string
+
+	// mu serializes publishes, so each PONG answers the latest PING
+	mu   sync.Mutex
+	conn *natsConn
+}
+
+// NewNATSPublisher parses a nats://, or tls://, server URL. Credentials in
+// the URL are used unless username is set; a user without a password is
+// sent as a token.
+func NewNATSPublisher(rawURL, username, password string) (*NATSPublisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("broker: invalid nats URL %q", rawURL)
+	}
+	p := &NATSPublisher{addr: u.Host, username: username, password: password}
+	switch u.Scheme {
+	case "nats":
+	case "tls":
+		p.useTLS = true
+	default:
+		return nil, fmt.Errorf("broker: unsupported nats URL scheme %q", u.Scheme)
+	}
+	if u.Port() == "" {
+		p.addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	if p.username == "" && u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			p.username, p.password = u.User.Username(), pass
+		} else {
+			p.token = u.User.Username()
+		}
+	}
+	return p, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, topic, key string, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		conn, err := p.connect(ctx)
+		if err != nil {
+			return fmt.Errorf("nats connect: %w", err)
+		}
+		p.conn = conn
+	}
+
+	// NATS has no message keys; subscribers partition on the payload
+	msg := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", topic, len(payload), payload)
+	if err := p.conn.write(msg); err != nil {
+		p.dropConn()
+		return fmt.Errorf("nats publish to %s: %w", topic, err)
+	}
+	select {
+	case err := <-p.conn.replies:
+		if err != nil {
+			p.dropConn()
+			return fmt.Errorf("nats publish to %s: %w", topic, err)
+		}
+		return nil
+	case <-ctx.Done():
+		// A late PONG would be taken for the next message's
+		p.dropConn()
+		return fmt.Errorf("nats publish to %s: %w", topic, ctx.Err())
+	}
+}
+
+func (p *NATSPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dropConn()
+	return nil
+}
+
+func (p *NATSPublisher) dropConn() {
+	if p.conn != nil {
+		p.conn.conn.Close()
+		p.conn = nil
+	}
+}
+
+// natsConn is one connection to the server. Its reader answers the server's
+// PINGs and passes PONGs and -ERR replies to replies.
+type natsConn struct {
+	conn    net.Conn
+	writeMu sync.Mutex
+	replies chan error
+}
+
+func (c *natsConn) write(s string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.conn.Write([]byte(s))
+	return err
+}
+
+// connect dials the server, reads its INFO and sends CONNECT, then waits
+// for the PONG to the first PING so rejected credentials fail here.
+func (p *NATSPublisher) connect(ctx context.Context) (*natsConn, error) {
+	dialer := &net.Dialer{Timeout: natsDialTimeout}
+	raw, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		raw.SetDeadline(deadline)
+	} else {
+		raw.SetDeadline(time.Now().Add(natsDialTimeout))
+	}
+
+	reader := bufio.NewReader(raw)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		raw.Close()
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		raw.Close()
+		return nil, fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	}
+
+	conn := raw
+	if p.useTLS {
+		host, _, _ := net.SplitHostPort(p.addr)
+		tlsConn := tls.Client(raw, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			raw.Close()
+			return nil, err
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	opts := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "ticres",
+		"lang":     "go",
+		"version":  "1.0.0",
+		"protocol": 1,
+	}
+	if p.username != "" {
+		opts["user"], opts["pass"] = p.username, p.password
+	}
+	if p.token != "" {
+		opts["auth_token"] = p.token
+	}
+	connect, err := json.Marshal(opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err := conn.Write([]byte("CONNECT " + string(connect) + "\r\nPING\r\n")); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "PONG" {
+			break
+		}
+		if strings.HasPrefix(line, "-ERR") {
+			conn.Close()
+			return nil, errors.New(natsError(line))
+		}
+	}
+	conn.SetDeadline(time.Time{})
+
+	c := &natsConn{conn: conn, replies: make(chan error, 4)}
+	go c.read(reader)
+	return c, nil
+}
+
+// read handles server messages until the connection closes.
+func (c *natsConn) read(reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			c.reply(err)
+			return
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PING":
+			if err := c.write("PONG\r\n"); err != nil {
+				c.reply(err)
+				return
+			}
+		case line == "PONG":
+			c.reply(nil)
+		case strings.HasPrefix(line, "-ERR"):
+			c.reply(errors.New(natsError(line)))
+		}
+	}
+}
+
+func (c *natsConn) reply(err error) {
+	select {
+	case c.replies <- err:
+	default:
+	}
+}
+
+func natsError(line string) string {
+	return "server error: " + strings.Trim(strings.TrimPrefix(line, "-ERR"), " '")
+}
@@ -0,0 +1,176 @@
+// Package cache keeps caches consistent across API instances. The shared
+// Redis cache is invalidated directly; caches held in process memory
+// subscribe to a Bus and are told about every invalidation on any instance.
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultChannel is the Redis pub/sub channel invalidations are sent on.
+const DefaultChannel = "cache:invalidations"
+
+// Handler drops a key from an in-process cache. It is called with the
+// handler's own prefix when invalidations may have been missed, such as after
+// a reconnect, and must then drop everything under that prefix.
+type Handler func(key string)
+
+type message struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+// Bus deletes keys from the shared Redis cache and broadcasts the
+// invalidation to every instance, including this one, so in-process caches
+// drop the same keys. Delivery is best effort: in-process caches should still
+// expire entries on their own.
+type Bus struct {
+	redis   *redis.Client
+	channel string
+	// origin tells this instance's messages apart; its own invalidations are
+	// applied locally before they are published
+	origin string
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus(rdb *redis.Client, channel string) *Bus {
+	origin := make([]byte, 8)
+	rand.Read(origin)
+	return &Bus{
+		redis:    rdb,
+		channel:  channel,
+		origin:   hex.EncodeToString(origin),
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// OnInvalidate registers fn for invalidated keys starting with prefix.
+func (b *Bus) OnInvalidate(prefix string, fn Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[prefix] = append(b.handlers[prefix], fn)
+}
+
+// Invalidate deletes the keys from Redis and has every instance drop them
+// from its in-process caches. Failures are logged; callers have already
+// committed the change the keys are stale for.
+func (b *Bus) Invalidate(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	if err := b.redis.Del(ctx, keys...).Err(); err != nil {
+		logger.FromContext(ctx).Warn("cache: failed to delete keys", logger.Any("keys", keys), logger.Err(err))
+	}
+	b.dispatch(keys)
+
+	data, err := json.Marshal(message{Origin: b.origin, Keys: keys})
+	if err != nil {
+		return
+	}
+	if err := b.redis.Publish(ctx, b.channel, data).Err(); err != nil {
+		logger.FromContext(ctx).Warn("cache: failed to publish invalidation", logger.Any("keys", keys), logger.Err(err))
+	}
+}
+
+// dispatch calls the handlers whose prefix matches each key.
+func (b *Bus) dispatch(keys []string) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for prefix, handlers := range b.handlers {
+		for _, key := range keys {
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			for _, fn := range handlers {
+				fn(key)
+			}
+		}
+	}
+}
+
+// flush has every handler drop everything under its prefix.
+func (b *Bus) flush() {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for prefix, handlers := range b.handlers {
+		for _, fn := range handlers {
+			fn(prefix)
+		}
+	}
+}
+
+// Run applies invalidations from other instances until ctx is done. Redis
+// does not queue pub/sub messages, so after a reconnect all in-process caches
+// are flushed in case an invalidation was missed.
+func (b *Bus) Run(ctx context.Context) {
+	sub := b.redis.Subscribe(ctx, b.channel)
+	defer sub.Close()
+
+	subscribed := false
+	messages := sub.ChannelWithSubscriptions()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			switch msg := msg.(type) {
+			case *redis.Subscription:
+				if subscribed {
+					logger.Warn("cache: invalidation bus resubscribed, flushing local caches")
+					b.flush()
+				}
+				subscribed = true
+			case *redis.Message:
+				var m message
+				if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+					logger.Warn("cache: invalid invalidation message", logger.Err(err))
+					continue
+				}
+				if m.Origin != b.origin {
+					b.dispatch(m.Keys)
+				}
+			}
+		}
+	}
+}
+
+// releaseWarmLock deletes the warm lock only if this instance still holds it.
+var releaseWarmLock = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Warm runs fn to refill key if no other instance is already doing so, so an
+// invalidated entry is rebuilt once instead of by every instance at the same
+// time. It reports whether fn ran. The lock expires after lockTTL in case the
+// instance dies mid-warmup.
+func (b *Bus) Warm(ctx context.Context, key string, lockTTL time.Duration, fn func(ctx context.Context) error) (bool, error) {
+	token := make([]byte, 8)
+	rand.Read(token)
+	lockKey := key + ":warming"
+
+	ok, err := b.redis.SetNX(ctx, lockKey, hex.EncodeToString(token), lockTTL).Result()
+	if err != nil || !ok {
+		return false, err
+	}
+	defer releaseWarmLock.Run(context.WithoutCancel(ctx), b.redis, []string{lockKey}, hex.EncodeToString(token))
+
+	return true, fn(ctx)
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"time"
+
+	"ticres/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+// loadTimeout bounds a load shared by several callers. It runs detached from
+// the caller that started it, so one caller giving up doesn't fail the rest.
+const loadTimeout = 10 * time.Second
+
+// Policy sets how long loaded values are kept. Jitter adds up to that
+// fraction of TTL to each write, so keys filled together don't expire
+// together. With StaleFor set, a value is kept that much longer and served
+// while a single caller reloads it in the background.
+type Policy struct {
+	TTL      time.Duration
+	Jitter   float64
+	StaleFor time.Duration
+}
+
+// entry is what is stored in Redis: the value and when it goes stale.
+type entry struct {
+	Value      json.RawMessage `json:"v"`
+	FreshUntil int64           `json:"fresh_until"`
+}
+
+// Loader reads JSON values through the shared Redis cache. Concurrent misses
+// for a key on one instance share a single load, so an expired or
+// invalidated key costs one query per instance instead of one per request,
+// and only one instance writes the result back. Redis failures fall back to
+// loading.
+type Loader struct {
+	bus    *Bus
+	policy Policy
+	group  singleflight.Group
+}
+
+func NewLoader(bus *Bus, policy Policy) *Loader {
+	return &Loader{bus: bus, policy: policy}
+}
+
+// Get decodes the value cached under key into dest, calling load on a miss.
+// load returns the value to cache; its errors are returned and not cached.
+func (l *Loader) Get(ctx context.Context, key string, dest interface{}, load func(ctx context.Context) (interface{}, error)) error {
+	if data, err := l.bus.redis.Get(ctx, key).Bytes(); err == nil {
+		var e entry
+		if err := json.Unmarshal(data, &e); err == nil {
+			if time.Now().UnixMilli() >= e.FreshUntil {
+				l.refresh(ctx, key, load)
+			}
+			if err := json.Unmarshal(e.Value, dest); err == nil {
+				return nil
+			}
+		}
+	} else if err != redis.Nil {
+		logger.FromContext(ctx).Warn("cache: failed to read key", logger.String("key", key), logger.Err(err))
+	}
+
+	result := l.group.DoChan(key, func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		l.bus.Warm(loadCtx, key, loadTimeout, func(ctx context.Context) error {
+			return l.store(ctx, key, data)
+		})
+		return data, nil
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return res.Err
+		}
+		// Each caller decodes its own copy of the shared result
+		return json.Unmarshal(res.Val.([]byte), dest)
+	}
+}
+
+// refresh reloads a stale key in the background, once per instance and only
+// on the instance that takes the warm lock.
+func (l *Loader) refresh(ctx context.Context, key string, load func(ctx context.Context) (interface{}, error)) {
+	go l.group.Do("refresh:"+key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		_, err := l.bus.Warm(ctx, key, loadTimeout, func(ctx context.Context) error {
+			value, err := load(ctx)
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			return l.store(ctx, key, data)
+		})
+		if err != nil {
+			logger.FromContext(ctx).Warn("cache: failed to refresh stale key", logger.String("key", key), logger.Err(err))
+		}
+		return nil, nil
+	})
+}
+
+// store writes a loaded value with a jittered TTL, kept StaleFor longer.
+func (l *Loader) store(ctx context.Context, key string, data []byte) error {
+	ttl := l.ttl()
+	stored, err := json.Marshal(entry{Value: data, FreshUntil: time.Now().Add(ttl).UnixMilli()})
+	if err != nil {
+		return err
+	}
+	if err := l.bus.redis.Set(ctx, key, stored, ttl+l.policy.StaleFor).Err(); err != nil {
+		logger.FromContext(ctx).Warn("cache: failed to write key", logger.String("key", key), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (l *Loader) ttl() time.Duration {
+	if l.policy.Jitter <= 0 {
+		return l.policy.TTL
+	}
+	return l.policy.TTL + time.Duration(rand.Float64()*l.policy.Jitter*float64(l.policy.TTL))
+}
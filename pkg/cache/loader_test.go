@@ -0,0 +1,203 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ticres/pkg/cache"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRedis answers the commands the Loader sends from a map, as a client
+// hook, so no Redis server is needed. With down set every command fails.
+type memoryRedis struct {
+	mu   sync.Mutex
+	data map[string]string
+	down bool
+}
+
+func newClient(m *memoryRedis) *redis.Client {
+	rdb := redis.NewClient(&redis.Options{Addr: "memory:6379"})
+	rdb.AddHook(m)
+	return rdb
+}
+
+func (m *memoryRedis) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (m *memoryRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func (m *memoryRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if m.down {
+			err := errors.New("dial tcp: connection refused")
+			cmd.SetErr(err)
+			return err
+		}
+		key, _ := cmd.Args()[1].(string)
+		switch cmd := cmd.(type) {
+		case *redis.StringCmd: // GET
+			value, ok := m.data[key]
+			if !ok {
+				cmd.SetErr(redis.Nil)
+				return redis.Nil
+			}
+			cmd.SetVal(value)
+		case *redis.BoolCmd: // SET NX, the warm lock
+			_, taken := m.data[key]
+			if !taken {
+				m.data[key] = cmd.Args()[2].(string)
+			}
+			cmd.SetVal(!taken)
+		case *redis.StatusCmd: // SET
+			m.data[key] = string(cmd.Args()[2].([]byte))
+			cmd.SetVal("OK")
+		case *redis.Cmd: // EVALSHA releasing the warm lock
+			delete(m.data, cmd.Args()[3].(string))
+			cmd.SetVal(int64(1))
+		}
+		return nil
+	}
+}
+
+type event struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func newLoader(m *memoryRedis) *cache.Loader {
+	return cache.NewLoader(cache.NewBus(newClient(m), cache.DefaultChannel), cache.Policy{TTL: time.Minute})
+}
+
+func TestLoader_CoalescesConcurrentLoads(t *testing.T) {
+	loader := newLoader(&memoryRedis{data: map[string]string{}})
+
+	var loads atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(ctx context.Context) (interface{}, error) {
+		if loads.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return event{ID: 1, Name: "Jazz Night"}, nil
+	}
+
+	const callers = 20
+	results := make([]event, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[0] = loader.Get(context.Background(), "event:1", &results[0], load)
+	}()
+	<-started
+	for i := 1; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = loader.Get(context.Background(), "event:1", &results[i], load)
+		}(i)
+	}
+	// Callers arriving after the load finished read the cached value instead,
+	// so the count holds however the goroutines are scheduled
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+	for i := range results {
+		require.NoError(t, errs[i])
+		assert.Equal(t, event{ID: 1, Name: "Jazz Night"}, results[i])
+	}
+}
+
+func TestLoader_CachesLoadedValue(t *testing.T) {
+	loader := newLoader(&memoryRedis{data: map[string]string{}})
+
+	var loads int
+	load := func(ctx context.Context) (interface{}, error) {
+		loads++
+		return event{ID: 1, Name: "Jazz Night"}, nil
+	}
+
+	var first, second event
+	require.NoError(t, loader.Get(context.Background(), "event:1", &first, load))
+	require.NoError(t, loader.Get(context.Background(), "event:1", &second, load))
+
+	assert.Equal(t, 1, loads)
+	assert.Equal(t, first, second)
+}
+
+func TestLoader_PropagatesLoadErrors(t *testing.T) {
+	loader := newLoader(&memoryRedis{data: map[string]string{}})
+	errDB := errors.New("db down")
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	failing := func(ctx context.Context) (interface{}, error) {
+		loads.Add(1)
+		<-release
+		return nil, errDB
+	}
+
+	// Every caller sharing the failed load gets its error
+	const callers = 5
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var dest event
+			errs[i] = loader.Get(context.Background(), "event:1", &dest, failing)
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	for _, err := range errs {
+		assert.ErrorIs(t, err, errDB)
+	}
+
+	// Errors are not cached: the next caller loads again
+	before := loads.Load()
+	var dest event
+	err := loader.Get(context.Background(), "event:1", &dest, func(ctx context.Context) (interface{}, error) {
+		loads.Add(1)
+		return event{ID: 1}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, before+1, loads.Load())
+	assert.Equal(t, int64(1), dest.ID)
+}
+
+func TestLoader_RedisDownFallsBackToLoading(t *testing.T) {
+	loader := newLoader(&memoryRedis{data: map[string]string{}, down: true})
+
+	var loads int
+	load := func(ctx context.Context) (interface{}, error) {
+		loads++
+		return event{ID: 1, Name: "Jazz Night"}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		var dest event
+		require.NoError(t, loader.Get(context.Background(), "event:1", &dest, load))
+		assert.Equal(t, "Jazz Night", dest.Name)
+	}
+	// Nothing could be cached, so each call loads
+	assert.Equal(t, 2, loads)
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"ticres/internal/entity"
+)
+
+// ErrTwoFactorRequired is returned by Login for accounts with two-factor
+// authentication, which the client can't sign in to.
+var ErrTwoFactorRequired = errors.New("client: account requires a two-factor code")
+
+// Login exchanges credentials for a token, which the client then sends with
+// every request.
+func (c *Client) Login(ctx context.Context, email, password string) (string, error) {
+	var resp struct {
+		Token             string `json:"token"`
+		TwoFactorRequired bool   `json:"two_factor_required"`
+	}
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/login",
+		body:   map[string]string{"email": email, "password": password},
+		retry:  true,
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+	if resp.TwoFactorRequired {
+		return "", ErrTwoFactorRequired
+	}
+	c.SetToken(resp.Token)
+	return resp.Token, nil
+}
+
+// EventPage is one page of ListEvents.
+type EventPage struct {
+	Events  []entity.Event
+	Total   int
+	Page    int
+	Limit   int
+	HasMore bool
+}
+
+// ListEvents returns a page of events matching filter. Zero page and limit
+// use the server defaults.
+func (c *Client) ListEvents(ctx context.Context, filter entity.EventFilter, page, limit int) (*EventPage, error) {
+	q := url.Values{}
+	for name, value := range map[string]string{
+		"q":        filter.Query,
+		"search":   filter.Search,
+		"location": filter.Location,
+		"category": filter.Category,
+		"status":   string(filter.Status),
+	} {
+		if value != "" {
+			q.Set(name, value)
+		}
+	}
+	if filter.DateFrom != nil {
+		q.Set("date_from", filter.DateFrom.Format("2006-01-02"))
+	}
+	if filter.DateTo != nil {
+		q.Set("date_to", filter.DateTo.Format("2006-01-02"))
+	}
+	if filter.MinPrice != nil {
+		q.Set("min_price", strconv.FormatFloat(*filter.MinPrice, 'f', -1, 64))
+	}
+	if filter.MaxPrice != nil {
+		q.Set("max_price", strconv.FormatFloat(*filter.MaxPrice, 'f', -1, 64))
+	}
+	if page > 0 {
+		q.Set("page", strconv.Itoa(page))
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	path := "/events"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var resp struct {
+		Data []entity.Event `json:"data"`
+		Meta struct {
+			Total   int  `json:"total"`
+			Page    int  `json:"page"`
+			Limit   int  `json:"limit"`
+			HasMore bool `json:"hasMore"`
+		} `json:"meta"`
+	}
+	if err := c.do(ctx, &request{method: http.MethodGet, path: path, retry: true}, &resp); err != nil {
+		return nil, err
+	}
+	return &EventPage{
+		Events:  resp.Data,
+		Total:   resp.Meta.Total,
+		Page:    resp.Meta.Page,
+		Limit:   resp.Meta.Limit,
+		HasMore: resp.Meta.HasMore,
+	}, nil
+}
+
+// BookingRequest books either SeatIDs of a seated event or Quantity
+// tickets: general admission, or the best available seats of a seated event,
+// optionally in TierID or Section. IdempotencyKey is generated when empty.
+type BookingRequest struct {
+	EventID        int64   `json:"event_id"`
+	SeatIDs        []int64 `json:"seat_ids,omitempty"`
+	Quantity       int     `json:"quantity,omitempty"`
+	TierID         *int64  `json:"tier_id,omitempty"`
+	Section        string  `json:"section,omitempty"`
+	IdempotencyKey string  `json:"-"`
+}
+
+// BookSeats creates a PENDING booking. A booking that reached the server
+// may have been created even if the response was lost, so it is only
+// retried when the server turned it away with 429.
+func (c *Client) BookSeats(ctx context.Context, req BookingRequest) (*entity.BookingWithPayment, error) {
+	key := req.IdempotencyKey
+	if key == "" {
+		key = newIdempotencyKey()
+	}
+
+	var resp struct {
+		Data entity.BookingWithPayment `json:"data"`
+	}
+	err := c.do(ctx, &request{
+		method:         http.MethodPost,
+		path:           "/bookings",
+		body:           req,
+		idempotencyKey: key,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Pay charges a PENDING booking. The server charges a booking at most once,
+// so Pay is retried; when a retry finds the booking already paid, the
+// payment the earlier attempt made is returned.
+func (c *Client) Pay(ctx context.Context, bookingID int64, paymentMethod string) (*entity.Transaction, error) {
+	var resp struct {
+		Data entity.Transaction `json:"data"`
+	}
+	req := &request{
+		method:         http.MethodPost,
+		path:           "/payments",
+		body:           map[string]interface{}{"booking_id": bookingID, "payment_method": paymentMethod},
+		idempotencyKey: "payment-" + strconv.FormatInt(bookingID, 10),
+		retry:          true,
+	}
+	err := c.do(ctx, req, &resp)
+	if IsCode(err, "payment_already_made") && req.attempts > 1 {
+		status, statusErr := c.PaymentStatus(ctx, bookingID)
+		if statusErr == nil && status.Transaction != nil && status.Transaction.Status == entity.PaymentCompleted {
+			return status.Transaction, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// PaymentStatus returns one of the user's bookings with its latest payment.
+func (c *Client) PaymentStatus(ctx context.Context, bookingID int64) (*entity.BookingWithPayment, error) {
+	var resp struct {
+		Data entity.BookingWithPayment `json:"data"`
+	}
+	err := c.do(ctx, &request{
+		method: http.MethodGet,
+		path:   "/payments/" + strconv.FormatInt(bookingID, 10),
+		retry:  true,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
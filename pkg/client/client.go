@@ -0,0 +1,215 @@
+// Package client is a typed Go client for the Ticres HTTP API, for internal
+// services and integration tests. Requests that are safe to repeat are
+// retried on network errors, 429 and 5xx gateway responses, and every
+// request that changes state carries an Idempotency-Key that stays the same
+// across its retries.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Config sets where the client sends requests and how hard it retries.
+// Requests that are safe to repeat are tried up to MaxRetries more times,
+// waiting RetryBackoff, doubled each time, in between unless the server
+// asks for longer with Retry-After.
+type Config struct {
+	// BaseURL is the API root including the version, such as
+	// http://localhost:8080/api/v1.
+	BaseURL      string
+	HTTPClient   *http.Client
+	MaxRetries   int
+	RetryBackoff time.Duration
+}
+
+// Client calls the API. It is safe for concurrent use; the token set by
+// Login or SetToken is sent with every request.
+type Client struct {
+	baseURL string
+	http    *http.Client
+	cfg     Config
+
+	mu    sync.RWMutex
+	token string
+}
+
+func New(cfg Config) *Client {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 200 * time.Millisecond
+	}
+	return &Client{
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+		http:    httpClient,
+		cfg:     cfg,
+	}
+}
+
+// SetToken sets the bearer token sent with every request.
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+func (c *Client) bearer() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// APIError is an error response from the API. Code is the stable
+// machine-readable identifier, such as "seat_unavailable".
+type APIError struct {
+	Status  int             `json:"-"`
+	Code    string          `json:"code"`
+	Message string          `json:"error"`
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("ticres: %d %s: %s", e.Status, e.Code, e.Message)
+}
+
+// IsCode reports whether err is an API error with the given code.
+func IsCode(err error, code string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// request is one logical API call, which may be sent more than once.
+type request struct {
+	method string
+	path   string
+	body   interface{}
+	// idempotencyKey is sent as Idempotency-Key on every attempt
+	idempotencyKey string
+	// retry allows retrying after the request may have reached the handler
+	retry bool
+	// attempts is how many times the request was sent
+	attempts int
+}
+
+// do sends req and decodes a successful response body into out.
+func (c *Client) do(ctx context.Context, req *request, out interface{}) error {
+	var payload []byte
+	if req.body != nil {
+		var err error
+		if payload, err = json.Marshal(req.body); err != nil {
+			return err
+		}
+	}
+
+	backoff := c.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		req.attempts++
+		resp, err := c.send(ctx, req, payload)
+		if err == nil && resp.StatusCode < 300 {
+			defer resp.Body.Close()
+			if out == nil {
+				return nil
+			}
+			return json.NewDecoder(resp.Body).Decode(out)
+		}
+
+		var wait time.Duration
+		if err == nil {
+			err = decodeError(resp)
+			wait = retryAfter(resp)
+		}
+		if attempt >= c.cfg.MaxRetries || !retryable(ctx, req, resp) {
+			return err
+		}
+
+		if wait < backoff {
+			wait = backoff
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) send(ctx context.Context, req *request, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, c.baseURL+req.path, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if payload != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if token := c.bearer(); token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	if req.idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", req.idempotencyKey)
+	}
+	return c.http.Do(httpReq)
+}
+
+// retryable decides whether a failed attempt is tried again. A 429 was
+// turned away before the handler ran, so any request may repeat it; other
+// failures may have been partly processed and are only retried when the
+// request is safe to repeat.
+func retryable(ctx context.Context, req *request, resp *http.Response) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if resp == nil {
+		return req.retry
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return req.retry
+	}
+	return false
+}
+
+func decodeError(resp *http.Response) error {
+	defer resp.Body.Close()
+	apiErr := &APIError{Status: resp.StatusCode}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Code == "" {
+		apiErr.Code = "http_" + strconv.Itoa(resp.StatusCode)
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+	return apiErr
+}
+
+// retryAfter reads a Retry-After header given in seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func newIdempotencyKey() string {
+	return uuid.NewString()
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+
+	migrations "ticres/db/migrations"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// NewMigrator returns a golang-migrate instance reading the embedded
+// migrations. Close it when done.
+func NewMigrator(host, port, user, password, dbname, sslmode string) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("load embedded migrations: %w", err)
+	}
+
+	// Built with url.URL so credentials with reserved characters are escaped
+	dsn := url.URL{
+		Scheme:   "pgx5",
+		User:     url.UserPassword(user, password),
+		Host:     net.JoinHostPort(host, port),
+		Path:     dbname,
+		RawQuery: url.Values{"sslmode": {sslmode}}.Encode(),
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", src, dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("connect migrator: %w", err)
+	}
+	return m, nil
+}
+
+// MigrateUp applies all pending migrations. An up-to-date schema is not an error.
+func MigrateUp(host, port, user, password, dbname, sslmode string) error {
+	m, err := NewMigrator(host, port, user, password, dbname, sslmode)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
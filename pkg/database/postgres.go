@@ -3,16 +3,42 @@ package database
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
+
+	"ticres/pkg/tracing"
+
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func NewPostgresConnection(host, port, user, password, dbname, sslmode string) (*pgxpool.Pool, error) {
+// PoolConfig tunes the connection pool. Zero fields keep the defaults: 10
+// connections at most and 2 kept open, connections replaced after an hour
+// or closed after 30 idle minutes, 5 seconds to connect and no statement
+// timeout. StatementTimeout is enforced by the server for every query.
+type PoolConfig struct {
+	MaxConns         int32
+	MinConns         int32
+	MaxConnLifetime  time.Duration
+	MaxConnIdleTime  time.Duration
+	ConnectTimeout   time.Duration
+	StatementTimeout time.Duration
+}
+
+func NewPostgresConnection(host, port, user, password, dbname, sslmode string, pool PoolConfig) (*pgxpool.Pool, error) {
 	// 1. Format Connection String (DSN)
 	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
 		user, password, host, port, dbname, sslmode,
 	)
+	return newPool(dsn, pool)
+}
 
+// NewPostgresReplica connects to a read-only replica, given as a DSN or a
+// postgres:// URL.
+func NewPostgresReplica(dsn string, pool PoolConfig) (*pgxpool.Pool, error) {
+	return newPool(dsn, pool)
+}
+
+func newPool(dsn string, pool PoolConfig) (*pgxpool.Pool, error) {
 	// 2. Parse Config
 	config, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
@@ -24,20 +50,50 @@ func NewPostgresConnection(host, port, user, password, dbname, sslmode string) (
 	config.MinConns = 2                        // Minimal 2 koneksi standby
 	config.MaxConnLifetime = 1 * time.Hour     // Refresh koneksi setiap jam
 	config.MaxConnIdleTime = 30 * time.Minute  // Tutup koneksi jika nganggur 30 menit
+	config.ConnConfig.Tracer = tracing.QueryTracer{} // Span per query (no-op jika tracing mati)
+	connectTimeout := applyPoolConfig(config, pool)
 
 	// 4. Create Pool
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
 	defer cancel()
 
-	pool, err := pgxpool.NewWithConfig(ctx, config)
+	db, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, err
 	}
 
 	// 5. Test Ping (Pastikan benar-benar connect)
-	if err := pool.Ping(ctx); err != nil {
+	if err := db.Ping(ctx); err != nil {
+		db.Close()
 		return nil, err
 	}
 
-	return pool, nil
+	return db, nil
+}
+
+// applyPoolConfig overrides the defaults with the fields of pool that are
+// set, and returns how long connecting may take.
+func applyPoolConfig(config *pgxpool.Config, pool PoolConfig) time.Duration {
+	if pool.MaxConns > 0 {
+		config.MaxConns = pool.MaxConns
+	}
+	if pool.MinConns > 0 {
+		config.MinConns = min(pool.MinConns, config.MaxConns)
+	}
+	if pool.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = pool.MaxConnLifetime
+	}
+	if pool.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = pool.MaxConnIdleTime
+	}
+	if pool.StatementTimeout > 0 {
+		config.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(pool.StatementTimeout.Milliseconds(), 10)
+	}
+
+	connectTimeout := 5 * time.Second
+	if pool.ConnectTimeout > 0 {
+		connectTimeout = pool.ConnectTimeout
+	}
+	config.ConnConfig.ConnectTimeout = connectTimeout
+	return connectTimeout
 }
\ No newline at end of file
@@ -4,25 +4,38 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"time"
+
+	"ticres/pkg/tracing"
 
 	"github.com/redis/go-redis/v9"
 )
 
-func NewRedClient(host, port, password string, useTLS bool)(*redis.Client, error) {
+// NewRedClient connects to Redis. Each command waits at most timeout for
+// the network, and goes through breaker when one is given. The client is
+// returned even when the first ping fails: it reconnects on its own, so
+// callers that can run without Redis may carry on with it.
+func NewRedClient(host, port, password string, useTLS bool, timeout time.Duration, breaker *RedisBreaker)(*redis.Client, error) {
 	opts := &redis.Options{
 		Addr: fmt.Sprintf("%s:%s", host, port),
 		Password: password,
 		DB: 0,
+		DialTimeout:  timeout,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
 	}
 	if useTLS {
 		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
 	}
 	client := redis.NewClient(opts)
+	client.AddHook(tracing.RedisHook{})
+	if breaker != nil {
+		client.AddHook(breaker)
+	}
 
 	if err := client.Ping(context.Background()).Err(); err != nil {
-		return nil , err
+		return client , err
 	}
 
 	return client , nil
 }
-
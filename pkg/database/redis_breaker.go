@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"ticres/pkg/clock"
+	"ticres/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrCacheUnavailable is returned for Redis commands skipped while the
+// circuit is open. Callers treat it like any other Redis failure.
+var ErrCacheUnavailable = errors.New("redis: circuit open, command skipped")
+
+// Circuit states reported by RedisBreaker.CircuitState.
+const (
+	CircuitClosed = "closed"
+	// CircuitOpen fails commands fast until the cooldown is over.
+	CircuitOpen = "open"
+	// CircuitHalfOpen lets a trial command decide whether the circuit closes.
+	CircuitHalfOpen = "half_open"
+)
+
+// RedisBreaker is a client hook that stops sending commands to Redis after
+// Threshold failures in a row, so an outage costs requests nothing instead
+// of a timeout per cache lookup. After Cooldown one trial command is let
+// through, and the circuit closes again once Redis answers. Replies such as
+// a missing key or a script error mean Redis is up and count as successes.
+type RedisBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	clock     clock.Clock
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+var _ redis.Hook = (*RedisBreaker)(nil)
+
+func NewRedisBreaker(threshold int, cooldown time.Duration, clk clock.Clock) *RedisBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &RedisBreaker{threshold: threshold, cooldown: cooldown, clock: clk}
+}
+
+func (b *RedisBreaker) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (b *RedisBreaker) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !b.allow() {
+			cmd.SetErr(ErrCacheUnavailable)
+			return ErrCacheUnavailable
+		}
+		err := next(ctx, cmd)
+		b.record(ctx, err)
+		return err
+	}
+}
+
+func (b *RedisBreaker) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !b.allow() {
+			for _, cmd := range cmds {
+				cmd.SetErr(ErrCacheUnavailable)
+			}
+			return ErrCacheUnavailable
+		}
+		err := next(ctx, cmds)
+		b.record(ctx, err)
+		return err
+	}
+}
+
+// CircuitState reports the breaker's state, for health reporting.
+func (b *RedisBreaker) CircuitState() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.failures < b.threshold:
+		return CircuitClosed
+	case b.clock.Now().Before(b.openUntil):
+		return CircuitOpen
+	default:
+		return CircuitHalfOpen
+	}
+}
+
+// allow reports whether a command may go out. Once the cooldown is over a
+// single trial command is let through while the others keep failing fast.
+func (b *RedisBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.clock.Now().Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *RedisBreaker) record(ctx context.Context, err error) {
+	// A caller that gave up says nothing about Redis
+	if err != nil && ctx.Err() != nil {
+		b.mu.Lock()
+		b.probing = false
+		b.mu.Unlock()
+		return
+	}
+	var reply redis.Error
+	ok := err == nil || errors.As(err, &reply)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if ok {
+		if b.failures >= b.threshold {
+			logger.Info("cache: redis recovered, circuit closed")
+		}
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		if b.failures == b.threshold {
+			logger.Error("cache: redis failing, circuit opened", logger.Int("failures", b.failures), logger.Err(err))
+		}
+		b.openUntil = b.clock.Now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,142 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticres/pkg/clock"
+	"ticres/pkg/database"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var errConnRefused = errors.New("dial tcp 127.0.0.1:6379: connect: connection refused")
+
+// fakeRedis stands in for the connection behind the hook: it answers every
+// command with err and counts the commands that reached it.
+type fakeRedis struct {
+	err   error
+	calls int
+}
+
+func (f *fakeRedis) process(ctx context.Context, cmd redis.Cmder) error {
+	f.calls++
+	cmd.SetErr(f.err)
+	return f.err
+}
+
+func send(hook redis.ProcessHook) error {
+	return hook(context.Background(), redis.NewStringCmd(context.Background(), "get", "key"))
+}
+
+func TestRedisBreaker_OpensAfterThreshold(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	b := database.NewRedisBreaker(3, 30*time.Second, clk)
+	backend := &fakeRedis{err: errConnRefused}
+	hook := b.ProcessHook(backend.process)
+
+	// Failures below the threshold still go out
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, send(hook), errConnRefused)
+		assert.Equal(t, database.CircuitClosed, b.CircuitState())
+	}
+
+	assert.ErrorIs(t, send(hook), errConnRefused)
+	assert.Equal(t, database.CircuitOpen, b.CircuitState())
+
+	// Open: commands fail fast without reaching Redis
+	assert.ErrorIs(t, send(hook), database.ErrCacheUnavailable)
+	assert.Equal(t, 3, backend.calls)
+}
+
+func TestRedisBreaker_SuccessResetsCount(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	b := database.NewRedisBreaker(2, 30*time.Second, clk)
+	backend := &fakeRedis{err: errConnRefused}
+	hook := b.ProcessHook(backend.process)
+
+	assert.ErrorIs(t, send(hook), errConnRefused)
+	backend.err = nil
+	assert.NoError(t, send(hook))
+	backend.err = errConnRefused
+	assert.ErrorIs(t, send(hook), errConnRefused)
+
+	// Two failures, but not in a row
+	assert.Equal(t, database.CircuitClosed, b.CircuitState())
+}
+
+func TestRedisBreaker_ReplyErrorsCountAsSuccess(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	b := database.NewRedisBreaker(1, 30*time.Second, clk)
+	backend := &fakeRedis{err: redis.Nil}
+	hook := b.ProcessHook(backend.process)
+
+	assert.ErrorIs(t, send(hook), redis.Nil)
+	assert.Equal(t, database.CircuitClosed, b.CircuitState())
+}
+
+func TestRedisBreaker_CancelledCallerDoesNotCount(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	b := database.NewRedisBreaker(1, 30*time.Second, clk)
+	backend := &fakeRedis{err: context.Canceled}
+	hook := b.ProcessHook(backend.process)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, hook(ctx, redis.NewStringCmd(ctx, "get", "key")))
+	assert.Equal(t, database.CircuitClosed, b.CircuitState())
+}
+
+func TestRedisBreaker_HalfOpenAfterCooldown(t *testing.T) {
+	tests := []struct {
+		name      string
+		trialErr  error
+		wantState string
+	}{
+		{name: "Trial Succeeds - Closes", wantState: database.CircuitClosed},
+		{name: "Trial Fails - Reopens", trialErr: errConnRefused, wantState: database.CircuitOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+			b := database.NewRedisBreaker(1, 30*time.Second, clk)
+			backend := &fakeRedis{err: errConnRefused}
+			hook := b.ProcessHook(backend.process)
+
+			assert.ErrorIs(t, send(hook), errConnRefused)
+			assert.Equal(t, database.CircuitOpen, b.CircuitState())
+
+			// Still open until the cooldown is fully over
+			clk.Advance(30*time.Second - time.Nanosecond)
+			assert.Equal(t, database.CircuitOpen, b.CircuitState())
+			assert.ErrorIs(t, send(hook), database.ErrCacheUnavailable)
+
+			clk.Advance(time.Nanosecond)
+			assert.Equal(t, database.CircuitHalfOpen, b.CircuitState())
+
+			// Only one trial goes out; commands sent while it runs fail fast
+			var during error
+			trial := b.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error {
+				during = send(hook)
+				return backend.process(ctx, cmd)
+			})
+			backend.err = tt.trialErr
+			assert.ErrorIs(t, send(trial), tt.trialErr)
+			assert.ErrorIs(t, during, database.ErrCacheUnavailable)
+			assert.Equal(t, 2, backend.calls)
+
+			assert.Equal(t, tt.wantState, b.CircuitState())
+			if tt.trialErr != nil {
+				// A failed trial starts a fresh cooldown
+				clk.Advance(29 * time.Second)
+				assert.Equal(t, database.CircuitOpen, b.CircuitState())
+				clk.Advance(time.Second)
+				assert.Equal(t, database.CircuitHalfOpen, b.CircuitState())
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package email
+
+import (
+	"context"
+
+	"ticres/pkg/logger"
+)
+
+// Message is a single outgoing email. Text is the plain-text alternative
+// shown by clients that don't render HTML.
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Sender delivers emails through a mail provider.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogSender writes emails to the application log instead of sending them.
+// Used in development when no provider is configured. The body is not logged
+// because it may contain credentials such as password reset links.
+type LogSender struct{}
+
+func (LogSender) Send(ctx context.Context, msg Message) error {
+	logger.Info("email: not sent, no provider configured",
+		logger.String("to", msg.To),
+		logger.String("subject", msg.Subject),
+	)
+	return nil
+}
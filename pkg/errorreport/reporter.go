@@ -0,0 +1,77 @@
+package errorreport
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Event is a crash worth a look from a developer, such as a panic recovered
+// while serving a request.
+type Event struct {
+	Message   string
+	Frames    []Frame
+	RequestID string
+	Method    string
+	URL       string
+	Route     string
+	UserID    *int64
+	Time      time.Time
+}
+
+// Frame is one call of a stack trace, innermost last.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// Reporter sends events to an error tracker. Report must not block the
+// caller. Close delivers what is still queued; nothing may be reported
+// after it.
+type Reporter interface {
+	Report(event Event)
+	Close(ctx context.Context) error
+}
+
+// NopReporter drops events. Used when no error tracker is configured.
+type NopReporter struct{}
+
+func (NopReporter) Report(Event)                    {}
+func (NopReporter) Close(ctx context.Context) error { return nil }
+
+// NewReporter returns a Sentry reporter when a DSN is configured, otherwise a
+// reporter that drops events.
+func NewReporter(dsn, environment, release string) (Reporter, error) {
+	if dsn == "" {
+		return NopReporter{}, nil
+	}
+	return NewSentryReporter(dsn, environment, release)
+}
+
+// Callers returns the stack from its caller outward, less the innermost
+// skip frames and the runtime's panic machinery, innermost last. Called in
+// a deferred recover with skip 1, the stack starts where the panic was
+// raised.
+func Callers(skip int) []Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []Frame
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, "runtime.") {
+			stack = append(stack, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	// Trackers list the outermost call first
+	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+		stack[i], stack[j] = stack[j], stack[i]
+	}
+	return stack
+}
@@ -0,0 +1,189 @@
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+const (
+	// sentryQueueSize bounds the events waiting to be sent; a crash loop
+	// beyond it drops events instead of piling up memory.
+	sentryQueueSize = 100
+	sentryTimeout   = 5 * time.Second
+)
+
+// SentryReporter sends events to Sentry's store endpoint from a background
+// goroutine, so a slow or unreachable Sentry never holds up a response.
+type SentryReporter struct {
+	endpoint    string
+	auth        string
+	environment string
+	release     string
+	serverName  string
+	client      *http.Client
+
+	queue     chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewSentryReporter parses a DSN of the form
+// https://<public key>@<host>/<project id> and starts the sender.
+func NewSentryReporter(dsn, environment, release string) (*SentryReporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sentry DSN: %w", err)
+	}
+	projectID := strings.Trim(u.Path, "/")
+	if u.User == nil || u.User.Username() == "" || u.Host == "" || projectID == "" {
+		return nil, fmt.Errorf("invalid sentry DSN: want https://<key>@<host>/<project>")
+	}
+	// Self-hosted Sentry can sit under a path prefix
+	prefix := ""
+	if i := strings.LastIndex(projectID, "/"); i >= 0 {
+		prefix, projectID = "/"+projectID[:i], projectID[i+1:]
+	}
+	if _, err := strconv.ParseUint(projectID, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid sentry DSN: project id %q is not a number", projectID)
+	}
+
+	serverName, _ := os.Hostname()
+	r := &SentryReporter{
+		endpoint:    fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, prefix, projectID),
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_client=ticres/1.0, sentry_key=%s", u.User.Username()),
+		environment: environment,
+		release:     release,
+		serverName:  serverName,
+		client:      &http.Client{Timeout: sentryTimeout},
+		queue:       make(chan Event, sentryQueueSize),
+		done:        make(chan struct{}),
+	}
+	go r.run()
+	return r, nil
+}
+
+func (r *SentryReporter) Report(event Event) {
+	select {
+	case r.queue <- event:
+	default:
+		logger.Warn("errorreport: queue full, dropping event", logger.String("message", event.Message))
+	}
+}
+
+// Close stops taking events and waits until the queued ones are sent or ctx
+// ends.
+func (r *SentryReporter) Close(ctx context.Context) error {
+	r.closeOnce.Do(func() { close(r.queue) })
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *SentryReporter) run() {
+	defer close(r.done)
+	for event := range r.queue {
+		if err := r.send(event); err != nil {
+			logger.Error("errorreport: failed to send event to sentry", logger.String("request_id", event.RequestID), logger.Err(err))
+		}
+	}
+}
+
+func (r *SentryReporter) send(event Event) error {
+	body, err := json.Marshal(r.payload(event))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sentryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.auth)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// payload builds a Sentry event with the panic as its exception.
+func (r *SentryReporter) payload(event Event) map[string]interface{} {
+	frames := make([]map[string]interface{}, len(event.Frames))
+	for i, f := range event.Frames {
+		frames[i] = map[string]interface{}{
+			"function": f.Function,
+			"abs_path": f.File,
+			"lineno":   f.Line,
+			"in_app":   strings.HasPrefix(f.Function, "ticres/"),
+		}
+	}
+
+	tags := map[string]string{}
+	if event.RequestID != "" {
+		tags["request_id"] = event.RequestID
+	}
+	if event.Route != "" {
+		tags["route"] = event.Route
+	}
+
+	p := map[string]interface{}{
+		"event_id":    newEventID(),
+		"timestamp":   event.Time.UTC().Format(time.RFC3339),
+		"level":       "fatal",
+		"platform":    "go",
+		"logger":      "http",
+		"server_name": r.serverName,
+		"environment": r.environment,
+		"message":     map[string]string{"formatted": event.Message},
+		"exception": map[string]interface{}{
+			"values": []map[string]interface{}{{
+				"type":       "panic",
+				"value":      event.Message,
+				"stacktrace": map[string]interface{}{"frames": frames},
+			}},
+		},
+		"tags": tags,
+	}
+	if r.release != "" {
+		p["release"] = r.release
+	}
+	if event.Method != "" {
+		p["request"] = map[string]string{"method": event.Method, "url": event.URL}
+	}
+	if event.UserID != nil {
+		p["user"] = map[string]string{"id": strconv.FormatInt(*event.UserID, 10)}
+	}
+	return p
+}
+
+// newEventID returns the 32 hex digits Sentry expects as an event ID.
+func newEventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,114 @@
+// Package i18n translates API responses and emails. English is the source
+// language: messages are written in English at the call site and the
+// English text is the key into the other catalogs, so a message missing
+// from a catalog is shown in English rather than lost.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Default is the language used when the client accepts none of the
+// supported ones.
+const Default = "en"
+
+// Catalogs hold the translations of one language as a JSON object from the
+// English message to the translated one. Placeholders are %s and must keep
+// their order.
+//
+//go:embed locales/*.json
+var localeFS embed.FS
+
+var catalogs = loadCatalogs()
+
+func loadCatalogs() map[string]map[string]string {
+	files, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	catalogs := map[string]map[string]string{Default: {}}
+	for _, f := range files {
+		raw, err := localeFS.ReadFile("locales/" + f.Name())
+		if err != nil {
+			panic(err)
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(raw, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: parse %s: %v", f.Name(), err))
+		}
+		catalogs[strings.TrimSuffix(f.Name(), ".json")] = messages
+	}
+	return catalogs
+}
+
+// Supported reports whether lang, such as "id", has a catalog.
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Match picks the supported language the client prefers from an
+// Accept-Language header such as "id-ID,id;q=0.9,en;q=0.8". Regional
+// variants match their base language.
+func Match(acceptLanguage string) string {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if q > bestQ && Supported(lang) {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// T translates message into lang and fills in args. Messages without a
+// translation are returned as given.
+func T(lang, message string, args ...interface{}) string {
+	if translated, ok := catalogs[lang][message]; ok && translated != "" {
+		message = translated
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Message is an untranslated message whose arguments are already
+// formatted, so it can be queued and translated later, such as by the
+// notification worker in the language of its emails.
+type Message struct {
+	Text string
+	Args []string
+}
+
+// Msg builds a Message. Args are formatted with fmt.Sprint, so the text
+// uses %s for each of them.
+func Msg(text string, args ...interface{}) Message {
+	m := Message{Text: text}
+	for _, arg := range args {
+		m.Args = append(m.Args, fmt.Sprint(arg))
+	}
+	return m
+}
+
+// In translates the message into lang.
+func (m Message) In(lang string) string {
+	args := make([]interface{}, len(m.Args))
+	for i, arg := range m.Args {
+		args[i] = arg
+	}
+	return T(lang, m.Text, args...)
+}
@@ -0,0 +1,193 @@
+// Package jwtkeys signs and verifies the API's access tokens.
+//
+// A KeySet signs either with HS256 and the shared JWT secret, or with RS256
+// and an RSA private key, so other services can check tokens with the
+// public key alone. RS256 tokens carry the key's ID in their kid header,
+// the RFC 7638 thumbprint of the public key, and the public keys are
+// published as a JSON Web Key Set.
+//
+// RSA keys rotate without logging anyone out. The KeySet signs with the
+// current key and also accepts tokens signed with the previous one, which
+// stays in the published set until the last token it signed has expired.
+package jwtkeys
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrNoKey      = errors.New("no signing key configured")
+	ErrUnknownKey = errors.New("token signed with an unknown key")
+)
+
+// JWK is the public half of an RSA signing key as published in a JWKS.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JWKS is the document served at /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySet holds the key tokens are signed with and the keys they are
+// verified with.
+type KeySet struct {
+	method jwt.SigningMethod
+	secret []byte
+
+	signingKey *rsa.PrivateKey
+	signingKID string
+	// verifyKeys is keyed by kid; jwks lists them current key first.
+	verifyKeys map[string]*rsa.PublicKey
+	jwks       JWKS
+}
+
+// NewHMAC returns a KeySet signing and verifying with HS256 and secret.
+func NewHMAC(secret []byte) (*KeySet, error) {
+	if len(secret) == 0 {
+		return nil, ErrNoKey
+	}
+	return &KeySet{method: jwt.SigningMethodHS256, secret: secret, jwks: JWKS{Keys: []JWK{}}}, nil
+}
+
+// NewRSA returns a KeySet signing with RS256 and current, and accepting
+// tokens signed with current or any of previous.
+func NewRSA(current *rsa.PrivateKey, previous ...*rsa.PublicKey) (*KeySet, error) {
+	if current == nil {
+		return nil, ErrNoKey
+	}
+	k := &KeySet{
+		method:     jwt.SigningMethodRS256,
+		signingKey: current,
+		verifyKeys: map[string]*rsa.PublicKey{},
+		jwks:       JWKS{Keys: []JWK{}},
+	}
+	for _, pub := range append([]*rsa.PublicKey{&current.PublicKey}, previous...) {
+		jwk := publicJWK(pub)
+		if _, dup := k.verifyKeys[jwk.KeyID]; dup {
+			continue
+		}
+		k.verifyKeys[jwk.KeyID] = pub
+		k.jwks.Keys = append(k.jwks.Keys, jwk)
+	}
+	k.signingKID = k.jwks.Keys[0].KeyID
+	return k, nil
+}
+
+// LoadRSAFiles reads the current private key and, when previousFile is set,
+// the previous key from PEM files. The previous key may be given as its
+// private or its public key.
+func LoadRSAFiles(currentFile, previousFile string) (*KeySet, error) {
+	if currentFile == "" {
+		return nil, ErrNoKey
+	}
+	data, err := os.ReadFile(currentFile)
+	if err != nil {
+		return nil, fmt.Errorf("read signing key: %w", err)
+	}
+	current, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse signing key: %w", err)
+	}
+
+	var previous []*rsa.PublicKey
+	if previousFile != "" {
+		data, err := os.ReadFile(previousFile)
+		if err != nil {
+			return nil, fmt.Errorf("read previous signing key: %w", err)
+		}
+		pub, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			key, privErr := jwt.ParseRSAPrivateKeyFromPEM(data)
+			if privErr != nil {
+				return nil, fmt.Errorf("parse previous signing key: %w", err)
+			}
+			pub = &key.PublicKey
+		}
+		previous = append(previous, pub)
+	}
+	return NewRSA(current, previous...)
+}
+
+// Algorithm returns the JWT algorithm tokens are signed with.
+func (k *KeySet) Algorithm() string {
+	return k.method.Alg()
+}
+
+// Sign returns the signed token for claims.
+func (k *KeySet) Sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.signingKey == nil {
+		return token.SignedString(k.secret)
+	}
+	token.Header["kid"] = k.signingKID
+	return token.SignedString(k.signingKey)
+}
+
+// Parse verifies tokenString and returns its claims. Only the KeySet's
+// algorithm is accepted, so an RS256 public key can't be passed off as an
+// HS256 secret.
+func (k *KeySet) Parse(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, k.key, jwt.WithValidMethods([]string{k.method.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
+func (k *KeySet) key(token *jwt.Token) (interface{}, error) {
+	if k.signingKey == nil {
+		return k.secret, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	pub, ok := k.verifyKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: kid %q", ErrUnknownKey, kid)
+	}
+	return pub, nil
+}
+
+// JWKS returns the public keys tokens may be signed with, for services
+// verifying them. It is empty for HS256, whose secret is never published.
+func (k *KeySet) JWKS() JWKS {
+	return k.jwks
+}
+
+func publicJWK(pub *rsa.PublicKey) JWK {
+	n := base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	// RFC 7638: the required members in lexicographic order, no whitespace
+	thumbprint, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{e, "RSA", n})
+	sum := sha256.Sum256(thumbprint)
+	return JWK{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: jwt.SigningMethodRS256.Alg(),
+		KeyID:     base64.RawURLEncoding.EncodeToString(sum[:]),
+		Modulus:   n,
+		Exponent:  e,
+	}
+}
@@ -0,0 +1,162 @@
+package jwtkeys_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"ticres/pkg/jwtkeys"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func claims() jwt.MapClaims {
+	return jwt.MapClaims{"user_id": float64(42), "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func TestKeySet_HMACRoundTrip(t *testing.T) {
+	keys, err := jwtkeys.NewHMAC([]byte("secret"))
+	require.NoError(t, err)
+
+	token, err := keys.Sign(claims())
+	require.NoError(t, err)
+
+	got, err := keys.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, float64(42), got["user_id"])
+	assert.Equal(t, "HS256", keys.Algorithm())
+	assert.Empty(t, keys.JWKS().Keys)
+
+	other, err := jwtkeys.NewHMAC([]byte("other secret"))
+	require.NoError(t, err)
+	_, err = other.Parse(token)
+	assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+}
+
+func TestKeySet_RSARoundTrip(t *testing.T) {
+	keys, err := jwtkeys.NewRSA(newKey(t))
+	require.NoError(t, err)
+
+	signed, err := keys.Sign(claims())
+	require.NoError(t, err)
+
+	got, err := keys.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, float64(42), got["user_id"])
+	assert.Equal(t, "RS256", keys.Algorithm())
+
+	// The kid header names the published key
+	token, _, err := jwt.NewParser().ParseUnverified(signed, jwt.MapClaims{})
+	require.NoError(t, err)
+	require.Len(t, keys.JWKS().Keys, 1)
+	assert.Equal(t, keys.JWKS().Keys[0].KeyID, token.Header["kid"])
+}
+
+func TestKeySet_NoKey(t *testing.T) {
+	_, err := jwtkeys.NewHMAC(nil)
+	assert.ErrorIs(t, err, jwtkeys.ErrNoKey)
+
+	_, err = jwtkeys.NewRSA(nil)
+	assert.ErrorIs(t, err, jwtkeys.ErrNoKey)
+}
+
+func TestKeySet_RejectsOtherAlgorithm(t *testing.T) {
+	key := newKey(t)
+	rsaKeys, err := jwtkeys.NewRSA(key)
+	require.NoError(t, err)
+	hmacKeys, err := jwtkeys.NewHMAC([]byte("secret"))
+	require.NoError(t, err)
+
+	t.Run("HS256 Token Signed With The Public Key", func(t *testing.T) {
+		// The classic confusion attack: the public modulus used as an
+		// HMAC secret
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+		token.Header["kid"] = rsaKeys.JWKS().Keys[0].KeyID
+		forged, err := token.SignedString(key.PublicKey.N.Bytes())
+		require.NoError(t, err)
+
+		_, err = rsaKeys.Parse(forged)
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	t.Run("RS256 Token Given To HS256", func(t *testing.T) {
+		signed, err := rsaKeys.Sign(claims())
+		require.NoError(t, err)
+
+		_, err = hmacKeys.Parse(signed)
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	t.Run("Unsigned Token", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, claims())
+		unsigned, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = hmacKeys.Parse(unsigned)
+		assert.Error(t, err)
+		_, err = rsaKeys.Parse(unsigned)
+		assert.Error(t, err)
+	})
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	oldKey, currentKey := newKey(t), newKey(t)
+
+	before, err := jwtkeys.NewRSA(oldKey)
+	require.NoError(t, err)
+	issued, err := before.Sign(claims())
+	require.NoError(t, err)
+
+	after, err := jwtkeys.NewRSA(currentKey, &oldKey.PublicKey)
+	require.NoError(t, err)
+
+	// Tokens signed before the rotation keep working
+	got, err := after.Parse(issued)
+	require.NoError(t, err)
+	assert.Equal(t, float64(42), got["user_id"])
+
+	// New tokens use the new key, which is published first
+	jwks := after.JWKS().Keys
+	require.Len(t, jwks, 2)
+	assert.Equal(t, before.JWKS().Keys[0].KeyID, jwks[1].KeyID)
+	signed, err := after.Sign(claims())
+	require.NoError(t, err)
+	token, _, err := jwt.NewParser().ParseUnverified(signed, jwt.MapClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, jwks[0].KeyID, token.Header["kid"])
+
+	// Once the previous key is dropped its tokens stop working
+	dropped, err := jwtkeys.NewRSA(currentKey)
+	require.NoError(t, err)
+	_, err = dropped.Parse(issued)
+	assert.ErrorIs(t, err, jwtkeys.ErrUnknownKey)
+}
+
+func TestKeySet_Thumbprint(t *testing.T) {
+	// The example key of RFC 7638, section 3.1
+	n := "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	modulus, err := base64.RawURLEncoding.DecodeString(n)
+	require.NoError(t, err)
+	example := &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: 65537}
+
+	keys, err := jwtkeys.NewRSA(newKey(t), example)
+	require.NoError(t, err)
+
+	jwks := keys.JWKS().Keys
+	require.Len(t, jwks, 2)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", jwks[1].KeyID)
+	assert.Equal(t, n, jwks[1].Modulus)
+	assert.Equal(t, "AQAB", jwks[1].Exponent)
+}
@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+
+// ContextWithRequestID stores the request ID so FromContext can attach it
+// to log lines further down the call chain.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "".
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// FromContext returns the global logger with the request ID from ctx
+// attached as the request_id field. Without a request ID (background jobs,
+// startup) it logs exactly like the package-level helpers.
+func FromContext(ctx context.Context) *zap.Logger {
+	// The global logger skips one frame for the helper wrappers; callers of
+	// the returned logger log directly, so undo that to keep caller accurate
+	l := GetLogger().WithOptions(zap.AddCallerSkip(-1))
+	if id := RequestIDFromContext(ctx); id != "" {
+		return l.With(zap.String("request_id", id))
+	}
+	return l
+}
@@ -97,6 +97,9 @@ func WithContext(fields ...zap.Field) *zap.Logger {
 	return GetLogger().With(fields...)
 }
 
+// Field is a structured log field, for building field lists to log.
+type Field = zap.Field
+
 // Common field helpers
 func String(key, val string) zap.Field {
 	return zap.String(key, val)
@@ -0,0 +1,351 @@
+// Package openapi checks HTTP requests and responses against the Swagger 2.0
+// document generated from the handler annotations. It understands the
+// subset of the spec swag emits: path, query and header parameters, JSON
+// bodies described by definitions, and per-status response schemas.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+type operation struct {
+	params    []spec.Parameter
+	responses *spec.Responses
+}
+
+// Validator holds the operations of one API document, keyed by method and
+// path template.
+type Validator struct {
+	basePath    string
+	definitions spec.Definitions
+	operations  map[string]operation
+}
+
+// New parses a Swagger 2.0 JSON document.
+func New(doc []byte) (*Validator, error) {
+	var swagger spec.Swagger
+	if err := json.Unmarshal(doc, &swagger); err != nil {
+		return nil, fmt.Errorf("openapi: parse document: %w", err)
+	}
+	if swagger.Paths == nil {
+		return nil, fmt.Errorf("openapi: document has no paths")
+	}
+
+	v := &Validator{
+		basePath:    strings.TrimSuffix(swagger.BasePath, "/"),
+		definitions: swagger.Definitions,
+		operations:  make(map[string]operation),
+	}
+	for path, item := range swagger.Paths.Paths {
+		ops := map[string]*spec.Operation{
+			http.MethodGet:     item.Get,
+			http.MethodPost:    item.Post,
+			http.MethodPut:     item.Put,
+			http.MethodPatch:   item.Patch,
+			http.MethodDelete:  item.Delete,
+			http.MethodHead:    item.Head,
+			http.MethodOptions: item.Options,
+		}
+		for method, op := range ops {
+			if op == nil {
+				continue
+			}
+			params := append(append([]spec.Parameter{}, item.Parameters...), op.Parameters...)
+			v.operations[method+" "+path] = operation{params: params, responses: op.Responses}
+		}
+	}
+	return v, nil
+}
+
+// Route is a request matched to a route: its method, the router's path
+// template in gin syntax (/api/v1/events/:id) and the path parameters.
+type Route struct {
+	Method     string
+	Path       string
+	PathParams map[string]string
+}
+
+// operation finds the documented operation for the route.
+func (v *Validator) operation(route Route) (operation, bool) {
+	path := strings.TrimPrefix(route.Path, v.basePath)
+	if path == route.Path && v.basePath != "" {
+		return operation{}, false
+	}
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	op, ok := v.operations[route.Method+" "+strings.Join(segments, "/")]
+	return op, ok
+}
+
+// Documents reports whether the route is in the document. Undocumented
+// routes are not validated.
+func (v *Validator) Documents(route Route) bool {
+	_, ok := v.operation(route)
+	return ok
+}
+
+// ValidateRequest checks the parameters and, for JSON requests, the body of
+// r against the route's operation. body is the raw request body; r.Body is
+// not read. A nil body, such as one too large to buffer, is not checked.
+func (v *Validator) ValidateRequest(route Route, r *http.Request, body []byte) []error {
+	op, ok := v.operation(route)
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	query := r.URL.Query()
+	for _, p := range op.params {
+		switch p.In {
+		case "path":
+			errs = append(errs, v.checkParam(p, []string{route.PathParams[p.Name]}, true)...)
+		case "query":
+			values, present := query[p.Name]
+			errs = append(errs, v.checkParam(p, values, present)...)
+		case "header":
+			values := r.Header.Values(p.Name)
+			errs = append(errs, v.checkParam(p, values, len(values) > 0)...)
+		case "body":
+			// Handlers bind bodies as JSON whatever the header says, so
+			// only bodies that declare another type are skipped
+			if ct := r.Header.Get("Content-Type"); body == nil || ct != "" && !isJSON(ct) {
+				continue
+			}
+			if len(bytes.TrimSpace(body)) == 0 {
+				if p.Required {
+					errs = append(errs, fmt.Errorf("body: required"))
+				}
+				continue
+			}
+			value, err := decode(body)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("body: invalid JSON: %v", err))
+				continue
+			}
+			errs = append(errs, v.checkSchema("body", p.Schema, value)...)
+		}
+	}
+	return errs
+}
+
+// ValidateResponse checks that status is documented for the route and that
+// a JSON body matches its schema.
+func (v *Validator) ValidateResponse(route Route, status int, contentType string, body []byte) []error {
+	op, ok := v.operation(route)
+	if !ok || op.responses == nil {
+		return nil
+	}
+
+	resp, ok := op.responses.StatusCodeResponses[status]
+	if !ok {
+		if op.responses.Default == nil {
+			return []error{fmt.Errorf("status %d is not documented", status)}
+		}
+		resp = *op.responses.Default
+	}
+	if resp.Schema == nil || !isJSON(contentType) || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	value, err := decode(body)
+	if err != nil {
+		return []error{fmt.Errorf("response: invalid JSON: %v", err)}
+	}
+	return v.checkSchema("response", resp.Schema, value)
+}
+
+func isJSON(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/json")
+}
+
+func decode(body []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// checkParam validates the raw values of a non-body parameter.
+func (v *Validator) checkParam(p spec.Parameter, values []string, present bool) []error {
+	name := p.In + "." + p.Name
+	if !present || len(values) == 0 || values[0] == "" && p.Type != "string" {
+		if p.Required {
+			return []error{fmt.Errorf("%s: required", name)}
+		}
+		return nil
+	}
+
+	if p.Type == "array" {
+		if p.CollectionFormat != "multi" {
+			values = strings.Split(values[0], ",")
+		}
+		var errs []error
+		if p.Items == nil {
+			return nil
+		}
+		item := spec.SimpleSchema{Type: p.Items.Type, Format: p.Items.Format}
+		for i, raw := range values {
+			errs = append(errs, checkSimple(fmt.Sprintf("%s[%d]", name, i), item, p.Items.Enum, p.Items.Minimum, p.Items.Maximum, raw)...)
+		}
+		return errs
+	}
+	return checkSimple(name, p.SimpleSchema, p.Enum, p.Minimum, p.Maximum, values[0])
+}
+
+// checkSimple validates one string-encoded parameter value.
+func checkSimple(name string, s spec.SimpleSchema, enum []interface{}, min, max *float64, raw string) []error {
+	var n float64
+	switch s.Type {
+	case "integer":
+		i, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return []error{fmt.Errorf("%s: %q is not an integer", name, raw)}
+		}
+		n = float64(i)
+	case "number":
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return []error{fmt.Errorf("%s: %q is not a number", name, raw)}
+		}
+		n = f
+	case "boolean":
+		if _, err := strconv.ParseBool(raw); err != nil {
+			return []error{fmt.Errorf("%s: %q is not a boolean", name, raw)}
+		}
+	}
+
+	if s.Type == "integer" || s.Type == "number" {
+		if min != nil && n < *min {
+			return []error{fmt.Errorf("%s: %s is below the minimum %v", name, raw, *min)}
+		}
+		if max != nil && n > *max {
+			return []error{fmt.Errorf("%s: %s is above the maximum %v", name, raw, *max)}
+		}
+	}
+	if len(enum) > 0 && !inEnum(enum, raw) {
+		return []error{fmt.Errorf("%s: %q is not one of %v", name, raw, enum)}
+	}
+	return nil
+}
+
+func inEnum(enum []interface{}, value string) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSchema validates a decoded JSON value. null is accepted anywhere:
+// Go encodes nil pointers, slices and maps as null, and Swagger 2.0 has no
+// way to mark a field nullable.
+func (v *Validator) checkSchema(path string, s *spec.Schema, value interface{}) []error {
+	if s == nil || value == nil {
+		return nil
+	}
+	if ref := s.Ref.String(); ref != "" {
+		def, ok := v.definitions[strings.TrimPrefix(ref, "#/definitions/")]
+		if !ok {
+			return []error{fmt.Errorf("%s: unknown schema %s", path, ref)}
+		}
+		return v.checkSchema(path, &def, value)
+	}
+
+	var errs []error
+	for i := range s.AllOf {
+		errs = append(errs, v.checkSchema(path, &s.AllOf[i], value)...)
+	}
+
+	switch {
+	case s.Type.Contains("object"):
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return append(errs, fmt.Errorf("%s: expected object", path))
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				errs = append(errs, fmt.Errorf("%s.%s: required", path, name))
+			}
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if prop, ok := s.Properties[k]; ok {
+				errs = append(errs, v.checkSchema(path+"."+k, &prop, obj[k])...)
+			} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+				errs = append(errs, v.checkSchema(path+"."+k, s.AdditionalProperties.Schema, obj[k])...)
+			}
+		}
+	case s.Type.Contains("array"):
+		arr, ok := value.([]interface{})
+		if !ok {
+			return append(errs, fmt.Errorf("%s: expected array", path))
+		}
+		if s.Items != nil && s.Items.Schema != nil {
+			for i, item := range arr {
+				errs = append(errs, v.checkSchema(fmt.Sprintf("%s[%d]", path, i), s.Items.Schema, item)...)
+			}
+		}
+	case s.Type.Contains("string"):
+		str, ok := value.(string)
+		if !ok {
+			return append(errs, fmt.Errorf("%s: expected string", path))
+		}
+		if len(s.Enum) > 0 && !inEnum(s.Enum, str) {
+			errs = append(errs, fmt.Errorf("%s: %q is not one of %v", path, str, s.Enum))
+		}
+	case s.Type.Contains("integer"):
+		n, ok := value.(json.Number)
+		if _, err := n.Int64(); !ok || err != nil {
+			return append(errs, fmt.Errorf("%s: expected integer", path))
+		}
+		errs = append(errs, checkRange(path, s, n)...)
+	case s.Type.Contains("number"):
+		n, ok := value.(json.Number)
+		if !ok {
+			return append(errs, fmt.Errorf("%s: expected number", path))
+		}
+		errs = append(errs, checkRange(path, s, n)...)
+	case s.Type.Contains("boolean"):
+		if _, ok := value.(bool); !ok {
+			return append(errs, fmt.Errorf("%s: expected boolean", path))
+		}
+	}
+	return errs
+}
+
+func checkRange(path string, s *spec.Schema, n json.Number) []error {
+	f, err := n.Float64()
+	if err != nil {
+		return []error{fmt.Errorf("%s: expected number", path)}
+	}
+	if s.Minimum != nil && f < *s.Minimum {
+		return []error{fmt.Errorf("%s: %s is below the minimum %v", path, n, *s.Minimum)}
+	}
+	if s.Maximum != nil && f > *s.Maximum {
+		return []error{fmt.Errorf("%s: %s is above the maximum %v", path, n, *s.Maximum)}
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, n.String()) {
+		return []error{fmt.Errorf("%s: %s is not one of %v", path, n, s.Enum)}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+var (
+	// ErrUnavailable means the gateway could not be reached or kept failing.
+	// The operation did not go through and can be retried later.
+	ErrUnavailable = errors.New("payment gateway unavailable")
+	// ErrDeclined is the gateway's final answer; retrying will not help.
+	ErrDeclined = errors.New("payment declined")
+)
+
+// ChargeRequest asks the gateway to collect Amount. Requests with the same
+// IdempotencyKey are charged at most once, which makes them safe to retry.
+// Reference is our own reference, shown on the gateway's side.
+type ChargeRequest struct {
+	IdempotencyKey string
+	Reference      string
+	Method         string
+	Amount         float64
+}
+
+// RefundRequest asks the gateway to return Amount of an earlier charge.
+type RefundRequest struct {
+	IdempotencyKey string
+	ChargeID       string
+	Amount         float64
+}
+
+// Gateway collects and returns customer payments.
+type Gateway interface {
+	// Charge collects the amount and returns the gateway's charge ID.
+	Charge(ctx context.Context, req ChargeRequest) (string, error)
+	// Refund returns money of a charge and returns the gateway's refund ID.
+	Refund(ctx context.Context, req RefundRequest) (string, error)
+}
+
+// SandboxGateway accepts every payment after a short delay. Used until a
+// real payment gateway is configured.
+type SandboxGateway struct {
+	delay time.Duration
+}
+
+func NewSandboxGateway() *SandboxGateway {
+	return &SandboxGateway{delay: 500 * time.Millisecond}
+}
+
+func (g *SandboxGateway) wait(ctx context.Context) error {
+	select {
+	case <-time.After(g.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (g *SandboxGateway) Charge(ctx context.Context, req ChargeRequest) (string, error) {
+	if err := g.wait(ctx); err != nil {
+		return "", err
+	}
+	logger.Info("payment sandbox: charge accepted",
+		logger.String("reference", req.Reference),
+		logger.Float64("amount", req.Amount),
+	)
+	return fmt.Sprintf("PAY-%s-%d", req.Reference, time.Now().UnixMilli()), nil
+}
+
+func (g *SandboxGateway) Refund(ctx context.Context, req RefundRequest) (string, error) {
+	if err := g.wait(ctx); err != nil {
+		return "", err
+	}
+	logger.Info("payment sandbox: refund accepted",
+		logger.String("charge_id", req.ChargeID),
+		logger.Float64("amount", req.Amount),
+	)
+	return fmt.Sprintf("RFD-%s-%d", req.ChargeID, time.Now().UnixMilli()), nil
+}
@@ -0,0 +1,183 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"ticres/pkg/logger"
+)
+
+// ResilienceConfig bounds how long and how often gateway calls are tried.
+// Each attempt gets CallTimeout. Requests with an idempotency key are tried
+// up to MaxRetries more times, waiting RetryBackoff, doubled each time, in
+// between. After FailureThreshold consecutive failed calls the circuit opens
+// and calls fail fast with ErrUnavailable for OpenDuration, after which one
+// trial call decides whether it closes again.
+type ResilienceConfig struct {
+	CallTimeout      time.Duration
+	MaxRetries       int
+	RetryBackoff     time.Duration
+	FailureThreshold int
+	OpenDuration     time.Duration
+}
+
+// Circuit states reported by CircuitState.
+const (
+	CircuitClosed = "closed"
+	// CircuitOpen fails calls fast until the open period is over.
+	CircuitOpen = "open"
+	// CircuitHalfOpen lets a trial call decide whether the circuit closes.
+	CircuitHalfOpen = "half_open"
+)
+
+// ResilientGateway wraps a Gateway with per-call timeouts, bounded retries
+// and a circuit breaker. Every failure other than ErrDeclined is reported
+// as ErrUnavailable.
+type ResilientGateway struct {
+	next Gateway
+	cfg  ResilienceConfig
+
+	mu          sync.Mutex
+	failures    int
+	openUntil   time.Time
+	probing     bool
+	lastSuccess time.Time
+}
+
+func NewResilientGateway(next Gateway, cfg ResilienceConfig) *ResilientGateway {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 1
+	}
+	return &ResilientGateway{next: next, cfg: cfg}
+}
+
+func (g *ResilientGateway) Charge(ctx context.Context, req ChargeRequest) (string, error) {
+	return g.call(ctx, "charge", req.IdempotencyKey != "", func(ctx context.Context) (string, error) {
+		return g.next.Charge(ctx, req)
+	})
+}
+
+func (g *ResilientGateway) Refund(ctx context.Context, req RefundRequest) (string, error) {
+	return g.call(ctx, "refund", req.IdempotencyKey != "", func(ctx context.Context) (string, error) {
+		return g.next.Refund(ctx, req)
+	})
+}
+
+func (g *ResilientGateway) call(ctx context.Context, op string, idempotent bool, fn func(context.Context) (string, error)) (string, error) {
+	attempts := 1
+	if idempotent {
+		attempts += g.cfg.MaxRetries
+	}
+
+	backoff := g.cfg.RetryBackoff
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if !g.allow() {
+			logger.Warn("payment: circuit open, gateway call skipped", logger.String("operation", op))
+			return "", fmt.Errorf("%w: circuit open", ErrUnavailable)
+		}
+
+		id, err := g.attempt(ctx, fn)
+		if err == nil || errors.Is(err, ErrDeclined) {
+			g.record(true)
+			return id, err
+		}
+		g.record(false)
+		lastErr = err
+
+		// The caller gave up; more attempts would outlive its deadline
+		if ctx.Err() != nil {
+			break
+		}
+		logger.Warn("payment: gateway call failed",
+			logger.String("operation", op),
+			logger.Int("attempt", attempt),
+			logger.Err(err),
+		)
+		if attempt < attempts {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return "", fmt.Errorf("%w: %v", ErrUnavailable, lastErr)
+			}
+			backoff *= 2
+		}
+	}
+
+	return "", fmt.Errorf("%w: %v", ErrUnavailable, lastErr)
+}
+
+func (g *ResilientGateway) attempt(ctx context.Context, fn func(context.Context) (string, error)) (string, error) {
+	if g.cfg.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.cfg.CallTimeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
+
+// CircuitState reports the circuit breaker's state, for health reporting.
+func (g *ResilientGateway) CircuitState() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch {
+	case g.failures < g.cfg.FailureThreshold:
+		return CircuitClosed
+	case time.Now().Before(g.openUntil):
+		return CircuitOpen
+	default:
+		return CircuitHalfOpen
+	}
+}
+
+// LastSuccess reports when a gateway call last went through, or the zero
+// time when none has. Unlike a closed circuit, which is also the state of an
+// instance that has not called the gateway yet, it shows the gateway works.
+func (g *ResilientGateway) LastSuccess() time.Time {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.lastSuccess
+}
+
+// allow reports whether a call may go out. Once the open period is over a
+// single trial call is let through while the others keep failing fast.
+func (g *ResilientGateway) allow() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.failures < g.cfg.FailureThreshold {
+		return true
+	}
+	if time.Now().Before(g.openUntil) || g.probing {
+		return false
+	}
+	g.probing = true
+	return true
+}
+
+func (g *ResilientGateway) record(ok bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.probing = false
+	if ok {
+		if g.failures >= g.cfg.FailureThreshold {
+			logger.Info("payment: gateway recovered, circuit closed")
+		}
+		g.failures = 0
+		g.lastSuccess = time.Now()
+		return
+	}
+
+	g.failures++
+	if g.failures >= g.cfg.FailureThreshold {
+		if g.failures == g.cfg.FailureThreshold {
+			logger.Error("payment: gateway failing, circuit opened", logger.Int("failures", g.failures))
+		}
+		g.openUntil = time.Now().Add(g.cfg.OpenDuration)
+	}
+}
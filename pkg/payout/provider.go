@@ -0,0 +1,46 @@
+package payout
+
+import (
+	"context"
+
+	"ticres/pkg/logger"
+)
+
+// Account identifies a destination bank account at the payout provider.
+type Account struct {
+	BankName      string
+	AccountNumber string
+	AccountHolder string
+}
+
+// Provider moves money to organizer bank accounts.
+type Provider interface {
+	// SendMicroDeposits transfers the given small amounts to the account. The
+	// organizer confirms them later to prove they own the account.
+	SendMicroDeposits(ctx context.Context, account Account, amounts []float64) error
+	// PennyDrop transfers a nominal amount and returns the account holder name
+	// reported by the receiving bank.
+	PennyDrop(ctx context.Context, account Account) (string, error)
+}
+
+// SandboxProvider pretends every transfer succeeds. Used until a real
+// disbursement gateway is configured.
+type SandboxProvider struct{}
+
+func NewSandboxProvider() *SandboxProvider {
+	return &SandboxProvider{}
+}
+
+func (p *SandboxProvider) SendMicroDeposits(ctx context.Context, account Account, amounts []float64) error {
+	logger.Info("payout sandbox: micro-deposits sent",
+		logger.String("bank", account.BankName),
+		logger.Any("amounts", amounts),
+	)
+	return nil
+}
+
+// PennyDrop echoes the registered holder name, so sandbox verifications always match.
+func (p *SandboxProvider) PennyDrop(ctx context.Context, account Account) (string, error) {
+	logger.Info("payout sandbox: penny drop sent", logger.String("bank", account.BankName))
+	return account.AccountHolder, nil
+}
@@ -0,0 +1,174 @@
+// Package signing signs and verifies webhook payloads with HMAC-SHA256, for
+// payment gateway callbacks coming in and organizer webhooks going out.
+//
+// A signature travels in one header value such as
+//
+//	t=1767225600,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd
+//
+// where t is the Unix time of signing and each v1 is the hex HMAC of
+// "<t>.<payload>". Binding the timestamp into the MAC lets a receiver
+// reject replays older than its tolerance.
+//
+// Secrets rotate without downtime. A Signer holds the new secret first and
+// the old ones after it and signs with all of them, so receivers that have
+// not switched yet still find a signature they can check. A Verifier
+// accepts a payload signed with any of its secrets.
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTolerance is how far a signature's timestamp may be from the
+// receiver's clock.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrNoSecret        = errors.New("no secret configured")
+	ErrMissingHeader   = errors.New("missing signature header")
+	ErrMalformedHeader = errors.New("malformed signature header")
+	ErrExpired         = errors.New("timestamp outside tolerance")
+	ErrMismatch        = errors.New("no matching signature")
+)
+
+// ParseSecrets splits a comma-separated secret list, such as an environment
+// variable holding "new-secret,old-secret", dropping empty entries.
+func ParseSecrets(list string) [][]byte {
+	var secrets [][]byte
+	for _, s := range strings.Split(list, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			secrets = append(secrets, []byte(s))
+		}
+	}
+	return secrets
+}
+
+// Signer signs outgoing payloads with every one of its secrets.
+type Signer struct {
+	secrets [][]byte
+	now     func() time.Time
+}
+
+// NewSigner returns a Signer for secrets, newest first.
+func NewSigner(secrets ...[]byte) (*Signer, error) {
+	if err := checkSecrets(secrets); err != nil {
+		return nil, err
+	}
+	return &Signer{secrets: secrets, now: time.Now}, nil
+}
+
+// Sign returns the signature header value for payload, stamped now.
+func (s *Signer) Sign(payload []byte) string {
+	return s.SignAt(s.now(), payload)
+}
+
+// SignAt returns the signature header value for payload stamped at t.
+func (s *Signer) SignAt(t time.Time, payload []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	var b strings.Builder
+	b.WriteString("t=" + ts)
+	for _, secret := range s.secrets {
+		b.WriteString(",v1=" + hex.EncodeToString(mac(secret, ts, payload)))
+	}
+	return b.String()
+}
+
+// Verifier checks incoming payloads against any of its secrets.
+type Verifier struct {
+	secrets   [][]byte
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+// NewVerifier returns a Verifier accepting signatures made with any of
+// secrets no further than tolerance from now. A tolerance of zero or less
+// uses DefaultTolerance.
+func NewVerifier(tolerance time.Duration, secrets ...[]byte) (*Verifier, error) {
+	if err := checkSecrets(secrets); err != nil {
+		return nil, err
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	return &Verifier{secrets: secrets, tolerance: tolerance, now: time.Now}, nil
+}
+
+// Verify checks header, the value made by Sign, against payload, the raw
+// request body exactly as received. It returns the signing time.
+func (v *Verifier) Verify(header string, payload []byte) (time.Time, error) {
+	if strings.TrimSpace(header) == "" {
+		return time.Time{}, ErrMissingHeader
+	}
+
+	var ts string
+	var sigs [][]byte
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return time.Time{}, ErrMalformedHeader
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			sig, err := hex.DecodeString(value)
+			if err != nil {
+				return time.Time{}, ErrMalformedHeader
+			}
+			sigs = append(sigs, sig)
+		}
+		// Other schemes are skipped so new ones can be added alongside v1
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(sigs) == 0 {
+		return time.Time{}, ErrMalformedHeader
+	}
+
+	signedAt := time.Unix(unix, 0)
+	if skew := v.now().Sub(signedAt); skew > v.tolerance || skew < -v.tolerance {
+		return signedAt, fmt.Errorf("%w: signed at %s", ErrExpired, signedAt.UTC().Format(time.RFC3339))
+	}
+
+	for _, secret := range v.secrets {
+		want := mac(secret, ts, payload)
+		for _, sig := range sigs {
+			if hmac.Equal(sig, want) {
+				return signedAt, nil
+			}
+		}
+	}
+	return signedAt, ErrMismatch
+}
+
+// Equal compares two secrets or signatures in constant time, so the time
+// taken does not reveal how much of a guess was right.
+func Equal(a, b string) bool {
+	return hmac.Equal([]byte(a), []byte(b))
+}
+
+func mac(secret []byte, ts string, payload []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+func checkSecrets(secrets [][]byte) error {
+	if len(secrets) == 0 {
+		return ErrNoSecret
+	}
+	for i, s := range secrets {
+		if len(s) == 0 {
+			return fmt.Errorf("%w: secret %d is empty", ErrNoSecret, i+1)
+		}
+	}
+	return nil
+}
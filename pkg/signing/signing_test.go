@@ -0,0 +1,148 @@
+package signing
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var signedAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newPair returns a Signer and a Verifier stopped at signedAt.
+func newPair(t *testing.T, signWith, verifyWith [][]byte) (*Signer, *Verifier) {
+	t.Helper()
+	s, err := NewSigner(signWith...)
+	require.NoError(t, err)
+	v, err := NewVerifier(DefaultTolerance, verifyWith...)
+	require.NoError(t, err)
+	s.now = func() time.Time { return signedAt }
+	v.now = func() time.Time { return signedAt }
+	return s, v
+}
+
+func TestSignVerify(t *testing.T) {
+	secret := [][]byte{[]byte("whsec_current")}
+	s, v := newPair(t, secret, secret)
+	payload := []byte(`{"event":"booking.paid","booking_id":42}`)
+
+	header := s.Sign(payload)
+	assert.True(t, strings.HasPrefix(header, "t=1767225600,v1="))
+
+	at, err := v.Verify(header, payload)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(signedAt))
+}
+
+func TestVerify_Rotation(t *testing.T) {
+	current, previous := []byte("whsec_current"), []byte("whsec_previous")
+	payload := []byte(`{}`)
+
+	tests := []struct {
+		name       string
+		signWith   [][]byte
+		verifyWith [][]byte
+		wantErr    error
+	}{
+		{name: "Receiver Not Switched Yet", signWith: [][]byte{current, previous}, verifyWith: [][]byte{previous}},
+		{name: "Receiver Switched", signWith: [][]byte{current, previous}, verifyWith: [][]byte{current}},
+		{name: "Sender Not Switched Yet", signWith: [][]byte{previous}, verifyWith: [][]byte{current, previous}},
+		{name: "Unknown Secret", signWith: [][]byte{[]byte("whsec_other")}, verifyWith: [][]byte{current, previous}, wantErr: ErrMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, v := newPair(t, tt.signWith, tt.verifyWith)
+			_, err := v.Verify(s.Sign(payload), payload)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	secret := [][]byte{[]byte("whsec_current")}
+	s, v := newPair(t, secret, secret)
+	payload := []byte(`{"amount":100000}`)
+	header := s.Sign(payload)
+
+	_, err := v.Verify(header, []byte(`{"amount":1}`))
+	assert.ErrorIs(t, err, ErrMismatch, "changed payload")
+
+	// A restamped header must not reuse the old MAC
+	restamped := strings.Replace(header, "t=1767225600", "t=1767225660", 1)
+	_, err = v.Verify(restamped, payload)
+	assert.ErrorIs(t, err, ErrMismatch, "changed timestamp")
+
+	last := header[len(header)-1:]
+	flipped := "0"
+	if last == "0" {
+		flipped = "1"
+	}
+	_, err = v.Verify(header[:len(header)-1]+flipped, payload)
+	assert.ErrorIs(t, err, ErrMismatch, "changed signature")
+}
+
+func TestVerify_Expiry(t *testing.T) {
+	secret := [][]byte{[]byte("whsec_current")}
+	payload := []byte(`{}`)
+
+	tests := []struct {
+		name    string
+		age     time.Duration
+		wantErr error
+	}{
+		{name: "Fresh", age: 0},
+		{name: "At Tolerance", age: DefaultTolerance},
+		{name: "Past Tolerance", age: DefaultTolerance + time.Second, wantErr: ErrExpired},
+		{name: "From The Future", age: -DefaultTolerance - time.Second, wantErr: ErrExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, v := newPair(t, secret, secret)
+			v.now = func() time.Time { return signedAt.Add(tt.age) }
+
+			at, err := v.Verify(s.Sign(payload), payload)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.True(t, at.Equal(signedAt))
+		})
+	}
+}
+
+func TestVerify_Malformed(t *testing.T) {
+	secret := [][]byte{[]byte("whsec_current")}
+	_, v := newPair(t, secret, secret)
+
+	tests := []struct {
+		name    string
+		header  string
+		wantErr error
+	}{
+		{name: "Empty", header: " ", wantErr: ErrMissingHeader},
+		{name: "Not Key Value", header: "garbage", wantErr: ErrMalformedHeader},
+		{name: "No Signature", header: "t=1767225600", wantErr: ErrMalformedHeader},
+		{name: "No Timestamp", header: "v1=00", wantErr: ErrMalformedHeader},
+		{name: "Signature Not Hex", header: "t=1767225600,v1=zz", wantErr: ErrMalformedHeader},
+		{name: "Other Schemes Skipped", header: "t=1767225600,v0=abc,v1=00", wantErr: ErrMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.Verify(tt.header, []byte(`{}`))
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSecrets(t *testing.T) {
+	_, err := NewSigner()
+	assert.ErrorIs(t, err, ErrNoSecret)
+
+	_, err = NewVerifier(0, []byte("whsec_current"), []byte{})
+	assert.ErrorIs(t, err, ErrNoSecret)
+
+	assert.Equal(t, [][]byte{[]byte("new"), []byte("old")}, ParseSecrets(" new, ,old,"))
+	assert.Empty(t, ParseSecrets(""))
+}
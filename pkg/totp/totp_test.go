@@ -0,0 +1,103 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"ticres/pkg/totp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 seed of RFC 6238 appendix B, base32 encoded.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	// Appendix B lists eight digit codes; six digit codes are their last six
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			code, err := totp.Code(rfcSecret, totp.Step(time.Unix(tt.unix, 0)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want[2:], code)
+
+			step, ok := totp.Validate(rfcSecret, tt.want[2:], time.Unix(tt.unix, 0))
+			assert.True(t, ok)
+			assert.Equal(t, totp.Step(time.Unix(tt.unix, 0)), step)
+		})
+	}
+}
+
+func TestValidate_Window(t *testing.T) {
+	// 1111111110 is the first second of its step
+	start := time.Unix(1111111110, 0)
+	issued := totp.Step(start)
+	code, err := totp.Code(rfcSecret, issued)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		at     time.Time
+		wantOK bool
+	}{
+		{name: "Same Step", at: start.Add(10 * time.Second), wantOK: true},
+		{name: "One Step Early", at: start.Add(-totp.Period), wantOK: true},
+		{name: "Last Second Of Next Step", at: start.Add(2*totp.Period - time.Second), wantOK: true},
+		{name: "Two Steps Late", at: start.Add(2 * totp.Period), wantOK: false},
+		{name: "Two Steps Early", at: start.Add(-totp.Period - time.Second), wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := totp.Validate(rfcSecret, code, tt.at)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				// The step the code was issued for, not the current one
+				assert.Equal(t, issued, step)
+			}
+		})
+	}
+}
+
+func TestValidate_Malformed(t *testing.T) {
+	at := time.Unix(59, 0)
+
+	_, ok := totp.Validate(rfcSecret, " 287082 ", at)
+	assert.True(t, ok, "surrounding spaces are ignored")
+
+	_, ok = totp.Validate(strings.ToLower(rfcSecret), "287082", at)
+	assert.True(t, ok, "lower case secrets decode")
+
+	_, ok = totp.Validate(rfcSecret, "94287082", at)
+	assert.False(t, ok, "eight digit codes are refused")
+
+	_, ok = totp.Validate("not base32!", "287082", at)
+	assert.False(t, ok)
+}
+
+func TestNewSecret(t *testing.T) {
+	secret, err := totp.NewSecret()
+	require.NoError(t, err)
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	require.NoError(t, err)
+	assert.Len(t, key, 20)
+
+	uri := totp.ProvisioningURI("TicRes", "user@example.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/TicRes:user@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+}
@@ -0,0 +1,143 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	bigQueryAPI = "https://bigquery.googleapis.com/bigquery/v2"
+	// metadataTokenURL hands out the access token of the service account
+	// the instance runs as (GCE, GKE workload identity, Cloud Run).
+	metadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// BigQuerySink streams rows into tables named after the datasets with the
+// tabledata.insertAll API. Each row's insertId is a hash of its content, so
+// a repeated batch is deduplicated by BigQuery.
+type BigQuerySink struct {
+	project string
+	dataset string
+	// staticToken is used as is when set
+	staticToken string
+	client      *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func NewBigQuerySink(project, dataset, accessToken string) *BigQuerySink {
+	return &BigQuerySink{
+		project:     project,
+		dataset:     dataset,
+		staticToken: accessToken,
+		client:      &http.Client{Timeout: time.Minute},
+	}
+}
+
+type bigQueryRow struct {
+	InsertID string          `json:"insertId"`
+	JSON     json.RawMessage `json:"json"`
+}
+
+func (s *BigQuerySink) Write(ctx context.Context, dataset string, rows []json.RawMessage) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	payload := struct {
+		IgnoreUnknownValues bool          `json:"ignoreUnknownValues"`
+		Rows                []bigQueryRow `json:"rows"`
+	}{IgnoreUnknownValues: true, Rows: make([]bigQueryRow, len(rows))}
+	for i, row := range rows {
+		sum := sha256.Sum256(row)
+		payload.Rows[i] = bigQueryRow{InsertID: hex.EncodeToString(sum[:16]), JSON: row}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s/datasets/%s/tables/%s/insertAll", bigQueryAPI, s.project, s.dataset, dataset)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("bigquery insert into %s returned status %d: %s", dataset, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	// insertAll answers 200 even when some rows were rejected
+	var result struct {
+		InsertErrors []json.RawMessage `json:"insertErrors"`
+	}
+	if err := json.Unmarshal(respBody, &result); err == nil && len(result.InsertErrors) > 0 {
+		return fmt.Errorf("bigquery rejected %d rows of %s: %s", len(result.InsertErrors), dataset, result.InsertErrors[0])
+	}
+	return nil
+}
+
+// accessToken returns the configured token, or a cached one from the
+// metadata server that is refreshed a minute before it expires.
+func (s *BigQuerySink) accessToken(ctx context.Context) (string, error) {
+	if s.staticToken != "" {
+		return s.staticToken, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Now().Add(time.Minute).Before(s.tokenExpiry) {
+		return s.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataTokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("bigquery: fetch access token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("bigquery: metadata server returned status %d", resp.StatusCode)
+	}
+
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", fmt.Errorf("bigquery: decode access token: %w", err)
+	}
+	s.token = tok.AccessToken
+	s.tokenExpiry = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	return s.token, nil
+}
@@ -0,0 +1,72 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ClickHouseSink inserts rows over the ClickHouse HTTP interface in
+// JSONEachRow format. Tables are named after the datasets and must exist;
+// columns missing from a table are skipped.
+type ClickHouseSink struct {
+	url      string
+	database string
+	username string
+	password string
+	client   *http.Client
+}
+
+func NewClickHouseSink(baseURL, database, username, password string) *ClickHouseSink {
+	return &ClickHouseSink{
+		url:      strings.TrimRight(baseURL, "/"),
+		database: database,
+		username: username,
+		password: password,
+		client:   &http.Client{Timeout: time.Minute},
+	}
+}
+
+func (s *ClickHouseSink) Write(ctx context.Context, dataset string, rows []json.RawMessage) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	for _, row := range rows {
+		body.Write(row)
+		body.WriteByte('\n')
+	}
+
+	params := url.Values{}
+	params.Set("database", s.database)
+	params.Set("query", fmt.Sprintf("INSERT INTO %s FORMAT JSONEachRow", dataset))
+	params.Set("input_format_skip_unknown_fields", "1")
+	params.Set("date_time_input_format", "best_effort")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+"/?"+params.Encode(), &body)
+	if err != nil {
+		return err
+	}
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("clickhouse insert into %s returned status %d: %s", dataset, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
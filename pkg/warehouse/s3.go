@@ -0,0 +1,54 @@
+package warehouse
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"ticres/pkg/storage"
+)
+
+// S3Sink writes each batch as a gzipped JSON Lines file under
+// <prefix>/<dataset>/dt=YYYY-MM-DD/, a layout Athena, BigQuery external
+// tables and Spark read as a date-partitioned table.
+type S3Sink struct {
+	store  *storage.S3Storage
+	prefix string
+}
+
+func NewS3Sink(endpoint, region, bucket, prefix, accessKeyID, secretKey, sessionToken string) *S3Sink {
+	return &S3Sink{
+		store:  storage.NewS3Storage(endpoint, region, bucket, accessKeyID, secretKey, sessionToken, ""),
+		prefix: prefix,
+	}
+}
+
+func (s *S3Sink) Write(ctx context.Context, dataset string, rows []json.RawMessage) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	for _, row := range rows {
+		zw.Write(row)
+		zw.Write([]byte{'\n'})
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	key := path.Join(s.prefix, dataset, "dt="+now.Format("2006-01-02"),
+		fmt.Sprintf("%s-%s.jsonl.gz", now.Format("20060102T150405Z"), hex.EncodeToString(suffix)))
+
+	return s.store.Put(ctx, key, &buf, "application/gzip")
+}
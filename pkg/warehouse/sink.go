@@ -0,0 +1,94 @@
+// Package warehouse ships exported rows to BI destinations: ClickHouse,
+// BigQuery, or an S3 bucket as gzipped JSON Lines files.
+package warehouse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Sink appends rows, each a JSON object, to a dataset at a destination.
+// Writes must be safe to repeat: a batch is sent again when the export stops
+// before its watermark is saved, so destinations should deduplicate on the
+// row key (ReplacingMergeTree in ClickHouse, insertId in BigQuery).
+type Sink interface {
+	Write(ctx context.Context, dataset string, rows []json.RawMessage) error
+}
+
+// Destination configures one export target. Datasets limits what is
+// shipped to it; empty means every dataset. Only the fields of its Type
+// are used.
+type Destination struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Datasets []string `json:"datasets"`
+
+	// clickhouse: HTTP interface URL and target database
+	URL      string `json:"url"`
+	Database string `json:"database"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+
+	// bigquery: tables are named after the datasets inside BQDataset.
+	// Without AccessToken a token is requested from the GCE metadata server.
+	Project     string `json:"project"`
+	BQDataset   string `json:"bq_dataset"`
+	AccessToken string `json:"access_token"`
+
+	// s3: files go under Prefix/<dataset>/dt=YYYY-MM-DD/
+	Endpoint        string `json:"endpoint"`
+	Region          string `json:"region"`
+	Bucket          string `json:"bucket"`
+	Prefix          string `json:"prefix"`
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	SessionToken    string `json:"session_token"`
+}
+
+// ParseDestinations reads a JSON array of destinations. An empty string
+// means exporting is disabled.
+func ParseDestinations(raw string) ([]Destination, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var dests []Destination
+	if err := json.Unmarshal([]byte(raw), &dests); err != nil {
+		return nil, fmt.Errorf("invalid warehouse destinations: %w", err)
+	}
+	seen := make(map[string]bool, len(dests))
+	for _, d := range dests {
+		if d.Name == "" {
+			return nil, fmt.Errorf("invalid warehouse destination: name is required")
+		}
+		if seen[d.Name] {
+			return nil, fmt.Errorf("invalid warehouse destination %q: duplicate name", d.Name)
+		}
+		seen[d.Name] = true
+	}
+	return dests, nil
+}
+
+// NewSink builds the sink for a destination.
+func NewSink(d Destination) (Sink, error) {
+	switch d.Type {
+	case "clickhouse":
+		if d.URL == "" || d.Database == "" {
+			return nil, fmt.Errorf("warehouse destination %q: url and database are required", d.Name)
+		}
+		return NewClickHouseSink(d.URL, d.Database, d.Username, d.Password), nil
+	case "bigquery":
+		if d.Project == "" || d.BQDataset == "" {
+			return nil, fmt.Errorf("warehouse destination %q: project and bq_dataset are required", d.Name)
+		}
+		return NewBigQuerySink(d.Project, d.BQDataset, d.AccessToken), nil
+	case "s3":
+		if d.Bucket == "" {
+			return nil, fmt.Errorf("warehouse destination %q: bucket is required", d.Name)
+		}
+		return NewS3Sink(d.Endpoint, d.Region, d.Bucket, d.Prefix, d.AccessKeyID, d.SecretAccessKey, d.SessionToken), nil
+	default:
+		return nil, fmt.Errorf("warehouse destination %q: unknown type %q", d.Name, d.Type)
+	}
+}
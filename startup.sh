@@ -11,9 +11,7 @@ echo "CACHE_PORT: ${CACHE_PORT}"
 echo "PORT: ${PORT}"
 
 echo "Running database migrations..."
-migrate -path /app/db/migrations \
-  -database "postgres://${DB_USER}:${DB_PASSWORD}@${DB_HOST}:${DB_PORT}/${DB_NAME}?sslmode=${SSL_MODE}" \
-  -verbose up 2>&1 || echo "Migration failed or already up-to-date (exit code: $?)"
+./migrate up 2>&1 || echo "Migration failed (exit code: $?)"
 
 if [ "$RUN_SEED" = "true" ]; then
   echo "Running database seed..."